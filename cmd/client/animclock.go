@@ -0,0 +1,109 @@
+// cmd/client/animclock.go
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// animFunc fait avancer une animation au temps écoulé depuis son début.
+// Elle renvoie false une fois terminée.
+type animFunc func(elapsed time.Duration) bool
+
+// animClock est l'horloge commune des animations du client (pions, dé,
+// compte à rebours, cérémonies). Un seul ticker les fait toutes avancer;
+// il s'arrête dès qu'aucune animation n'est en cours.
+type animClock struct {
+	mu      sync.Mutex
+	anims   map[int]*clockAnim
+	next    int
+	frame   time.Duration
+	running bool
+}
+
+// clockAnim est une animation inscrite sur l'horloge
+type clockAnim struct {
+	start time.Time
+	step  animFunc
+}
+
+// start inscrit une animation, rafraîchie toutes les frame, et démarre
+// l'horloge si elle dormait. stop la retire avant sa fin.
+func (a *animClock) start(frame time.Duration, step animFunc) (stop func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.anims == nil {
+		a.anims = make(map[int]*clockAnim)
+	}
+	id := a.next
+	a.next++
+	a.anims[id] = &clockAnim{start: time.Now(), step: step}
+	a.frame = frame
+	if !a.running {
+		a.running = true
+		go a.run()
+	}
+
+	return func() {
+		a.mu.Lock()
+		delete(a.anims, id)
+		a.mu.Unlock()
+	}
+}
+
+// run fait avancer les animations à chaque image. Chacune reçoit le temps
+// réellement écoulé: une image en retard ne ralentit pas l'animation.
+func (a *animClock) run() {
+	a.mu.Lock()
+	frame := a.frame
+	a.mu.Unlock()
+
+	ticker := time.NewTicker(frame)
+	defer ticker.Stop()
+
+	for now := time.Now(); ; now = <-ticker.C {
+		a.mu.Lock()
+		if len(a.anims) == 0 {
+			a.running = false
+			a.mu.Unlock()
+			return
+		}
+		if a.frame != frame {
+			frame = a.frame
+			ticker.Reset(frame)
+		}
+		current := make(map[int]*clockAnim, len(a.anims))
+		for id, anim := range a.anims {
+			current[id] = anim
+		}
+		a.mu.Unlock()
+
+		// Hors verrou: une animation peut en lancer ou en arrêter d'autres
+		for id, anim := range current {
+			if !anim.step(now.Sub(anim.start)) {
+				a.mu.Lock()
+				delete(a.anims, id)
+				a.mu.Unlock()
+			}
+		}
+	}
+}
+
+// animate inscrit une animation sur l'horloge du client, au rythme de la
+// qualité de rendu choisie
+func (c *Client) animate(step animFunc) (stop func()) {
+	return c.anims.start(c.renderQuality().frame, step)
+}
+
+// animProgress retourne l'avancement d'une animation de la durée donnée,
+// entre 0 et 1
+func animProgress(elapsed, duration time.Duration) float64 {
+	if duration <= 0 || elapsed >= duration {
+		return 1
+	}
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(elapsed) / float64(duration)
+}
@@ -0,0 +1,62 @@
+// cmd/client/animclock_test.go
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// L'horloge fait avancer les animations selon le temps écoulé et se met en
+// sommeil quand il n'en reste plus
+func TestAnimClockRunsUntilIdle(t *testing.T) {
+	var clock animClock
+	done := make(chan time.Duration, 1)
+	clock.start(time.Millisecond, func(elapsed time.Duration) bool {
+		if elapsed < 20*time.Millisecond {
+			return true
+		}
+		done <- elapsed
+		return false
+	})
+	stop := clock.start(time.Millisecond, func(time.Duration) bool { return true })
+	stop()
+
+	select {
+	case elapsed := <-done:
+		if elapsed < 20*time.Millisecond {
+			t.Errorf("animation ended after %v", elapsed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("animation never finished")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		clock.mu.Lock()
+		running := clock.running
+		clock.mu.Unlock()
+		if !running {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("clock still running without animations")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAnimProgressIsClamped(t *testing.T) {
+	for _, tc := range []struct {
+		elapsed, duration time.Duration
+		want              float64
+	}{
+		{-time.Second, time.Second, 0},
+		{500 * time.Millisecond, time.Second, 0.5},
+		{2 * time.Second, time.Second, 1},
+		{time.Second, 0, 1},
+	} {
+		if got := animProgress(tc.elapsed, tc.duration); got != tc.want {
+			t.Errorf("animProgress(%v, %v) = %v, want %v", tc.elapsed, tc.duration, got, tc.want)
+		}
+	}
+}
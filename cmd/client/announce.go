@@ -0,0 +1,77 @@
+// cmd/client/announce.go
+package main
+
+import (
+	"image/color"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// setContent affiche un écran sous la bannière des annonces du serveur. Il
+// arrête la démonstration du menu principal, ou son attente.
+func (c *Client) setContent(content fyne.CanvasObject) {
+	c.attract.next()
+	c.window.SetContent(container.NewBorder(c.banner, nil, nil, nil, content))
+}
+
+// handleAnnouncement affiche ou retire la bannière d'annonce
+func (c *Client) handleAnnouncement(msg *models.NetworkMessage) {
+	var announcement models.AnnouncementPayload
+	if err := protocol.ExtractPayload(msg.Payload, &announcement); err != nil {
+		log.Printf("❌ Invalid announcement: %v", err)
+		return
+	}
+
+	fyne.Do(func() {
+		c.showBanner(announcement)
+	})
+
+	// Retirer la bannière à l'expiration, si elle n'a pas été remplacée
+	if announcement.ExpiresAt != nil {
+		time.AfterFunc(time.Until(*announcement.ExpiresAt), func() {
+			fyne.Do(func() {
+				if c.bannerID == announcement.ID {
+					c.banner.RemoveAll()
+				}
+			})
+		})
+	}
+}
+
+// showBanner remplace la bannière courante. Doit tourner sur le fil de Fyne.
+func (c *Client) showBanner(announcement models.AnnouncementPayload) {
+	c.bannerID = announcement.ID
+	c.banner.RemoveAll()
+	if announcement.Text == "" {
+		return
+	}
+
+	icon, background := "📢", color.NRGBA{33, 150, 243, 255}
+	switch announcement.Level {
+	case constants.AnnounceMaintenance:
+		icon, background = "🛠", color.NRGBA{230, 126, 34, 255}
+	case constants.AnnounceEvent:
+		icon, background = "🎉", color.NRGBA{142, 68, 173, 255}
+	}
+
+	text := widget.NewLabel(icon + " " + announcement.Text)
+	text.Wrapping = fyne.TextWrapWord
+	dismiss := widget.NewButton("✕", func() {
+		c.banner.RemoveAll()
+	})
+	dismiss.Importance = widget.LowImportance
+
+	c.banner.Add(container.NewStack(
+		canvas.NewRectangle(background),
+		container.NewBorder(nil, nil, nil, dismiss, text),
+	))
+}
@@ -0,0 +1,149 @@
+// cmd/client/attract.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/demo"
+)
+
+// Mode démonstration: après un moment sans quitter le menu principal, des
+// IA jouent en accéléré avec les règles communes. Chaque partie vérifie au
+// passage la cohérence des règles (voir internal/shared/demo).
+const (
+	attractIdle  = 45 * time.Second       // Inactivité sur le menu avant la démonstration
+	attractStep  = 150 * time.Millisecond // Entre deux lancers
+	attractPause = 4 * time.Second        // Entre deux parties
+)
+
+// attractSeats sont les couleurs des IA de la démonstration
+var attractSeats = []constants.PlayerColor{constants.ColorRed, constants.ColorGreen, constants.ColorYellow, constants.ColorBlue}
+
+// attractState suit le mode démonstration. La génération change à chaque
+// écran affiché: le minuteur et la partie d'une génération dépassée
+// s'arrêtent d'eux-mêmes.
+type attractState struct {
+	mu         sync.Mutex
+	generation int
+	timer      *time.Timer
+	games      int // Parties terminées depuis le lancement du client
+	failures   int // Parties arrêtées sur un état impossible
+}
+
+// next ouvre une nouvelle génération et arrête le minuteur en cours
+func (a *attractState) next() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.generation++
+	if a.timer != nil {
+		a.timer.Stop()
+		a.timer = nil
+	}
+	return a.generation
+}
+
+// current indique que la génération est toujours celle de l'écran affiché
+func (a *attractState) current(generation int) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.generation == generation
+}
+
+// scheduleAttract lance la démonstration si le menu principal reste affiché
+// attractIdle. Appelé après l'affichage du menu.
+func (c *Client) scheduleAttract() {
+	generation := c.attract.next()
+	timer := time.AfterFunc(attractIdle, func() {
+		fyne.Do(func() {
+			if c.attract.current(generation) {
+				c.showAttract()
+			}
+		})
+	})
+
+	c.attract.mu.Lock()
+	c.attract.timer = timer
+	c.attract.mu.Unlock()
+}
+
+// showAttract affiche la démonstration. N'importe quel bouton ramène au menu.
+func (c *Client) showAttract() {
+	board := canvas.NewImageFromImage(renderReplay(demo.New(0, attractSeats...).Game, 0, false))
+	board.FillMode = canvas.ImageFillContain
+	board.ScaleMode = c.renderQuality().scaling
+	board.SetMinSize(fyne.NewSize(replayBoardSize, replayBoardSize))
+
+	caption := widget.NewLabelWithStyle("🎬 Demo game", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	playBtn := widget.NewButton("▶ Play", c.showMainMenu)
+	playBtn.Importance = widget.HighImportance
+
+	c.setContent(container.NewBorder(
+		caption,
+		container.NewCenter(playBtn),
+		nil, nil,
+		board,
+	))
+	generation := c.attract.next()
+	go c.runAttract(generation, board, caption)
+}
+
+// runAttract enchaîne les parties de démonstration tant que l'écran reste
+// affiché
+func (c *Client) runAttract(generation int, board *canvas.Image, caption *widget.Label) {
+	step := attractStep
+	if c.lowSpec() {
+		step *= 2
+	}
+
+	for seed := time.Now().UnixNano(); c.attract.current(generation); seed++ {
+		game := demo.New(seed, attractSeats...)
+		var err error
+		for !game.Over() && err == nil {
+			time.Sleep(step)
+			if !c.attract.current(generation) {
+				return
+			}
+			if _, err = game.Step(); err != nil {
+				log.Printf("⚠️ Demo game broke the rules: %v", err)
+			}
+
+			img := renderReplay(game.Game, len(game.TurnHistory), false)
+			fyne.Do(func() {
+				if c.attract.current(generation) {
+					board.Image = img
+					board.Refresh()
+				}
+			})
+		}
+
+		c.attract.mu.Lock()
+		if err != nil {
+			c.attract.failures++
+		} else {
+			c.attract.games++
+		}
+		games, failures := c.attract.games, c.attract.failures
+		c.attract.mu.Unlock()
+
+		text := "🎬 Demo game stopped"
+		if err == nil {
+			text = fmt.Sprintf("🎬 Demo game: %s wins", colorLabel(game.Winner.Color))
+			log.Printf("🎬 Demo game %d finished after %d actions (%d stopped on a rule error)", games, len(game.TurnHistory), failures)
+		}
+		fyne.Do(func() {
+			if c.attract.current(generation) {
+				caption.SetText(text)
+			}
+		})
+		time.Sleep(attractPause)
+	}
+}
@@ -0,0 +1,66 @@
+// cmd/client/auth.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// credentialsMessage prépare la connexion au compte, par création ou mot de passe
+func (c *Client) credentialsMessage(register bool, username, email, password string) *models.NetworkMessage {
+	if register {
+		return &models.NetworkMessage{
+			Type:      constants.MsgRegister,
+			Payload:   models.RegisterPayload{Username: username, Email: email, Password: password, Device: c.deviceID()},
+			Timestamp: time.Now(),
+		}
+	}
+	return &models.NetworkMessage{
+		Type:      constants.MsgLogin,
+		Payload:   models.LoginPayload{Username: username, Password: password, Device: c.deviceID()},
+		Timestamp: time.Now(),
+	}
+}
+
+// tokenLogin reconnecte le compte avec le jeton d'authentification courant
+func (c *Client) tokenLogin() *models.NetworkMessage {
+	return &models.NetworkMessage{
+		Type:      constants.MsgLogin,
+		Payload:   models.LoginPayload{Token: c.authToken, Device: c.deviceID()},
+		Timestamp: time.Now(),
+	}
+}
+
+// handleAuthenticated enregistre le compte vérifié par le serveur
+func (c *Client) handleAuthenticated(msg *models.NetworkMessage) {
+	var payload models.AuthenticatedPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil || payload.User == nil {
+		log.Printf("❌ Invalid authentication: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	firstLogin := c.user == nil || c.user.ID != payload.User.ID
+	c.user = payload.User
+	c.authToken = payload.Token
+	c.mu.Unlock()
+
+	log.Printf("✅ Authenticated as %s (%d)", payload.User.Username, payload.User.ID)
+
+	// Reconnexion par jeton: rester sur l'écran en cours
+	if !firstLogin {
+		return
+	}
+	fyne.Do(func() {
+		dialog.ShowInformation("Connected", fmt.Sprintf("✅ Logged in as %s!", payload.User.Username), c.window)
+		c.showFriendsMenu()
+	})
+}
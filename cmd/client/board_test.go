@@ -0,0 +1,79 @@
+// cmd/client/board_test.go
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+)
+
+// cellAt retrouve la case de la grille sous le centre d'un pion
+func cellAt(playerColor constants.PlayerColor, tokenIndex, position int) [2]int {
+	x, y := positionPixel(playerColor, tokenIndex, position, 1)
+	return [2]int{int(math.Floor(x)), int(math.Floor(y))}
+}
+
+// Chaque position moteur d'une couleur (-1 à 57) doit tomber sur la case
+// dessinée pour elle: base, parcours, couloir puis centre
+func TestEnginePositionsLandOnDrawnCells(t *testing.T) {
+	pathCells := make(map[[2]int]int, PATH_LEN)
+	for i, cell := range boardPath {
+		if other, dup := pathCells[cell]; dup {
+			t.Fatalf("path indices %d and %d share cell %v", other, i, cell)
+		}
+		pathCells[cell] = i
+	}
+
+	for _, playerColor := range constants.BoardQuadrants {
+		zone := homeZones[playerColor]
+		for ti := 0; ti < constants.TokensPerPlayer; ti++ {
+			cell := cellAt(playerColor, ti, -1)
+			if cell[0] < zone[0] || cell[0] >= zone[0]+HOME_SIZE || cell[1] < zone[1] || cell[1] >= zone[1]+HOME_SIZE {
+				t.Errorf("%s pawn %d base cell %v is outside its home zone at %v", playerColor, ti+1, cell, zone)
+			}
+		}
+
+		for position := 0; position < PATH_LEN; position++ {
+			if got := pathCells[cellAt(playerColor, 0, position)]; got != position {
+				t.Errorf("%s position %d is drawn on path index %d", playerColor, position, got)
+			}
+		}
+
+		for offset, want := range homeStretchCells[playerColor] {
+			if got := cellAt(playerColor, 0, PATH_LEN+offset); got != want {
+				t.Errorf("%s position %d lands on %v, the stretch cell is %v", playerColor, PATH_LEN+offset, got, want)
+			}
+		}
+
+		home := cellAt(playerColor, 0, PATH_LEN+HOME_STRETCH_LEN)
+		if home[0] < 6 || home[0] > 8 || home[1] < 6 || home[1] > 8 {
+			t.Errorf("%s home position lands on %v, outside the center", playerColor, home)
+		}
+	}
+}
+
+// Les tables du client doivent reprendre celles du moteur
+func TestClientTablesMatchConstants(t *testing.T) {
+	if len(safeCells) != len(constants.SafePositions) {
+		t.Errorf("client has %d safe cells, engine has %d", len(safeCells), len(constants.SafePositions))
+	}
+	for _, position := range constants.SafePositions {
+		if !safeCells[position] {
+			t.Errorf("engine safe position %d is not safe on the client", position)
+		}
+	}
+
+	for _, playerColor := range constants.BoardQuadrants {
+		start := constants.StartingPositions[playerColor]
+		if start < 0 || start >= PATH_LEN {
+			t.Fatalf("%s starts at %d, outside the path", playerColor, start)
+		}
+		if !safeCells[start] {
+			t.Errorf("%s start cell %d should be safe", playerColor, start)
+		}
+		if len(homeStretchCells[playerColor]) != HOME_STRETCH_LEN {
+			t.Errorf("%s has no home stretch cells", playerColor)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// cmd/client/chat.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/e2e"
+)
+
+// setChatPassword dérive la clé de chat de la salle. Le mot de passe reste
+// sur le poste: seuls les joueurs qui le connaissent lisent le chat.
+func (c *Client) setChatPassword(password, roomID string) {
+	if password == "" {
+		c.mu.Lock()
+		c.chatKey = nil
+		c.mu.Unlock()
+		return
+	}
+
+	key, err := e2e.DeriveRoomKey(password, roomID)
+	if err != nil {
+		log.Printf("❌ Failed to derive chat key: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	c.chatKey = key
+	c.mu.Unlock()
+}
+
+// createChatPanel construit le chat affiché à côté du plateau
+func (c *Client) createChatPanel() fyne.CanvasObject {
+	c.chatMessages = nil
+	c.chatList = widget.NewList(
+		func() int {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return len(c.chatMessages)
+		},
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Wrapping = fyne.TextWrapWord
+			return label
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if id < len(c.chatMessages) {
+				item.(*widget.Label).SetText(c.chatMessages[id])
+			}
+		},
+	)
+
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder("Say something...")
+	send := func() {
+		if entry.Text == "" {
+			return
+		}
+		if err := c.sendChat(entry.Text); err != nil {
+			dialog.ShowError(err, c.window)
+			return
+		}
+		entry.SetText("")
+	}
+	entry.OnSubmitted = func(string) { send() }
+
+	title := "💬 Chat"
+	if c.gameState != nil && c.gameState.Room != nil && c.gameState.Room.E2EChat {
+		title = "🔒 Chat (end-to-end encrypted)"
+	}
+
+	scroll := container.NewVScroll(c.chatList)
+	scroll.SetMinSize(fyne.NewSize(0, 160))
+
+	// En mode enfant, pas de texte libre: seulement des émoticônes
+	input := fyne.CanvasObject(container.NewBorder(nil, nil, nil, widget.NewButton("Send", send), entry))
+	if c.kidMode() {
+		input = c.kidChatBar()
+	}
+
+	return container.NewBorder(
+		widget.NewLabelWithStyle(title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		input,
+		nil, nil,
+		scroll,
+	)
+}
+
+// sendChat envoie un message, chiffré si la salle l'exige
+func (c *Client) sendChat(text string) error {
+	c.mu.Lock()
+	key := c.chatKey
+	encrypted := c.gameState != nil && c.gameState.Room != nil && c.gameState.Room.E2EChat
+	c.mu.Unlock()
+
+	chat := models.ChatPayload{UserID: c.user.ID, Username: c.user.Username}
+	if encrypted {
+		if key == nil {
+			return fmt.Errorf("this room's chat is encrypted: rejoin with the chat password")
+		}
+		sealed, err := e2e.Seal(key, text)
+		if err != nil {
+			return err
+		}
+		chat.Ciphertext = sealed
+	} else {
+		chat.Text = text
+	}
+
+	c.send <- &models.NetworkMessage{
+		Type:      constants.MsgChatMessage,
+		Payload:   chat,
+		Timestamp: time.Now(),
+	}
+	return nil
+}
+
+// handleChatMessage affiche un message reçu, déchiffré si possible
+func (c *Client) handleChatMessage(msg *models.NetworkMessage) {
+	var chat models.ChatPayload
+	if err := protocol.ExtractPayload(msg.Payload, &chat); err != nil {
+		log.Printf("❌ Invalid chat message: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	key := c.chatKey
+	c.mu.Unlock()
+
+	text := chat.Text
+	if chat.Code != "" {
+		// Message du serveur (coach du tutoriel), dans la langue du joueur
+		text = c.translate(chat.Code, chat.Params, chat.Text)
+	} else if chat.Ciphertext != "" {
+		text = "🔒 (encrypted message: wrong or missing chat password)"
+		if key != nil {
+			if plain, err := e2e.Open(key, chat.Ciphertext); err == nil {
+				text = plain
+			}
+		}
+	}
+	if chat.Code == "" && c.kidMode() && !isKidEmote(text) {
+		// Le mode enfant n'affiche que les émoticônes des autres joueurs
+		return
+	}
+
+	line := fmt.Sprintf("%s  %s: %s", chat.SentAt.Local().Format("15:04"), chat.Username, text)
+
+	c.mu.Lock()
+	c.chatMessages = append(c.chatMessages, line)
+	list := c.chatList
+	c.mu.Unlock()
+
+	if list != nil {
+		fyne.Do(func() {
+			list.Refresh()
+			list.ScrollToBottom()
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// cmd/client/confirm.go
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/moves"
+)
+
+// confirmMovesPreference demande une confirmation avant de jouer un pion,
+// contre les clics égarés sur le plateau
+const confirmMovesPreference = "confirm_moves"
+
+// destinationMarkColor entoure la case d'arrivée du pion sélectionné
+var destinationMarkColor = color.NRGBA{255, 255, 0, 220}
+
+// confirmMoves indique si les coups doivent être confirmés (par défaut non)
+func (c *Client) confirmMoves() bool {
+	return c.app.Preferences().Bool(confirmMovesPreference)
+}
+
+// selectedDestination calcule la case d'arrivée du pion sélectionné.
+// ok vaut false sous une variante, où seul le serveur la connaît.
+// L'appelant doit détenir c.mu.
+func (c *Client) selectedDestination() (player *models.Player, to int, ok bool) {
+	if c.selectedToken == nil || c.currentDice == 0 || c.gameState == nil || c.gameState.Room == nil || c.gameState.Room.Rules != "" {
+		return nil, 0, false
+	}
+	player, _ = c.myPlayer()
+	if player == nil {
+		return nil, 0, false
+	}
+	room := c.gameState.Room
+	to, ok = moves.LegalWith(room.HouseRules, room.Players, player, player.Tokens[c.selectedToken.TokenIndex], c.currentDice)
+	return player, to, ok
+}
+
+// describeMove résume le coup du pion sélectionné pour la confirmation.
+// L'appelant doit détenir c.mu.
+func (c *Client) describeMove(tokenIndex int) string {
+	text := fmt.Sprintf("Move %s by %d?", pawnLabel(tokenIndex), c.currentDice)
+	player, to, ok := c.selectedDestination()
+	if !ok {
+		return text
+	}
+
+	switch {
+	case player.Tokens[tokenIndex].Position == moves.Base:
+		text += "\nIt leaves the base for the start square."
+	case to == moves.Home:
+		text += "\nIt reaches home 🏁"
+	case to >= moves.StretchStart:
+		text += fmt.Sprintf("\nIt enters the home stretch, %d squares from home.", moves.Home-to)
+	case moves.IsSafe(to):
+		text += "\nIt lands on a safe square ⭐"
+		if hint := c.safeSquareHint(); hint != "" {
+			text += "\n" + hint
+		}
+	default:
+		text += fmt.Sprintf("\nIt lands %d squares from home.", moves.Progress(player.Color, moves.Home)-moves.Progress(player.Color, to))
+		for _, other := range c.gameState.Room.Players {
+			if other.Color == player.Color {
+				continue
+			}
+			for _, token := range other.Tokens {
+				if token.Position == to {
+					text += fmt.Sprintf("\n💥 It captures %s's %s!", other.Username, pawnLabel(token.ID))
+				}
+			}
+		}
+	}
+	return text
+}
+
+// askMoveConfirmation sélectionne le pion et affiche sous le plateau la
+// barre qui confirme ou annule le coup. L'appelant doit détenir c.mu.
+func (c *Client) askMoveConfirmation(player *models.Player, playerIndex, tokenIndex int) {
+	c.selectedToken = &SelectedToken{PlayerIndex: playerIndex, TokenIndex: tokenIndex}
+	question := c.describeMove(tokenIndex)
+	dice := c.currentDice
+
+	answer := func(ok bool) {
+		c.hideMoveConfirmation()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		// Le tour a pu se terminer pendant que la question était affichée
+		selected := c.selectedToken != nil && c.selectedToken.TokenIndex == tokenIndex
+		if ok && selected && c.currentDice == dice && c.canMoveToken(player, tokenIndex) {
+			c.moveSelectedToken(player, playerIndex, tokenIndex)
+		} else if selected {
+			c.selectedToken = nil
+		}
+		c.refreshBoard()
+	}
+
+	moveBtn := widget.NewButton("✔ Move", func() { answer(true) })
+	moveBtn.Importance = widget.HighImportance
+	cancelBtn := widget.NewButton("✖ Cancel", func() { answer(false) })
+
+	fyne.Do(func() {
+		if c.confirmBar == nil {
+			return
+		}
+		c.confirmBar.Objects = []fyne.CanvasObject{widget.NewLabel(question), moveBtn, cancelBtn}
+		c.confirmBar.Refresh()
+		c.confirmBar.Show()
+	})
+}
+
+// hideMoveConfirmation retire la barre de confirmation. Doit tourner sur le
+// fil de Fyne.
+func (c *Client) hideMoveConfirmation() {
+	if c.confirmBar == nil {
+		return
+	}
+	c.confirmBar.Hide()
+	c.confirmBar.RemoveAll()
+}
@@ -0,0 +1,167 @@
+// cmd/client/crash.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// crashLogLines est le nombre de lignes de journal jointes à un rapport
+const crashLogLines = 200
+
+// logRing garde les dernières lignes du journal en mémoire
+type logRing struct {
+	lines   []string
+	partial string
+	mu      sync.Mutex
+}
+
+var clientLogs = &logRing{}
+
+func (r *logRing) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	text := r.partial + string(p)
+	parts := strings.Split(text, "\n")
+	r.partial = parts[len(parts)-1]
+
+	r.lines = append(r.lines, parts[:len(parts)-1]...)
+	if len(r.lines) > crashLogLines {
+		r.lines = r.lines[len(r.lines)-crashLogLines:]
+	}
+	return len(p), nil
+}
+
+// Lines retourne une copie des lignes conservées
+func (r *logRing) Lines() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.lines...)
+}
+
+// installCrashLog duplique le journal dans le tampon des rapports
+func installCrashLog() {
+	log.SetOutput(io.MultiWriter(os.Stderr, clientLogs))
+}
+
+// recoverCrash écrit un rapport local lors d'une panique puis la relance.
+// À différer en tête de main et des goroutines du client.
+func (c *Client) recoverCrash() {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	report := models.CrashReportPayload{
+		Version:   constants.ClientVersion,
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Panic:     fmt.Sprint(r),
+		Stack:     string(debug.Stack()),
+		Logs:      clientLogs.Lines(),
+		CrashedAt: time.Now(),
+	}
+
+	if err := os.MkdirAll(c.crashDir, 0755); err == nil {
+		data, _ := json.MarshalIndent(report, "", "  ")
+		name := fmt.Sprintf("crash-%d.json", report.CrashedAt.Unix())
+		os.WriteFile(filepath.Join(c.crashDir, name), data, 0644)
+	}
+
+	panic(r)
+}
+
+// offerCrashReport propose, au démarrage, d'envoyer le dernier rapport.
+// Rien n'est envoyé sans l'accord explicite du joueur.
+func (c *Client) offerCrashReport() {
+	paths, _ := filepath.Glob(filepath.Join(c.crashDir, "crash-*.json"))
+	if len(paths) == 0 {
+		return
+	}
+	sort.Strings(paths)
+
+	data, err := os.ReadFile(paths[len(paths)-1])
+	if err != nil {
+		return
+	}
+	var report models.CrashReportPayload
+	if err := json.Unmarshal(data, &report); err != nil {
+		c.discardCrashReports(paths)
+		return
+	}
+
+	preview := widget.NewMultiLineEntry()
+	preview.SetText(fmt.Sprintf("%s\n\n%s\n%s", report.Panic, strings.Join(report.Logs, "\n"), report.Stack))
+	preview.Disable()
+
+	scroll := container.NewVScroll(preview)
+	scroll.SetMinSize(fyne.NewSize(600, 300))
+
+	content := container.NewBorder(
+		widget.NewLabel("Ludo King closed unexpectedly last time.\nThis is exactly what would be sent to the maintainers (no account details):"),
+		nil, nil, nil,
+		scroll,
+	)
+
+	dialog.ShowCustomConfirm("Send crash report?", "Send", "Don't send", content, func(send bool) {
+		c.discardCrashReports(paths)
+		if !send {
+			return
+		}
+
+		c.mu.Lock()
+		c.pendingCrash = &report
+		c.mu.Unlock()
+
+		if c.connected {
+			c.submitCrashReport()
+		} else {
+			dialog.ShowInformation("Crash report", "The report will be sent the next time you connect to a server.", c.window)
+		}
+	}, c.window)
+}
+
+// submitCrashReport envoie le rapport accepté par le joueur
+func (c *Client) submitCrashReport() {
+	c.mu.Lock()
+	report := c.pendingCrash
+	c.pendingCrash = nil
+	c.mu.Unlock()
+
+	if report == nil {
+		return
+	}
+
+	c.send <- &models.NetworkMessage{
+		Type:      constants.MsgCrashReport,
+		Payload:   report,
+		Timestamp: time.Now(),
+	}
+	log.Printf("🐞 Crash report sent")
+}
+
+// discardCrashReports supprime les rapports locaux
+func (c *Client) discardCrashReports(paths []string) {
+	for _, path := range paths {
+		os.Remove(path)
+	}
+}
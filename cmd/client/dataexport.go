@@ -0,0 +1,70 @@
+// cmd/client/dataexport.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// requestDataExport demande au serveur de préparer l'export des données du
+// joueur. Il prévient quand l'archive est prête.
+func (c *Client) requestDataExport() {
+	c.send <- &models.NetworkMessage{
+		Type:      constants.MsgRequestDataExport,
+		Timestamp: time.Now(),
+	}
+	dialog.ShowInformation("📦 My data", "Your data export is being prepared. You will be notified when it is ready.", c.window)
+}
+
+// handleDataExportReady propose de télécharger l'archive préparée par le
+// serveur
+func (c *Client) handleDataExportReady(msg *models.NetworkMessage) {
+	var payload models.DataExportReadyPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		return
+	}
+
+	question := fmt.Sprintf("Your data export is ready (%d KB). It stays available until %s.\nDownload it now?",
+		(payload.Size+1023)/1024, payload.ExpiresAt.Local().Format("02/01 15:04"))
+	fyne.Do(func() {
+		dialog.ShowConfirm("📦 My data", question, func(ok bool) {
+			if ok {
+				c.send <- &models.NetworkMessage{
+					Type:      constants.MsgDownloadDataExport,
+					Timestamp: time.Now(),
+				}
+			}
+		}, c.window)
+	})
+}
+
+// handleDataExport enregistre l'archive reçue là où le joueur le choisit
+func (c *Client) handleDataExport(msg *models.NetworkMessage) {
+	var payload models.DataExportPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		return
+	}
+
+	fyne.Do(func() {
+		save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				return
+			}
+			defer writer.Close()
+			if _, err := writer.Write([]byte(payload.Data)); err != nil {
+				log.Printf("⚠️ Failed to save data export: %v", err)
+				dialog.ShowError(err, c.window)
+			}
+		}, c.window)
+		save.SetFileName(payload.FileName)
+		save.Show()
+	})
+}
@@ -0,0 +1,218 @@
+// cmd/client/debug.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// debugMessageLimit borne le nombre de messages affichés par la console
+const debugMessageLimit = 500
+
+// debugConsole est la console développeur (Ctrl+Shift+D)
+type debugConsole struct {
+	window   fyne.Window
+	messages []string
+	list     *widget.List
+	state    *widget.Entry
+	fps      *widget.Label
+	frames   int // Rendus du plateau depuis la dernière mesure
+	mu       sync.Mutex
+}
+
+// installDebugConsole enregistre le raccourci d'ouverture de la console
+func (c *Client) installDebugConsole() {
+	c.debug = &debugConsole{}
+
+	shortcut := &desktop.CustomShortcut{
+		KeyName:  fyne.KeyD,
+		Modifier: fyne.KeyModifierControl | fyne.KeyModifierShift,
+	}
+	c.window.Canvas().AddShortcut(shortcut, func(fyne.Shortcut) {
+		c.toggleDebugConsole()
+	})
+
+	go c.debug.measureFPS()
+}
+
+// recordMessage ajoute un message entrant (◀) ou sortant (▶) au flux
+func (d *debugConsole) recordMessage(direction string, msg *models.NetworkMessage) {
+	if d == nil {
+		return
+	}
+
+	payload, _ := json.Marshal(msg.Payload)
+	line := fmt.Sprintf("%s %s %s %s", time.Now().Format("15:04:05.000"), direction, msg.Type, payload)
+
+	d.mu.Lock()
+	d.messages = append(d.messages, line)
+	if len(d.messages) > debugMessageLimit {
+		d.messages = d.messages[len(d.messages)-debugMessageLimit:]
+	}
+	visible := d.window != nil
+	d.mu.Unlock()
+
+	if visible {
+		fyne.Do(func() {
+			d.list.Refresh()
+			d.list.ScrollToBottom()
+		})
+	}
+}
+
+// frameRendered compte un rendu du plateau
+func (d *debugConsole) frameRendered() {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	d.frames++
+	d.mu.Unlock()
+}
+
+// measureFPS met à jour la fréquence de rendu chaque seconde
+func (d *debugConsole) measureFPS() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		d.mu.Lock()
+		frames := d.frames
+		d.frames = 0
+		label := d.fps
+		d.mu.Unlock()
+
+		if label != nil {
+			fyne.Do(func() {
+				label.SetText(fmt.Sprintf("Board renderer: %d FPS", frames))
+			})
+		}
+	}
+}
+
+// toggleDebugConsole ouvre ou ferme la console
+func (c *Client) toggleDebugConsole() {
+	d := c.debug
+
+	d.mu.Lock()
+	open := d.window
+	d.mu.Unlock()
+
+	if open != nil {
+		open.Close()
+		return
+	}
+
+	d.list = widget.NewList(
+		func() int {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			return len(d.messages)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			if id < len(d.messages) {
+				item.(*widget.Label).SetText(d.messages[id])
+			}
+		},
+	)
+
+	d.state = widget.NewMultiLineEntry()
+	d.state.Wrapping = fyne.TextWrapOff
+
+	d.fps = widget.NewLabel("Board renderer: - FPS")
+
+	overlayCheck := widget.NewCheck("Board coordinates", nil)
+	overlayCheck.SetChecked(c.coordinateOverlay())
+	overlayCheck.OnChanged = c.setCoordinateOverlay
+	legend := widget.NewLabel(overlayLegend)
+	legend.Wrapping = fyne.TextWrapWord
+
+	refreshBtn := widget.NewButton("Refresh state", func() {
+		d.state.SetText(c.gameStateJSON())
+	})
+	resyncBtn := widget.NewButton("Force resync", func() {
+		if !c.isOnlineGame() || !c.connected {
+			dialog.ShowInformation("Debug", "Resync is only available in online games.", d.window)
+			return
+		}
+		c.send <- &models.NetworkMessage{Type: constants.MsgSyncState, Timestamp: time.Now()}
+	})
+	dumpBtn := widget.NewButton("Dump state to file", func() {
+		path, err := c.dumpGameState()
+		if err != nil {
+			dialog.ShowError(err, d.window)
+			return
+		}
+		dialog.ShowInformation("Debug", "State written to "+path, d.window)
+	})
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem("Messages", d.list),
+		container.NewTabItem("Game state", d.state),
+	)
+
+	window := c.app.NewWindow("Debug console")
+	window.SetContent(container.NewBorder(
+		container.NewHBox(d.fps, refreshBtn, resyncBtn, dumpBtn, overlayCheck),
+		legend, nil, nil,
+		tabs,
+	))
+	window.Resize(fyne.NewSize(900, 600))
+	window.SetOnClosed(func() {
+		d.mu.Lock()
+		d.window = nil
+		d.fps = nil
+		d.mu.Unlock()
+	})
+
+	d.mu.Lock()
+	d.window = window
+	d.mu.Unlock()
+
+	d.state.SetText(c.gameStateJSON())
+	window.Show()
+}
+
+// gameStateJSON sérialise l'état local de la partie
+func (c *Client) gameStateJSON() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.gameState == nil {
+		return "(no game in progress)"
+	}
+	data, err := json.MarshalIndent(c.gameState, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// dumpGameState écrit l'état local dans le stockage de l'application
+func (c *Client) dumpGameState() (string, error) {
+	dir := filepath.Join(c.app.Storage().RootURI().Path(), "debug")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("state-%d.json", time.Now().Unix()))
+	return path, os.WriteFile(path, []byte(c.gameStateJSON()), 0644)
+}
@@ -0,0 +1,43 @@
+// cmd/client/delta.go
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// handleStateDelta reporte les pions déplacés sur l'état local, puis
+// compare sa somme de contrôle à celle du serveur. En cas d'écart, l'état
+// complet est redemandé une fois, jusqu'à sa réception.
+func (c *Client) handleStateDelta(msg *models.NetworkMessage) {
+	var payload models.StateDeltaPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid state delta: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	if c.gameState == nil || c.gameState.Room == nil {
+		c.mu.Unlock()
+		return
+	}
+	room := c.gameState.Room
+	inSync := room.ApplyDelta(&payload) && room.Checksum() == payload.Checksum
+	request := !inSync && !c.awaitingSync
+	if request {
+		c.awaitingSync = true
+	}
+	c.mu.Unlock()
+
+	if request {
+		log.Printf("⚠️ Game state drifted from the server (checksum %08x), resyncing", payload.Checksum)
+		c.send <- &models.NetworkMessage{Type: constants.MsgSyncState, Timestamp: time.Now()}
+	}
+	if c.boardImage != nil {
+		c.refreshBoard()
+	}
+}
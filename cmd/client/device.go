@@ -0,0 +1,30 @@
+// cmd/client/device.go
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+)
+
+// devicePreference garde l'identifiant de cette installation du client
+const devicePreference = "device_id"
+
+// deviceID retourne l'identifiant de l'installation, créé au premier
+// lancement. Il n'est dérivé d'aucune donnée de la machine: le serveur s'en
+// sert seulement pour ne pas placer ensemble deux comptes du même appareil.
+func (c *Client) deviceID() string {
+	prefs := c.app.Preferences()
+	if id := prefs.String(devicePreference); id != "" {
+		return id
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		log.Printf("⚠️ Failed to create a device id: %v", err)
+		return ""
+	}
+	id := hex.EncodeToString(buf)
+	prefs.SetString(devicePreference, id)
+	return id
+}
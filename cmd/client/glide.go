@@ -0,0 +1,164 @@
+// cmd/client/glide.go
+package main
+
+import (
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/moves"
+)
+
+// glideStep est la durée de la traversée d'une case par un pion animé
+const glideStep = 70 * time.Millisecond
+
+// flyBackDuration est la durée du retour à la base d'un pion capturé
+const flyBackDuration = 450 * time.Millisecond
+
+// glide est le trajet animé d'un pion, case par case
+type glide struct {
+	cells []int
+	step  time.Duration
+	start time.Time
+}
+
+// duration retourne la durée totale du trajet
+func (g *glide) duration() time.Duration {
+	return time.Duration(len(g.cells)-1) * g.step
+}
+
+// end retourne l'instant où le pion atteint la dernière case
+func (g *glide) end() time.Time {
+	return g.start.Add(g.duration())
+}
+
+// startGlide anime le pion de from jusqu'à sa position actuelle, le long des
+// cases parcourues avec le dé. c.mu doit être tenu.
+func (c *Client) startGlide(token *models.Token, from, dice int) {
+	if from == token.Position || token.Position == moves.Base {
+		return
+	}
+	c.queueGlide(token, &glide{cells: journeyCells(from, token.Position, dice), step: glideStep})
+}
+
+// startFlyBack renvoie à sa base, d'un seul vol, un pion capturé sur la case
+// from. c.mu doit être tenu.
+func (c *Client) startFlyBack(token *models.Token, from int) {
+	if from == moves.Base {
+		return
+	}
+	c.queueGlide(token, &glide{cells: []int{from, moves.Base}, step: flyBackDuration})
+}
+
+// queueGlide joue un trajet après ceux déjà en cours: le pion pris attend
+// que son preneur soit arrivé, deux coups rapides se suivent au lieu de se
+// superposer. c.mu doit être tenu.
+func (c *Client) queueGlide(token *models.Token, g *glide) {
+	if !c.renderQuality().animate || len(g.cells) < 2 {
+		return
+	}
+
+	now := time.Now()
+	g.start = now
+	if c.glideQueue.After(now) {
+		g.start = c.glideQueue
+	}
+	c.glideQueue = g.end()
+	if c.glides == nil {
+		c.glides = make(map[*models.Token][]*glide)
+	}
+	c.glides[token] = append(c.glides[token], g)
+
+	c.animate(func(time.Duration) bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		done := !time.Now().Before(g.end())
+		if done {
+			c.dropGlide(token, g)
+			c.refreshBoard()
+		} else {
+			c.refreshPawns()
+		}
+		return !done
+	})
+}
+
+// dropGlide retire un trajet terminé de la file du pion. c.mu doit être tenu.
+func (c *Client) dropGlide(token *models.Token, g *glide) {
+	queue := c.glides[token]
+	for i, queued := range queue {
+		if queued == g {
+			queue = append(queue[:i:i], queue[i+1:]...)
+			break
+		}
+	}
+	if len(queue) == 0 {
+		delete(c.glides, token)
+	} else {
+		c.glides[token] = queue
+	}
+}
+
+// currentGlide retourne le trajet du pion à l'écran: le premier de sa file
+// qui n'est pas terminé
+func (c *Client) currentGlide(token *models.Token, now time.Time) *glide {
+	for _, g := range c.glides[token] {
+		if now.Before(g.end()) {
+			return g
+		}
+	}
+	return nil
+}
+
+// easeInOut adoucit le départ et l'arrivée de chaque pas d'un trajet
+func easeInOut(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
+
+// glideDice retrouve le dé d'un déplacement annoncé par le serveur
+func glideDice(color constants.PlayerColor, from, to int) int {
+	if from == moves.Base {
+		return constants.RollToStart
+	}
+	d := moves.Progress(color, to) - moves.Progress(color, from)
+	if d <= 0 {
+		d += constants.TotalCells
+	}
+	return d
+}
+
+// glidePixel retourne la position à l'écran d'un pion en cours de trajet,
+// interpolée entre deux cases selon le temps écoulé. Un trajet en attente
+// laisse le pion sur sa case de départ.
+func (c *Client) glidePixel(player *models.Player, tokenIndex int, token *models.Token, cs float64) (float64, float64, bool) {
+	now := time.Now()
+	g := c.currentGlide(token, now)
+	if g == nil {
+		return 0, 0, false
+	}
+
+	t := animProgress(now.Sub(g.start), g.duration()) * float64(len(g.cells)-1)
+	i := int(t)
+	if i > len(g.cells)-2 {
+		i = len(g.cells) - 2
+	}
+	frac := easeInOut(t - float64(i))
+
+	x0, y0 := positionPixel(player.Color, tokenIndex, g.cells[i], cs)
+	x1, y1 := positionPixel(player.Color, tokenIndex, g.cells[i+1], cs)
+	return x0 + (x1-x0)*frac, y0 + (y1-y0)*frac, true
+}
+
+// roomToken retrouve un pion de la partie affichée, nil s'il n'existe pas.
+// c.mu doit être tenu.
+func (c *Client) roomToken(playerID int64, tokenID int) *models.Token {
+	if c.gameState == nil || c.gameState.Room == nil {
+		return nil
+	}
+	for _, player := range c.gameState.Room.Players {
+		if player.ID == playerID && tokenID >= 0 && tokenID < len(player.Tokens) {
+			return player.Tokens[tokenID]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// cmd/client/hints.go
+package main
+
+import (
+	"fmt"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/moves"
+)
+
+// ruleHintsPreference affiche une astuce sur la règle en cause quand un
+// coup est refusé
+const ruleHintsPreference = "rule_hints"
+
+// hintSafeSquare n'est pas un code d'erreur: le coup est permis mais
+// n'aboutit pas à la capture attendue
+const hintSafeSquare = "SAFE_SQUARE"
+
+// ruleHints associe les refus du moteur à une courte explication
+var ruleHints = map[string]string{
+	constants.ErrNeedSix:       "Only a 6 brings a pawn out of the base, and it also gives you another roll.",
+	constants.ErrPawnHome:      "Pawns that reached home are done: play another one.",
+	constants.ErrPastHome:      "A pawn must land exactly on home. Move another pawn and try again next turn.",
+	constants.ErrOwnPawnThere:  "Two of your pawns cannot share a square, except home (or two on the track with blockades).",
+	constants.ErrBlockade:      "Two pawns of one colour on a square form a blockade: nobody else can land on it or pass it.",
+	constants.ErrNotYourTurn:   "Tap one of your pawns during another player's turn to premove it.",
+	constants.ErrDiceNotRolled: "Roll the dice, then tap a pawn with a green ring.",
+	constants.ErrDiceRolled:    "You already rolled: tap a pawn with a green ring to play it.",
+	constants.ErrGamePaused:    "The game is paused. Play resumes when a player allowed to pause presses Resume.",
+	constants.ErrInvalidMove:   "This room plays a rule variant: its rules decide which moves are allowed.",
+	hintSafeSquare:             "Pawns on a safe square ⭐ cannot be captured: both pawns stay.",
+}
+
+// ruleHintsEnabled indique si les astuces sont affichées (par défaut oui)
+func (c *Client) ruleHintsEnabled() bool {
+	return c.app.Preferences().BoolWithFallback(ruleHintsPreference, true)
+}
+
+// ruleHint retourne l'astuce du code, vide si elle est désactivée ou
+// inconnue
+func (c *Client) ruleHint(code string) string {
+	if !c.ruleHintsEnabled() {
+		return ""
+	}
+	if hint, ok := ruleHints[code]; ok {
+		return "💡 " + hint
+	}
+	return ""
+}
+
+// rejectionText explique au joueur pourquoi le pion ne peut pas jouer le
+// dé courant. L'appelant doit détenir c.mu.
+func (c *Client) rejectionText(player *models.Player, tokenIndex int) string {
+	text := fmt.Sprintf("❌ %s cannot move with a %d", pawnLabel(tokenIndex), c.currentDice)
+
+	// Sous une variante, seul le serveur connaît la raison
+	if c.gameState.Room.Rules != "" {
+		return text
+	}
+	room := c.gameState.Room
+	code, params := moves.RejectionWith(room.HouseRules, room.Players, player, player.Tokens[tokenIndex], c.currentDice)
+	if code == "" {
+		return text
+	}
+	text += ": " + c.translate(code, params, "")
+	if hint := c.ruleHint(code); hint != "" {
+		text += "\n" + hint
+	}
+	return text
+}
+
+// safeSquareHint prévient que le pion sélectionné rejoindra un adversaire
+// sur une case sûre sans le capturer. L'appelant doit détenir c.mu.
+func (c *Client) safeSquareHint() string {
+	player, to, ok := c.selectedDestination()
+	if !ok || to >= moves.StretchStart || !moves.IsSafe(to) {
+		return ""
+	}
+	for _, other := range c.gameState.Room.Players {
+		if other.Color == player.Color {
+			continue
+		}
+		for _, token := range other.Tokens {
+			if token.Position == to {
+				return c.ruleHint(hintSafeSquare)
+			}
+		}
+	}
+	return ""
+}
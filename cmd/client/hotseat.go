@@ -0,0 +1,193 @@
+// cmd/client/hotseat.go
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/dice"
+)
+
+// Pass & Play: 2 à 4 joueurs se partagent l'appareil, sans serveur. La
+// boucle locale des parties contre l'IA fait tourner les tours; à chaque
+// changement de joueur, un écran demande de passer l'appareil.
+const (
+	hotSeatMode = "hotseat"
+
+	hotSeatNameLength = 16 // Longueur maximale d'un nom de joueur
+)
+
+// hotSeat indique que la partie affichée se joue à plusieurs sur l'appareil
+func (c *Client) hotSeat() bool {
+	return c.gameState != nil && c.gameState.Room != nil && c.gameState.Room.GameMode == hotSeatMode
+}
+
+// seatID retourne le joueur qui tient l'appareil: le joueur courant en
+// Pass & Play, le joueur connecté sinon. L'appelant doit détenir c.mu.
+func (c *Client) seatID() int64 {
+	if c.hotSeat() {
+		room := c.gameState.Room
+		return room.Players[room.CurrentTurn].ID
+	}
+	return c.user.ID
+}
+
+// showHotSeatSetup fait choisir le nombre de joueurs et leurs noms
+func (c *Client) showHotSeatSetup() {
+	if c.user == nil {
+		c.user = &models.User{
+			ID:       time.Now().Unix(),
+			Username: fmt.Sprintf("Player%d", time.Now().Unix()%1000),
+		}
+	}
+
+	// Un nom par siège, gardé quand le nombre de joueurs change
+	entries := make([]*widget.Entry, len(constants.BoardQuadrants))
+	for i := range entries {
+		entries[i] = widget.NewEntry()
+		entries[i].SetPlaceHolder(hotSeatName(i))
+	}
+
+	seats := container.NewVBox()
+	numPlayersSelect := widget.NewSelect([]string{"2", "3", "4"}, func(value string) {
+		seats.RemoveAll()
+		for i, color := range constants.SeatColors(hotSeatCount(value)) {
+			seats.Add(widget.NewLabel(colorLabel(color) + ":"))
+			seats.Add(entries[i])
+		}
+	})
+	numPlayersSelect.SetSelected("2")
+
+	startBtn := widget.NewButton("Start Game", func() {
+		var names []string
+		for i := 0; i < hotSeatCount(numPlayersSelect.Selected); i++ {
+			name := strings.TrimSpace(entries[i].Text)
+			if name == "" {
+				name = hotSeatName(i)
+			}
+			if len([]rune(name)) > hotSeatNameLength {
+				name = string([]rune(name)[:hotSeatNameLength])
+			}
+			names = append(names, name)
+		}
+		c.createHotSeatGame(names)
+	})
+	startBtn.Importance = widget.HighImportance
+
+	backBtn := widget.NewButton("Back", func() {
+		c.showMainMenu()
+	})
+
+	form := container.NewVBox(
+		widget.NewLabelWithStyle("Pass & Play", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel("Share this device: each player rolls on their turn."),
+		widget.NewSeparator(),
+		widget.NewLabel("Number of Players:"),
+		numPlayersSelect,
+		seats,
+		widget.NewSeparator(),
+		startBtn,
+		backBtn,
+	)
+
+	c.setContent(container.NewCenter(form))
+}
+
+// hotSeatCount convertit le choix du nombre de joueurs
+func hotSeatCount(value string) int {
+	switch value {
+	case "3":
+		return 3
+	case "4":
+		return 4
+	}
+	return 2
+}
+
+// hotSeatName est le nom d'un joueur qui n'en a pas saisi
+func hotSeatName(seat int) string {
+	return fmt.Sprintf("Player %d", seat+1)
+}
+
+// createHotSeatGame prépare une partie locale entre joueurs humains, un par
+// nom, assis dans l'ordre des couleurs
+func (c *Client) createHotSeatGame(names []string) {
+	room := &models.Room{
+		ID:          fmt.Sprintf("HOTSEAT_%d", time.Now().Unix()),
+		Name:        "Pass & Play",
+		HostID:      c.user.ID,
+		Players:     make([]*models.Player, 0, len(names)),
+		MaxPlayers:  len(names),
+		GameMode:    hotSeatMode,
+		State:       constants.StateWaiting,
+		CreatedAt:   time.Now(),
+		CurrentTurn: 0,
+	}
+
+	c.diceProfiles = make(map[constants.PlayerColor]dice.Profile)
+	c.aiProfiles = nil
+	c.aiBots = nil
+	for i, color := range constants.SeatColors(len(names)) {
+		// Identifiants propres à la partie: aucun joueur n'est le compte connecté
+		player := models.NewPlayer(int64(i+1), names[i], color)
+		player.IsReady = true
+		room.Players = append(room.Players, player)
+		c.diceProfiles[color] = dice.Fair
+	}
+
+	c.gameState = &models.Game{
+		Room:      room,
+		Board:     models.NewBoard(),
+		StartTime: time.Now(),
+	}
+
+	c.showGameBoard()
+}
+
+// handDeviceTo demande de passer l'appareil au joueur suivant. Le dé reste
+// bloqué jusqu'à ce qu'il confirme l'avoir en main. Appelé depuis le fil de
+// l'interface.
+func (c *Client) handDeviceTo(player *models.Player) {
+	who := fmt.Sprintf("%s (%s)", player.Username, colorLabel(player.Color))
+	c.statusLabel.SetText(fmt.Sprintf("🔄 Hand the device to %s", who))
+	c.diceButton.Disable()
+
+	title := widget.NewLabelWithStyle(fmt.Sprintf("Hand the device to %s", colorLabel(player.Color)), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	title.SizeName = theme.SizeNameSubHeadingText
+	content := container.NewVBox(
+		title,
+		widget.NewLabelWithStyle(fmt.Sprintf("%s, tap Ready when you have it.", player.Username), fyne.TextAlignCenter, fyne.TextStyle{}),
+	)
+	handoff := dialog.NewCustom("🔄 Pass & Play", "Ready", content, c.window)
+	handoff.SetOnClosed(func() {
+		c.statusLabel.SetText(fmt.Sprintf("🎲 %s, roll the dice.", who))
+		c.diceButton.Enable()
+	})
+	handoff.Show()
+}
+
+// hotSeatWin termine la partie Pass & Play sur la victoire du joueur.
+// L'appelant doit détenir c.mu.
+func (c *Client) hotSeatWin(player *models.Player) {
+	c.gameState.Room.State = constants.StateFinished
+	c.gameState.Winner = player
+	c.isMyTurn = false
+	c.currentDice = 0
+
+	who := fmt.Sprintf("%s (%s)", player.Username, colorLabel(player.Color))
+	c.narrate("Game over: %s won!", c.spokenPlayer(player))
+	fyne.Do(func() {
+		c.diceButton.Disable()
+		c.statusLabel.SetText(fmt.Sprintf("🏆 %s WINS!", who))
+		dialog.ShowInformation("Victory!", fmt.Sprintf("🏆 Congratulations %s! You won the game!", who), c.window)
+	})
+}
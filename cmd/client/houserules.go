@@ -0,0 +1,74 @@
+// cmd/client/houserules.go
+package main
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// houseRulesForm regroupe les cases à cocher des règles maison de l'écran
+// de création de salle
+type houseRulesForm struct {
+	swap, blockades, freeFinish, captureBonus, noTripleSix, undo *widget.Check
+}
+
+func newHouseRulesForm() *houseRulesForm {
+	return &houseRulesForm{
+		swap:         widget.NewCheck("🔄 A captured pawn swaps places instead of going home", nil),
+		blockades:    widget.NewCheck("🧱 Blockades: two pawns on a square cannot be passed", nil),
+		freeFinish:   widget.NewCheck("🏁 No exact roll needed to reach home", nil),
+		captureBonus: widget.NewCheck("💥 Extra roll after a capture", nil),
+		noTripleSix:  widget.NewCheck("🎲 No penalty for three sixes in a row", nil),
+		undo:         widget.NewCheck("↩ Players may take back a move until the next roll", nil),
+	}
+}
+
+// content retourne les cases à afficher dans le formulaire
+func (f *houseRulesForm) content() fyne.CanvasObject {
+	return container.NewVBox(f.swap, f.blockades, f.freeFinish, f.captureBonus, f.noTripleSix, f.undo)
+}
+
+// config retourne les règles cochées
+func (f *houseRulesForm) config() models.RuleConfig {
+	return models.RuleConfig{
+		SwapOnCapture: f.swap.Checked,
+		Blockades:     f.blockades.Checked,
+		FreeFinish:    f.freeFinish.Checked,
+		CaptureBonus:  f.captureBonus.Checked,
+		NoTripleSix:   f.noTripleSix.Checked,
+		Undo:          f.undo.Checked,
+	}
+}
+
+// houseRulesLine résume les règles maison d'une salle pour le lobby, vide
+// pour les règles classiques
+func houseRulesLine(rc models.RuleConfig) string {
+	var rules []string
+	if rc.SwapOnCapture {
+		rules = append(rules, "captures swap")
+	}
+	if rc.Blockades {
+		rules = append(rules, "blockades")
+	}
+	if rc.FreeFinish {
+		rules = append(rules, "no exact finish")
+	}
+	if rc.CaptureBonus {
+		rules = append(rules, "capture bonus roll")
+	}
+	if rc.NoTripleSix {
+		rules = append(rules, "no three-six penalty")
+	}
+	if rc.Undo {
+		rules = append(rules, "undo")
+	}
+	if len(rules) == 0 {
+		return ""
+	}
+	return "🏠 House rules: " + strings.Join(rules, ", ")
+}
@@ -0,0 +1,185 @@
+// cmd/client/kidmode.go
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Mode enfant: chat limité aux émoticônes, coins masqués, parties contre
+// l'IA ou dans des salles privées seulement. Un code parental local le
+// verrouille; seule son empreinte salée est enregistrée.
+const (
+	kidModePreference    = "kid_mode"
+	kidModePinPreference = "kid_mode_pin"
+
+	kidModePinLength = 4
+)
+
+// kidEmotes sont les seuls messages qu'un enfant peut envoyer ou lire
+var kidEmotes = []string{"👋", "👍", "😀", "😮", "😢", "🎉", "🍀", "🎲"}
+
+// kidMode indique que le mode enfant est actif
+func (c *Client) kidMode() bool {
+	return c.app.Preferences().Bool(kidModePreference)
+}
+
+// isKidEmote indique qu'un message de chat est une émoticône autorisée
+func isKidEmote(text string) bool {
+	for _, emote := range kidEmotes {
+		if text == emote {
+			return true
+		}
+	}
+	return false
+}
+
+// hashKidPin retourne l'empreinte enregistrée d'un code: "sel:sha256"
+func hashKidPin(salt, pin string) string {
+	sum := sha256.Sum256([]byte(salt + pin))
+	return salt + ":" + hex.EncodeToString(sum[:])
+}
+
+// checkKidPin compare un code saisi à l'empreinte enregistrée
+func checkKidPin(stored, pin string) bool {
+	salt, _, ok := strings.Cut(stored, ":")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashKidPin(salt, pin)), []byte(stored)) == 1
+}
+
+// validKidPin vérifie qu'un code compte exactement kidModePinLength chiffres
+func validKidPin(pin string) bool {
+	if len(pin) != kidModePinLength {
+		return false
+	}
+	for _, r := range pin {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// setKidMode active le mode enfant avec un nouveau code parental
+func (c *Client) setKidMode(pin string) error {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return err
+	}
+	prefs := c.app.Preferences()
+	prefs.SetString(kidModePinPreference, hashKidPin(hex.EncodeToString(buf), pin))
+	prefs.SetBool(kidModePreference, true)
+	return nil
+}
+
+// clearKidMode désactive le mode enfant si le code parental est le bon
+func (c *Client) clearKidMode(pin string) error {
+	prefs := c.app.Preferences()
+	if !checkKidPin(prefs.String(kidModePinPreference), pin) {
+		return fmt.Errorf("Wrong PIN")
+	}
+	prefs.SetBool(kidModePreference, false)
+	prefs.RemoveValue(kidModePinPreference)
+	return nil
+}
+
+// kidModeSettings est le réglage du mode enfant. L'activer demande de
+// choisir un code, le désactiver demande ce code.
+func (c *Client) kidModeSettings() fyne.CanvasObject {
+	status := widget.NewLabel("")
+	var button *widget.Button
+	update := func() {
+		if c.kidMode() {
+			status.SetText("🧒 Kid mode is on: emotes-only chat, no coins, AI and private rooms only.")
+			button.SetText("Turn off (PIN)")
+		} else {
+			status.SetText("Kid mode is off.")
+			button.SetText("Turn on kid mode")
+		}
+	}
+	button = widget.NewButton("", func() {
+		if c.kidMode() {
+			c.askKidPinToDisable(update)
+		} else {
+			c.askKidPinToEnable(update)
+		}
+	})
+	update()
+	status.Wrapping = fyne.TextWrapWord
+
+	return container.NewVBox(status, button)
+}
+
+// askKidPinToEnable fait choisir et confirmer le code parental
+func (c *Client) askKidPinToEnable(done func()) {
+	pinEntry := widget.NewPasswordEntry()
+	pinEntry.SetPlaceHolder(fmt.Sprintf("%d-digit PIN", kidModePinLength))
+	confirmEntry := widget.NewPasswordEntry()
+	confirmEntry.SetPlaceHolder("Repeat the PIN")
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("PIN", pinEntry),
+		widget.NewFormItem("Confirm", confirmEntry),
+	}
+	dialog.ShowForm("🧒 Kid mode", "Turn on", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		switch {
+		case !validKidPin(pinEntry.Text):
+			dialog.ShowError(fmt.Errorf("The PIN must be %d digits", kidModePinLength), c.window)
+			return
+		case pinEntry.Text != confirmEntry.Text:
+			dialog.ShowError(fmt.Errorf("The two PINs differ"), c.window)
+			return
+		}
+		if err := c.setKidMode(pinEntry.Text); err != nil {
+			dialog.ShowError(err, c.window)
+			return
+		}
+		done()
+	}, c.window)
+}
+
+// askKidPinToDisable demande le code parental avant de quitter le mode enfant
+func (c *Client) askKidPinToDisable(done func()) {
+	pinEntry := widget.NewPasswordEntry()
+	pinEntry.SetPlaceHolder("Parent PIN")
+
+	items := []*widget.FormItem{widget.NewFormItem("PIN", pinEntry)}
+	dialog.ShowForm("🧒 Kid mode", "Turn off", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := c.clearKidMode(pinEntry.Text); err != nil {
+			dialog.ShowError(err, c.window)
+			return
+		}
+		done()
+	}, c.window)
+}
+
+// kidChatBar remplace la saisie du chat par une rangée d'émoticônes
+func (c *Client) kidChatBar() fyne.CanvasObject {
+	bar := container.NewHBox()
+	for _, emote := range kidEmotes {
+		emote := emote
+		bar.Add(widget.NewButton(emote, func() {
+			if err := c.sendChat(emote); err != nil {
+				dialog.ShowError(err, c.window)
+			}
+		}))
+	}
+	return container.NewHScroll(bar)
+}
@@ -0,0 +1,57 @@
+// cmd/client/lan.go
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/discovery"
+)
+
+// lanBrowseTimeout couvre au moins une annonce de chaque serveur
+const lanBrowseTimeout = 3 * time.Second
+
+// showLANServers cherche les serveurs du réseau local (IPv4 et IPv6) et
+// remplit l'adresse choisie dans le champ de connexion
+func (c *Client) showLANServers(target *widget.Entry) {
+	progress := dialog.NewInformation("LAN", "Searching for servers on your network...", c.window)
+	progress.Show()
+
+	go func() {
+		servers, err := discovery.Browse(lanBrowseTimeout)
+
+		fyne.Do(func() {
+			progress.Hide()
+
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("LAN discovery failed: %v", err), c.window)
+				return
+			}
+			if len(servers) == 0 {
+				dialog.ShowInformation("LAN", "No server found on your network.", c.window)
+				return
+			}
+
+			var picker dialog.Dialog
+			list := widget.NewList(
+				func() int { return len(servers) },
+				func() fyne.CanvasObject { return widget.NewLabel("") },
+				func(id widget.ListItemID, item fyne.CanvasObject) {
+					item.(*widget.Label).SetText(fmt.Sprintf("%s  (%s)", servers[id].Name, servers[id].Address))
+				},
+			)
+			list.OnSelected = func(id widget.ListItemID) {
+				target.SetText(servers[id].Address)
+				picker.Hide()
+			}
+
+			picker = dialog.NewCustom("LAN servers", "Cancel", list, c.window)
+			picker.Resize(fyne.NewSize(420, 300))
+			picker.Show()
+		})
+	}()
+}
@@ -0,0 +1,49 @@
+// cmd/client/language.go
+package main
+
+import (
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+)
+
+// languagePreference garde la langue des messages du serveur. Sans choix
+// du joueur, la langue du système est utilisée.
+const languagePreference = "language"
+
+// language retourne la langue choisie par le joueur
+func (c *Client) language() string {
+	return i18n.Match(c.app.Preferences().StringWithFallback(languagePreference, string(lang.SystemLocale())))
+}
+
+// translate rend un message du serveur dans la langue du joueur. fallback
+// est le texte anglais envoyé avec le code, pour un code encore inconnu de
+// ce client.
+func (c *Client) translate(code string, params map[string]any, fallback string) string {
+	text := i18n.Render(c.language(), code, params)
+	if text == code && fallback != "" {
+		return fallback
+	}
+	return text
+}
+
+// languageSelect est le choix de la langue dans les réglages
+func (c *Client) languageSelect() *widget.Select {
+	langs := i18n.Languages()
+	options := make([]string, len(langs))
+	for i, l := range langs {
+		options[i] = i18n.Name(l)
+	}
+
+	choice := widget.NewSelect(options, nil)
+	choice.SetSelected(i18n.Name(c.language()))
+	choice.OnChanged = func(selected string) {
+		for i, option := range options {
+			if option == selected {
+				c.app.Preferences().SetString(languagePreference, langs[i])
+			}
+		}
+	}
+	return choice
+}
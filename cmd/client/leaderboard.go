@@ -0,0 +1,171 @@
+// cmd/client/leaderboard.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// leaderboardPrefetch est le nombre de lignes restantes sous lesquelles la
+// page suivante est demandée pendant le défilement
+const leaderboardPrefetch = 5
+
+// leaderboardView est l'écran du classement, chargé page par page au
+// défilement. Ses champs sont protégés par c.mu.
+type leaderboardView struct {
+	entries []models.LeaderboardEntry
+	search  string
+	hasMore bool
+	loading bool
+	list    *widget.List
+	status  *widget.Label
+}
+
+// showLeaderboard affiche le classement du serveur, avec recherche par pseudo
+func (c *Client) showLeaderboard() {
+	if !c.connected {
+		dialog.ShowInformation("Leaderboard", "Connect to a server to see the leaderboard.", c.window)
+		return
+	}
+
+	view := &leaderboardView{hasMore: true, status: widget.NewLabel("Loading...")}
+	view.list = widget.NewList(
+		func() int {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return len(view.entries)
+		},
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			c.mu.Lock()
+			if id >= len(view.entries) {
+				c.mu.Unlock()
+				return
+			}
+			entry := view.entries[id]
+			nearEnd := id >= len(view.entries)-leaderboardPrefetch
+			c.mu.Unlock()
+
+			item.(*widget.Label).SetText(leaderboardLine(entry))
+			// Défilement infini: la fin de la liste réclame la page suivante
+			if nearEnd {
+				c.requestLeaderboardPage(view, false)
+			}
+		},
+	)
+
+	searchEntry := widget.NewEntry()
+	searchEntry.SetPlaceHolder("Search a player...")
+	searchEntry.OnChanged = func(text string) {
+		c.mu.Lock()
+		view.search = text
+		c.mu.Unlock()
+		c.requestLeaderboardPage(view, true)
+	}
+
+	backBtn := widget.NewButton("Back", func() {
+		c.mu.Lock()
+		c.leaderboard = nil
+		c.mu.Unlock()
+		c.showMainMenu()
+	})
+
+	c.mu.Lock()
+	c.leaderboard = view
+	c.mu.Unlock()
+	c.requestLeaderboardPage(view, true)
+
+	c.setContent(container.NewBorder(
+		container.NewVBox(
+			widget.NewLabelWithStyle("🏆 Leaderboard", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+			searchEntry,
+		),
+		container.NewHBox(backBtn, view.status),
+		nil, nil,
+		view.list,
+	))
+}
+
+// requestLeaderboardPage demande la page qui suit les lignes déjà reçues.
+// reset repart du début, pour une nouvelle recherche.
+func (c *Client) requestLeaderboardPage(view *leaderboardView, reset bool) {
+	c.mu.Lock()
+	if reset {
+		view.entries = nil
+		view.hasMore = true
+		view.loading = false
+	}
+	if view.loading || !view.hasMore {
+		c.mu.Unlock()
+		return
+	}
+	view.loading = true
+	request := models.LeaderboardRequestPayload{
+		Offset: len(view.entries),
+		Limit:  constants.LeaderboardPageSize,
+		Search: view.search,
+	}
+	c.mu.Unlock()
+
+	if reset {
+		view.list.Refresh()
+	}
+
+	c.send <- &models.NetworkMessage{
+		Type:      constants.MsgGetLeaderboard,
+		Payload:   request,
+		Timestamp: time.Now(),
+	}
+}
+
+// handleLeaderboard ajoute une page reçue au classement affiché. Les pages
+// d'une recherche abandonnée ou déjà reçues sont ignorées.
+func (c *Client) handleLeaderboard(msg *models.NetworkMessage) {
+	var page models.LeaderboardPayload
+	if err := protocol.ExtractPayload(msg.Payload, &page); err != nil {
+		log.Printf("❌ Invalid leaderboard: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	view := c.leaderboard
+	if view == nil || page.Search != view.search || page.Offset != len(view.entries) {
+		c.mu.Unlock()
+		return
+	}
+	view.entries = append(view.entries, page.Entries...)
+	view.hasMore = page.HasMore
+	view.loading = false
+
+	status := fmt.Sprintf("%d players", len(view.entries))
+	switch {
+	case len(view.entries) == 0 && view.search != "":
+		status = "No player matches your search."
+	case len(view.entries) == 0:
+		status = "No ranked players yet."
+	case view.hasMore:
+		status += ", scroll for more"
+	}
+	c.mu.Unlock()
+
+	fyne.Do(func() {
+		view.status.SetText(status)
+		view.list.Refresh()
+	})
+}
+
+// leaderboardLine formate une ligne du classement
+func leaderboardLine(entry models.LeaderboardEntry) string {
+	return fmt.Sprintf("#%d  %s  (Lv %d) — %d wins / %d games, %.1f%%, ⭐ %.0f",
+		entry.Rank, entry.Username, entry.Level, entry.GamesWon, entry.TotalGames, entry.WinRate, entry.Rating)
+}
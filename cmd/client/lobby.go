@@ -0,0 +1,334 @@
+// cmd/client/lobby.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/roles"
+)
+
+// colorEmojis représente chaque couleur dans les listes de joueurs
+var colorEmojis = map[constants.PlayerColor]string{
+	constants.ColorRed:    "🔴",
+	constants.ColorGreen:  "🟢",
+	constants.ColorYellow: "🟡",
+	constants.ColorBlue:   "🔵",
+}
+
+// showLobby affiche la salle en attente: joueurs, score de la série et
+// bouton prêt, sur le décor du thème choisi par l'hôte. Selon son rôle, le
+// joueur peut aussi exclure, priver de chat, changer les couleurs et le
+// thème, et lancer la partie. Doit tourner sur le fil de Fyne.
+func (c *Client) showLobby() {
+	c.mu.Lock()
+	if c.gameState == nil || c.gameState.Room == nil {
+		c.mu.Unlock()
+		return
+	}
+	room := c.gameState.Room
+	title := fmt.Sprintf("🔑 %s — Room %s", room.Name, room.ID)
+	subtitle := fmt.Sprintf("Game %d · %d/%d players", room.GameNumber, len(room.Players), room.MaxPlayers)
+	if room.Match != nil {
+		subtitle += fmt.Sprintf(" · Best of %d", room.Match.BestOf)
+	}
+	if room.RuleSet == constants.RuleSetQuick {
+		subtitle += " · " + ruleSetQuickLabel
+	}
+	var me int64
+	if c.user != nil {
+		me = c.user.ID
+	}
+	can := func(perm constants.Permission) bool { return roles.Can(room, me, perm) }
+	canStart := len(room.Players) >= constants.MinPlayers
+	rows := make([]fyne.CanvasObject, len(room.Players))
+	for i, player := range room.Players {
+		label := widget.NewLabel(fmt.Sprintf("%s %s %s  🏆 %d", colorEmojis[player.Color], player.Username,
+			roleBadge(room, player.ID), room.Series[player.ID]))
+		row := container.NewHBox(label, c.handicapControl(player, can(constants.PermChangeRules)))
+		if can(constants.PermChangeRules) {
+			row.Add(c.colorControl(player))
+		}
+		if can(constants.PermManageRoles) && player.ID != room.HostID {
+			row.Add(c.roleControl(room, player))
+		}
+		if roles.Outranks(room, me, player.ID) {
+			if can(constants.PermModerateChat) {
+				row.Add(c.muteButton(player, room.Muted[player.ID]))
+			}
+			if can(constants.PermKick) {
+				row.Add(c.kickButton(player))
+			}
+		}
+		rows[i] = row
+	}
+	canLaunch := can(constants.PermStartGame)
+	canManage := can(constants.PermManageRoles)
+	canDecorate := can(constants.PermChangeRules)
+	theme := room.Theme
+	houseRules := houseRulesLine(room.HouseRules)
+	rivalries := c.rivalryLines(room)
+	missing := c.missingRivalries(room)
+	c.mu.Unlock()
+	c.requestRivalries(missing)
+
+	readyBtn := widget.NewButton("✅ Ready", nil)
+	readyBtn.OnTapped = func() {
+		readyBtn.Disable()
+		readyBtn.SetText("⏳ Waiting for the other players…")
+		c.send <- &models.NetworkMessage{Type: constants.MsgReady, Timestamp: time.Now()}
+	}
+	leaveBtn := widget.NewButton("Leave", func() {
+		c.leaveRoom()
+	})
+
+	content := container.NewVBox(
+		widget.NewLabelWithStyle(title, fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(subtitle, fyne.TextAlignCenter, fyne.TextStyle{}),
+	)
+	if houseRules != "" {
+		content.Add(widget.NewLabelWithStyle(houseRules, fyne.TextAlignCenter, fyne.TextStyle{Italic: true}))
+	}
+	content.Add(widget.NewSeparator())
+	content.Add(widget.NewLabel("Series wins:"))
+	for _, row := range rows {
+		content.Add(row)
+	}
+	for _, line := range rivalries {
+		content.Add(widget.NewLabel(line))
+	}
+	content.Add(widget.NewSeparator())
+	content.Add(readyBtn)
+	if canLaunch {
+		startBtn := widget.NewButton("▶ Start Game", func() {
+			c.send <- &models.NetworkMessage{Type: constants.MsgStartGame, Timestamp: time.Now()}
+		})
+		startBtn.Importance = widget.HighImportance
+		if !canStart {
+			startBtn.Disable()
+		}
+		content.Add(startBtn)
+	}
+	if canManage {
+		content.Add(widget.NewButton("⚙ Permissions", c.showPermissionsDialog))
+	}
+	if canDecorate {
+		content.Add(widget.NewButton("🎉 Party theme", func() {
+			c.showLobbyThemeDialog(theme)
+		}))
+	}
+	content.Add(leaveBtn)
+
+	c.setContent(c.partyBackdrop(theme, container.NewCenter(content)))
+	c.playPartyMusic(theme)
+}
+
+// handicapOptions associe les libellés du lobby aux handicaps de départ
+var handicapOptions = []struct{ label, value string }{
+	{"No handicap", constants.HandicapNone},
+	{"🚀 Head start", constants.HandicapHeadStart},
+	{"🔁 Extra lap", constants.HandicapExtraLap},
+}
+
+// handicapLabel retourne le libellé affiché d'un handicap
+func handicapLabel(handicap string) string {
+	for _, option := range handicapOptions {
+		if option.value == handicap {
+			return option.label
+		}
+	}
+	return handicap
+}
+
+// handicapControl affiche le handicap d'un joueur, modifiable si le rôle
+// du joueur local le permet. L'appelant doit détenir c.mu.
+func (c *Client) handicapControl(player *models.Player, editable bool) fyne.CanvasObject {
+	if !editable {
+		if player.Handicap == constants.HandicapNone {
+			return widget.NewLabel("")
+		}
+		return widget.NewLabel(handicapLabel(player.Handicap))
+	}
+
+	labels := make([]string, len(handicapOptions))
+	for i, option := range handicapOptions {
+		labels[i] = option.label
+	}
+	playerID := player.ID
+	selector := widget.NewSelect(labels, nil)
+	selector.SetSelected(handicapLabel(player.Handicap))
+	selector.OnChanged = func(selected string) {
+		for _, option := range handicapOptions {
+			if option.label == selected {
+				c.send <- &models.NetworkMessage{
+					Type:      constants.MsgSetHandicap,
+					Payload:   models.SetHandicapPayload{PlayerID: playerID, Handicap: option.value},
+					Timestamp: time.Now(),
+				}
+			}
+		}
+	}
+	return selector
+}
+
+// colorControl permet de changer la couleur d'un joueur. Le
+// joueur qui l'avait prend l'ancienne. L'appelant doit détenir c.mu.
+func (c *Client) colorControl(player *models.Player) fyne.CanvasObject {
+	labels := make([]string, len(constants.BoardQuadrants))
+	for i, color := range constants.BoardQuadrants {
+		labels[i] = colorLabel(color)
+	}
+	playerID := player.ID
+	selector := widget.NewSelect(labels, nil)
+	selector.SetSelected(colorLabel(player.Color))
+	selector.OnChanged = func(selected string) {
+		for _, color := range constants.BoardQuadrants {
+			if colorLabel(color) == selected {
+				c.send <- &models.NetworkMessage{
+					Type:      constants.MsgAssignColor,
+					Payload:   models.AssignColorPayload{PlayerID: playerID, Color: color},
+					Timestamp: time.Now(),
+				}
+			}
+		}
+	}
+	return selector
+}
+
+// colorLabel retourne le libellé affiché d'une couleur
+func colorLabel(color constants.PlayerColor) string {
+	name := string(color)
+	if name == "" {
+		return ""
+	}
+	return colorEmojis[color] + " " + strings.ToUpper(name[:1]) + name[1:]
+}
+
+// kickButton exclut un joueur de la salle, après confirmation
+func (c *Client) kickButton(player *models.Player) fyne.CanvasObject {
+	playerID, username := player.ID, player.Username
+	return widget.NewButton("✖ Kick", func() {
+		dialog.ShowConfirm("Kick player", fmt.Sprintf("Remove %s from the room? They will not be able to join again.", username),
+			func(ok bool) {
+				if !ok {
+					return
+				}
+				c.send <- &models.NetworkMessage{
+					Type:      constants.MsgKickPlayer,
+					Payload:   models.KickPlayerPayload{PlayerID: playerID},
+					Timestamp: time.Now(),
+				}
+			}, c.window)
+	})
+}
+
+// handleKicked ramène au menu le joueur exclu de la salle
+func (c *Client) handleKicked(msg *models.NetworkMessage) {
+	var payload models.KickedPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid kick notice: %v", err)
+		return
+	}
+	log.Printf("🚪 Kicked from room %s", payload.RoomID)
+
+	c.mu.Lock()
+	c.gameState = nil
+	c.mu.Unlock()
+
+	text := c.translate(payload.Reason, nil, "You were removed from this room.")
+	fyne.Do(func() {
+		c.showMainMenu()
+		dialog.ShowInformation("🚪 Removed from room", text, c.window)
+	})
+}
+
+// handlePlayerJoined ajoute le nouveau joueur à la salle en attente
+func (c *Client) handlePlayerJoined(msg *models.NetworkMessage) {
+	var payload models.PlayerJoinedPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil || payload.Player == nil {
+		log.Printf("❌ Invalid player joined: %v", err)
+		return
+	}
+	log.Printf("👤 Player joined: %s", payload.Player.Username)
+
+	c.mu.Lock()
+	waiting := c.gameState != nil && c.gameState.Room != nil && c.gameState.Room.State == constants.StateWaiting
+	if waiting {
+		room := c.gameState.Room
+		known := false
+		for _, player := range room.Players {
+			known = known || player.ID == payload.Player.ID
+		}
+		if !known {
+			room.Players = append(room.Players, payload.Player)
+		}
+	}
+	c.mu.Unlock()
+
+	if waiting {
+		fyne.Do(c.showLobby)
+	}
+}
+
+// leaveRoom quitte la salle en ligne et revient au menu
+func (c *Client) leaveRoom() {
+	c.send <- &models.NetworkMessage{Type: constants.MsgLeaveRoom, Timestamp: time.Now()}
+	c.mu.Lock()
+	c.gameState = nil
+	c.mu.Unlock()
+	c.showMainMenu()
+}
+
+// requestRematch demande une nouvelle partie dans la même salle
+func (c *Client) requestRematch() {
+	c.send <- &models.NetworkMessage{Type: constants.MsgRematch, Timestamp: time.Now()}
+}
+
+// seriesText résume le score de la série, meilleur score en tête
+func seriesText(gameNumber int, series map[int64]int, players []*models.Player) string {
+	if gameNumber == 0 {
+		return ""
+	}
+	ranked := append([]*models.Player(nil), players...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return series[ranked[i].ID] > series[ranked[j].ID]
+	})
+
+	lines := make([]string, 0, len(ranked))
+	for _, player := range ranked {
+		if player.IsAI {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s %s: %d", colorEmojis[player.Color], player.Username, series[player.ID]))
+	}
+	return fmt.Sprintf("📊 Series after game %d:\n%s", gameNumber, strings.Join(lines, "\n"))
+}
+
+// matchText résume un match au meilleur de N et annonce son vainqueur
+func matchText(match *models.MatchScore, players []*models.Player) string {
+	scores := make([]string, 0, len(players))
+	champion := ""
+	for _, player := range players {
+		scores = append(scores, fmt.Sprintf("%s %d", player.Username, match.Wins[player.ID]))
+		if player.ID == match.WinnerID {
+			champion = player.Username
+		}
+	}
+
+	text := fmt.Sprintf("🎯 Best of %d — %s", match.BestOf, strings.Join(scores, " · "))
+	if champion != "" {
+		text += fmt.Sprintf("\n🏆 %s wins the match!", champion)
+	}
+	return text
+}
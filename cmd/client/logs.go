@@ -0,0 +1,300 @@
+// cmd/client/logs.go
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Rotation du journal local
+const (
+	logFileName    = "client.log"
+	logMaxSize     = 1 << 20 // Taille d'un fichier avant rotation
+	logKeepFiles   = 3       // Anciens fichiers conservés (client.log.1 à .3)
+	logViewerLimit = 2000    // Entrées affichées au plus dans le visualiseur
+)
+
+// Niveaux et catégories des entrées du journal
+const (
+	logLevelInfo  = "info"
+	logLevelWarn  = "warn"
+	logLevelError = "error"
+
+	logCategoryNetwork = "network"
+	logCategoryApp     = "app"
+)
+
+// logEntry est une ligne du journal, enregistrée en JSON
+type logEntry struct {
+	Time     time.Time `json:"time"`
+	Level    string    `json:"level"`
+	Category string    `json:"category"`
+	Message  string    `json:"message"`
+}
+
+// logFile écrit le journal en JSON dans un fichier à rotation
+type logFile struct {
+	dir     string
+	file    *os.File
+	size    int64
+	partial string
+	mu      sync.Mutex
+}
+
+// clientLogDir est le dossier du journal dans le dossier de configuration
+// de l'utilisateur, ou dans le stockage de l'application à défaut
+func clientLogDir(fallback string) string {
+	if dir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(dir, "ludo-king", "logs")
+	}
+	return filepath.Join(fallback, "logs")
+}
+
+// openLogFile ouvre le journal, en continuant le fichier existant
+func openLogFile(dir string) (*logFile, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	l := &logFile{dir: dir}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *logFile) open() error {
+	file, err := os.OpenFile(filepath.Join(l.dir, logFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	l.file, l.size = file, info.Size()
+	return nil
+}
+
+// Write reçoit la sortie du paquet log et enregistre une entrée par ligne
+func (l *logFile) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	text := l.partial + string(p)
+	lines := strings.Split(text, "\n")
+	l.partial = lines[len(lines)-1]
+
+	for _, line := range lines[:len(lines)-1] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		data, err := json.Marshal(classifyLogLine(line, time.Now()))
+		if err != nil {
+			continue
+		}
+		l.writeEntry(append(data, '\n'))
+	}
+	return len(p), nil
+}
+
+func (l *logFile) writeEntry(data []byte) {
+	if l.file == nil {
+		return
+	}
+	if l.size+int64(len(data)) > logMaxSize {
+		l.rotate()
+		if l.file == nil {
+			return
+		}
+	}
+	n, _ := l.file.Write(data)
+	l.size += int64(n)
+}
+
+// rotate décale client.log -> client.log.1 -> ... et supprime le plus ancien
+func (l *logFile) rotate() {
+	l.file.Close()
+	l.file = nil
+
+	base := filepath.Join(l.dir, logFileName)
+	os.Remove(fmt.Sprintf("%s.%d", base, logKeepFiles))
+	for i := logKeepFiles - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", base, i), fmt.Sprintf("%s.%d", base, i+1))
+	}
+	os.Rename(base, base+".1")
+
+	l.open()
+}
+
+// classifyLogLine déduit niveau et catégorie des conventions du journal:
+// ❌ pour les erreurs, ⚠️ pour les avertissements, 📨/📤 pour le réseau
+func classifyLogLine(line string, at time.Time) logEntry {
+	entry := logEntry{Time: at, Level: logLevelInfo, Category: logCategoryApp, Message: line}
+
+	// Retirer l'horodatage ajouté par le paquet log ("2006/01/02 15:04:05 ")
+	if len(line) > 20 && line[4] == '/' && line[7] == '/' && line[13] == ':' {
+		entry.Message = line[20:]
+	}
+
+	lower := strings.ToLower(entry.Message)
+	switch {
+	case strings.Contains(entry.Message, "❌") || strings.Contains(lower, "panic"):
+		entry.Level = logLevelError
+	case strings.Contains(entry.Message, "⚠️") || strings.Contains(lower, "failed"):
+		entry.Level = logLevelWarn
+	}
+
+	for _, marker := range []string{"📨", "📤", "connect", "relay", "server", "session"} {
+		if strings.Contains(entry.Message, marker) || strings.Contains(lower, marker) {
+			entry.Category = logCategoryNetwork
+			break
+		}
+	}
+	return entry
+}
+
+// readLogEntries lit le journal courant et les fichiers tournés, du plus
+// ancien au plus récent
+func readLogEntries(dir string) []logEntry {
+	base := filepath.Join(dir, logFileName)
+	paths := []string{}
+	for i := logKeepFiles; i >= 1; i-- {
+		paths = append(paths, fmt.Sprintf("%s.%d", base, i))
+	}
+	paths = append(paths, base)
+
+	var entries []logEntry
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var entry logEntry
+			if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+				entries = append(entries, entry)
+			}
+		}
+		file.Close()
+	}
+	return entries
+}
+
+// filterLogEntries garde les entrées du niveau minimal, de la catégorie et
+// contenant le texte demandés ("" = tout)
+func filterLogEntries(entries []logEntry, minLevel, category, query string) []logEntry {
+	rank := map[string]int{logLevelInfo: 0, logLevelWarn: 1, logLevelError: 2}
+	query = strings.ToLower(query)
+
+	var kept []logEntry
+	for _, entry := range entries {
+		if minLevel != "" && rank[entry.Level] < rank[minLevel] {
+			continue
+		}
+		if category != "" && entry.Category != category {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(entry.Message), query) {
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	if len(kept) > logViewerLimit {
+		kept = kept[len(kept)-logViewerLimit:]
+	}
+	return kept
+}
+
+func (e logEntry) String() string {
+	return fmt.Sprintf("%s [%s/%s] %s", e.Time.Local().Format("01-02 15:04:05"), e.Level, e.Category, e.Message)
+}
+
+// installLogFile ajoute le fichier journal à la sortie du paquet log
+func (c *Client) installLogFile() {
+	file, err := openLogFile(c.logDir)
+	if err != nil {
+		log.Printf("⚠️ Log file disabled: %v", err)
+		return
+	}
+	log.SetOutput(io.MultiWriter(os.Stderr, clientLogs, file))
+}
+
+// showLogViewer affiche le journal local avec des filtres, pour joindre un
+// extrait utile à un rapport de bug
+func (c *Client) showLogViewer() {
+	all := readLogEntries(c.logDir)
+	shown := all
+
+	list := widget.NewList(
+		func() int { return len(shown) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			item.(*widget.Label).SetText(shown[id].String())
+		},
+	)
+
+	levels := map[string]string{"All levels": "", "Warnings and errors": logLevelWarn, "Errors only": logLevelError}
+	categories := map[string]string{"All events": "", "Network": logCategoryNetwork, "Application": logCategoryApp}
+
+	levelSelect := widget.NewSelect([]string{"All levels", "Warnings and errors", "Errors only"}, nil)
+	categorySelect := widget.NewSelect([]string{"All events", "Network", "Application"}, nil)
+	search := widget.NewEntry()
+	search.SetPlaceHolder("Search…")
+	count := widget.NewLabel("")
+
+	refresh := func() {
+		shown = filterLogEntries(all, levels[levelSelect.Selected], categories[categorySelect.Selected], search.Text)
+		count.SetText(fmt.Sprintf("%d entries", len(shown)))
+		list.Refresh()
+		list.ScrollToBottom()
+	}
+	levelSelect.OnChanged = func(string) { refresh() }
+	categorySelect.OnChanged = func(string) { refresh() }
+	search.OnChanged = func(string) { refresh() }
+	levelSelect.SetSelected("All levels")
+	categorySelect.SetSelected("All events")
+
+	copyBtn := widget.NewButton("Copy shown entries", func() {
+		lines := make([]string, len(shown))
+		for i, entry := range shown {
+			lines[i] = entry.String()
+		}
+		c.window.Clipboard().SetContent(strings.Join(lines, "\n"))
+		count.SetText(fmt.Sprintf("%d entries copied", len(shown)))
+	})
+	reloadBtn := widget.NewButton("Reload", func() {
+		all = readLogEntries(c.logDir)
+		refresh()
+	})
+	backBtn := widget.NewButton("Back", func() {
+		c.showSettings()
+	})
+
+	c.setContent(container.NewBorder(
+		container.NewVBox(
+			widget.NewLabelWithStyle("📜 Logs", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+			container.NewGridWithColumns(3, levelSelect, categorySelect, search),
+		),
+		container.NewVBox(
+			widget.NewLabel("Log files: "+c.logDir),
+			container.NewHBox(count, copyBtn, reloadBtn, backBtn),
+		),
+		nil, nil,
+		list,
+	))
+	refresh()
+}
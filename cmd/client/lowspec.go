@@ -0,0 +1,108 @@
+// cmd/client/lowspec.go
+package main
+
+import (
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2/canvas"
+)
+
+// lowSpecPreference allège le rendu pour les machines modestes: plateau
+// dessiné en plus petit et moins souvent, sans ombres ni animations
+const lowSpecPreference = "low_spec"
+
+// renderQuality règle le coût du rendu du plateau et des animations
+type renderQuality struct {
+	scale     float32       // Fraction de la taille affichée réellement dessinée
+	details   bool          // Ombres et reflets des pions
+	interval  time.Duration // Délai minimal entre deux rendus, 0 = à chaque changement
+	frame     time.Duration // Intervalle entre deux images des animations
+	countdown time.Duration // Rafraîchissement du compte à rebours du tour
+	animate   bool          // Trajets des pions et cérémonie d'ouverture animés
+	scaling   canvas.ImageScale
+}
+
+var (
+	fullQuality = renderQuality{
+		scale:     1,
+		details:   true,
+		frame:     33 * time.Millisecond,
+		countdown: countdownTick,
+		animate:   true,
+		scaling:   canvas.ImageScaleSmooth,
+	}
+	lowQuality = renderQuality{
+		scale:     0.5,
+		interval:  250 * time.Millisecond,
+		frame:     200 * time.Millisecond,
+		countdown: time.Second,
+		scaling:   canvas.ImageScaleFastest,
+	}
+)
+
+// lowSpec indique si le mode économe est activé
+func (c *Client) lowSpec() bool {
+	return c.app.Preferences().Bool(lowSpecPreference)
+}
+
+// renderQuality retourne la qualité de rendu choisie dans les réglages
+func (c *Client) renderQuality() renderQuality {
+	if c.lowSpec() {
+		return lowQuality
+	}
+	return fullQuality
+}
+
+// minBoardPixels est la taille minimale du plateau dessiné en pleine qualité
+const minBoardPixels = 450
+
+// boardPixels retourne la taille en pixels de l'image du plateau; Fyne
+// l'étire à la taille affichée
+func (c *Client) boardPixels() int {
+	size := c.boardSize
+	if size < minBoardPixels {
+		size = minBoardPixels
+	}
+	return int(size * c.renderQuality().scale)
+}
+
+// frameLimiter espace les rendus du plateau. Les demandes trop proches
+// sont regroupées en un seul rendu à la fin de l'intervalle.
+type frameLimiter struct {
+	mu      sync.Mutex
+	last    time.Time
+	pending bool
+}
+
+// allow indique si un rendu peut avoir lieu tout de suite. Sinon, later
+// est programmé une seule fois pour la fin de l'intervalle.
+func (f *frameLimiter) allow(interval time.Duration, later func()) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	wait := interval - time.Since(f.last)
+	if wait <= 0 {
+		f.last = time.Now()
+		return true
+	}
+	if !f.pending {
+		f.pending = true
+		time.AfterFunc(wait, func() {
+			f.mu.Lock()
+			f.pending = false
+			f.mu.Unlock()
+			later()
+		})
+	}
+	return false
+}
+
+// setLowSpec change de mode et redessine le plateau affiché
+func (c *Client) setLowSpec(on bool) {
+	c.app.Preferences().SetBool(lowSpecPreference, on)
+	if c.boardImage != nil {
+		c.boardImage.ScaleMode = c.renderQuality().scaling
+		go c.refreshBoard()
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"image"
 	"image/color"
-	"image/draw"
 	"log"
 	"math"
+	"math/rand"
 	"net"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,8 +24,16 @@ import (
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/obrien-tchaleu/ludo-king-go/internal/client/audio"
 	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/longpoll"
 	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/moves"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/relay"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/ai"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/dice"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/rules"
 )
 
 // ============================================================================
@@ -71,6 +81,14 @@ var boardPath = [PATH_LEN][2]int{
 	{8, 9}, {8, 10}, {8, 11}, {8, 12},
 }
 
+// homeZones est le coin haut gauche de la base (HOME_SIZE cases) de chaque couleur
+var homeZones = map[constants.PlayerColor][2]int{
+	constants.ColorRed:    {0, 0},
+	constants.ColorGreen:  {9, 0},
+	constants.ColorYellow: {9, 9},
+	constants.ColorBlue:   {0, 9},
+}
+
 var homePositions = map[constants.PlayerColor][4][2]int{
 	constants.ColorRed:    {{1, 1}, {4, 1}, {1, 4}, {4, 4}},
 	constants.ColorGreen:  {{10, 1}, {13, 1}, {10, 4}, {13, 4}},
@@ -78,15 +96,25 @@ var homePositions = map[constants.PlayerColor][4][2]int{
 	constants.ColorBlue:   {{1, 10}, {4, 10}, {1, 13}, {4, 13}},
 }
 
-var startIndex = map[constants.PlayerColor]int{
-	constants.ColorRed:    0,
-	constants.ColorGreen:  13,
-	constants.ColorYellow: 26,
-	constants.ColorBlue:   39,
+// homeStretchCells sont les cases du couloir de chaque couleur, de l'entrée
+// vers le centre (positions moteur 52 à 56)
+var homeStretchCells = map[constants.PlayerColor][HOME_STRETCH_LEN][2]int{
+	constants.ColorRed:    {{7, 13}, {7, 12}, {7, 11}, {7, 10}, {7, 9}},
+	constants.ColorGreen:  {{1, 7}, {2, 7}, {3, 7}, {4, 7}, {5, 7}},
+	constants.ColorYellow: {{7, 1}, {7, 2}, {7, 3}, {7, 4}, {7, 5}},
+	constants.ColorBlue:   {{13, 7}, {12, 7}, {11, 7}, {10, 7}, {9, 7}},
 }
 
-var safeCells = map[int]bool{
-	1: true, 9: true, 14: true, 22: true, 27: true, 35: true, 40: true, 48: true,
+// safeCells reprend les cases sûres du moteur, indexées par position
+var safeCells = positionSet(constants.SafePositions)
+
+// positionSet indexe une liste de positions du parcours
+func positionSet(positions []int) map[int]bool {
+	set := make(map[int]bool, len(positions))
+	for _, position := range positions {
+		set[position] = true
+	}
+	return set
 }
 
 // ============================================================================
@@ -99,12 +127,25 @@ type Client struct {
 	conn          net.Conn
 	user          *models.User
 	gameState     *models.Game
+	awaitingSync  bool // État complet redemandé après un écart de somme de contrôle
 	mainMenu      *fyne.Container
 	gameBoard     *fyne.Container
+	confirmBar    *fyne.Container
 	boardImage    *canvas.Image
+	scene         *boardScene // Repères et pions posés sur boardImage
 	diceButton    *widget.Button
 	diceDisplay   *canvas.Text
 	diceValue     *canvas.Text
+	turnCountdown *canvas.Text  // Temps restant au joueur courant
+	countdownStop func()        // Arrête le compte à rebours en cours
+	matchDialog   dialog.Dialog // Recherche ou proposition de partie affichée
+	// passwordRetry renvoie la dernière demande d'entrée dans une salle
+	// avec le mot de passe saisi, si la salle est privée
+	passwordRetry func(password string)
+	// transfer est la salle déplacée vers une autre instance, suivie à la
+	// fermeture de la connexion actuelle
+	transfer      *models.TransferPayload
+	rivalries     map[int64]*models.Rivalry // Bilan contre les adversaires du lobby, nil = jamais affronté
 	statusLabel   *widget.Label
 	playersList   *widget.List
 	send          chan *models.NetworkMessage
@@ -112,12 +153,48 @@ type Client struct {
 	done          chan bool
 	currentDice   int
 	isMyTurn      bool
+	pauseButton   *widget.Button // Pause de l'hôte, nil si le joueur ne peut pas la demander
+	paused        bool           // Partie mise en pause par l'hôte
+	pausedDice    bool           // Le dé était actif au moment de la pause
 	boardSize     float32
+	frames        frameLimiter // Espace les rendus en mode économe
+	anims         animClock    // Horloge commune des animations
+	glides        map[*models.Token][]*glide
+	glideQueue    time.Time
 	mu            sync.Mutex
-	rollCount     int
-	selectedToken *SelectedToken // Pion sélectionné
+	diceRand      *rand.Rand
+	diceProfiles  map[constants.PlayerColor]dice.Profile // Dé de chaque joueur en mode IA
+	selectedToken *SelectedToken                         // Pion sélectionné
+	premove       *SelectedToken                         // Pion présélectionné pendant le tour adverse
+	spinning      bool                                   // Sélecteur du dé à viser en cours
+	spinStart     time.Time
+	spinCommit    string        // Engagement du serveur pour le sélecteur en cours
+	debug         *debugConsole // Console développeur (Ctrl+Shift+D)
+	chatKey       []byte        // Clé du chat chiffré, dérivée du mot de passe
+	chatPassword  string        // Mot de passe saisi à la création, avant le code
+	chatMessages  []string
+	chatList      *widget.List
+	crashDir      string                     // Rapports de plantage locaux
+	pendingCrash  *models.CrashReportPayload // Rapport accepté, envoyé à la connexion
 	connected     bool
 	serverAddress string
+	relayAddress  string                                // Relais de secours si la connexion directe échoue
+	httpFallback  string                                // URL du transport HTTP si TCP est bloqué, vide = déduite du serveur
+	sessionToken  string                                // Jeton de reconnexion, renouvelé à chaque reprise
+	authToken     string                                // Jeton d'authentification du compte connecté
+	profileStore  *ai.ProfileStore                      // Profils persistants des IA
+	aiProfiles    map[constants.PlayerColor]*ai.Profile // Profil de chaque IA de la partie
+	aiBots        map[constants.PlayerColor]ai.Bot      // IA du catalogue assise à chaque couleur
+	audio         *audio.Manager
+	theme         themeState      // Pack de ressources actif (thème saisonnier)
+	party         partyState      // Décor et musique du lobby à thème
+	banner        *fyne.Container // Annonce du serveur, affichée sur tous les écrans
+	bannerID      int64
+	logDir        string           // Journal local à rotation
+	leaderboard   *leaderboardView // Classement affiché, nil ailleurs
+	roomBrowser   *roomBrowserView // Liste des salles affichée, nil ailleurs
+	narration     narrationLog     // Événements décrits pour les lecteurs d'écran
+	attract       attractState     // Démonstration du menu principal
 }
 
 // SelectedToken représente un pion sélectionné
@@ -131,6 +208,8 @@ type SelectedToken struct {
 // ============================================================================
 
 func main() {
+	installCrashLog()
+
 	myApp := app.NewWithID("com.ludoking.game")
 	myApp.Settings().SetTheme(&LudoTheme{})
 	client := &Client{
@@ -139,13 +218,28 @@ func main() {
 		send:      make(chan *models.NetworkMessage, 256),
 		receive:   make(chan *models.NetworkMessage, 256),
 		done:      make(chan bool),
-		rollCount: 0,
+		diceRand:  rand.New(dice.New().Source()),
 		connected: false,
+		profileStore: ai.NewProfileStore(
+			filepath.Join(myApp.Storage().RootURI().Path(), "ai_profiles"),
+		),
+		crashDir: filepath.Join(myApp.Storage().RootURI().Path(), "crashes"),
+		audio:    audio.NewManager(),
+		banner:   container.NewVBox(),
+		logDir:   clientLogDir(myApp.Storage().RootURI().Path()),
 	}
+	defer client.recoverCrash()
+	client.installLogFile()
+	client.applyThemePack()
+	client.loadEventSounds()
+	client.loadMusic()
 
 	client.window.Resize(fyne.NewSize(1280, 800))
 	client.window.CenterOnScreen()
+	client.installDebugConsole()
+	client.installCoordinateOverlay()
 	client.showMainMenu()
+	client.offerCrashReport()
 	client.window.ShowAndRun()
 }
 
@@ -154,6 +248,9 @@ func main() {
 // ============================================================================
 
 func (c *Client) showMainMenu() {
+	c.stopPartyMusic()
+	c.playMusicFor(audio.PlaylistMenu)
+
 	title := canvas.NewText("LUDO KING", color.White)
 	title.TextSize = 48
 	title.Alignment = fyne.TextAlignCenter
@@ -174,6 +271,10 @@ func (c *Client) showMainMenu() {
 		c.showAISetup()
 	})
 
+	passAndPlayBtn := widget.NewButton("👪 Pass & Play", func() {
+		c.showHotSeatSetup()
+	})
+
 	settingsBtn := widget.NewButton("⚙️ Settings", func() {
 		c.showSettings()
 	})
@@ -182,6 +283,10 @@ func (c *Client) showMainMenu() {
 		c.showLeaderboard()
 	})
 
+	replaysBtn := widget.NewButton("🎞 Replays", func() {
+		c.showReplays()
+	})
+
 	quitBtn := widget.NewButton("Exit", func() {
 		c.window.Close()
 	})
@@ -190,7 +295,9 @@ func (c *Client) showMainMenu() {
 		playOnlineBtn,
 		playWithFriendsBtn,
 		playVsAIBtn,
+		passAndPlayBtn,
 		leaderboardBtn,
+		replaysBtn,
 		settingsBtn,
 		quitBtn,
 	)
@@ -207,7 +314,8 @@ func (c *Client) showMainMenu() {
 		container.NewCenter(buttonsContainer),
 	)
 
-	c.window.SetContent(c.mainMenu)
+	c.setContent(c.mainMenu)
+	c.scheduleAttract()
 }
 
 // ============================================================================
@@ -216,19 +324,60 @@ func (c *Client) showMainMenu() {
 
 func (c *Client) showServerConnect() {
 	serverEntry := widget.NewEntry()
-	serverEntry.SetPlaceHolder("Server address")
+	serverEntry.SetPlaceHolder("host:port, [ipv6]:port")
 	serverEntry.SetText("localhost:8080")
 
+	relayEntry := widget.NewEntry()
+	relayEntry.SetPlaceHolder("Relay host:port (optional)")
+	relayEntry.SetText(c.relayAddress)
+
+	httpEntry := widget.NewEntry()
+	httpEntry.SetPlaceHolder("HTTP fallback URL (optional)")
+	httpEntry.SetText(c.httpFallback)
+
 	usernameEntry := widget.NewEntry()
 	usernameEntry.SetPlaceHolder("Username")
-	usernameEntry.SetText(fmt.Sprintf("Player%d", time.Now().Unix()%1000))
 
-	connectBtn := widget.NewButton("Connect", func() {
-		server := serverEntry.Text
+	passwordEntry := widget.NewPasswordEntry()
+	passwordEntry.SetPlaceHolder("Password")
+
+	emailEntry := widget.NewEntry()
+	emailEntry.SetPlaceHolder("Email (new accounts only)")
+
+	connect := func(register bool) {
 		username := usernameEntry.Text
 
-		if username == "" {
-			dialog.ShowError(fmt.Errorf("please enter username"), c.window)
+		if username == "" || passwordEntry.Text == "" {
+			dialog.ShowError(fmt.Errorf("please enter username and password"), c.window)
+			return
+		}
+		if register && emailEntry.Text == "" {
+			dialog.ShowError(fmt.Errorf("please enter an email to create an account"), c.window)
+			return
+		}
+
+		server, err := protocol.NormalizeAddress(serverEntry.Text, constants.DefaultServerPort)
+		if err != nil {
+			dialog.ShowError(err, c.window)
+			return
+		}
+
+		c.relayAddress = ""
+		if strings.TrimSpace(relayEntry.Text) != "" {
+			c.relayAddress, err = protocol.NormalizeAddress(relayEntry.Text, relay.DefaultPort)
+			if err != nil {
+				dialog.ShowError(err, c.window)
+				return
+			}
+		}
+
+		c.httpFallback = strings.TrimSpace(httpEntry.Text)
+
+		credentials := c.credentialsMessage(register, username, emailEntry.Text, passwordEntry.Text)
+
+		// Déjà connecté à ce serveur (mot de passe refusé): réessayer directement
+		if c.connected && c.serverAddress == server {
+			c.send <- credentials
 			return
 		}
 
@@ -236,30 +385,30 @@ func (c *Client) showServerConnect() {
 		progress := dialog.NewInformation("Connecting", "Connecting to server...", c.window)
 		progress.Show()
 
-		// Connexion dans une goroutine
+		// Connexion dans une goroutine, la réponse AUTHENTICATED ouvre le menu
 		go func() {
-			err := c.connectToServer(server, username)
+			err := c.connectToServer(server, credentials)
 
 			fyne.Do(func() {
 				progress.Hide()
 
 				if err != nil {
 					dialog.ShowError(
-						fmt.Errorf("Connection failed: %v\n\nMake sure the server is running:\ngo run cmd/server/main.go", err),
-						c.window,
-					)
-				} else {
-					dialog.ShowInformation(
-						"Connected",
-						fmt.Sprintf("✅ Connected as %s!", username),
+						fmt.Errorf("Connection failed: %v\n\nMake sure the server is running:\ngo run ./cmd/server", err),
 						c.window,
 					)
-					c.showFriendsMenu()
 				}
 			})
 		}()
-	})
+	}
+
+	connectBtn := widget.NewButton("Log in", func() { connect(false) })
 	connectBtn.Importance = widget.HighImportance
+	registerBtn := widget.NewButton("Create account", func() { connect(true) })
+
+	lanBtn := widget.NewButton("🔍 Find LAN servers", func() {
+		c.showLANServers(serverEntry)
+	})
 
 	backBtn := widget.NewButton("Back", func() {
 		c.showMainMenu()
@@ -270,49 +419,122 @@ func (c *Client) showServerConnect() {
 		widget.NewSeparator(),
 		widget.NewLabel("Server Address:"),
 		serverEntry,
-		widget.NewLabel("Username:"),
+		lanBtn,
+		relayEntry,
+		httpEntry,
+		widget.NewLabel("Account:"),
 		usernameEntry,
+		passwordEntry,
+		emailEntry,
 		widget.NewSeparator(),
 		connectBtn,
+		registerBtn,
 		backBtn,
 	)
 
-	c.window.SetContent(container.NewCenter(form))
+	c.setContent(container.NewCenter(form))
 }
 
-func (c *Client) connectToServer(address, username string) error {
-	conn, err := net.DialTimeout("tcp", address, 5*time.Second)
+// connectToServer ouvre la connexion puis s'authentifie avec credentials
+// (LOGIN ou REGISTER). c.user n'est connu qu'à la réponse AUTHENTICATED.
+func (c *Client) connectToServer(address string, credentials *models.NetworkMessage) error {
+	conn, err := c.dial(address)
+	if err != nil && c.relayAddress != "" {
+		// Serveur injoignable directement: passer par le relais configuré
+		log.Printf("Direct connection failed (%v), trying relay %s", err, c.relayAddress)
+		conn, err = relay.DialVia(c.dial, c.relayAddress, relayName(address))
+	}
+	if err != nil {
+		// Port du jeu bloqué (réseau d'entreprise): mêmes messages par HTTP
+		url := c.httpFallbackURL(address)
+		log.Printf("TCP connection failed (%v), trying HTTP transport %s", err, url)
+		conn, err = longpoll.Dial(url, c.httpClient(longpoll.PollWait+10*time.Second))
+	}
 	if err != nil {
 		return fmt.Errorf("failed to connect: %w", err)
 	}
 
 	c.conn = conn
-	c.serverAddress = address
-	c.user = &models.User{
-		ID:       time.Now().Unix(),
-		Username: username,
+	resume := c.sessionToken != "" && c.serverAddress == address
+	if resume && c.authToken != "" {
+		// Reprendre la place du compte déjà vérifié sur ce serveur
+		credentials = c.tokenLogin()
 	}
+	c.serverAddress = address
 
-	// Démarrer les goroutines de communication
-	go c.readMessages()
-	go c.writeMessages()
+	// Démarrer les goroutines de communication. closed arrête l'écriture
+	// à la coupure, pour qu'une reconnexion ne perde aucun message.
+	closed := make(chan struct{})
+	go c.readMessages(conn, closed)
+	go c.writeMessages(conn, closed)
 	go c.processMessages()
 
 	c.connected = true
-	log.Printf("✅ Connected to server %s as %s", address, username)
+	log.Printf("✅ Connected to server %s", address)
+	c.sendHello()
+	c.send <- credentials
+
+	// Rapport de plantage accepté avant la connexion
+	c.submitCrashReport()
+
+	// Reprendre la place occupée avant la coupure
+	if resume {
+		c.send <- &models.NetworkMessage{
+			Type:      constants.MsgResume,
+			Payload:   models.SessionTokenPayload{Token: c.sessionToken},
+			Timestamp: time.Now(),
+		}
+	}
 
 	return nil
 }
 
-func (c *Client) readMessages() {
-	decoder := json.NewDecoder(c.conn)
+// httpFallbackURL retourne l'URL du transport HTTP: celle saisie, sinon le
+// port par défaut du transport sur l'hôte du serveur
+func (c *Client) httpFallbackURL(address string) string {
+	if c.httpFallback != "" {
+		if !strings.Contains(c.httpFallback, "://") {
+			return "http://" + c.httpFallback
+		}
+		return c.httpFallback
+	}
+	return "http://" + net.JoinHostPort(relayName(address), longpoll.DefaultPort)
+}
+
+// relayName retrouve le nom d'enregistrement du serveur: l'hôte saisi, sans le port
+func relayName(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return host
+}
+
+func (c *Client) readMessages(conn net.Conn, closed chan struct{}) {
+	defer c.recoverCrash()
+	in := protocol.NewSerializer(conn, nil)
 	for {
 		var msg models.NetworkMessage
-		if err := decoder.Decode(&msg); err != nil {
+		if err := in.Decode(&msg); err != nil {
+			close(closed)
 			if c.connected {
 				log.Printf("❌ Connection lost: %v", err)
 				c.connected = false
 
+				// Mise à jour du serveur: suivre la salle sur l'autre instance
+				if transfer := c.takeTransfer(); transfer != nil {
+					c.done <- true
+					go c.followTransfer(transfer)
+					return
+				}
+
+				// Partie en cours: reprendre la place tenue par le serveur
+				if c.canResume() {
+					c.done <- true
+					go c.reconnect()
+					return
+				}
+
 				fyne.Do(func() {
 					dialog.ShowError(
 						fmt.Errorf("Connection to server lost"),
@@ -326,22 +548,41 @@ func (c *Client) readMessages() {
 		}
 
 		log.Printf("📨 Received: %s", msg.Type)
+		c.debug.recordMessage("◀", &msg)
+
+		// Les messages qui suivent le WELCOME arrivent dans le codec négocié
+		if codec := welcomeCodec(&msg); codec != "" {
+			next, err := protocol.SwitchDecoder(in, codec)
+			if err != nil {
+				log.Printf("❌ Cannot read codec %s: %v", codec, err)
+			} else {
+				in = next
+				log.Printf("📦 Server messages now use %s", codec)
+			}
+		}
 		c.receive <- &msg
 	}
 }
 
-func (c *Client) writeMessages() {
-	encoder := json.NewEncoder(c.conn)
-	for msg := range c.send {
-		if err := encoder.Encode(msg); err != nil {
-			log.Printf("❌ Failed to send: %v", err)
+func (c *Client) writeMessages(conn net.Conn, closed <-chan struct{}) {
+	encoder := json.NewEncoder(conn)
+	for {
+		select {
+		case msg := <-c.send:
+			if err := encoder.Encode(protocol.Stamp(msg)); err != nil {
+				log.Printf("❌ Failed to send: %v", err)
+				return
+			}
+			log.Printf("📤 Sent: %s", msg.Type)
+			c.debug.recordMessage("▶", msg)
+		case <-closed:
 			return
 		}
-		log.Printf("📤 Sent: %s", msg.Type)
 	}
 }
 
 func (c *Client) processMessages() {
+	defer c.recoverCrash()
 	for {
 		select {
 		case msg := <-c.receive:
@@ -353,39 +594,316 @@ func (c *Client) processMessages() {
 }
 
 func (c *Client) handleServerMessage(msg *models.NetworkMessage) {
+	// Un message illisible est ignoré plutôt que de faire planter l'interface
+	if err := protocol.DecodePayload(msg); err != nil {
+		log.Printf("❌ Dropped %s: %v", msg.Type, err)
+		return
+	}
+
 	switch msg.Type {
 	case constants.MsgRoomCreated:
 		c.handleRoomCreated(msg)
+	case constants.MsgAnnouncement:
+		c.handleAnnouncement(msg)
+	case constants.MsgWelcome:
+		c.handleWelcome(msg)
+	case constants.MsgAuthenticated:
+		c.handleAuthenticated(msg)
 	case constants.MsgRoomJoined:
 		c.handleRoomJoined(msg)
 	case constants.MsgPlayerJoined:
 		c.handlePlayerJoined(msg)
 	case constants.MsgGameStart:
 		c.handleGameStart(msg)
+	case constants.MsgRollOff:
+		c.handleRollOff(msg)
 	case constants.MsgDiceRolled:
 		c.handleDiceRolled(msg)
 	case constants.MsgTokenMoved:
 		c.handleTokenMoved(msg)
+	case constants.MsgTokenCaptured:
+		c.handleTokenCaptured(msg)
 	case constants.MsgTurnChanged:
 		c.handleTurnChanged(msg)
+	case constants.MsgTurnTimer:
+		c.handleTurnTimer(msg)
+	case constants.MsgGamePaused:
+		c.handleGamePaused(msg)
+	case constants.MsgMoveUndone:
+		c.handleMoveUndone(msg)
+	case constants.MsgGameResumed:
+		c.handleGameResumed(msg)
+	case constants.MsgTurnTimedOut:
+		c.handleTurnTimedOut(msg)
+	case constants.MsgEventBatch:
+		c.handleEventBatch(msg)
+	case constants.MsgSessionToken:
+		c.handleSessionToken(msg)
+	case constants.MsgAbortVotes:
+		c.handleAbortVotes(msg)
+	case constants.MsgGameOver:
+		c.handleGameOver(msg)
+	case constants.MsgSpinStarted:
+		c.handleSpinStarted(msg)
+	case constants.MsgSpinResult:
+		c.handleSpinResult(msg)
+	case constants.MsgProfile:
+		c.handleProfile(msg)
+	case constants.MsgRivalries:
+		c.handleRivalries(msg)
+	case constants.MsgRatingHistory:
+		c.handleRatingHistory(msg)
+	case constants.MsgDataExportReady:
+		c.handleDataExportReady(msg)
+	case constants.MsgDataExport:
+		c.handleDataExport(msg)
+	case constants.MsgLeaderboard:
+		c.handleLeaderboard(msg)
+	case constants.MsgGameState:
+		c.handleGameState(msg)
+	case constants.MsgStateDelta:
+		c.handleStateDelta(msg)
+	case constants.MsgRoomList:
+		c.handleRoomList(msg)
+	case constants.MsgKicked:
+		c.handleKicked(msg)
+	case constants.MsgTransfer:
+		c.handleTransfer(msg)
+	case constants.MsgMatchFound:
+		c.handleMatchFound(msg)
+	case constants.MsgMatchCancelled:
+		c.handleMatchCancelled(msg)
+	case constants.MsgChatMessage:
+		c.handleChatMessage(msg)
 	case constants.MsgError:
 		c.handleError(msg)
 	}
 }
 
+// handleGameState remplace l'état local par celui du serveur
+func (c *Client) handleGameState(msg *models.NetworkMessage) {
+	var payload models.GameStatePayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil || payload.Game == nil {
+		log.Printf("❌ Invalid game state: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	c.gameState = payload.Game
+	c.awaitingSync = false
+	// L'état final envoyé après la fin de partie contient tout l'historique
+	if payload.Game.Room != nil && payload.Game.Room.State == constants.StateFinished {
+		c.saveReplay()
+	}
+	waiting := payload.Game.Room != nil && payload.Game.Room.State == constants.StateWaiting
+	playing := payload.Game.Room != nil && payload.Game.Room.State == constants.StatePlaying
+	c.mu.Unlock()
+
+	// Reprise après une coupure: réafficher la partie là où elle en est
+	if payload.Resync && playing {
+		fyne.Do(func() {
+			c.resumeGame(payload.AwaitingMove)
+			c.startCountdown(time.Duration(payload.TurnRemainingMs) * time.Millisecond)
+			if payload.Paused {
+				c.stopCountdown()
+				c.showPause(true, time.Time{})
+			}
+		})
+		return
+	}
+
+	// Salle rejointe ou revanche: retour au lobby
+	if waiting {
+		fyne.Do(c.showLobby)
+		return
+	}
+
+	if c.boardImage != nil {
+		c.refreshBoard()
+	}
+	// Spectateur arrivé en cours de tour
+	if playing && payload.TurnRemainingMs > 0 {
+		c.startCountdown(time.Duration(payload.TurnRemainingMs) * time.Millisecond)
+	}
+}
+
+// handleSessionToken mémorise le dernier jeton de reconnexion
+func (c *Client) handleSessionToken(msg *models.NetworkMessage) {
+	var payload models.SessionTokenPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid session token: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	c.sessionToken = payload.Token
+	c.mu.Unlock()
+}
+
+// handleEventBatch rejoue un lot d'événements reçu en tant que spectateur
+func (c *Client) handleEventBatch(msg *models.NetworkMessage) {
+	var batch models.EventBatchPayload
+	if err := protocol.ExtractPayload(msg.Payload, &batch); err != nil {
+		log.Printf("❌ Invalid event batch: %v", err)
+		return
+	}
+
+	for _, event := range batch.Events {
+		c.handleServerMessage(event)
+	}
+}
+
+// handleAbortVotes affiche l'avancement du vote d'abandon
+func (c *Client) handleAbortVotes(msg *models.NetworkMessage) {
+	var payload models.AbortVotesPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid abort votes: %v", err)
+		return
+	}
+
+	fyne.Do(func() {
+		c.statusLabel.SetText(fmt.Sprintf("🏳 Abort vote: %d/%d", len(payload.Votes), payload.Needed))
+	})
+}
+
+// handleGameOver affiche le résultat d'une partie en ligne
+func (c *Client) handleGameOver(msg *models.NetworkMessage) {
+	var payload models.GameOverPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid game over: %v", err)
+		return
+	}
+	c.forgetRivalries()
+
+	// Les spectateurs n'entendent ni victoire ni défaite
+	if payload.Winner != nil && c.user != nil {
+		for _, player := range payload.Rankings {
+			switch {
+			case player.ID != c.user.ID:
+			case player.ID == payload.Winner.ID:
+				c.playEvent(audio.EventVictory)
+			default:
+				c.playEvent(audio.EventDefeat)
+			}
+		}
+	}
+
+	var text string
+	switch {
+	case payload.Reason == constants.GameOverAborted:
+		text = "🏳 Game aborted by all players.\nNo win or loss recorded."
+	case payload.Reason == constants.GameOverStalemate && payload.Winner == nil:
+		text = "🤝 Stalemate: no one can move.\nThe game ends in a draw."
+	case payload.Reason == constants.GameOverStalemate:
+		text = fmt.Sprintf("🤝 Stalemate: no one can move.\n🏆 %s wins with the most tokens home!", payload.Winner.Username)
+	case payload.Winner != nil:
+		text = fmt.Sprintf("🏆 %s wins!", payload.Winner.Username)
+	default:
+		text = "Game over."
+	}
+	switch {
+	case payload.Reason == constants.GameOverAborted:
+		c.narrate("Game over: aborted by all players.")
+	case payload.Winner == nil:
+		c.narrate("Game over: draw.")
+	case c.user != nil && payload.Winner.ID == c.user.ID:
+		c.narrate("Game over: you won!")
+	default:
+		c.narrate("Game over: %s won.", c.spokenPlayer(payload.Winner))
+	}
+	if payload.Fastest != nil {
+		text += fmt.Sprintf("\n\n⚡ Fastest player: %s (%.1fs per move)",
+			payload.Fastest.Username, payload.Fastest.AvgDecision().Seconds())
+	}
+
+	// Points des variantes à score
+	scores := ""
+	for _, player := range payload.Rankings {
+		if player.Score != 0 {
+			scores += fmt.Sprintf("\n%s: %d pts", player.Username, player.Score)
+		}
+	}
+	if scores != "" {
+		text += "\n\n📜 Variant scores:" + scores
+	}
+
+	c.mu.Lock()
+	var players []*models.Player
+	if c.gameState != nil && c.gameState.Room != nil {
+		players = c.gameState.Room.Players
+	}
+	c.mu.Unlock()
+	if series := seriesText(payload.GameNumber, payload.Series, players); series != "" {
+		text += "\n\n" + series
+	}
+
+	// Match en cours: la partie suivante s'enchaîne toute seule
+	if payload.Match != nil {
+		text += "\n\n" + matchText(payload.Match, players)
+		if payload.Match.WinnerID == 0 {
+			fyne.Do(func() {
+				dialog.ShowInformation("Game Over", text+"\n\n⏳ Next game starts in a few seconds…", c.window)
+			})
+			return
+		}
+	}
+
+	fyne.Do(func() {
+		dialog.ShowCustomConfirm("Game Over", "🔁 Rematch", "Leave", widget.NewLabel(text), func(rematch bool) {
+			if rematch {
+				c.requestRematch()
+			} else {
+				c.leaveRoom()
+			}
+		}, c.window)
+	})
+}
+
+// voteAbort propose d'abandonner la partie en ligne en cours
+func (c *Client) voteAbort() {
+	dialog.ShowConfirm("Abort Game", "Vote to abandon this game?\nIt ends only if every player agrees.", func(ok bool) {
+		if !ok {
+			return
+		}
+		c.send <- &models.NetworkMessage{
+			Type:      constants.MsgVoteAbort,
+			Timestamp: time.Now(),
+		}
+	}, c.window)
+}
+
 func (c *Client) handleRoomCreated(msg *models.NetworkMessage) {
-	payload := msg.Payload.(map[string]interface{})
-	roomID := payload["room_id"].(string)
+	var payload models.RoomCreatedPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid room created: %v", err)
+		return
+	}
+	roomID := payload.RoomID
 
 	log.Printf("✅ Room created: %s", roomID)
 
+	// La clé du chat dépend du code attribué par le serveur
+	c.mu.Lock()
+	password := c.chatPassword
+	c.chatPassword = ""
+	c.mu.Unlock()
+	c.setChatPassword(password, roomID)
+
+	text := fmt.Sprintf("🔑 Room Code: %s\n\nShare this code with your friends!", roomID)
+	// Adresse publique obtenue par redirection automatique sur le routeur
+	if payload.PublicAddress != "" {
+		text += fmt.Sprintf("\n\n🌍 Friends outside your network can connect to %s", payload.PublicAddress)
+	}
+
+	if payload.Room != nil {
+		c.mu.Lock()
+		c.gameState = &models.Game{Room: payload.Room}
+		c.mu.Unlock()
+	}
+
 	fyne.Do(func() {
-		dialog.ShowInformation(
-			"Room Created",
-			fmt.Sprintf("🔑 Room Code: %s\n\nShare this code with your friends!", roomID),
-			c.window,
-		)
-		// TODO: Afficher le lobby en attente
+		c.showLobby()
+		dialog.ShowInformation("Room Created", text, c.window)
 	})
 }
 
@@ -401,26 +919,43 @@ func (c *Client) handleRoomJoined(msg *models.NetworkMessage) {
 	})
 }
 
-func (c *Client) handlePlayerJoined(msg *models.NetworkMessage) {
-	log.Printf("👤 Player joined")
-	// Rafraîchir la liste des joueurs
-}
-
 func (c *Client) handleGameStart(msg *models.NetworkMessage) {
 	log.Printf("🎮 Game starting!")
 
+	// Partie fraîche (revanche ou partie suivante d'un match)
+	var payload models.GameStatePayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err == nil && payload.Game != nil {
+		c.mu.Lock()
+		c.gameState = payload.Game
+		c.mu.Unlock()
+	}
+
 	fyne.Do(func() {
+		c.closeMatchDialog()
 		c.showGameBoard()
 	})
 }
 
 func (c *Client) handleDiceRolled(msg *models.NetworkMessage) {
-	payload := msg.Payload.(map[string]interface{})
-	diceValue := int(payload["dice_value"].(float64))
+	var payload models.DiceRolledPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid dice roll: %v", err)
+		return
+	}
+	diceValue, playerID := payload.DiceValue, payload.PlayerID
+
+	c.playEvent(audio.EventDiceRoll)
 
 	c.mu.Lock()
 	c.currentDice = diceValue
+	roller := c.roomPlayer(playerID)
+	if playerID == c.user.ID {
+		if player, index := c.myPlayer(); player != nil {
+			c.playPremove(player, index)
+		}
+	}
 	c.mu.Unlock()
+	c.narrateRoll(roller, diceValue)
 
 	fyne.Do(func() {
 		c.diceValue.Text = fmt.Sprintf("%d", diceValue)
@@ -431,6 +966,21 @@ func (c *Client) handleDiceRolled(msg *models.NetworkMessage) {
 
 func (c *Client) handleTokenMoved(msg *models.NetworkMessage) {
 	log.Printf("🎯 Token moved")
+	c.playEvent(audio.EventTokenMove)
+
+	// Suivre le pion case par case, sauf s'il a déjà été déplacé ici
+	var payload models.TokenMovedPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err == nil {
+		c.mu.Lock()
+		if token := c.roomToken(payload.PlayerID, payload.TokenID); token != nil && token.Position != payload.ToPos {
+			token.Position = payload.ToPos
+			token.IsHome = payload.ToPos == moves.Home
+			c.startGlide(token, payload.FromPos, glideDice(token.Color, payload.FromPos, payload.ToPos))
+		}
+		mover := c.roomPlayer(payload.PlayerID)
+		c.mu.Unlock()
+		c.narrateMove(mover, payload.TokenID, payload.FromPos, payload.ToPos)
+	}
 
 	fyne.Do(func() {
 		c.refreshBoard()
@@ -438,16 +988,29 @@ func (c *Client) handleTokenMoved(msg *models.NetworkMessage) {
 }
 
 func (c *Client) handleTurnChanged(msg *models.NetworkMessage) {
-	payload := msg.Payload.(map[string]interface{})
-	playerID := int64(payload["player_id"].(float64))
+	var payload models.TurnChangedPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid turn change: %v", err)
+		return
+	}
+	playerID := payload.PlayerID
 
 	c.mu.Lock()
 	c.isMyTurn = (playerID == c.user.ID)
 	c.currentDice = 0
 	c.selectedToken = nil
+	current := c.roomPlayer(playerID)
 	c.mu.Unlock()
+	c.narrateTurn(current)
+
+	// Le serveur relance le compte à rebours si le nouveau joueur est humain
+	c.stopCountdown()
+	if c.isMyTurn {
+		c.playEvent(audio.EventYourTurn)
+	}
 
 	fyne.Do(func() {
+		c.hideMoveConfirmation()
 		if c.isMyTurn {
 			c.statusLabel.SetText("🎲 Your turn! Roll the dice.")
 			c.diceButton.Enable()
@@ -460,13 +1023,31 @@ func (c *Client) handleTurnChanged(msg *models.NetworkMessage) {
 }
 
 func (c *Client) handleError(msg *models.NetworkMessage) {
-	payload := msg.Payload.(models.ErrorPayload)
+	var payload models.ErrorPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid error payload: %v", err)
+		return
+	}
+
+	log.Printf("❌ Server error: %s %v", payload.Code, payload.Params)
+	message := c.translate(payload.Code, payload.Params, payload.Message)
+	c.mu.Lock()
+	inGame := c.gameState != nil
+	c.mu.Unlock()
+	if hint := c.ruleHint(payload.Code); hint != "" && inGame {
+		message += "\n\n" + hint
+	}
 
-	log.Printf("❌ Server error: %s", payload.Message)
+	if c.askRoomPassword(payload.Code, message) {
+		return
+	}
 
 	fyne.Do(func() {
+		if payload.Code == constants.ErrAlreadyInRoom {
+			c.closeMatchDialog()
+		}
 		dialog.ShowError(
-			fmt.Errorf("Server: %s", payload.Message),
+			fmt.Errorf("Server: %s", message),
 			c.window,
 		)
 	})
@@ -494,50 +1075,70 @@ func (c *Client) showFriendsMenu() {
 		c.showJoinRoomDialog()
 	})
 
+	quickMatchBtn := widget.NewButton("⚡ Quick Match", func() {
+		c.chooseMatchQueue()
+	})
+
+	watchRoomBtn := widget.NewButton("Watch Room", func() {
+		c.showSpectateDialog()
+	})
+
+	browseBtn := widget.NewButton("🌐 Browse Rooms", func() {
+		c.showRoomBrowser()
+	})
+
+	profileBtn := widget.NewButton("📊 My Profile", func() {
+		c.requestProfile()
+	})
+
+	tutorialBtn := widget.NewButton("🎓 Tutorial vs Coach", func() {
+		c.startTutorial()
+	})
+
 	backBtn := widget.NewButton("Back", func() {
 		c.showMainMenu()
 	})
 
+	// Le mode enfant se limite aux salles privées entre amis
+	if c.kidMode() {
+		quickMatchBtn.Hide()
+		browseBtn.Hide()
+		watchRoomBtn.Hide()
+	}
+
 	content := container.NewVBox(
 		title,
 		widget.NewSeparator(),
 		widget.NewLabel("Choose an option:"),
+		quickMatchBtn,
 		createRoomBtn,
+		browseBtn,
 		joinRoomBtn,
+		watchRoomBtn,
+		profileBtn,
+		tutorialBtn,
 		widget.NewSeparator(),
 		backBtn,
 	)
 
-	c.window.SetContent(container.NewCenter(content))
+	c.setContent(container.NewCenter(content))
 }
 
 func (c *Client) showJoinRoomDialog() {
 	roomCodeEntry := widget.NewEntry()
-	roomCodeEntry.SetPlaceHolder("Enter Room Code (ex: ROOM_83985)")
+	roomCodeEntry.SetPlaceHolder("Enter Room Code (ex: K7MQ2X)")
+
+	chatPasswordEntry := widget.NewPasswordEntry()
+	chatPasswordEntry.SetPlaceHolder("Chat password (encrypted rooms only)")
 
 	joinBtn := widget.NewButton("Join", func() {
-		roomCode := roomCodeEntry.Text
+		roomCode := strings.ToUpper(strings.TrimSpace(roomCodeEntry.Text))
 		if roomCode == "" {
 			dialog.ShowError(fmt.Errorf("Please enter a room code"), c.window)
 			return
 		}
 
-		// Envoyer le message de jointure au serveur
-		c.send <- &models.NetworkMessage{
-			Type: constants.MsgJoinRoom,
-			Payload: map[string]interface{}{
-				"room_id":  roomCode,
-				"user_id":  c.user.ID,
-				"username": c.user.Username,
-			},
-			Timestamp: time.Now(),
-		}
-
-		dialog.ShowInformation(
-			"Joining",
-			fmt.Sprintf("Joining room %s...", roomCode),
-			c.window,
-		)
+		c.joinRoom(roomCode, chatPasswordEntry.Text)
 	})
 	joinBtn.Importance = widget.HighImportance
 
@@ -550,74 +1151,223 @@ func (c *Client) showJoinRoomDialog() {
 		widget.NewSeparator(),
 		widget.NewLabel("📝 Enter the room code:"),
 		roomCodeEntry,
+		chatPasswordEntry,
 		widget.NewSeparator(),
 		joinBtn,
 		backBtn,
 	)
 
-	c.window.SetContent(container.NewCenter(form))
+	c.setContent(container.NewCenter(form))
 }
 
-func (c *Client) showRoomCreation() {
-	roomNameEntry := widget.NewEntry()
-	roomNameEntry.SetPlaceHolder("Room Name")
-	roomNameEntry.SetText("Game Room")
+// joinRoom demande à rejoindre une salle par son code
+func (c *Client) joinRoom(roomCode, chatPassword string) {
+	c.setChatPassword(chatPassword, roomCode)
+	c.sendRoomRequest(constants.MsgJoinRoom, roomCode, "")
 
-	maxPlayersSelect := widget.NewSelect([]string{"2", "3", "4"}, func(value string) {})
-	maxPlayersSelect.SetSelected("4")
+	dialog.ShowInformation(
+		"Joining",
+		fmt.Sprintf("Joining room %s...", roomCode),
+		c.window,
+	)
+}
 
-	createBtn := widget.NewButton("Create Room", func() {
-		roomName := roomNameEntry.Text
-		if roomName == "" {
-			roomName = "Game Room"
-		}
+func (c *Client) showSpectateDialog() {
+	roomCodeEntry := widget.NewEntry()
+	roomCodeEntry.SetPlaceHolder("Enter Room Code")
 
-		maxPlayers := 4
-		switch maxPlayersSelect.Selected {
-		case "2":
-			maxPlayers = 2
-		case "3":
-			maxPlayers = 3
+	watchBtn := widget.NewButton("Watch", func() {
+		roomCode := strings.ToUpper(strings.TrimSpace(roomCodeEntry.Text))
+		if roomCode == "" {
+			dialog.ShowError(fmt.Errorf("Please enter a room code"), c.window)
+			return
 		}
 
-		// Envoyer au serveur
-		c.send <- &models.NetworkMessage{
-			Type: constants.MsgCreateRoom,
-			Payload: map[string]interface{}{
-				"name":        roomName,
-				"max_players": maxPlayers,
-				"game_mode":   "online",
-				"is_private":  false,
-				"user_id":     c.user.ID,
-				"username":    c.user.Username,
-			},
-			Timestamp: time.Now(),
-		}
+		c.sendRoomRequest(constants.MsgSpectate, roomCode, "")
 	})
-	createBtn.Importance = widget.HighImportance
+	watchBtn.Importance = widget.HighImportance
 
 	backBtn := widget.NewButton("Back", func() {
 		c.showFriendsMenu()
 	})
 
 	form := container.NewVBox(
-		widget.NewLabelWithStyle("Create New Room", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("Watch Game Room", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
 		widget.NewSeparator(),
-		widget.NewLabel("Room Name:"),
-		roomNameEntry,
-		widget.NewLabel("Max Players:"),
-		maxPlayersSelect,
+		widget.NewLabel("📝 Enter the room code:"),
+		roomCodeEntry,
 		widget.NewSeparator(),
-		createBtn,
+		watchBtn,
 		backBtn,
 	)
 
-	c.window.SetContent(container.NewCenter(form))
+	c.setContent(container.NewCenter(form))
 }
 
-// ============================================================================
-// MODE IA (LOCAL)
-// ============================================================================
+func (c *Client) showRoomCreation() {
+	roomNameEntry := widget.NewEntry()
+	roomNameEntry.SetPlaceHolder("Room Name")
+	roomNameEntry.SetText("Game Room")
+
+	maxPlayersSelect := widget.NewSelect([]string{"2", "3", "4"}, func(value string) {})
+	maxPlayersSelect.SetSelected("4")
+
+	formatSelect := widget.NewSelect([]string{"Single game", "Best of 3", "Best of 5"}, nil)
+	formatSelect.SetSelected("Single game")
+
+	ruleSetSelect := widget.NewSelect([]string{ruleSetClassicLabel, ruleSetQuickLabel}, nil)
+	ruleSetSelect.SetSelected(ruleSetClassicLabel)
+
+	houseRules := newHouseRulesForm()
+
+	skillDiceCheck := widget.NewCheck("🎯 Skill dice: stop a spinning selector (casual)", nil)
+
+	chatPasswordEntry := widget.NewPasswordEntry()
+	chatPasswordEntry.SetPlaceHolder("Chat password (share it with your friends yourself)")
+	chatPasswordEntry.Hide()
+	encryptedChatCheck := widget.NewCheck("🔒 End-to-end encrypted chat", func(on bool) {
+		if on {
+			chatPasswordEntry.Show()
+		} else {
+			chatPasswordEntry.Hide()
+		}
+	})
+
+	roomPasswordEntry := widget.NewPasswordEntry()
+	roomPasswordEntry.SetPlaceHolder("Room password (asked to players and spectators)")
+	roomPasswordEntry.Hide()
+	privateCheck := widget.NewCheck("🔑 Private room (hidden from the browser)", func(on bool) {
+		if on {
+			roomPasswordEntry.Show()
+		} else {
+			roomPasswordEntry.Hide()
+		}
+	})
+	if c.kidMode() {
+		privateCheck.SetChecked(true)
+		privateCheck.Disable()
+	}
+
+	rulesEntry := widget.NewMultiLineEntry()
+	rulesEntry.SetPlaceHolder("can_move: standard || (from_base && dice == 1)\nextra_turn: standard || captures")
+	rulesEntry.SetMinRowsVisible(4)
+	rulesEntry.Hide()
+	rulesCheck := widget.NewCheck("📜 Custom rules (community variant)", func(on bool) {
+		if on {
+			rulesEntry.Show()
+		} else {
+			rulesEntry.Hide()
+		}
+	})
+
+	createBtn := widget.NewButton("Create Room", func() {
+		roomName := roomNameEntry.Text
+		if roomName == "" {
+			roomName = "Game Room"
+		}
+
+		maxPlayers := 4
+		switch maxPlayersSelect.Selected {
+		case "2":
+			maxPlayers = 2
+		case "3":
+			maxPlayers = 3
+		}
+
+		bestOf := 0
+		switch formatSelect.Selected {
+		case "Best of 3":
+			bestOf = 3
+		case "Best of 5":
+			bestOf = 5
+		}
+
+		roomPassword := ""
+		if privateCheck.Checked {
+			roomPassword = roomPasswordEntry.Text
+		}
+
+		if encryptedChatCheck.Checked && chatPasswordEntry.Text == "" {
+			dialog.ShowError(fmt.Errorf("Please choose a chat password"), c.window)
+			return
+		}
+
+		// Vérifier le script ici pour signaler les erreurs avant l'envoi
+		variant := ""
+		if rulesCheck.Checked {
+			if _, err := rules.Compile(rulesEntry.Text); err != nil {
+				dialog.ShowError(fmt.Errorf("Invalid rules: %v", err), c.window)
+				return
+			}
+			variant = rulesEntry.Text
+		}
+
+		// Le mot de passe n'est jamais envoyé: la clé est dérivée à la réception du code
+		c.mu.Lock()
+		c.chatPassword = ""
+		if encryptedChatCheck.Checked {
+			c.chatPassword = chatPasswordEntry.Text
+		}
+		c.mu.Unlock()
+
+		// Envoyer au serveur
+		c.send <- &models.NetworkMessage{
+			Type: constants.MsgCreateRoom,
+			Payload: models.CreateRoomPayload{
+				Name:          roomName,
+				MaxPlayers:    maxPlayers,
+				BestOf:        bestOf,
+				GameMode:      "online",
+				IsPrivate:     privateCheck.Checked,
+				Password:      roomPassword,
+				SkillDice:     skillDiceCheck.Checked,
+				EncryptedChat: encryptedChatCheck.Checked,
+				Rules:         variant,
+				RuleSet:       ruleSetFromLabel(ruleSetSelect.Selected),
+				HouseRules:    houseRules.config(),
+				UserID:        c.user.ID,
+				Username:      c.user.Username,
+			},
+			Timestamp: time.Now(),
+		}
+	})
+	createBtn.Importance = widget.HighImportance
+
+	backBtn := widget.NewButton("Back", func() {
+		c.showFriendsMenu()
+	})
+
+	form := container.NewVBox(
+		widget.NewLabelWithStyle("Create New Room", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewSeparator(),
+		widget.NewLabel("Room Name:"),
+		roomNameEntry,
+		widget.NewLabel("Max Players:"),
+		maxPlayersSelect,
+		widget.NewLabel("Format:"),
+		formatSelect,
+		widget.NewLabel("Game type:"),
+		ruleSetSelect,
+		widget.NewLabel("House rules:"),
+		houseRules.content(),
+		skillDiceCheck,
+		privateCheck,
+		roomPasswordEntry,
+		encryptedChatCheck,
+		chatPasswordEntry,
+		rulesCheck,
+		rulesEntry,
+		widget.NewSeparator(),
+		createBtn,
+		backBtn,
+	)
+
+	c.setContent(container.NewCenter(form))
+}
+
+// ============================================================================
+// MODE IA (LOCAL)
+// ============================================================================
 
 func (c *Client) showAISetup() {
 	if c.user == nil {
@@ -633,6 +1383,22 @@ func (c *Client) showAISetup() {
 	numOpponentsSelect := widget.NewSelect([]string{"1", "2", "3"}, func(value string) {})
 	numOpponentsSelect.SetSelected("1")
 
+	// Le handicap de dé est optionnel et toujours affiché au joueur
+	handicapInfo := widget.NewLabel("")
+	handicapInfo.Wrapping = fyne.TextWrapWord
+	handicapCheck := widget.NewCheck("AI dice handicap", nil)
+	updateHandicapInfo := func() {
+		profile := dice.Fair
+		if handicapCheck.Checked {
+			profile = dice.HandicapForLevel(aiLevelSelect.Selected)
+		}
+		handicapInfo.SetText(fmt.Sprintf("AI rolls a 6 with %.1f%% chance (fair die: %.1f%%)",
+			profile.Probability(6)*100, dice.Fair.Probability(6)*100))
+	}
+	handicapCheck.OnChanged = func(bool) { updateHandicapInfo() }
+	aiLevelSelect.OnChanged = func(string) { updateHandicapInfo() }
+	updateHandicapInfo()
+
 	startBtn := widget.NewButton("Start Game", func() {
 		numOpponents := 1
 		switch numOpponentsSelect.Selected {
@@ -641,7 +1407,7 @@ func (c *Client) showAISetup() {
 		case "3":
 			numOpponents = 3
 		}
-		c.createAIGame(aiLevelSelect.Selected, numOpponents)
+		c.createAIGame(aiLevelSelect.Selected, numOpponents, handicapCheck.Checked)
 	})
 	startBtn.Importance = widget.HighImportance
 
@@ -656,15 +1422,17 @@ func (c *Client) showAISetup() {
 		aiLevelSelect,
 		widget.NewLabel("Number of Opponents:"),
 		numOpponentsSelect,
+		handicapCheck,
+		handicapInfo,
 		widget.NewSeparator(),
 		startBtn,
 		backBtn,
 	)
 
-	c.window.SetContent(container.NewCenter(form))
+	c.setContent(container.NewCenter(form))
 }
 
-func (c *Client) createAIGame(aiLevel string, numOpponents int) {
+func (c *Client) createAIGame(aiLevel string, numOpponents int, handicap bool) {
 	room := &models.Room{
 		ID:          fmt.Sprintf("AI_%d", time.Now().Unix()),
 		Name:        "AI Game",
@@ -680,11 +1448,31 @@ func (c *Client) createAIGame(aiLevel string, numOpponents int) {
 	player := models.NewPlayer(c.user.ID, c.user.Username, constants.ColorRed)
 	room.Players = append(room.Players, player)
 
-	colors := []constants.PlayerColor{constants.ColorBlue, constants.ColorGreen, constants.ColorYellow}
-	for i := 0; i < numOpponents; i++ {
+	aiDice := dice.Fair
+	if handicap {
+		aiDice = dice.HandicapForLevel(aiLevel)
+	}
+	c.diceProfiles = map[constants.PlayerColor]dice.Profile{player.Color: dice.Fair}
+
+	colors := constants.SeatColors(room.MaxPlayers)[1:]
+	c.aiProfiles = make(map[constants.PlayerColor]*ai.Profile)
+	c.aiBots = make(map[constants.PlayerColor]ai.Bot)
+	for i, bot := range ai.DrawBots(numOpponents, aiLevel, c.diceRand) {
 		aiPlayer := models.NewAIPlayer(colors[i], aiLevel)
-		aiPlayer.Username = fmt.Sprintf("AI Bot %d", i+1)
+		aiPlayer.Username = bot.Name
+		aiPlayer.Avatar = bot.Avatar
+		c.aiBots[aiPlayer.Color] = bot
 		room.Players = append(room.Players, aiPlayer)
+		c.diceProfiles[aiPlayer.Color] = aiDice
+
+		// Chaque IA nommée se souvient des parties précédentes contre ce joueur
+		profile, err := c.profileStore.Load(aiPlayer.Username, c.user.Username)
+		if err != nil {
+			log.Printf("⚠️ Failed to load AI profile: %v", err)
+			profile = ai.NewProfile(aiPlayer.Username, c.user.Username)
+		}
+		profile.GamesPlayed++
+		c.aiProfiles[aiPlayer.Color] = profile
 	}
 
 	c.gameState = &models.Game{
@@ -705,6 +1493,8 @@ func (c *Client) showGameBoard() {
 		dialog.ShowError(fmt.Errorf("no game state"), c.window)
 		return
 	}
+	c.stopPartyMusic()
+	c.playMusicFor(audio.PlaylistGame)
 
 	log.Printf("🎮 Starting game board...")
 
@@ -712,20 +1502,21 @@ func (c *Client) showGameBoard() {
 	c.isMyTurn = c.gameState.Room.CurrentTurn == 0
 	c.boardSize = 600
 	c.selectedToken = nil
+	c.premove = nil
 
-	boardPixelSize := int(c.boardSize)
-	rendered := c.renderBoard(boardPixelSize, boardPixelSize)
-	c.boardImage = canvas.NewImageFromImage(rendered)
+	// Fond, zone de clic des cases, repères puis pions: le fond est dessiné
+	// au premier rafraîchissement
+	c.boardImage = canvas.NewImageFromImage(image.NewNRGBA(image.Rect(0, 0, 1, 1)))
+	c.boardImage.ScaleMode = c.renderQuality().scaling
 	c.boardImage.Resize(fyne.NewSize(c.boardSize, c.boardSize))
 	c.boardImage.SetMinSize(fyne.NewSize(c.boardSize, c.boardSize))
-
-	boardContainer := container.NewWithoutLayout(c.boardImage)
-	boardContainer.Resize(fyne.NewSize(c.boardSize, c.boardSize))
+	c.scene = newBoardScene(c.boardSize)
 
 	boardTapHandler := NewTappableRect(c.boardSize, func(pos fyne.Position) {
 		c.onBoardTapped(pos)
 	})
-	boardContainer.Add(boardTapHandler)
+	boardContainer := container.NewWithoutLayout(c.boardImage, boardTapHandler, c.scene.marks, c.scene.pawns)
+	boardContainer.Resize(fyne.NewSize(c.boardSize, c.boardSize))
 
 	c.diceDisplay = canvas.NewText("🎲", color.White)
 	c.diceDisplay.TextSize = 64
@@ -744,6 +1535,7 @@ func (c *Client) showGameBoard() {
 				widget.NewLabelWithStyle("🎲 Dice", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
 				container.NewCenter(c.diceDisplay),
 				container.NewCenter(c.diceValue),
+				container.NewCenter(c.newTurnCountdown()),
 			),
 		),
 	)
@@ -771,9 +1563,19 @@ func (c *Client) showGameBoard() {
 		c.playersList,
 		widget.NewSeparator(),
 		widget.NewLabelWithStyle("💡 Rules", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-		widget.NewLabel("• Roll 6 to move out\n• Click pawn to select (yellow)\n• Click again to move\n• Exact number to finish"),
+		widget.NewLabel("• Roll 6 to move out\n• Click pawn to select (yellow), pawns are numbered 1-4\n• Click again to move\n• Click a pawn during another turn to premove it\n• Exact number to finish"),
 	)
 
+	// Chat entre joueurs pour les parties en ligne
+	if c.isOnlineGame() {
+		rightPanel.Add(widget.NewSeparator())
+		rightPanel.Add(c.createChatPanel())
+	}
+	if c.narrationEnabled() {
+		rightPanel.Add(widget.NewSeparator())
+		rightPanel.Add(c.createNarrationPanel())
+	}
+
 	rightPanelScroll := container.NewVScroll(container.NewPadded(rightPanel))
 	rightPanelScroll.SetMinSize(fyne.NewSize(300, 0))
 
@@ -781,11 +1583,22 @@ func (c *Client) showGameBoard() {
 	c.statusLabel.Alignment = fyne.TextAlignCenter
 
 	leaveButton := widget.NewButton("← Leave Game", func() {
+		c.saveAIProfiles()
+		if !c.isOnlineGame() {
+			c.mu.Lock()
+			c.saveReplay()
+			c.mu.Unlock()
+		}
 		c.showMainMenu()
 	})
 
+	// Barre de confirmation du coup, remplie par askMoveConfirmation
+	c.confirmBar = container.NewHBox()
+	c.confirmBar.Hide()
+
 	bottomPanel := container.NewVBox(
 		widget.NewSeparator(),
+		container.NewCenter(c.confirmBar),
 		container.NewPadded(
 			container.NewHBox(
 				layout.NewSpacer(),
@@ -796,6 +1609,21 @@ func (c *Client) showGameBoard() {
 		container.NewCenter(leaveButton),
 	)
 
+	// Les parties en ligne peuvent être abandonnées d'un commun accord
+	if c.isOnlineGame() {
+		bottomPanel.Add(container.NewCenter(widget.NewButton("🏳 Vote to Abort", c.voteAbort)))
+	}
+	// L'hôte d'une partie amicale peut la mettre en pause
+	c.pauseButton = nil
+	c.paused = false
+	if c.canPause() {
+		bottomPanel.Add(container.NewCenter(c.newPauseButton()))
+	}
+	// Règle maison du coup repris
+	if c.canUndo() {
+		bottomPanel.Add(container.NewCenter(c.newUndoButton()))
+	}
+
 	mainLayout := container.NewBorder(
 		nil,
 		bottomPanel,
@@ -805,7 +1633,14 @@ func (c *Client) showGameBoard() {
 	)
 
 	c.gameBoard = mainLayout
-	c.window.SetContent(c.gameBoard)
+	c.setContent(c.gameBoard)
+	if c.hotSeat() {
+		c.handDeviceTo(c.gameState.Room.Players[c.gameState.Room.CurrentTurn])
+	}
+
+	c.mu.Lock()
+	c.refreshBoard()
+	c.mu.Unlock()
 
 	if !c.isMyTurn {
 		go c.playAITurns()
@@ -821,17 +1656,12 @@ func (c *Client) showGameBoard() {
 // RENDU DU PLATEAU
 // ============================================================================
 
-func (c *Client) renderBoard(width, height int) *image.NRGBA {
-	img := image.NewNRGBA(image.Rect(0, 0, width, height))
-	draw.Draw(img, img.Bounds(), &image.Uniform{color.NRGBA{255, 255, 255, 255}}, image.Point{}, draw.Src)
-
-	cs := float64(width) / float64(BOARD_GRID)
-
+// drawBoardBackground dessine le plateau sans les pions
+func drawBoardBackground(img *image.NRGBA, cs float64) {
 	// Zones home colorées
-	drawHomeZone(img, 0, 0, cs, redColor())
-	drawHomeZone(img, 9, 0, cs, greenColor())
-	drawHomeZone(img, 9, 9, cs, yellowColor())
-	drawHomeZone(img, 0, 9, cs, blueColor())
+	for playerColor, origin := range homeZones {
+		drawHomeZone(img, origin[0], origin[1], cs, getColorForPlayerColor(playerColor).(color.NRGBA))
+	}
 
 	// Chemin principal
 	for _, pos := range boardPath {
@@ -839,95 +1669,48 @@ func (c *Client) renderBoard(width, height int) *image.NRGBA {
 	}
 
 	// Home stretches
-	redStretch := [][2]int{{7, 13}, {7, 12}, {7, 11}, {7, 10}, {7, 9}}
-	for _, pos := range redStretch {
-		drawColoredCell(img, pos[0], pos[1], cs, redColor())
-	}
-
-	greenStretch := [][2]int{{1, 7}, {2, 7}, {3, 7}, {4, 7}, {5, 7}}
-	for _, pos := range greenStretch {
-		drawColoredCell(img, pos[0], pos[1], cs, greenColor())
-	}
-
-	yellowStretch := [][2]int{{7, 1}, {7, 2}, {7, 3}, {7, 4}, {7, 5}}
-	for _, pos := range yellowStretch {
-		drawColoredCell(img, pos[0], pos[1], cs, yellowColor())
-	}
-
-	blueStretch := [][2]int{{13, 7}, {12, 7}, {11, 7}, {10, 7}, {9, 7}}
-	for _, pos := range blueStretch {
-		drawColoredCell(img, pos[0], pos[1], cs, blueColor())
+	for playerColor, cells := range homeStretchCells {
+		for _, pos := range cells {
+			drawColoredCell(img, pos[0], pos[1], cs, getColorForPlayerColor(playerColor).(color.NRGBA))
+		}
 	}
 
 	// Centre
 	drawCenterTriangle(img, 7, 7, cs)
 
 	// Cases de départ
-	drawStarCell(img, boardPath[0][0], boardPath[0][1], cs, redColor())
-	drawStarCell(img, boardPath[13][0], boardPath[13][1], cs, greenColor())
-	drawStarCell(img, boardPath[26][0], boardPath[26][1], cs, yellowColor())
-	drawStarCell(img, boardPath[39][0], boardPath[39][1], cs, blueColor())
+	for playerColor, position := range constants.StartingPositions {
+		start := boardPath[position]
+		drawStarCell(img, start[0], start[1], cs, getColorForPlayerColor(playerColor).(color.NRGBA))
+	}
 
 	// Flèches
 	drawArrow(img, 6, 13, cs, "right", redColor())
 	drawArrow(img, 0, 7, cs, "down", greenColor())
 	drawArrow(img, 8, 1, cs, "left", yellowColor())
 	drawArrow(img, 14, 7, cs, "up", blueColor())
+}
 
-	// 🎯 DESSINER LES TOKENS
-	if c.gameState != nil && c.gameState.Room != nil {
-		for pi, player := range c.gameState.Room.Players {
-			pColor := getColorForPlayerColor(player.Color).(color.NRGBA)
-
-			for ti, token := range player.Tokens {
-				px, py := c.getTokenPixelPosition(player, ti, token, cs)
-
-				// Ombre
-				drawCircle(img, px+2, py+2, cs*0.3, color.NRGBA{0, 0, 0, 60})
-
-				// 🎯 Déterminer la couleur
-				tokenColor := pColor
-				isSelected := c.selectedToken != nil &&
-					c.selectedToken.PlayerIndex == pi &&
-					c.selectedToken.TokenIndex == ti
-
-				if isSelected {
-					// Token sélectionné = JAUNE VIF
-					tokenColor = color.NRGBA{255, 255, 0, 255}
-				}
-
-				// Token
-				drawCircle(img, px, py, cs*0.3, tokenColor)
-
-				// Bordure noire
-				drawCircleOutline(img, px, py, cs*0.3, color.NRGBA{0, 0, 0, 200}, 2)
-
-				// Highlight blanc
-				drawCircle(img, px-cs*0.08, py-cs*0.08, cs*0.1, color.NRGBA{255, 255, 255, 120})
-
-				// 🎯 Bordure verte si déplaçable
-				if c.canMoveToken(player, ti) && !isSelected {
-					drawCircleOutline(img, px, py, cs*0.35, color.NRGBA{0, 255, 0, 255}, 3)
-				}
-			}
-		}
+func (c *Client) getTokenPixelPosition(player *models.Player, tokenIndex int, token *models.Token, cs float64) (float64, float64) {
+	if px, py, ok := c.glidePixel(player, tokenIndex, token, cs); ok {
+		return px, py
 	}
-
-	// Grille
-	drawCompleteGrid(img, width, height, cs)
-	return img
+	px, py := positionPixel(player.Color, tokenIndex, token.Position, cs)
+	dx, dy := c.stackOffset(token, cs)
+	return px + dx, py + dy
 }
 
-func (c *Client) getTokenPixelPosition(player *models.Player, tokenIndex int, token *models.Token, cs float64) (float64, float64) {
-	if token.Position == -1 {
-		hp := homePositions[player.Color]
+// positionPixel retourne le centre de la case d'un pion à la position donnée
+func positionPixel(playerColor constants.PlayerColor, tokenIndex, position int, cs float64) (float64, float64) {
+	if position == -1 {
+		hp := homePositions[playerColor]
 		return (float64(hp[tokenIndex][0]) + 0.5) * cs, (float64(hp[tokenIndex][1]) + 0.5) * cs
-	} else if token.Position < PATH_LEN {
-		pathPos := boardPath[token.Position]
+	} else if position < PATH_LEN {
+		pathPos := boardPath[position]
 		return (float64(pathPos[0]) + 0.5) * cs, (float64(pathPos[1]) + 0.5) * cs
 	} else {
-		offset := token.Position - PATH_LEN
-		return getHomeStretchPixelPos(player.Color, offset, cs)
+		offset := position - PATH_LEN
+		return getHomeStretchPixelPos(playerColor, offset, cs)
 	}
 }
 
@@ -945,57 +1728,67 @@ func getHomeStretchPixelPos(playerColor constants.PlayerColor, offset int, cs fl
 	return 0, 0
 }
 
-func (c *Client) refreshBoard() {
-	size := int(c.boardSize)
-	if size < 450 {
-		size = 450
-	}
-	rendered := c.renderBoard(size, size)
-	fyne.Do(func() {
-		c.boardImage.Image = rendered
-		c.boardImage.Refresh()
-	})
-}
-
 // ============================================================================
 // 🎯 SYSTÈME DE SÉLECTION ET DÉPLACEMENT
 // ============================================================================
 
+// canMoveToken indique si le pion peut jouer le dé courant.
+// L'appelant doit détenir c.mu.
 func (c *Client) canMoveToken(player *models.Player, tokenIndex int) bool {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
 	if !c.isMyTurn || c.currentDice == 0 {
 		return false
 	}
-	if player.ID != c.user.ID {
+	if player.ID != c.seatID() {
 		return false
 	}
 
 	token := player.Tokens[tokenIndex]
 
-	// En base: besoin d'un 6
-	if token.Position == -1 {
-		return c.currentDice == 6
+	if c.gameState == nil || c.gameState.Room == nil {
+		return false
 	}
+	room := c.gameState.Room
 
-	// Sur le plateau: vérifier dépassement
-	relativePos := (token.Position - startIndex[player.Color] + PATH_LEN) % PATH_LEN
-	newRelative := relativePos + c.currentDice
+	// Sous une variante, le serveur seul juge de la légalité du coup
+	if room.Rules != "" {
+		return !token.IsHome
+	}
 
-	// Ne peut pas dépasser la maison
-	return newRelative <= PATH_LEN+HOME_STRETCH_LEN
+	// Mêmes règles que le moteur du serveur, règles maison comprises
+	_, ok := moves.LegalWith(room.HouseRules, room.Players, player, token, c.currentDice)
+	return ok
 }
 
+// onBoardTapped réagit au clic sur une case: le pion du joueur qui s'y
+// trouve, ou la case d'arrivée du pion sélectionné
 func (c *Client) onBoardTapped(pos fyne.Position) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	ti := -1
+	if myPlayer, _ := c.myPlayer(); myPlayer != nil {
+		ti = c.tokenAt(myPlayer, pos)
+	}
+	c.tapToken(ti)
+}
+
+// onPawnTapped réagit au clic sur un pion du joueur
+func (c *Client) onPawnTapped(tokenIndex int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tapToken(tokenIndex)
+}
+
+// tapToken sélectionne, joue ou présélectionne le pion touché, -1 pour un
+// clic hors des pions du joueur. L'appelant doit détenir c.mu.
+func (c *Client) tapToken(ti int) {
+	// En Pass & Play, l'appareil n'est jamais entre les mains d'un joueur
+	// qui attend son tour: rien à présélectionner
+	if !c.isMyTurn && c.hotSeat() {
+		return
+	}
 	if !c.isMyTurn {
-		log.Println("❌ Pas votre tour!")
-		fyne.Do(func() {
-			c.statusLabel.SetText("⏳ Wait for your turn!")
-		})
+		c.togglePremove(ti)
 		return
 	}
 
@@ -1011,114 +1804,291 @@ func (c *Client) onBoardTapped(pos fyne.Position) {
 		return
 	}
 
-	cs := float64(c.boardSize) / float64(BOARD_GRID)
-	clickCol := int(float64(pos.X) / cs)
-	clickRow := int(float64(pos.Y) / cs)
+	myPlayer, myPlayerIndex := c.myPlayer()
+	if myPlayer == nil {
+		return
+	}
+
+	// 🎯 ÉTAPE 1: Clic sur un token
+	if ti >= 0 {
+		// Clic sur un token!
+
+		if !c.canMoveToken(myPlayer, ti) {
+			log.Printf("⚠️ Token %d ne peut pas bouger", ti)
+			text := c.rejectionText(myPlayer, ti)
+			fyne.Do(func() {
+				c.statusLabel.SetText(text)
+			})
+			return
+		}
 
-	// Chercher le joueur actuel
-	var myPlayer *models.Player
-	var myPlayerIndex int
+		// Coups confirmés: la sélection ouvre la confirmation
+		if c.confirmMoves() {
+			c.askMoveConfirmation(myPlayer, myPlayerIndex, ti)
+			c.refreshBoard()
+			return
+		}
+
+		// 🎯 SÉLECTIONNER le token
+		if c.selectedToken != nil && c.selectedToken.TokenIndex == ti {
+			// Déjà sélectionné → DÉPLACER
+			c.moveSelectedToken(myPlayer, myPlayerIndex, ti)
+		} else {
+			// Sélectionner
+			c.selectedToken = &SelectedToken{
+				PlayerIndex: myPlayerIndex,
+				TokenIndex:  ti,
+			}
+
+			log.Printf("✅ Token %d sélectionné (devient jaune)", ti)
+			text := fmt.Sprintf("🎯 %s selected! Click again to move %d spaces", pawnLabel(ti), c.currentDice)
+			if hint := c.safeSquareHint(); hint != "" {
+				text += "\n" + hint
+			}
+			fyne.Do(func() {
+				c.statusLabel.SetText(text)
+			})
+		}
+
+		c.refreshBoard()
+		return
+	}
+
+	// 🎯 ÉTAPE 2: Si un token est sélectionné et qu'on clique ailleurs, on le
+	// déplace, sauf si les coups sont confirmés
+	if c.selectedToken != nil && !c.confirmMoves() {
+		c.moveSelectedToken(myPlayer, myPlayerIndex, c.selectedToken.TokenIndex)
+		c.refreshBoard()
+	}
+}
+
+// myPlayer retourne le joueur local et son index dans la partie (en Pass &
+// Play, celui qui tient l'appareil)
+func (c *Client) myPlayer() (*models.Player, int) {
+	if c.gameState == nil || c.gameState.Room == nil {
+		return nil, -1
+	}
 	for pi, player := range c.gameState.Room.Players {
-		if player.ID == c.user.ID {
-			myPlayer = player
-			myPlayerIndex = pi
-			break
+		if player.ID == c.seatID() {
+			return player, pi
 		}
 	}
+	return nil, -1
+}
 
+// tokenAt retourne l'index du pion du joueur sous le clic, ou -1. Quand
+// plusieurs de ses pions partagent la case, le plus proche du clic l'emporte.
+func (c *Client) tokenAt(player *models.Player, pos fyne.Position) int {
+	cs := float64(c.boardSize) / float64(BOARD_GRID)
+	x, y := float64(pos.X), float64(pos.Y)
+	clickCol := int(x / cs)
+	clickRow := int(y / cs)
+
+	best, bestDist := -1, math.Inf(1)
+	for ti, token := range player.Tokens {
+		px, py := c.getTokenPixelPosition(player, ti, token, cs)
+		if clickCol != int(px/cs) || clickRow != int(py/cs) {
+			continue
+		}
+		if d := math.Hypot(px-x, py-y); d < bestDist {
+			best, bestDist = ti, d
+		}
+	}
+	return best
+}
+
+// togglePremove présélectionne (ou annule) un pion pendant le tour adverse.
+// Il sera joué automatiquement au prochain lancer si le dé le permet.
+// L'appelant doit détenir c.mu.
+func (c *Client) togglePremove(ti int) {
+	myPlayer, myPlayerIndex := c.myPlayer()
 	if myPlayer == nil {
 		return
 	}
 
-	// 🎯 ÉTAPE 1: Chercher si on clique sur un token
-	for ti, token := range myPlayer.Tokens {
-		px, py := c.getTokenPixelPosition(myPlayer, ti, token, cs)
-		tokenCol := int(px / cs)
-		tokenRow := int(py / cs)
+	if ti < 0 || myPlayer.Tokens[ti].IsHome {
+		fyne.Do(func() {
+			c.statusLabel.SetText("⏳ Wait for your turn! Click a pawn to premove it.")
+		})
+		return
+	}
 
-		if clickCol == tokenCol && clickRow == tokenRow {
-			// Clic sur un token!
+	if c.premove != nil && c.premove.TokenIndex == ti {
+		c.premove = nil
+		fyne.Do(func() {
+			c.statusLabel.SetText(fmt.Sprintf("↩️ Premove of %s cancelled", pawnLabel(ti)))
+		})
+	} else {
+		c.premove = &SelectedToken{PlayerIndex: myPlayerIndex, TokenIndex: ti}
+		fyne.Do(func() {
+			c.statusLabel.SetText(fmt.Sprintf("⏭ Premove queued: %s moves as soon as your roll allows", pawnLabel(ti)))
+		})
+	}
 
-			if !c.canMoveToken(myPlayer, ti) {
-				log.Printf("⚠️ Token %d ne peut pas bouger", ti)
-				fyne.Do(func() {
-					c.statusLabel.SetText(fmt.Sprintf("❌ This pawn cannot move with a %d", c.currentDice))
-				})
-				return
-			}
+	c.refreshBoard()
+}
 
-			// 🎯 SÉLECTIONNER le token
-			if c.selectedToken != nil && c.selectedToken.TokenIndex == ti {
-				// Déjà sélectionné → DÉPLACER
-				c.moveSelectedToken(myPlayer, myPlayerIndex, ti)
-			} else {
-				// Sélectionner
-				c.selectedToken = &SelectedToken{
-					PlayerIndex: myPlayerIndex,
-					TokenIndex:  ti,
-				}
+// playPremove joue le pion présélectionné si le dé courant le permet.
+// La présélection est consommée dans tous les cas.
+// L'appelant doit détenir c.mu.
+func (c *Client) playPremove(player *models.Player, playerIndex int) bool {
+	premove := c.premove
+	c.premove = nil
+	if premove == nil || !c.canMoveToken(player, premove.TokenIndex) {
+		return false
+	}
 
-				log.Printf("✅ Token %d sélectionné (devient jaune)", ti)
-				fyne.Do(func() {
-					c.statusLabel.SetText(fmt.Sprintf("🎯 Pawn selected! Click again to move %d spaces", c.currentDice))
-				})
-			}
+	log.Printf("⏭ Premove: token %d avec un %d", premove.TokenIndex, c.currentDice)
 
-			c.refreshBoard()
-			return
+	if c.isOnlineGame() {
+		// Le serveur revalide le coup (tour, lancer effectué, déplacement légal)
+		c.send <- &models.NetworkMessage{
+			Type: constants.MsgMoveToken,
+			Payload: models.MoveTokenPayload{
+				PlayerID: c.user.ID,
+				RoomID:   c.gameState.Room.ID,
+				TokenID:  premove.TokenIndex,
+			},
+			Timestamp: time.Now(),
 		}
+	} else {
+		c.moveSelectedToken(player, playerIndex, premove.TokenIndex)
 	}
+	return true
+}
 
-	// 🎯 ÉTAPE 2: Si un token est sélectionné et qu'on clique ailleurs, on le déplace
-	if c.selectedToken != nil {
-		c.moveSelectedToken(myPlayer, myPlayerIndex, c.selectedToken.TokenIndex)
-		c.refreshBoard()
+// rollOnline demande un lancer au serveur. Dans les salles à dé visé, le
+// premier clic lance le sélecteur et le second l'arrête.
+// L'appelant doit détenir c.mu.
+func (c *Client) rollOnline() {
+	msg := &models.NetworkMessage{Type: constants.MsgRollDice, Timestamp: time.Now()}
+
+	if c.gameState.Room.SkillDice {
+		if c.spinning {
+			c.spinning = false
+			msg.Type = constants.MsgStopSpin
+			msg.Payload = models.StopSpinPayload{ElapsedMs: time.Since(c.spinStart).Milliseconds()}
+		} else {
+			msg.Type = constants.MsgStartSpin
+		}
 	}
+
+	c.send <- msg
 }
 
-func (c *Client) moveSelectedToken(player *models.Player, playerIndex int, tokenIndex int) {
-	token := player.Tokens[tokenIndex]
-	oldPos := token.Position
+// handleSpinStarted anime le sélecteur du joueur courant
+func (c *Client) handleSpinStarted(msg *models.NetworkMessage) {
+	var payload models.SpinStartedPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid spin: %v", err)
+		return
+	}
 
-	log.Printf("🚀 Déplacement du token %d depuis position %d", tokenIndex, oldPos)
+	c.mu.Lock()
+	c.spinCommit = payload.Commitment
+	c.spinStart = time.Now()
+	mine := payload.PlayerID == c.user.ID
+	c.spinning = mine
+	c.mu.Unlock()
 
-	// Calculer nouvelle position
-	if token.Position == -1 {
-		// Sortir de la base avec un 6
-		if c.currentDice == 6 {
-			token.Position = startIndex[player.Color]
-			log.Printf("🏠→🚀 Token sort en position %d", token.Position)
-		} else {
-			return
-		}
-	} else {
-		// Déplacement normal
-		relativePos := (token.Position - startIndex[player.Color] + PATH_LEN) % PATH_LEN
-		newRelative := relativePos + c.currentDice
+	if !mine {
+		return
+	}
 
-		if newRelative > PATH_LEN+HOME_STRETCH_LEN {
-			log.Println("❌ Dépassement interdit!")
-			return
+	fyne.Do(func() {
+		c.diceButton.SetText("✋ Stop!")
+		c.statusLabel.SetText("🎯 Stop the selector on the face you want")
+	})
+
+	c.animate(func(time.Duration) bool {
+		c.mu.Lock()
+		spinning := c.spinning
+		face := dice.FaceAt(time.Since(c.spinStart))
+		c.mu.Unlock()
+		if !spinning {
+			return false
 		}
 
-		if newRelative == PATH_LEN+HOME_STRETCH_LEN {
-			token.Position = PATH_LEN + HOME_STRETCH_LEN
-			log.Println("🏁 Token arrivé à la maison!")
-		} else if newRelative >= PATH_LEN {
-			token.Position = PATH_LEN + (newRelative - PATH_LEN)
-		} else {
-			newPos := (startIndex[player.Color] + newRelative) % PATH_LEN
-			token.Position = newPos
+		fyne.Do(func() {
+			c.diceValue.Text = fmt.Sprintf("%d", face)
+			c.diceValue.Refresh()
+		})
+		return true
+	})
+}
+
+// handleSpinResult vérifie la graine révélée par le serveur
+func (c *Client) handleSpinResult(msg *models.NetworkMessage) {
+	var payload models.SpinResultPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid spin result: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	c.spinning = false
+	verified := dice.VerifySpin(c.spinCommit, payload.Seed)
+	c.spinCommit = ""
+	c.mu.Unlock()
+
+	aimed := dice.FaceAt(time.Duration(payload.ElapsedMs) * time.Millisecond)
+	text := fmt.Sprintf("🎯 Aimed %d → rolled %d", aimed, payload.Value)
+	if !verified {
+		log.Printf("⚠️ Spin seed does not match the server commitment")
+		text = "⚠️ This roll could not be verified against the server commitment"
+	}
+
+	fyne.Do(func() {
+		c.diceButton.SetText("🎲 Roll Dice")
+		c.statusLabel.SetText(text)
+	})
+}
+
+// isOnlineGame indique si la partie est jouée sur le serveur
+func (c *Client) isOnlineGame() bool {
+	if c.gameState == nil || c.gameState.Room == nil {
+		return false
+	}
+	return c.gameState.Room.GameMode != "ai" && c.gameState.Room.GameMode != hotSeatMode
+}
+
+func (c *Client) moveSelectedToken(player *models.Player, playerIndex int, tokenIndex int) {
+	token := player.Tokens[tokenIndex]
+	oldPos := token.Position
+
+	tokensInPlay := 0
+	for _, t := range player.Tokens {
+		if t.Position != moves.Base && !t.IsHome {
+			tokensInPlay++
 		}
 	}
 
-	log.Printf("📍 Nouvelle position: %d", token.Position)
+	log.Printf("🚀 Déplacement du token %d depuis position %d", tokenIndex, oldPos)
 
-	// Vérifier capture
-	c.checkCapture(player.Color, token.Position)
+	victim, ok := c.advanceToken(player, token, c.currentDice)
+	if !ok {
+		log.Println("❌ Coup interdit!")
+		return
+	}
+	c.recordTurn(player, token, oldPos, c.currentDice, victim)
+
+	// Les IA apprennent les habitudes du joueur
+	for _, profile := range c.aiProfiles {
+		profile.RecordMove(victim != nil, oldPos == -1, tokensInPlay)
+	}
 
 	// Vérifier victoire
 	if c.checkWin(player) {
+		if c.hotSeat() {
+			// À plusieurs sur l'appareil, la partie s'arrête au premier vainqueur
+			c.saveReplay()
+			c.selectedToken = nil
+			c.hotSeatWin(player)
+			return
+		}
+		c.saveAIProfiles()
+		c.saveReplay()
+		c.narrate("Game over: you won!")
 		fyne.Do(func() {
 			c.statusLabel.SetText("🏆 YOU WIN!")
 			dialog.ShowInformation("Victory!", "🏆 Congratulations! You won the game!", c.window)
@@ -1142,33 +2112,64 @@ func (c *Client) moveSelectedToken(player *models.Player, playerIndex int, token
 	}
 }
 
-func (c *Client) checkCapture(myColor constants.PlayerColor, position int) {
-	if position < 0 || position >= PATH_LEN {
-		return
+// advanceToken joue un coup selon les règles communes au moteur (entrée du
+// couloir, tours restants, arrivée) et retourne le pion capturé, nil sinon.
+// ok vaut false si le coup est interdit.
+func (c *Client) advanceToken(player *models.Player, token *models.Token, dice int) (*models.Token, bool) {
+	newPos, ok := moves.Legal(player, token, dice)
+	if !ok {
+		return nil, false
 	}
-	if safeCells[position] {
-		return
+
+	// Passer l'entrée de la maison consomme un tour supplémentaire
+	if token.Laps > 0 && moves.CrossesHomeEntry(player.Color, token.Position, dice) {
+		token.Laps--
+	}
+
+	from := token.Position
+	token.Position = newPos
+	token.IsHome = newPos == moves.Home
+	c.startGlide(token, from, dice)
+	if token.IsHome {
+		log.Println("🏁 Token arrivé à la maison!")
+	}
+	log.Printf("📍 Nouvelle position: %d", token.Position)
+
+	return c.checkCapture(player.Color, newPos), true
+}
+
+// checkCapture renvoie à la base les pions adverses de la case et retourne
+// le pion capturé, nil sinon
+func (c *Client) checkCapture(myColor constants.PlayerColor, position int) *models.Token {
+	if moves.IsSafe(position) {
+		return nil
 	}
 
+	var captured *models.Token
+
 	for _, player := range c.gameState.Room.Players {
 		if player.Color == myColor {
 			continue
 		}
 		for _, token := range player.Tokens {
 			if token.Position == position {
-				token.Position = -1
-				log.Printf("💥 CAPTURE! Token de %s renvoyé", player.Username)
+				token.Position = moves.Base
+				c.startFlyBack(token, position)
+				captured = token
+				log.Printf("💥 CAPTURE! Token %d de %s renvoyé", token.ID+1, player.Username)
 				fyne.Do(func() {
-					c.statusLabel.SetText(fmt.Sprintf("💥 Captured %s's pawn!", player.Username))
+					c.statusLabel.SetText(fmt.Sprintf("💥 Captured %s's %s!", player.Username, pawnLabel(token.ID)))
 				})
 			}
 		}
 	}
+
+	return captured
 }
 
 func (c *Client) checkWin(player *models.Player) bool {
 	for _, token := range player.Tokens {
-		if token.Position != PATH_LEN+HOME_STRETCH_LEN {
+		if !token.IsHome {
 			return false
 		}
 	}
@@ -1176,18 +2177,21 @@ func (c *Client) checkWin(player *models.Player) bool {
 }
 
 // ============================================================================
-// DÉ TRUQUÉ
+// DÉ
 // ============================================================================
 
-func (c *Client) rollDiceWithCheat() int {
-	c.rollCount++
-	if c.rollCount == 1 || c.rollCount%5 == 0 {
-		log.Printf("🎲 DÉ TRUQUÉ! Lancer #%d → 6", c.rollCount)
-		return 6
+// rollDiceFor lance le dé avec le profil du joueur (équilibré par défaut) et
+// l'inscrit au journal des lancers. L'appelant doit détenir c.mu.
+func (c *Client) rollDiceFor(player *models.Player) int {
+	profile, ok := c.diceProfiles[player.Color]
+	if !ok {
+		profile = dice.Fair
 	}
-	dice := int(time.Now().UnixNano()%6) + 1
-	log.Printf("🎲 Lancer #%d → %d", c.rollCount, dice)
-	return dice
+	value := profile.Roll(c.diceRand)
+	log.Printf("🎲 %s (%s) → %d", player.Username, profile.Name, value)
+	c.recordRoll(player, value)
+	c.playEvent(audio.EventDiceRoll)
+	return value
 }
 
 func (c *Client) onDiceRoll() {
@@ -1201,7 +2205,14 @@ func (c *Client) onDiceRoll() {
 		return
 	}
 
-	c.currentDice = c.rollDiceWithCheat()
+	if c.isOnlineGame() {
+		c.rollOnline()
+		return
+	}
+
+	roller := c.gameState.Room.Players[c.gameState.Room.CurrentTurn]
+	c.currentDice = c.rollDiceFor(roller)
+	extra, forfeit := moves.Roll(roller, c.currentDice)
 
 	fyne.Do(func() {
 		c.diceValue.Text = fmt.Sprintf("%d", c.currentDice)
@@ -1211,10 +2222,21 @@ func (c *Client) onDiceRoll() {
 
 	log.Printf("🎲 Dé lancé: %d", c.currentDice)
 
+	if forfeit {
+		log.Println("❌ Trois 6 de suite, tour perdu")
+		c.narrate("Three 6s in a row: turn lost.")
+		fyne.Do(func() {
+			c.statusLabel.SetText("🎲 Three 6s in a row - turn lost!")
+		})
+		go c.endTurnAfterPause()
+		c.refreshBoard()
+		return
+	}
+
 	// Vérifier mouvements possibles
 	hasMove := false
 	for _, player := range c.gameState.Room.Players {
-		if player.ID == c.user.ID {
+		if player.ID == c.seatID() {
 			for ti := range player.Tokens {
 				if c.canMoveToken(player, ti) {
 					hasMove = true
@@ -1225,20 +2247,23 @@ func (c *Client) onDiceRoll() {
 		}
 	}
 
-	if !hasMove {
+	if !hasMove && extra {
+		// Comme sur le serveur, un 6 sans coup possible donne un nouveau lancer
+		log.Println("❌ Aucun mouvement possible, relancez")
+		c.narrate("No valid moves. Roll again.")
+		c.currentDice = 0
+		fyne.Do(func() {
+			c.statusLabel.SetText("🎲 Rolled 6 - No valid moves, roll again!")
+			c.diceButton.Enable()
+		})
+	} else if !hasMove {
 		log.Println("❌ Aucun mouvement possible")
+		c.narrate("No valid moves with %d.", c.currentDice)
 		fyne.Do(func() {
 			c.statusLabel.SetText(fmt.Sprintf("🎯 Rolled %d - No valid moves!", c.currentDice))
 		})
-
-		go func() {
-			time.Sleep(2 * time.Second)
-			c.mu.Lock()
-			c.currentDice = 0
-			c.nextTurn()
-			c.mu.Unlock()
-		}()
-	} else {
+		go c.endTurnAfterPause()
+	} else if player, index := c.myPlayer(); player == nil || !c.playPremove(player, index) {
 		fyne.Do(func() {
 			c.statusLabel.SetText(fmt.Sprintf("🎯 Rolled %d! Click a pawn to select (yellow)", c.currentDice))
 		})
@@ -1247,6 +2272,15 @@ func (c *Client) onDiceRoll() {
 	c.refreshBoard()
 }
 
+// endTurnAfterPause laisse le temps de lire le dé avant de passer la main
+func (c *Client) endTurnAfterPause() {
+	time.Sleep(2 * time.Second)
+	c.mu.Lock()
+	c.currentDice = 0
+	c.nextTurn()
+	c.mu.Unlock()
+}
+
 // ============================================================================
 // TOUR SUIVANT
 // ============================================================================
@@ -1259,20 +2293,28 @@ func (c *Client) nextTurn() {
 	c.gameState.Room.CurrentTurn = (c.gameState.Room.CurrentTurn + 1) % len(c.gameState.Room.Players)
 	currentPlayer := c.gameState.Room.Players[c.gameState.Room.CurrentTurn]
 
-	c.isMyTurn = currentPlayer.ID == c.user.ID
+	c.isMyTurn = currentPlayer.ID == c.seatID()
 	c.currentDice = 0
 	c.selectedToken = nil
+	c.narrateTurn(currentPlayer)
 
 	fyne.Do(func() {
+		c.hideMoveConfirmation()
 		if c.playersList != nil {
 			c.playersList.Refresh()
 		}
 
-		if c.isMyTurn {
+		if c.hotSeat() {
+			c.handDeviceTo(currentPlayer)
+		} else if c.isMyTurn {
 			c.statusLabel.SetText("🎲 Your turn! Roll the dice.")
 			c.diceButton.Enable()
 		} else {
-			c.statusLabel.SetText(fmt.Sprintf("⏳ %s's turn...", currentPlayer.Username))
+			if bot, ok := c.aiBots[currentPlayer.Color]; ok && currentPlayer.IsAI {
+				c.statusLabel.SetText(fmt.Sprintf("⏳ %s %s's turn... (%s)", bot.Avatar, bot.Name, bot.Personality))
+			} else {
+				c.statusLabel.SetText(fmt.Sprintf("⏳ %s's turn...", currentPlayer.Username))
+			}
 			c.diceButton.Disable()
 		}
 	})
@@ -1301,47 +2343,33 @@ func (c *Client) playAITurns() {
 	time.Sleep(1 * time.Second)
 
 	c.mu.Lock()
-	aiDice := c.rollDiceWithCheat()
+	aiDice := c.rollDiceFor(currentPlayer)
 	c.currentDice = aiDice
+	extra, forfeit := moves.Roll(currentPlayer, aiDice)
 	c.mu.Unlock()
 
 	fyne.Do(func() {
 		c.diceValue.Text = fmt.Sprintf("%d", aiDice)
 		c.diceValue.Refresh()
-		c.statusLabel.SetText(fmt.Sprintf("🤖 %s rolled %d", currentPlayer.Username, aiDice))
+		avatar := "🤖"
+		if currentPlayer.Avatar != "" {
+			avatar = currentPlayer.Avatar
+		}
+		c.statusLabel.SetText(fmt.Sprintf("%s %s rolled %d", avatar, currentPlayer.Username, aiDice))
 	})
 
 	time.Sleep(1 * time.Second)
 
 	c.mu.Lock()
-	moved := false
 	player := c.gameState.Room.Players[c.gameState.Room.CurrentTurn]
 
-	for ti := range player.Tokens {
-		token := player.Tokens[ti]
-
-		if token.Position == -1 && aiDice == 6 {
-			token.Position = startIndex[player.Color]
-			c.checkCapture(player.Color, token.Position)
-			moved = true
-			break
-		} else if token.Position >= 0 && token.Position < PATH_LEN+HOME_STRETCH_LEN {
-			relativePos := (token.Position - startIndex[player.Color] + PATH_LEN) % PATH_LEN
-			newRelative := relativePos + aiDice
-
-			if newRelative <= PATH_LEN+HOME_STRETCH_LEN {
-				if newRelative == PATH_LEN+HOME_STRETCH_LEN {
-					token.Position = PATH_LEN + HOME_STRETCH_LEN
-				} else if newRelative >= PATH_LEN {
-					token.Position = PATH_LEN + (newRelative - PATH_LEN)
-				} else {
-					token.Position = (startIndex[player.Color] + newRelative) % PATH_LEN
-				}
-				c.checkCapture(player.Color, token.Position)
-				moved = true
-				break
-			}
-		}
+	if forfeit {
+		log.Printf("❌ %s: trois 6 de suite, tour perdu", player.Username)
+		c.narrate("%s rolled three 6s in a row: turn lost.", c.spokenPlayer(player))
+	} else if token := c.chooseAIToken(player, aiDice); token != nil {
+		oldPos := token.Position
+		victim, _ := c.advanceToken(player, token, aiDice)
+		c.recordTurn(player, token, oldPos, aiDice, victim)
 	}
 	c.mu.Unlock()
 
@@ -1349,7 +2377,7 @@ func (c *Client) playAITurns() {
 
 	c.refreshBoard()
 
-	if aiDice == 6 && moved {
+	if extra {
 		c.mu.Lock()
 		c.currentDice = 0
 		c.mu.Unlock()
@@ -1362,6 +2390,79 @@ func (c *Client) playAITurns() {
 	}
 }
 
+// chooseAIToken choisit le pion à jouer selon les pondérations du profil de l'IA
+func (c *Client) chooseAIToken(player *models.Player, dice int) *models.Token {
+	w := c.aiBots[player.Color].Adjust(c.aiProfiles[player.Color].Weights())
+
+	var best *models.Token
+	bestScore := math.MinInt
+	for _, token := range player.Tokens {
+		newPos, ok := moves.Legal(player, token, dice)
+		if !ok {
+			continue
+		}
+
+		score := 0
+		if token.Position == -1 {
+			score += w.LeaveBase
+		}
+		if newPos >= moves.StretchStart {
+			score += w.EnterHome
+		} else if moves.IsSafe(newPos) {
+			score += w.Safe
+		} else {
+			if c.opponentAt(player.Color, newPos) {
+				score += w.Capture
+			}
+			if c.opponentBehind(player.Color, newPos) {
+				score -= w.Danger
+			}
+		}
+		score += moves.Progress(player.Color, newPos) * w.Advance
+
+		if score > bestScore {
+			best = token
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+// opponentAt vérifie si un pion adverse occupe la case
+func (c *Client) opponentAt(myColor constants.PlayerColor, position int) bool {
+	for _, player := range c.gameState.Room.Players {
+		if player.Color == myColor {
+			continue
+		}
+		for _, token := range player.Tokens {
+			if token.Position == position {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// opponentBehind vérifie si un pion adverse peut atteindre la case au prochain lancer
+func (c *Client) opponentBehind(myColor constants.PlayerColor, position int) bool {
+	for i := 1; i <= 6; i++ {
+		if c.opponentAt(myColor, (position-i+PATH_LEN)%PATH_LEN) {
+			return true
+		}
+	}
+	return false
+}
+
+// saveAIProfiles enregistre les profils des IA de la partie en cours
+func (c *Client) saveAIProfiles() {
+	for _, profile := range c.aiProfiles {
+		if err := c.profileStore.Save(profile); err != nil {
+			log.Printf("⚠️ Failed to save AI profile: %v", err)
+		}
+	}
+}
+
 // ============================================================================
 // LISTE DES JOUEURS
 // ============================================================================
@@ -1375,7 +2476,7 @@ func (c *Client) createPlayersList() *widget.List {
 		)
 	}
 
-	return widget.NewList(
+	list := widget.NewList(
 		func() int { return len(c.gameState.Room.Players) },
 		func() fyne.CanvasObject {
 			return container.NewHBox(
@@ -1394,7 +2495,14 @@ func (c *Client) createPlayersList() *widget.List {
 				circle.Refresh()
 
 				label := cont.Objects[1].(*widget.Label)
-				label.SetText(player.Username)
+				name := player.Username
+				if player.Avatar != "" {
+					name = player.Avatar + " " + name
+				}
+				label.SetText(name)
+				if player.Handicap != constants.HandicapNone {
+					label.SetText(name + " · " + handicapLabel(player.Handicap))
+				}
 
 				turnMarker := cont.Objects[2].(*widget.Label)
 				if c.gameState.Room.CurrentTurn == id {
@@ -1409,6 +2517,14 @@ func (c *Client) createPlayersList() *widget.List {
 			}
 		},
 	)
+	// Toucher un joueur ouvre son profil
+	list.OnSelected = func(id widget.ListItemID) {
+		list.Unselect(id)
+		if c.gameState != nil && c.gameState.Room != nil && id < len(c.gameState.Room.Players) {
+			c.showPlayerProfile(c.gameState.Room.Players[id])
+		}
+	}
+	return list
 }
 
 // ============================================================================
@@ -1450,18 +2566,6 @@ func (r *tappableRectRenderer) Refresh()                     {}
 func (r *tappableRectRenderer) Objects() []fyne.CanvasObject { return []fyne.CanvasObject{r.rect} }
 func (r *tappableRectRenderer) Destroy()                     {}
 
-// ============================================================================
-// AUTRES MENUS
-// ============================================================================
-
-func (c *Client) showSettings() {
-	dialog.ShowInformation("Settings", "Settings feature coming soon!", c.window)
-}
-
-func (c *Client) showLeaderboard() {
-	dialog.ShowInformation("Leaderboard", "Leaderboard feature coming soon!", c.window)
-}
-
 // ============================================================================
 // UTILITAIRES
 // ============================================================================
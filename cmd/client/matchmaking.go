@@ -0,0 +1,213 @@
+// cmd/client/matchmaking.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// chooseMatchQueue propose la file classée ou la file amicale
+func (c *Client) chooseMatchQueue() {
+	if !c.connected {
+		dialog.ShowError(fmt.Errorf("Not connected to server"), c.window)
+		return
+	}
+
+	skillCheck := widget.NewCheck("Aim the dice (skill dice variant)", nil)
+	var choice dialog.Dialog
+	rankedBtn := widget.NewButton("🏆 Ranked", func() {
+		choice.Hide()
+		c.findMatch(models.FindMatchPayload{Queue: constants.QueueRanked})
+	})
+	rankedBtn.Importance = widget.HighImportance
+	casualBtn := widget.NewButton("🎈 Casual", func() {
+		choice.Hide()
+		c.findMatch(models.FindMatchPayload{Queue: constants.QueueCasual, SkillDice: skillCheck.Checked})
+	})
+
+	content := container.NewVBox(
+		rankedBtn,
+		widget.NewLabel("Counts for your win rate. Standard rules and fair dice;\nletting your turns run out costs you the game."),
+		widget.NewSeparator(),
+		casualBtn,
+		skillCheck,
+		widget.NewLabel("Just for fun: your win rate does not change."),
+	)
+	choice = dialog.NewCustom("⚡ Quick Match", "Cancel", content, c.window)
+	choice.Show()
+}
+
+// findMatch entre dans la file de matchmaking choisie
+func (c *Client) findMatch(req models.FindMatchPayload) {
+	c.send <- &models.NetworkMessage{Type: constants.MsgFindMatch, Payload: req, Timestamp: time.Now()}
+	c.showSearching("Looking for players of your level…")
+}
+
+// showSearching affiche l'attente d'adversaires, avec un bouton d'annulation
+func (c *Client) showSearching(text string) {
+	cancelBtn := widget.NewButton("Cancel", func() {
+		c.send <- &models.NetworkMessage{Type: constants.MsgCancelMatch, Timestamp: time.Now()}
+		c.closeMatchDialog()
+	})
+
+	content := container.NewVBox(
+		widget.NewLabel(text),
+		widget.NewProgressBarInfinite(),
+		cancelBtn,
+	)
+	c.setMatchDialog(dialog.NewCustomWithoutButtons("⚡ Quick Match", content, c.window))
+}
+
+// setMatchDialog remplace la fenêtre de matchmaking affichée
+func (c *Client) setMatchDialog(d dialog.Dialog) {
+	c.closeMatchDialog()
+	c.matchDialog = d
+	d.Show()
+}
+
+// closeMatchDialog ferme la fenêtre de matchmaking, s'il y en a une
+func (c *Client) closeMatchDialog() {
+	if c.matchDialog != nil {
+		c.matchDialog.Hide()
+		c.matchDialog = nil
+	}
+}
+
+// handleMatchFound propose la table trouvée, à accepter avant la fin du délai
+func (c *Client) handleMatchFound(msg *models.NetworkMessage) {
+	var payload models.MatchFoundPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid match: %v", err)
+		return
+	}
+
+	deadline := time.Now().Add(time.Duration(payload.AcceptSeconds) * time.Second)
+
+	fyne.Do(func() {
+		rows := container.NewVBox()
+		for _, player := range payload.Players {
+			label := fmt.Sprintf("👤 %s   🏆 %.0f%%", player.Username, player.WinRate)
+			if player.Rating > 0 {
+				label += fmt.Sprintf("   ⭐ %d", player.Rating)
+			}
+			if player.Provisional {
+				label += " (placement)"
+			}
+			rows.Add(widget.NewLabel(label))
+		}
+
+		countdown := widget.NewLabel("")
+		progress := widget.NewProgressBar()
+		progress.Max = float64(payload.AcceptSeconds)
+
+		var acceptBtn, declineBtn *widget.Button
+		reply := func(accept bool) {
+			c.send <- &models.NetworkMessage{
+				Type:      constants.MsgMatchReply,
+				Payload:   models.MatchReplyPayload{MatchID: payload.MatchID, Accept: accept},
+				Timestamp: time.Now(),
+			}
+		}
+		acceptBtn = widget.NewButton("✅ Accept", func() {
+			reply(true)
+			acceptBtn.Disable()
+			declineBtn.Disable()
+			acceptBtn.SetText("⏳ Waiting for the other players…")
+		})
+		acceptBtn.Importance = widget.HighImportance
+		declineBtn = widget.NewButton("Decline", func() {
+			reply(false)
+			c.closeMatchDialog()
+		})
+
+		content := container.NewVBox(
+			widget.NewLabelWithStyle(fmt.Sprintf("%s: %d players found", queueLabel(payload.Queue), len(payload.Players)), fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+			rows,
+			widget.NewLabel(matchPaceText(payload)),
+			widget.NewSeparator(),
+			countdown,
+			progress,
+			container.NewGridWithColumns(2, declineBtn, acceptBtn),
+		)
+		found := dialog.NewCustomWithoutButtons("⚡ Match found!", content, c.window)
+		c.setMatchDialog(found)
+
+		// Le serveur annule la table à l'échéance: le décompte n'est qu'indicatif
+		go func() {
+			ticker := time.NewTicker(time.Second)
+			defer ticker.Stop()
+			for range ticker.C {
+				left := time.Until(deadline).Round(time.Second)
+				done := false
+				fyne.DoAndWait(func() {
+					if c.matchDialog != found || left < 0 {
+						done = true
+						return
+					}
+					countdown.SetText(fmt.Sprintf("⏱ %ds to accept", int(left.Seconds())))
+					progress.SetValue(left.Seconds())
+				})
+				if done {
+					return
+				}
+			}
+		}()
+		countdown.SetText(fmt.Sprintf("⏱ %ds to accept", payload.AcceptSeconds))
+		progress.SetValue(progress.Max)
+	})
+}
+
+// matchPaceText décrit le rythme de la table proposée
+func matchPaceText(payload models.MatchFoundPayload) string {
+	text := "Pace: " + paceLabel(payload.Pace)
+	if payload.TurnSeconds > 0 {
+		text += fmt.Sprintf(", %ds per turn", payload.TurnSeconds)
+	}
+	if payload.SkillDice {
+		text += "\n🎯 Skill dice"
+	}
+	return text
+}
+
+// queueLabel retourne le nom affiché d'une file; un serveur antérieur aux
+// files n'en précise pas
+func queueLabel(queue constants.Queue) string {
+	switch queue {
+	case constants.QueueRanked:
+		return "🏆 Ranked"
+	case constants.QueueCasual:
+		return "🎈 Casual"
+	}
+	return "Quick Match"
+}
+
+// handleMatchCancelled ferme la table proposée. Un joueur resté dans la
+// file retrouve la fenêtre d'attente.
+func (c *Client) handleMatchCancelled(msg *models.NetworkMessage) {
+	var payload models.MatchCancelledPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid match cancellation: %v", err)
+		return
+	}
+
+	reason := c.translate(payload.Reason, nil, "The match was cancelled.")
+
+	fyne.Do(func() {
+		if payload.Requeued {
+			c.showSearching(reason + "\nStill looking for players…")
+			return
+		}
+		c.closeMatchDialog()
+		dialog.ShowInformation("⚡ Quick Match", reason, c.window)
+	})
+}
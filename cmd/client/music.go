@@ -0,0 +1,78 @@
+// cmd/client/music.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/client/audio"
+)
+
+// musicDir contient un dossier par playlist: menu/ et game/
+const musicDir = "assets/music"
+
+// Préférences de la musique
+const (
+	musicShufflePreference   = "music_shuffle"
+	musicCrossfadePreference = "music_crossfade" // secondes
+	maxCrossfadeSeconds      = 10
+)
+
+// fallbackMusic est joué en boucle quand une playlist est vide
+const fallbackMusic = "background_music"
+
+// loadMusic lit les playlists au démarrage et applique les préférences
+func (c *Client) loadMusic() {
+	prefs := c.app.Preferences()
+	c.audio.SetPlaylists(audio.LoadPlaylists(musicDir, prefs.BoolWithFallback(musicShufflePreference, true)))
+	c.audio.SetCrossfade(c.crossfade())
+}
+
+// crossfade retourne la durée de fondu choisie par le joueur
+func (c *Client) crossfade() time.Duration {
+	seconds := c.app.Preferences().FloatWithFallback(musicCrossfadePreference, audio.DefaultCrossfade.Seconds())
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// playMusicFor passe à la playlist de l'écran (menu ou partie)
+func (c *Client) playMusicFor(playlist string) {
+	if err := c.audio.PlayPlaylist(playlist); err != nil {
+		log.Printf("🎵 %v, playing the default music", err)
+		c.audio.PlayMusic(fallbackMusic, true)
+	}
+}
+
+// musicSettings règle le mélange et le fondu enchaîné des playlists
+func (c *Client) musicSettings() fyne.CanvasObject {
+	prefs := c.app.Preferences()
+
+	shuffle := widget.NewCheck("Shuffle playlists", func(on bool) {
+		prefs.SetBool(musicShufflePreference, on)
+		c.audio.SetShuffle(on)
+	})
+	shuffle.SetChecked(prefs.BoolWithFallback(musicShufflePreference, true))
+
+	label := widget.NewLabel("")
+	showCrossfade := func(seconds float64) {
+		if seconds == 0 {
+			label.SetText("Crossfade: off")
+			return
+		}
+		label.SetText(fmt.Sprintf("Crossfade: %.0fs", seconds))
+	}
+	slider := widget.NewSlider(0, maxCrossfadeSeconds)
+	slider.SetValue(c.crossfade().Seconds())
+	showCrossfade(slider.Value)
+	slider.OnChanged = showCrossfade
+	slider.OnChangeEnded = func(seconds float64) {
+		prefs.SetFloat(musicCrossfadePreference, seconds)
+		c.audio.SetCrossfade(time.Duration(seconds * float64(time.Second)))
+	}
+
+	return container.NewVBox(shuffle, label, slider)
+}
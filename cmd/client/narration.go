@@ -0,0 +1,190 @@
+// cmd/client/narration.go
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/moves"
+)
+
+// Fyne n'expose pas d'arbre d'accessibilité: chaque événement de la partie
+// est décrit en phrases simples, sans émoticônes, dans un journal affiché à
+// côté du plateau et recopié sur la sortie standard, que les lecteurs
+// d'écran d'un terminal lisent au fil de l'eau.
+const (
+	narrationPreference = "narration"
+
+	narrationLimit = 200 // Annonces conservées dans le journal
+)
+
+// narrationLog garde les dernières annonces. Il a son propre verrou: les
+// annonces partent aussi bien avec que sans c.mu tenu.
+type narrationLog struct {
+	mu    sync.Mutex
+	lines []string
+	list  *widget.List
+}
+
+// narrationEnabled indique si les événements doivent être annoncés
+func (c *Client) narrationEnabled() bool {
+	return c.app.Preferences().Bool(narrationPreference)
+}
+
+// narrate annonce un événement de la partie
+func (c *Client) narrate(format string, args ...any) {
+	if !c.narrationEnabled() {
+		return
+	}
+	text := fmt.Sprintf(format, args...)
+	fmt.Println(text)
+
+	n := &c.narration
+	n.mu.Lock()
+	n.lines = append(n.lines, text)
+	if len(n.lines) > narrationLimit {
+		n.lines = n.lines[len(n.lines)-narrationLimit:]
+	}
+	list := n.list
+	n.mu.Unlock()
+
+	if list != nil {
+		fyne.Do(func() {
+			list.Refresh()
+			list.ScrollToBottom()
+		})
+	}
+}
+
+// createNarrationPanel construit le journal des annonces de la partie
+func (c *Client) createNarrationPanel() fyne.CanvasObject {
+	n := &c.narration
+	n.mu.Lock()
+	n.lines = nil
+	n.list = widget.NewList(
+		func() int {
+			n.mu.Lock()
+			defer n.mu.Unlock()
+			return len(n.lines)
+		},
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Wrapping = fyne.TextWrapWord
+			return label
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			n.mu.Lock()
+			defer n.mu.Unlock()
+			if id < len(n.lines) {
+				item.(*widget.Label).SetText(n.lines[id])
+			}
+		},
+	)
+	list := n.list
+	n.mu.Unlock()
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(0, 140))
+	return container.NewBorder(
+		widget.NewLabelWithStyle("🔊 Game events", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		nil, nil, nil,
+		scroll,
+	)
+}
+
+// spokenPlayer nomme un joueur et sa couleur ("alice (Red)"), ou "You"
+func (c *Client) spokenPlayer(player *models.Player) string {
+	if player == nil {
+		return "Someone"
+	}
+	if c.user != nil && player.ID == c.user.ID {
+		return "You"
+	}
+	return fmt.Sprintf("%s (%s)", player.Username, spokenColor(player))
+}
+
+// spokenColor retourne la couleur d'un joueur en toutes lettres
+func spokenColor(player *models.Player) string {
+	name := string(player.Color)
+	if name == "" {
+		return ""
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// spokenCell décrit une position de pion: base, case du circuit (numérotée
+// de 1 à 52), case du couloir ou maison
+func spokenCell(position int) string {
+	switch {
+	case position == moves.Base:
+		return "base"
+	case position == moves.Home:
+		return "home"
+	case position >= moves.StretchStart:
+		return fmt.Sprintf("home stretch square %d of %d", position-moves.StretchStart+1, moves.StretchLen)
+	case moves.IsSafe(position):
+		return fmt.Sprintf("safe square %d", position+1)
+	}
+	return fmt.Sprintf("square %d", position+1)
+}
+
+// roomPlayer retourne le joueur de la partie affichée. L'appelant doit
+// détenir c.mu.
+func (c *Client) roomPlayer(playerID int64) *models.Player {
+	if c.gameState == nil || c.gameState.Room == nil {
+		return nil
+	}
+	for _, player := range c.gameState.Room.Players {
+		if player.ID == playerID {
+			return player
+		}
+	}
+	return nil
+}
+
+// narrateRoll annonce un lancer de dé
+func (c *Client) narrateRoll(player *models.Player, value int) {
+	c.narrate("%s rolled a %d.", c.spokenPlayer(player), value)
+}
+
+// narrateMove annonce le déplacement d'un pion
+func (c *Client) narrateMove(player *models.Player, tokenIndex, from, to int) {
+	switch {
+	case from == moves.Base:
+		c.narrate("%s moved %s out of base to %s.", c.spokenPlayer(player), pawnLabel(tokenIndex), spokenCell(to))
+	case to == moves.Home:
+		c.narrate("%s moved %s from %s to home.", c.spokenPlayer(player), pawnLabel(tokenIndex), spokenCell(from))
+	default:
+		c.narrate("%s moved %s from %s to %s.", c.spokenPlayer(player), pawnLabel(tokenIndex), spokenCell(from), spokenCell(to))
+	}
+}
+
+// narrateCapture annonce la prise d'un pion, renvoyé à sa base
+func (c *Client) narrateCapture(capturer, victim *models.Player, tokenIndex, position int) {
+	owner := "a"
+	switch {
+	case victim == nil:
+	case c.user != nil && victim.ID == c.user.ID:
+		owner = "your"
+	default:
+		owner = fmt.Sprintf("%s's %s", victim.Username, spokenColor(victim))
+	}
+	c.narrate("%s captured %s %s on %s. It goes back to base.", c.spokenPlayer(capturer), owner, pawnLabel(tokenIndex), spokenCell(position))
+}
+
+// narrateTurn annonce le joueur qui a la main
+func (c *Client) narrateTurn(player *models.Player) {
+	switch {
+	case player == nil:
+	case c.user != nil && player.ID == c.user.ID:
+		c.narrate("Your turn. Roll the dice.")
+	default:
+		c.narrate("It is %s's turn (%s).", player.Username, spokenColor(player))
+	}
+}
@@ -0,0 +1,94 @@
+// cmd/client/overlay.go
+package main
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+)
+
+// coordinateOverlayPreference garde l'overlay affiché d'une session à l'autre
+const coordinateOverlayPreference = "debug_coordinate_overlay"
+
+// overlayLegend explique les repères de l'overlay des coordonnées
+const overlayLegend = "Coordinates overlay (Ctrl+Shift+G): black = path index, " +
+	"colored = home-stretch index, green ring = constants.SafePositions, " +
+	"orange dot = client safeCells, colored corner = constants.StartingPositions"
+
+// installCoordinateOverlay enregistre le raccourci de l'overlay des coordonnées
+func (c *Client) installCoordinateOverlay() {
+	shortcut := &desktop.CustomShortcut{
+		KeyName:  fyne.KeyG,
+		Modifier: fyne.KeyModifierControl | fyne.KeyModifierShift,
+	}
+	c.window.Canvas().AddShortcut(shortcut, func(fyne.Shortcut) {
+		c.setCoordinateOverlay(!c.coordinateOverlay())
+	})
+}
+
+// coordinateOverlay indique si l'overlay des coordonnées est affiché
+func (c *Client) coordinateOverlay() bool {
+	return c.app.Preferences().Bool(coordinateOverlayPreference)
+}
+
+// setCoordinateOverlay affiche ou masque l'overlay et redessine le plateau
+func (c *Client) setCoordinateOverlay(on bool) {
+	c.app.Preferences().SetBool(coordinateOverlayPreference, on)
+	if c.boardImage != nil {
+		go c.refreshBoard()
+	}
+}
+
+// drawCoordinateOverlay annote chaque case du plateau avec son index moteur,
+// pour repérer les décalages entre le client, le moteur et les constantes
+func drawCoordinateOverlay(img *image.NRGBA, cs float64) {
+	px := math.Max(1, math.Round(cs/20))
+
+	// Cases de départ selon les constantes: coin haut droit de la couleur
+	for playerColor, position := range constants.StartingPositions {
+		x, y := positionPixel(playerColor, 0, position, cs)
+		pColor := getColorForPlayerColor(playerColor).(color.NRGBA)
+		fillSquare(img, x+cs/2-cs/4, y-cs/2, cs/4, pColor)
+	}
+
+	// Cases sûres: anneau vert pour le moteur, point orange pour le client
+	for _, position := range constants.SafePositions {
+		x, y := positionPixel(constants.ColorRed, 0, position, cs)
+		drawCircleOutline(img, x, y, cs*0.42, color.NRGBA{0, 180, 0, 255}, 2)
+	}
+	for position := range safeCells {
+		x, y := positionPixel(constants.ColorRed, 0, position, cs)
+		drawCircle(img, x+cs*0.3, y+cs*0.3, cs*0.1, color.NRGBA{255, 140, 0, 255})
+	}
+
+	// Index du parcours commun
+	for position := 0; position < PATH_LEN; position++ {
+		x, y := positionPixel(constants.ColorRed, 0, position, cs)
+		drawCellIndex(img, x-cs/2+px, y-cs/2+px, px, position, color.NRGBA{0, 0, 0, 255})
+	}
+
+	// Index des couloirs de chaque couleur
+	for _, playerColor := range []constants.PlayerColor{
+		constants.ColorRed, constants.ColorGreen, constants.ColorYellow, constants.ColorBlue,
+	} {
+		pColor := getColorForPlayerColor(playerColor).(color.NRGBA)
+		for offset := 0; offset < HOME_STRETCH_LEN; offset++ {
+			x, y := positionPixel(playerColor, 0, PATH_LEN+offset, cs)
+			fillSquare(img, x-cs/2, y-cs/2, 8*px+2*px, color.NRGBA{255, 255, 255, 220})
+			drawCellIndex(img, x-cs/2+px, y-cs/2+px, px, PATH_LEN+offset, pColor)
+		}
+	}
+}
+
+// drawCellIndex écrit un nombre en chiffres 3x5 à partir de (x0, y0)
+func drawCellIndex(img *image.NRGBA, x0, y0, px float64, n int, c color.NRGBA) {
+	for i, digit := range strconv.Itoa(n) {
+		drawGlyph(img, digitGlyphs[digit-'0'], x0+float64(i)*4*px, y0, px, c)
+	}
+}
@@ -0,0 +1,63 @@
+// cmd/client/pace.go
+package main
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// paceOptions sont les rythmes proposés dans les réglages. Le premier,
+// automatique, laisse le serveur déduire le rythme des parties jouées.
+var paceOptions = []struct {
+	label string
+	pace  constants.Pace
+}{
+	{"Automatic (from my games)", ""},
+	{"⚡ Fast", constants.PaceFast},
+	{"Normal", constants.PaceNormal},
+	{"🐢 Relaxed", constants.PaceRelaxed},
+}
+
+// paceLabel retourne le nom affiché d'un rythme
+func paceLabel(pace constants.Pace) string {
+	for _, option := range paceOptions {
+		if option.pace == pace {
+			return option.label
+		}
+	}
+	return string(pace)
+}
+
+// paceSelect est le choix du rythme de jeu dans les réglages. Il est
+// enregistré sur le serveur, donc seulement une fois connecté.
+func (c *Client) paceSelect() *widget.Select {
+	labels := make([]string, len(paceOptions))
+	for i, option := range paceOptions {
+		labels[i] = option.label
+	}
+
+	choice := widget.NewSelect(labels, nil)
+	if c.user == nil || !c.connected {
+		choice.PlaceHolder = "Log in to choose"
+		choice.Disable()
+		return choice
+	}
+	choice.SetSelected(paceLabel(c.user.Pace))
+	choice.OnChanged = func(selected string) {
+		for _, option := range paceOptions {
+			if option.label == selected && option.pace != c.user.Pace {
+				c.user.Pace = option.pace
+				c.send <- &models.NetworkMessage{
+					Type:      constants.MsgSetPace,
+					Payload:   models.SetPacePayload{Pace: option.pace},
+					Timestamp: time.Now(),
+				}
+			}
+		}
+	}
+	return choice
+}
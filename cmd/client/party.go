@@ -0,0 +1,273 @@
+// cmd/client/party.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/client/audio"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/assetpack"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// noPartyPack est le choix "sans pack" du dialogue de thème
+const noPartyPack = "None"
+
+// partyState garde le pack du lobby à thème chargé et le morceau joué,
+// pour ne pas relire l'archive à chaque mise à jour du lobby
+type partyState struct {
+	pack     *assetpack.Pack
+	track    string          // Morceau en cours, "" sans musique de salle
+	fetching map[string]bool // Packs en cours de téléchargement
+	mu       sync.Mutex
+}
+
+// partyPack retourne le pack du thème, chargé depuis les packs téléchargés.
+// Un pack absent est téléchargé en arrière-plan puis le lobby est
+// redessiné; en attendant le résultat est nil.
+func (c *Client) partyPack(name string) *assetpack.Pack {
+	c.party.mu.Lock()
+	defer c.party.mu.Unlock()
+
+	if c.party.pack != nil && c.party.pack.Manifest.Name == name {
+		return c.party.pack
+	}
+
+	path := filepath.Join(c.packsDir(), name+".zip")
+	if pack, err := assetpack.Load(path); err == nil {
+		c.party.pack = pack
+		return pack
+	}
+
+	if c.party.fetching == nil {
+		c.party.fetching = make(map[string]bool)
+	}
+	if !c.party.fetching[name] {
+		c.party.fetching[name] = true
+		go func() {
+			// Un échec n'est pas retenté avant le prochain lancement
+			if err := c.fetchThemePack(c.defaultAssetsURL(), name); err != nil {
+				log.Printf("⚠️ Failed to download lobby theme %s: %v", name, err)
+				return
+			}
+			c.party.mu.Lock()
+			delete(c.party.fetching, name)
+			c.party.mu.Unlock()
+			fyne.Do(c.refreshPartyLobby)
+		}()
+	}
+	return nil
+}
+
+// refreshPartyLobby redessine le lobby si le joueur y est encore, une fois
+// le pack de son thème téléchargé
+func (c *Client) refreshPartyLobby() {
+	c.mu.Lock()
+	waiting := c.gameState != nil && c.gameState.Room != nil && c.gameState.Room.State == constants.StateWaiting
+	c.mu.Unlock()
+	if waiting {
+		c.showLobby()
+	}
+}
+
+// partyBackdrop place le contenu du lobby sur le décor du thème: l'image du
+// pack si elle est disponible, sinon la couleur de fond
+func (c *Client) partyBackdrop(theme *models.LobbyTheme, content fyne.CanvasObject) fyne.CanvasObject {
+	if theme == nil {
+		return content
+	}
+
+	var layers []fyne.CanvasObject
+	if background, ok := parseBackground(theme.Background); ok {
+		layers = append(layers, canvas.NewRectangle(background))
+	}
+	if theme.Pack != "" {
+		if pack := c.partyPack(theme.Pack); pack != nil && pack.Lobby != nil {
+			art := canvas.NewImageFromImage(pack.Lobby)
+			art.FillMode = canvas.ImageFillStretch
+			art.Translucency = 0.3
+			layers = append(layers, art)
+		}
+	}
+	return container.NewStack(append(layers, content)...)
+}
+
+// parseBackground lit une couleur "#rrggbb"
+func parseBackground(hex string) (color.Color, bool) {
+	value, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if len(hex) != 7 || err != nil {
+		return nil, false
+	}
+	return color.NRGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 255}, true
+}
+
+// playPartyMusic joue le morceau du thème dans le lobby. Sans morceau, la
+// musique habituelle reprend si celle d'une salle à thème jouait.
+func (c *Client) playPartyMusic(theme *models.LobbyTheme) {
+	var pack *assetpack.Pack
+	if theme != nil && theme.Track != "" {
+		pack = c.partyPack(theme.Pack)
+	}
+
+	c.party.mu.Lock()
+	previous := c.party.track
+	c.party.track = ""
+	if pack != nil && pack.Music[theme.Track] != nil {
+		c.party.track = theme.Pack + "/" + theme.Track
+	}
+	track := c.party.track
+	c.party.mu.Unlock()
+
+	switch {
+	case track == previous:
+	case track == "":
+		c.playMusicFor(audio.PlaylistMenu)
+	default:
+		c.playPartyTrack(pack, theme.Track, track)
+	}
+}
+
+// playPartyTrack extrait un morceau du pack et le joue en boucle
+func (c *Client) playPartyTrack(pack *assetpack.Pack, track, sound string) {
+	dir := filepath.Join(c.packsDir(), pack.Manifest.Name, "music")
+	path := filepath.Join(dir, track+filepath.Ext(pack.Manifest.Music[track]))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+	if err := os.WriteFile(path, pack.Music[track], 0644); err != nil {
+		log.Printf("⚠️ Failed to extract track %s: %v", sound, err)
+		return
+	}
+	c.audio.LoadSound(sound, path)
+	c.audio.PlayMusic(sound, true)
+}
+
+// stopPartyMusic oublie le morceau de la salle à thème, remplacé par la
+// musique de la partie ou du menu
+func (c *Client) stopPartyMusic() {
+	c.party.mu.Lock()
+	c.party.track = ""
+	c.party.mu.Unlock()
+}
+
+// showLobbyThemeDialog propose à l'hôte les packs du serveur qui ont un
+// décor ou des morceaux, avec une couleur de fond
+func (c *Client) showLobbyThemeDialog(current *models.LobbyTheme) {
+	go func() {
+		var packs []assetpack.Manifest
+		resp, err := c.httpClient(downloadTimeout).Get(c.defaultAssetsURL() + "/packs")
+		if err == nil {
+			json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&packs)
+			resp.Body.Close()
+		} else {
+			log.Printf("⚠️ Failed to list lobby themes: %v", err)
+		}
+
+		fyne.Do(func() {
+			c.lobbyThemeForm(current, partyPacks(packs))
+		})
+	}()
+}
+
+// partyPacks garde les packs qui peuvent habiller un lobby
+func partyPacks(packs []assetpack.Manifest) []assetpack.Manifest {
+	var party []assetpack.Manifest
+	for _, manifest := range packs {
+		if manifest.Lobby != "" || len(manifest.Music) > 0 {
+			party = append(party, manifest)
+		}
+	}
+	return party
+}
+
+// lobbyThemeForm est le formulaire du thème. Tout laisser vide revient au
+// lobby standard. Doit tourner sur le fil de Fyne.
+func (c *Client) lobbyThemeForm(current *models.LobbyTheme, packs []assetpack.Manifest) {
+	if current == nil {
+		current = &models.LobbyTheme{}
+	}
+
+	background := widget.NewEntry()
+	background.SetPlaceHolder("#7b1fa2")
+	background.SetText(current.Background)
+
+	tracks := widget.NewSelect(nil, nil)
+	titles := []string{noPartyPack}
+	for _, manifest := range packs {
+		titles = append(titles, manifest.Title)
+	}
+	var pack *assetpack.Manifest
+	packSelect := widget.NewSelect(titles, func(title string) {
+		pack = nil
+		for i := range packs {
+			if packs[i].Title == title {
+				pack = &packs[i]
+			}
+		}
+		var names []string
+		if pack != nil {
+			for track := range pack.Music {
+				names = append(names, track)
+			}
+		}
+		tracks.Options = names
+		tracks.ClearSelected()
+		tracks.Refresh()
+	})
+	packSelect.SetSelected(noPartyPack)
+	for _, manifest := range packs {
+		if manifest.Name == current.Pack {
+			packSelect.SetSelected(manifest.Title)
+			tracks.SetSelected(current.Track)
+		}
+	}
+
+	form := []*widget.FormItem{
+		widget.NewFormItem("Background", background),
+		widget.NewFormItem("Theme", packSelect),
+		widget.NewFormItem("Music", tracks),
+	}
+	dialog.ShowForm("🎉 Party room", "Apply", "Cancel", form, func(apply bool) {
+		if !apply {
+			return
+		}
+		theme := &models.LobbyTheme{Background: strings.TrimSpace(background.Text)}
+		if theme.Background != "" {
+			if _, ok := parseBackground(theme.Background); !ok {
+				dialog.ShowError(fmt.Errorf("background must look like #7b1fa2"), c.window)
+				return
+			}
+		}
+		if pack != nil {
+			theme.Pack = pack.Name
+			theme.Track = tracks.Selected
+		}
+		c.sendLobbyTheme(theme)
+	}, c.window)
+}
+
+// sendLobbyTheme demande au serveur de changer le thème du lobby, nil pour
+// revenir au lobby standard
+func (c *Client) sendLobbyTheme(theme *models.LobbyTheme) {
+	c.send <- &models.NetworkMessage{
+		Type:      constants.MsgSetLobbyTheme,
+		Payload:   models.SetLobbyThemePayload{Theme: theme},
+		Timestamp: time.Now(),
+	}
+}
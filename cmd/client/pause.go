@@ -0,0 +1,96 @@
+// cmd/client/pause.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/roles"
+)
+
+// canPause indique que le rôle du joueur lui permet de mettre en pause une
+// partie en ligne non classée
+func (c *Client) canPause() bool {
+	if !c.isOnlineGame() || c.user == nil {
+		return false
+	}
+	if c.gameState == nil || c.gameState.Room == nil {
+		return false
+	}
+	room := c.gameState.Room
+	return roles.Can(room, c.user.ID, constants.PermPause) && room.Queue != constants.QueueRanked
+}
+
+// newPauseButton crée le bouton de pause
+func (c *Client) newPauseButton() *widget.Button {
+	c.pauseButton = widget.NewButton("⏸ Pause", func() {
+		msgType := constants.MsgPauseGame
+		if c.paused {
+			msgType = constants.MsgResumeGame
+		}
+		c.send <- &models.NetworkMessage{Type: msgType, Timestamp: time.Now()}
+	})
+	return c.pauseButton
+}
+
+// handleGamePaused fige le plateau pendant la pause
+func (c *Client) handleGamePaused(msg *models.NetworkMessage) {
+	var payload models.GamePausedPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid pause: %v", err)
+		return
+	}
+	c.stopCountdown()
+	c.showPause(true, payload.ResumesAt)
+}
+
+// handleGameResumed rend la main au joueur courant. Le compte à rebours
+// repart avec le TURN_TIMER qui suit.
+func (c *Client) handleGameResumed(msg *models.NetworkMessage) {
+	c.showPause(false, time.Time{})
+}
+
+// showPause applique l'état de pause au plateau. resumesAt est la reprise
+// automatique, zéro si elle n'est pas connue (reprise de session).
+func (c *Client) showPause(paused bool, resumesAt time.Time) {
+	fyne.Do(func() {
+		if paused == c.paused {
+			return
+		}
+		c.paused = paused
+
+		text := "▶ The game resumed."
+		if paused {
+			text = "⏸ The game is paused."
+			if !resumesAt.IsZero() {
+				text += fmt.Sprintf(" It resumes by itself at %s.", resumesAt.Local().Format("15:04"))
+			}
+		}
+		if c.statusLabel != nil {
+			c.statusLabel.SetText(text)
+		}
+		if c.pauseButton != nil {
+			c.pauseButton.SetText("⏸ Pause")
+			if paused {
+				c.pauseButton.SetText("▶ Resume")
+			}
+		}
+
+		if c.diceButton == nil {
+			return
+		}
+		if paused {
+			c.pausedDice = !c.diceButton.Disabled()
+			c.diceButton.Disable()
+		} else if c.pausedDice {
+			c.diceButton.Enable()
+		}
+	})
+}
@@ -0,0 +1,124 @@
+// cmd/client/pawns.go
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"math"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/client/audio"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/moves"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// digitGlyphs sont les chiffres 0 à 9 en 3x5 pixels, une ligne par octet
+// (bit 2 = colonne de gauche)
+var digitGlyphs = [10][5]uint8{
+	{0b111, 0b101, 0b101, 0b101, 0b111},
+	{0b010, 0b110, 0b010, 0b010, 0b111},
+	{0b110, 0b001, 0b010, 0b100, 0b111},
+	{0b110, 0b001, 0b010, 0b001, 0b110},
+	{0b101, 0b101, 0b111, 0b001, 0b001},
+	{0b111, 0b100, 0b110, 0b001, 0b110},
+	{0b011, 0b100, 0b111, 0b101, 0b111},
+	{0b111, 0b001, 0b010, 0b010, 0b010},
+	{0b111, 0b101, 0b111, 0b101, 0b111},
+	{0b111, 0b101, 0b111, 0b001, 0b110},
+}
+
+// pawnLabel nomme un pion comme sur le plateau ("Pawn 3")
+func pawnLabel(tokenIndex int) string {
+	return fmt.Sprintf("Pawn %d", tokenIndex+1)
+}
+
+// drawPawnNumber écrit le numéro du pion (index + 1) au centre du jeton,
+// en blanc avec une ombre pour rester lisible sur toutes les couleurs
+func drawPawnNumber(img *image.NRGBA, cx, cy, cs float64, tokenIndex int) {
+	if tokenIndex < 0 || tokenIndex >= 4 {
+		return
+	}
+
+	// Un pixel du glyphe couvre environ 1/14 de case, au moins 1 pixel
+	px := math.Max(1, math.Round(cs/14))
+	x0 := math.Round(cx - 1.5*px)
+	y0 := math.Round(cy - 2.5*px)
+
+	drawGlyph(img, digitGlyphs[tokenIndex+1], x0+1, y0+1, px, color.NRGBA{0, 0, 0, 180})
+	drawGlyph(img, digitGlyphs[tokenIndex+1], x0, y0, px, color.NRGBA{255, 255, 255, 255})
+}
+
+// drawGlyph dessine un glyphe 3x5 dont le coin haut gauche est en (x0, y0)
+func drawGlyph(img *image.NRGBA, glyph [5]uint8, x0, y0, px float64, c color.NRGBA) {
+	for row, bits := range glyph {
+		for col := 0; col < 3; col++ {
+			if bits&(0b100>>col) == 0 {
+				continue
+			}
+			fillSquare(img, x0+float64(col)*px, y0+float64(row)*px, px, c)
+		}
+	}
+}
+
+func fillSquare(img *image.NRGBA, x0, y0, size float64, c color.NRGBA) {
+	bounds := img.Bounds()
+	for y := int(y0); y < int(y0+size); y++ {
+		for x := int(x0); x < int(x0+size); x++ {
+			if x >= 0 && y >= 0 && x < bounds.Max.X && y < bounds.Max.Y {
+				img.SetNRGBA(x, y, c)
+			}
+		}
+	}
+}
+
+// handleTokenCaptured annonce quel pion a été renvoyé à la base
+func (c *Client) handleTokenCaptured(msg *models.NetworkMessage) {
+	var payload models.TokenCapturedPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid capture: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	var capturer, victim *models.Player
+	if c.gameState != nil && c.gameState.Room != nil {
+		for _, player := range c.gameState.Room.Players {
+			switch player.ID {
+			case payload.CapturedBy:
+				capturer = player
+			case payload.CapturedFrom:
+				victim = player
+			}
+		}
+	}
+	// Le pion pris rentre à sa base une fois le preneur arrivé
+	if token := c.roomToken(payload.CapturedFrom, payload.TokenID); token != nil && token.Position != moves.Base {
+		token.Position = moves.Base
+		c.startFlyBack(token, payload.Position)
+	}
+	c.mu.Unlock()
+	if capturer == nil || victim == nil {
+		return
+	}
+	c.playEvent(audio.EventTokenCapture)
+	c.narrateCapture(capturer, victim, payload.TokenID, payload.Position)
+
+	var text string
+	switch c.user.ID {
+	case victim.ID:
+		text = fmt.Sprintf("💥 Your %s was captured by %s!", pawnLabel(payload.TokenID), capturer.Username)
+	case capturer.ID:
+		text = fmt.Sprintf("💥 Captured %s's %s!", victim.Username, pawnLabel(payload.TokenID))
+	default:
+		text = fmt.Sprintf("💥 %s captured %s's %s", capturer.Username, victim.Username, pawnLabel(payload.TokenID))
+	}
+	log.Printf("💥 Pion %d de %s capturé par %s", payload.TokenID+1, victim.Username, capturer.Username)
+
+	fyne.Do(func() {
+		c.statusLabel.SetText(text)
+	})
+}
@@ -0,0 +1,42 @@
+// cmd/client/preview.go
+package main
+
+import "image/color"
+
+// Transparence de l'aperçu du coup: le fantôme du pion à l'arrivée et les
+// points du trajet
+const (
+	ghostAlpha = 110
+	trailAlpha = 170
+)
+
+// movePreviewMarks montre où mène le pion sélectionné avec le dé courant:
+// un point sur chaque case traversée, un fantôme du pion sur la case
+// d'arrivée, entourée. Rien sous une variante, où seul le serveur connaît
+// l'arrivée. L'appelant doit détenir c.mu.
+func (c *Client) movePreviewMarks(cs float64) []boardMark {
+	player, to, ok := c.selectedDestination()
+	if !ok {
+		return nil
+	}
+	ti := c.selectedToken.TokenIndex
+	from := player.Tokens[ti].Position
+
+	var marks []boardMark
+	trail := trailColor(player.Color)
+	trail.A = trailAlpha
+	cells := journeyCells(from, to, c.currentDice)
+	for _, cell := range cells[1 : len(cells)-1] {
+		px, py := positionPixel(player.Color, ti, cell, cs)
+		marks = append(marks, circleMark(px, py, cs*0.09, trail, color.NRGBA{}, 0))
+	}
+
+	ghost := getColorForPlayerColor(player.Color).(color.NRGBA)
+	ghost.A = ghostAlpha
+	px, py := positionPixel(player.Color, ti, to, cs)
+	marks = append(marks,
+		circleMark(px, py, cs*0.3, ghost, color.NRGBA{0, 0, 0, ghostAlpha}, 1),
+		circleMark(px, py, cs*0.42, color.NRGBA{}, destinationMarkColor, 3),
+	)
+	return marks
+}
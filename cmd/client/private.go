@@ -0,0 +1,63 @@
+// cmd/client/private.go
+package main
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// sendRoomRequest demande à rejoindre ou regarder une salle. Si elle est
+// privée, le serveur refuse et la demande est renvoyée avec le mot de
+// passe saisi (voir askRoomPassword).
+func (c *Client) sendRoomRequest(msgType constants.MessageType, roomCode, password string) {
+	c.mu.Lock()
+	c.passwordRetry = func(password string) {
+		c.sendRoomRequest(msgType, roomCode, password)
+	}
+	c.mu.Unlock()
+
+	payload := models.JoinRoomPayload{
+		RoomID:   roomCode,
+		Password: password,
+		UserID:   c.user.ID,
+		Username: c.user.Username,
+	}
+	c.send <- &models.NetworkMessage{Type: msgType, Payload: payload, Timestamp: time.Now()}
+}
+
+// askRoomPassword demande le mot de passe d'une salle privée quand le
+// serveur le réclame. Retourne false pour les autres erreurs.
+func (c *Client) askRoomPassword(code, message string) bool {
+	if code != constants.ErrRoomPasswordRequired && code != constants.ErrRoomPasswordWrong {
+		return false
+	}
+
+	c.mu.Lock()
+	retry := c.passwordRetry
+	c.mu.Unlock()
+	if retry == nil {
+		return false
+	}
+
+	fyne.Do(func() {
+		password := widget.NewPasswordEntry()
+		password.SetPlaceHolder("Room password")
+		dialog.ShowForm("🔑 Private room", "Enter", "Cancel",
+			[]*widget.FormItem{
+				widget.NewFormItem("", widget.NewLabel(message)),
+				widget.NewFormItem("Password", password),
+			},
+			func(ok bool) {
+				if ok && password.Text != "" {
+					retry(password.Text)
+				}
+			}, c.window)
+	})
+	return true
+}
@@ -0,0 +1,159 @@
+// cmd/client/profile.go
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// requestProfile demande au serveur le profil du joueur
+func (c *Client) requestProfile() {
+	c.requestProfileOf(models.ProfileRequestPayload{UserID: c.user.ID})
+}
+
+// requestProfileOf demande le profil d'un autre joueur, par identifiant ou
+// par nom
+func (c *Client) requestProfileOf(request models.ProfileRequestPayload) {
+	c.send <- &models.NetworkMessage{
+		Type:      constants.MsgGetProfile,
+		Payload:   request,
+		Timestamp: time.Now(),
+	}
+}
+
+// handleProfile affiche le profil reçu: niveau, coins, statistiques et
+// indice de chance
+func (c *Client) handleProfile(msg *models.NetworkMessage) {
+	var payload models.ProfilePayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil || payload.Stats == nil {
+		return
+	}
+	stats := *payload.Stats
+
+	luck := "-"
+	if stats.TotalDiceRolls > 0 {
+		luck = fmt.Sprintf("%.0f (%s)", stats.LuckIndex(), luckVerdict(stats.LuckIndex()))
+	}
+
+	pace := "-"
+	if stats.Decisions > 0 {
+		pace = fmt.Sprintf("%.1fs per move", stats.AvgDecision().Seconds())
+	}
+
+	text := fmt.Sprintf(
+		"⭐ Rating: %s\n"+
+			"Games: %d (%d won, %d lost, %d aborted)\n"+
+			"Win rate: %.1f%%\n"+
+			"Current streak: %d, best: %d\n"+
+			"Tokens captured / lost: %d / %d\n"+
+			"⏱ Average decision time: %s\n\n"+
+			"🎲 Dice rolled: %d, sixes: %d\n"+
+			"🍀 Luck index: %s\n"+
+			"100 is what a fair die gives on average.",
+		ratingText(&stats),
+		stats.TotalGames, stats.GamesWon, stats.GamesLost, stats.GamesAborted,
+		stats.WinRate, stats.CurrentStreak, stats.HighestStreak,
+		stats.TokensCaptured, stats.TokensLost, pace,
+		stats.TotalDiceRolls, stats.SixesRolled, luck,
+	)
+	if len(stats.Rivals) > 0 {
+		text += "\n\n⚔ Biggest rivals:"
+		for _, rival := range stats.Rivals {
+			text += fmt.Sprintf("\n%s: %d–%d in %d games", rival.OpponentName, rival.Wins, rival.Losses, rival.Games)
+		}
+	}
+
+	fyne.Do(func() {
+		name := c.user.Username
+		content := container.NewVBox()
+		if user := payload.User; user != nil {
+			name = user.Username
+			if c.kidMode() {
+				content.Add(widget.NewLabel(fmt.Sprintf("Level %d", user.Level)))
+			} else {
+				content.Add(widget.NewLabel(fmt.Sprintf("Level %d · 🪙 %d coins", user.Level, user.Coins)))
+			}
+			content.Add(experienceBar(user))
+		}
+		content.Add(widget.NewLabel(text))
+		if stats.RankedGames > 0 {
+			content.Add(widget.NewButton("📈 Rating history", func() {
+				c.requestRatingHistory(stats.UserID)
+			}))
+		}
+		if stats.UserID == c.user.ID {
+			content.Add(widget.NewButton("📦 Download my data", c.requestDataExport))
+		}
+		content.Add(widget.NewSeparator())
+		content.Add(c.profileSearch())
+		dialog.ShowCustom("📊 "+name, "Close", content, c.window)
+	})
+}
+
+// experienceBar montre l'avancement vers le niveau suivant
+func experienceBar(user *models.User) fyne.CanvasObject {
+	bar := widget.NewProgressBar()
+	bar.SetValue(user.LevelProgress())
+	bar.TextFormatter = func() string {
+		return fmt.Sprintf("%d / %d XP to level %d", user.Experience%constants.ExperiencePerLevel,
+			constants.ExperiencePerLevel, user.Level+1)
+	}
+	return bar
+}
+
+// profileSearch ouvre le profil d'un joueur d'après son nom
+func (c *Client) profileSearch() fyne.CanvasObject {
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder("Username")
+	search := func() {
+		if username := strings.TrimSpace(entry.Text); username != "" {
+			c.requestProfileOf(models.ProfileRequestPayload{Username: username})
+		}
+	}
+	entry.OnSubmitted = func(string) { search() }
+	return container.NewBorder(nil, nil, nil, widget.NewButton("🔍 View profile", search), entry)
+}
+
+// showPlayerProfile ouvre le profil d'un joueur de la partie. Les IA et les
+// parties hors ligne n'ont pas de profil.
+func (c *Client) showPlayerProfile(player *models.Player) {
+	if player.IsAI || !c.connected || c.user == nil {
+		return
+	}
+	c.requestProfileOf(models.ProfileRequestPayload{UserID: player.ID})
+}
+
+// ratingText présente la cote et son incertitude, avec l'avancement du
+// placement tant qu'il n'est pas terminé
+func ratingText(stats *models.PlayerStats) string {
+	if stats.RatingDeviation == 0 {
+		return "-"
+	}
+	text := fmt.Sprintf("%.0f ± %.0f", stats.Rating, 2*stats.RatingDeviation)
+	if stats.Provisional() {
+		text += fmt.Sprintf(" (placement %d/%d)", stats.RankedGames, constants.PlacementGames)
+	}
+	return text
+}
+
+// luckVerdict résume l'indice de chance en quelques mots
+func luckVerdict(index float64) string {
+	switch {
+	case index >= 115:
+		return "lucky"
+	case index <= 85:
+		return "unlucky"
+	default:
+		return "average"
+	}
+}
@@ -0,0 +1,134 @@
+// cmd/client/ratingchart.go
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// Dimensions de la courbe des cotes, en pixels
+const (
+	chartWidth  = 360
+	chartHeight = 180
+	chartMargin = 10
+)
+
+var (
+	chartLineColor  = color.NRGBA{R: 33, G: 150, B: 243, A: 255}
+	chartDecayColor = color.NRGBA{R: 230, G: 126, B: 34, A: 255}
+	chartAxisColor  = color.NRGBA{R: 128, G: 128, B: 128, A: 160}
+)
+
+// requestRatingHistory demande au serveur l'évolution de la cote d'un joueur
+func (c *Client) requestRatingHistory(userID int64) {
+	c.send <- &models.NetworkMessage{
+		Type:      constants.MsgGetRatingHistory,
+		Payload:   models.RatingHistoryRequestPayload{UserID: userID},
+		Timestamp: time.Now(),
+	}
+}
+
+// handleRatingHistory trace la courbe des cotes reçue
+func (c *Client) handleRatingHistory(msg *models.NetworkMessage) {
+	var payload models.RatingHistoryPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		return
+	}
+
+	fyne.Do(func() {
+		if len(payload.Points) == 0 {
+			dialog.ShowInformation("📈 Rating history", "No ranked game played yet.", c.window)
+			return
+		}
+
+		first, last := payload.Points[0], payload.Points[len(payload.Points)-1]
+		low, high := ratingRange(payload.Points)
+		caption := widget.NewLabel(fmt.Sprintf("%.0f → %.0f over %d entries (range %.0f–%.0f)\nOrange dots mark season decay.",
+			first.Rating, last.Rating, len(payload.Points), low, high))
+
+		content := container.NewVBox(ratingChart(payload.Points), caption)
+		dialog.ShowCustom("📈 Rating history", "Close", content, c.window)
+	})
+}
+
+// ratingRange retourne la plus petite et la plus grande cote de la courbe
+func ratingRange(points []models.RatingPoint) (low, high float64) {
+	low, high = math.Inf(1), math.Inf(-1)
+	for _, point := range points {
+		low = math.Min(low, point.Rating)
+		high = math.Max(high, point.Rating)
+	}
+	return low, high
+}
+
+// ratingChart dessine les cotes de gauche à droite, la plus ancienne en
+// premier. Les points d'érosion de fin de saison sont mis en évidence.
+func ratingChart(points []models.RatingPoint) fyne.CanvasObject {
+	low, high := ratingRange(points)
+	// Une cote constante est tracée au milieu
+	if high-low < 1 {
+		low, high = low-50, high+50
+	}
+
+	plotWidth := float32(chartWidth - 2*chartMargin)
+	plotHeight := float32(chartHeight - 2*chartMargin)
+	position := func(i int, rating float64) fyne.Position {
+		x := float32(chartMargin)
+		if len(points) > 1 {
+			x += plotWidth * float32(i) / float32(len(points)-1)
+		}
+		y := chartMargin + plotHeight*float32((high-rating)/(high-low))
+		return fyne.NewPos(x, y)
+	}
+
+	background := canvas.NewRectangle(color.Transparent)
+	background.StrokeColor = chartAxisColor
+	background.StrokeWidth = 1
+	background.Resize(fyne.NewSize(chartWidth, chartHeight))
+	objects := []fyne.CanvasObject{background}
+
+	// Repère de la cote initiale quand elle est dans l'intervalle
+	if constants.RatingInitial > low && constants.RatingInitial < high {
+		y := position(0, constants.RatingInitial).Y
+		baseline := canvas.NewLine(chartAxisColor)
+		baseline.Position1 = fyne.NewPos(chartMargin, y)
+		baseline.Position2 = fyne.NewPos(chartWidth-chartMargin, y)
+		objects = append(objects, baseline)
+	}
+
+	for i := 1; i < len(points); i++ {
+		segment := canvas.NewLine(chartLineColor)
+		segment.StrokeWidth = 2
+		segment.Position1 = position(i-1, points[i-1].Rating)
+		segment.Position2 = position(i, points[i].Rating)
+		objects = append(objects, segment)
+	}
+
+	for i, point := range points {
+		dotColor := chartLineColor
+		if point.Reason == "decay" {
+			dotColor = chartDecayColor
+		}
+		dot := canvas.NewCircle(dotColor)
+		center := position(i, point.Rating)
+		dot.Move(fyne.NewPos(center.X-3, center.Y-3))
+		dot.Resize(fyne.NewSize(6, 6))
+		objects = append(objects, dot)
+	}
+
+	chart := container.NewWithoutLayout(objects...)
+	// Le conteneur sans disposition n'a pas de taille propre
+	return container.NewGridWrap(fyne.NewSize(chartWidth, chartHeight), chart)
+}
@@ -0,0 +1,82 @@
+// cmd/client/reconnect.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+)
+
+// Reprise automatique après une coupure (voir configs/client.yaml). Les
+// essais restent bien en deçà de la fenêtre de reconnexion du serveur.
+const (
+	reconnectAttempts = 3
+	reconnectDelay    = 2 * time.Second // Multiplié par le numéro de l'essai
+)
+
+// canResume indique si une coupure peut être rattrapée: partie en ligne
+// en cours, jeton de reconnexion et compte déjà vérifié
+func (c *Client) canResume() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.isOnlineGame() && c.sessionToken != "" && c.authToken != ""
+}
+
+// reconnect rouvre la connexion et reprend la place tenue par le serveur.
+// Le serveur renvoie alors l'état complet de la partie.
+func (c *Client) reconnect() {
+	fyne.Do(func() {
+		if c.statusLabel != nil {
+			c.statusLabel.SetText("📡 Connection lost, reconnecting...")
+		}
+	})
+
+	for attempt := 1; attempt <= reconnectAttempts; attempt++ {
+		time.Sleep(reconnectDelay * time.Duration(attempt))
+
+		err := c.connectToServer(c.serverAddress, nil)
+		if err == nil {
+			log.Printf("📡 Reconnected after %d attempt(s)", attempt)
+			return
+		}
+		log.Printf("❌ Reconnect attempt %d/%d failed: %v", attempt, reconnectAttempts, err)
+	}
+
+	fyne.Do(func() {
+		dialog.ShowError(fmt.Errorf("Connection to server lost"), c.window)
+		c.showMainMenu()
+	})
+}
+
+// resumeGame réaffiche la partie reprise au tour où elle en est. Si le
+// joueur avait déjà lancé le dé, il lui reste à jouer ce lancer.
+func (c *Client) resumeGame(awaitingMove bool) {
+	c.showGameBoard()
+
+	c.mu.Lock()
+	room := c.gameState.Room
+	c.isMyTurn = room.Players[room.CurrentTurn].ID == c.user.ID
+	if c.isMyTurn && awaitingMove {
+		c.currentDice = room.LastDice
+	}
+	myTurn, dice := c.isMyTurn, c.currentDice
+	c.mu.Unlock()
+
+	switch {
+	case myTurn && dice > 0:
+		c.diceValue.Text = fmt.Sprintf("%d", dice)
+		c.diceValue.Refresh()
+		c.diceButton.Disable()
+		c.statusLabel.SetText(fmt.Sprintf("📡 Reconnected! You rolled %d, click a pawn", dice))
+	case myTurn:
+		c.diceButton.Enable()
+		c.statusLabel.SetText("📡 Reconnected! Your turn, roll the dice.")
+	default:
+		c.diceButton.Disable()
+		c.statusLabel.SetText("📡 Reconnected! ⏳ Opponent's turn...")
+	}
+	c.refreshBoard()
+}
@@ -0,0 +1,380 @@
+// cmd/client/replay.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/dice"
+)
+
+// replayBoardSize est la taille du plateau dans le lecteur de parties
+const replayBoardSize = 600
+
+// recordTurn ajoute un coup à l'historique de la partie locale.
+// L'appelant doit détenir c.mu.
+func (c *Client) recordTurn(player *models.Player, token *models.Token, from, diceValue int, victim *models.Token) {
+	moved := *token
+	action := models.TurnAction{
+		PlayerID:   player.ID,
+		DiceValue:  diceValue,
+		TokenMoved: &moved,
+		FromPos:    from,
+		ToPos:      token.Position,
+		Timestamp:  time.Now(),
+	}
+	if victim != nil {
+		captured := *victim
+		action.Captured = &captured
+	}
+	c.gameState.TurnHistory = append(c.gameState.TurnHistory, action)
+
+	c.narrateMove(player, token.ID, from, token.Position)
+	if victim != nil {
+		for _, owner := range c.gameState.Room.Players {
+			if owner.Color == victim.Color {
+				c.narrateCapture(player, owner, victim.ID, token.Position)
+			}
+		}
+	}
+}
+
+// recordRoll ajoute un lancer au journal des dés de la partie locale.
+// L'appelant doit détenir c.mu.
+func (c *Client) recordRoll(player *models.Player, value int) {
+	c.gameState.TurnHistory = append(c.gameState.TurnHistory, models.TurnAction{
+		PlayerID:  player.ID,
+		DiceValue: value,
+		Timestamp: time.Now(),
+	})
+	c.narrateRoll(player, value)
+}
+
+// rollAudit résume les lancers de la partie pour vérifier l'équité du dé
+func rollAudit(game *models.Game) string {
+	audit := dice.Audit(game.Rolls())
+	if audit.Total == 0 {
+		return "No dice rolls recorded."
+	}
+
+	text := fmt.Sprintf("%d rolls:", audit.Total)
+	for face, count := range audit.Counts {
+		text += fmt.Sprintf("  %d×%d", face+1, count)
+	}
+	text += fmt.Sprintf("  (χ² %.1f", audit.ChiSquare)
+	if !audit.Plausible() {
+		text += ", unusual for a fair die"
+	}
+	return text + ")"
+}
+
+// replayDir est le dossier des parties enregistrées
+func (c *Client) replayDir() string {
+	return filepath.Join(c.app.Storage().RootURI().Path(), "replays")
+}
+
+// saveReplay enregistre la partie en cours pour le lecteur.
+// L'appelant doit détenir c.mu.
+func (c *Client) saveReplay() {
+	if c.gameState == nil || len(c.gameState.TurnHistory) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(c.gameState)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.replayDir(), 0755); err != nil {
+		log.Printf("⚠️ Failed to save replay: %v", err)
+		return
+	}
+
+	name := fmt.Sprintf("game-%s.json", time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(filepath.Join(c.replayDir(), name), data, 0644); err != nil {
+		log.Printf("⚠️ Failed to save replay: %v", err)
+	}
+}
+
+// showReplays liste les parties enregistrées, les plus récentes en premier
+func (c *Client) showReplays() {
+	paths, _ := filepath.Glob(filepath.Join(c.replayDir(), "game-*.json"))
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+
+	list := widget.NewList(
+		func() int { return len(paths) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(paths[id]), "game-"), ".json")
+			item.(*widget.Label).SetText(name)
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		c.openReplay(paths[id])
+		list.UnselectAll()
+	}
+
+	// Les états exportés par la console développeur se relisent aussi
+	openBtn := widget.NewButton("Open file…", func() {
+		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil || reader == nil {
+				return
+			}
+			reader.Close()
+			c.openReplay(reader.URI().Path())
+		}, c.window)
+	})
+	backBtn := widget.NewButton("Back", func() {
+		c.showMainMenu()
+	})
+
+	var body fyne.CanvasObject = list
+	if len(paths) == 0 {
+		body = widget.NewLabel("No saved games yet. Finished games are saved automatically.")
+	}
+
+	c.setContent(container.NewBorder(
+		widget.NewLabelWithStyle("🎞 Replays", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		container.NewHBox(openBtn, backBtn),
+		nil, nil,
+		body,
+	))
+}
+
+// openReplay charge une partie et ouvre le lecteur
+func (c *Client) openReplay(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		dialog.ShowError(err, c.window)
+		return
+	}
+
+	var game models.Game
+	if err := json.Unmarshal(data, &game); err != nil || game.Room == nil {
+		dialog.ShowError(fmt.Errorf("not a saved game: %s", filepath.Base(path)), c.window)
+		return
+	}
+	if len(game.TurnHistory) == 0 {
+		dialog.ShowInformation("Replay", "This game has no recorded moves.", c.window)
+		return
+	}
+
+	c.showReplayViewer(&game)
+}
+
+// showReplayViewer affiche la partie coup par coup, avec en option le
+// trajet complet de chaque pion
+func (c *Client) showReplayViewer(game *models.Game) {
+	step := len(game.TurnHistory)
+	journeys := true
+
+	board := canvas.NewImageFromImage(renderReplay(game, step, journeys))
+	board.FillMode = canvas.ImageFillContain
+	board.SetMinSize(fyne.NewSize(replayBoardSize, replayBoardSize))
+
+	stepLabel := widget.NewLabel("")
+	redraw := func() {
+		text := fmt.Sprintf("Step %d / %d", step, len(game.TurnHistory))
+		if step > 0 {
+			text += " · " + describeAction(game, game.TurnHistory[step-1])
+		}
+		stepLabel.SetText(text)
+		board.Image = renderReplay(game, step, journeys)
+		board.Refresh()
+	}
+
+	slider := widget.NewSlider(0, float64(len(game.TurnHistory)))
+	slider.Step = 1
+	slider.Value = float64(step)
+	slider.OnChanged = func(value float64) {
+		step = int(value)
+		redraw()
+	}
+
+	journeyCheck := widget.NewCheck("Token journeys", func(on bool) {
+		journeys = on
+		redraw()
+	})
+	journeyCheck.SetChecked(true)
+
+	legend := widget.NewLabel("Trails follow each token's path, ✕ marks a capture.")
+	audit := widget.NewLabel("🎲 " + rollAudit(game))
+
+	window := c.app.NewWindow("Replay - " + game.Room.Name)
+	window.SetContent(container.NewBorder(
+		nil,
+		container.NewVBox(slider, container.NewHBox(stepLabel, journeyCheck, legend), audit),
+		nil, nil,
+		board,
+	))
+	redraw()
+	window.Show()
+}
+
+// describeAction résume un coup en nommant les pions comme sur le plateau
+func describeAction(game *models.Game, action models.TurnAction) string {
+	if action.TokenMoved == nil {
+		return fmt.Sprintf("rolled %d", action.DiceValue)
+	}
+	text := fmt.Sprintf("%s %s +%d", action.TokenMoved.Color, pawnLabel(action.TokenMoved.ID), action.DiceValue)
+	if action.Captured != nil {
+		text += fmt.Sprintf(", captured %s %s", action.Captured.Color, pawnLabel(action.Captured.ID))
+	}
+	return text
+}
+
+// replayToken identifie un pion dans l'historique
+type replayToken struct {
+	color constants.PlayerColor
+	id    int
+}
+
+// renderReplay dessine la position après step coups et, si demandé, les
+// trajets parcourus jusque-là
+func renderReplay(game *models.Game, step int, journeys bool) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, replayBoardSize, replayBoardSize))
+	draw.Draw(img, img.Bounds(), &image.Uniform{color.NRGBA{255, 255, 255, 255}}, image.Point{}, draw.Src)
+
+	cs := float64(replayBoardSize) / float64(BOARD_GRID)
+	drawBoardBackground(img, cs)
+
+	colors := make(map[int64]constants.PlayerColor)
+	positions := make(map[replayToken]int)
+	for _, player := range game.Room.Players {
+		colors[player.ID] = player.Color
+		for i := range player.Tokens {
+			positions[replayToken{player.Color, i}] = -1
+		}
+	}
+
+	for _, action := range game.TurnHistory[:step] {
+		if action.TokenMoved == nil {
+			continue
+		}
+		playerColor := colors[action.PlayerID]
+		moved := replayToken{playerColor, action.TokenMoved.ID}
+
+		if journeys {
+			drawJourney(img, playerColor, moved.id, journeyCells(action.FromPos, action.ToPos, action.DiceValue), cs)
+		}
+		positions[moved] = action.ToPos
+
+		if action.Captured != nil {
+			positions[replayToken{action.Captured.Color, action.Captured.ID}] = -1
+			if journeys {
+				drawCaptureMarker(img, playerColor, moved.id, action.ToPos, cs)
+			}
+		}
+	}
+
+	drawCompleteGrid(img, replayBoardSize, replayBoardSize, cs)
+
+	for _, player := range game.Room.Players {
+		pColor := getColorForPlayerColor(player.Color).(color.NRGBA)
+		for i := range player.Tokens {
+			px, py := positionPixel(player.Color, i, positions[replayToken{player.Color, i}], cs)
+			drawCircle(img, px, py, cs*0.3, pColor)
+			drawCircleOutline(img, px, py, cs*0.3, color.NRGBA{0, 0, 0, 200}, 2)
+			drawPawnNumber(img, px, py, cs, i)
+		}
+	}
+
+	return img
+}
+
+// journeyCells retourne les cases traversées par un coup, départ compris.
+// Sortie de base: la base puis la case de départ. Entrée dans la maison: le
+// reste du dé après le chemin sert aux cases de la maison.
+func journeyCells(from, to, diceValue int) []int {
+	cells := []int{from}
+	if from == -1 || from >= PATH_LEN {
+		if from >= PATH_LEN {
+			for pos := from + 1; pos <= to; pos++ {
+				cells = append(cells, pos)
+			}
+			return cells
+		}
+		return append(cells, to)
+	}
+
+	pathSteps := diceValue
+	if to >= PATH_LEN {
+		pathSteps = diceValue - (to - PATH_LEN) - 1
+	}
+
+	pos := from
+	for i := 0; i < pathSteps && i < PATH_LEN; i++ {
+		pos = (pos + 1) % PATH_LEN
+		cells = append(cells, pos)
+	}
+	for home := PATH_LEN; home <= to; home++ {
+		cells = append(cells, home)
+	}
+	if cells[len(cells)-1] != to {
+		cells = append(cells, to)
+	}
+	return cells
+}
+
+// trailOffset décale le tracé de chaque pion pour que les trajets
+// superposés restent lisibles
+func trailOffset(tokenID int, cs float64) (float64, float64) {
+	dx := []float64{-1, 1, -1, 1}
+	dy := []float64{-1, -1, 1, 1}
+	i := tokenID % 4
+	return dx[i] * cs * 0.12, dy[i] * cs * 0.12
+}
+
+// drawJourney trace le trajet d'un coup dans la couleur du joueur
+func drawJourney(img *image.NRGBA, playerColor constants.PlayerColor, tokenID int, cells []int, cs float64) {
+	trail := trailColor(playerColor)
+	ox, oy := trailOffset(tokenID, cs)
+
+	for i := 1; i < len(cells); i++ {
+		x0, y0 := positionPixel(playerColor, tokenID, cells[i-1], cs)
+		x1, y1 := positionPixel(playerColor, tokenID, cells[i], cs)
+		drawThickLine(img, x0+ox, y0+oy, x1+ox, y1+oy, cs*0.07, trail)
+	}
+}
+
+// drawCaptureMarker dessine une croix à l'emplacement d'une capture
+func drawCaptureMarker(img *image.NRGBA, playerColor constants.PlayerColor, tokenID, position int, cs float64) {
+	px, py := positionPixel(playerColor, tokenID, position, cs)
+	size := cs * 0.35
+	black := color.NRGBA{0, 0, 0, 255}
+	drawThickLine(img, px-size, py-size, px+size, py+size, cs*0.08, black)
+	drawThickLine(img, px-size, py+size, px+size, py-size, cs*0.08, black)
+}
+
+// drawThickLine trace un segment en posant des disques le long du trajet
+func drawThickLine(img *image.NRGBA, x0, y0, x1, y1, width float64, c color.NRGBA) {
+	length := math.Hypot(x1-x0, y1-y0)
+	steps := int(length/(width/2)) + 1
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		drawCircle(img, x0+(x1-x0)*t, y0+(y1-y0)*t, width, c)
+	}
+}
+
+// trailColor assombrit la couleur du joueur pour la distinguer des cases
+func trailColor(playerColor constants.PlayerColor) color.NRGBA {
+	base := getColorForPlayerColor(playerColor).(color.NRGBA)
+	return color.NRGBA{base.R * 3 / 4, base.G * 3 / 4, base.B * 3 / 4, 255}
+}
@@ -0,0 +1,93 @@
+// cmd/client/rivalries.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// missingRivalries retourne les adversaires du lobby dont le bilan n'a pas
+// encore été demandé, et les note comme demandés. L'appelant doit détenir
+// c.mu.
+func (c *Client) missingRivalries(room *models.Room) []int64 {
+	if c.user == nil {
+		return nil
+	}
+	if c.rivalries == nil {
+		c.rivalries = make(map[int64]*models.Rivalry)
+	}
+
+	var missing []int64
+	for _, player := range room.Players {
+		if player.IsAI || player.ID == c.user.ID {
+			continue
+		}
+		if _, asked := c.rivalries[player.ID]; !asked {
+			c.rivalries[player.ID] = nil
+			missing = append(missing, player.ID)
+		}
+	}
+	return missing
+}
+
+// requestRivalries demande au serveur le bilan contre ces adversaires
+func (c *Client) requestRivalries(opponents []int64) {
+	if len(opponents) == 0 {
+		return
+	}
+	c.send <- &models.NetworkMessage{
+		Type:      constants.MsgGetRivalries,
+		Payload:   models.RivalriesRequestPayload{OpponentIDs: opponents},
+		Timestamp: time.Now(),
+	}
+}
+
+// handleRivalries retient les bilans reçus et redessine le lobby
+func (c *Client) handleRivalries(msg *models.NetworkMessage) {
+	var payload models.RivalriesPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid rivalries: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	if c.rivalries == nil {
+		c.rivalries = make(map[int64]*models.Rivalry)
+	}
+	for i := range payload.Records {
+		record := payload.Records[i]
+		c.rivalries[record.OpponentID] = &record
+	}
+	waiting := c.gameState != nil && c.gameState.Room != nil && c.gameState.Room.State == constants.StateWaiting
+	c.mu.Unlock()
+
+	if waiting && len(payload.Records) > 0 {
+		fyne.Do(c.showLobby)
+	}
+}
+
+// forgetRivalries oublie les bilans connus, changés par la partie terminée
+func (c *Client) forgetRivalries() {
+	c.mu.Lock()
+	c.rivalries = nil
+	c.mu.Unlock()
+}
+
+// rivalryLines retourne le bilan contre chaque adversaire déjà affronté du
+// lobby. L'appelant doit détenir c.mu.
+func (c *Client) rivalryLines(room *models.Room) []string {
+	var lines []string
+	for _, player := range room.Players {
+		if record := c.rivalries[player.ID]; record != nil && record.Games > 0 {
+			lines = append(lines, fmt.Sprintf("⚔ You vs %s: %d–%d", player.Username, record.Wins, record.Losses))
+		}
+	}
+	return lines
+}
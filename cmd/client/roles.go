@@ -0,0 +1,136 @@
+// cmd/client/roles.go
+package main
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/roles"
+)
+
+// roleLabels associe les libellés du lobby aux rôles d'une salle
+var roleLabels = map[constants.RoomRole]string{
+	constants.RoleHost:      "👑 Host",
+	constants.RoleModerator: "🛡 Moderator",
+	constants.RoleMember:    "Member",
+	constants.RoleSpectator: "👁 Spectator",
+}
+
+// permissionLabels associe les libellés du lobby aux permissions
+var permissionLabels = map[constants.Permission]string{
+	constants.PermKick:         "Kick players",
+	constants.PermChangeRules:  "Change colors and handicaps",
+	constants.PermModerateChat: "Mute players in chat",
+	constants.PermStartGame:    "Start the game",
+	constants.PermPause:        "Pause the game",
+	constants.PermManageRoles:  "Manage roles",
+}
+
+// roleBadge retourne le badge affiché à côté d'un joueur: rôle s'il en a
+// un particulier, et sourdine
+func roleBadge(room *models.Room, playerID int64) string {
+	badge := ""
+	if role := roles.RoleOf(room, playerID); role != constants.RoleMember {
+		badge = roleLabels[role]
+	}
+	if room.Muted[playerID] {
+		badge += " 🔇"
+	}
+	return badge
+}
+
+// roleControl permet à l'hôte de nommer un joueur modérateur ou de le
+// rendre membre. L'appelant doit détenir c.mu.
+func (c *Client) roleControl(room *models.Room, player *models.Player) fyne.CanvasObject {
+	labels := make([]string, len(roles.Configurable))
+	for i, role := range roles.Configurable {
+		labels[i] = roleLabels[role]
+	}
+	playerID := player.ID
+	selector := widget.NewSelect(labels, nil)
+	selector.SetSelected(roleLabels[roles.RoleOf(room, playerID)])
+	selector.OnChanged = func(selected string) {
+		for _, role := range roles.Configurable {
+			if roleLabels[role] == selected {
+				c.send <- &models.NetworkMessage{
+					Type:      constants.MsgSetRole,
+					Payload:   models.SetRolePayload{PlayerID: playerID, Role: role},
+					Timestamp: time.Now(),
+				}
+			}
+		}
+	}
+	return selector
+}
+
+// muteButton retire ou rend la parole à un joueur dans le chat de la salle
+func (c *Client) muteButton(player *models.Player, muted bool) fyne.CanvasObject {
+	text := "🔇 Mute"
+	if muted {
+		text = "🔊 Unmute"
+	}
+	playerID := player.ID
+	return widget.NewButton(text, func() {
+		c.send <- &models.NetworkMessage{
+			Type:      constants.MsgMutePlayer,
+			Payload:   models.MutePlayerPayload{PlayerID: playerID, Muted: !muted},
+			Timestamp: time.Now(),
+		}
+	})
+}
+
+// showPermissionsDialog permet à l'hôte de choisir les permissions des
+// modérateurs et des membres. Doit tourner sur le fil de Fyne.
+func (c *Client) showPermissionsDialog() {
+	c.mu.Lock()
+	if c.gameState == nil || c.gameState.Room == nil {
+		c.mu.Unlock()
+		return
+	}
+	room := c.gameState.Room
+	checks := make(map[constants.RoomRole]map[constants.Permission]*widget.Check)
+	form := container.NewVBox()
+	for _, role := range roles.Configurable {
+		granted := make(map[constants.Permission]bool)
+		for _, perm := range roles.Permissions(room, role) {
+			granted[perm] = true
+		}
+		checks[role] = make(map[constants.Permission]*widget.Check)
+		form.Add(widget.NewLabelWithStyle(roleLabels[role], fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		for _, perm := range roles.All {
+			if perm == constants.PermManageRoles {
+				continue
+			}
+			check := widget.NewCheck(permissionLabels[perm], nil)
+			check.SetChecked(granted[perm])
+			checks[role][perm] = check
+			form.Add(check)
+		}
+	}
+	c.mu.Unlock()
+
+	dialog.ShowCustomConfirm("⚙ Permissions", "Save", "Cancel", form, func(ok bool) {
+		if !ok {
+			return
+		}
+		for _, role := range roles.Configurable {
+			perms := []constants.Permission{}
+			for _, perm := range roles.All {
+				if check := checks[role][perm]; check != nil && check.Checked {
+					perms = append(perms, perm)
+				}
+			}
+			c.send <- &models.NetworkMessage{
+				Type:      constants.MsgSetPermissions,
+				Payload:   models.SetPermissionsPayload{Role: role, Permissions: perms},
+				Timestamp: time.Now(),
+			}
+		}
+	}, c.window)
+}
@@ -0,0 +1,94 @@
+// cmd/client/rolloff.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// Rythme de la cérémonie d'ouverture
+const (
+	rollOffRoundDelay = 900 * time.Millisecond
+	rollOffCloseDelay = 1500 * time.Millisecond
+)
+
+// handleRollOff rejoue le tirage du premier joueur, manche par manche
+func (c *Client) handleRollOff(msg *models.NetworkMessage) {
+	var payload models.RollOffPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil || len(payload.Rounds) == 0 {
+		log.Printf("❌ Invalid roll-off: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	names := make(map[int64]string)
+	if c.gameState != nil && c.gameState.Room != nil {
+		for _, player := range c.gameState.Room.Players {
+			names[player.ID] = player.Username
+		}
+	}
+	c.mu.Unlock()
+
+	starter := names[payload.StarterID]
+	log.Printf("🎲 Tirage d'ouverture: %s commence après %d manche(s)", starter, len(payload.Rounds))
+
+	// En mode économe, toutes les manches s'affichent d'un coup
+	roundDelay := rollOffRoundDelay
+	if !c.renderQuality().animate {
+		roundDelay = 0
+	}
+
+	fyne.Do(func() {
+		lines := container.NewVBox()
+		ceremony := dialog.NewCustomWithoutButtons("🎲 Who starts?", lines, c.window)
+		ceremony.Show()
+
+		// Une manche de plus à chaque roundDelay, puis le vainqueur
+		shown := 0
+		reveal := time.Duration(len(payload.Rounds)) * roundDelay
+		revealed := false
+		c.animate(func(elapsed time.Duration) bool {
+			for shown < len(payload.Rounds) && elapsed >= time.Duration(shown)*roundDelay {
+				text := rollOffRoundText(shown, payload.Rounds[shown], names)
+				if shown < len(payload.Rounds)-1 {
+					text += "  →  tie, re-roll!"
+				}
+				fyne.Do(func() {
+					lines.Add(widget.NewLabel(text))
+				})
+				shown++
+			}
+			if !revealed && elapsed >= reveal {
+				revealed = true
+				fyne.Do(func() {
+					lines.Add(widget.NewLabelWithStyle(fmt.Sprintf("🏁 %s starts!", starter),
+						fyne.TextAlignCenter, fyne.TextStyle{Bold: true}))
+				})
+			}
+			if elapsed >= reveal+rollOffCloseDelay {
+				fyne.Do(ceremony.Hide)
+				return false
+			}
+			return true
+		})
+	})
+}
+
+// rollOffRoundText résume une manche ("Round 1: Alice 5 · Bob 3")
+func rollOffRoundText(index int, round []models.RollOffRoll, names map[int64]string) string {
+	rolls := make([]string, len(round))
+	for i, roll := range round {
+		rolls[i] = fmt.Sprintf("%s 🎲%d", names[roll.PlayerID], roll.Value)
+	}
+	return fmt.Sprintf("Round %d: %s", index+1, strings.Join(rolls, " · "))
+}
@@ -0,0 +1,189 @@
+// cmd/client/rooms.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// roomBrowserView est l'écran des salles publiques. Ses champs sont
+// protégés par c.mu.
+type roomBrowserView struct {
+	rooms  []models.RoomSummary
+	list   *widget.List
+	status *widget.Label
+}
+
+// showRoomBrowser liste les salles publiques qui attendent des joueurs
+func (c *Client) showRoomBrowser() {
+	view := &roomBrowserView{status: widget.NewLabel("Loading...")}
+	view.list = widget.NewList(
+		func() int {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return len(view.rooms)
+		},
+		func() fyne.CanvasObject {
+			return container.NewBorder(nil, nil, nil, widget.NewButton("Join", nil), widget.NewLabel(""))
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			c.mu.Lock()
+			if id >= len(view.rooms) {
+				c.mu.Unlock()
+				return
+			}
+			summary := view.rooms[id]
+			c.mu.Unlock()
+
+			row := item.(*fyne.Container)
+			row.Objects[0].(*widget.Label).SetText(roomLine(summary))
+			row.Objects[1].(*widget.Button).OnTapped = func() {
+				c.joinListedRoom(summary)
+			}
+		},
+	)
+
+	refreshBtn := widget.NewButton("🔄 Refresh", func() {
+		c.requestRoomList()
+	})
+	backBtn := widget.NewButton("Back", func() {
+		c.closeRoomBrowser()
+		c.showFriendsMenu()
+	})
+
+	c.mu.Lock()
+	c.roomBrowser = view
+	c.mu.Unlock()
+	c.requestRoomList()
+
+	c.setContent(container.NewBorder(
+		widget.NewLabelWithStyle("🌐 Public Rooms", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		container.NewHBox(backBtn, refreshBtn, view.status),
+		nil, nil,
+		view.list,
+	))
+}
+
+// closeRoomBrowser oublie l'écran des salles: les listes reçues ensuite
+// sont ignorées
+func (c *Client) closeRoomBrowser() {
+	c.mu.Lock()
+	c.roomBrowser = nil
+	c.mu.Unlock()
+}
+
+// requestRoomList demande la liste des salles au serveur
+func (c *Client) requestRoomList() {
+	c.mu.Lock()
+	if view := c.roomBrowser; view != nil {
+		view.status.SetText("Loading...")
+	}
+	c.mu.Unlock()
+
+	c.send <- &models.NetworkMessage{Type: constants.MsgListRooms, Timestamp: time.Now()}
+}
+
+// handleRoomList affiche la liste reçue, si l'écran des salles est ouvert
+func (c *Client) handleRoomList(msg *models.NetworkMessage) {
+	var payload models.RoomListPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid room list: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	view := c.roomBrowser
+	if view != nil {
+		view.rooms = payload.Rooms
+	}
+	c.mu.Unlock()
+	if view == nil {
+		return
+	}
+
+	status := fmt.Sprintf("%d open rooms", len(payload.Rooms))
+	if len(payload.Rooms) == 0 {
+		status = "No open room, create one!"
+	}
+	fyne.Do(func() {
+		view.status.SetText(status)
+		view.list.Refresh()
+	})
+}
+
+// roomLine décrit une salle de la liste: nom, hôte, places et options
+func roomLine(summary models.RoomSummary) string {
+	var tags []string
+	if summary.BestOf > 0 {
+		tags = append(tags, fmt.Sprintf("best of %d", summary.BestOf))
+	}
+	if summary.RuleSet == constants.RuleSetQuick {
+		tags = append(tags, "⚡ quick")
+	}
+	if summary.HouseRules {
+		tags = append(tags, "🏠 house rules")
+	}
+	if summary.Variant {
+		tags = append(tags, "custom rules")
+	}
+	if summary.SkillDice {
+		tags = append(tags, "skill dice")
+	}
+	if summary.E2EChat {
+		tags = append(tags, "🔒 chat")
+	}
+	if summary.Party {
+		tags = append(tags, "🎉 party")
+	}
+
+	line := fmt.Sprintf("%s — host %s — 👥 %d/%d", summary.Name, summary.HostName, summary.Players, summary.MaxPlayers)
+	if len(tags) > 0 {
+		line += " · " + strings.Join(tags, ", ")
+	}
+	return line
+}
+
+// joinListedRoom rejoint une salle de la liste. Une salle à chat chiffré
+// demande d'abord le mot de passe du chat.
+func (c *Client) joinListedRoom(summary models.RoomSummary) {
+	if !summary.E2EChat {
+		c.closeRoomBrowser()
+		c.joinRoom(summary.ID, "")
+		return
+	}
+
+	password := widget.NewPasswordEntry()
+	dialog.ShowForm("🔒 Encrypted chat", "Join", "Cancel",
+		[]*widget.FormItem{widget.NewFormItem("Chat password", password)},
+		func(ok bool) {
+			if ok {
+				c.closeRoomBrowser()
+				c.joinRoom(summary.ID, password.Text)
+			}
+		}, c.window)
+}
+
+// Libellés des jeux de règles à la création d'une salle
+const (
+	ruleSetClassicLabel = "Classic (4 pawns)"
+	ruleSetQuickLabel   = "⚡ Quick (2 pawns, first home wins)"
+)
+
+// ruleSetFromLabel retrouve le jeu de règles choisi dans la liste
+func ruleSetFromLabel(label string) constants.RuleSet {
+	if label == ruleSetQuickLabel {
+		return constants.RuleSetQuick
+	}
+	return constants.RuleSetClassic
+}
@@ -0,0 +1,481 @@
+// cmd/client/scene.go
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// pawnExtent est le côté du widget d'un pion, en tailles de pion: de quoi
+// contenir l'anneau de survol
+const pawnExtent = 0.9
+
+// Couleurs des pions et de leurs anneaux
+var (
+	selectedPawnColor = color.NRGBA{255, 255, 0, 255}   // Pion sélectionné
+	movableRingColor  = color.NRGBA{0, 255, 0, 255}     // Pion qui peut jouer le dé
+	premoveRingColor  = color.NRGBA{0, 200, 255, 255}   // Pion présélectionné
+	hoverHaloColor    = color.NRGBA{255, 255, 255, 200} // Pion sous la souris
+	pawnOutlineColor  = color.NRGBA{0, 0, 0, 200}
+	pawnShadowColor   = color.NRGBA{0, 0, 0, 60}
+	pawnShineColor    = color.NRGBA{255, 255, 255, 120}
+)
+
+// boardScene est le plateau affiché, en couches d'objets Fyne: l'image du
+// fond (cases, skin du thème, grille), redessinée seulement quand sa taille
+// ou ses réglages changent, puis les repères (barrages, pastilles, alertes)
+// et un widget par pion. Un rafraîchissement ne touche que ce qui a changé.
+type boardScene struct {
+	mu            sync.Mutex
+	backgroundKey string // Réglages du fond affiché
+
+	marks    *fyne.Container
+	markList []boardMark
+	pawns    *fyne.Container
+	pawnList []*pawnWidget
+}
+
+// newBoardScene crée les couches vides d'un plateau de la taille donnée
+func newBoardScene(size float32) *boardScene {
+	scene := &boardScene{
+		marks: container.NewWithoutLayout(),
+		pawns: container.NewWithoutLayout(),
+	}
+	scene.marks.Resize(fyne.NewSize(size, size))
+	scene.pawns.Resize(fyne.NewSize(size, size))
+	return scene
+}
+
+// boardMark est un repère posé sur le plateau: un disque ou un anneau,
+// avec un texte éventuel
+type boardMark struct {
+	x, y, radius float32
+	fill         color.NRGBA
+	stroke       color.NRGBA
+	strokeWidth  float32
+	text         string
+}
+
+// circleMark crée un repère en forme de disque ou d'anneau
+func circleMark(x, y, radius float64, fill, stroke color.NRGBA, strokeWidth float32) boardMark {
+	return boardMark{x: float32(x), y: float32(y), radius: float32(radius), fill: fill, stroke: stroke, strokeWidth: strokeWidth}
+}
+
+// objects crée les objets Fyne du repère
+func (m boardMark) objects() []fyne.CanvasObject {
+	circle := canvas.NewCircle(m.fill)
+	circle.StrokeColor = m.stroke
+	circle.StrokeWidth = m.strokeWidth
+	circle.Move(fyne.NewPos(m.x-m.radius, m.y-m.radius))
+	circle.Resize(fyne.NewSize(2*m.radius, 2*m.radius))
+	if m.text == "" {
+		return []fyne.CanvasObject{circle}
+	}
+
+	text := canvas.NewText(m.text, color.White)
+	text.TextStyle = fyne.TextStyle{Bold: true}
+	text.TextSize = m.radius * 1.4
+	text.Alignment = fyne.TextAlignCenter
+	size := text.MinSize()
+	text.Move(fyne.NewPos(m.x-size.Width/2, m.y-size.Height/2))
+	text.Resize(size)
+	return []fyne.CanvasObject{circle, text}
+}
+
+// setMarks remplace les repères affichés s'ils ont changé. Fil de Fyne.
+func (s *boardScene) setMarks(marks []boardMark) {
+	if sameMarks(s.markList, marks) {
+		return
+	}
+	s.markList = marks
+	objects := make([]fyne.CanvasObject, 0, len(marks))
+	for _, mark := range marks {
+		objects = append(objects, mark.objects()...)
+	}
+	s.marks.Objects = objects
+	s.marks.Refresh()
+}
+
+func sameMarks(a, b []boardMark) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// setPawns applique l'apparence de chaque pion. Seuls les widgets dont
+// l'apparence change sont redessinés. Fil de Fyne.
+func (s *boardScene) setPawns(c *Client, looks []pawnLook) {
+	if len(looks) != len(s.pawnList) {
+		s.pawnList = s.pawnList[:0]
+		objects := make([]fyne.CanvasObject, 0, len(looks))
+		for range looks {
+			pawn := newPawnWidget(c)
+			s.pawnList = append(s.pawnList, pawn)
+			objects = append(objects, pawn)
+		}
+		s.pawns.Objects = objects
+		s.pawns.Refresh()
+	}
+	for i, look := range looks {
+		s.pawnList[i].set(look)
+	}
+}
+
+// pawnLook est l'apparence d'un pion à l'écran
+type pawnLook struct {
+	player, token int     // Index du joueur et du pion dans la partie
+	x, y, size    float32 // Centre et taille, réduite sur une case partagée
+	fill          color.NRGBA
+	sprite        *image.NRGBA // Pion du thème, nil = pion dessiné
+	selected      bool
+	ring          color.NRGBA
+	ringRadius    float32 // En tailles de pion, 0 = sans anneau
+	mine          bool    // Pion du joueur local, qu'il peut toucher
+	details       bool    // Ombre et reflet, sauf en mode économe
+}
+
+// pawnWidget affiche un pion. Le toucher le désigne directement; le
+// survoler l'entoure d'un halo quand il appartient au joueur.
+type pawnWidget struct {
+	widget.BaseWidget
+	c       *Client
+	look    pawnLook
+	hovered bool
+}
+
+func newPawnWidget(c *Client) *pawnWidget {
+	p := &pawnWidget{c: c}
+	p.ExtendBaseWidget(p)
+	p.Hide()
+	return p
+}
+
+// set applique une apparence, sans rien redessiner si elle n'a pas changé
+func (p *pawnWidget) set(look pawnLook) {
+	if look == p.look && p.Visible() {
+		return
+	}
+	side := look.size * pawnExtent
+	p.look = look
+	p.Move(fyne.NewPos(look.x-side/2, look.y-side/2))
+	p.Resize(fyne.NewSize(side, side))
+	p.Show()
+	p.Refresh()
+}
+
+// Tapped joue ou présélectionne le pion du joueur; sous un pion adverse,
+// le clic vaut pour la case
+func (p *pawnWidget) Tapped(event *fyne.PointEvent) {
+	if p.look.mine {
+		p.c.onPawnTapped(p.look.token)
+		return
+	}
+	p.c.onBoardTapped(p.Position().Add(event.Position))
+}
+
+// MouseIn allume le halo d'un pion du joueur
+func (p *pawnWidget) MouseIn(*desktop.MouseEvent) {
+	p.hovered = true
+	if p.look.mine {
+		p.Refresh()
+	}
+}
+
+// MouseMoved fait partie de desktop.Hoverable
+func (p *pawnWidget) MouseMoved(*desktop.MouseEvent) {}
+
+// MouseOut éteint le halo
+func (p *pawnWidget) MouseOut() {
+	p.hovered = false
+	if p.look.mine {
+		p.Refresh()
+	}
+}
+
+// Cursor montre la main sur les pions du joueur
+func (p *pawnWidget) Cursor() desktop.Cursor {
+	if p.look.mine {
+		return desktop.PointerCursor
+	}
+	return desktop.DefaultCursor
+}
+
+func (p *pawnWidget) CreateRenderer() fyne.WidgetRenderer {
+	r := &pawnRenderer{
+		p:      p,
+		shadow: canvas.NewCircle(pawnShadowColor),
+		body:   canvas.NewCircle(color.Transparent),
+		sprite: canvas.NewImageFromImage(nil),
+		shine:  canvas.NewCircle(pawnShineColor),
+		number: canvas.NewText("", color.White),
+		ring:   canvas.NewCircle(color.Transparent),
+		halo:   canvas.NewCircle(color.Transparent),
+	}
+	r.sprite.FillMode = canvas.ImageFillContain
+	r.number.TextStyle = fyne.TextStyle{Bold: true}
+	r.number.Alignment = fyne.TextAlignCenter
+	r.ring.StrokeWidth = 3
+	r.halo.StrokeColor = hoverHaloColor
+	r.halo.StrokeWidth = 2
+	r.Refresh()
+	return r
+}
+
+type pawnRenderer struct {
+	p                               *pawnWidget
+	shadow, body, shine, ring, halo *canvas.Circle
+	sprite                          *canvas.Image
+	number                          *canvas.Text
+}
+
+// Layout place les disques du pion autour du centre du widget
+func (r *pawnRenderer) Layout(size fyne.Size) {
+	ts := size.Width / pawnExtent
+	c := size.Width / 2
+	place := func(obj fyne.CanvasObject, dx, dy, radius float32) {
+		obj.Move(fyne.NewPos(c+dx-radius, c+dy-radius))
+		obj.Resize(fyne.NewSize(2*radius, 2*radius))
+	}
+
+	look := r.p.look
+	bodyRadius := ts * 0.3
+	if look.sprite != nil {
+		// Sous un pion du thème, le disque ne marque que la sélection
+		bodyRadius = ts * 0.35
+	}
+	place(r.shadow, 2, 2, ts*0.3)
+	place(r.body, 0, 0, bodyRadius)
+	place(r.sprite, 0, 0, ts*0.35)
+	place(r.shine, -ts*0.08, -ts*0.08, ts*0.1)
+	place(r.ring, 0, 0, ts*look.ringRadius)
+	place(r.halo, 0, 0, ts*0.44)
+
+	r.number.TextSize = ts * 0.3
+	textSize := r.number.MinSize()
+	r.number.Move(fyne.NewPos(c-textSize.Width/2, c-textSize.Height/2))
+	r.number.Resize(textSize)
+}
+
+func (r *pawnRenderer) MinSize() fyne.Size { return fyne.NewSize(1, 1) }
+
+// Refresh reporte l'apparence du pion sur ses objets
+func (r *pawnRenderer) Refresh() {
+	look := r.p.look
+	drawn := look.sprite == nil
+	selected := look.selected
+
+	r.body.FillColor = look.fill
+	r.body.StrokeColor = pawnOutlineColor
+	r.body.StrokeWidth = 2
+	if !drawn {
+		r.body.StrokeWidth = 0
+	}
+	setVisible(r.body, drawn || selected)
+	setVisible(r.sprite, !drawn)
+	if !drawn && r.sprite.Image != look.sprite {
+		r.sprite.Image = look.sprite
+	}
+	setVisible(r.shadow, look.details)
+	setVisible(r.shine, drawn && look.details)
+
+	r.number.Text = fmt.Sprint(look.token + 1)
+	r.ring.StrokeColor = look.ring
+	setVisible(r.ring, look.ringRadius > 0)
+	setVisible(r.halo, r.p.hovered && look.mine)
+
+	r.Layout(r.p.Size())
+	for _, obj := range r.Objects() {
+		obj.Refresh()
+	}
+}
+
+func (r *pawnRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.shadow, r.body, r.sprite, r.shine, r.number, r.ring, r.halo}
+}
+
+func (r *pawnRenderer) Destroy() {}
+
+func setVisible(obj fyne.CanvasObject, visible bool) {
+	if visible {
+		obj.Show()
+	} else {
+		obj.Hide()
+	}
+}
+
+// pawnLooks calcule l'apparence de tous les pions de la partie, cs étant
+// la taille d'une case à l'écran. c.mu doit être tenu.
+func (c *Client) pawnLooks(cs float64) []pawnLook {
+	if c.gameState == nil || c.gameState.Room == nil {
+		return nil
+	}
+
+	details := c.renderQuality().details
+	spriteSize := int(float64(c.boardPixels()) / float64(BOARD_GRID) * 0.7)
+	var looks []pawnLook
+	for pi, player := range c.gameState.Room.Players {
+		pColor := getColorForPlayerColor(player.Color).(color.NRGBA)
+		sprite := c.themeImage(string(player.Color), spriteSize)
+		mine := c.user != nil && player.ID == c.seatID()
+
+		for ti, token := range player.Tokens {
+			px, py := c.getTokenPixelPosition(player, ti, token, cs)
+			look := pawnLook{
+				player:  pi,
+				token:   ti,
+				x:       float32(px),
+				y:       float32(py),
+				size:    float32(cs * c.tokenScale(token)),
+				fill:    pColor,
+				sprite:  sprite,
+				mine:    mine && !token.IsHome,
+				details: details,
+			}
+
+			selected := c.selectedToken != nil && c.selectedToken.PlayerIndex == pi && c.selectedToken.TokenIndex == ti
+			switch {
+			case selected:
+				look.fill, look.selected = selectedPawnColor, true
+			case c.premove != nil && c.premove.PlayerIndex == pi && c.premove.TokenIndex == ti:
+				look.ring, look.ringRadius = premoveRingColor, 0.4
+			case c.canMoveToken(player, ti):
+				look.ring, look.ringRadius = movableRingColor, 0.35
+			}
+			looks = append(looks, look)
+		}
+	}
+	return looks
+}
+
+// boardMarks rassemble les repères posés sur le plateau. c.mu doit être tenu.
+func (c *Client) boardMarks(cs float64) []boardMark {
+	if c.gameState == nil || c.gameState.Room == nil {
+		return nil
+	}
+	players := c.gameState.Room.Players
+
+	var marks []boardMark
+	if c.gameState.Room.HouseRules.Blockades {
+		marks = append(marks, blockadeMarks(players, cs)...)
+	}
+	marks = append(marks, stackBadgeMarks(players, cs)...)
+	if c.threatAlerts() {
+		marks = append(marks, c.threatMarks(cs)...)
+	}
+	marks = append(marks, c.movePreviewMarks(cs)...)
+	return marks
+}
+
+// backgroundSettings résume ce dont dépend l'image du fond: elle n'est
+// redessinée que s'il change
+func (c *Client) backgroundSettings(size int) string {
+	c.theme.mu.Lock()
+	pack := c.theme.pack
+	c.theme.mu.Unlock()
+
+	key := fmt.Sprintf("%d/%p/%v", size, pack, c.coordinateOverlay())
+	if c.gameState != nil && c.gameState.Room != nil && c.app.Preferences().Bool(dimQuadrantsPreference) {
+		for _, player := range c.gameState.Room.Players {
+			key += "/" + string(player.Color)
+		}
+	}
+	return key
+}
+
+// renderBackground dessine le fond du plateau: cases ou skin du thème,
+// quadrants inoccupés estompés, grille et overlay des coordonnées
+func (c *Client) renderBackground(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{color.NRGBA{255, 255, 255, 255}}, image.Point{}, draw.Src)
+
+	cs := float64(width) / float64(BOARD_GRID)
+	if skin := c.themeImage("board", width); skin != nil {
+		draw.Draw(img, img.Bounds(), skin, image.Point{}, draw.Over)
+	} else {
+		drawBoardBackground(img, cs)
+	}
+
+	// Quadrants inoccupés estompés, si demandé
+	if c.gameState != nil && c.gameState.Room != nil && c.app.Preferences().Bool(dimQuadrantsPreference) {
+		dimUnusedQuadrants(img, c.gameState.Room.Players, cs)
+	}
+
+	drawCompleteGrid(img, width, height, cs)
+
+	// Overlay développeur des index de cases (Ctrl+Shift+G)
+	if c.coordinateOverlay() {
+		drawCoordinateOverlay(img, cs)
+	}
+	return img
+}
+
+// refreshBoard met à jour le plateau affiché: le fond si ses réglages ont
+// changé, les repères et les pions. En mode économe, les rendus trop
+// rapprochés sont regroupés.
+func (c *Client) refreshBoard() {
+	scene := c.scene
+	if scene == nil {
+		return
+	}
+	if quality := c.renderQuality(); quality.interval > 0 {
+		later := func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.refreshBoard()
+		}
+		if !c.frames.allow(quality.interval, later) {
+			return
+		}
+	}
+	c.debug.frameRendered()
+
+	var background *image.NRGBA
+	size := c.boardPixels()
+	key := c.backgroundSettings(size)
+	scene.mu.Lock()
+	if key != scene.backgroundKey {
+		scene.backgroundKey = key
+		background = c.renderBackground(size, size)
+	}
+	scene.mu.Unlock()
+
+	cs := float64(c.boardSize) / float64(BOARD_GRID)
+	marks := c.boardMarks(cs)
+	looks := c.pawnLooks(cs)
+	fyne.Do(func() {
+		if background != nil {
+			c.boardImage.Image = background
+			c.boardImage.Refresh()
+		}
+		scene.setMarks(marks)
+		scene.setPawns(c, looks)
+	})
+}
+
+// refreshPawns ne met à jour que les pions, pour les images d'une
+// animation
+func (c *Client) refreshPawns() {
+	scene := c.scene
+	if scene == nil {
+		return
+	}
+	looks := c.pawnLooks(float64(c.boardSize) / float64(BOARD_GRID))
+	fyne.Do(func() {
+		scene.setPawns(c, looks)
+	})
+}
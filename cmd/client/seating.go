@@ -0,0 +1,50 @@
+// cmd/client/seating.go
+package main
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// dimQuadrantsPreference estompe les quadrants sans joueur (parties à 2 ou 3)
+const dimQuadrantsPreference = "dim_unused_quadrants"
+
+// dimUnusedQuadrants voile les quadrants des couleurs absentes de la partie
+func dimUnusedQuadrants(img *image.NRGBA, players []*models.Player, cs float64) {
+	seated := make(map[constants.PlayerColor]bool)
+	for _, player := range players {
+		seated[player.Color] = true
+	}
+
+	for _, quadrant := range constants.BoardQuadrants {
+		if seated[quadrant] {
+			continue
+		}
+		// Le quadrant (6x6 cases) entoure la base de la couleur
+		base := homePositions[quadrant][0]
+		dimRect(img, float64(base[0]-1)*cs, float64(base[1]-1)*cs, 6*cs)
+	}
+}
+
+// dimRect mélange un carré de l'image avec du gris clair
+func dimRect(img *image.NRGBA, x0, y0, size float64) {
+	bounds := img.Bounds()
+	for y := int(math.Round(y0)); y < int(math.Round(y0+size)); y++ {
+		for x := int(math.Round(x0)); x < int(math.Round(x0+size)); x++ {
+			if x < 0 || y < 0 || x >= bounds.Max.X || y >= bounds.Max.Y {
+				continue
+			}
+			c := img.NRGBAAt(x, y)
+			img.SetNRGBA(x, y, color.NRGBA{
+				R: uint8((int(c.R) + 2*220) / 3),
+				G: uint8((int(c.G) + 2*220) / 3),
+				B: uint8((int(c.B) + 2*220) / 3),
+				A: c.A,
+			})
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// cmd/client/sounds.go
+package main
+
+import (
+	"log"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/client/audio"
+)
+
+// soundPreferencePrefix préfixe le son choisi pour chaque événement
+// ("sound_dice_roll"...). Vide ou absent: son par défaut.
+const soundPreferencePrefix = "sound_"
+
+// Libellés des événements dans les réglages
+var eventLabels = map[string]string{
+	audio.EventDiceRoll:     "Dice roll",
+	audio.EventTokenMove:    "Pawn move",
+	audio.EventTokenCapture: "Capture",
+	audio.EventYourTurn:     "Your turn",
+	audio.EventVictory:      "Victory",
+	audio.EventDefeat:       "Defeat",
+}
+
+// Choix spéciaux de la liste des sons
+const (
+	soundDefault = "Default"
+	soundSilent  = "None"
+	soundBrowse  = "Choose a file…"
+)
+
+// loadEventSounds applique les sons choisis par le joueur
+func (c *Client) loadEventSounds() {
+	for _, event := range audio.Events {
+		c.audio.SetEventSound(event, c.app.Preferences().String(soundPreferencePrefix+event))
+	}
+}
+
+// playEvent joue le son d'un événement de partie
+func (c *Client) playEvent(event string) {
+	if err := c.audio.PlayEvent(event); err != nil {
+		log.Printf("⚠️ Sound for %s: %v", event, err)
+	}
+}
+
+// setEventSound enregistre et applique le son d'un événement
+func (c *Client) setEventSound(event, sound string) {
+	c.app.Preferences().SetString(soundPreferencePrefix+event, sound)
+	c.audio.SetEventSound(event, sound)
+}
+
+// soundSettings liste les événements avec le son de chacun
+func (c *Client) soundSettings() fyne.CanvasObject {
+	form := container.New(layout.NewFormLayout())
+	for _, event := range audio.Events {
+		form.Add(widget.NewLabel(eventLabels[event]))
+		form.Add(c.eventSoundSelect(event))
+	}
+	return form
+}
+
+// eventSoundSelect choisit le son d'un événement: par défaut, aucun, un son
+// chargé (thème compris) ou un fichier audio
+func (c *Client) eventSoundSelect(event string) *widget.Select {
+	options := []string{soundDefault, soundSilent}
+	values := []string{"", audio.SoundNone}
+	for _, name := range c.audio.SoundNames() {
+		if audio.IsSoundFile(name) {
+			continue
+		}
+		options = append(options, name)
+		values = append(values, name)
+	}
+	current := c.audio.EventSound(event)
+	if audio.IsSoundFile(current) {
+		options = append(options, filepath.Base(current))
+		values = append(values, current)
+	}
+	options = append(options, soundBrowse)
+
+	choice := widget.NewSelect(options, nil)
+	selectValue := func(value string) {
+		for i, v := range values {
+			if v == value || (v == "" && value == event) {
+				choice.SetSelected(options[i])
+				return
+			}
+		}
+	}
+	selectValue(current)
+
+	choice.OnChanged = func(selected string) {
+		if selected == soundBrowse {
+			c.browseEventSound(func(path string) {
+				if path == "" {
+					selectValue(c.audio.EventSound(event))
+					return
+				}
+				c.setEventSound(event, path)
+				c.showSettings()
+			})
+			return
+		}
+		for i, option := range options {
+			if option == selected {
+				c.setEventSound(event, values[i])
+			}
+		}
+	}
+	return choice
+}
+
+// browseEventSound demande un fichier audio, path est vide si le joueur annule
+func (c *Client) browseEventSound(done func(path string)) {
+	picker := dialog.NewFileOpen(func(file fyne.URIReadCloser, err error) {
+		if err != nil || file == nil {
+			done("")
+			return
+		}
+		file.Close()
+		done(file.URI().Path())
+	}, c.window)
+	picker.SetFilter(storage.NewExtensionFileFilter([]string{".mp3", ".wav", ".ogg", ".flac"}))
+	picker.Show()
+}
@@ -0,0 +1,163 @@
+// cmd/client/stack.go
+package main
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/moves"
+)
+
+// Disposition des pions empilés, en fraction de case
+const (
+	// stackSpread écarte les pions de la pile du centre de la case
+	stackSpread = 0.2
+	// stackShrink réduit les pions d'une pile pour qu'ils tiennent côte à côte
+	stackShrink = 0.55
+)
+
+// sameCell indique que deux pions occupent la même case à l'écran: le
+// parcours est commun, le couloir et la maison propres à chaque couleur
+func sameCell(a, b *models.Token) bool {
+	if a.Position != b.Position || a.Position == moves.Base {
+		return false
+	}
+	return a.Position < moves.StretchStart || a.Color == b.Color
+}
+
+// stackSlot retourne le rang du pion dans la pile de sa case et la taille
+// de la pile
+func stackSlot(players []*models.Player, token *models.Token) (int, int) {
+	slot, size := 0, 0
+	for _, player := range players {
+		for _, other := range player.Tokens {
+			if !sameCell(token, other) {
+				continue
+			}
+			if other == token {
+				slot = size
+			}
+			size++
+		}
+	}
+	return slot, size
+}
+
+// stackLayout place un pion dans sa pile, en fraction de case depuis le
+// centre: deux pions sur la diagonale, trois ou quatre dans les coins, au-delà
+// en cercle
+func stackLayout(slot, size int) (float64, float64) {
+	switch {
+	case size < 2:
+		return 0, 0
+	case size == 2:
+		d := (float64(slot) - 0.5) * 2 * stackSpread
+		return d, d
+	case size <= 4:
+		corners := [4][2]float64{{-1, -1}, {1, -1}, {-1, 1}, {1, 1}}
+		return corners[slot][0] * stackSpread, corners[slot][1] * stackSpread
+	}
+	angle := 2*math.Pi*float64(slot)/float64(size) - math.Pi/2
+	return math.Cos(angle) * stackSpread * 1.2, math.Sin(angle) * stackSpread * 1.2
+}
+
+// stackScale retourne la taille d'un pion relative à un pion seul sur sa case
+func stackScale(size int) float64 {
+	if size < 2 {
+		return 1
+	}
+	return stackShrink
+}
+
+// stackOf retourne le rang du pion dans sa pile et la taille de la pile. Un
+// pion en cours de trajet n'est dans aucune pile.
+func (c *Client) stackOf(token *models.Token) (int, int) {
+	if c.gameState == nil || c.gameState.Room == nil {
+		return 0, 1
+	}
+	if _, gliding := c.glides[token]; gliding {
+		return 0, 1
+	}
+	return stackSlot(c.gameState.Room.Players, token)
+}
+
+// stackOffset décale un pion empilé dans sa case, pour que chaque pion de la
+// pile reste visible
+func (c *Client) stackOffset(token *models.Token, cs float64) (float64, float64) {
+	dx, dy := stackLayout(c.stackOf(token))
+	return dx * cs, dy * cs
+}
+
+// tokenScale retourne la taille à laquelle dessiner un pion
+func (c *Client) tokenScale(token *models.Token) float64 {
+	_, size := c.stackOf(token)
+	return stackScale(size)
+}
+
+// stackBadgeMarks affiche le nombre de pions de chaque case partagée, dans
+// une pastille au coin haut droit de la case
+func stackBadgeMarks(players []*models.Player, cs float64) []boardMark {
+	var marks []boardMark
+	type cellKey struct {
+		color    constants.PlayerColor
+		position int
+	}
+	counted := make(map[cellKey]bool)
+	for _, player := range players {
+		for _, token := range player.Tokens {
+			if token.Position == moves.Base {
+				continue
+			}
+			key := cellKey{position: token.Position}
+			if token.Position >= moves.StretchStart {
+				key.color = token.Color
+			}
+			if counted[key] {
+				continue
+			}
+			counted[key] = true
+
+			_, size := stackSlot(players, token)
+			if size < 2 {
+				continue
+			}
+			if size > 9 {
+				size = 9
+			}
+			px, py := positionPixel(token.Color, 0, token.Position, cs)
+			badge := circleMark(px+cs*0.32, py-cs*0.32, cs*0.16, color.NRGBA{30, 30, 30, 230}, color.NRGBA{255, 255, 255, 255}, 1)
+			badge.text = strconv.Itoa(size)
+			marks = append(marks, badge)
+		}
+	}
+	return marks
+}
+
+// blockadeMarks entoure de la couleur de leur camp les barrages: deux pions
+// d'une même couleur sur une case du parcours
+func blockadeMarks(players []*models.Player, cs float64) []boardMark {
+	var marks []boardMark
+	for _, player := range players {
+		pColor := getColorForPlayerColor(player.Color).(color.NRGBA)
+		count := make(map[int]int)
+		for _, token := range player.Tokens {
+			if token.Position >= 0 && token.Position < moves.StretchStart {
+				count[token.Position]++
+			}
+		}
+		// Dans l'ordre des pions, pour que les repères d'un rendu à
+		// l'autre se comparent
+		for _, token := range player.Tokens {
+			if count[token.Position] < moves.BlockadeSize {
+				continue
+			}
+			count[token.Position] = 0
+			px, py := positionPixel(player.Color, 0, token.Position, cs)
+			marks = append(marks, circleMark(px, py, cs*0.48, color.NRGBA{}, pColor, 4))
+		}
+	}
+	return marks
+}
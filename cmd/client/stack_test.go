@@ -0,0 +1,32 @@
+// cmd/client/stack_test.go
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// Les pions d'une pile, réduits, doivent rester dans leur case sans se
+// chevaucher, quelle que soit la taille de la pile
+func TestStackLayoutKeepsPawnsApartInsideTheirCell(t *testing.T) {
+	for size := 2; size <= 8; size++ {
+		r := 0.3 * stackScale(size)
+		var centers [][2]float64
+		for slot := 0; slot < size; slot++ {
+			dx, dy := stackLayout(slot, size)
+			if math.Abs(dx)+r > 0.5 || math.Abs(dy)+r > 0.5 {
+				t.Errorf("pile of %d: pawn %d at (%.2f, %.2f) overflows its cell", size, slot, dx, dy)
+			}
+			for _, other := range centers {
+				if d := math.Hypot(dx-other[0], dy-other[1]); size <= 4 && d < 2*r {
+					t.Errorf("pile of %d: pawn %d overlaps another (distance %.2f)", size, slot, d)
+				}
+			}
+			centers = append(centers, [2]float64{dx, dy})
+		}
+	}
+
+	if dx, dy := stackLayout(0, 1); dx != 0 || dy != 0 || stackScale(1) != 1 {
+		t.Error("a lone pawn must stay centered at full size")
+	}
+}
@@ -0,0 +1,370 @@
+// cmd/client/themes.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/assetpack"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+)
+
+// Choix du thème enregistré dans les préférences
+const (
+	themePreference = "theme_pack"
+	themeAuto       = "auto"    // Pack de la saison en cours, s'il y en a un
+	themeClassic    = "classic" // Plateau dessiné par le client
+	assetsPort      = "8081"
+	downloadTimeout = 30 * time.Second
+)
+
+// themeState est le pack actif et ses images mises à l'échelle
+type themeState struct {
+	pack   *assetpack.Pack
+	scaled map[string]*image.NRGBA // "board@600", "red@35"...
+	mu     sync.Mutex
+}
+
+// packsDir est le dossier des packs téléchargés
+func (c *Client) packsDir() string {
+	return filepath.Join(c.app.Storage().RootURI().Path(), "packs")
+}
+
+// localPacks retourne les manifestes des packs téléchargés
+func (c *Client) localPacks() []assetpack.Manifest {
+	paths, _ := filepath.Glob(filepath.Join(c.packsDir(), "*.zip"))
+	packs := make([]assetpack.Manifest, 0, len(paths))
+	for _, path := range paths {
+		if manifest, err := assetpack.ReadManifest(path); err == nil {
+			packs = append(packs, *manifest)
+		}
+	}
+	return packs
+}
+
+// applyThemePack active le pack choisi, ou celui de la saison en mode auto
+func (c *Client) applyThemePack() {
+	choice := c.app.Preferences().StringWithFallback(themePreference, themeAuto)
+
+	name := ""
+	switch choice {
+	case themeClassic:
+	case themeAuto:
+		for _, manifest := range c.localPacks() {
+			if manifest.InSeason(time.Now()) {
+				name = manifest.Name
+				break
+			}
+		}
+	default:
+		name = choice
+	}
+
+	var pack *assetpack.Pack
+	if name != "" {
+		var err error
+		pack, err = assetpack.Load(filepath.Join(c.packsDir(), name+".zip"))
+		if err != nil {
+			log.Printf("⚠️ Failed to load theme %s: %v", name, err)
+			pack = nil
+		}
+	}
+
+	c.theme.mu.Lock()
+	c.theme.pack = pack
+	c.theme.scaled = make(map[string]*image.NRGBA)
+	c.theme.mu.Unlock()
+
+	c.audio.LoadAllSounds()
+	if pack != nil {
+		c.loadPackSounds(pack)
+		log.Printf("🎨 Theme %q enabled", pack.Manifest.Title)
+	}
+}
+
+// loadPackSounds extrait les sons du pack et remplace ceux par défaut
+func (c *Client) loadPackSounds(pack *assetpack.Pack) {
+	dir := filepath.Join(c.packsDir(), pack.Manifest.Name, "sounds")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+	for sound, data := range pack.Sounds {
+		path := filepath.Join(dir, filepath.Base(sound))
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			continue
+		}
+		c.audio.LoadSound(sound, path)
+	}
+}
+
+// themeImage retourne l'image du pack ("board" ou une couleur) à la taille
+// demandée, nil si le pack actif n'en fournit pas
+func (c *Client) themeImage(name string, size int) *image.NRGBA {
+	c.theme.mu.Lock()
+	defer c.theme.mu.Unlock()
+
+	pack := c.theme.pack
+	if pack == nil || size <= 0 {
+		return nil
+	}
+
+	var src image.Image
+	if name == "board" {
+		src = pack.Board
+	} else {
+		src = pack.Tokens[constants.PlayerColor(name)]
+	}
+	if src == nil {
+		return nil
+	}
+
+	key := fmt.Sprintf("%s@%d", name, size)
+	if scaled, ok := c.theme.scaled[key]; ok {
+		return scaled
+	}
+	scaled := scaleImage(src, size)
+	c.theme.scaled[key] = scaled
+	return scaled
+}
+
+// scaleImage redimensionne une image en carré (plus proche voisin)
+func scaleImage(src image.Image, size int) *image.NRGBA {
+	dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+	b := src.Bounds()
+	for y := 0; y < size; y++ {
+		sy := b.Min.Y + y*b.Dy()/size
+		for x := 0; x < size; x++ {
+			sx := b.Min.X + x*b.Dx()/size
+			dst.Set(x, y, src.At(sx, sy))
+		}
+	}
+	return dst
+}
+
+// defaultAssetsURL devine l'adresse des packs à partir du serveur de jeu
+func (c *Client) defaultAssetsURL() string {
+	host := "localhost"
+	if c.serverAddress != "" {
+		if h, _, err := net.SplitHostPort(c.serverAddress); err == nil {
+			host = h
+		}
+	}
+	return "http://" + net.JoinHostPort(host, assetsPort)
+}
+
+// showSettings affiche le choix du thème du plateau et de la langue
+func (c *Client) showSettings() {
+	current := c.app.Preferences().StringWithFallback(themePreference, themeAuto)
+
+	options := []string{"Automatic (seasonal)", "Classic"}
+	values := []string{themeAuto, themeClassic}
+	for _, manifest := range c.localPacks() {
+		label := manifest.Title
+		if manifest.Season != nil {
+			label += fmt.Sprintf(" (%s → %s)", manifest.Season.From, manifest.Season.To)
+		}
+		options = append(options, label)
+		values = append(values, manifest.Name)
+	}
+
+	themes := widget.NewRadioGroup(options, nil)
+	for i, value := range values {
+		if value == current {
+			themes.SetSelected(options[i])
+		}
+	}
+	themes.OnChanged = func(selected string) {
+		for i, option := range options {
+			if option == selected {
+				c.app.Preferences().SetString(themePreference, values[i])
+				c.applyThemePack()
+			}
+		}
+	}
+
+	urlEntry := widget.NewEntry()
+	urlEntry.SetText(c.defaultAssetsURL())
+
+	browseBtn := widget.NewButton("Browse server themes", func() {
+		c.browseThemePacks(strings.TrimRight(urlEntry.Text, "/"))
+	})
+	dimCheck := widget.NewCheck("Dim unused quadrants in 2-3 player games", func(on bool) {
+		c.app.Preferences().SetBool(dimQuadrantsPreference, on)
+	})
+	dimCheck.SetChecked(c.app.Preferences().Bool(dimQuadrantsPreference))
+	threatCheck := widget.NewCheck("Warn me about captures and opponents close to winning", func(on bool) {
+		c.app.Preferences().SetBool(threatAlertsPreference, on)
+	})
+	threatCheck.SetChecked(c.threatAlerts())
+	confirmCheck := widget.NewCheck("Ask before moving a pawn (shows where it lands)", func(on bool) {
+		c.app.Preferences().SetBool(confirmMovesPreference, on)
+	})
+	confirmCheck.SetChecked(c.confirmMoves())
+	hintsCheck := widget.NewCheck("Explain the rule when a move is refused", func(on bool) {
+		c.app.Preferences().SetBool(ruleHintsPreference, on)
+	})
+	hintsCheck.SetChecked(c.ruleHintsEnabled())
+	lowSpecCheck := widget.NewCheck("🐢 Low-spec mode: lighter board, fewer redraws, no animations", c.setLowSpec)
+	lowSpecCheck.SetChecked(c.lowSpec())
+	narrationCheck := widget.NewCheck("🔊 Describe every game event in text (screen readers)", func(on bool) {
+		c.app.Preferences().SetBool(narrationPreference, on)
+	})
+	narrationCheck.SetChecked(c.narrationEnabled())
+
+	logsBtn := widget.NewButton("📜 View logs", func() {
+		c.showLogViewer()
+	})
+	backBtn := widget.NewButton("Back", func() {
+		c.showMainMenu()
+	})
+
+	c.setContent(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle("⚙️ Settings", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewSeparator(),
+		widget.NewLabel("Board theme:"),
+		themes,
+		widget.NewSeparator(),
+		widget.NewLabel("Theme server:"),
+		urlEntry,
+		browseBtn,
+		widget.NewSeparator(),
+		dimCheck,
+		threatCheck,
+		confirmCheck,
+		hintsCheck,
+		lowSpecCheck,
+		narrationCheck,
+		widget.NewSeparator(),
+		widget.NewLabel("Server messages language:"),
+		c.languageSelect(),
+		widget.NewLabel("Game pace (Quick Match):"),
+		c.paceSelect(),
+		widget.NewSeparator(),
+		widget.NewLabel("Connection:"),
+		c.proxySettings(),
+		widget.NewSeparator(),
+		widget.NewLabel("Sounds:"),
+		c.soundSettings(),
+		widget.NewSeparator(),
+		widget.NewLabel("Music:"),
+		c.musicSettings(),
+		widget.NewSeparator(),
+		widget.NewLabel("Parental controls:"),
+		c.kidModeSettings(),
+		widget.NewSeparator(),
+		logsBtn,
+		backBtn,
+	)))
+}
+
+// browseThemePacks liste les packs proposés par le serveur
+func (c *Client) browseThemePacks(baseURL string) {
+	go func() {
+		client := c.httpClient(downloadTimeout)
+		resp, err := client.Get(baseURL + "/packs")
+		var packs []assetpack.Manifest
+		if err == nil {
+			err = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&packs)
+			resp.Body.Close()
+		}
+
+		fyne.Do(func() {
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("failed to list themes: %v", err), c.window)
+				return
+			}
+			if len(packs) == 0 {
+				dialog.ShowInformation("Themes", "This server offers no themes.", c.window)
+				return
+			}
+
+			list := container.NewVBox()
+			for _, manifest := range packs {
+				manifest := manifest
+				label := manifest.Title
+				if manifest.Season != nil {
+					label += fmt.Sprintf(" (%s → %s)", manifest.Season.From, manifest.Season.To)
+				}
+				list.Add(container.NewHBox(
+					widget.NewLabel(label),
+					widget.NewButton("Download", func() {
+						c.downloadThemePack(baseURL, manifest.Name)
+					}),
+				))
+			}
+			dialog.ShowCustom("Server themes", "Close", list, c.window)
+		})
+	}()
+}
+
+// downloadThemePack télécharge un pack, le vérifie puis l'installe
+func (c *Client) downloadThemePack(baseURL, name string) {
+	go func() {
+		err := c.fetchThemePack(baseURL, name)
+		fyne.Do(func() {
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("failed to download theme: %v", err), c.window)
+				return
+			}
+			c.applyThemePack()
+			c.showSettings()
+			dialog.ShowInformation("Themes", "Theme installed.", c.window)
+		})
+	}()
+}
+
+func (c *Client) fetchThemePack(baseURL, name string) error {
+	if err := os.MkdirAll(c.packsDir(), 0755); err != nil {
+		return err
+	}
+
+	client := c.httpClient(downloadTimeout)
+	resp, err := client.Get(baseURL + "/packs/" + name + ".zip")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server answered %s", resp.Status)
+	}
+
+	tmp, err := os.CreateTemp(c.packsDir(), "download-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, io.LimitReader(resp.Body, assetpack.MaxPackSize+1))
+	tmp.Close()
+	if err != nil {
+		return err
+	}
+	if n > assetpack.MaxPackSize {
+		return fmt.Errorf("theme is larger than %d MB", assetpack.MaxPackSize>>20)
+	}
+
+	// Ne garder que les archives complètes et conformes à leur nom
+	pack, err := assetpack.Load(tmp.Name())
+	if err != nil {
+		return err
+	}
+	if pack.Manifest.Name != name {
+		return fmt.Errorf("theme name mismatch")
+	}
+	return os.Rename(tmp.Name(), filepath.Join(c.packsDir(), name+".zip"))
+}
@@ -0,0 +1,81 @@
+// cmd/client/threats.go
+package main
+
+import (
+	"image/color"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/moves"
+)
+
+// threatAlertsPreference affiche les alertes de capture et de victoire
+// adverse sur le plateau
+const threatAlertsPreference = "threat_alerts"
+
+// Couleurs des repères d'alerte, volontairement discrets
+var (
+	exposedMarkColor  = color.NRGBA{220, 40, 40, 170} // Anneau autour d'un pion à portée
+	finisherMarkColor = color.NRGBA{255, 170, 0, 230} // Point sur le dernier pion d'un adversaire
+)
+
+// threatAlerts indique si les alertes sont activées (par défaut oui, pour
+// aider les nouveaux joueurs)
+func (c *Client) threatAlerts() bool {
+	return c.app.Preferences().BoolWithFallback(threatAlertsPreference, true)
+}
+
+// localPlayer retourne le joueur assis du client, nil pour un spectateur
+func (c *Client) localPlayer(players []*models.Player) *models.Player {
+	if c.user == nil {
+		return nil
+	}
+	for _, player := range players {
+		if player.ID == c.seatID() {
+			return player
+		}
+	}
+	return nil
+}
+
+// threatMarks signale les pions du joueur qu'un adversaire peut prendre
+// au prochain dé, et les adversaires à un coup de la victoire
+func (c *Client) threatMarks(cs float64) []boardMark {
+	// Les parties contre l'IA restent « en attente » côté client: seule la
+	// fin de partie coupe les alertes
+	if c.gameState == nil || c.gameState.Room == nil || c.gameState.Room.State == constants.StateFinished {
+		return nil
+	}
+	players := c.gameState.Room.Players
+	me := c.localPlayer(players)
+	if me == nil {
+		return nil
+	}
+
+	var marks []boardMark
+	threats := moves.FindThreats(players, me)
+	for _, token := range threats.Exposed {
+		px, py := c.tokenPixel(players, token, cs)
+		marks = append(marks, circleMark(px, py, cs*0.45, color.NRGBA{}, exposedMarkColor, 2))
+	}
+	for _, token := range threats.Finishers {
+		px, py := c.tokenPixel(players, token, cs)
+		marks = append(marks, circleMark(px+cs*0.28, py-cs*0.28, cs*0.1, finisherMarkColor, color.NRGBA{}, 0))
+	}
+	return marks
+}
+
+// tokenPixel retrouve la case à l'écran d'un pion de la partie, sans son
+// trajet en cours: les repères attendent le pion à l'arrivée
+func (c *Client) tokenPixel(players []*models.Player, token *models.Token, cs float64) (float64, float64) {
+	for _, player := range players {
+		for ti, t := range player.Tokens {
+			if t == token {
+				px, py := positionPixel(player.Color, ti, token.Position, cs)
+				dx, dy := c.stackOffset(token, cs)
+				return px + dx, py + dy
+			}
+		}
+	}
+	return positionPixel(token.Color, 0, token.Position, cs)
+}
@@ -0,0 +1,66 @@
+// cmd/client/transfer.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// handleTransfer suit la salle en attente déplacée vers une autre instance
+// pendant une mise à jour du serveur. La connexion actuelle est fermée;
+// readMessages lance ensuite followTransfer.
+func (c *Client) handleTransfer(msg *models.NetworkMessage) {
+	var payload models.TransferPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid server transfer: %v", err)
+		return
+	}
+	log.Printf("🚚 Room %s moves to %s", payload.RoomID, payload.Address)
+
+	c.mu.Lock()
+	c.transfer = &payload
+	c.mu.Unlock()
+
+	fyne.Do(func() {
+		if c.statusLabel != nil {
+			c.statusLabel.SetText("🚚 Server update: moving your room to another server…")
+		}
+	})
+	c.conn.Close()
+}
+
+// takeTransfer retourne le transfert demandé par le serveur, une seule fois
+func (c *Client) takeTransfer() *models.TransferPayload {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	transfer := c.transfer
+	c.transfer = nil
+	return transfer
+}
+
+// followTransfer se connecte à la nouvelle instance avec le ticket reçu et
+// rejoint la salle recréée
+func (c *Client) followTransfer(transfer *models.TransferPayload) {
+	login := &models.NetworkMessage{
+		Type:      constants.MsgLogin,
+		Payload:   models.LoginPayload{Transfer: transfer.Ticket, Device: c.deviceID()},
+		Timestamp: time.Now(),
+	}
+	if err := c.connectToServer(transfer.Address, login); err != nil {
+		log.Printf("❌ Transfer to %s failed: %v", transfer.Address, err)
+		fyne.Do(func() {
+			dialog.ShowError(fmt.Errorf("The server restarted and the new server could not be reached: %v", err), c.window)
+			c.showMainMenu()
+		})
+		return
+	}
+	c.sendRoomRequest(constants.MsgJoinRoom, transfer.RoomID, "")
+}
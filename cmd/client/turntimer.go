@@ -0,0 +1,149 @@
+// cmd/client/turntimer.go
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// Rafraîchissement du compte à rebours et seuil d'alerte
+const (
+	countdownTick    = 250 * time.Millisecond
+	countdownWarning = 10 * time.Second
+)
+
+var (
+	countdownColor        = color.NRGBA{R: 220, G: 220, B: 220, A: 255}
+	countdownWarningColor = color.NRGBA{R: 255, G: 80, B: 80, A: 255}
+)
+
+// newTurnCountdown crée l'affichage du temps restant, placé sous le dé
+func (c *Client) newTurnCountdown() *canvas.Text {
+	c.stopCountdown()
+	c.turnCountdown = canvas.NewText("", countdownColor)
+	c.turnCountdown.Alignment = fyne.TextAlignCenter
+	c.turnCountdown.TextSize = 18
+	c.turnCountdown.TextStyle = fyne.TextStyle{Bold: true}
+	return c.turnCountdown
+}
+
+// handleTurnTimer lance le compte à rebours du joueur courant
+func (c *Client) handleTurnTimer(msg *models.NetworkMessage) {
+	var payload models.TurnTimerPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid turn timer: %v", err)
+		return
+	}
+
+	// Décompter depuis la durée reçue plutôt que l'échéance: l'horloge du
+	// client peut être décalée de celle du serveur
+	c.startCountdown(time.Duration(payload.RemainingMs) * time.Millisecond)
+}
+
+// handleTurnTimedOut signale le tour sauté d'un joueur trop lent
+func (c *Client) handleTurnTimedOut(msg *models.NetworkMessage) {
+	var payload models.TurnTimedOutPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid turn timeout: %v", err)
+		return
+	}
+
+	c.stopCountdown()
+
+	c.mu.Lock()
+	mine := c.user != nil && payload.PlayerID == c.user.ID
+	name := ""
+	if c.gameState != nil && c.gameState.Room != nil {
+		for _, player := range c.gameState.Room.Players {
+			if player.ID == payload.PlayerID {
+				name = player.Username
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	text := fmt.Sprintf("⏰ %s ran out of time, turn skipped.", name)
+	if mine {
+		text = "⏰ You ran out of time, your turn was skipped."
+	}
+	log.Print(text)
+	c.narrate("%s", strings.TrimPrefix(text, "⏰ "))
+
+	fyne.Do(func() {
+		if c.statusLabel != nil {
+			c.statusLabel.SetText(text)
+		}
+		if mine {
+			fyne.CurrentApp().SendNotification(fyne.NewNotification("Ludo King", text))
+		}
+	})
+}
+
+// startCountdown affiche le temps restant jusqu'à zéro ou au prochain tour
+func (c *Client) startCountdown(remaining time.Duration) {
+	c.stopCountdown()
+	if remaining <= 0 {
+		return
+	}
+
+	deadline := time.Now().Add(remaining)
+	tick := c.renderQuality().countdown
+	shown := time.Duration(-1)
+	stop := c.animate(func(time.Duration) bool {
+		left := time.Until(deadline)
+		if left < 0 {
+			left = 0
+		}
+		// Au plus un affichage par pas du compte à rebours
+		if step := left.Truncate(tick); step != shown {
+			shown = step
+			c.showCountdown(left)
+		}
+		return left > 0
+	})
+	c.mu.Lock()
+	c.countdownStop = stop
+	c.mu.Unlock()
+}
+
+// stopCountdown arrête et efface le compte à rebours en cours
+func (c *Client) stopCountdown() {
+	c.mu.Lock()
+	if c.countdownStop != nil {
+		c.countdownStop()
+		c.countdownStop = nil
+	}
+	c.mu.Unlock()
+
+	fyne.Do(func() {
+		if c.turnCountdown != nil {
+			c.turnCountdown.Text = ""
+			c.turnCountdown.Refresh()
+		}
+	})
+}
+
+// showCountdown met à jour le texte, en rouge sur les dernières secondes
+func (c *Client) showCountdown(left time.Duration) {
+	seconds := int((left + time.Second - 1) / time.Second)
+	fyne.Do(func() {
+		if c.turnCountdown == nil {
+			return
+		}
+		c.turnCountdown.Text = fmt.Sprintf("⏱ %ds", seconds)
+		c.turnCountdown.Color = countdownColor
+		if left <= countdownWarning {
+			c.turnCountdown.Color = countdownWarningColor
+		}
+		c.turnCountdown.Refresh()
+	})
+}
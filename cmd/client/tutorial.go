@@ -0,0 +1,23 @@
+// cmd/client/tutorial.go
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2/dialog"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// startTutorial demande une partie d'apprentissage contre le coach du
+// serveur. Le plateau s'ouvre à la réception du début de partie; le coach
+// commente ensuite dans le chat.
+func (c *Client) startTutorial() {
+	if !c.connected {
+		dialog.ShowError(fmt.Errorf("Not connected to server"), c.window)
+		return
+	}
+	c.send <- &models.NetworkMessage{Type: constants.MsgStartTutorial, Timestamp: time.Now()}
+}
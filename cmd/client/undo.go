@@ -0,0 +1,74 @@
+// cmd/client/undo.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// canUndo indique que le joueur peut reprendre ses coups: partie en ligne
+// non classée avec la règle maison du coup repris, hors spectateurs
+func (c *Client) canUndo() bool {
+	if !c.isOnlineGame() || c.user == nil {
+		return false
+	}
+	if c.gameState == nil || c.gameState.Room == nil {
+		return false
+	}
+	room := c.gameState.Room
+	return room.HouseRules.Undo && room.Queue != constants.QueueRanked && c.roomPlayer(c.user.ID) != nil
+}
+
+// newUndoButton crée le bouton qui reprend le dernier coup. Le serveur le
+// refuse une fois le dé relancé.
+func (c *Client) newUndoButton() *widget.Button {
+	return widget.NewButton("↩ Undo move", func() {
+		c.send <- &models.NetworkMessage{Type: constants.MsgUndoMove, Timestamp: time.Now()}
+	})
+}
+
+// handleMoveUndone rend la main au joueur qui a repris son coup, avec le
+// même dé. Les pions reviennent en place par le delta qui suit.
+func (c *Client) handleMoveUndone(msg *models.NetworkMessage) {
+	var payload models.MoveUndonePayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		log.Printf("❌ Invalid undo: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	c.isMyTurn = payload.PlayerID == c.user.ID
+	c.currentDice = payload.DiceValue
+	c.selectedToken = nil
+	c.premove = nil
+	mine := c.isMyTurn
+	name := ""
+	if player := c.roomPlayer(payload.PlayerID); player != nil {
+		name = player.Username
+	}
+	c.mu.Unlock()
+
+	text := fmt.Sprintf("↩ %s took back their move.", name)
+	if mine {
+		text = fmt.Sprintf("↩ Move taken back. Play your %d again.", payload.DiceValue)
+	}
+	c.narrate("%s", strings.TrimPrefix(text, "↩ "))
+
+	fyne.Do(func() {
+		c.hideMoveConfirmation()
+		c.diceValue.Text = fmt.Sprintf("%d", payload.DiceValue)
+		c.diceValue.Refresh()
+		c.diceButton.Disable()
+		c.statusLabel.SetText(text)
+		c.refreshBoard()
+	})
+}
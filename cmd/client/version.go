@@ -0,0 +1,95 @@
+// cmd/client/version.go
+package main
+
+import (
+	"log"
+	"net"
+	"net/url"
+	"runtime"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/longpoll"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// sendHello annonce la version du client, avant tout autre message
+func (c *Client) sendHello() {
+	c.send <- &models.NetworkMessage{
+		Type: constants.MsgHello,
+		Payload: models.HelloPayload{
+			Version: constants.ClientVersion,
+			OS:      runtime.GOOS,
+			Arch:    runtime.GOARCH,
+			Codecs:  offeredCodecs(c.conn),
+		},
+		Timestamp: time.Now(),
+	}
+}
+
+// offeredCodecs retourne les codecs proposés au serveur pour ses messages.
+// Le transport HTTP ne porte que du JSON.
+func offeredCodecs(conn net.Conn) []string {
+	if conn == nil || conn.RemoteAddr().Network() == longpoll.Network {
+		return nil
+	}
+	return []string{protocol.CodecMsgPack}
+}
+
+// welcomeCodec retourne le codec annoncé par un WELCOME, dans lequel
+// arrivent les messages suivants du serveur
+func welcomeCodec(msg *models.NetworkMessage) string {
+	if msg.Type != constants.MsgWelcome {
+		return ""
+	}
+	var welcome models.WelcomePayload
+	if err := protocol.ExtractPayload(msg.Payload, &welcome); err != nil {
+		return ""
+	}
+	return welcome.Codec
+}
+
+// handleWelcome propose ou impose la mise à jour annoncée par le serveur
+func (c *Client) handleWelcome(msg *models.NetworkMessage) {
+	var welcome models.WelcomePayload
+	if err := protocol.ExtractPayload(msg.Payload, &welcome); err != nil {
+		log.Printf("❌ Invalid welcome: %v", err)
+		return
+	}
+	if welcome.Update == constants.UpdateNone || welcome.Update == "" {
+		return
+	}
+
+	required := welcome.Update == constants.UpdateRequired
+	if required {
+		// Le serveur ferme la connexion: ne pas tenter de reprise
+		c.connected = false
+	}
+
+	message := c.translate(constants.MsgTextUpdateAvailable,
+		map[string]any{"latest": welcome.LatestVersion, "version": constants.ClientVersion}, welcome.Message)
+	if required {
+		message = c.translate(constants.ErrClientOutdated,
+			map[string]any{"version": constants.ClientVersion, "min": welcome.MinVersion}, welcome.Message)
+	}
+
+	fyne.Do(func() {
+		content := container.NewVBox(widget.NewLabel(message))
+		if link, err := url.Parse(welcome.DownloadURL); err == nil && welcome.DownloadURL != "" {
+			content.Add(widget.NewHyperlink("Download the latest version", link))
+		}
+
+		title := "Update available"
+		if required {
+			title = "Update required"
+			c.showMainMenu()
+		}
+		dialog.ShowCustom(title, "OK", content, c.window)
+	})
+}
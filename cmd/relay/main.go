@@ -0,0 +1,37 @@
+// cmd/relay/main.go
+//
+// relay raccorde les joueurs aux serveurs qui ne peuvent pas recevoir de
+// connexions entrantes. Les serveurs s'y enregistrent avec le secret du
+// relais (server.relay dans configs/server.yaml) et les clients s'y replient
+// si la connexion directe échoue.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/relay"
+)
+
+func main() {
+	addr := flag.String("addr", ":"+relay.DefaultPort, "adresse d'écoute du relais")
+	secret := flag.String("secret", os.Getenv("LUDO_RELAY_SECRET"), "secret exigé des serveurs qui s'enregistrent (défaut: $LUDO_RELAY_SECRET)")
+	flag.Parse()
+
+	if *secret == "" {
+		log.Fatalf("A registration secret is required: pass -secret or set LUDO_RELAY_SECRET")
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("Failed to start relay: %v", err)
+	}
+	defer listener.Close()
+
+	log.Printf("📡 Ludo King relay started on %s", listener.Addr())
+	if err := relay.NewRelay(*secret).Serve(listener); err != nil {
+		log.Fatalf("Relay stopped: %v", err)
+	}
+}
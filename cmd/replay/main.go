@@ -0,0 +1,218 @@
+// cmd/replay/main.go
+//
+// replay rejoue l'enregistrement d'une salle (serveur lancé avec -record)
+// contre un serveur neuf, en respectant l'ordre et le rythme des messages.
+// Pour retrouver les mêmes dés, lancer le serveur avec -seed <graine>.
+//
+// Avec -verify, replay reconstruit plutôt des parties terminées (fichiers
+// game-*.json enregistrés par le client) à partir de leur historique et
+// vérifie que les règles actuelles mènent au même état final.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/server/game"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/server/recording"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// roomWait borne l'attente de la création d'une salle avant de la rejoindre
+const roomWait = 2 * time.Second
+
+// Replayer associe les connexions et salles enregistrées aux nouvelles
+type Replayer struct {
+	addr  string
+	conns map[uint64]*json.Encoder
+	rooms map[string]string // Code enregistré -> code attribué par le serveur
+	mu    sync.Mutex
+	cond  *sync.Cond
+}
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "adresse du serveur à rejouer")
+	speed := flag.Float64("speed", 1, "facteur de vitesse (2 = deux fois plus vite)")
+	verify := flag.Bool("verify", false, "reconstruire des parties enregistrées et comparer leur état final")
+	flag.Parse()
+
+	if *verify && flag.NArg() > 0 {
+		os.Exit(verifyGames(flag.Args()))
+	}
+	if flag.NArg() != 1 || *speed <= 0 {
+		log.Printf("usage: replay [-addr host:port] [-speed n] <room>.jsonl")
+		log.Printf("       replay -verify <game>.json...")
+		os.Exit(2)
+	}
+
+	entries, err := recording.ReadFile(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("Failed to read recording: %v", err)
+	}
+
+	r := &Replayer{
+		addr:  *addr,
+		conns: make(map[uint64]*json.Encoder),
+		rooms: make(map[string]string),
+	}
+	r.cond = sync.NewCond(&r.mu)
+
+	start := time.Now()
+	for _, entry := range entries {
+		if entry.Message == nil {
+			log.Printf("🌱 Room %s used seed %d (start the server with -seed %d)", entry.Room, entry.Seed, entry.Seed)
+			continue
+		}
+
+		due := time.Duration(float64(entry.AtMs)/(*speed)) * time.Millisecond
+		time.Sleep(time.Until(start.Add(due)))
+
+		if err := r.send(entry); err != nil {
+			log.Fatalf("Failed to replay message from conn %d: %v", entry.Conn, err)
+		}
+	}
+
+	// Laisser le temps aux dernières réponses d'arriver
+	time.Sleep(time.Second)
+	log.Printf("✅ Replayed %d entries", len(entries))
+}
+
+// send rejoue un message sur la connexion correspondante
+func (r *Replayer) send(entry recording.Entry) error {
+	encoder, err := r.conn(entry.Conn)
+	if err != nil {
+		return err
+	}
+
+	msg := *entry.Message
+	r.remapRoom(&msg)
+
+	log.Printf("▶ conn %d: %s", entry.Conn, msg.Type)
+	return encoder.Encode(&msg)
+}
+
+// conn ouvre la connexion d'un client enregistré au premier message
+func (r *Replayer) conn(id uint64) (*json.Encoder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if encoder, ok := r.conns[id]; ok {
+		return encoder, nil
+	}
+
+	conn, err := net.Dial("tcp", r.addr)
+	if err != nil {
+		return nil, err
+	}
+	go r.readResponses(id, conn)
+
+	encoder := json.NewEncoder(conn)
+	r.conns[id] = encoder
+	return encoder, nil
+}
+
+// readResponses journalise les réponses et retient les codes de salle
+func (r *Replayer) readResponses(id uint64, conn net.Conn) {
+	defer conn.Close()
+
+	decoder := json.NewDecoder(conn)
+	for {
+		var msg models.NetworkMessage
+		if err := decoder.Decode(&msg); err != nil {
+			return
+		}
+		log.Printf("◀ conn %d: %s", id, msg.Type)
+
+		if msg.Type != constants.MsgRoomCreated {
+			continue
+		}
+		payload, _ := msg.Payload.(map[string]interface{})
+		if roomID, ok := payload["room_id"].(string); ok {
+			r.mu.Lock()
+			r.rooms[""] = roomID // Associée au prochain code enregistré inconnu
+			r.cond.Broadcast()
+			r.mu.Unlock()
+		}
+	}
+}
+
+// remapRoom remplace le code de salle enregistré par celui du serveur rejoué
+func (r *Replayer) remapRoom(msg *models.NetworkMessage) {
+	payload, ok := msg.Payload.(map[string]interface{})
+	if !ok {
+		return
+	}
+	recorded, ok := payload["room_id"].(string)
+	if !ok || recorded == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deadline := time.Now().Add(roomWait)
+	for {
+		if roomID, ok := r.rooms[recorded]; ok {
+			payload["room_id"] = roomID
+			return
+		}
+		if roomID, ok := r.rooms[""]; ok {
+			delete(r.rooms, "")
+			r.rooms[recorded] = roomID
+			payload["room_id"] = roomID
+			return
+		}
+		if time.Now().After(deadline) {
+			log.Printf("⚠️ No replayed room for %s, sending as recorded", recorded)
+			return
+		}
+
+		// Réveiller l'attente à l'échéance même sans création de salle
+		timer := time.AfterFunc(time.Until(deadline), r.cond.Broadcast)
+		r.cond.Wait()
+		timer.Stop()
+	}
+}
+
+// verifyGames reconstruit chaque partie enregistrée et signale celles dont
+// l'état final diffère. Retourne le code de sortie du programme.
+func verifyGames(paths []string) int {
+	failed := 0
+	for _, path := range paths {
+		if err := verifyGame(path); err != nil {
+			log.Printf("❌ %s: %v", path, err)
+			failed++
+			continue
+		}
+		log.Printf("✅ %s", path)
+	}
+
+	log.Printf("%d/%d games match their rebuild", len(paths)-failed, len(paths))
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// verifyGame lit une partie enregistrée et la compare à sa reconstruction
+func verifyGame(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var stored models.Game
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	if stored.Room == nil {
+		return fmt.Errorf("no room in the recorded game")
+	}
+	return game.Verify(&stored)
+}
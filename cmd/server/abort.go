@@ -0,0 +1,60 @@
+// cmd/server/abort.go
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// handleVoteAbort enregistre le vote d'un joueur pour abandonner la partie.
+// La partie est abandonnée quand tous les joueurs humains ont voté.
+func (s *Server) handleVoteAbort(client *Client, msg *models.NetworkMessage) {
+	s.mu.RLock()
+	gameRoom := s.rooms[client.roomID]
+	s.mu.RUnlock()
+
+	if gameRoom == nil || client.spectating {
+		return
+	}
+
+	gameRoom.mu.Lock()
+	if gameRoom.room.State != constants.StatePlaying {
+		gameRoom.mu.Unlock()
+		return
+	}
+
+	gameRoom.abortVotes[client.userID] = true
+
+	votes := make([]int64, 0, len(gameRoom.abortVotes))
+	needed := 0
+	for _, player := range gameRoom.room.Players {
+		if player.IsAI {
+			continue
+		}
+		needed++
+		if gameRoom.abortVotes[player.ID] {
+			votes = append(votes, player.ID)
+		}
+	}
+	gameRoom.mu.Unlock()
+
+	s.broadcastToRoom(client.roomID, &models.NetworkMessage{
+		Type:      constants.MsgAbortVotes,
+		Payload:   models.AbortVotesPayload{Votes: votes, Needed: needed},
+		Timestamp: time.Now(),
+	})
+
+	if len(votes) < needed {
+		return
+	}
+
+	if err := gameRoom.engine.Abort(); err != nil {
+		log.Printf("Failed to abort game in room %s: %v", client.roomID, err)
+		return
+	}
+
+	log.Printf("Game in room %s aborted by unanimous vote", client.roomID)
+}
@@ -0,0 +1,35 @@
+// cmd/server/admin.go
+package main
+
+import (
+	"log"
+	"net/http"
+	"net/http/pprof"
+)
+
+// startAdmin démarre l'API d'administration, à n'exposer qu'en local
+func (s *Server) startAdmin(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	mux.HandleFunc("/bandwidth", s.handleBandwidth)
+	mux.HandleFunc("/announce", s.handleAnnounce)
+	mux.HandleFunc("/balance", s.handleBalance)
+	mux.HandleFunc("/drain", s.handleDrain)
+	mux.HandleFunc("/export", s.handleExport)
+	mux.HandleFunc("/import", s.handleImport)
+	mux.HandleFunc("/dashboard", s.handleDashboard)
+	mux.HandleFunc("/dashboard/events", s.handleDashboardEvents)
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	registerChaosRoutes(mux)
+
+	go func() {
+		log.Printf("🛠 Admin API listening on %s", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("Admin API stopped: %v", err)
+		}
+	}()
+}
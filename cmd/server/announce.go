@@ -0,0 +1,116 @@
+// cmd/server/announce.go
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// maxAnnouncementLen borne la taille d'une annonce
+const maxAnnouncementLen = 280
+
+// announcementBoard garde l'annonce en cours pour les clients qui se
+// connectent après sa diffusion
+type announcementBoard struct {
+	current *models.AnnouncementPayload
+	nextID  int64
+	mu      sync.Mutex
+}
+
+// announceRequest est le corps de POST /announce
+type announceRequest struct {
+	Text     string `json:"text"`
+	Level    string `json:"level"`      // info par défaut
+	Duration int    `json:"duration_s"` // 0 = annonce ponctuelle, non rejouée
+}
+
+// handleAnnounce diffuse (POST) ou retire (DELETE) une annonce
+func (s *Server) handleAnnounce(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var req announceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.Text = strings.TrimSpace(req.Text)
+		if req.Text == "" || len(req.Text) > maxAnnouncementLen {
+			http.Error(w, "text must be 1 to 280 bytes", http.StatusBadRequest)
+			return
+		}
+		switch req.Level {
+		case "":
+			req.Level = constants.AnnounceInfo
+		case constants.AnnounceInfo, constants.AnnounceMaintenance, constants.AnnounceEvent:
+		default:
+			http.Error(w, "level must be info, maintenance or event", http.StatusBadRequest)
+			return
+		}
+
+		announcement := s.announce(req.Text, req.Level, time.Duration(req.Duration)*time.Second)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(announcement)
+
+	case http.MethodDelete:
+		s.announce("", "", 0)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// announce envoie une annonce à tous les clients connectés. Un texte vide
+// retire la bannière en cours.
+func (s *Server) announce(text, level string, duration time.Duration) models.AnnouncementPayload {
+	b := s.announcements
+	b.mu.Lock()
+	b.nextID++
+	announcement := models.AnnouncementPayload{ID: b.nextID, Level: level, Text: text}
+	b.current = nil
+	if text != "" && duration > 0 {
+		expires := time.Now().Add(duration)
+		announcement.ExpiresAt = &expires
+		b.current = &announcement
+	}
+	b.mu.Unlock()
+
+	msg := &models.NetworkMessage{
+		Type:      constants.MsgAnnouncement,
+		Payload:   announcement,
+		Timestamp: time.Now(),
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, client := range s.conns {
+		s.sendMessage(client, msg)
+	}
+	return announcement
+}
+
+// sendCurrentAnnouncement rejoue l'annonce en cours à un nouveau client
+func (s *Server) sendCurrentAnnouncement(client *Client) {
+	b := s.announcements
+	b.mu.Lock()
+	current := b.current
+	if current != nil && time.Now().After(*current.ExpiresAt) {
+		b.current, current = nil, nil
+	}
+	b.mu.Unlock()
+
+	if current != nil {
+		s.sendMessage(client, &models.NetworkMessage{
+			Type:      constants.MsgAnnouncement,
+			Payload:   *current,
+			Timestamp: time.Now(),
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestAnnouncementReplayedToNewClients(t *testing.T) {
+	s := &Server{
+		conns:         make(map[uint64]*Client),
+		announcements: &announcementBoard{},
+	}
+	online := &Client{connID: 1, send: make(chan *models.NetworkMessage, 4)}
+	s.conns[online.connID] = online
+
+	s.announce("Maintenance in 10 minutes", constants.AnnounceMaintenance, 10*time.Minute)
+	if len(online.send) != 1 {
+		t.Fatalf("connected client got %d messages, want 1", len(online.send))
+	}
+
+	late := &Client{connID: 2, send: make(chan *models.NetworkMessage, 4)}
+	s.sendCurrentAnnouncement(late)
+	if len(late.send) != 1 {
+		t.Fatal("a client connecting during the announcement should receive it")
+	}
+
+	// Retirer la bannière: plus rien à rejouer
+	s.announce("", "", 0)
+	again := &Client{connID: 3, send: make(chan *models.NetworkMessage, 4)}
+	s.sendCurrentAnnouncement(again)
+	if len(again.send) != 0 {
+		t.Error("a cleared announcement should not be replayed")
+	}
+}
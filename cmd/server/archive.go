@@ -0,0 +1,68 @@
+// cmd/server/archive.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/database"
+)
+
+// maxArchiveBytes borne la taille d'une archive envoyée à /import
+const maxArchiveBytes = 512 << 20
+
+// handleExport télécharge l'archive des joueurs de l'instance: comptes,
+// statistiques et historique, à importer sur un autre hébergement
+func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	archive, err := s.db.ExportArchive()
+	if err != nil {
+		log.Printf("Failed to export players: %v", err)
+		http.Error(w, "export unavailable", http.StatusInternalServerError)
+		return
+	}
+	log.Printf("📦 Exported %d players and %d games", len(archive.Users), len(archive.Games))
+
+	name := fmt.Sprintf("ludo-export-%s.json", archive.ExportedAt.Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	json.NewEncoder(w).Encode(archive)
+}
+
+// handleImport ajoute les joueurs d'une archive (POST) sous de nouveaux ids
+// et retourne la correspondance des ids
+func (s *Server) handleImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var archive database.Archive
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxArchiveBytes)).Decode(&archive); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if archive.Version != database.ArchiveVersion {
+		http.Error(w, fmt.Sprintf("archive version must be %d", database.ArchiveVersion), http.StatusBadRequest)
+		return
+	}
+
+	report, err := s.db.ImportArchive(&archive)
+	if err != nil {
+		log.Printf("Failed to import players: %v", err)
+		http.Error(w, "import failed, nothing was imported", http.StatusInternalServerError)
+		return
+	}
+	log.Printf("📦 Imported %d players and %d games, %d accounts and %d known games skipped",
+		report.Users, report.Games, len(report.Skipped), report.SkippedGames)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,47 @@
+// cmd/server/archive_test.go
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/database"
+)
+
+func TestExportedPlayersImportIntoAnotherInstance(t *testing.T) {
+	oldStore := database.NewMemoryStore()
+	oldStore.CreateUser("alice", "a@example.com", "hash")
+	oldHost := &Server{db: oldStore}
+
+	rec := httptest.NewRecorder()
+	oldHost.handleExport(rec, httptest.NewRequest(http.MethodGet, "/export", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Header().Get("Content-Disposition"), "attachment") {
+		t.Fatalf("export answered %d %v", rec.Code, rec.Header())
+	}
+
+	newStore := database.NewMemoryStore()
+	newStore.CreateUser("admin", "admin@example.com", "")
+	newHost := &Server{db: newStore}
+
+	rec2 := httptest.NewRecorder()
+	newHost.handleImport(rec2, httptest.NewRequest(http.MethodPost, "/import", rec.Body))
+	var report database.ImportReport
+	if err := json.NewDecoder(rec2.Body).Decode(&report); err != nil || rec2.Code != http.StatusOK {
+		t.Fatalf("import answered %d (%v)", rec2.Code, err)
+	}
+	if report.Users != 1 || report.IDs[1] != 2 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if user, _ := newStore.GetUserByUsername("alice"); user == nil || user.PasswordHash != "hash" {
+		t.Errorf("alice cannot log in on the new host: %+v", user)
+	}
+
+	rec3 := httptest.NewRecorder()
+	newHost.handleImport(rec3, httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(`{"version": 99}`)))
+	if rec3.Code != http.StatusBadRequest {
+		t.Errorf("Expected an unknown archive version to be refused, got %d", rec3.Code)
+	}
+}
@@ -0,0 +1,70 @@
+// cmd/server/assets.go
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/assetpack"
+)
+
+// startAssets sert les packs de ressources (thèmes saisonniers) aux clients.
+// Contrairement à l'API d'administration, ce service peut être public.
+func (s *Server) startAssets(addr, dir string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /packs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(listAssetPacks(dir))
+	})
+	mux.HandleFunc("GET /packs/{file}", func(w http.ResponseWriter, r *http.Request) {
+		name, ok := strings.CutSuffix(r.PathValue("file"), ".zip")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		manifest, err := assetpack.ReadManifest(filepath.Join(dir, name+".zip"))
+		if err != nil || manifest.Name != name {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeFile(w, r, filepath.Join(dir, name+".zip"))
+	})
+
+	go func() {
+		log.Printf("🎨 Asset packs from %s served on %s", dir, addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("Asset server stopped: %v", err)
+		}
+	}()
+}
+
+// listAssetPacks lit les manifestes des archives valides du dossier.
+// Le nom d'un pack doit correspondre à celui de son archive.
+func listAssetPacks(dir string) []assetpack.Manifest {
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.zip"))
+
+	packs := make([]assetpack.Manifest, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || info.Size() > assetpack.MaxPackSize {
+			continue
+		}
+		manifest, err := assetpack.ReadManifest(path)
+		if err != nil {
+			log.Printf("Skipping asset pack %s: %v", filepath.Base(path), err)
+			continue
+		}
+		if manifest.Name+".zip" != filepath.Base(path) {
+			continue
+		}
+		packs = append(packs, *manifest)
+	}
+
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs
+}
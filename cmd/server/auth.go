@@ -0,0 +1,215 @@
+// cmd/server/auth.go
+package main
+
+import (
+	"log"
+	"net/mail"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// Règles des comptes
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72 // bcrypt ignore les octets suivants
+	authTokenTTL      = 7 * 24 * time.Hour
+)
+
+// authToken associe un jeton d'authentification à un compte vérifié
+type authToken struct {
+	userID    int64
+	username  string
+	expiresAt time.Time
+}
+
+// AuthStore gère les jetons d'authentification remis après une connexion
+// par mot de passe. Contrairement aux jetons de session, ils ne sont pas liés
+// à une salle et restent valables jusqu'à expiration.
+type AuthStore struct {
+	tokens map[string]*authToken
+	ttl    time.Duration
+	mu     sync.Mutex
+}
+
+// NewAuthStore crée un stockage de jetons d'authentification
+func NewAuthStore(ttl time.Duration) *AuthStore {
+	return &AuthStore{
+		tokens: make(map[string]*authToken),
+		ttl:    ttl,
+	}
+}
+
+// Issue crée un jeton pour un compte vérifié
+func (st *AuthStore) Issue(userID int64, username string, now time.Time) string {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.pruneLocked(now)
+
+	token := newSessionToken()
+	st.tokens[token] = &authToken{userID: userID, username: username, expiresAt: now.Add(st.ttl)}
+	return token
+}
+
+// Verify retourne le compte associé à un jeton encore valable
+func (st *AuthStore) Verify(token string, now time.Time) (userID int64, username string, ok bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.pruneLocked(now)
+
+	auth, exists := st.tokens[token]
+	if !exists {
+		return 0, "", false
+	}
+	return auth.userID, auth.username, true
+}
+
+// pruneLocked supprime les jetons expirés
+func (st *AuthStore) pruneLocked(now time.Time) {
+	for token, auth := range st.tokens {
+		if now.After(auth.expiresAt) {
+			delete(st.tokens, token)
+		}
+	}
+}
+
+// requiresAuth indique si un message exige un compte vérifié. Seuls la
+// présentation, l'authentification, la reprise de session (jeton déjà
+// vérifié) et les rapports de plantage sont acceptés avant.
+func requiresAuth(msgType constants.MessageType) bool {
+	switch msgType {
+	case constants.MsgHello, constants.MsgRegister, constants.MsgLogin,
+		constants.MsgResume, constants.MsgCrashReport, constants.MsgPing:
+		return false
+	}
+	return true
+}
+
+// validateRegistration vérifie un formulaire de création de compte
+func validateRegistration(payload models.RegisterPayload) error {
+	if err := protocol.ValidateUsername(payload.Username); err != nil {
+		return i18n.NewError(constants.ErrInvalidUsername, i18n.Params{
+			"min": protocol.UsernameMinLength,
+			"max": protocol.UsernameMaxLength,
+		})
+	}
+	if _, err := mail.ParseAddress(payload.Email); err != nil {
+		return i18n.NewError(constants.ErrInvalidEmail, nil)
+	}
+	if len(payload.Password) < minPasswordLength {
+		return i18n.NewError(constants.ErrPasswordTooShort, i18n.Params{"min": minPasswordLength})
+	}
+	if len(payload.Password) > maxPasswordLength {
+		return i18n.NewError(constants.ErrPasswordTooLong, i18n.Params{"max": maxPasswordLength})
+	}
+	return nil
+}
+
+// handleRegister crée un compte et authentifie la connexion
+func (s *Server) handleRegister(client *Client, msg *models.NetworkMessage) {
+	var payload models.RegisterPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+	client.device = payload.Device
+	if err := validateRegistration(payload); err != nil {
+		s.sendFailure(client, err, constants.ErrAuthFailed)
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(payload.Password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Printf("Failed to hash password: %v", err)
+		s.sendError(client, constants.ErrServerFailure, nil)
+		return
+	}
+
+	user, err := s.db.CreateUser(payload.Username, payload.Email, string(hash))
+	if err != nil {
+		log.Printf("Failed to register %s: %v", payload.Username, err)
+		s.sendError(client, constants.ErrAccountTaken, nil)
+		return
+	}
+
+	log.Printf("New account: %s (%d)", user.Username, user.ID)
+	s.authenticate(client, user.ID, user.Username, user)
+}
+
+// handleLogin vérifie un mot de passe ou un jeton d'authentification
+func (s *Server) handleLogin(client *Client, msg *models.NetworkMessage) {
+	var payload models.LoginPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+	client.device = payload.Device
+
+	if payload.Transfer != "" {
+		s.loginTransferred(client, payload.Transfer)
+		return
+	}
+
+	if payload.Token != "" {
+		userID, username, ok := s.auth.Verify(payload.Token, time.Now())
+		if !ok {
+			s.sendError(client, constants.ErrLoginExpired, nil)
+			return
+		}
+		user, err := s.db.GetUserByID(userID)
+		if err != nil {
+			log.Printf("Failed to load user %d: %v", userID, err)
+			user = &models.User{ID: userID, Username: username}
+		}
+		s.authenticate(client, userID, username, user)
+		return
+	}
+
+	user, err := s.db.GetUserByUsername(payload.Username)
+	if err != nil {
+		// Même réponse qu'un mauvais mot de passe: ne pas révéler les comptes
+		log.Printf("Login failed for %q: %v", payload.Username, err)
+		s.sendError(client, constants.ErrBadCredentials, nil)
+		return
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(payload.Password)); err != nil {
+		log.Printf("Login failed for %q: wrong password", payload.Username)
+		s.sendError(client, constants.ErrBadCredentials, nil)
+		return
+	}
+
+	if err := s.db.UpdateLastLogin(user.ID); err != nil {
+		log.Printf("Failed to update last login: %v", err)
+	}
+	s.authenticate(client, user.ID, user.Username, user)
+}
+
+// authenticate lie la connexion au compte vérifié et lui remet un jeton
+func (s *Server) authenticate(client *Client, userID int64, username string, user *models.User) {
+	client.userID = userID
+	client.username = username
+	client.authenticated = true
+	if user != nil {
+		client.pace = user.Pace
+	}
+
+	s.sendMessage(client, &models.NetworkMessage{
+		Type: constants.MsgAuthenticated,
+		Payload: models.AuthenticatedPayload{
+			User:  user,
+			Token: s.auth.Issue(userID, username, time.Now()),
+		},
+		Timestamp: time.Now(),
+	})
+	s.noticeDataExport(client)
+
+	log.Printf("%s authenticated (%d)", username, userID)
+}
@@ -0,0 +1,58 @@
+// cmd/server/auth_test.go
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestAuthTokenVerifiesUntilExpiry(t *testing.T) {
+	store := NewAuthStore(time.Hour)
+	now := time.Now()
+	token := store.Issue(42, "alice", now)
+
+	userID, username, ok := store.Verify(token, now.Add(time.Minute))
+	if !ok || userID != 42 || username != "alice" {
+		t.Fatalf("Verify = %d/%s/%v, want 42/alice/true", userID, username, ok)
+	}
+	if _, _, ok := store.Verify("forged", now); ok {
+		t.Error("Expected an unknown token to be rejected")
+	}
+	if _, _, ok := store.Verify(token, now.Add(2*time.Hour)); ok {
+		t.Error("Expected the token to expire")
+	}
+}
+
+func TestRequiresAuthBeforeGameMessages(t *testing.T) {
+	for _, msgType := range []constants.MessageType{constants.MsgHello, constants.MsgLogin, constants.MsgRegister, constants.MsgResume} {
+		if requiresAuth(msgType) {
+			t.Errorf("%s should be accepted before login", msgType)
+		}
+	}
+	for _, msgType := range []constants.MessageType{constants.MsgCreateRoom, constants.MsgJoinRoom, constants.MsgSpectate, constants.MsgMoveToken} {
+		if !requiresAuth(msgType) {
+			t.Errorf("%s should require login", msgType)
+		}
+	}
+}
+
+func TestValidateRegistration(t *testing.T) {
+	valid := models.RegisterPayload{Username: "alice", Email: "alice@example.com", Password: "correct-horse"}
+	if err := validateRegistration(valid); err != nil {
+		t.Fatalf("Expected a valid registration, got %v", err)
+	}
+
+	short := valid
+	short.Password = "1234"
+	if validateRegistration(short) == nil {
+		t.Error("Expected a short password to be rejected")
+	}
+	badEmail := valid
+	badEmail.Email = "alice"
+	if validateRegistration(badEmail) == nil {
+		t.Error("Expected an invalid email to be rejected")
+	}
+}
@@ -0,0 +1,40 @@
+// cmd/server/balance.go
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+)
+
+// handleBalance renvoie le rapport d'équilibre des couleurs et de l'ordre de
+// jeu. ?since=2006-01-02 limite le rapport aux parties jouées depuis cette
+// date, pour vérifier qu'un changement de règles ou de plateau n'avantage
+// aucun siège.
+func (s *Server) handleBalance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var since *time.Time
+	if value := r.URL.Query().Get("since"); value != "" {
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			http.Error(w, "since must be a date (YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		since = &date
+	}
+
+	report, err := s.db.GetBalanceReport(since)
+	if err != nil {
+		log.Printf("Failed to build balance report: %v", err)
+		http.Error(w, "balance report unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
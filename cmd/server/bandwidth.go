@@ -0,0 +1,238 @@
+// cmd/server/bandwidth.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Surveillance de la bande passante
+const (
+	bandwidthCheckInterval = 10 * time.Second
+	bandwidthWarnRate      = 64 << 10 // Octets par seconde, dans un sens, par client
+)
+
+// trafficCounter compte les octets reçus et envoyés
+type trafficCounter struct {
+	in  atomic.Uint64
+	out atomic.Uint64
+}
+
+// countingConn compte le trafic d'une connexion
+type countingConn struct {
+	net.Conn
+	traffic *trafficCounter
+}
+
+func (c *countingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	c.traffic.in.Add(uint64(n))
+	return n, err
+}
+
+func (c *countingConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	c.traffic.out.Add(uint64(n))
+	return n, err
+}
+
+// bandwidthTracker cumule le trafic par salle et surveille les débits
+type bandwidthTracker struct {
+	rooms   map[string]*trafficCounter
+	samples map[uint64]trafficSample // Dernier relevé par connexion
+	warned  int64                    // Avertissements émis depuis le démarrage
+	mu      sync.Mutex
+}
+
+type trafficSample struct {
+	in, out uint64
+	at      time.Time
+}
+
+func newBandwidthTracker() *bandwidthTracker {
+	return &bandwidthTracker{
+		rooms:   make(map[string]*trafficCounter),
+		samples: make(map[uint64]trafficSample),
+	}
+}
+
+// addRoom impute du trafic à une salle
+func (b *bandwidthTracker) addRoom(roomID string, in, out uint64) {
+	if roomID == "" || (in == 0 && out == 0) {
+		return
+	}
+
+	b.mu.Lock()
+	counter := b.rooms[roomID]
+	if counter == nil {
+		counter = &trafficCounter{}
+		b.rooms[roomID] = counter
+	}
+	b.mu.Unlock()
+
+	counter.in.Add(in)
+	counter.out.Add(out)
+}
+
+// watchBandwidth lance les contrôles de débit périodiques
+func (s *Server) watchBandwidth() {
+	ticker := time.NewTicker(bandwidthCheckInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		s.checkBandwidth(now)
+	}
+}
+
+// checkBandwidth avertit des clients dont le débit dépasse le seuil
+func (s *Server) checkBandwidth(now time.Time) {
+	type usage struct {
+		connID   uint64
+		username string
+		in, out  uint64
+	}
+
+	s.mu.RLock()
+	clients := make([]usage, 0, len(s.clients))
+	for _, client := range s.clients {
+		clients = append(clients, usage{
+			connID:   client.connID,
+			username: client.username,
+			in:       client.traffic.in.Load(),
+			out:      client.traffic.out.Load(),
+		})
+	}
+	rooms := make(map[string]bool, len(s.rooms))
+	for id := range s.rooms {
+		rooms[id] = true
+	}
+	s.mu.RUnlock()
+
+	b := s.bandwidth
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	seen := make(map[uint64]bool, len(clients))
+	for _, c := range clients {
+		seen[c.connID] = true
+		last, ok := b.samples[c.connID]
+		b.samples[c.connID] = trafficSample{in: c.in, out: c.out, at: now}
+		if !ok {
+			continue
+		}
+
+		elapsed := now.Sub(last.at).Seconds()
+		if elapsed <= 0 {
+			continue
+		}
+		inRate := float64(c.in-last.in) / elapsed
+		outRate := float64(c.out-last.out) / elapsed
+		if inRate > bandwidthWarnRate || outRate > bandwidthWarnRate {
+			b.warned++
+			log.Printf("⚠️ Bandwidth: client %s (conn %d) at %.1f KiB/s in, %.1f KiB/s out",
+				c.username, c.connID, inRate/1024, outRate/1024)
+		}
+	}
+
+	// Oublier les connexions fermées et les salles supprimées
+	for connID := range b.samples {
+		if !seen[connID] {
+			delete(b.samples, connID)
+		}
+	}
+	for id := range b.rooms {
+		if !rooms[id] {
+			delete(b.rooms, id)
+		}
+	}
+}
+
+// bandwidthReport est la réponse de GET /bandwidth
+type bandwidthReport struct {
+	Clients []clientTraffic `json:"clients"`
+	Rooms   []roomTraffic   `json:"rooms"`
+}
+
+type clientTraffic struct {
+	ConnID   uint64 `json:"conn_id"`
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+	RoomID   string `json:"room_id,omitempty"`
+	BytesIn  uint64 `json:"bytes_in"`
+	BytesOut uint64 `json:"bytes_out"`
+}
+
+type roomTraffic struct {
+	RoomID   string `json:"room_id"`
+	BytesIn  uint64 `json:"bytes_in"`
+	BytesOut uint64 `json:"bytes_out"`
+}
+
+// bandwidthSnapshot relève le trafic des clients connectés et des salles
+func (s *Server) bandwidthSnapshot() bandwidthReport {
+	report := bandwidthReport{}
+
+	s.mu.RLock()
+	for _, client := range s.clients {
+		report.Clients = append(report.Clients, clientTraffic{
+			ConnID:   client.connID,
+			UserID:   client.userID,
+			Username: client.username,
+			RoomID:   client.roomID,
+			BytesIn:  client.traffic.in.Load(),
+			BytesOut: client.traffic.out.Load(),
+		})
+	}
+	s.mu.RUnlock()
+
+	s.bandwidth.mu.Lock()
+	for id, counter := range s.bandwidth.rooms {
+		report.Rooms = append(report.Rooms, roomTraffic{
+			RoomID:   id,
+			BytesIn:  counter.in.Load(),
+			BytesOut: counter.out.Load(),
+		})
+	}
+	s.bandwidth.mu.Unlock()
+
+	sort.Slice(report.Clients, func(i, j int) bool {
+		return report.Clients[i].ConnID < report.Clients[j].ConnID
+	})
+	sort.Slice(report.Rooms, func(i, j int) bool {
+		return report.Rooms[i].RoomID < report.Rooms[j].RoomID
+	})
+	return report
+}
+
+// handleBandwidth répond à GET /bandwidth
+func (s *Server) handleBandwidth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.bandwidthSnapshot())
+}
+
+// writeBandwidthMetrics ajoute les compteurs de trafic à /metrics
+func (s *Server) writeBandwidthMetrics(w io.Writer) {
+	report := s.bandwidthSnapshot()
+
+	s.bandwidth.mu.Lock()
+	warned := s.bandwidth.warned
+	s.bandwidth.mu.Unlock()
+
+	fmt.Fprintf(w, "ludo_bandwidth_warnings_total %d\n", warned)
+	for _, c := range report.Clients {
+		fmt.Fprintf(w, "ludo_client_bytes_total{conn=\"%d\",direction=\"in\"} %d\n", c.ConnID, c.BytesIn)
+		fmt.Fprintf(w, "ludo_client_bytes_total{conn=\"%d\",direction=\"out\"} %d\n", c.ConnID, c.BytesOut)
+	}
+	for _, room := range report.Rooms {
+		fmt.Fprintf(w, "ludo_room_bytes_total{room=%q,direction=\"in\"} %d\n", room.RoomID, room.BytesIn)
+		fmt.Fprintf(w, "ludo_room_bytes_total{room=%q,direction=\"out\"} %d\n", room.RoomID, room.BytesOut)
+	}
+}
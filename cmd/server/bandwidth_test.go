@@ -0,0 +1,32 @@
+// cmd/server/bandwidth_test.go
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckBandwidthWarnsAboveThreshold(t *testing.T) {
+	s := &Server{
+		clients:   make(map[int64]*Client),
+		rooms:     make(map[string]*GameRoom),
+		bandwidth: newBandwidthTracker(),
+	}
+	client := &Client{connID: 1, userID: 7, username: "noisy"}
+	s.clients[client.userID] = client
+
+	start := time.Now()
+	s.checkBandwidth(start)
+
+	client.traffic.out.Add(bandwidthWarnRate * 5)
+	s.checkBandwidth(start.Add(10 * time.Second))
+	if s.bandwidth.warned != 0 {
+		t.Fatalf("warned = %d below the threshold", s.bandwidth.warned)
+	}
+
+	client.traffic.out.Add(bandwidthWarnRate * 20)
+	s.checkBandwidth(start.Add(20 * time.Second))
+	if s.bandwidth.warned != 1 {
+		t.Errorf("warned = %d, want 1", s.bandwidth.warned)
+	}
+}
@@ -0,0 +1,103 @@
+// cmd/server/chaos.go
+
+//go:build chaos
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// faultConfig décrit les pannes injectées (build de test uniquement).
+// Les taux sont des probabilités entre 0 et 1.
+type faultConfig struct {
+	BroadcastDelayMaxMs int     `json:"broadcast_delay_max_ms"`
+	DropRate            float64 `json:"drop_rate"`
+	DisconnectRate      float64 `json:"disconnect_rate"`
+	DBErrorRate         float64 `json:"db_error_rate"`
+}
+
+var chaos = struct {
+	faults faultConfig
+	rand   *rand.Rand
+	mu     sync.Mutex
+}{rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+// registerChaosRoutes expose GET/PUT /chaos sur l'API d'administration
+func registerChaosRoutes(mux *http.ServeMux) {
+	log.Printf("⚠️ Chaos build: fault injection enabled via /chaos")
+
+	mux.HandleFunc("/chaos", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var faults faultConfig
+			if err := json.NewDecoder(r.Body).Decode(&faults); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			chaos.mu.Lock()
+			chaos.faults = faults
+			chaos.mu.Unlock()
+			log.Printf("⚠️ Chaos faults updated: %+v", faults)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		chaos.mu.Lock()
+		faults := chaos.faults
+		chaos.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(faults)
+	})
+}
+
+// roll tire une probabilité sous le verrou du générateur
+func (c *faultConfig) roll(rate float64) bool {
+	return rate > 0 && chaos.rand.Float64() < rate
+}
+
+// chaosBeforeSend retarde l'envoi d'un message et indique s'il faut l'envoyer
+func chaosBeforeSend(msg *models.NetworkMessage) bool {
+	chaos.mu.Lock()
+	drop := chaos.faults.roll(chaos.faults.DropRate)
+	var delay time.Duration
+	if max := chaos.faults.BroadcastDelayMaxMs; max > 0 {
+		delay = time.Duration(chaos.rand.Intn(max)) * time.Millisecond
+	}
+	chaos.mu.Unlock()
+
+	if drop {
+		log.Printf("⚠️ Chaos: dropped %s", msg.Type)
+		return false
+	}
+	time.Sleep(delay)
+	return true
+}
+
+// chaosDisconnect indique s'il faut couper la connexion courante
+func chaosDisconnect() bool {
+	chaos.mu.Lock()
+	defer chaos.mu.Unlock()
+	return chaos.faults.roll(chaos.faults.DisconnectRate)
+}
+
+// chaosDBError simule l'échec d'une opération en base
+func chaosDBError(op string) error {
+	chaos.mu.Lock()
+	defer chaos.mu.Unlock()
+	if chaos.faults.roll(chaos.faults.DBErrorRate) {
+		return fmt.Errorf("chaos: injected %s failure", op)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+// cmd/server/chaos_off.go
+
+//go:build !chaos
+
+package main
+
+import (
+	"net/http"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// Sans le tag de compilation chaos, les points d'injection sont inactifs
+
+func registerChaosRoutes(mux *http.ServeMux) {}
+
+func chaosBeforeSend(msg *models.NetworkMessage) bool { return true }
+
+func chaosDisconnect() bool { return false }
+
+func chaosDBError(op string) error { return nil }
@@ -0,0 +1,84 @@
+// cmd/server/chat.go
+package main
+
+import (
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/hooks"
+)
+
+// Tailles maximales des messages de chat
+const (
+	maxChatTextLen       = 500
+	maxChatCiphertextLen = 1024 // Base64 d'un texte maximal, nonce et tag compris
+)
+
+// handleChatMessage relaie un message de chat aux joueurs de la salle
+func (s *Server) handleChatMessage(client *Client, msg *models.NetworkMessage) {
+	var chat models.ChatPayload
+	if err := protocol.ExtractPayload(msg.Payload, &chat); err != nil {
+		return
+	}
+
+	s.mu.RLock()
+	gameRoom := s.rooms[client.roomID]
+	s.mu.RUnlock()
+
+	if gameRoom == nil || client.spectating {
+		return
+	}
+
+	gameRoom.mu.RLock()
+	muted := gameRoom.room.Muted[client.userID]
+	gameRoom.mu.RUnlock()
+	if muted {
+		s.sendError(client, constants.ErrChatMuted, nil)
+		return
+	}
+
+	// Les salles chiffrées ne relaient que du texte chiffré, pour qu'un
+	// client mal configuré ne divulgue rien en clair
+	if gameRoom.room.E2EChat {
+		if chat.Ciphertext == "" || len(chat.Ciphertext) > maxChatCiphertextLen {
+			s.sendError(client, constants.ErrEncryptedChatOnly, nil)
+			return
+		}
+		chat.Text = ""
+	} else {
+		if chat.Text == "" || len(chat.Text) > maxChatTextLen {
+			return
+		}
+		chat.Ciphertext = ""
+	}
+
+	// L'expéditeur est celui de la connexion, pas celui annoncé; seuls les
+	// messages du serveur portent un code à traduire
+	chat.UserID = client.userID
+	chat.Username = client.username
+	chat.Code = ""
+	chat.Params = nil
+	chat.SentAt = time.Now()
+
+	if !s.hooks.ChatMessage(hooks.ChatEvent{
+		RoomID:    client.roomID,
+		UserID:    chat.UserID,
+		Username:  chat.Username,
+		Text:      chat.Text,
+		Encrypted: gameRoom.room.E2EChat,
+	}) {
+		return
+	}
+
+	s.broadcastToRoom(client.roomID, &models.NetworkMessage{
+		Type:      constants.MsgChatMessage,
+		Payload:   chat,
+		Timestamp: chat.SentAt,
+	})
+
+	if gameRoom.tutor != nil {
+		gameRoom.tutor.chat(chat.UserID, chat.Text)
+	}
+}
@@ -0,0 +1,33 @@
+// cmd/server/codec.go
+package main
+
+import (
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/longpoll"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// Le codec des messages du serveur se négocie au HELLO: le client liste les
+// formats qu'il sait lire, le WELCOME annonce celui retenu, et tout ce que
+// le serveur écrit ensuite l'utilise. Les messages du client restent en
+// JSON: ils sont courts, c'est l'état de la partie qui pèse.
+
+// negotiateCodec choisit le codec des messages envoyés au client. Le
+// transport HTTP ne porte que du JSON.
+func negotiateCodec(client *Client, hello models.HelloPayload) string {
+	if client.conn == nil || client.conn.RemoteAddr().Network() == longpoll.Network {
+		return ""
+	}
+	return protocol.NegotiateCodec(hello.Codecs)
+}
+
+// negotiatedCodec retourne le codec annoncé par un WELCOME, à utiliser
+// pour les messages suivants
+func negotiatedCodec(msg *models.NetworkMessage) string {
+	if msg.Type != constants.MsgWelcome {
+		return ""
+	}
+	welcome, _ := msg.Payload.(models.WelcomePayload)
+	return welcome.Codec
+}
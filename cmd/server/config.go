@@ -0,0 +1,120 @@
+// cmd/server/config.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Bornes acceptées pour la durée d'un tour
+const (
+	minTurnTimeout = 5   // secondes
+	maxTurnTimeout = 300 // secondes
+)
+
+// Durée maximale de la fenêtre de reconnexion (0 la désactive)
+const maxReconnectTimeout = 600 // secondes
+
+// validateConfig vérifie la cohérence d'une configuration
+func validateConfig(config *Config) error {
+	if config.Server.Port == "" {
+		return fmt.Errorf("server port is required")
+	}
+
+	if config.Game.TurnTimeout < minTurnTimeout || config.Game.TurnTimeout > maxTurnTimeout {
+		return fmt.Errorf("turn_timeout must be between %d and %d seconds", minTurnTimeout, maxTurnTimeout)
+	}
+
+	if config.Game.ReconnectTimeout < 0 || config.Game.ReconnectTimeout > maxReconnectTimeout {
+		return fmt.Errorf("reconnect_timeout must be between 0 and %d seconds", maxReconnectTimeout)
+	}
+
+	if config.Game.MinPlayersPerRoom < 2 || config.Game.MaxPlayersPerRoom > 4 ||
+		config.Game.MinPlayersPerRoom > config.Game.MaxPlayersPerRoom {
+		return fmt.Errorf("players per room must satisfy 2 <= min <= max <= 4")
+	}
+
+	if config.Server.Relay.Addr != "" && config.Server.Relay.Secret == "" {
+		return fmt.Errorf("relay.secret is required when relay.addr is set")
+	}
+
+	if err := validateDriver(config); err != nil {
+		return err
+	}
+
+	if err := validateSeason(config); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// watchConfigReload recharge la configuration à chaque SIGHUP
+func (s *Server) watchConfigReload(path string) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	for range signals {
+		if err := s.reloadConfig(path); err != nil {
+			log.Printf("⚠️ Config reload rejected, keeping previous config: %v", err)
+			continue
+		}
+		log.Printf("🔄 Config reloaded from %s", path)
+	}
+}
+
+// reloadConfig applique une nouvelle configuration sans couper les connexions.
+// En cas d'erreur, la configuration courante reste en place.
+func (s *Server) reloadConfig(path string) error {
+	config, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	if err := validateConfig(config); err != nil {
+		return err
+	}
+
+	current := s.getConfig()
+
+	// L'écoute réseau et la base ne changent qu'au redémarrage
+	if config.Server != current.Server || config.Database != current.Database {
+		log.Printf("⚠️ Server and database settings require a restart, keeping current values")
+		config.Server = current.Server
+		config.Database = current.Database
+	}
+
+	s.configMu.Lock()
+	s.config = config
+	s.configMu.Unlock()
+
+	// Appliquer la durée des tours aux parties en cours; celles du
+	// matchmaking gardent la durée de leur rythme. Les rappels du moteur
+	// prennent s.mu: ne pas le détenir en appelant le moteur.
+	s.mu.RLock()
+	gameRooms := make([]*GameRoom, 0, len(s.rooms))
+	for _, gameRoom := range s.rooms {
+		gameRooms = append(gameRooms, gameRoom)
+	}
+	s.mu.RUnlock()
+
+	for _, gameRoom := range gameRooms {
+		gameRoom.mu.RLock()
+		engine, pace := gameRoom.engine, gameRoom.room.Pace
+		gameRoom.mu.RUnlock()
+
+		engine.SetTurnTimeout(paceTurnTimeout(pace, config.Game.TurnTimeout))
+	}
+
+	return nil
+}
+
+// getConfig retourne la configuration courante
+func (s *Server) getConfig() *Config {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	return s.config
+}
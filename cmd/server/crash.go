@@ -0,0 +1,55 @@
+// cmd/server/crash.go
+package main
+
+import (
+	"log"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// Limites des rapports de plantage acceptés
+const (
+	maxCrashReportsPerConn = 3
+	maxCrashLogLines       = 200
+	maxCrashFieldLen       = 64 << 10
+)
+
+// handleCrashReport stocke un rapport de plantage pour les mainteneurs
+func (s *Server) handleCrashReport(client *Client, msg *models.NetworkMessage) {
+	client.crashReports++
+	if client.crashReports > maxCrashReportsPerConn {
+		return
+	}
+
+	var report models.CrashReportPayload
+	if err := protocol.ExtractPayload(msg.Payload, &report); err != nil {
+		log.Printf("Invalid crash report: %v", err)
+		return
+	}
+
+	if len(report.Logs) > maxCrashLogLines {
+		report.Logs = report.Logs[len(report.Logs)-maxCrashLogLines:]
+	}
+	report.Panic = truncate(report.Panic, maxCrashFieldLen)
+	report.Stack = truncate(report.Stack, maxCrashFieldLen)
+	report.Version = truncate(report.Version, 32)
+	report.OS = truncate(report.OS, 32)
+	report.Arch = truncate(report.Arch, 32)
+
+	go func() {
+		if err := s.db.SaveCrashReport(&report); err != nil {
+			log.Printf("Failed to save crash report: %v", err)
+			return
+		}
+		log.Printf("🐞 Crash report received (client %s, %s/%s)", report.Version, report.OS, report.Arch)
+	}()
+}
+
+// truncate coupe une chaîne à n octets
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,171 @@
+// cmd/server/dashboard.go
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Tableau de bord de l'API d'administration
+const (
+	dashboardInterval = 2 * time.Second // Rythme des instantanés envoyés en SSE
+	recentErrorsSize  = 50              // Erreurs gardées pour le tableau de bord
+)
+
+//go:embed dashboard.html
+var dashboardPage []byte
+
+// recentError est une erreur envoyée à un joueur
+type recentError struct {
+	Time     time.Time `json:"time"`
+	Code     string    `json:"code"`
+	Username string    `json:"username,omitempty"`
+	RoomID   string    `json:"room_id,omitempty"`
+}
+
+// errorLog garde les dernières erreurs envoyées aux joueurs. La valeur
+// zéro est prête à l'emploi.
+type errorLog struct {
+	mu      sync.Mutex
+	entries []recentError
+	next    int
+}
+
+// add enregistre une erreur, en écrasant la plus ancienne une fois plein
+func (l *errorLog) add(entry recentError) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.entries) < recentErrorsSize {
+		l.entries = append(l.entries, entry)
+		return
+	}
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % recentErrorsSize
+}
+
+// recent retourne les erreurs gardées, la plus récente d'abord
+func (l *errorLog) recent() []recentError {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := make([]recentError, 0, len(l.entries))
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		entries = append(entries, l.entries[(l.next+i)%len(l.entries)])
+	}
+	return entries
+}
+
+// dashboardRoom est l'occupation d'une salle
+type dashboardRoom struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	State      string `json:"state"`
+	Players    int    `json:"players"`
+	Humans     int    `json:"humans"`
+	Connected  int    `json:"connected"`
+	MaxPlayers int    `json:"max_players"`
+	Spectators int    `json:"spectators"`
+}
+
+// dashboardSnapshot est l'état du serveur affiché par le tableau de bord
+type dashboardSnapshot struct {
+	Time         time.Time       `json:"time"`
+	Clients      int             `json:"clients"`
+	Rooms        []dashboardRoom `json:"rooms"`
+	Players      int             `json:"players"`
+	Capacity     int             `json:"capacity"`
+	QueueWaiting int             `json:"queue_waiting"`
+	QueuePending int             `json:"queue_pending"` // Joueurs d'une table proposée
+	Errors       []recentError   `json:"errors"`
+}
+
+// dashboardSnapshot relève l'occupation des salles et de la file
+func (s *Server) dashboardSnapshot() dashboardSnapshot {
+	s.mu.RLock()
+	gameRooms := make([]*GameRoom, 0, len(s.rooms))
+	for _, gameRoom := range s.rooms {
+		gameRooms = append(gameRooms, gameRoom)
+	}
+	snap := dashboardSnapshot{Time: time.Now(), Clients: len(s.clients)}
+	s.mu.RUnlock()
+
+	for _, gameRoom := range gameRooms {
+		gameRoom.mu.RLock()
+		room := dashboardRoom{
+			ID:         gameRoom.room.ID,
+			Name:       gameRoom.room.Name,
+			State:      string(gameRoom.room.State),
+			Players:    len(gameRoom.room.Players),
+			Connected:  len(gameRoom.clients),
+			MaxPlayers: gameRoom.room.MaxPlayers,
+		}
+		for _, player := range gameRoom.room.Players {
+			if !player.IsAI {
+				room.Humans++
+			}
+		}
+		gameRoom.mu.RUnlock()
+
+		gameRoom.spectatorMu.Lock()
+		room.Spectators = len(gameRoom.spectators)
+		gameRoom.spectatorMu.Unlock()
+
+		snap.Rooms = append(snap.Rooms, room)
+		snap.Players += room.Players
+		snap.Capacity += room.MaxPlayers
+	}
+	sort.Slice(snap.Rooms, func(i, j int) bool { return snap.Rooms[i].ID < snap.Rooms[j].ID })
+
+	if q := s.matchmaking; q != nil {
+		q.mu.Lock()
+		snap.QueueWaiting = len(q.waiting)
+		for _, match := range q.pending {
+			snap.QueuePending += len(match.entries)
+		}
+		q.mu.Unlock()
+	}
+
+	snap.Errors = s.errors.recent()
+	return snap
+}
+
+// handleDashboard sert la page du tableau de bord
+func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(dashboardPage)
+}
+
+// handleDashboardEvents envoie un instantané en Server-Sent Events toutes
+// les dashboardInterval, jusqu'à la fermeture de la page
+func (s *Server) handleDashboardEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	ticker := time.NewTicker(dashboardInterval)
+	defer ticker.Stop()
+	for {
+		data, err := json.Marshal(s.dashboardSnapshot())
+		if err != nil {
+			return
+		}
+		if _, err := fmt.Fprintf(w, "event: snapshot\ndata: %s\n\n", data); err != nil {
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// cmd/server/dashboard_test.go
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestErrorLogKeepsMostRecent(t *testing.T) {
+	var log errorLog
+	for i := 0; i < recentErrorsSize+5; i++ {
+		log.add(recentError{Code: fmt.Sprint(i)})
+	}
+
+	recent := log.recent()
+	if len(recent) != recentErrorsSize {
+		t.Fatalf("kept %d errors, want %d", len(recent), recentErrorsSize)
+	}
+	if recent[0].Code != fmt.Sprint(recentErrorsSize+4) || recent[len(recent)-1].Code != "5" {
+		t.Errorf("kept %s..%s, want newest first down to 5", recent[0].Code, recent[len(recent)-1].Code)
+	}
+}
+
+func TestDashboardSnapshotCountsSeats(t *testing.T) {
+	s := &Server{
+		clients:     make(map[int64]*Client),
+		rooms:       make(map[string]*GameRoom),
+		matchmaking: newMatchmakingQueue(),
+	}
+	room := &models.Room{ID: "ABC234", MaxPlayers: 4, State: constants.StateWaiting}
+	room.Players = []*models.Player{
+		models.NewPlayer(1, "host", constants.ColorRed),
+		models.NewAIPlayer(constants.ColorBlue, "easy"),
+	}
+	s.rooms[room.ID] = newGameRoom(room)
+	s.matchmaking.waiting = []*queueEntry{{client: &Client{userID: 3}}}
+
+	snap := s.dashboardSnapshot()
+	if snap.Players != 2 || snap.Capacity != 4 || snap.QueueWaiting != 1 {
+		t.Fatalf("snapshot = %+v, want 2/4 seats and one queued player", snap)
+	}
+	if got := snap.Rooms[0]; got.Humans != 1 || got.State != string(constants.StateWaiting) {
+		t.Errorf("room = %+v, want one human waiting", got)
+	}
+}
@@ -0,0 +1,187 @@
+// cmd/server/dataexport.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// Export des données personnelles demandé par un joueur
+const (
+	dataExportTTL      = 24 * time.Hour // Un export prêt reste téléchargeable ce temps
+	dataExportCooldown = time.Hour      // Délai entre deux demandes d'un même joueur
+)
+
+// dataExport est l'archive prête d'un joueur
+type dataExport struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+// dataExportDesk suit les exports en préparation et ceux prêts à être
+// téléchargés, un par joueur. L'archive ne quitte pas la mémoire.
+type dataExportDesk struct {
+	mu        sync.Mutex
+	pending   map[int64]bool
+	requested map[int64]time.Time
+	ready     map[int64]*dataExport
+}
+
+// begin réserve la préparation d'un export. ok est faux si un export est
+// déjà en préparation, ou si la dernière demande date de moins de
+// dataExportCooldown: wait donne alors le temps restant.
+func (d *dataExportDesk) begin(userID int64, now time.Time) (wait time.Duration, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.pending == nil {
+		d.pending = make(map[int64]bool)
+		d.requested = make(map[int64]time.Time)
+		d.ready = make(map[int64]*dataExport)
+	}
+	d.sweep(now)
+	if d.pending[userID] {
+		return 0, false
+	}
+	if last, seen := d.requested[userID]; seen && now.Sub(last) < dataExportCooldown {
+		return dataExportCooldown - now.Sub(last), false
+	}
+	d.pending[userID] = true
+	d.requested[userID] = now
+	return 0, true
+}
+
+// finish range l'archive préparée, nil si la préparation a échoué: le
+// joueur peut alors redemander un export sans attendre
+func (d *dataExportDesk) finish(userID int64, data []byte, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.pending, userID)
+	if data == nil {
+		delete(d.requested, userID)
+		return
+	}
+	d.ready[userID] = &dataExport{data: data, expiresAt: now.Add(dataExportTTL)}
+}
+
+// sweep oublie les demandes dont le délai est passé et les exports expirés,
+// pour que les joueurs partis ne restent pas en mémoire. L'appelant détient
+// d.mu.
+func (d *dataExportDesk) sweep(now time.Time) {
+	for userID, last := range d.requested {
+		if !d.pending[userID] && now.Sub(last) >= dataExportCooldown {
+			delete(d.requested, userID)
+		}
+	}
+	for userID, export := range d.ready {
+		if now.After(export.expiresAt) {
+			delete(d.ready, userID)
+		}
+	}
+}
+
+// get retourne l'export prêt d'un joueur, nil s'il n'y en a pas ou s'il a
+// expiré
+func (d *dataExportDesk) get(userID int64, now time.Time) *dataExport {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	export := d.ready[userID]
+	if export != nil && now.After(export.expiresAt) {
+		delete(d.ready, userID)
+		return nil
+	}
+	return export
+}
+
+// handleRequestDataExport lance la préparation de l'export des données du
+// joueur. Le joueur est prévenu quand il est prêt.
+func (s *Server) handleRequestDataExport(client *Client, msg *models.NetworkMessage) {
+	wait, ok := s.dataExports.begin(client.userID, time.Now())
+	if !ok && wait == 0 {
+		s.sendError(client, constants.ErrDataExportPending, nil)
+		return
+	}
+	if !ok {
+		s.sendError(client, constants.ErrDataExportCooldown, i18n.Params{"minutes": int(math.Ceil(wait.Minutes()))})
+		return
+	}
+
+	log.Printf("📦 Data export requested by %s (%d)", client.username, client.userID)
+	go s.prepareDataExport(client.userID)
+}
+
+// prepareDataExport rassemble les données du joueur puis prévient toutes
+// ses connexions
+func (s *Server) prepareDataExport(userID int64) {
+	archive, err := s.db.ExportPlayer(userID)
+	var data []byte
+	if err == nil {
+		data, err = json.MarshalIndent(archive, "", "  ")
+	}
+	if err != nil {
+		log.Printf("Failed to prepare data export of %d: %v", userID, err)
+		s.dataExports.finish(userID, nil, time.Now())
+		s.eachUserConn(userID, func(client *Client) {
+			s.sendError(client, constants.ErrDataExportFailed, nil)
+		})
+		return
+	}
+
+	s.dataExports.finish(userID, data, time.Now())
+	s.eachUserConn(userID, s.noticeDataExport)
+}
+
+// noticeDataExport prévient le joueur qu'un export l'attend. Appelé aussi à
+// la connexion, pour un export préparé pendant son absence.
+func (s *Server) noticeDataExport(client *Client) {
+	export := s.dataExports.get(client.userID, time.Now())
+	if export == nil {
+		return
+	}
+	s.sendMessage(client, &models.NetworkMessage{
+		Type:      constants.MsgDataExportReady,
+		Payload:   models.DataExportReadyPayload{Size: len(export.data), ExpiresAt: export.expiresAt},
+		Timestamp: time.Now(),
+	})
+}
+
+// handleDownloadDataExport envoie au joueur son export prêt
+func (s *Server) handleDownloadDataExport(client *Client, msg *models.NetworkMessage) {
+	export := s.dataExports.get(client.userID, time.Now())
+	if export == nil {
+		s.sendError(client, constants.ErrDataExportMissing, nil)
+		return
+	}
+	s.sendMessage(client, &models.NetworkMessage{
+		Type: constants.MsgDataExport,
+		Payload: models.DataExportPayload{
+			FileName: fmt.Sprintf("ludo-data-%s.json", client.username),
+			Data:     string(export.data),
+		},
+		Timestamp: time.Now(),
+	})
+}
+
+// eachUserConn appelle send pour chaque connexion identifiée d'un joueur.
+// Comme pour les annonces, l'envoi se fait sous le verrou du serveur:
+// handleDisconnect retire la connexion avant de la fermer.
+func (s *Server) eachUserConn(userID int64, send func(client *Client)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, client := range s.conns {
+		if client.authenticated && client.userID == userID {
+			send(client)
+		}
+	}
+}
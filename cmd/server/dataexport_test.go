@@ -0,0 +1,75 @@
+// cmd/server/dataexport_test.go
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/database"
+)
+
+func TestPlayerDownloadsTheirDataExport(t *testing.T) {
+	store := database.NewMemoryStore()
+	alice, _ := store.CreateUser("alice", "a@example.com", "secret-hash")
+	client := &Client{userID: alice.ID, username: "alice", authenticated: true, send: make(chan *models.NetworkMessage, 8)}
+	s := &Server{db: store, conns: map[uint64]*Client{1: client}}
+
+	s.handleRequestDataExport(client, &models.NetworkMessage{})
+	select {
+	case msg := <-client.send:
+		if msg.Type != constants.MsgDataExportReady || msg.Payload.(models.DataExportReadyPayload).Size == 0 {
+			t.Fatalf("got %s %+v, want the ready notice", msg.Type, msg.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the export was never announced")
+	}
+
+	// Une seconde demande attend la fin du délai entre deux exports
+	s.handleRequestDataExport(client, &models.NetworkMessage{})
+	if msg := lastMessage(client); msg == nil || msg.Payload.(models.ErrorPayload).Code != constants.ErrDataExportCooldown {
+		t.Errorf("second request answered %+v", msg)
+	}
+
+	s.handleDownloadDataExport(client, &models.NetworkMessage{})
+	msg := lastMessage(client)
+	if msg == nil || msg.Type != constants.MsgDataExport {
+		t.Fatalf("download answered %+v", msg)
+	}
+	var archive database.Archive
+	if err := json.Unmarshal([]byte(msg.Payload.(models.DataExportPayload).Data), &archive); err != nil {
+		t.Fatalf("export is not an archive: %v", err)
+	}
+	if len(archive.Users) != 1 || archive.Users[0].User.Username != "alice" || archive.Users[0].PasswordHash != "" {
+		t.Errorf("unexpected export: %+v", archive.Users)
+	}
+
+	// Un export préparé pendant l'absence du joueur est annoncé à sa connexion
+	s.noticeDataExport(client)
+	if msg := lastMessage(client); msg == nil || msg.Type != constants.MsgDataExportReady {
+		t.Errorf("login notice = %+v", msg)
+	}
+}
+
+// Les demandes anciennes et les exports expirés ne restent pas en mémoire
+func TestDataExportDeskSweepsExpiredEntries(t *testing.T) {
+	var desk dataExportDesk
+	start := time.Now()
+
+	desk.begin(1, start)
+	desk.finish(1, []byte("{}"), start)
+	desk.begin(2, start) // Toujours en préparation
+
+	later := start.Add(dataExportTTL + time.Minute)
+	if _, ok := desk.begin(3, later); !ok {
+		t.Fatal("a first request must be accepted")
+	}
+	if _, seen := desk.requested[1]; seen || desk.ready[1] != nil {
+		t.Error("expired export of player 1 is still kept")
+	}
+	if !desk.pending[2] || desk.requested[2].IsZero() {
+		t.Error("an export in preparation must not be forgotten")
+	}
+}
@@ -0,0 +1,45 @@
+// cmd/server/delta.go
+package main
+
+import (
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// tokenKey désigne un pion d'un joueur
+type tokenKey struct {
+	playerID int64
+	tokenID  int
+}
+
+// stateTracker retient l'état des pions envoyé dans le dernier delta. Il
+// appartient à un moteur et n'est utilisé que sous son verrou, depuis ses
+// rappels.
+type stateTracker struct {
+	sent map[tokenKey]models.TokenDelta
+}
+
+func newStateTracker() *stateTracker {
+	return &stateTracker{sent: make(map[tokenKey]models.TokenDelta)}
+}
+
+// delta retourne les pions changés depuis le dernier appel, avec la somme
+// de contrôle de la salle. Le premier delta d'une partie contient tous
+// les pions.
+func (t *stateTracker) delta(room *models.Room) models.StateDeltaPayload {
+	payload := models.StateDeltaPayload{
+		CurrentTurn: room.CurrentTurn,
+		Checksum:    room.Checksum(),
+	}
+	for _, player := range room.Players {
+		for _, token := range player.Tokens {
+			state := token.TokenState(player.ID)
+			key := tokenKey{player.ID, token.ID}
+			if previous, ok := t.sent[key]; ok && previous == state {
+				continue
+			}
+			t.sent[key] = state
+			payload.Tokens = append(payload.Tokens, state)
+		}
+	}
+	return payload
+}
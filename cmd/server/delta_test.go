@@ -0,0 +1,65 @@
+// cmd/server/delta_test.go
+package main
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func newDeltaRoom() *models.Room {
+	return &models.Room{Players: []*models.Player{
+		models.NewPlayer(1, "alice", constants.ColorRed),
+		models.NewPlayer(2, "bob", constants.ColorYellow),
+	}}
+}
+
+func TestStateDeltaCarriesOnlyMovedTokens(t *testing.T) {
+	server, client := newDeltaRoom(), newDeltaRoom()
+	tracker := newStateTracker()
+
+	first := tracker.delta(server)
+	if len(first.Tokens) != 2*constants.TokensPerPlayer {
+		t.Fatalf("first delta has %d tokens, want every token", len(first.Tokens))
+	}
+
+	server.Players[0].Tokens[2].Position = 14
+	server.Players[1].Tokens[0].IsHome = true
+	server.CurrentTurn = 1
+	delta := tracker.delta(server)
+	if len(delta.Tokens) != 2 {
+		t.Fatalf("delta = %+v, want the two changed tokens", delta.Tokens)
+	}
+
+	if !client.ApplyDelta(&delta) || client.Checksum() != delta.Checksum {
+		t.Fatal("client state should match the server after applying the delta")
+	}
+	if again := tracker.delta(server); len(again.Tokens) != 0 || again.Checksum != delta.Checksum {
+		t.Errorf("unchanged state: delta = %+v", again)
+	}
+}
+
+func TestStateDeltaDetectsDrift(t *testing.T) {
+	server, client := newDeltaRoom(), newDeltaRoom()
+	tracker := newStateTracker()
+	tracker.delta(server)
+
+	// Déplacement manqué par le client: le delta suivant ne le répète pas
+	server.Players[0].Tokens[0].Position = 5
+	tracker.delta(server)
+	server.Players[1].Tokens[3].Position = 30
+	delta := tracker.delta(server)
+
+	if !client.ApplyDelta(&delta) {
+		t.Fatal("delta only names known tokens")
+	}
+	if client.Checksum() == delta.Checksum {
+		t.Error("a missed move should change the checksum")
+	}
+
+	unknown := models.StateDeltaPayload{Tokens: []models.TokenDelta{{PlayerID: 9, TokenID: 0}}}
+	if client.ApplyDelta(&unknown) {
+		t.Error("a delta for an unknown player should ask for a resync")
+	}
+}
@@ -0,0 +1,452 @@
+// cmd/server/drain.go
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/server/game"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/database"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/rules"
+)
+
+// Mises à jour sans interruption: l'instance à remplacer est vidée (POST
+// /drain sur l'API d'administration). Elle refuse les nouvelles salles,
+// confie ses salles en attente à une autre instance du registre partagé,
+// laisse finir les parties en cours puis s'arrête.
+const (
+	heartbeatInterval  = 10 * time.Second
+	instanceTTL        = 3 * heartbeatInterval // Instance sans nouvelles considérée arrêtée
+	drainCheckInterval = 2 * time.Second
+	transferTicketTTL  = 2 * time.Minute
+	drainExitDelay     = 2 * time.Second // Laisser partir les derniers messages
+)
+
+// clusterRegistry est le registre partagé des instances, tenu dans la base
+// commune (migration 008)
+type clusterRegistry interface {
+	HeartbeatInstance(instance database.Instance) error
+	ListInstances(since time.Time) ([]database.Instance, error)
+	RemoveInstance(id string) error
+	SaveRoomTransfer(transfer database.RoomTransfer) error
+	ClaimRoomTransfer(roomID, target string) (*database.RoomTransfer, error)
+	SaveTransferTicket(ticket database.TransferTicket) error
+	ClaimTransferTicket(ticket, target string, now time.Time) (*database.TransferTicket, error)
+}
+
+// drainStatus est la réponse de /drain
+type drainStatus struct {
+	Draining bool `json:"draining"`
+	Playing  int  `json:"playing"` // Parties en cours, à laisser finir
+	Waiting  int  `json:"waiting"` // Salles en attente, à transférer
+}
+
+// handleDrain lance le vidage (POST) ou en donne l'avancement (GET)
+func (s *Server) handleDrain(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if s.draining.CompareAndSwap(false, true) {
+			log.Printf("🚰 Draining: no new rooms, waiting rooms move to another instance")
+			go s.runDrain()
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	playing, waiting := s.roomCounts()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(drainStatus{Draining: s.draining.Load(), Playing: playing, Waiting: waiting})
+}
+
+// refuseWhileDraining répond au client si l'instance se vide. Retourne
+// true si la demande doit être abandonnée.
+func (s *Server) refuseWhileDraining(client *Client) bool {
+	if !s.draining.Load() {
+		return false
+	}
+	s.sendError(client, constants.ErrServerDraining, nil)
+	return true
+}
+
+// roomCounts compte les parties en cours et les salles en attente
+func (s *Server) roomCounts() (playing, waiting int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, gameRoom := range s.rooms {
+		gameRoom.mu.RLock()
+		switch gameRoom.room.State {
+		case constants.StatePlaying:
+			playing++
+		case constants.StateWaiting:
+			waiting++
+		}
+		gameRoom.mu.RUnlock()
+	}
+	return playing, waiting
+}
+
+// heartbeat publie l'état de l'instance dans le registre à intervalle régulier
+func (s *Server) heartbeat() {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		s.publishInstance()
+		<-ticker.C
+	}
+}
+
+// publishInstance inscrit ou met à jour l'instance dans le registre
+func (s *Server) publishInstance() {
+	config := s.getConfig()
+	playing, waiting := s.roomCounts()
+
+	s.mu.RLock()
+	players := len(s.clients)
+	s.mu.RUnlock()
+
+	err := s.registry.HeartbeatInstance(database.Instance{
+		ID:        config.Cluster.InstanceID,
+		Address:   config.Cluster.PublicAddr,
+		Draining:  s.draining.Load(),
+		Players:   players,
+		Rooms:     playing + waiting,
+		Heartbeat: time.Now(),
+	})
+	if err != nil {
+		log.Printf("Failed to publish instance heartbeat: %v", err)
+	}
+}
+
+// runDrain vide l'instance puis arrête le serveur
+func (s *Server) runDrain() {
+	s.sendMatchCancelled(s.matchmaking.flush(constants.ErrServerDraining), nil)
+	if s.registry != nil {
+		s.publishInstance()
+	}
+
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		waiting := s.transferWaitingRooms()
+		playing, _ := s.roomCounts()
+		if playing > 0 {
+			continue
+		}
+		if waiting > 0 {
+			// Aucune instance pour les reprendre: prévenir avant de partir
+			log.Printf("🚰 No instance available for %d waiting room(s)", waiting)
+			s.closeWaitingRooms()
+		}
+		s.exitDrained()
+		return
+	}
+}
+
+// transferWaitingRooms confie les salles en attente à l'instance la moins
+// chargée du registre. Retourne le nombre de salles restées faute de cible.
+func (s *Server) transferWaitingRooms() int {
+	rooms := s.waitingRooms()
+	if len(rooms) == 0 {
+		return 0
+	}
+	if s.registry == nil {
+		return len(rooms)
+	}
+
+	self := s.getConfig().Cluster.InstanceID
+	instances, err := s.registry.ListInstances(time.Now().Add(-instanceTTL))
+	if err != nil {
+		log.Printf("Failed to list instances: %v", err)
+		return len(rooms)
+	}
+	target := pickSibling(instances, self)
+	if target == nil {
+		return len(rooms)
+	}
+
+	remaining := 0
+	for roomID, gameRoom := range rooms {
+		if err := s.transferRoom(roomID, gameRoom, target); err != nil {
+			log.Printf("Failed to transfer room %s to %s: %v", roomID, target.ID, err)
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// pickSibling choisit l'instance active la moins chargée, hors de self et
+// des instances en vidage. Retourne nil s'il n'y en a aucune.
+func pickSibling(instances []database.Instance, self string) *database.Instance {
+	candidates := make([]database.Instance, 0, len(instances))
+	for _, instance := range instances {
+		if instance.ID != self && !instance.Draining && instance.Address != "" {
+			candidates = append(candidates, instance)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Players < candidates[j].Players
+	})
+	return &candidates[0]
+}
+
+// waitingRooms retourne les salles en attente de joueurs
+func (s *Server) waitingRooms() map[string]*GameRoom {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rooms := make(map[string]*GameRoom)
+	for roomID, gameRoom := range s.rooms {
+		gameRoom.mu.RLock()
+		if gameRoom.room.State == constants.StateWaiting {
+			rooms[roomID] = gameRoom
+		}
+		gameRoom.mu.RUnlock()
+	}
+	return rooms
+}
+
+// transferRoom confie une salle en attente à target et y envoie ses joueurs,
+// chacun avec un ticket de connexion. La salle disparaît de cette instance.
+func (s *Server) transferRoom(roomID string, gameRoom *GameRoom, target *database.Instance) error {
+	gameRoom.mu.Lock()
+	if gameRoom.room.State != constants.StateWaiting {
+		// Partie lancée entre-temps: elle finira ici
+		gameRoom.mu.Unlock()
+		return nil
+	}
+	players := len(gameRoom.clients)
+	if err := s.handOverRoom(roomID, gameRoom, target); err != nil {
+		gameRoom.mu.Unlock()
+		return err
+	}
+	gameRoom.mu.Unlock()
+
+	// Diffuser les derniers messages avant que la salle disparaisse: un
+	// joueur qui se déconnecte ensuite ne la trouve plus
+	gameRoom.close()
+
+	// Le verrou du serveur se prend avant celui d'une salle, jamais après
+	s.mu.Lock()
+	delete(s.rooms, roomID)
+	s.mu.Unlock()
+
+	log.Printf("🚰 Room %s transferred to %s (%d player(s))", roomID, target.ID, players)
+	return nil
+}
+
+// handOverRoom enregistre la salle et les tickets de ses joueurs dans le
+// registre, puis envoie chacun vers target. L'appelant détient le verrou
+// de la salle.
+func (s *Server) handOverRoom(roomID string, gameRoom *GameRoom, target *database.Instance) error {
+	// Les joueurs rejoignent la salle recréée: seuls ses réglages voyagent
+	settings := *gameRoom.room
+	settings.Players = nil
+	settings.Match = nil
+	err := s.registry.SaveRoomTransfer(database.RoomTransfer{
+		RoomID:       roomID,
+		Target:       target.ID,
+		Room:         &settings,
+		PasswordHash: gameRoom.room.Password,
+	})
+	if err != nil {
+		return err
+	}
+
+	expires := time.Now().Add(transferTicketTTL)
+	for _, client := range gameRoom.clients {
+		ticket := newSessionToken()
+		err := s.registry.SaveTransferTicket(database.TransferTicket{
+			Ticket:    ticket,
+			UserID:    client.userID,
+			Username:  client.username,
+			RoomID:    roomID,
+			Target:    target.ID,
+			ExpiresAt: expires,
+		})
+		if err != nil {
+			log.Printf("Failed to issue transfer ticket for %s: %v", client.username, err)
+			continue
+		}
+		client.roomID = ""
+		s.sendMessage(client, &models.NetworkMessage{
+			Type:      constants.MsgTransfer,
+			Payload:   models.TransferPayload{Address: target.Address, RoomID: roomID, Ticket: ticket},
+			Timestamp: time.Now(),
+		})
+	}
+	s.dropSpectators(gameRoom)
+	return nil
+}
+
+// dropSpectators détache les spectateurs d'une salle qui quitte l'instance.
+// L'appelant détient le verrou de la salle.
+func (s *Server) dropSpectators(gameRoom *GameRoom) {
+	gameRoom.spectatorMu.Lock()
+	defer gameRoom.spectatorMu.Unlock()
+
+	for _, spectator := range gameRoom.spectators {
+		spectator.roomID = ""
+		spectator.spectating = false
+		s.sendError(spectator, constants.ErrServerDraining, nil)
+	}
+	gameRoom.spectators = make(map[uint64]*Client)
+}
+
+// closeWaitingRooms prévient les joueurs des salles en attente restées sans
+// instance pour les reprendre
+func (s *Server) closeWaitingRooms() {
+	for _, gameRoom := range s.waitingRooms() {
+		gameRoom.mu.RLock()
+		for _, client := range gameRoom.clients {
+			s.sendError(client, constants.ErrServerDraining, nil)
+		}
+		gameRoom.mu.RUnlock()
+	}
+}
+
+// exitDrained quitte le registre, enregistre les statistiques en attente et
+// arrête le serveur. Les connexions encore ouvertes sont coupées par la
+// sortie du processus.
+func (s *Server) exitDrained() {
+	if s.registry != nil {
+		if err := s.registry.RemoveInstance(s.getConfig().Cluster.InstanceID); err != nil {
+			log.Printf("Failed to leave the instance registry: %v", err)
+		}
+	}
+	time.Sleep(drainExitDelay)
+	if s.stats != nil {
+		s.stats.Close()
+	}
+	if s.recorder != nil {
+		s.recorder.Close()
+	}
+	log.Printf("🚰 Drained, exiting")
+	os.Exit(0)
+}
+
+// flush vide la file de matchmaking. Chaque joueur en attente ou à qui une
+// table était proposée reçoit un avis d'annulation.
+func (q *MatchmakingQueue) flush(reason string) map[*Client]models.MatchCancelledPayload {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	notices := make(map[*Client]models.MatchCancelledPayload)
+	for _, match := range q.pending {
+		everyone := make(map[int64]bool, len(match.entries))
+		for _, entry := range match.entries {
+			everyone[entry.client.userID] = true
+		}
+		for client, notice := range q.cancel(match, everyone, reason) {
+			notices[client] = notice
+		}
+	}
+	for _, entry := range q.waiting {
+		notices[entry.client] = models.MatchCancelledPayload{Reason: reason}
+	}
+	q.waiting = nil
+	return notices
+}
+
+// loginTransferred authentifie un joueur arrivant d'une instance en vidage
+// avec son ticket à usage unique
+func (s *Server) loginTransferred(client *Client, ticket string) {
+	if s.registry == nil {
+		s.sendError(client, constants.ErrLoginExpired, nil)
+		return
+	}
+
+	claimed, err := s.registry.ClaimTransferTicket(ticket, s.getConfig().Cluster.InstanceID, time.Now())
+	if err != nil {
+		log.Printf("Failed to claim transfer ticket: %v", err)
+		s.sendError(client, constants.ErrServerFailure, nil)
+		return
+	}
+	if claimed == nil {
+		s.sendError(client, constants.ErrLoginExpired, nil)
+		return
+	}
+
+	user, err := s.db.GetUserByID(claimed.UserID)
+	if err != nil {
+		log.Printf("Failed to load user %d: %v", claimed.UserID, err)
+		user = &models.User{ID: claimed.UserID, Username: claimed.Username}
+	}
+	client.transferRoom = claimed.RoomID
+	s.authenticate(client, claimed.UserID, claimed.Username, user)
+}
+
+// claimTransferredRoom recrée la salle confiée à cette instance par une
+// instance en vidage. Retourne nil si aucune salle n'attend sous ce code.
+func (s *Server) claimTransferredRoom(roomID string) *GameRoom {
+	if s.registry == nil {
+		return nil
+	}
+
+	s.transferMu.Lock()
+	defer s.transferMu.Unlock()
+
+	// Un autre joueur de la salle est peut-être arrivé le premier
+	s.mu.RLock()
+	gameRoom := s.rooms[roomID]
+	s.mu.RUnlock()
+	if gameRoom != nil {
+		return gameRoom
+	}
+
+	transfer, err := s.registry.ClaimRoomTransfer(roomID, s.getConfig().Cluster.InstanceID)
+	if err != nil {
+		log.Printf("Failed to claim transferred room %s: %v", roomID, err)
+		return nil
+	}
+	if transfer == nil || transfer.Room == nil {
+		return nil
+	}
+
+	// La variante a été vérifiée à la création sur l'autre instance
+	var variant *rules.Script
+	if transfer.Room.Rules != "" {
+		if variant, err = rules.Compile(transfer.Room.Rules); err != nil {
+			log.Printf("Transferred room %s has invalid rules: %v", roomID, err)
+			return nil
+		}
+	}
+
+	room := transfer.Room
+	room.ID = roomID
+	room.Password = transfer.PasswordHash
+	room.State = constants.StateWaiting
+	room.Players = make([]*models.Player, 0, constants.MaxPlayers)
+	if room.Series == nil {
+		room.Series = make(map[int64]int)
+	}
+
+	gameRoom = newGameRoom(room)
+	if room.BestOf > 0 {
+		gameRoom.match = game.NewMatch(room.BestOf)
+		room.Match = gameRoom.match.Score()
+	}
+	gameRoom.engine = s.newEngine(roomID, gameRoom, variant)
+	gameRoom.open(roomID)
+
+	s.mu.Lock()
+	s.rooms[roomID] = gameRoom
+	s.mu.Unlock()
+
+	log.Printf("🚰 Room %s taken over from a draining instance", roomID)
+	return gameRoom
+}
@@ -0,0 +1,173 @@
+// cmd/server/drain_test.go
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/server/game"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/database"
+)
+
+// memoryRegistry remplace la base partagée entre deux instances de test
+type memoryRegistry struct {
+	instances map[string]database.Instance
+	rooms     map[string]database.RoomTransfer
+	tickets   map[string]database.TransferTicket
+}
+
+func newMemoryRegistry() *memoryRegistry {
+	return &memoryRegistry{
+		instances: make(map[string]database.Instance),
+		rooms:     make(map[string]database.RoomTransfer),
+		tickets:   make(map[string]database.TransferTicket),
+	}
+}
+
+func (m *memoryRegistry) HeartbeatInstance(instance database.Instance) error {
+	m.instances[instance.ID] = instance
+	return nil
+}
+
+func (m *memoryRegistry) ListInstances(since time.Time) ([]database.Instance, error) {
+	var instances []database.Instance
+	for _, instance := range m.instances {
+		if !instance.Heartbeat.Before(since) {
+			instances = append(instances, instance)
+		}
+	}
+	return instances, nil
+}
+
+func (m *memoryRegistry) RemoveInstance(id string) error {
+	delete(m.instances, id)
+	return nil
+}
+
+func (m *memoryRegistry) SaveRoomTransfer(transfer database.RoomTransfer) error {
+	m.rooms[transfer.RoomID] = transfer
+	return nil
+}
+
+func (m *memoryRegistry) ClaimRoomTransfer(roomID, target string) (*database.RoomTransfer, error) {
+	transfer, ok := m.rooms[roomID]
+	if !ok || transfer.Target != target {
+		return nil, nil
+	}
+	delete(m.rooms, roomID)
+	return &transfer, nil
+}
+
+func (m *memoryRegistry) SaveTransferTicket(ticket database.TransferTicket) error {
+	m.tickets[ticket.Ticket] = ticket
+	return nil
+}
+
+func (m *memoryRegistry) ClaimTransferTicket(ticket, target string, now time.Time) (*database.TransferTicket, error) {
+	claimed, ok := m.tickets[ticket]
+	if !ok || claimed.Target != target || now.After(claimed.ExpiresAt) {
+		return nil, nil
+	}
+	delete(m.tickets, ticket)
+	return &claimed, nil
+}
+
+func newClusterServer(registry clusterRegistry, id, addr string) *Server {
+	config := &Config{}
+	config.Game.TurnTimeout = 30
+	config.Cluster.InstanceID = id
+	config.Cluster.PublicAddr = addr
+	return &Server{
+		clients:     make(map[int64]*Client),
+		rooms:       make(map[string]*GameRoom),
+		matchmaking: newMatchmakingQueue(),
+		config:      config,
+		registry:    registry,
+	}
+}
+
+func TestPickSiblingSkipsSelfAndDraining(t *testing.T) {
+	instances := []database.Instance{
+		{ID: "a", Address: "a:8080", Players: 1},
+		{ID: "b", Address: "b:8080", Players: 40},
+		{ID: "c", Address: "c:8080", Players: 3},
+		{ID: "d", Address: "d:8080", Players: 0, Draining: true},
+	}
+	if got := pickSibling(instances, "a"); got == nil || got.ID != "c" {
+		t.Fatalf("sibling = %+v, want the least loaded active instance c", got)
+	}
+	if got := pickSibling(instances[:1], "a"); got != nil {
+		t.Errorf("sibling = %+v, want none when alone", got)
+	}
+}
+
+func TestDrainTransfersWaitingRoom(t *testing.T) {
+	registry := newMemoryRegistry()
+	old := newClusterServer(registry, "old", "old:8080")
+	next := newClusterServer(registry, "next", "next:8080")
+	next.publishInstance()
+
+	host := &Client{userID: 1, username: "host", roomID: "ABC234", send: make(chan *models.NetworkMessage, 8)}
+	gameRoom := newGameRoom(&models.Room{
+		ID:         "ABC234",
+		Name:       "Friday night",
+		HostID:     host.userID,
+		MaxPlayers: 4,
+		State:      constants.StateWaiting,
+		Password:   "hash",
+		Players:    []*models.Player{models.NewPlayer(host.userID, host.username, constants.ColorRed)},
+	})
+	gameRoom.engine = game.NewEngine(gameRoom.room, models.RuleConfig{}, game.EngineCallbacks{})
+	gameRoom.clients[host.userID] = host
+	old.rooms["ABC234"] = gameRoom
+
+	old.draining.Store(true)
+	if remaining := old.transferWaitingRooms(); remaining != 0 {
+		t.Fatalf("remaining = %d, want the room transferred", remaining)
+	}
+	if len(old.rooms) != 0 || host.roomID != "" {
+		t.Fatal("transferred room should leave the draining instance")
+	}
+
+	msg := lastMessage(host)
+	if msg == nil || msg.Type != constants.MsgTransfer {
+		t.Fatalf("host got %+v, want %s", msg, constants.MsgTransfer)
+	}
+	transfer := msg.Payload.(models.TransferPayload)
+	if transfer.Address != "next:8080" || transfer.RoomID != "ABC234" {
+		t.Errorf("transfer = %+v, want room ABC234 on next:8080", transfer)
+	}
+
+	// Sur l'instance cible, le ticket est à usage unique
+	claimed, _ := registry.ClaimTransferTicket(transfer.Ticket, "next", time.Now())
+	if claimed == nil || claimed.UserID != host.userID {
+		t.Fatalf("ticket = %+v, want one for the host", claimed)
+	}
+	if again, _ := registry.ClaimTransferTicket(transfer.Ticket, "next", time.Now()); again != nil {
+		t.Error("a transfer ticket must not be usable twice")
+	}
+
+	recreated := next.claimTransferredRoom("ABC234")
+	if recreated == nil {
+		t.Fatal("target instance did not recreate the room")
+	}
+	if recreated.room.Name != "Friday night" || recreated.room.HostID != host.userID || recreated.room.Password != "hash" {
+		t.Errorf("recreated room = %+v, want the original settings", recreated.room)
+	}
+	if next.claimTransferredRoom("ABC234") != recreated {
+		t.Error("later players should join the same recreated room")
+	}
+}
+
+func TestDrainingRefusesNewRooms(t *testing.T) {
+	s := newClusterServer(nil, "", "")
+	s.draining.Store(true)
+	client := &Client{userID: 1, send: make(chan *models.NetworkMessage, 4)}
+
+	s.handleFindMatch(client, &models.NetworkMessage{})
+	if msg := lastMessage(client); msg == nil || msg.Payload.(models.ErrorPayload).Code != constants.ErrServerDraining {
+		t.Fatalf("find match reply = %+v, want %s", msg, constants.ErrServerDraining)
+	}
+}
@@ -0,0 +1,46 @@
+// cmd/server/handicap.go
+package main
+
+import (
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// handleSetHandicap permet à un joueur autorisé de régler le handicap de départ d'un
+// joueur avant la partie. Le lobby de tous les joueurs est mis à jour.
+func (s *Server) handleSetHandicap(client *Client, msg *models.NetworkMessage) {
+	var payload models.SetHandicapPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+	switch payload.Handicap {
+	case constants.HandicapNone, constants.HandicapHeadStart, constants.HandicapExtraLap:
+	default:
+		s.sendError(client, constants.ErrUnknownHandicap, nil)
+		return
+	}
+
+	gameRoom := s.lobbyRoom(client, constants.PermChangeRules)
+	if gameRoom == nil {
+		return
+	}
+
+	gameRoom.mu.Lock()
+	found := false
+	for _, player := range gameRoom.room.Players {
+		if player.ID == payload.PlayerID {
+			player.Handicap = payload.Handicap
+			found = true
+		}
+	}
+	gameRoom.mu.Unlock()
+
+	if !found {
+		s.sendError(client, constants.ErrPlayerNotInRoom, nil)
+		return
+	}
+
+	s.broadcastLobby(client.roomID, gameRoom)
+}
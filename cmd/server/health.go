@@ -0,0 +1,164 @@
+// cmd/server/health.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+)
+
+// Garde-fous contre les fuites de mémoire et de goroutines
+const (
+	healthCheckInterval   = time.Minute
+	goroutineGrowthChecks = 10               // Contrôles consécutifs en hausse avant alerte
+	goroutinesPerClient   = 4                // Lecture, écriture, marge pour les timers
+	goroutineBaseline     = 50               // Goroutines du serveur hors clients
+	staleRoomAge          = 10 * time.Minute // Salle terminée ou vide jamais supprimée
+
+	goroutinesPerRoom = 2 // Goroutine propriétaire et diffusion (roombus.go)
+)
+
+// healthSnapshot est le résultat d'un contrôle
+type healthSnapshot struct {
+	Goroutines int
+	HeapBytes  uint64
+	Rooms      int
+	Clients    int
+	Sessions   int
+	StaleRooms int
+}
+
+// healthMonitor suit l'évolution des ressources entre deux contrôles
+type healthMonitor struct {
+	last      healthSnapshot
+	growth    int                  // Contrôles consécutifs où les goroutines augmentent
+	idleSince map[string]time.Time // Salles terminées ou vides, depuis quand
+	alerts    map[string]int64     // Nombre d'alertes par type
+	checkedAt time.Time
+	mu        sync.Mutex
+}
+
+func newHealthMonitor() *healthMonitor {
+	return &healthMonitor{
+		idleSince: make(map[string]time.Time),
+		alerts:    make(map[string]int64),
+	}
+}
+
+// watchHealth lance les contrôles périodiques
+func (s *Server) watchHealth() {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.checkHealth(time.Now())
+	}
+}
+
+// checkHealth relève les compteurs et journalise les croissances anormales
+func (s *Server) checkHealth(now time.Time) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	snap := healthSnapshot{
+		Goroutines: runtime.NumGoroutine(),
+		HeapBytes:  mem.HeapAlloc,
+		Sessions:   s.sessions.Len(),
+	}
+
+	idle := make(map[string]bool)
+	s.mu.RLock()
+	snap.Rooms = len(s.rooms)
+	snap.Clients = len(s.clients)
+	for id, gameRoom := range s.rooms {
+		gameRoom.mu.RLock()
+		if gameRoom.room.State == constants.StateFinished || len(gameRoom.clients) == 0 {
+			idle[id] = true
+		}
+		gameRoom.mu.RUnlock()
+	}
+	s.mu.RUnlock()
+
+	h := s.health
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for id := range h.idleSince {
+		if !idle[id] {
+			delete(h.idleSince, id)
+		}
+	}
+	for id := range idle {
+		since, seen := h.idleSince[id]
+		if !seen {
+			h.idleSince[id] = now
+		} else if now.Sub(since) >= staleRoomAge {
+			snap.StaleRooms++
+		}
+	}
+
+	if !h.checkedAt.IsZero() && snap.Goroutines > h.last.Goroutines {
+		h.growth++
+	} else {
+		h.growth = 0
+	}
+
+	if h.growth >= goroutineGrowthChecks {
+		h.alertLocked("goroutine_growth", "goroutines grew for %d checks in a row (%d now)", h.growth, snap.Goroutines)
+	}
+	if budget := goroutineBaseline + goroutinesPerClient*snap.Clients + goroutinesPerRoom*snap.Rooms; snap.Goroutines > budget {
+		h.alertLocked("goroutine_budget", "%d goroutines for %d clients and %d rooms (budget %d): timers or loops may not be stopped",
+			snap.Goroutines, snap.Clients, snap.Rooms, budget)
+	}
+	if snap.StaleRooms > 0 {
+		h.alertLocked("stale_rooms", "%d rooms finished or empty for over %v are still in memory", snap.StaleRooms, staleRoomAge)
+	}
+
+	h.last = snap
+	h.checkedAt = now
+}
+
+// alertLocked journalise une alerte et incrémente son compteur
+func (h *healthMonitor) alertLocked(kind, format string, args ...interface{}) {
+	h.alerts[kind]++
+	log.Printf("⚠️ Leak guard [%s]: %s", kind, fmt.Sprintf(format, args...))
+}
+
+// handleMetrics expose les compteurs au format texte Prometheus
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	s.health.mu.Lock()
+	snap := s.health.last
+	alerts := make(map[string]int64, len(s.health.alerts))
+	for kind, count := range s.health.alerts {
+		alerts[kind] = count
+	}
+	s.health.mu.Unlock()
+
+	stats := s.stats.Metrics()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintf(w, "ludo_goroutines %d\n", runtime.NumGoroutine())
+	fmt.Fprintf(w, "ludo_heap_bytes %d\n", snap.HeapBytes)
+	fmt.Fprintf(w, "ludo_rooms %d\n", snap.Rooms)
+	fmt.Fprintf(w, "ludo_clients %d\n", snap.Clients)
+	fmt.Fprintf(w, "ludo_sessions %d\n", snap.Sessions)
+	fmt.Fprintf(w, "ludo_stale_rooms %d\n", snap.StaleRooms)
+	fmt.Fprintf(w, "ludo_stats_queue_depth %d\n", stats.QueueDepth)
+	fmt.Fprintf(w, "ludo_stats_rejected_total %d\n", stats.Rejected)
+	s.writeBandwidthMetrics(w)
+
+	kinds := make([]string, 0, len(alerts))
+	for kind := range alerts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		fmt.Fprintf(w, "ludo_leak_alerts_total{kind=%q} %d\n", kind, alerts[kind])
+	}
+}
@@ -0,0 +1,37 @@
+// cmd/server/health_test.go
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestCheckHealthFlagsStaleRooms(t *testing.T) {
+	s := &Server{
+		clients:  make(map[int64]*Client),
+		rooms:    make(map[string]*GameRoom),
+		sessions: NewSessionStore(),
+		health:   newHealthMonitor(),
+	}
+	s.rooms["ABC234"] = &GameRoom{
+		room:    &models.Room{ID: "ABC234", State: constants.StateFinished},
+		clients: make(map[int64]*Client),
+	}
+
+	start := time.Now()
+	s.checkHealth(start)
+	if s.health.alerts["stale_rooms"] != 0 {
+		t.Fatal("a room that just finished should not be reported yet")
+	}
+
+	s.checkHealth(start.Add(staleRoomAge))
+	if s.health.alerts["stale_rooms"] != 1 {
+		t.Errorf("stale_rooms alerts = %d, want 1", s.health.alerts["stale_rooms"])
+	}
+	if s.health.last.StaleRooms != 1 {
+		t.Errorf("StaleRooms = %d, want 1", s.health.last.StaleRooms)
+	}
+}
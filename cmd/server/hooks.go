@@ -0,0 +1,90 @@
+// cmd/server/hooks.go
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/hooks"
+)
+
+// announcerName est l'expéditeur des annonces des extensions dans le chat
+const announcerName = "📢 Server"
+
+// loadHooks charge les plugins Go et lance les scripts de la configuration
+func (s *Server) loadHooks(plugins, scripts []string) {
+	for _, path := range plugins {
+		h, err := hooks.LoadPlugin(path, s)
+		if err != nil {
+			log.Printf("⚠️ Failed to load plugin %s: %v", path, err)
+			continue
+		}
+		s.hooks.Register(h)
+		log.Printf("🧩 Plugin %s loaded", path)
+	}
+
+	for _, path := range scripts {
+		script, err := hooks.StartScript(path, s)
+		if err != nil {
+			log.Printf("⚠️ Failed to start hook script %s: %v", path, err)
+			continue
+		}
+		s.hooks.Register(script)
+		log.Printf("🧩 Hook script %s started", path)
+	}
+}
+
+// Announce envoie un message du serveur dans le chat d'une salle, ou de
+// toutes les salles si roomID est vide
+func (s *Server) Announce(roomID, text string) {
+	msg := &models.NetworkMessage{
+		Type: constants.MsgChatMessage,
+		Payload: models.ChatPayload{
+			Username: announcerName,
+			Text:     text,
+			SentAt:   time.Now(),
+		},
+		Timestamp: time.Now(),
+	}
+
+	if roomID != "" {
+		s.broadcastToRoom(roomID, msg)
+		return
+	}
+
+	s.mu.RLock()
+	ids := make([]string, 0, len(s.rooms))
+	for id := range s.rooms {
+		ids = append(ids, id)
+	}
+	s.mu.RUnlock()
+
+	for _, id := range ids {
+		s.broadcastToRoom(id, msg)
+	}
+}
+
+// GrantCoins crédite des coins à un joueur pour le compte d'une extension
+func (s *Server) GrantCoins(userID int64, amount int) error {
+	return s.db.AddCoins(userID, amount)
+}
+
+// hookPlayer copie un joueur pour les extensions
+func hookPlayer(p *models.Player) hooks.Player {
+	return hooks.Player{
+		ID:       p.ID,
+		Username: p.Username,
+		Color:    string(p.Color),
+		IsAI:     p.IsAI,
+	}
+}
+
+func hookPlayers(players []*models.Player) []hooks.Player {
+	copies := make([]hooks.Player, 0, len(players))
+	for _, p := range players {
+		copies = append(copies, hookPlayer(p))
+	}
+	return copies
+}
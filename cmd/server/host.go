@@ -0,0 +1,181 @@
+// cmd/server/host.go
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/roles"
+)
+
+// permittedRoom retourne la salle du client si son rôle lui donne la
+// permission demandée. Sinon l'erreur est envoyée au client et le résultat
+// est nil.
+func (s *Server) permittedRoom(client *Client, perm constants.Permission) *GameRoom {
+	s.mu.RLock()
+	gameRoom := s.rooms[client.roomID]
+	s.mu.RUnlock()
+
+	if gameRoom == nil {
+		s.sendError(client, constants.ErrRoomNotFound, nil)
+		return nil
+	}
+
+	gameRoom.mu.RLock()
+	err := roles.Check(gameRoom.room, client.userID, perm)
+	gameRoom.mu.RUnlock()
+
+	if err != nil {
+		s.sendFailure(client, err, constants.ErrPermissionDenied)
+		return nil
+	}
+	return gameRoom
+}
+
+// lobbyRoom est permittedRoom pour les actions réservées à la salle en
+// attente
+func (s *Server) lobbyRoom(client *Client, perm constants.Permission) *GameRoom {
+	gameRoom := s.permittedRoom(client, perm)
+	if gameRoom == nil {
+		return nil
+	}
+
+	gameRoom.mu.RLock()
+	waiting := gameRoom.room.State == constants.StateWaiting
+	gameRoom.mu.RUnlock()
+
+	if !waiting {
+		s.sendError(client, constants.ErrGameStarted, nil)
+		return nil
+	}
+	return gameRoom
+}
+
+// outranks vérifie que le client a un rang supérieur au joueur visé. Sinon
+// le refus est envoyé au client.
+func (s *Server) outranks(client *Client, gameRoom *GameRoom, playerID int64, perm constants.Permission) bool {
+	gameRoom.mu.RLock()
+	ok := roles.Outranks(gameRoom.room, client.userID, playerID)
+	gameRoom.mu.RUnlock()
+
+	if !ok {
+		s.sendError(client, constants.ErrPermissionDenied, i18n.Params{"permission": string(perm)})
+	}
+	return ok
+}
+
+// broadcastLobby renvoie l'état de la salle en attente à tous ses joueurs
+func (s *Server) broadcastLobby(roomID string, gameRoom *GameRoom) {
+	gameRoom.mu.RLock()
+	engine := gameRoom.engine
+	gameRoom.mu.RUnlock()
+
+	s.broadcastToRoom(roomID, &models.NetworkMessage{
+		Type:      constants.MsgGameState,
+		Payload:   models.GameStatePayload{Game: gameRoom.snapshot(engine.GetGameState())},
+		Timestamp: time.Now(),
+	})
+}
+
+// handleKickPlayer exclut un joueur de rang inférieur de la salle avant la
+// partie. Il ne peut plus la rejoindre, mais peut encore la regarder.
+func (s *Server) handleKickPlayer(client *Client, msg *models.NetworkMessage) {
+	var payload models.KickPlayerPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+
+	gameRoom := s.lobbyRoom(client, constants.PermKick)
+	if gameRoom == nil {
+		return
+	}
+	if payload.PlayerID == client.userID {
+		s.sendError(client, constants.ErrCannotKickSelf, nil)
+		return
+	}
+	if !s.outranks(client, gameRoom, payload.PlayerID, constants.PermKick) {
+		return
+	}
+
+	gameRoom.mu.RLock()
+	engine := gameRoom.engine
+	gameRoom.mu.RUnlock()
+
+	// Le moteur prend son propre verrou: ne pas détenir celui de la salle
+	if err := engine.RemovePlayer(payload.PlayerID); err != nil {
+		s.sendFailure(client, err, constants.ErrPlayerNotInRoom)
+		return
+	}
+
+	roomID := client.roomID
+	gameRoom.mu.Lock()
+	kicked := gameRoom.clients[payload.PlayerID]
+	left := gameRoom.removeClient(payload.PlayerID)
+	gameRoom.kicked[payload.PlayerID] = true
+	roles.Forget(gameRoom.room, payload.PlayerID)
+	gameRoom.mu.Unlock()
+	<-left
+
+	if kicked != nil {
+		kicked.roomID = ""
+		s.sendMessage(kicked, &models.NetworkMessage{
+			Type:      constants.MsgKicked,
+			Payload:   models.KickedPayload{RoomID: roomID, Reason: constants.ErrKickedFromRoom},
+			Timestamp: time.Now(),
+		})
+		log.Printf("%s kicked %s from room %s", client.username, kicked.username, roomID)
+	}
+
+	s.broadcastLobby(roomID, gameRoom)
+}
+
+// handleAssignColor change la couleur d'un joueur avant la partie. Le
+// joueur qui avait cette couleur prend l'ancienne.
+func (s *Server) handleAssignColor(client *Client, msg *models.NetworkMessage) {
+	var payload models.AssignColorPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+
+	gameRoom := s.lobbyRoom(client, constants.PermChangeRules)
+	if gameRoom == nil {
+		return
+	}
+
+	gameRoom.mu.RLock()
+	engine := gameRoom.engine
+	gameRoom.mu.RUnlock()
+
+	if err := engine.AssignColor(payload.PlayerID, payload.Color); err != nil {
+		s.sendFailure(client, err, constants.ErrUnknownColor)
+		return
+	}
+
+	s.broadcastLobby(client.roomID, gameRoom)
+}
+
+// handleStartGame lance la partie à la demande d'un joueur autorisé, même
+// si tous les joueurs ne sont pas prêts
+func (s *Server) handleStartGame(client *Client, msg *models.NetworkMessage) {
+	gameRoom := s.lobbyRoom(client, constants.PermStartGame)
+	if gameRoom == nil {
+		return
+	}
+
+	gameRoom.mu.RLock()
+	players := len(gameRoom.room.Players)
+	gameRoom.mu.RUnlock()
+
+	if players < constants.MinPlayers {
+		s.sendError(client, constants.ErrNotEnoughPlayers, i18n.Params{"min": constants.MinPlayers})
+		return
+	}
+
+	s.startGame(client.roomID, gameRoom)
+}
@@ -0,0 +1,182 @@
+// cmd/server/host_test.go
+package main
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/server/game"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/roles"
+)
+
+func newLobbyServer() (*Server, *Client, *Client) {
+	host := &Client{userID: 1, username: "host", roomID: "ABC234", send: make(chan *models.NetworkMessage, 8)}
+	guest := &Client{userID: 2, username: "guest", roomID: "ABC234", send: make(chan *models.NetworkMessage, 8)}
+
+	gameRoom := newGameRoom(&models.Room{
+		ID:         "ABC234",
+		HostID:     host.userID,
+		MaxPlayers: 4,
+		State:      constants.StateWaiting,
+		Players: []*models.Player{
+			models.NewPlayer(host.userID, host.username, constants.ColorRed),
+			models.NewPlayer(guest.userID, guest.username, constants.ColorYellow),
+		},
+	})
+	gameRoom.engine = game.NewEngine(gameRoom.room, models.RuleConfig{}, game.EngineCallbacks{})
+	gameRoom.clients[host.userID] = host
+	gameRoom.clients[guest.userID] = guest
+
+	s := &Server{
+		clients:     map[int64]*Client{1: host, 2: guest},
+		rooms:       map[string]*GameRoom{"ABC234": gameRoom},
+		matchmaking: newMatchmakingQueue(),
+	}
+	return s, host, guest
+}
+
+func lastMessage(client *Client) *models.NetworkMessage {
+	var last *models.NetworkMessage
+	for len(client.send) > 0 {
+		last = <-client.send
+	}
+	return last
+}
+
+func TestKickPlayerRemovesAndBarsRejoin(t *testing.T) {
+	s, host, guest := newLobbyServer()
+
+	s.handleKickPlayer(guest, &models.NetworkMessage{Payload: models.KickPlayerPayload{PlayerID: host.userID}})
+	if msg := lastMessage(guest); msg == nil || msg.Payload.(models.ErrorPayload).Code != constants.ErrPermissionDenied {
+		t.Fatalf("guest kick reply = %+v, want %s", msg, constants.ErrPermissionDenied)
+	}
+
+	s.handleKickPlayer(host, &models.NetworkMessage{Payload: models.KickPlayerPayload{PlayerID: guest.userID}})
+	if msg := lastMessage(guest); msg == nil || msg.Type != constants.MsgKicked {
+		t.Fatalf("kicked player got %+v, want %s", msg, constants.MsgKicked)
+	}
+	if msg := lastMessage(host); msg == nil || msg.Type != constants.MsgGameState {
+		t.Fatalf("host got %+v, want the updated lobby", msg)
+	}
+	gameRoom := s.rooms["ABC234"]
+	if len(gameRoom.room.Players) != 1 || gameRoom.clients[guest.userID] != nil || guest.roomID != "" {
+		t.Fatal("kicked player is still seated in the room")
+	}
+
+	s.handleJoinRoom(guest, &models.NetworkMessage{Payload: map[string]interface{}{"room_id": "ABC234"}})
+	if msg := lastMessage(guest); msg == nil || msg.Payload.(models.ErrorPayload).Code != constants.ErrKickedFromRoom {
+		t.Fatalf("rejoin reply = %+v, want %s", msg, constants.ErrKickedFromRoom)
+	}
+}
+
+func TestStartGameNeedsEnoughPlayers(t *testing.T) {
+	s, host, guest := newLobbyServer()
+	s.handleKickPlayer(host, &models.NetworkMessage{Payload: models.KickPlayerPayload{PlayerID: guest.userID}})
+	lastMessage(host)
+
+	s.handleStartGame(host, &models.NetworkMessage{})
+	if msg := lastMessage(host); msg == nil || msg.Payload.(models.ErrorPayload).Code != constants.ErrNotEnoughPlayers {
+		t.Fatalf("start reply = %+v, want %s", msg, constants.ErrNotEnoughPlayers)
+	}
+}
+
+func TestMalformedPayloadIsRefusedBeforeDispatch(t *testing.T) {
+	s, host, _ := newLobbyServer()
+
+	s.handleMessage(host, &models.NetworkMessage{
+		Type:    constants.MsgCreateRoom,
+		Payload: map[string]interface{}{"name": "Room", "max_players": "four"},
+	})
+	if msg := lastMessage(host); msg == nil || msg.Payload.(models.ErrorPayload).Code != constants.ErrInvalidPayload {
+		t.Errorf("Expected %s, got %+v", constants.ErrInvalidPayload, msg)
+	}
+}
+
+func TestLobbyThemeNeedsAnOfferedPack(t *testing.T) {
+	s, host, guest := newLobbyServer()
+	dir := t.TempDir()
+	file, err := os.Create(filepath.Join(dir, "disco.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := zip.NewWriter(file)
+	w, _ := archive.Create("manifest.json")
+	w.Write([]byte(`{"name":"disco","title":"Disco","version":1,"music":{"fever":"fever.mp3"}}`))
+	archive.Close()
+	file.Close()
+	s.config = &Config{}
+	s.config.Server.AssetsAddr = ":8081"
+	s.config.Server.AssetsDir = dir
+
+	setTheme := func(client *Client, theme models.LobbyTheme) *models.NetworkMessage {
+		s.handleSetLobbyTheme(client, &models.NetworkMessage{Payload: models.SetLobbyThemePayload{Theme: &theme}})
+		return lastMessage(client)
+	}
+
+	if msg := setTheme(guest, models.LobbyTheme{Background: "#ff00aa"}); msg.Payload.(models.ErrorPayload).Code != constants.ErrPermissionDenied {
+		t.Fatalf("guest theme reply = %+v, want %s", msg, constants.ErrPermissionDenied)
+	}
+	if msg := setTheme(host, models.LobbyTheme{Pack: "halloween"}); msg.Payload.(models.ErrorPayload).Code != constants.ErrUnknownTheme {
+		t.Fatalf("unknown pack reply = %+v, want %s", msg, constants.ErrUnknownTheme)
+	}
+	if msg := setTheme(host, models.LobbyTheme{Pack: "disco", Track: "stayin"}); msg.Payload.(models.ErrorPayload).Code != constants.ErrUnknownTrack {
+		t.Fatalf("unknown track reply = %+v, want %s", msg, constants.ErrUnknownTrack)
+	}
+
+	want := models.LobbyTheme{Background: "#ff00aa", Pack: "disco", Track: "fever"}
+	if msg := setTheme(host, want); msg.Type != constants.MsgGameState {
+		t.Fatalf("theme reply = %+v, want the updated lobby", msg)
+	}
+	if msg := lastMessage(guest); msg == nil || *msg.Payload.(models.GameStatePayload).Game.Room.Theme != want {
+		t.Fatalf("guest did not receive the theme: %+v", msg)
+	}
+}
+
+func TestPausePermissionGuardsFriendlyGames(t *testing.T) {
+	s, host, guest := newLobbyServer()
+	gameRoom := s.rooms["ABC234"]
+	if err := gameRoom.engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer gameRoom.engine.Abort()
+
+	s.handlePauseGame(guest, &models.NetworkMessage{})
+	if msg := lastMessage(guest); msg == nil || msg.Payload.(models.ErrorPayload).Code != constants.ErrPermissionDenied {
+		t.Fatalf("guest pause reply = %+v, want %s", msg, constants.ErrPermissionDenied)
+	}
+
+	// Un modérateur à qui l'hôte donne la permission peut mettre en pause
+	if err := roles.Assign(gameRoom.room, guest.userID, constants.RoleModerator); err != nil {
+		t.Fatal(err)
+	}
+	if err := roles.SetPermissions(gameRoom.room, constants.RoleModerator, []constants.Permission{constants.PermPause}); err != nil {
+		t.Fatal(err)
+	}
+	s.handlePauseGame(guest, &models.NetworkMessage{})
+	if !gameRoom.engine.Paused() {
+		t.Fatal("moderator pause did not pause the game")
+	}
+	s.handleResumeGame(guest, &models.NetworkMessage{})
+	if gameRoom.engine.Paused() {
+		t.Fatal("moderator resume did not resume the game")
+	}
+
+	s.handlePauseGame(host, &models.NetworkMessage{})
+	if !gameRoom.engine.Paused() {
+		t.Fatal("host pause did not pause the game")
+	}
+	s.handleResumeGame(host, &models.NetworkMessage{})
+	if gameRoom.engine.Paused() {
+		t.Fatal("host resume did not resume the game")
+	}
+
+	gameRoom.room.Queue = constants.QueueRanked
+	s.handlePauseGame(host, &models.NetworkMessage{})
+	if msg := lastMessage(host); msg == nil || msg.Payload.(models.ErrorPayload).Code != constants.ErrRankedPause {
+		t.Fatalf("ranked pause reply = %+v, want %s", msg, constants.ErrRankedPause)
+	}
+}
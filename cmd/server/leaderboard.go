@@ -0,0 +1,62 @@
+// cmd/server/leaderboard.go
+package main
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// maxLeaderboardSearch borne la recherche à la longueur d'un pseudo
+const maxLeaderboardSearch = 20
+
+// normalizeLeaderboardRequest ramène une demande de page dans les bornes
+// acceptées: taille par défaut, page maximale, décalage positif
+func normalizeLeaderboardRequest(req models.LeaderboardRequestPayload) models.LeaderboardRequestPayload {
+	if req.Limit <= 0 {
+		req.Limit = constants.LeaderboardPageSize
+	}
+	if req.Limit > constants.LeaderboardPageMax {
+		req.Limit = constants.LeaderboardPageMax
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	req.Search = strings.TrimSpace(req.Search)
+	if len(req.Search) > maxLeaderboardSearch {
+		req.Search = req.Search[:maxLeaderboardSearch]
+	}
+	return req
+}
+
+// handleGetLeaderboard renvoie une page du classement
+func (s *Server) handleGetLeaderboard(client *Client, msg *models.NetworkMessage) {
+	var req models.LeaderboardRequestPayload
+	if err := protocol.ExtractPayload(msg.Payload, &req); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+	req = normalizeLeaderboardRequest(req)
+
+	entries, hasMore, err := s.db.GetLeaderboard(req.Offset, req.Limit, req.Search)
+	if err != nil {
+		log.Printf("Failed to load leaderboard: %v", err)
+		// Classement indisponible: page vide plutôt qu'une erreur
+		entries, hasMore = nil, false
+	}
+
+	s.sendMessage(client, &models.NetworkMessage{
+		Type: constants.MsgLeaderboard,
+		Payload: models.LeaderboardPayload{
+			Entries: entries,
+			Offset:  req.Offset,
+			Search:  req.Search,
+			HasMore: hasMore,
+		},
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,25 @@
+// cmd/server/leaderboard_test.go
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestNormalizeLeaderboardRequest(t *testing.T) {
+	req := normalizeLeaderboardRequest(models.LeaderboardRequestPayload{Offset: -5, Search: "  ali  "})
+	if req.Offset != 0 || req.Limit != constants.LeaderboardPageSize || req.Search != "ali" {
+		t.Errorf("normalized = %+v, want offset 0, default page, trimmed search", req)
+	}
+
+	req = normalizeLeaderboardRequest(models.LeaderboardRequestPayload{Limit: 10000, Search: strings.Repeat("x", 64)})
+	if req.Limit != constants.LeaderboardPageMax {
+		t.Errorf("Limit = %d, want %d", req.Limit, constants.LeaderboardPageMax)
+	}
+	if len(req.Search) != maxLeaderboardSearch {
+		t.Errorf("search length = %d, want %d", len(req.Search), maxLeaderboardSearch)
+	}
+}
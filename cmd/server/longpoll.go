@@ -0,0 +1,27 @@
+// cmd/server/longpoll.go
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/longpoll"
+)
+
+// startLongPoll sert le transport HTTP de secours. Chaque session est traitée
+// comme une connexion TCP: mêmes messages, même authentification.
+func (s *Server) startLongPoll(addr, certFile, keyFile string) {
+	server := &http.Server{Addr: addr, Handler: longpoll.NewHandler(s.handleConnection)}
+
+	go func() {
+		var err error
+		if certFile != "" {
+			log.Printf("🌐 HTTPS fallback transport on %s", addr)
+			err = server.ListenAndServeTLS(certFile, keyFile)
+		} else {
+			log.Printf("🌐 HTTP fallback transport on %s", addr)
+			err = server.ListenAndServe()
+		}
+		log.Printf("HTTP fallback transport stopped: %v", err)
+	}()
+}
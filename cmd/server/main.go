@@ -3,19 +3,33 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/obrien-tchaleu/ludo-king-go/internal/server/game"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/server/recording"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/server/room"
 	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/discovery"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
 	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
 	"github.com/obrien-tchaleu/ludo-king-go/pkg/database"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/dice"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/hooks"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/portmap"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/rating"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/rules"
 )
 
 // Config représente la configuration du serveur
@@ -24,44 +38,144 @@ type Config struct {
 		Host           string `yaml:"host"`
 		Port           string `yaml:"port"`
 		MaxConnections int    `yaml:"max_connections"`
+		AdminAddr      string `yaml:"admin_addr"` // Vide = API d'administration désactivée
+		LANDiscovery   bool   `yaml:"lan_discovery"`
+		AssetsAddr     string `yaml:"assets_addr"` // Vide = pas de packs de ressources
+		AssetsDir      string `yaml:"assets_dir"`
+		PortMapping    bool   `yaml:"port_mapping"` // Redirection automatique NAT-PMP/UPnP
+		// Relais utilisé quand le serveur ne peut pas recevoir de connexions
+		Relay struct {
+			Addr   string `yaml:"addr"`   // Vide = pas de relais
+			Name   string `yaml:"name"`   // Nom donné aux joueurs, nom d'hôte par défaut
+			Secret string `yaml:"secret"` // Secret d'enregistrement du relais
+		} `yaml:"relay"`
+		// Transport HTTP de secours pour les réseaux qui bloquent le port du jeu
+		HTTPFallback struct {
+			Addr     string `yaml:"addr"`      // Vide = désactivé
+			CertFile string `yaml:"cert_file"` // Certificat et clé pour servir en HTTPS
+			KeyFile  string `yaml:"key_file"`
+		} `yaml:"http_fallback"`
 	} `yaml:"server"`
 	Database struct {
+		Driver   string `yaml:"driver"` // "mysql" (défaut) ou "memory"
 		Host     string `yaml:"host"`
 		Port     string `yaml:"port"`
 		Username string `yaml:"username"`
 		Password string `yaml:"password"`
 		Database string `yaml:"database"`
+		// Réplica en lecture seule pour le classement et les profils (optionnel)
+		ReadReplica struct {
+			Host     string `yaml:"host"`
+			Port     string `yaml:"port"`
+			Username string `yaml:"username"`
+			Password string `yaml:"password"`
+		} `yaml:"read_replica"`
 	} `yaml:"database"`
 	Game struct {
-		MaxPlayersPerRoom int `yaml:"max_players_per_room"`
-		MinPlayersPerRoom int `yaml:"min_players_per_room"`
-		TurnTimeout       int `yaml:"turn_timeout"`
-		ReconnectTimeout  int `yaml:"reconnect_timeout"`
+		MaxPlayersPerRoom int  `yaml:"max_players_per_room"`
+		MinPlayersPerRoom int  `yaml:"min_players_per_room"`
+		TurnTimeout       int  `yaml:"turn_timeout"`
+		ReconnectTimeout  int  `yaml:"reconnect_timeout"`
+		SaveSeries        bool `yaml:"save_series"` // Enregistrer le score des séries de revanches
 	} `yaml:"game"`
 	Logging struct {
 		Level string `yaml:"level"`
 		File  string `yaml:"file"`
 	} `yaml:"logging"`
+	// Versions du client, relues à chaque SIGHUP
+	Updates struct {
+		MinVersion    string `yaml:"min_version"` // Vide = toutes les versions acceptées
+		LatestVersion string `yaml:"latest_version"`
+		DownloadURL   string `yaml:"download_url"`
+	} `yaml:"updates"`
+	// Saisons classées, relues à chaque SIGHUP
+	Season struct {
+		Start          string  `yaml:"start"` // Début de la première saison (AAAA-MM-JJ), vide = pas de saisons
+		LengthDays     int     `yaml:"length_days"`
+		InactiveDays   int     `yaml:"inactive_days"`   // Sans partie classée depuis, la cote s'érode
+		DecayPoints    float64 `yaml:"decay_points"`    // Points retirés en fin de saison
+		DecayDeviation float64 `yaml:"decay_deviation"` // Incertitude ajoutée en fin de saison
+	} `yaml:"season"`
+	// Registre partagé des instances, pour les mises à jour sans interruption
+	Cluster struct {
+		InstanceID string `yaml:"instance_id"` // Vide = instance seule, sans transfert
+		PublicAddr string `yaml:"public_addr"` // Adresse donnée aux joueurs transférés ici
+	} `yaml:"cluster"`
+	// Extensions chargées au démarrage
+	Hooks struct {
+		Plugins []string `yaml:"plugins"` // Plugins Go (-buildmode=plugin)
+		Scripts []string `yaml:"scripts"` // Programmes recevant les événements en JSON
+	} `yaml:"hooks"`
 }
 
 // Server représente le serveur de jeu
 type Server struct {
-	listener    net.Listener
-	clients     map[int64]*Client
-	rooms       map[string]*GameRoom
-	db          *database.DB
-	mu          sync.RWMutex
-	matchmaking *MatchmakingQueue
-	config      *Config
+	listener      net.Listener
+	clients       map[int64]*Client
+	conns         map[uint64]*Client // Toutes les connexions, identifiées ou non
+	rooms         map[string]*GameRoom
+	db            database.Store
+	mu            sync.RWMutex
+	matchmaking   *MatchmakingQueue
+	config        *Config
+	configMu      sync.RWMutex
+	stats         *database.StatsBatcher
+	sessions      *SessionStore
+	auth          *AuthStore // Jetons remis après une connexion par mot de passe
+	health        *healthMonitor
+	bandwidth     *bandwidthTracker
+	recorder      *recording.Recorder // Enregistrement des messages entrants (debug)
+	seed          int64               // Graine imposée aux moteurs, 0 = aléatoire
+	nextConnID    atomic.Uint64       // Identifiant des connexions enregistrées
+	publicAddr    string              // Adresse publique obtenue du routeur, vide sinon
+	hooks         *hooks.Registry     // Extensions des opérateurs
+	announcements *announcementBoard
+	errors        errorLog // Dernières erreurs envoyées, pour le tableau de bord
+
+	// Mises à jour sans interruption (voir drain.go)
+	registry   clusterRegistry // Registre partagé des instances, nil sans cluster.instance_id
+	draining   atomic.Bool     // Vidage en cours: plus de nouvelles salles
+	transferMu sync.Mutex      // Une seule reprise de salle transférée à la fois
+
+	// Exports des données personnelles demandés par les joueurs
+	dataExports dataExportDesk
+
+	// Codes réservés par reserveRoomID pour une salle pas encore
+	// enregistrée, protégés par mu
+	pendingRoomIDs map[string]bool
 }
 
 // Client représente un client connecté
 type Client struct {
 	conn     net.Conn
+	connID   uint64
 	userID   int64
 	username string
 	roomID   string
 	send     chan *models.NetworkMessage
+	// spectating indique que le client regarde la salle sans y jouer
+	spectating bool
+	// crashReports compte les rapports de plantage reçus sur la connexion
+	crashReports int
+	// traffic compte les octets échangés sur la connexion
+	traffic trafficCounter
+	// version est celle annoncée par HELLO, vide avant
+	version string
+	// outdated indique un client refusé, en attente de déconnexion
+	outdated bool
+	// authenticated indique que userID a été vérifié (mot de passe ou jeton)
+	authenticated bool
+	// transferRoom est la salle reprise d'une instance en vidage, qui
+	// accueille ce joueur sans redemander son mot de passe
+	transferRoom string
+	// pace est le rythme de jeu déclaré, vide s'il faut le déduire
+	pace constants.Pace
+	// device identifie l'installation du client, vide pour un client ancien
+	device string
+	// sendMu protège send et closed: un envoi après la déconnexion est
+	// ignoré au lieu d'écrire dans un canal fermé
+	sendMu sync.Mutex
+	closed bool
 }
 
 // GameRoom représente une salle avec son moteur
@@ -70,58 +184,169 @@ type GameRoom struct {
 	engine  *game.Engine
 	clients map[int64]*Client
 	mu      sync.RWMutex
-}
 
-// MatchmakingQueue gère le matchmaking
-type MatchmakingQueue struct {
-	waiting []*Client
-	mu      sync.Mutex
+	// Votes d'abandon des joueurs humains
+	abortVotes map[int64]bool
+
+	// Match au meilleur de N, nil pour des parties simples
+	match *game.Match
+
+	// Joueurs exclus par l'hôte, qui ne peuvent plus rejoindre la salle
+	kicked map[int64]bool
+
+	// Dés lancés par joueur, enregistrés dans les statistiques en fin de partie
+	dice   map[int64]diceTally
+	diceMu sync.Mutex
+
+	// Spectateurs, par connexion: leurs événements sont regroupés par
+	// fenêtre de temps
+	spectators     map[uint64]*Client
+	spectatorQueue []*models.NetworkMessage
+	spectatorMu    sync.Mutex
+	// spectatorFlushing indique qu'une boucle de regroupement tourne
+	spectatorFlushing bool
+
+	// Bus de la salle (voir roombus.go), nil tant qu'elle n'est pas ouverte
+	bus *roomBus
+
+	// Coach des salles d'apprentissage (voir tutorial.go), nil sinon
+	tutor *tutor
 }
 
+// Regroupement des écritures de statistiques
+const (
+	statsBatchWindow   = 500 * time.Millisecond
+	statsBatchMaxSize  = 100
+	statsQueueCapacity = 1024
+)
+
+// configPath est le fichier de configuration, relu à chaque SIGHUP
+const configPath = "configs/server.yaml"
+
 func main() {
+	recordDir := flag.String("record", "", "enregistre les messages entrants de chaque salle dans ce répertoire")
+	seed := flag.Int64("seed", 0, "graine imposée aux moteurs de jeu (rejouer un enregistrement)")
+	flag.Parse()
+
 	// Charger la configuration
-	config, err := loadConfig("configs/server.yaml")
+	config, err := loadConfig(configPath)
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := validateConfig(config); err != nil {
+		log.Fatalf("Invalid config: %v", err)
+	}
 
 	// Connexion à la base de données
-	db, err := database.NewDB(
-		config.Database.Host,
-		config.Database.Port,
-		config.Database.Username,
-		config.Database.Password,
-		config.Database.Database,
-	)
+	db, err := openStore(config)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer db.Close()
 
-	log.Printf("✅ Connected to database successfully")
+	// Regrouper les écritures de statistiques
+	stats := database.NewStatsBatcher(db, statsBatchWindow, statsBatchMaxSize, statsQueueCapacity)
+	defer stats.Close()
 
 	// Créer le serveur
 	server := &Server{
-		clients:     make(map[int64]*Client),
-		rooms:       make(map[string]*GameRoom),
-		db:          db,
-		matchmaking: &MatchmakingQueue{waiting: make([]*Client, 0)},
-		config:      config,
+		clients:       make(map[int64]*Client),
+		conns:         make(map[uint64]*Client),
+		rooms:         make(map[string]*GameRoom),
+		db:            db,
+		matchmaking:   newMatchmakingQueue(),
+		config:        config,
+		stats:         stats,
+		sessions:      NewSessionStore(),
+		auth:          NewAuthStore(authTokenTTL),
+		health:        newHealthMonitor(),
+		bandwidth:     newBandwidthTracker(),
+		seed:          *seed,
+		hooks:         hooks.NewRegistry(),
+		announcements: &announcementBoard{},
+	}
+	server.loadHooks(config.Hooks.Plugins, config.Hooks.Scripts)
+	if config.Cluster.InstanceID != "" {
+		// validateConfig n'accepte le mode cluster qu'avec MySQL
+		server.registry = db.(*database.DB)
+	}
+
+	if *recordDir != "" {
+		recorder, err := recording.NewRecorder(*recordDir)
+		if err != nil {
+			log.Fatalf("Failed to start recorder: %v", err)
+		}
+		defer recorder.Close()
+		server.recorder = recorder
+		log.Printf("⏺ Recording inbound messages to %s", *recordDir)
 	}
 
 	// Démarrer le serveur TCP
-	listener, err := net.Listen("tcp", ":"+config.Server.Port)
+	listener, err := net.Listen("tcp", listenAddress(config.Server.Host, config.Server.Port))
 	if err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 	defer listener.Close()
 
 	server.listener = listener
-	log.Printf("🎲 Ludo King Server started on port %s", config.Server.Port)
+	log.Printf("🎲 Ludo King Server started on %s", listener.Addr())
+
+	// Annoncer le serveur sur le réseau local (IPv4 et IPv6)
+	if config.Server.LANDiscovery {
+		name, _ := os.Hostname()
+		go func() {
+			if err := discovery.Announce(name, config.Server.Port, nil); err != nil {
+				log.Printf("LAN discovery disabled: %v", err)
+			}
+		}()
+	}
+
+	// Ouvrir le port sur le routeur pour les joueurs hors du LAN
+	if config.Server.PortMapping {
+		if mapping := server.mapPublicPort(config.Server.Port); mapping != nil {
+			defer mapping.Close()
+		}
+	}
+
+	// S'enregistrer auprès du relais pour les joueurs qui ne peuvent pas nous joindre
+	if config.Server.Relay.Addr != "" {
+		name := config.Server.Relay.Name
+		if name == "" {
+			name, _ = os.Hostname()
+		}
+		go server.registerWithRelay(config.Server.Relay.Addr, name, config.Server.Relay.Secret)
+	}
 
 	// Démarrer le matchmaking automatique
 	go server.processMatchmaking()
 
+	// S'inscrire dans le registre partagé des instances
+	if server.registry != nil {
+		go server.heartbeat()
+	}
+
+	// Surveiller les fuites de goroutines et de salles
+	go server.watchHealth()
+	go server.watchBandwidth()
+
+	// Clore les saisons classées
+	go server.watchSeasons()
+
+	if config.Server.AdminAddr != "" {
+		server.startAdmin(config.Server.AdminAddr)
+	}
+
+	if config.Server.AssetsAddr != "" {
+		server.startAssets(config.Server.AssetsAddr, config.Server.AssetsDir)
+	}
+
+	if fallback := config.Server.HTTPFallback; fallback.Addr != "" {
+		server.startLongPoll(fallback.Addr, fallback.CertFile, fallback.KeyFile)
+	}
+
+	// Recharger la configuration sur SIGHUP
+	go server.watchConfigReload(configPath)
+
 	// Accepter les connexions
 	for {
 		conn, err := listener.Accept()
@@ -134,6 +359,36 @@ func main() {
 	}
 }
 
+// listenAddress construit l'adresse d'écoute. Un hôte vide, "0.0.0.0" ou
+// "::" écoute en double pile (IPv4 et IPv6) sur toutes les interfaces.
+func listenAddress(host, port string) string {
+	switch host {
+	case "", "0.0.0.0", "::", "[::]":
+		return ":" + port
+	}
+	return net.JoinHostPort(strings.Trim(host, "[]"), port)
+}
+
+// mapPublicPort demande au routeur de rediriger le port du serveur.
+// Un échec n'empêche pas le démarrage: le jeu reste accessible en LAN.
+func (s *Server) mapPublicPort(port string) *portmap.Mapping {
+	number, err := strconv.Atoi(port)
+	if err != nil {
+		log.Printf("Port mapping skipped: invalid port %q", port)
+		return nil
+	}
+
+	mapping, err := portmap.Map(number)
+	if err != nil {
+		log.Printf("⚠️ Automatic port mapping unavailable, forward port %s manually for remote players: %v", port, err)
+		return nil
+	}
+
+	s.publicAddr = mapping.PublicAddress()
+	log.Printf("🌍 Port mapped via %s, remote players can join at %s", mapping.Method, s.publicAddr)
+	return mapping
+}
+
 // loadConfig charge la configuration depuis un fichier YAML
 func loadConfig(path string) (*Config, error) {
 	file, err := os.Open(path)
@@ -158,15 +413,22 @@ func (s *Server) handleConnection(conn net.Conn) {
 	log.Printf("New connection from %s", conn.RemoteAddr())
 
 	client := &Client{
-		conn: conn,
-		send: make(chan *models.NetworkMessage, 256),
+		connID: s.nextConnID.Add(1),
+		send:   make(chan *models.NetworkMessage, 256),
 	}
+	client.conn = &countingConn{Conn: conn, traffic: &client.traffic}
+
+	s.mu.Lock()
+	s.conns[client.connID] = client
+	s.mu.Unlock()
 
 	// Goroutine pour envoyer les messages
 	go s.writeMessages(client)
+	s.sendCurrentAnnouncement(client)
 
 	// Lire les messages
-	decoder := json.NewDecoder(conn)
+	decoder := json.NewDecoder(client.conn)
+	var counted uint64
 	for {
 		var msg models.NetworkMessage
 		if err := decoder.Decode(&msg); err != nil {
@@ -175,24 +437,97 @@ func (s *Server) handleConnection(conn net.Conn) {
 			return
 		}
 
+		if chaosDisconnect() {
+			log.Printf("Chaos: forcing disconnect of client %d", client.userID)
+			s.handleDisconnect(client)
+			return
+		}
+
+		receivedAt := time.Now()
 		s.handleMessage(client, &msg)
+
+		// Imputer les octets lus depuis le message précédent à la salle
+		total := client.traffic.in.Load()
+		s.bandwidth.addRoom(client.roomID, total-counted, 0)
+		counted = total
+
+		// La salle n'est connue qu'après traitement (création, jointure)
+		if s.recorder != nil {
+			s.recorder.Record(receivedAt, client.connID, client.roomID, &msg)
+		}
 	}
 }
 
 // writeMessages envoie les messages au client
 func (s *Server) writeMessages(client *Client) {
-	encoder := json.NewEncoder(client.conn)
+	out := protocol.NewSerializer(nil, client.conn)
+	var counted uint64
 	for msg := range client.send {
-		if err := encoder.Encode(msg); err != nil {
+		if !chaosBeforeSend(msg) {
+			continue
+		}
+		if err := out.Encode(protocol.Stamp(msg)); err != nil {
 			log.Printf("Failed to send message: %v", err)
 			return
 		}
+		if codec := negotiatedCodec(msg); codec != "" {
+			next, err := protocol.NewCodecSerializer(codec, nil, client.conn)
+			if err != nil {
+				log.Printf("Failed to switch codec: %v", err)
+				return
+			}
+			out = next
+		}
+
+		total := client.traffic.out.Load()
+		s.bandwidth.addRoom(client.roomID, 0, total-counted)
+		counted = total
 	}
 }
 
 // handleMessage traite un message reçu
 func (s *Server) handleMessage(client *Client, msg *models.NetworkMessage) {
+	if err := protocol.CheckVersion(msg); err != nil {
+		s.sendError(client, constants.ErrProtocolVersion, i18n.Params{"version": msg.Version, "max": protocol.Version})
+		return
+	}
+	// Payload typé et validé avant tout gestionnaire
+	if err := protocol.DecodePayload(msg); err != nil {
+		log.Printf("Rejected message from client %d: %v", client.userID, err)
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+	if msg.Type != constants.MsgHello && !s.checkClientVersion(client) {
+		return
+	}
+	if requiresAuth(msg.Type) && !client.authenticated {
+		s.sendError(client, constants.ErrLoginRequired, nil)
+		return
+	}
+
+	// Les messages adressés à la salle sont traités un à un par sa goroutine
+	if roomScoped(msg.Type) {
+		s.mu.RLock()
+		gameRoom := s.rooms[client.roomID]
+		s.mu.RUnlock()
+
+		if gameRoom != nil {
+			gameRoom.do(func() { s.dispatch(client, msg) })
+			return
+		}
+	}
+	s.dispatch(client, msg)
+}
+
+// dispatch appelle le gestionnaire d'un message
+func (s *Server) dispatch(client *Client, msg *models.NetworkMessage) {
 	switch msg.Type {
+	case constants.MsgHello:
+		s.handleHello(client, msg)
+	case constants.MsgRegister:
+		s.handleRegister(client, msg)
+	case constants.MsgLogin:
+		s.handleLogin(client, msg)
 	case constants.MsgCreateRoom:
 		s.handleCreateRoom(client, msg)
 	case constants.MsgJoinRoom:
@@ -205,6 +540,70 @@ func (s *Server) handleMessage(client *Client, msg *models.NetworkMessage) {
 		s.handleMoveToken(client, msg)
 	case constants.MsgReady:
 		s.handlePlayerReady(client, msg)
+	case constants.MsgRematch:
+		s.handleRematch(client, msg)
+	case constants.MsgSetHandicap:
+		s.handleSetHandicap(client, msg)
+	case constants.MsgKickPlayer:
+		s.handleKickPlayer(client, msg)
+	case constants.MsgAssignColor:
+		s.handleAssignColor(client, msg)
+	case constants.MsgStartGame:
+		s.handleStartGame(client, msg)
+	case constants.MsgSetRole:
+		s.handleSetRole(client, msg)
+	case constants.MsgSetPermissions:
+		s.handleSetPermissions(client, msg)
+	case constants.MsgMutePlayer:
+		s.handleMutePlayer(client, msg)
+	case constants.MsgSpectate:
+		s.handleSpectateRoom(client, msg)
+	case constants.MsgResume:
+		s.handleResumeSession(client, msg)
+	case constants.MsgVoteAbort:
+		s.handleVoteAbort(client, msg)
+	case constants.MsgStartSpin:
+		s.handleStartSpin(client, msg)
+	case constants.MsgStopSpin:
+		s.handleStopSpin(client, msg)
+	case constants.MsgCrashReport:
+		s.handleCrashReport(client, msg)
+	case constants.MsgSyncState:
+		s.handleSyncState(client, msg)
+	case constants.MsgGetProfile:
+		s.handleGetProfile(client, msg)
+	case constants.MsgGetRivalries:
+		s.handleGetRivalries(client, msg)
+	case constants.MsgPauseGame:
+		s.handlePauseGame(client, msg)
+	case constants.MsgResumeGame:
+		s.handleResumeGame(client, msg)
+	case constants.MsgUndoMove:
+		s.handleUndoMove(client, msg)
+	case constants.MsgSetLobbyTheme:
+		s.handleSetLobbyTheme(client, msg)
+	case constants.MsgGetRatingHistory:
+		s.handleGetRatingHistory(client, msg)
+	case constants.MsgGetLeaderboard:
+		s.handleGetLeaderboard(client, msg)
+	case constants.MsgChatMessage:
+		s.handleChatMessage(client, msg)
+	case constants.MsgListRooms:
+		s.handleListRooms(client, msg)
+	case constants.MsgFindMatch:
+		s.handleFindMatch(client, msg)
+	case constants.MsgCancelMatch:
+		s.handleCancelMatch(client, msg)
+	case constants.MsgMatchReply:
+		s.handleMatchReply(client, msg)
+	case constants.MsgSetPace:
+		s.handleSetPace(client, msg)
+	case constants.MsgStartTutorial:
+		s.handleStartTutorial(client, msg)
+	case constants.MsgRequestDataExport:
+		s.handleRequestDataExport(client, msg)
+	case constants.MsgDownloadDataExport:
+		s.handleDownloadDataExport(client, msg)
 	case constants.MsgPing:
 		s.sendMessage(client, &models.NetworkMessage{
 			Type:      constants.MsgPong,
@@ -215,26 +614,77 @@ func (s *Server) handleMessage(client *Client, msg *models.NetworkMessage) {
 
 // handleCreateRoom crée une nouvelle salle
 func (s *Server) handleCreateRoom(client *Client, msg *models.NetworkMessage) {
-	payload := msg.Payload.(map[string]interface{})
+	var payload models.CreateRoomPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
 
-	// Générer un ID unique
-	roomID := generateRoomID()
+	// Variante choisie par l'hôte, vérifiée avant de créer la salle
+	var variant *rules.Script
+	if strings.TrimSpace(payload.Rules) != "" {
+		script, err := rules.Compile(payload.Rules)
+		if err != nil {
+			s.sendError(client, constants.ErrInvalidRules, i18n.Params{"detail": err.Error()})
+			return
+		}
+		variant = script
+	}
+	if _, ok := game.LookupRuleSet(payload.RuleSet); !ok {
+		s.sendError(client, constants.ErrUnknownRuleSet, nil)
+		return
+	}
+
+	// Mot de passe haché avant de créer la salle, jamais gardé en clair
+	var passwordHash string
+	if payload.Password != "" {
+		hash, err := room.HashPassword(payload.Password)
+		if err != nil {
+			s.sendFailure(client, err, constants.ErrServerFailure)
+			return
+		}
+		passwordHash = hash
+	}
+
+	if s.refuseWhileDraining(client) {
+		return
+	}
+
+	// Une salle choisie remplace la recherche de partie en cours
+	s.leaveMatchmaking(client)
+
+	// Générer un code unique parmi les salles actives
+	roomID := s.reserveRoomID()
 
 	// Créer la salle
 	room := &models.Room{
 		ID:         roomID,
-		Name:       payload["name"].(string),
-		HostID:     int64(payload["user_id"].(float64)),
+		Name:       payload.Name,
+		HostID:     client.userID,
 		Players:    make([]*models.Player, 0, constants.MaxPlayers),
-		MaxPlayers: int(payload["max_players"].(float64)),
-		GameMode:   payload["game_mode"].(string),
+		MaxPlayers: payload.MaxPlayers,
+		GameMode:   payload.GameMode,
 		State:      constants.StateWaiting,
 		CreatedAt:  time.Now(),
-		IsPrivate:  payload["is_private"].(bool),
+		IsPrivate:  payload.IsPrivate,
+		GameNumber: 1,
+		Series:     make(map[int64]int),
+	}
+	if game.ValidBestOf(payload.BestOf) {
+		room.BestOf = payload.BestOf
+	}
+	if passwordHash != "" {
+		room.Password = passwordHash
+		room.IsPrivate = true
 	}
+	room.SkillDice = payload.SkillDice
+	room.E2EChat = payload.EncryptedChat
+	if variant != nil {
+		room.Rules = variant.Source()
+	}
+	room.RuleSet = payload.RuleSet
+	room.HouseRules = payload.HouseRules
 
-	client.userID = room.HostID
-	client.username = payload["username"].(string)
 	client.roomID = roomID
 
 	// Créer le joueur hôte
@@ -242,15 +692,57 @@ func (s *Server) handleCreateRoom(client *Client, msg *models.NetworkMessage) {
 	room.Players = append(room.Players, player)
 
 	// Créer le moteur de jeu
-	gameRoom := &GameRoom{
-		room:    room,
-		clients: make(map[int64]*Client),
+	gameRoom := newGameRoom(room)
+	gameRoom.addClient(client)
+	if room.BestOf > 0 {
+		gameRoom.match = game.NewMatch(room.BestOf)
+		room.Match = gameRoom.match.Score()
+	}
+
+	gameRoom.engine = s.newEngine(roomID, gameRoom, variant)
+	gameRoom.open(roomID)
+
+	// Enregistrer la salle
+	s.mu.Lock()
+	s.rooms[roomID] = gameRoom
+	delete(s.pendingRoomIDs, roomID)
+	s.clients[client.userID] = client
+	s.mu.Unlock()
+
+	// Envoyer la confirmation
+	s.sendMessage(client, &models.NetworkMessage{
+		Type: constants.MsgRoomCreated,
+		Payload: models.RoomCreatedPayload{
+			RoomID:        roomID,
+			Room:          room,
+			PublicAddress: s.publicAddr,
+		},
+		Timestamp: time.Now(),
+	})
+
+	s.sendSessionToken(client)
+
+	log.Printf("Room created: %s by %s", roomID, client.username)
+}
+
+// newGameRoom prépare une salle, sans moteur ni joueur connecté
+func newGameRoom(room *models.Room) *GameRoom {
+	return &GameRoom{
+		room:       room,
+		clients:    make(map[int64]*Client),
+		abortVotes: make(map[int64]bool),
+		kicked:     make(map[int64]bool),
+		dice:       make(map[int64]diceTally),
+		spectators: make(map[uint64]*Client),
 	}
-	gameRoom.clients[client.userID] = client
+}
 
-	// Callbacks du moteur
+// newEngine crée le moteur d'une partie de la salle, branché sur les diffusions
+func (s *Server) newEngine(roomID string, gameRoom *GameRoom, variant *rules.Script) *game.Engine {
+	tracker := newStateTracker()
 	callbacks := game.EngineCallbacks{
 		OnDiceRolled: func(playerID int64, value int, extraTurn bool) {
+			gameRoom.recordDiceRoll(playerID, value)
 			s.broadcastToRoom(roomID, &models.NetworkMessage{
 				Type: constants.MsgDiceRolled,
 				Payload: models.DiceRolledPayload{
@@ -260,6 +752,9 @@ func (s *Server) handleCreateRoom(client *Client, msg *models.NetworkMessage) {
 				},
 				Timestamp: time.Now(),
 			})
+			if gameRoom.tutor != nil {
+				gameRoom.tutor.diceRolled(gameRoom.student(), playerID, value, extraTurn)
+			}
 		},
 		OnTokenMoved: func(playerID int64, token *models.Token, from, to int) {
 			s.broadcastToRoom(roomID, &models.NetworkMessage{
@@ -272,6 +767,9 @@ func (s *Server) handleCreateRoom(client *Client, msg *models.NetworkMessage) {
 				},
 				Timestamp: time.Now(),
 			})
+			if gameRoom.tutor != nil {
+				gameRoom.tutor.tokenMoved(gameRoom.student(), playerID, from, to)
+			}
 		},
 		OnTokenCaptured: func(capturer, victim int64, token *models.Token, pos int) {
 			s.broadcastToRoom(roomID, &models.NetworkMessage{
@@ -284,87 +782,161 @@ func (s *Server) handleCreateRoom(client *Client, msg *models.NetworkMessage) {
 				},
 				Timestamp: time.Now(),
 			})
+			if gameRoom.tutor != nil {
+				gameRoom.tutor.tokenCaptured(capturer, victim)
+			}
+		},
+		OnRollOff: func(rounds [][]models.RollOffRoll, starterID int64) {
+			gameRoom.do(func() { gameRoom.noteFirstStarter(starterID) })
+			s.broadcastToRoom(roomID, &models.NetworkMessage{
+				Type:      constants.MsgRollOff,
+				Payload:   models.RollOffPayload{Rounds: rounds, StarterID: starterID},
+				Timestamp: time.Now(),
+			})
 		},
 		OnTurnChanged: func(playerID int64) {
 			s.broadcastToRoom(roomID, &models.NetworkMessage{
 				Type:      constants.MsgTurnChanged,
-				Payload:   map[string]interface{}{"player_id": playerID},
+				Payload:   models.TurnChangedPayload{PlayerID: playerID},
 				Timestamp: time.Now(),
 			})
+			// Sous le verrou du moteur: les pions de la salle sont stables
+			s.broadcastToRoom(roomID, &models.NetworkMessage{
+				Type:      constants.MsgStateDelta,
+				Payload:   tracker.delta(gameRoom.room),
+				Timestamp: time.Now(),
+			})
+			if gameRoom.tutor != nil {
+				gameRoom.tutor.turnChanged(playerID)
+			}
 		},
-		OnGameOver: func(winner *models.Player, rankings []*models.Player) {
-			s.handleGameOver(roomID, winner, rankings)
+		OnTurnTimer: func(playerID int64, deadline time.Time) {
+			s.broadcastToRoom(roomID, &models.NetworkMessage{
+				Type: constants.MsgTurnTimer,
+				Payload: models.TurnTimerPayload{
+					PlayerID:    playerID,
+					Deadline:    deadline,
+					RemainingMs: time.Until(deadline).Milliseconds(),
+				},
+				Timestamp: time.Now(),
+			})
 		},
-	}
-
-	gameRoom.engine = game.NewEngine(room, callbacks)
-
-	// Enregistrer la salle
-	s.mu.Lock()
-	s.rooms[roomID] = gameRoom
-	s.clients[client.userID] = client
-	s.mu.Unlock()
-
-	// Envoyer la confirmation
-	s.sendMessage(client, &models.NetworkMessage{
-		Type: constants.MsgRoomCreated,
-		Payload: map[string]interface{}{
-			"room_id": roomID,
-			"room":    room,
+		OnTurnTimedOut: func(playerID int64) {
+			gameRoom.recordTimeout(playerID)
+			s.broadcastToRoom(roomID, &models.NetworkMessage{
+				Type:      constants.MsgTurnTimedOut,
+				Payload:   models.TurnTimedOutPayload{PlayerID: playerID},
+				Timestamp: time.Now(),
+			})
+			if gameRoom.tutor != nil {
+				gameRoom.tutor.timedOut(playerID)
+			}
 		},
-		Timestamp: time.Now(),
-	})
+		OnGameOver: func(winner *models.Player, rankings []*models.Player, reason string) {
+			// Le moteur appelle sous son verrou: la fin de partie passe par la salle
+			gameRoom.do(func() { s.handleGameOver(roomID, winner, rankings, reason) })
+			if gameRoom.tutor != nil {
+				gameRoom.tutor.gameOver(winner)
+			}
+		},
+		OnPaused: func(paused bool, resumesAt time.Time) {
+			s.broadcastPause(roomID, paused, resumesAt)
+		},
+		OnMoveUndone: func(playerID int64, dice int) {
+			s.broadcastToRoom(roomID, &models.NetworkMessage{
+				Type:      constants.MsgMoveUndone,
+				Payload:   models.MoveUndonePayload{PlayerID: playerID, DiceValue: dice},
+				Timestamp: time.Now(),
+			})
+			// Les pions reviennent en arrière: le delta les remet en place
+			s.broadcastToRoom(roomID, &models.NetworkMessage{
+				Type:      constants.MsgStateDelta,
+				Payload:   tracker.delta(gameRoom.room),
+				Timestamp: time.Now(),
+			})
+		},
+	}
 
-	log.Printf("Room created: %s by %s", roomID, client.username)
+	engine := game.NewEngine(gameRoom.room, gameRoom.room.HouseRules, callbacks)
+	if variant != nil {
+		engine.SetRules(variant)
+	}
+	engine.SetTurnTimeout(paceTurnTimeout(gameRoom.room.Pace, s.getConfig().Game.TurnTimeout))
+	switch {
+	case s.seed != 0:
+		engine.Reseed(s.seed)
+	case s.recorder != nil:
+		// Une partie enregistrée doit pouvoir être rejouée: dés avec graine
+		engine.Reseed(dice.RandomSeed())
+	}
+	if s.recorder != nil {
+		s.recorder.RecordSeed(roomID, engine.Seed())
+	}
+	return engine
 }
 
 // handleJoinRoom permet à un joueur de rejoindre une salle
 func (s *Server) handleJoinRoom(client *Client, msg *models.NetworkMessage) {
-	payload := msg.Payload.(map[string]interface{})
-	roomID := payload["room_id"].(string)
+	var payload models.JoinRoomPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+	roomID := payload.RoomID
+
+	if s.refuseWhileDraining(client) {
+		return
+	}
 
 	s.mu.RLock()
 	gameRoom, exists := s.rooms[roomID]
 	s.mu.RUnlock()
 
+	// Salle confiée par une instance en vidage, recréée à la première arrivée
+	if !exists && client.transferRoom == roomID {
+		gameRoom = s.claimTransferredRoom(roomID)
+		exists = gameRoom != nil
+	}
 	if !exists {
-		s.sendError(client, constants.ErrRoomNotFound, "Room not found")
+		s.sendError(client, constants.ErrRoomNotFound, nil)
 		return
 	}
 
-	gameRoom.mu.Lock()
-	defer gameRoom.mu.Unlock()
+	// Un joueur transféré était déjà admis dans la salle
+	if client.transferRoom != roomID {
+		if err := room.CheckPassword(gameRoom.room, payload.Password); err != nil {
+			s.sendFailure(client, err, constants.ErrRoomPasswordWrong)
+			return
+		}
+	}
+
+	s.leaveMatchmaking(client)
 
+	gameRoom.mu.Lock()
+	if gameRoom.kicked[client.userID] {
+		gameRoom.mu.Unlock()
+		s.sendError(client, constants.ErrKickedFromRoom, nil)
+		return
+	}
 	if len(gameRoom.room.Players) >= gameRoom.room.MaxPlayers {
-		s.sendError(client, constants.ErrGameFull, "Room is full")
+		gameRoom.mu.Unlock()
+		s.sendError(client, constants.ErrRoomFull, i18n.Params{"max": gameRoom.room.MaxPlayers})
 		return
 	}
 
-	// Choisir une couleur disponible
-	colors := []constants.PlayerColor{
-		constants.ColorRed, constants.ColorBlue,
-		constants.ColorGreen, constants.ColorYellow,
-	}
+	// Choisir une couleur disponible (en diagonale à deux joueurs)
 	usedColors := make(map[constants.PlayerColor]bool)
 	for _, p := range gameRoom.room.Players {
 		usedColors[p.Color] = true
 	}
+	playerColor := constants.FreeSeat(gameRoom.room.MaxPlayers, usedColors)
 
-	var playerColor constants.PlayerColor
-	for _, c := range colors {
-		if !usedColors[c] {
-			playerColor = c
-			break
-		}
-	}
-
-	client.userID = int64(payload["user_id"].(float64))
-	client.username = payload["username"].(string)
 	client.roomID = roomID
 
 	player := models.NewPlayer(client.userID, client.username, playerColor)
 	gameRoom.room.Players = append(gameRoom.room.Players, player)
-	gameRoom.clients[client.userID] = client
+	gameRoom.addClient(client)
+	gameRoom.mu.Unlock()
 
 	s.mu.Lock()
 	s.clients[client.userID] = client
@@ -373,7 +945,7 @@ func (s *Server) handleJoinRoom(client *Client, msg *models.NetworkMessage) {
 	// Notifier tous les joueurs
 	s.broadcastToRoom(roomID, &models.NetworkMessage{
 		Type:      constants.MsgPlayerJoined,
-		Payload:   map[string]interface{}{"player": player},
+		Payload:   models.PlayerJoinedPayload{Player: player},
 		Timestamp: time.Now(),
 	})
 
@@ -381,11 +953,15 @@ func (s *Server) handleJoinRoom(client *Client, msg *models.NetworkMessage) {
 	s.sendMessage(client, &models.NetworkMessage{
 		Type: constants.MsgGameState,
 		Payload: models.GameStatePayload{
-			Game: gameRoom.engine.GetGameState(),
+			Game: gameRoom.snapshot(gameRoom.engine.GetGameState()),
 		},
 		Timestamp: time.Now(),
 	})
 
+	s.sendSessionToken(client)
+
+	s.hooks.PlayerJoin(hooks.PlayerJoinEvent{RoomID: roomID, Player: hookPlayer(player)})
+
 	log.Printf("%s joined room %s", client.username, roomID)
 }
 
@@ -399,13 +975,44 @@ func (s *Server) handleRollDice(client *Client, msg *models.NetworkMessage) {
 		return
 	}
 
-	gameRoom.engine.RollDice(client.userID)
+	// Les salles à dé visé passent par le sélecteur
+	if gameRoom.room.SkillDice {
+		s.sendError(client, constants.ErrSpinnerRoom, nil)
+		return
+	}
+
+	if _, _, err := gameRoom.engine.RollDice(client.userID); err != nil {
+		s.sendFailure(client, err, constants.ErrInvalidMove)
+	}
+}
+
+// handleSyncState renvoie l'état complet de la partie à un client désynchronisé
+func (s *Server) handleSyncState(client *Client, msg *models.NetworkMessage) {
+	s.mu.RLock()
+	gameRoom := s.rooms[client.roomID]
+	s.mu.RUnlock()
+
+	if gameRoom == nil {
+		s.sendError(client, constants.ErrRoomNotFound, nil)
+		return
+	}
+
+	s.sendMessage(client, &models.NetworkMessage{
+		Type: constants.MsgGameState,
+		Payload: models.GameStatePayload{
+			Game: gameRoom.snapshot(gameRoom.engine.GetGameState()),
+		},
+		Timestamp: time.Now(),
+	})
 }
 
 // handleMoveToken traite un déplacement de token
 func (s *Server) handleMoveToken(client *Client, msg *models.NetworkMessage) {
-	payload := msg.Payload.(map[string]interface{})
-	tokenID := int(payload["token_id"].(float64))
+	var payload models.MoveTokenPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
 
 	s.mu.RLock()
 	gameRoom := s.rooms[client.roomID]
@@ -415,9 +1022,9 @@ func (s *Server) handleMoveToken(client *Client, msg *models.NetworkMessage) {
 		return
 	}
 
-	err := gameRoom.engine.MoveToken(client.userID, tokenID)
+	err := gameRoom.engine.MoveToken(client.userID, payload.TokenID)
 	if err != nil {
-		s.sendError(client, constants.ErrInvalidMove, err.Error())
+		s.sendFailure(client, err, constants.ErrInvalidMove)
 	}
 }
 
@@ -432,8 +1039,6 @@ func (s *Server) handlePlayerReady(client *Client, msg *models.NetworkMessage) {
 	}
 
 	gameRoom.mu.Lock()
-	defer gameRoom.mu.Unlock()
-
 	for _, player := range gameRoom.room.Players {
 		if player.ID == client.userID {
 			player.IsReady = true
@@ -449,14 +1054,39 @@ func (s *Server) handlePlayerReady(client *Client, msg *models.NetworkMessage) {
 			break
 		}
 	}
+	starting := allReady && len(gameRoom.room.Players) >= constants.MinPlayers &&
+		gameRoom.room.State == constants.StateWaiting
+	gameRoom.mu.Unlock()
 
-	if allReady && len(gameRoom.room.Players) >= constants.MinPlayers {
-		gameRoom.engine.Start()
-		s.broadcastToRoom(client.roomID, &models.NetworkMessage{
-			Type:      constants.MsgGameStart,
-			Timestamp: time.Now(),
-		})
+	// broadcastToRoom et les callbacks du moteur reprennent le verrou de la salle
+	if !starting {
+		return
+	}
+
+	s.startGame(client.roomID, gameRoom)
+}
+
+// startGame lance la partie d'une salle dont tous les joueurs sont prêts.
+// L'appelant ne doit pas détenir le verrou de la salle.
+func (s *Server) startGame(roomID string, gameRoom *GameRoom) {
+	engine := gameRoom.engine
+
+	// Le plateau s'affiche avant le tirage du premier joueur, pions en place
+	engine.Prepare()
+	s.broadcastToRoom(roomID, &models.NetworkMessage{
+		Type:      constants.MsgGameStart,
+		Payload:   models.GameStatePayload{Game: gameRoom.snapshot(engine.GetGameState())},
+		Timestamp: time.Now(),
+	})
+	if err := engine.Start(); err != nil {
+		log.Printf("Failed to start game in room %s: %v", roomID, err)
+		return
 	}
+	s.hooks.GameStart(hooks.GameStartEvent{
+		RoomID:   roomID,
+		RoomName: gameRoom.room.Name,
+		Players:  hookPlayers(gameRoom.room.Players),
+	})
 }
 
 // broadcastToRoom envoie un message à tous les joueurs d'une salle
@@ -468,60 +1098,126 @@ func (s *Server) broadcastToRoom(roomID string, msg *models.NetworkMessage) {
 	if gameRoom == nil {
 		return
 	}
-
-	gameRoom.mu.RLock()
-	defer gameRoom.mu.RUnlock()
-
-	for _, client := range gameRoom.clients {
-		select {
-		case client.send <- msg:
-		default:
-			log.Printf("Failed to send to client %d", client.userID)
-		}
-	}
+	gameRoom.publish(msg)
 }
 
 // sendMessage envoie un message à un client
 func (s *Server) sendMessage(client *Client, msg *models.NetworkMessage) {
+	if !client.trySend(msg) {
+		log.Printf("Failed to send message to client")
+	}
+}
+
+// trySend met un message dans la file d'envoi sans jamais bloquer. Il
+// retourne false si la file est pleine ou la connexion fermée.
+func (c *Client) trySend(msg *models.NetworkMessage) bool {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	if c.closed {
+		return false
+	}
 	select {
-	case client.send <- msg:
+	case c.send <- msg:
+		return true
 	default:
-		log.Printf("Failed to send message to client")
+		return false
 	}
 }
 
-// sendError envoie une erreur au client
-func (s *Server) sendError(client *Client, code, message string) {
+// close ferme la file d'envoi, une seule fois
+func (c *Client) close() {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	if !c.closed {
+		c.closed = true
+		close(c.send)
+	}
+}
+
+// connected indique que la connexion du client est toujours ouverte
+func (c *Client) connected() bool {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	return !c.closed
+}
+
+// sendError envoie au client un code de message et ses paramètres, que
+// le client traduit dans la langue du joueur
+func (s *Server) sendError(client *Client, code string, params i18n.Params) {
+	s.errors.add(recentError{Time: time.Now(), Code: code, Username: client.username, RoomID: client.roomID})
 	s.sendMessage(client, &models.NetworkMessage{
 		Type: constants.MsgError,
 		Payload: models.ErrorPayload{
 			Code:    code,
-			Message: message,
+			Params:  params,
+			Message: i18n.Render(i18n.Default, code, params),
 		},
 		Timestamp: time.Now(),
 	})
 }
 
+// sendFailure envoie l'erreur retournée par le moteur ou une validation.
+// Une erreur sans code de message prend le code fallback.
+func (s *Server) sendFailure(client *Client, err error, fallback string) {
+	code, params := i18n.CodeOf(err, fallback)
+	s.sendError(client, code, params)
+}
+
 // handleDisconnect gère la déconnexion d'un client
 func (s *Server) handleDisconnect(client *Client) {
 	s.mu.Lock()
 	delete(s.clients, client.userID)
+	delete(s.conns, client.connID)
 	s.mu.Unlock()
 
-	if client.roomID != "" {
-		s.handleLeaveRoom(client, nil)
+	s.leaveMatchmaking(client)
+
+	if client.spectating {
+		s.removeSpectator(client)
+	} else if client.roomID != "" {
+		// Garder la place pendant la fenêtre de reconnexion
+		window := time.Duration(s.getConfig().Game.ReconnectTimeout) * time.Second
+		s.sessions.Suspend(client.userID, window)
+		s.holdSeat(client)
+
+		userID, roomID := client.userID, client.roomID
+		time.AfterFunc(window, func() { s.expireSeat(userID, roomID) })
 	}
 
-	close(client.send)
+	client.close()
 }
 
 // handleLeaveRoom gère la sortie d'une salle
 func (s *Server) handleLeaveRoom(client *Client, msg *models.NetworkMessage) {
-	// Implementation similaire...
+	roomID := client.roomID
+	if roomID == "" {
+		return
+	}
+	if client.spectating {
+		s.removeSpectator(client)
+		client.roomID = ""
+		client.spectating = false
+		return
+	}
+
+	s.mu.RLock()
+	gameRoom := s.rooms[roomID]
+	s.mu.RUnlock()
+
+	// Une sortie volontaire ne laisse pas de place à reprendre
+	client.roomID = ""
+	s.sessions.Revoke(client.userID)
+	if gameRoom != nil {
+		s.releaseSeat(roomID, gameRoom, client.userID)
+	}
+
+	log.Printf("%s left room %s", client.username, roomID)
 }
 
 // handleGameOver gère la fin de partie
-func (s *Server) handleGameOver(roomID string, winner *models.Player, rankings []*models.Player) {
+func (s *Server) handleGameOver(roomID string, winner *models.Player, rankings []*models.Player, reason string) {
 	s.mu.RLock()
 	gameRoom := s.rooms[roomID]
 	s.mu.RUnlock()
@@ -530,51 +1226,118 @@ func (s *Server) handleGameOver(roomID string, winner *models.Player, rankings [
 		return
 	}
 
+	// Score de la série, avant une éventuelle revanche
+	gameNumber, series := gameRoom.recordSeriesResult(winner)
+	match := gameRoom.recordMatchResult(winner)
+	if match != nil && match.WinnerID == 0 {
+		time.AfterFunc(matchNextGameDelay, func() {
+			gameRoom.do(func() { s.nextMatchGame(roomID, gameRoom) })
+		})
+	}
+
 	// Sauvegarder en base de données
+	engine := gameRoom.engine
 	go func() {
-		game := gameRoom.engine.GetGameState()
-		if err := s.db.SaveGameHistory(game); err != nil {
+		game := engine.GetGameState()
+
+		// État final avec l'historique complet, pour le lecteur de parties
+		s.broadcastToRoom(roomID, &models.NetworkMessage{
+			Type:      constants.MsgGameState,
+			Payload:   models.GameStatePayload{Game: gameRoom.snapshot(game)},
+			Timestamp: time.Now(),
+		})
+
+		if err := chaosDBError("save game history"); err != nil {
+			log.Printf("Failed to save game: %v", err)
+		} else if err := s.db.SaveGameHistory(game); err != nil {
 			log.Printf("Failed to save game: %v", err)
 		}
 
 		// Mettre à jour les stats
+		var opponents map[int64][]rating.Opponent
+		if game.Room.Queue == constants.QueueRanked {
+			opponents = s.ratedOpponents(game)
+		}
 		for _, player := range game.Room.Players {
-			if player.IsAI {
+			// Les parties d'apprentissage ne comptent pas dans les statistiques
+			if player.IsAI || game.Room.Tutorial {
 				continue
 			}
-			won := player.ID == winner.ID
-			s.db.UpdatePlayerStats(player.ID, won, 0, 0)
+			tally := gameRoom.tallyFor(player.ID)
+			update := database.StatUpdate{
+				UserID:      player.ID,
+				Won:         winner != nil && player.ID == winner.ID,
+				Aborted:     game.Aborted,
+				SixesRolled: tally.sixes,
+				DiceRolls:   tally.rolls,
+				Decisions:   player.Decisions,
+				DecisionMs:  player.DecisionMs,
+				Opponents:   opponents[player.ID],
+			}
+			applyQueueRules(&update, game.Room.Queue, tally)
+			if err := s.stats.Add(update); err != nil {
+				// File pleine: écrire directement plutôt que perdre la mise à jour
+				if err := s.db.WriteStatUpdate(update); err != nil {
+					log.Printf("Failed to update stats: %v", err)
+				}
+			}
+		}
+
+		if s.getConfig().Game.SaveSeries {
+			s.saveSeries(game.Room, gameNumber, series)
 		}
 	}()
 
+	// Le moteur appelle ce callback sous son verrou: ne pas relire son état ici
+	duration := 0
+	if startedAt := gameRoom.room.StartedAt; startedAt != nil {
+		duration = int(time.Since(*startedAt).Seconds())
+	}
+
+	event := hooks.GameOverEvent{
+		RoomID:   roomID,
+		Rankings: hookPlayers(rankings),
+		Reason:   reason,
+		Duration: time.Duration(duration) * time.Second,
+	}
+	if winner != nil {
+		w := hookPlayer(winner)
+		event.Winner = &w
+	}
+	s.hooks.GameOver(event)
+
 	// Notifier les joueurs
 	s.broadcastToRoom(roomID, &models.NetworkMessage{
 		Type: constants.MsgGameOver,
 		Payload: models.GameOverPayload{
 			Winner:   winner,
 			Rankings: rankings,
-			Duration: int(time.Since(gameRoom.engine.GetGameState().StartTime).Seconds()),
+			Duration: duration,
+			Reason:   reason,
+			Fastest:  game.FastestPlayer(gameRoom.room.Players),
+
+			GameNumber: gameNumber,
+			Series:     series,
+			Match:      match,
 		},
 		Timestamp: time.Now(),
 	})
 }
 
-// processMatchmaking traite le matchmaking automatique
-func (s *Server) processMatchmaking() {
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
+// reserveRoomID génère un code de salle court, absent des salles actives et
+// des salles en création, et le réserve. La réservation est levée quand la
+// salle est enregistrée dans s.rooms, sous le même verrou.
+func (s *Server) reserveRoomID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	for range ticker.C {
-		s.matchmaking.mu.Lock()
-		if len(s.matchmaking.waiting) >= constants.MinPlayers {
-			// Créer une partie automatiquement
-			// Implementation...
-		}
-		s.matchmaking.mu.Unlock()
+	id := room.GenerateRoomID(func(id string) bool {
+		_, exists := s.rooms[id]
+		return exists || s.pendingRoomIDs[id]
+	})
+	if s.pendingRoomIDs == nil {
+		s.pendingRoomIDs = make(map[string]bool)
 	}
-}
-
-// generateRoomID génère un ID de salle unique
-func generateRoomID() string {
-	return fmt.Sprintf("ROOM_%d", time.Now().UnixNano())
+	s.pendingRoomIDs[id] = true
+	return id
 }
@@ -0,0 +1,64 @@
+// cmd/server/match.go
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// matchNextGameDelay laisse lire le résultat avant la partie suivante du match
+const matchNextGameDelay = 6 * time.Second
+
+// noteFirstStarter retient qui a gagné le tirage de la première partie
+func (gr *GameRoom) noteFirstStarter(starterID int64) {
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+
+	if gr.match == nil {
+		return
+	}
+	for i, player := range gr.room.Players {
+		if player.ID == starterID {
+			gr.match.SetFirstStarter(i)
+		}
+	}
+}
+
+// recordMatchResult compte la partie dans le match et retourne le score, nil
+// hors match
+func (gr *GameRoom) recordMatchResult(winner *models.Player) *models.MatchScore {
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+
+	if gr.match == nil {
+		return nil
+	}
+	gr.match.Record(winner)
+	gr.room.Match = gr.match.Score()
+	return gr.match.Score()
+}
+
+// nextMatchGame enchaîne la partie suivante d'un match non terminé, sans
+// repasser par le lobby. Le premier joueur tourne à chaque partie.
+func (s *Server) nextMatchGame(roomID string, gameRoom *GameRoom) {
+	gameRoom.mu.Lock()
+	if gameRoom.match == nil || gameRoom.match.Decided() || gameRoom.room.State != constants.StateFinished {
+		gameRoom.mu.Unlock()
+		return
+	}
+
+	gameRoom.resetForRematch()
+	for _, player := range gameRoom.room.Players {
+		player.IsReady = true
+	}
+	gameRoom.engine = s.newEngine(roomID, gameRoom, gameRoom.variant())
+	gameRoom.engine.SetStarter(gameRoom.match.NextStarter(len(gameRoom.room.Players)))
+	number := gameRoom.room.GameNumber
+	gameRoom.mu.Unlock()
+
+	log.Printf("Match in room %s: starting game %d", roomID, number)
+	s.startGame(roomID, gameRoom)
+}
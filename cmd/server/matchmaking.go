@@ -0,0 +1,570 @@
+// cmd/server/matchmaking.go
+package main
+
+import (
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// Réglages du matchmaking. Les écarts sont en points de cote.
+const (
+	matchTick        = 2 * time.Second
+	matchFillWait    = 20 * time.Second // Attente avant d'accepter une table incomplète
+	matchBaseSpread  = 50.0             // Écart accepté dès l'entrée dans la file
+	matchSpreadStep  = 25.0             // Élargissement à chaque matchSpreadEvery d'attente
+	matchSpreadEvery = 10 * time.Second
+	matchNeutralRate = 50.0             // Taux de victoire affiché d'un joueur sans partie jouée
+	matchPaceWait    = 30 * time.Second // Attente avant d'accepter un rythme voisin
+)
+
+// queueEntry est un joueur en attente d'adversaires
+type queueEntry struct {
+	client  *Client
+	winRate float64
+	// Cote et incertitude: l'écart accepté grandit avec l'incertitude
+	rating      float64
+	deviation   float64
+	provisional bool // En parties de placement
+	pace        constants.Pace
+	device      string    // Appareil du joueur, vide si inconnu
+	joined      time.Time // Conservé quand le joueur revient dans la file
+
+	queue     constants.Queue
+	skillDice bool // Variante à dé visé, file amicale seulement
+}
+
+// sameDevice indique si deux joueurs jouent depuis le même appareil
+func (e *queueEntry) sameDevice(other *queueEntry) bool {
+	return e.device != "" && e.device == other.device
+}
+
+// sameTable indique si deux joueurs attendent le même genre de partie
+func (e *queueEntry) sameTable(other *queueEntry) bool {
+	return e.queue == other.queue && e.skillDice == other.skillDice
+}
+
+// spread retourne l'écart de cote accepté, qui grandit avec l'attente
+func (e *queueEntry) spread(now time.Time) float64 {
+	steps := float64(now.Sub(e.joined) / matchSpreadEvery)
+	return matchBaseSpread + steps*matchSpreadStep
+}
+
+// tolerance retourne l'écart de cote accepté entre le joueur et other:
+// celui de l'attente, élargi de la plus grande des deux incertitudes. Un
+// joueur en placement rencontre ainsi des niveaux variés.
+func (e *queueEntry) tolerance(other *queueEntry, now time.Time) float64 {
+	return e.spread(now) + math.Max(e.deviation, other.deviation)
+}
+
+// paceSpread retourne l'écart de rythme accepté: le même rythme, puis un
+// rythme voisin après matchPaceWait
+func (e *queueEntry) paceSpread(now time.Time) int {
+	if now.Sub(e.joined) >= matchPaceWait {
+		return 1
+	}
+	return 0
+}
+
+// groupPace retourne le rythme d'une table: celui de ses joueurs s'ils
+// sont tous d'accord, le rythme normal sinon
+func groupPace(group []*queueEntry) constants.Pace {
+	pace := group[0].pace
+	for _, entry := range group[1:] {
+		if entry.pace != pace {
+			return constants.PaceNormal
+		}
+	}
+	if pace == "" {
+		return constants.PaceNormal
+	}
+	return pace
+}
+
+// pendingMatch est une table proposée, en attente des réponses
+type pendingMatch struct {
+	id       string
+	entries  []*queueEntry
+	accepted map[int64]bool
+	timer    *time.Timer
+}
+
+// MatchmakingQueue gère le matchmaking
+type MatchmakingQueue struct {
+	waiting []*queueEntry
+	pending map[string]*pendingMatch
+	nextID  int
+	mu      sync.Mutex
+}
+
+func newMatchmakingQueue() *MatchmakingQueue {
+	return &MatchmakingQueue{pending: make(map[string]*pendingMatch)}
+}
+
+// queued indique si le joueur attend déjà ou a une table proposée
+func (q *MatchmakingQueue) queued(userID int64) bool {
+	for _, entry := range q.waiting {
+		if entry.client.userID == userID {
+			return true
+		}
+	}
+	return q.pendingFor(userID) != nil
+}
+
+// pendingFor retourne la table proposée au joueur, nil sinon
+func (q *MatchmakingQueue) pendingFor(userID int64) *pendingMatch {
+	for _, match := range q.pending {
+		for _, entry := range match.entries {
+			if entry.client.userID == userID {
+				return match
+			}
+		}
+	}
+	return nil
+}
+
+// removeWaiting retire le joueur de la file d'attente
+func (q *MatchmakingQueue) removeWaiting(userID int64) {
+	for i, entry := range q.waiting {
+		if entry.client.userID == userID {
+			q.waiting = append(q.waiting[:i], q.waiting[i+1:]...)
+			return
+		}
+	}
+}
+
+// formMatches regroupe les joueurs d'une même file, de niveau et de rythme
+// proches. Le plus ancien de la file choisit ses adversaires dans ses
+// écarts acceptés, les plus proches d'abord, et en file classée jamais
+// deux comptes du même appareil. Une table pleine part tout de suite; une table de 2 ou 3
+// joueurs seulement après matchFillWait, pour laisser la file se remplir.
+func formMatches(waiting []*queueEntry, now time.Time) (groups [][]*queueEntry, rest []*queueEntry) {
+	ordered := append([]*queueEntry(nil), waiting...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].joined.Before(ordered[j].joined)
+	})
+
+	used := make(map[*queueEntry]bool)
+	for i, anchor := range ordered {
+		if used[anchor] {
+			continue
+		}
+
+		paceLimit := anchor.paceSpread(now)
+		var candidates []*queueEntry
+		for _, other := range ordered[i+1:] {
+			if used[other] || !other.sameTable(anchor) || math.Abs(other.rating-anchor.rating) > anchor.tolerance(other, now) {
+				continue
+			}
+			if paceDistance(other.pace, anchor.pace) <= paceLimit {
+				candidates = append(candidates, other)
+			}
+		}
+		sort.SliceStable(candidates, func(a, b int) bool {
+			return math.Abs(candidates[a].rating-anchor.rating) < math.Abs(candidates[b].rating-anchor.rating)
+		})
+
+		group := []*queueEntry{anchor}
+		for _, other := range candidates {
+			if len(group) == constants.MaxPlayers {
+				break
+			}
+			if anchor.queue != constants.QueueRanked || !sharesDevice(group, other) {
+				group = append(group, other)
+			}
+		}
+		full := len(group) == constants.MaxPlayers
+		if full || (len(group) >= constants.MinPlayers && now.Sub(anchor.joined) >= matchFillWait) {
+			for _, entry := range group {
+				used[entry] = true
+			}
+			groups = append(groups, group)
+		}
+	}
+
+	for _, entry := range ordered {
+		if !used[entry] {
+			rest = append(rest, entry)
+		}
+	}
+	return groups, rest
+}
+
+// sharesDevice indique si le joueur partage son appareil avec un joueur du groupe
+func sharesDevice(group []*queueEntry, entry *queueEntry) bool {
+	for _, member := range group {
+		if member.sameDevice(entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// deviceAccounts retourne les autres comptes du même appareil présents
+// dans la file ou dans une table proposée
+func (q *MatchmakingQueue) deviceAccounts(entry *queueEntry) []string {
+	var names []string
+	check := func(other *queueEntry) {
+		if other.client.userID != entry.client.userID && other.sameDevice(entry) {
+			names = append(names, other.client.username)
+		}
+	}
+	for _, other := range q.waiting {
+		check(other)
+	}
+	for _, match := range q.pending {
+		for _, other := range match.entries {
+			check(other)
+		}
+	}
+	return names
+}
+
+// handleFindMatch place le joueur dans la file de matchmaking demandée
+func (s *Server) handleFindMatch(client *Client, msg *models.NetworkMessage) {
+	var payload models.FindMatchPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+	queue, code := matchQueue(payload)
+	if code != "" {
+		s.sendError(client, code, nil)
+		return
+	}
+	if client.roomID != "" {
+		s.sendError(client, constants.ErrAlreadyInRoom, nil)
+		return
+	}
+	if s.refuseWhileDraining(client) {
+		return
+	}
+
+	winRate := matchNeutralRate
+	rating, deviation := constants.RatingInitial, constants.RatingInitialDeviation
+	provisional := true
+	pace := client.pace
+	if stats, err := s.db.GetPlayerStats(client.userID); err != nil {
+		log.Printf("Matchmaking: no stats for %s, assuming an average player: %v", client.username, err)
+	} else {
+		if stats.TotalGames > 0 {
+			winRate = stats.WinRate
+		}
+		if stats.RatingDeviation > 0 {
+			rating, deviation = stats.Rating, stats.RatingDeviation
+		}
+		provisional = stats.Provisional()
+		if pace == "" {
+			pace = inferPace(stats)
+		}
+	}
+	if pace == "" {
+		pace = constants.PaceNormal
+	}
+
+	q := s.matchmaking
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.queued(client.userID) {
+		return
+	}
+	entry := &queueEntry{
+		client:      client,
+		winRate:     winRate,
+		rating:      rating,
+		deviation:   deviation,
+		provisional: provisional,
+		pace:        pace,
+		device:      client.device,
+		joined:      time.Now(),
+		queue:       queue,
+		skillDice:   payload.SkillDice,
+	}
+	// Plusieurs comptes d'un appareil en file classée: ils ne seront pas
+	// placés ensemble, mais le cas est signalé (échange de victoires)
+	if others := q.deviceAccounts(entry); queue == constants.QueueRanked && len(others) > 0 {
+		log.Printf("⚠️ Matchmaking: %s shares a device with %v, they will not be matched together", client.username, others)
+	}
+	q.waiting = append(q.waiting, entry)
+
+	log.Printf("🔎 %s is looking for a %s match (rating %.0f ± %.0f, %s pace)", client.username, queue, rating, deviation, pace)
+}
+
+// handleCancelMatch retire le joueur de la file
+func (s *Server) handleCancelMatch(client *Client, msg *models.NetworkMessage) {
+	s.leaveMatchmaking(client)
+}
+
+// handleMatchReply enregistre l'acceptation ou le refus d'une table proposée
+func (s *Server) handleMatchReply(client *Client, msg *models.NetworkMessage) {
+	var payload models.MatchReplyPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+
+	q := s.matchmaking
+	q.mu.Lock()
+	match := q.pending[payload.MatchID]
+	if match == nil || q.pendingFor(client.userID) != match {
+		q.mu.Unlock()
+		return
+	}
+
+	if !payload.Accept {
+		notices := q.cancel(match, map[int64]bool{client.userID: true}, constants.MsgTextMatchDeclined)
+		s.sendMatchCancelled(notices, client)
+		q.mu.Unlock()
+		return
+	}
+
+	match.accepted[client.userID] = true
+	if len(match.accepted) < len(match.entries) {
+		q.mu.Unlock()
+		return
+	}
+	delete(q.pending, match.id)
+	match.timer.Stop()
+	q.mu.Unlock()
+
+	s.startMatch(match)
+}
+
+// leaveMatchmaking retire le joueur de la file. Une table qui lui était
+// proposée est annulée, les autres joueurs retournent dans la file.
+func (s *Server) leaveMatchmaking(client *Client) {
+	q := s.matchmaking
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.removeWaiting(client.userID)
+	if match := q.pendingFor(client.userID); match != nil {
+		notices := q.cancel(match, map[int64]bool{client.userID: true}, constants.MsgTextMatchDeclined)
+		s.sendMatchCancelled(notices, client)
+	}
+}
+
+// cancel annule une table proposée: les joueurs de dropped sortent du
+// matchmaking, les autres reprennent leur place dans la file. Retourne
+// l'avis à envoyer à chacun.
+func (q *MatchmakingQueue) cancel(match *pendingMatch, dropped map[int64]bool, reason string) map[*Client]models.MatchCancelledPayload {
+	delete(q.pending, match.id)
+	match.timer.Stop()
+
+	notices := make(map[*Client]models.MatchCancelledPayload, len(match.entries))
+	for _, entry := range match.entries {
+		requeued := !dropped[entry.client.userID]
+		if requeued {
+			q.waiting = append(q.waiting, entry)
+		}
+		notices[entry.client] = models.MatchCancelledPayload{
+			MatchID:  match.id,
+			Reason:   reason,
+			Requeued: requeued,
+		}
+	}
+	return notices
+}
+
+// sendMatchCancelled envoie les avis d'annulation, sauf au joueur skip
+// (celui qui a refusé ou s'est déconnecté). L'appelant détient q.mu: un
+// joueur remis dans la file ne reçoit pas sa prochaine table avant l'avis.
+func (s *Server) sendMatchCancelled(notices map[*Client]models.MatchCancelledPayload, skip *Client) {
+	for client, notice := range notices {
+		if client == skip {
+			continue
+		}
+		s.sendMessage(client, &models.NetworkMessage{
+			Type:      constants.MsgMatchCancelled,
+			Payload:   notice,
+			Timestamp: time.Now(),
+		})
+	}
+}
+
+// processMatchmaking forme les tables à intervalle régulier
+func (s *Server) processMatchmaking() {
+	ticker := time.NewTicker(matchTick)
+	defer ticker.Stop()
+
+	q := s.matchmaking
+	for now := range ticker.C {
+		// Proposer sous le verrou: l'annulation d'une table ne peut pas
+		// arriver à un joueur avant la proposition
+		q.mu.Lock()
+		for _, match := range q.propose(now, s.expireMatch) {
+			s.sendMatchFound(match)
+		}
+		q.mu.Unlock()
+	}
+}
+
+// propose forme les tables possibles et les met en attente des réponses.
+// expire est appelé si tous n'ont pas accepté à temps. L'appelant détient
+// q.mu.
+func (q *MatchmakingQueue) propose(now time.Time, expire func(id string)) []*pendingMatch {
+	groups, rest := formMatches(q.waiting, now)
+	q.waiting = rest
+
+	matches := make([]*pendingMatch, 0, len(groups))
+	for _, group := range groups {
+		q.nextID++
+		id := strconv.Itoa(q.nextID)
+		match := &pendingMatch{
+			id:       id,
+			entries:  group,
+			accepted: make(map[int64]bool),
+			timer: time.AfterFunc(constants.MatchAcceptTime*time.Second, func() {
+				expire(id)
+			}),
+		}
+		q.pending[id] = match
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// sendMatchFound propose la table à chacun de ses joueurs
+func (s *Server) sendMatchFound(match *pendingMatch) {
+	players := make([]models.MatchPlayer, len(match.entries))
+	for i, entry := range match.entries {
+		players[i] = models.MatchPlayer{
+			ID:          entry.client.userID,
+			Username:    entry.client.username,
+			WinRate:     entry.winRate,
+			Rating:      int(math.Round(entry.rating)),
+			Provisional: entry.provisional,
+		}
+	}
+
+	pace := groupPace(match.entries)
+	msg := &models.NetworkMessage{
+		Type: constants.MsgMatchFound,
+		Payload: models.MatchFoundPayload{
+			MatchID:       match.id,
+			Players:       players,
+			AcceptSeconds: constants.MatchAcceptTime,
+			Pace:          pace,
+			TurnSeconds:   int(paceTurnTimeout(pace, s.getConfig().Game.TurnTimeout) / time.Second),
+			Queue:         match.entries[0].queue,
+			SkillDice:     match.entries[0].skillDice,
+		},
+		Timestamp: time.Now(),
+	}
+	for _, entry := range match.entries {
+		s.sendMessage(entry.client, msg)
+	}
+	log.Printf("🤝 Match %s proposed to %d players", match.id, len(match.entries))
+}
+
+// expireMatch annule une table dont tous les joueurs n'ont pas répondu:
+// ceux qui n'ont pas accepté quittent la file
+func (s *Server) expireMatch(id string) {
+	q := s.matchmaking
+	q.mu.Lock()
+	match := q.pending[id]
+	if match == nil {
+		q.mu.Unlock()
+		return
+	}
+	dropped := make(map[int64]bool)
+	for _, entry := range match.entries {
+		if !match.accepted[entry.client.userID] {
+			dropped[entry.client.userID] = true
+		}
+	}
+	notices := q.cancel(match, dropped, constants.MsgTextMatchExpired)
+	s.sendMatchCancelled(notices, nil)
+	q.mu.Unlock()
+}
+
+// startMatch crée la salle d'une table acceptée par tous et lance la partie
+func (s *Server) startMatch(match *pendingMatch) {
+	roomID := s.reserveRoomID()
+	host := match.entries[0].client
+	queue := match.entries[0].queue
+
+	name := "Ranked match"
+	if queue == constants.QueueCasual {
+		name = "Casual match"
+	}
+	room := &models.Room{
+		ID:         roomID,
+		Name:       name,
+		HostID:     host.userID,
+		Players:    make([]*models.Player, 0, len(match.entries)),
+		MaxPlayers: len(match.entries),
+		GameMode:   "online",
+		State:      constants.StateWaiting,
+		CreatedAt:  time.Now(),
+		IsPrivate:  true,
+		GameNumber: 1,
+		Series:     make(map[int64]int),
+		Pace:       groupPace(match.entries),
+		Queue:      queue,
+		SkillDice:  match.entries[0].skillDice,
+	}
+
+	gameRoom := newGameRoom(room)
+	usedColors := make(map[constants.PlayerColor]bool)
+	for _, entry := range match.entries {
+		color := constants.FreeSeat(room.MaxPlayers, usedColors)
+		usedColors[color] = true
+
+		player := models.NewPlayer(entry.client.userID, entry.client.username, color)
+		// Accepter la table vaut déclaration « prêt »
+		player.IsReady = true
+		room.Players = append(room.Players, player)
+		gameRoom.addClient(entry.client)
+	}
+	gameRoom.engine = s.newEngine(roomID, gameRoom, nil)
+
+	// Un joueur a pu se déconnecter ou rejoindre une salle depuis son
+	// acceptation. handleDisconnect retire la connexion sous s.mu avant de
+	// lire client.roomID: vérifier et asseoir les joueurs sous ce verrou.
+	s.mu.Lock()
+	delete(s.pendingRoomIDs, roomID)
+	dropped := make(map[int64]bool)
+	for _, entry := range match.entries {
+		if s.conns[entry.client.connID] != entry.client || entry.client.roomID != "" {
+			dropped[entry.client.userID] = true
+		}
+	}
+	if len(dropped) > 0 {
+		s.mu.Unlock()
+		s.abortMatch(match, dropped)
+		return
+	}
+	gameRoom.open(roomID)
+	s.rooms[roomID] = gameRoom
+	for _, entry := range match.entries {
+		entry.client.roomID = roomID
+		s.clients[entry.client.userID] = entry.client
+	}
+	s.mu.Unlock()
+
+	for _, entry := range match.entries {
+		s.sendSessionToken(entry.client)
+	}
+
+	log.Printf("🎮 Match %s accepted, starting room %s", match.id, roomID)
+	s.startGame(roomID, gameRoom)
+}
+
+// abortMatch annule une table acceptée dont un joueur est parti avant la
+// création de la salle: les autres retournent dans la file
+func (s *Server) abortMatch(match *pendingMatch, dropped map[int64]bool) {
+	q := s.matchmaking
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	notices := q.cancel(match, dropped, constants.MsgTextMatchDeclined)
+	s.sendMatchCancelled(notices, nil)
+	log.Printf("🚫 Match %s aborted: %d player(s) left before it started", match.id, len(dropped))
+}
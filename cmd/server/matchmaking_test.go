@@ -0,0 +1,291 @@
+// cmd/server/matchmaking_test.go
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/database"
+)
+
+func newQueueEntry(userID int64, rating float64, joined time.Time) *queueEntry {
+	return &queueEntry{client: &Client{userID: userID}, rating: rating, joined: joined, queue: constants.QueueRanked}
+}
+
+func groupIDs(group []*queueEntry) []int64 {
+	ids := make([]int64, len(group))
+	for i, entry := range group {
+		ids[i] = entry.client.userID
+	}
+	return ids
+}
+
+func TestFormMatchesFillsTableWithClosestPlayers(t *testing.T) {
+	now := time.Now()
+	waiting := []*queueEntry{
+		newQueueEntry(1, 1500, now),
+		newQueueEntry(2, 1700, now),
+		newQueueEntry(3, 1525, now),
+		newQueueEntry(4, 1475, now),
+		newQueueEntry(5, 1510, now),
+		newQueueEntry(6, 1540, now),
+	}
+
+	groups, rest := formMatches(waiting, now)
+	if len(groups) != 1 {
+		t.Fatalf("groups = %d, want 1 full table", len(groups))
+	}
+	// 6 est dans l'écart accepté mais le plus éloigné du joueur 1
+	want := []int64{1, 5, 3, 4}
+	got := groupIDs(groups[0])
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("table = %v, want %v", got, want)
+		}
+	}
+	if ids := groupIDs(rest); len(ids) != 2 || ids[0] != 2 || ids[1] != 6 {
+		t.Errorf("still waiting = %v, want [2 6]", ids)
+	}
+}
+
+func TestFormMatchesWaitsBeforeSmallTable(t *testing.T) {
+	now := time.Now()
+	waiting := []*queueEntry{newQueueEntry(1, 1500, now), newQueueEntry(2, 1525, now)}
+
+	if groups, _ := formMatches(waiting, now); len(groups) != 0 {
+		t.Fatal("two fresh players should wait for a fuller table")
+	}
+
+	waiting[0].joined = now.Add(-matchFillWait)
+	groups, rest := formMatches(waiting, now)
+	if len(groups) != 1 || len(groups[0]) != 2 || len(rest) != 0 {
+		t.Fatalf("groups = %d, rest = %d, want one table of two", len(groups), len(rest))
+	}
+}
+
+func TestFormMatchesWidensSpreadWithWait(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-time.Minute)
+	waiting := []*queueEntry{newQueueEntry(1, 1350, old), newQueueEntry(2, 1550, old)}
+
+	// Écart de 200 points: accepté après six paliers d'attente (50 + 6×25)
+	groups, _ := formMatches(waiting, now)
+	if len(groups) != 1 {
+		t.Fatalf("groups = %d, want players matched after a long wait", len(groups))
+	}
+
+	waiting = []*queueEntry{newQueueEntry(1, 1350, now.Add(-matchFillWait)), newQueueEntry(2, 1550, now)}
+	if groups, _ := formMatches(waiting, now); len(groups) != 0 {
+		t.Error("a 200 point gap should not be accepted after a short wait")
+	}
+}
+
+func TestCancelRequeuesOnlyRemainingPlayers(t *testing.T) {
+	now := time.Now()
+	q := newMatchmakingQueue()
+	q.waiting = []*queueEntry{newQueueEntry(1, 1500, now.Add(-matchFillWait)), newQueueEntry(2, 1500, now)}
+
+	matches := q.propose(now, func(string) {})
+	if len(matches) != 1 {
+		t.Fatalf("matches = %d, want 1", len(matches))
+	}
+
+	notices := q.cancel(matches[0], map[int64]bool{2: true}, constants.MsgTextMatchDeclined)
+	if len(q.pending) != 0 {
+		t.Error("cancelled match should leave the pending list")
+	}
+	if ids := groupIDs(q.waiting); len(ids) != 1 || ids[0] != 1 {
+		t.Fatalf("requeued = %v, want [1]", ids)
+	}
+	for client, notice := range notices {
+		if notice.Requeued != (client.userID == 1) {
+			t.Errorf("player %d requeued = %v", client.userID, notice.Requeued)
+		}
+	}
+	// Le joueur remis dans la file garde son ancienneté
+	if !q.waiting[0].joined.Before(now) {
+		t.Error("requeued player lost their place in the queue")
+	}
+}
+
+func TestFormMatchesKeepsPacesApart(t *testing.T) {
+	now := time.Now()
+	joined := now.Add(-matchFillWait)
+	fast, relaxed := newQueueEntry(1, 1500, joined), newQueueEntry(2, 1500, joined)
+	fast.pace, relaxed.pace = constants.PaceFast, constants.PaceRelaxed
+	normal := newQueueEntry(3, 1500, joined)
+	normal.pace = constants.PaceNormal
+
+	if groups, _ := formMatches([]*queueEntry{fast, relaxed, normal}, now); len(groups) != 0 {
+		t.Fatalf("groups = %v, want no table before the pace wait", groups)
+	}
+
+	// Après l'attente, le rythme voisin est accepté, pas l'opposé
+	fast.joined = now.Add(-matchPaceWait)
+	groups, rest := formMatches([]*queueEntry{fast, relaxed, normal}, now)
+	if len(groups) != 1 || len(rest) != 1 || rest[0] != relaxed {
+		t.Fatalf("groups = %d, rest = %v, want fast and normal together", len(groups), groupIDs(rest))
+	}
+	if pace := groupPace(groups[0]); pace != constants.PaceNormal {
+		t.Errorf("mixed table pace = %s, want normal", pace)
+	}
+}
+
+func TestInferPaceFromDecisionTime(t *testing.T) {
+	tests := []struct {
+		decisions int
+		avg       time.Duration
+		want      constants.Pace
+	}{
+		{decisions: 5, avg: time.Second, want: constants.PaceNormal},
+		{decisions: 40, avg: 2 * time.Second, want: constants.PaceFast},
+		{decisions: 40, avg: 8 * time.Second, want: constants.PaceNormal},
+		{decisions: 40, avg: 20 * time.Second, want: constants.PaceRelaxed},
+	}
+	for _, tt := range tests {
+		stats := &models.PlayerStats{Decisions: tt.decisions, DecisionMs: int64(tt.decisions) * tt.avg.Milliseconds()}
+		if got := inferPace(stats); got != tt.want {
+			t.Errorf("%d decisions of %v: pace = %s, want %s", tt.decisions, tt.avg, got, tt.want)
+		}
+	}
+
+	if got := paceTurnTimeout(constants.PaceNormal, 45); got != 45*time.Second {
+		t.Errorf("normal pace timeout = %v, want the configured 45s", got)
+	}
+	if got := paceTurnTimeout(constants.PaceFast, 45); got != constants.PaceFastTurnTimeout*time.Second {
+		t.Errorf("fast pace timeout = %v", got)
+	}
+}
+
+func TestFormMatchesSeparatesAccountsOfOneDevice(t *testing.T) {
+	now := time.Now()
+	waiting := []*queueEntry{
+		newQueueEntry(1, 1500, now),
+		newQueueEntry(2, 1500, now),
+		newQueueEntry(3, 1505, now),
+		newQueueEntry(4, 1510, now),
+		newQueueEntry(5, 1550, now),
+	}
+	waiting[0].device, waiting[1].device = "laptop", "laptop"
+
+	groups, rest := formMatches(waiting, now)
+	if len(groups) != 1 {
+		t.Fatalf("groups = %d, want one full table", len(groups))
+	}
+	if got := groupIDs(groups[0]); len(got) != 4 || got[1] == 2 || got[2] == 2 || got[3] == 2 {
+		t.Errorf("table = %v, player 2 shares a device with player 1", got)
+	}
+	if ids := groupIDs(rest); len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("still waiting = %v, want [2]", ids)
+	}
+}
+
+func TestFormMatchesKeepsQueuesApart(t *testing.T) {
+	now := time.Now()
+	waiting := []*queueEntry{
+		newQueueEntry(1, 1500, now),
+		newQueueEntry(2, 1500, now),
+		newQueueEntry(3, 1500, now),
+		newQueueEntry(4, 1500, now),
+	}
+	waiting[1].queue, waiting[3].queue = constants.QueueCasual, constants.QueueCasual
+	// Même appareil: accepté en partie amicale
+	waiting[1].device, waiting[3].device = "family-pc", "family-pc"
+
+	if groups, _ := formMatches(waiting, now); len(groups) != 0 {
+		t.Fatalf("groups = %d, ranked and casual players should not share a table", len(groups))
+	}
+
+	for _, entry := range waiting {
+		entry.joined = now.Add(-matchFillWait)
+	}
+	groups, _ := formMatches(waiting, now)
+	if len(groups) != 2 {
+		t.Fatalf("groups = %d, want one ranked and one casual table", len(groups))
+	}
+	for _, group := range groups {
+		if !group[0].sameTable(group[1]) {
+			t.Errorf("table %v mixes queues", groupIDs(group))
+		}
+	}
+}
+
+func TestQueueRulesForStats(t *testing.T) {
+	if _, code := matchQueue(models.FindMatchPayload{SkillDice: true}); code != constants.ErrRankedVariant {
+		t.Errorf("ranked skill dice: code = %q, want %q", code, constants.ErrRankedVariant)
+	}
+	if queue, code := matchQueue(models.FindMatchPayload{Queue: constants.QueueCasual, SkillDice: true}); queue != constants.QueueCasual || code != "" {
+		t.Errorf("casual skill dice: queue %q, code %q", queue, code)
+	}
+
+	update := database.StatUpdate{Won: true}
+	applyQueueRules(&update, constants.QueueRanked, diceTally{timeouts: constants.RankedAFKLimit})
+	if !update.AFK || update.Won {
+		t.Errorf("inactive ranked winner: %+v, want an AFK loss", update)
+	}
+
+	update = database.StatUpdate{Won: true}
+	applyQueueRules(&update, constants.QueueCasual, diceTally{timeouts: constants.RankedAFKLimit})
+	if !update.Casual || update.AFK || !update.Won {
+		t.Errorf("casual winner: %+v", update)
+	}
+}
+
+func TestFormMatchesWidensToleranceForPlacement(t *testing.T) {
+	now := time.Now()
+	joined := now.Add(-matchFillWait)
+	veteran, newcomer := newQueueEntry(1, 1500, joined), newQueueEntry(2, 1800, joined)
+	veteran.deviation = 60
+
+	if groups, _ := formMatches([]*queueEntry{veteran, newcomer}, now); len(groups) != 0 {
+		t.Fatal("a 300 point gap between settled players should not be accepted")
+	}
+
+	// L'incertitude d'un joueur en placement élargit l'écart accepté
+	newcomer.deviation = constants.RatingInitialDeviation
+	if groups, _ := formMatches([]*queueEntry{veteran, newcomer}, now); len(groups) != 1 {
+		t.Error("a player in placement should be matched despite the gap")
+	}
+}
+
+// Un joueur déconnecté après avoir accepté n'est pas assis: la table est
+// annulée et les autres retournent dans la file
+func TestStartMatchSkipsDisconnectedPlayer(t *testing.T) {
+	now := time.Now()
+	present := &Client{connID: 1, userID: 1, send: make(chan *models.NetworkMessage, 8)}
+	gone := &Client{connID: 2, userID: 2, send: make(chan *models.NetworkMessage, 8)}
+	s := &Server{
+		config:         &Config{},
+		conns:          map[uint64]*Client{present.connID: present},
+		clients:        map[int64]*Client{present.userID: present},
+		rooms:          make(map[string]*GameRoom),
+		pendingRoomIDs: make(map[string]bool),
+		matchmaking:    newMatchmakingQueue(),
+	}
+	q := s.matchmaking
+	q.waiting = []*queueEntry{
+		{client: present, rating: 1500, joined: now.Add(-matchFillWait), queue: constants.QueueRanked},
+		{client: gone, rating: 1500, joined: now, queue: constants.QueueRanked},
+	}
+	matches := q.propose(now, func(string) {})
+	if len(matches) != 1 {
+		t.Fatalf("matches = %d, want 1", len(matches))
+	}
+	delete(q.pending, matches[0].id)
+	matches[0].timer.Stop()
+	gone.close()
+
+	s.startMatch(matches[0])
+	if len(s.rooms) != 0 || len(s.pendingRoomIDs) != 0 || present.roomID != "" || gone.roomID != "" {
+		t.Fatal("a match with a disconnected player must not start")
+	}
+	if ids := groupIDs(q.waiting); len(ids) != 1 || ids[0] != present.userID {
+		t.Fatalf("requeued = %v, want [1]", ids)
+	}
+	msg := lastMessage(present)
+	if msg == nil || msg.Type != constants.MsgMatchCancelled || !msg.Payload.(models.MatchCancelledPayload).Requeued {
+		t.Fatalf("present player got %+v, want a requeue notice", msg)
+	}
+}
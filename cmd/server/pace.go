@@ -0,0 +1,95 @@
+// cmd/server/pace.go
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// Déduction du rythme d'un joueur qui n'en a pas déclaré
+const (
+	paceMinDecisions = 20               // Coups joués avant de se fier au temps moyen
+	paceFastDecision = 4 * time.Second  // En dessous: rythme rapide
+	paceSlowDecision = 12 * time.Second // Au-dessus: rythme tranquille
+)
+
+// validPace indique si le rythme existe
+func validPace(pace constants.Pace) bool {
+	for _, known := range constants.Paces {
+		if pace == known {
+			return true
+		}
+	}
+	return false
+}
+
+// paceRank situe le rythme de 0 (rapide) à 2 (tranquille); un rythme
+// inconnu compte comme normal
+func paceRank(pace constants.Pace) int {
+	for i, known := range constants.Paces {
+		if pace == known {
+			return i
+		}
+	}
+	return 1
+}
+
+// paceDistance compte les crans entre deux rythmes
+func paceDistance(a, b constants.Pace) int {
+	d := paceRank(a) - paceRank(b)
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// inferPace déduit le rythme du temps de réflexion moyen du joueur
+func inferPace(stats *models.PlayerStats) constants.Pace {
+	if stats == nil || stats.Decisions < paceMinDecisions {
+		return constants.PaceNormal
+	}
+	switch avg := stats.AvgDecision(); {
+	case avg < paceFastDecision:
+		return constants.PaceFast
+	case avg > paceSlowDecision:
+		return constants.PaceRelaxed
+	}
+	return constants.PaceNormal
+}
+
+// paceTurnTimeout retourne la durée des tours d'une salle au rythme donné.
+// Le rythme normal, ou l'absence de rythme, garde la durée configurée.
+func paceTurnTimeout(pace constants.Pace, configured int) time.Duration {
+	switch pace {
+	case constants.PaceFast:
+		return constants.PaceFastTurnTimeout * time.Second
+	case constants.PaceRelaxed:
+		return constants.PaceRelaxedTurnTimeout * time.Second
+	}
+	return time.Duration(configured) * time.Second
+}
+
+// handleSetPace enregistre le rythme de jeu préféré du joueur
+func (s *Server) handleSetPace(client *Client, msg *models.NetworkMessage) {
+	var payload models.SetPacePayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+	if payload.Pace != "" && !validPace(payload.Pace) {
+		s.sendError(client, constants.ErrUnknownPace, nil)
+		return
+	}
+
+	if err := s.db.SetPace(client.userID, payload.Pace); err != nil {
+		log.Printf("Failed to save pace of %s: %v", client.username, err)
+		s.sendError(client, constants.ErrServerFailure, nil)
+		return
+	}
+	client.pace = payload.Pace
+	log.Printf("%s prefers a %q pace", client.username, payload.Pace)
+}
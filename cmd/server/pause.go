@@ -0,0 +1,70 @@
+// cmd/server/pause.go
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// maxPause est la durée au-delà de laquelle une partie en pause reprend
+// d'elle-même, pour qu'un joueur parti ne bloque pas la table
+const maxPause = 15 * time.Minute
+
+// pausableRoom retourne la salle du client si son rôle lui permet de mettre
+// en pause et que la partie n'est pas classée. Sinon l'erreur est envoyée au
+// client et le résultat est nil.
+func (s *Server) pausableRoom(client *Client) *GameRoom {
+	gameRoom := s.permittedRoom(client, constants.PermPause)
+	if gameRoom == nil {
+		return nil
+	}
+
+	gameRoom.mu.RLock()
+	ranked := gameRoom.room.Queue == constants.QueueRanked
+	gameRoom.mu.RUnlock()
+
+	if ranked {
+		s.sendError(client, constants.ErrRankedPause, nil)
+		return nil
+	}
+	return gameRoom
+}
+
+// handlePauseGame met la partie en pause à la demande d'un joueur autorisé
+func (s *Server) handlePauseGame(client *Client, msg *models.NetworkMessage) {
+	gameRoom := s.pausableRoom(client)
+	if gameRoom == nil {
+		return
+	}
+	if err := gameRoom.engine.Pause(maxPause); err != nil {
+		s.sendFailure(client, err, constants.ErrGameNotStarted)
+		return
+	}
+	log.Printf("%s paused room %s", client.username, client.roomID)
+}
+
+// handleResumeGame reprend la partie mise en pause
+func (s *Server) handleResumeGame(client *Client, msg *models.NetworkMessage) {
+	gameRoom := s.pausableRoom(client)
+	if gameRoom == nil {
+		return
+	}
+	if err := gameRoom.engine.Resume(); err != nil {
+		s.sendFailure(client, err, constants.ErrGameNotPaused)
+		return
+	}
+	log.Printf("%s resumed room %s", client.username, client.roomID)
+}
+
+// broadcastPause annonce la pause ou la reprise à la salle
+func (s *Server) broadcastPause(roomID string, paused bool, resumesAt time.Time) {
+	msg := &models.NetworkMessage{Type: constants.MsgGameResumed, Timestamp: time.Now()}
+	if paused {
+		msg.Type = constants.MsgGamePaused
+		msg.Payload = models.GamePausedPayload{ResumesAt: resumesAt}
+	}
+	s.broadcastToRoom(roomID, msg)
+}
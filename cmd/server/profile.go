@@ -0,0 +1,151 @@
+// cmd/server/profile.go
+package main
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// profileRivals est le nombre de rivaux affichés sur un profil
+const profileRivals = 5
+
+// ratingHistoryPoints est le nombre de cotes tracées sur la courbe d'un profil
+const ratingHistoryPoints = 50
+
+// diceTally compte les dés lancés par un joueur pendant une partie, et
+// les tours qu'il a laissé passer
+type diceTally struct {
+	sixes    int
+	rolls    int
+	timeouts int
+}
+
+// recordDiceRoll compte un lancer. Appelé sous le verrou du moteur.
+func (gr *GameRoom) recordDiceRoll(playerID int64, value int) {
+	gr.diceMu.Lock()
+	defer gr.diceMu.Unlock()
+
+	tally := gr.dice[playerID]
+	tally.rolls++
+	if value == constants.DiceMax {
+		tally.sixes++
+	}
+	gr.dice[playerID] = tally
+}
+
+// recordTimeout compte un tour perdu par inactivité. Appelé sous le verrou du moteur.
+func (gr *GameRoom) recordTimeout(playerID int64) {
+	gr.diceMu.Lock()
+	defer gr.diceMu.Unlock()
+
+	tally := gr.dice[playerID]
+	tally.timeouts++
+	gr.dice[playerID] = tally
+}
+
+// tallyFor retourne les dés lancés par un joueur depuis le début de la partie
+func (gr *GameRoom) tallyFor(playerID int64) diceTally {
+	gr.diceMu.Lock()
+	defer gr.diceMu.Unlock()
+	return gr.dice[playerID]
+}
+
+// handleGetProfile renvoie le compte et les statistiques enregistrées d'un
+// joueur, désigné par son identifiant ou son nom. Le compte d'un autre
+// joueur est envoyé sans ses données privées.
+func (s *Server) handleGetProfile(client *Client, msg *models.NetworkMessage) {
+	var payload models.ProfileRequestPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+
+	var user *models.User
+	var err error
+	if username := strings.TrimSpace(payload.Username); username != "" {
+		if user, err = s.db.GetUserByUsername(username); err != nil {
+			s.sendError(client, constants.ErrUnknownPlayer, i18n.Params{"username": username})
+			return
+		}
+	} else if user, err = s.db.GetUserByID(payload.UserID); err != nil {
+		log.Printf("Failed to load user %d: %v", payload.UserID, err)
+		user = nil
+	}
+	userID := payload.UserID
+	if user != nil {
+		userID = user.ID
+		if userID != client.userID {
+			user = user.Public()
+		}
+	}
+
+	stats, err := s.db.GetPlayerStats(userID)
+	if err != nil {
+		log.Printf("Failed to load profile %d: %v", userID, err)
+		// Joueur sans partie enregistrée: profil vide
+		stats = &models.PlayerStats{UserID: userID}
+	}
+	if rivals, err := s.db.GetTopRivals(userID, profileRivals); err != nil {
+		log.Printf("Failed to load rivals of %d: %v", userID, err)
+	} else {
+		stats.Rivals = rivals
+	}
+
+	s.sendMessage(client, &models.NetworkMessage{
+		Type:      constants.MsgProfile,
+		Payload:   models.ProfilePayload{User: user, Stats: stats},
+		Timestamp: time.Now(),
+	})
+}
+
+// handleGetRivalries renvoie le bilan du joueur contre les adversaires de
+// son lobby
+func (s *Server) handleGetRivalries(client *Client, msg *models.NetworkMessage) {
+	var payload models.RivalriesRequestPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+	if len(payload.OpponentIDs) > constants.MaxPlayers {
+		payload.OpponentIDs = payload.OpponentIDs[:constants.MaxPlayers]
+	}
+
+	records, err := s.db.GetRivalries(client.userID, payload.OpponentIDs)
+	if err != nil {
+		log.Printf("Failed to load rivalries of %d: %v", client.userID, err)
+		return
+	}
+
+	s.sendMessage(client, &models.NetworkMessage{
+		Type:      constants.MsgRivalries,
+		Payload:   models.RivalriesPayload{Records: records},
+		Timestamp: time.Now(),
+	})
+}
+
+// handleGetRatingHistory renvoie l'évolution de la cote d'un joueur
+func (s *Server) handleGetRatingHistory(client *Client, msg *models.NetworkMessage) {
+	var payload models.RatingHistoryRequestPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+
+	points, err := s.db.GetRatingHistory(payload.UserID, ratingHistoryPoints)
+	if err != nil {
+		log.Printf("Failed to load rating history of %d: %v", payload.UserID, err)
+		return
+	}
+
+	s.sendMessage(client, &models.NetworkMessage{
+		Type:      constants.MsgRatingHistory,
+		Payload:   models.RatingHistoryPayload{UserID: payload.UserID, Points: points},
+		Timestamp: time.Now(),
+	})
+}
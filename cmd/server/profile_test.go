@@ -0,0 +1,43 @@
+// cmd/server/profile_test.go
+package main
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/database"
+)
+
+func TestProfileByUsernameHidesPrivateFields(t *testing.T) {
+	store := database.NewMemoryStore()
+	alice, _ := store.CreateUser("alice", "alice@example.com", "hash")
+	bob, _ := store.CreateUser("bob", "bob@example.com", "hash")
+	store.WriteStatUpdate(database.StatUpdate{UserID: bob.ID, Won: true})
+
+	s := &Server{db: store}
+	client := &Client{userID: alice.ID, username: "alice", send: make(chan *models.NetworkMessage, 4)}
+	getProfile := func(request models.ProfileRequestPayload) *models.NetworkMessage {
+		s.handleGetProfile(client, &models.NetworkMessage{Payload: request})
+		return lastMessage(client)
+	}
+
+	msg := getProfile(models.ProfileRequestPayload{Username: "BOB"})
+	profile, ok := msg.Payload.(models.ProfilePayload)
+	if !ok || profile.User == nil || profile.User.ID != bob.ID {
+		t.Fatalf("profile reply = %+v, want bob's profile", msg)
+	}
+	if profile.User.Email != "" || profile.User.Coins != 1200 || profile.Stats.GamesWon != 1 {
+		t.Errorf("unexpected public profile %+v, stats %+v", profile.User, profile.Stats)
+	}
+
+	msg = getProfile(models.ProfileRequestPayload{UserID: alice.ID})
+	if profile := msg.Payload.(models.ProfilePayload); profile.User.Email != "alice@example.com" {
+		t.Errorf("own profile email = %q, want it shown", profile.User.Email)
+	}
+
+	msg = getProfile(models.ProfileRequestPayload{Username: "nobody"})
+	if msg.Payload.(models.ErrorPayload).Code != constants.ErrUnknownPlayer {
+		t.Errorf("unknown player reply = %+v, want %s", msg, constants.ErrUnknownPlayer)
+	}
+}
@@ -0,0 +1,62 @@
+// cmd/server/queues.go
+package main
+
+import (
+	"log"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/database"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/rating"
+)
+
+// matchQueue retourne la file demandée et vérifie ses règles: une partie
+// classée se joue avec les règles standard et des dés équitables. Sans
+// file précisée, le joueur entre dans la file classée.
+func matchQueue(req models.FindMatchPayload) (constants.Queue, string) {
+	queue := req.Queue
+	if queue == "" {
+		queue = constants.QueueRanked
+	}
+	if queue == constants.QueueRanked && req.SkillDice {
+		return queue, constants.ErrRankedVariant
+	}
+	return queue, ""
+}
+
+// applyQueueRules adapte les statistiques d'une partie à sa file. Une
+// partie amicale ne touche pas au classement; dans une partie classée,
+// le joueur qui a laissé passer trop de tours perd, sans récompense, et
+// sa cote compte une défaite contre chaque adversaire.
+func applyQueueRules(update *database.StatUpdate, queue constants.Queue, tally diceTally) {
+	switch queue {
+	case constants.QueueCasual:
+		update.Casual = true
+	case constants.QueueRanked:
+		if tally.timeouts >= constants.RankedAFKLimit {
+			update.AFK = true
+			update.Won = false
+			for i := range update.Opponents {
+				update.Opponents[i].Score = 0
+			}
+		}
+	}
+}
+
+// ratedOpponents retourne les adversaires de chaque joueur humain d'une
+// partie classée, avec leur cote d'avant la partie
+func (s *Server) ratedOpponents(game *models.Game) map[int64][]rating.Opponent {
+	ratings := make(map[int64]*models.PlayerStats)
+	for _, player := range game.Room.Players {
+		if player.IsAI {
+			continue
+		}
+		stats, err := s.db.GetPlayerStats(player.ID)
+		if err != nil {
+			log.Printf("Rating: no stats for %s, using the initial rating: %v", player.Username, err)
+			continue
+		}
+		ratings[player.ID] = stats
+	}
+	return database.RatedOpponents(game, ratings)
+}
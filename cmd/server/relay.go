@@ -0,0 +1,23 @@
+// cmd/server/relay.go
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/relay"
+)
+
+// relayRetryDelay espace les tentatives d'enregistrement auprès du relais
+const relayRetryDelay = 15 * time.Second
+
+// registerWithRelay garde le serveur enregistré auprès du relais configuré.
+// Les joueurs raccordés par le relais sont traités comme des connexions directes.
+func (s *Server) registerWithRelay(addr, name, secret string) {
+	for {
+		log.Printf("📡 Registering with relay %s as %q", addr, name)
+		err := relay.Register(addr, name, secret, s.handleConnection)
+		log.Printf("Relay connection lost: %v (retrying in %s)", err, relayRetryDelay)
+		time.Sleep(relayRetryDelay)
+	}
+}
@@ -0,0 +1,100 @@
+// cmd/server/roles.go
+package main
+
+import (
+	"log"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/roles"
+)
+
+// handleSetRole nomme un joueur modérateur ou le rend membre. Les rôles
+// restent valables pendant la partie et les revanches.
+func (s *Server) handleSetRole(client *Client, msg *models.NetworkMessage) {
+	var payload models.SetRolePayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+
+	gameRoom := s.permittedRoom(client, constants.PermManageRoles)
+	if gameRoom == nil {
+		return
+	}
+
+	gameRoom.mu.Lock()
+	err := roles.Assign(gameRoom.room, payload.PlayerID, payload.Role)
+	gameRoom.mu.Unlock()
+
+	if err != nil {
+		s.sendFailure(client, err, constants.ErrUnknownRole)
+		return
+	}
+
+	log.Printf("%s made player %d %s in room %s", client.username, payload.PlayerID, payload.Role, client.roomID)
+	s.broadcastLobby(client.roomID, gameRoom)
+}
+
+// handleSetPermissions remplace les permissions d'un rôle de la salle
+func (s *Server) handleSetPermissions(client *Client, msg *models.NetworkMessage) {
+	var payload models.SetPermissionsPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+
+	gameRoom := s.permittedRoom(client, constants.PermManageRoles)
+	if gameRoom == nil {
+		return
+	}
+
+	gameRoom.mu.Lock()
+	err := roles.SetPermissions(gameRoom.room, payload.Role, payload.Permissions)
+	gameRoom.mu.Unlock()
+
+	if err != nil {
+		s.sendFailure(client, err, constants.ErrUnknownPermission)
+		return
+	}
+
+	s.broadcastLobby(client.roomID, gameRoom)
+}
+
+// handleMutePlayer retire ou rend la parole à un joueur de rang inférieur,
+// avant comme pendant la partie
+func (s *Server) handleMutePlayer(client *Client, msg *models.NetworkMessage) {
+	var payload models.MutePlayerPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+
+	gameRoom := s.permittedRoom(client, constants.PermModerateChat)
+	if gameRoom == nil {
+		return
+	}
+	if !s.outranks(client, gameRoom, payload.PlayerID, constants.PermModerateChat) {
+		return
+	}
+
+	gameRoom.mu.Lock()
+	roles.SetMuted(gameRoom.room, payload.PlayerID, payload.Muted)
+	gameRoom.mu.Unlock()
+
+	s.broadcastLobby(client.roomID, gameRoom)
+}
+
+// snapshot copie l'état d'une partie avant son envoi. writeMessages l'encode
+// plus tard, hors de tout verrou: la copie de la salle, prise sous
+// gameRoom.mu, lui évite de lire les tables de rôles et de sourdine
+// qu'un hôte est en train de remplacer.
+func (gr *GameRoom) snapshot(game *models.Game) *models.Game {
+	state := *game
+	gr.mu.RLock()
+	room := *game.Room
+	gr.mu.RUnlock()
+	state.Room = &room
+	return &state
+}
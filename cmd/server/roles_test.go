@@ -0,0 +1,95 @@
+// cmd/server/roles_test.go
+package main
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestMemberGrantedStartGame(t *testing.T) {
+	s, host, guest := newLobbyServer()
+
+	s.handleStartGame(guest, &models.NetworkMessage{})
+	if msg := lastMessage(guest); msg == nil || msg.Payload.(models.ErrorPayload).Code != constants.ErrPermissionDenied {
+		t.Fatalf("member start reply = %+v, want %s", msg, constants.ErrPermissionDenied)
+	}
+
+	s.handleSetPermissions(guest, &models.NetworkMessage{Payload: models.SetPermissionsPayload{
+		Role:        constants.RoleMember,
+		Permissions: []constants.Permission{constants.PermStartGame},
+	}})
+	if msg := lastMessage(guest); msg == nil || msg.Payload.(models.ErrorPayload).Code != constants.ErrPermissionDenied {
+		t.Fatalf("member permissions reply = %+v, want %s", msg, constants.ErrPermissionDenied)
+	}
+
+	s.handleSetPermissions(host, &models.NetworkMessage{Payload: models.SetPermissionsPayload{
+		Role:        constants.RoleMember,
+		Permissions: []constants.Permission{constants.PermStartGame},
+	}})
+	if msg := lastMessage(host); msg == nil || msg.Type != constants.MsgGameState {
+		t.Fatalf("host permissions reply = %+v, want the updated lobby", msg)
+	}
+	if got := s.rooms["ABC234"].room.Permissions[constants.RoleMember]; len(got) != 1 {
+		t.Fatalf("member permissions = %v, want start_game", got)
+	}
+}
+
+func TestMutedPlayerCannotChat(t *testing.T) {
+	s, host, guest := newLobbyServer()
+
+	s.handleMutePlayer(guest, &models.NetworkMessage{Payload: models.MutePlayerPayload{PlayerID: host.userID, Muted: true}})
+	if msg := lastMessage(guest); msg == nil || msg.Payload.(models.ErrorPayload).Code != constants.ErrPermissionDenied {
+		t.Fatalf("member mute reply = %+v, want %s", msg, constants.ErrPermissionDenied)
+	}
+
+	s.handleMutePlayer(host, &models.NetworkMessage{Payload: models.MutePlayerPayload{PlayerID: guest.userID, Muted: true}})
+	lastMessage(host)
+	lastMessage(guest)
+
+	s.handleChatMessage(guest, &models.NetworkMessage{Payload: models.ChatPayload{Text: "hello"}})
+	if msg := lastMessage(guest); msg == nil || msg.Payload.(models.ErrorPayload).Code != constants.ErrChatMuted {
+		t.Fatalf("muted chat reply = %+v, want %s", msg, constants.ErrChatMuted)
+	}
+	if msg := lastMessage(host); msg != nil {
+		t.Fatalf("host received %+v from a muted player", msg)
+	}
+}
+
+// Lancé avec -race: l'état envoyé s'encode pendant que l'hôte coupe et rend
+// la parole, comme le ferait writeMessages
+func TestMuteDuringStateBroadcast(t *testing.T) {
+	s, host, guest := newLobbyServer()
+	host.send = make(chan *models.NetworkMessage, 64)
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case msg := <-host.send:
+				if _, err := json.Marshal(msg); err != nil {
+					t.Error(err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 200; i++ {
+		s.handleMutePlayer(host, &models.NetworkMessage{Payload: models.MutePlayerPayload{PlayerID: guest.userID, Muted: i%2 == 0}})
+		lastMessage(guest)
+	}
+	close(done)
+	wg.Wait()
+
+	if s.rooms["ABC234"].room.Muted[guest.userID] {
+		t.Error("guest still muted after the last unmute")
+	}
+}
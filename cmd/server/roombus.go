@@ -0,0 +1,250 @@
+// cmd/server/roombus.go
+package main
+
+import (
+	"log"
+	"sync"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// Le bus d'une salle sépare deux rôles:
+//   - la goroutine propriétaire exécute une à une les commandes adressées à
+//     la salle: messages des joueurs, minuteries, fin de partie;
+//   - la goroutine de sortie diffuse les messages à sa propre liste de
+//     destinataires, tenue à jour par les arrivées et départs dans l'ordre
+//     des messages.
+//
+// Les rappels du moteur, qui tournent sous son verrou, ne font que déposer
+// un message ou une commande: ils ne prennent jamais le verrou de la salle.
+
+// Capacités du bus d'une salle
+const (
+	roomOutboxSize   = 256  // Messages en attente de diffusion
+	roomMailboxLimit = 1024 // Commandes en attente, au-delà elles sont perdues
+)
+
+// roomEvent est un élément du flux sortant: un message à diffuser, une
+// arrivée ou un départ de destinataire. Un événement vide sert de repère.
+type roomEvent struct {
+	msg   *models.NetworkMessage
+	join  *Client
+	leave int64
+	// done est fermé une fois l'événement appliqué
+	done chan struct{}
+}
+
+// roomBus porte les files d'une salle ouverte
+type roomBus struct {
+	mu      sync.Mutex
+	pending []func()
+	closed  bool
+	wake    chan struct{}
+	outbox  chan roomEvent
+	drained chan struct{} // Fermé quand la goroutine de sortie s'arrête
+}
+
+// open démarre les goroutines de la salle. Les clients déjà assis sont les
+// premiers destinataires. À appeler avant d'enregistrer la salle.
+func (gr *GameRoom) open(roomID string) {
+	bus := &roomBus{
+		wake:    make(chan struct{}, 1),
+		outbox:  make(chan roomEvent, roomOutboxSize),
+		drained: make(chan struct{}),
+	}
+
+	gr.mu.RLock()
+	recipients := make(map[int64]*Client, len(gr.clients))
+	for id, client := range gr.clients {
+		recipients[id] = client
+	}
+	gr.mu.RUnlock()
+
+	// La salle n'est pas encore partagée: pas de verrou pour le bus
+	gr.bus = bus
+
+	go gr.run(roomID, bus)
+	go gr.fanOut(bus, recipients)
+}
+
+// close arrête les goroutines de la salle et attend que les messages déjà
+// déposés soient diffusés. Les commandes en attente sont abandonnées. À
+// appeler avant de retirer la salle du serveur, jamais depuis sa goroutine.
+func (gr *GameRoom) close() {
+	bus := gr.bus
+	if bus == nil {
+		return
+	}
+
+	bus.mu.Lock()
+	if !bus.closed {
+		bus.closed = true
+		bus.pending = nil
+		close(bus.outbox)
+		close(bus.wake)
+	}
+	bus.mu.Unlock()
+
+	<-bus.drained
+}
+
+// do confie une commande à la goroutine de la salle, sans attendre. Une
+// salle sans bus (tests) l'exécute tout de suite.
+func (gr *GameRoom) do(cmd func()) {
+	bus := gr.bus
+	if bus == nil {
+		cmd()
+		return
+	}
+
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	if bus.closed {
+		return
+	}
+	if len(bus.pending) >= roomMailboxLimit {
+		log.Printf("Room mailbox full, command dropped")
+		return
+	}
+	bus.pending = append(bus.pending, cmd)
+	select {
+	case bus.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run exécute les commandes de la salle dans leur ordre d'arrivée
+func (gr *GameRoom) run(roomID string, bus *roomBus) {
+	for range bus.wake {
+		for {
+			bus.mu.Lock()
+			if len(bus.pending) == 0 {
+				bus.mu.Unlock()
+				break
+			}
+			cmd := bus.pending[0]
+			bus.pending = bus.pending[1:]
+			bus.mu.Unlock()
+
+			gr.runCommand(roomID, cmd)
+		}
+	}
+}
+
+// runCommand exécute une commande sans laisser une erreur arrêter la salle
+func (gr *GameRoom) runCommand(roomID string, cmd func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Room %s: command panicked: %v", roomID, r)
+		}
+	}()
+	cmd()
+}
+
+// post dépose un événement dans le flux sortant. Retourne false si la salle
+// est fermée.
+func (bus *roomBus) post(ev roomEvent) bool {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	if bus.closed {
+		return false
+	}
+	// La goroutine de sortie ne prend aucun verrou: la file se vide toujours
+	bus.outbox <- ev
+	return true
+}
+
+// publish diffuse un message aux joueurs puis aux spectateurs de la salle
+func (gr *GameRoom) publish(msg *models.NetworkMessage) {
+	if gr.bus == nil {
+		gr.mu.RLock()
+		gr.deliver(gr.clients, msg)
+		gr.mu.RUnlock()
+		return
+	}
+	gr.bus.post(roomEvent{msg: msg})
+}
+
+// addClient assoit un client dans la salle. L'appelant détient gr.mu.
+func (gr *GameRoom) addClient(client *Client) {
+	gr.clients[client.userID] = client
+	if gr.bus != nil {
+		gr.bus.post(roomEvent{join: client})
+	}
+}
+
+// removeClient retire un client de la salle. Le canal retourné est fermé
+// quand plus aucun message de la salle ne lui sera envoyé. L'appelant
+// détient gr.mu, mais attend le canal après l'avoir relâché.
+func (gr *GameRoom) removeClient(userID int64) <-chan struct{} {
+	delete(gr.clients, userID)
+	return gr.sync(roomEvent{leave: userID})
+}
+
+// flush retourne un canal fermé quand tous les messages déjà déposés ont
+// été diffusés
+func (gr *GameRoom) flush() <-chan struct{} {
+	return gr.sync(roomEvent{})
+}
+
+// sync dépose un événement et retourne un canal fermé une fois appliqué
+func (gr *GameRoom) sync(ev roomEvent) <-chan struct{} {
+	ev.done = make(chan struct{})
+	switch {
+	case gr.bus == nil:
+		close(ev.done)
+	case !gr.bus.post(ev):
+		// Salle fermée: attendre la fin de la diffusion en cours
+		return gr.bus.drained
+	}
+	return ev.done
+}
+
+// fanOut diffuse le flux sortant jusqu'à la fermeture de la salle
+func (gr *GameRoom) fanOut(bus *roomBus, recipients map[int64]*Client) {
+	defer close(bus.drained)
+
+	for ev := range bus.outbox {
+		switch {
+		case ev.join != nil:
+			recipients[ev.join.userID] = ev.join
+		case ev.msg != nil:
+			gr.deliver(recipients, ev.msg)
+		case ev.leave != 0:
+			delete(recipients, ev.leave)
+		}
+		if ev.done != nil {
+			close(ev.done)
+		}
+	}
+}
+
+// deliver envoie un message à chaque destinataire sans jamais bloquer, puis
+// le met en attente pour les spectateurs
+func (gr *GameRoom) deliver(recipients map[int64]*Client, msg *models.NetworkMessage) {
+	for _, client := range recipients {
+		if !client.trySend(msg) {
+			log.Printf("Failed to send to client %d", client.userID)
+		}
+	}
+
+	// Les spectateurs reçoivent les événements par lots
+	gr.queueForSpectators(msg)
+}
+
+// roomScoped indique si un message vise la salle du client et doit passer
+// par sa goroutine
+func roomScoped(msgType constants.MessageType) bool {
+	switch msgType {
+	case constants.MsgLeaveRoom, constants.MsgRollDice, constants.MsgMoveToken,
+		constants.MsgReady, constants.MsgRematch, constants.MsgSetHandicap,
+		constants.MsgKickPlayer, constants.MsgAssignColor, constants.MsgStartGame,
+		constants.MsgSetRole, constants.MsgSetPermissions, constants.MsgMutePlayer,
+		constants.MsgVoteAbort, constants.MsgStartSpin, constants.MsgStopSpin,
+		constants.MsgSyncState, constants.MsgChatMessage, constants.MsgSetLobbyTheme,
+		constants.MsgPauseGame, constants.MsgResumeGame, constants.MsgUndoMove:
+		return true
+	}
+	return false
+}
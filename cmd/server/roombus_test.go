@@ -0,0 +1,102 @@
+// cmd/server/roombus_test.go
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func newBusClient(id int64) *Client {
+	return &Client{userID: id, send: make(chan *models.NetworkMessage, 8)}
+}
+
+func waitFor(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("room bus did not catch up")
+	}
+}
+
+func TestRoomBusFollowsMembership(t *testing.T) {
+	first, second := newBusClient(1), newBusClient(2)
+	gameRoom := newGameRoom(&models.Room{ID: "BUS234"})
+	gameRoom.addClient(first)
+	gameRoom.open("BUS234")
+	defer gameRoom.close()
+
+	gameRoom.publish(&models.NetworkMessage{Type: constants.MsgTurnChanged})
+	gameRoom.mu.Lock()
+	gameRoom.addClient(second)
+	gameRoom.mu.Unlock()
+	gameRoom.publish(&models.NetworkMessage{Type: constants.MsgDiceRolled})
+
+	gameRoom.mu.Lock()
+	left := gameRoom.removeClient(first.userID)
+	gameRoom.mu.Unlock()
+	waitFor(t, left)
+	gameRoom.publish(&models.NetworkMessage{Type: constants.MsgTokenMoved})
+	waitFor(t, gameRoom.flush())
+
+	if len(first.send) != 2 {
+		t.Errorf("first client got %d messages, want 2 before leaving", len(first.send))
+	}
+	if msg := <-second.send; msg.Type != constants.MsgDiceRolled {
+		t.Errorf("second client first got %s, want messages sent after joining only", msg.Type)
+	}
+	if msg := <-second.send; msg.Type != constants.MsgTokenMoved {
+		t.Errorf("second client then got %s, want %s", msg.Type, constants.MsgTokenMoved)
+	}
+}
+
+func TestRoomBusPublishDoesNotTakeRoomLock(t *testing.T) {
+	client := newBusClient(1)
+	gameRoom := newGameRoom(&models.Room{ID: "BUS234"})
+	gameRoom.addClient(client)
+	gameRoom.open("BUS234")
+	defer gameRoom.close()
+
+	// Comme un rappel du moteur pendant qu'un gestionnaire tient la salle
+	published := make(chan struct{})
+	gameRoom.mu.Lock()
+	go func() {
+		gameRoom.publish(&models.NetworkMessage{Type: constants.MsgTurnChanged})
+		close(published)
+	}()
+	waitFor(t, published)
+	gameRoom.mu.Unlock()
+
+	waitFor(t, gameRoom.flush())
+	if len(client.send) != 1 {
+		t.Errorf("client got %d messages, want 1", len(client.send))
+	}
+}
+
+func TestRoomBusRunsCommandsInOrder(t *testing.T) {
+	gameRoom := newGameRoom(&models.Room{ID: "BUS234"})
+	gameRoom.open("BUS234")
+	defer gameRoom.close()
+
+	var order []int
+	done := make(chan struct{})
+	for i := 1; i <= 50; i++ {
+		i := i
+		gameRoom.do(func() {
+			order = append(order, i)
+			if i == 50 {
+				close(done)
+			}
+		})
+	}
+	waitFor(t, done)
+
+	for i, got := range order {
+		if got != i+1 {
+			t.Fatalf("command %d ran at position %d", got, i+1)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// cmd/server/rooms.go
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/server/room"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/roles"
+)
+
+// handleListRooms envoie les salles publiques qui attendent des joueurs
+func (s *Server) handleListRooms(client *Client, msg *models.NetworkMessage) {
+	s.mu.RLock()
+	gameRooms := make([]*GameRoom, 0, len(s.rooms))
+	for _, gameRoom := range s.rooms {
+		gameRooms = append(gameRooms, gameRoom)
+	}
+	s.mu.RUnlock()
+
+	// Résumer sous le verrou de chaque salle: les joueurs peuvent changer
+	summaries := make([]models.RoomSummary, 0, len(gameRooms))
+	for _, gameRoom := range gameRooms {
+		gameRoom.mu.RLock()
+		if room.Listed(gameRoom.room) {
+			summaries = append(summaries, room.Summarize(gameRoom.room))
+		}
+		gameRoom.mu.RUnlock()
+	}
+	room.SortSummaries(summaries)
+
+	s.sendMessage(client, &models.NetworkMessage{
+		Type:      constants.MsgRoomList,
+		Payload:   models.RoomListPayload{Rooms: summaries},
+		Timestamp: time.Now(),
+	})
+}
+
+// releaseSeat libère la place d'un joueur qui quitte la salle ou n'est pas
+// revenu à temps. Avant la partie, la place se libère et l'hôte passe au
+// joueur suivant; la salle ferme quand il n'y reste plus personne. Une
+// partie lancée continue: l'IA joue à sa place. À appeler depuis la
+// goroutine de la salle.
+func (s *Server) releaseSeat(roomID string, gameRoom *GameRoom, userID int64) {
+	gameRoom.mu.RLock()
+	engine := gameRoom.engine
+	gameRoom.mu.RUnlock()
+
+	// Le moteur prend son propre verrou: ne pas détenir celui de la salle
+	if err := engine.RemovePlayer(userID); err != nil {
+		if engine.TakeOver(userID) == nil {
+			log.Printf("AI took over player %d in room %s", userID, roomID)
+		}
+		gameRoom.mu.Lock()
+		left := gameRoom.removeClient(userID)
+		gameRoom.mu.Unlock()
+		<-left
+		return
+	}
+
+	gameRoom.mu.Lock()
+	left := gameRoom.removeClient(userID)
+	roles.Forget(gameRoom.room, userID)
+	var humans []*models.Player
+	for _, p := range gameRoom.room.Players {
+		if !p.IsAI {
+			humans = append(humans, p)
+		}
+	}
+	if gameRoom.room.HostID == userID && len(humans) > 0 {
+		gameRoom.room.HostID = humans[0].ID
+	}
+	gameRoom.mu.Unlock()
+	<-left
+
+	if len(humans) == 0 {
+		// Le verrou du serveur se prend avant celui d'une salle, jamais après
+		s.mu.Lock()
+		if s.rooms[roomID] == gameRoom {
+			delete(s.rooms, roomID)
+		}
+		s.mu.Unlock()
+
+		// close attend la goroutine de diffusion: pas depuis celle de la salle
+		go gameRoom.close()
+		log.Printf("Room %s closed: all players left", roomID)
+		return
+	}
+
+	s.broadcastLobby(roomID, gameRoom)
+}
@@ -0,0 +1,89 @@
+// cmd/server/season.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/database"
+)
+
+// seasonCheckInterval est la période de vérification de la fin de saison
+const seasonCheckInterval = time.Hour
+
+// seasonDateLayout est le format de season.start
+const seasonDateLayout = "2006-01-02"
+
+// validateSeason vérifie le calendrier et l'érosion des saisons
+func validateSeason(config *Config) error {
+	season := config.Season
+	if season.Start == "" {
+		return nil
+	}
+	if _, err := time.Parse(seasonDateLayout, season.Start); err != nil {
+		return fmt.Errorf("season start must be a date like 2026-01-01: %w", err)
+	}
+	if season.LengthDays < 1 {
+		return fmt.Errorf("season length_days must be at least 1")
+	}
+	if season.InactiveDays < 0 || season.DecayPoints < 0 || season.DecayDeviation < 0 {
+		return fmt.Errorf("season inactivity and decay cannot be negative")
+	}
+	return nil
+}
+
+// seasonNumber retourne le numéro de la saison en cours à now (1 pour la
+// première), 0 avant le début de la première
+func seasonNumber(start time.Time, lengthDays int, now time.Time) int {
+	if now.Before(start) {
+		return 0
+	}
+	length := time.Duration(lengthDays) * 24 * time.Hour
+	return int(now.Sub(start)/length) + 1
+}
+
+// watchSeasons clôt la saison précédente dès qu'une nouvelle commence
+func (s *Server) watchSeasons() {
+	s.closeSeason(time.Now())
+
+	ticker := time.NewTicker(seasonCheckInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.closeSeason(time.Now())
+	}
+}
+
+// closeSeason clôt la saison qui vient de se terminer, si aucune instance
+// ne l'a déjà fait. Seule la dernière saison terminée est close: après un
+// long arrêt du serveur, les précédentes ne sont pas rattrapées.
+func (s *Server) closeSeason(now time.Time) {
+	config := s.getConfig().Season
+	if config.Start == "" {
+		return
+	}
+	start, err := time.Parse(seasonDateLayout, config.Start)
+	if err != nil {
+		return
+	}
+
+	ended := seasonNumber(start, config.LengthDays, now) - 1
+	if ended < 1 {
+		return
+	}
+
+	decay := database.SeasonDecay{
+		InactiveSince: now.AddDate(0, 0, -config.InactiveDays),
+		Points:        config.DecayPoints,
+		Deviation:     config.DecayDeviation,
+	}
+	decayed, closed, err := s.db.EndSeason(ended, decay)
+	if err != nil {
+		log.Printf("Failed to close season %d: %v", ended, err)
+		return
+	}
+	if closed {
+		log.Printf("🏁 Season %d closed, %d inactive ratings decayed", ended, decayed)
+	}
+}
@@ -0,0 +1,75 @@
+// cmd/server/season_test.go
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/database"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/rating"
+)
+
+func TestSeasonNumber(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		now  time.Time
+		want int
+	}{
+		{now: start.Add(-time.Hour), want: 0},
+		{now: start, want: 1},
+		{now: start.AddDate(0, 0, 29), want: 1},
+		{now: start.AddDate(0, 0, 30), want: 2},
+		{now: start.AddDate(0, 0, 95), want: 4},
+	}
+	for _, tt := range tests {
+		if got := seasonNumber(start, 30, tt.now); got != tt.want {
+			t.Errorf("seasonNumber(%s) = %d, want %d", tt.now.Format(seasonDateLayout), got, tt.want)
+		}
+	}
+}
+
+func TestCloseSeasonDecaysInactiveRatingsOnce(t *testing.T) {
+	store := database.NewMemoryStore()
+	user, _ := store.CreateUser("veteran", "v@example.com", "")
+	store.WriteStatUpdate(database.StatUpdate{
+		UserID:    user.ID,
+		Won:       true,
+		Opponents: []rating.Opponent{{Rating: 1500, Deviation: 60, Score: 1}},
+	})
+	before, _ := store.GetPlayerStats(user.ID)
+
+	config := &Config{}
+	config.Season.Start = "2026-01-01"
+	config.Season.LengthDays = 30
+	config.Season.InactiveDays = 30
+	config.Season.DecayPoints = 50
+	config.Season.DecayDeviation = 40
+	s := &Server{db: store, config: config}
+
+	// La partie vient d'être jouée: pas d'érosion à la fin de la saison 1
+	s.closeSeason(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	if after, _ := store.GetPlayerStats(user.ID); after.Rating != before.Rating {
+		t.Fatalf("active player decayed from %.0f to %.0f", before.Rating, after.Rating)
+	}
+
+	// Un an plus tard, le joueur n'a plus joué depuis inactive_days; la
+	// même saison n'est close qu'une fois
+	now := time.Now().AddDate(1, 0, 0)
+	s.closeSeason(now)
+	s.closeSeason(now)
+	after, _ := store.GetPlayerStats(user.ID)
+	if after.Rating != before.Rating-50 || after.RatingDeviation != before.RatingDeviation+40 {
+		t.Errorf("rating %.0f ± %.0f, want one decay from %.0f ± %.0f",
+			after.Rating, after.RatingDeviation, before.Rating, before.RatingDeviation)
+	}
+
+	// L'historique garde la partie puis l'érosion, dans l'ordre
+	history, _ := store.GetRatingHistory(user.ID, ratingHistoryPoints)
+	if len(history) != 2 || history[0].Reason != database.RatingReasonGame ||
+		history[1].Reason != database.RatingReasonDecay || history[1].Rating != after.Rating {
+		t.Errorf("unexpected rating history %+v", history)
+	}
+	if last, _ := store.GetRatingHistory(user.ID, 1); len(last) != 1 || last[0].Reason != database.RatingReasonDecay {
+		t.Errorf("expected the limit to keep the latest entry, got %+v", last)
+	}
+}
@@ -0,0 +1,114 @@
+// cmd/server/series.go
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/server/game"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/rules"
+)
+
+// recordSeriesResult compte la victoire dans la série de la salle et retourne
+// le numéro de la partie et une copie du score
+func (gr *GameRoom) recordSeriesResult(winner *models.Player) (int, map[int64]int) {
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+
+	if gr.room.Series == nil {
+		gr.room.Series = make(map[int64]int)
+	}
+	if winner != nil {
+		gr.room.Series[winner.ID]++
+	}
+
+	series := make(map[int64]int, len(gr.room.Series))
+	for id, wins := range gr.room.Series {
+		series[id] = wins
+	}
+	return gr.room.GameNumber, series
+}
+
+// resetForRematch prépare la partie suivante de la série. L'appelant doit
+// détenir gr.mu.
+func (gr *GameRoom) resetForRematch() {
+	for _, player := range gr.room.Players {
+		player.ResetForRematch()
+	}
+	gr.room.State = constants.StateWaiting
+	gr.room.StartedAt = nil
+	gr.room.CurrentTurn = 0
+	gr.room.LastDice = 0
+	gr.room.GameNumber++
+	gr.abortVotes = make(map[int64]bool)
+
+	gr.diceMu.Lock()
+	gr.dice = make(map[int64]diceTally)
+	gr.diceMu.Unlock()
+}
+
+// variant recompile la variante de la salle, validée à sa création
+func (gr *GameRoom) variant() *rules.Script {
+	if gr.room.Rules == "" {
+		return nil
+	}
+	script, _ := rules.Compile(gr.room.Rules)
+	return script
+}
+
+// handleRematch relance une partie dans la même salle une fois la précédente
+// terminée. Les joueurs repassent par le lobby et se déclarent prêts.
+func (s *Server) handleRematch(client *Client, msg *models.NetworkMessage) {
+	s.mu.RLock()
+	gameRoom := s.rooms[client.roomID]
+	s.mu.RUnlock()
+
+	if gameRoom == nil || client.spectating || s.refuseWhileDraining(client) {
+		return
+	}
+
+	gameRoom.mu.Lock()
+	switch {
+	case gameRoom.match != nil && !gameRoom.match.Decided():
+		// La partie suivante du match s'enchaîne automatiquement
+		gameRoom.mu.Unlock()
+		return
+	case gameRoom.room.State == constants.StateFinished:
+		gameRoom.resetForRematch()
+		if gameRoom.match != nil {
+			gameRoom.match = game.NewMatch(gameRoom.room.BestOf)
+			gameRoom.room.Match = gameRoom.match.Score()
+		}
+		gameRoom.engine = s.newEngine(client.roomID, gameRoom, gameRoom.variant())
+		log.Printf("Rematch in room %s: game %d", client.roomID, gameRoom.room.GameNumber)
+	case gameRoom.room.State == constants.StateWaiting:
+		// Revanche déjà lancée par un autre joueur
+	default:
+		gameRoom.mu.Unlock()
+		s.sendError(client, constants.ErrGameNotOver, nil)
+		return
+	}
+	engine := gameRoom.engine
+	gameRoom.mu.Unlock()
+
+	s.broadcastToRoom(client.roomID, &models.NetworkMessage{
+		Type:      constants.MsgGameState,
+		Payload:   models.GameStatePayload{Game: gameRoom.snapshot(engine.GetGameState())},
+		Timestamp: time.Now(),
+	})
+}
+
+// saveSeries enregistre le score de la série des joueurs humains
+func (s *Server) saveSeries(room *models.Room, gameNumber int, series map[int64]int) {
+	var players []int64
+	for _, player := range room.Players {
+		if !player.IsAI {
+			players = append(players, player.ID)
+		}
+	}
+	if err := s.db.SaveSeries(room.ID, room.CreatedAt, gameNumber, series, players); err != nil {
+		log.Printf("Failed to save series: %v", err)
+	}
+}
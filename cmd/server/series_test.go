@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestSeriesSurvivesRematch(t *testing.T) {
+	alice := models.NewPlayer(1, "alice", constants.ColorRed)
+	bob := models.NewPlayer(2, "bob", constants.ColorYellow)
+	gameRoom := &GameRoom{
+		room: &models.Room{
+			Players:    []*models.Player{alice, bob},
+			State:      constants.StateFinished,
+			GameNumber: 1,
+		},
+		abortVotes: map[int64]bool{1: true},
+		dice:       map[int64]diceTally{1: {sixes: 2, rolls: 9}},
+	}
+	alice.IsReady = true
+	alice.Tokens[0].Position = 57
+
+	number, series := gameRoom.recordSeriesResult(alice)
+	if number != 1 || series[1] != 1 || series[2] != 0 {
+		t.Fatalf("after game 1: game %d, series %v", number, series)
+	}
+
+	gameRoom.resetForRematch()
+	if gameRoom.room.State != constants.StateWaiting || gameRoom.room.GameNumber != 2 {
+		t.Fatalf("rematch: state %v, game %d", gameRoom.room.State, gameRoom.room.GameNumber)
+	}
+	if alice.IsReady || alice.Tokens[0].Position != -1 || alice.Color != constants.ColorRed {
+		t.Error("players should be back in base, unready, on the same seat")
+	}
+	if len(gameRoom.abortVotes) != 0 || gameRoom.tallyFor(1).rolls != 0 {
+		t.Error("per-game votes and dice should be cleared")
+	}
+
+	// Match nul: le score ne change pas, le numéro de partie avance
+	number, series = gameRoom.recordSeriesResult(nil)
+	if number != 2 || series[1] != 1 {
+		t.Errorf("after a draw: game %d, series %v", number, series)
+	}
+}
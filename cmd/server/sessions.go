@@ -0,0 +1,273 @@
+// cmd/server/sessions.go
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// session associe un jeton de reconnexion à une place dans une salle
+type session struct {
+	userID    int64
+	roomID    string
+	expiresAt time.Time // zéro tant que le client est connecté
+}
+
+// SessionStore gère les jetons de reconnexion.
+// Un jeton n'est utilisable qu'une fois, pendant la fenêtre de reconnexion.
+type SessionStore struct {
+	sessions map[string]*session
+	byUser   map[int64]string
+	mu       sync.Mutex
+}
+
+// NewSessionStore crée un nouveau stockage de sessions
+func NewSessionStore() *SessionStore {
+	return &SessionStore{
+		sessions: make(map[string]*session),
+		byUser:   make(map[int64]string),
+	}
+}
+
+// Issue crée un jeton pour un joueur et invalide le précédent
+func (st *SessionStore) Issue(userID int64, roomID string) string {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.pruneLocked()
+
+	if old, exists := st.byUser[userID]; exists {
+		delete(st.sessions, old)
+	}
+
+	token := newSessionToken()
+	st.sessions[token] = &session{userID: userID, roomID: roomID}
+	st.byUser[userID] = token
+	return token
+}
+
+// Suspend démarre la fenêtre de reconnexion d'un joueur déconnecté
+func (st *SessionStore) Suspend(userID int64, window time.Duration) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if token, exists := st.byUser[userID]; exists {
+		st.sessions[token].expiresAt = time.Now().Add(window)
+	}
+}
+
+// Resume consomme un jeton et en émet un nouveau.
+// Le jeton doit appartenir à un joueur déconnecté dont la fenêtre n'a pas expiré.
+func (st *SessionStore) Resume(token string) (userID int64, roomID string, newToken string, err error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.pruneLocked()
+
+	sess, exists := st.sessions[token]
+	if !exists {
+		return 0, "", "", i18n.NewError(constants.ErrInvalidSession, nil)
+	}
+	if sess.expiresAt.IsZero() {
+		return 0, "", "", i18n.NewError(constants.ErrSessionActive, nil)
+	}
+
+	// Rotation: l'ancien jeton ne pourra plus jamais servir
+	delete(st.sessions, token)
+	newToken = newSessionToken()
+	st.sessions[newToken] = &session{userID: sess.userID, roomID: sess.roomID}
+	st.byUser[sess.userID] = newToken
+
+	return sess.userID, sess.roomID, newToken, nil
+}
+
+// Expire clôt la fenêtre de reconnexion échue d'un joueur. Elle retourne
+// false si le joueur a repris sa place, joue ailleurs ou peut encore revenir.
+func (st *SessionStore) Expire(userID int64, roomID string) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	token, exists := st.byUser[userID]
+	if !exists {
+		// Déjà purgée par pruneLocked
+		return true
+	}
+	sess := st.sessions[token]
+	if sess.roomID != roomID || sess.expiresAt.IsZero() || time.Now().Before(sess.expiresAt) {
+		return false
+	}
+	delete(st.sessions, token)
+	delete(st.byUser, userID)
+	return true
+}
+
+// Revoke invalide le jeton d'un joueur qui quitte sa salle
+func (st *SessionStore) Revoke(userID int64) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if token, exists := st.byUser[userID]; exists {
+		delete(st.sessions, token)
+		delete(st.byUser, userID)
+	}
+}
+
+// pruneLocked supprime les sessions dont la fenêtre de reconnexion a expiré
+func (st *SessionStore) pruneLocked() {
+	now := time.Now()
+	for token, sess := range st.sessions {
+		if !sess.expiresAt.IsZero() && now.After(sess.expiresAt) {
+			delete(st.sessions, token)
+			if st.byUser[sess.userID] == token {
+				delete(st.byUser, sess.userID)
+			}
+		}
+	}
+}
+
+// newSessionToken génère un jeton aléatoire non devinable
+func newSessionToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand ne doit pas échouer
+	}
+	return hex.EncodeToString(b)
+}
+
+// sendSessionToken émet un nouveau jeton de reconnexion pour le client
+func (s *Server) sendSessionToken(client *Client) {
+	token := s.sessions.Issue(client.userID, client.roomID)
+	s.sendMessage(client, &models.NetworkMessage{
+		Type:      constants.MsgSessionToken,
+		Payload:   models.SessionTokenPayload{Token: token, RoomID: client.roomID},
+		Timestamp: time.Now(),
+	})
+}
+
+// handleResumeSession rattache un client reconnecté à sa place grâce à son jeton
+func (s *Server) handleResumeSession(client *Client, msg *models.NetworkMessage) {
+	var payload models.SessionTokenPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+
+	userID, roomID, newToken, err := s.sessions.Resume(payload.Token)
+	if err != nil {
+		s.sendFailure(client, err, constants.ErrInvalidSession)
+		return
+	}
+
+	s.mu.RLock()
+	gameRoom := s.rooms[roomID]
+	s.mu.RUnlock()
+
+	if gameRoom == nil {
+		s.sendError(client, constants.ErrRoomNotFound, nil)
+		return
+	}
+
+	gameRoom.mu.Lock()
+	if old, exists := gameRoom.clients[userID]; exists {
+		client.username = old.username
+	}
+	client.userID = userID
+	client.roomID = roomID
+	client.authenticated = true
+	gameRoom.addClient(client)
+	gameRoom.mu.Unlock()
+
+	s.mu.Lock()
+	s.clients[userID] = client
+	s.mu.Unlock()
+
+	s.sendMessage(client, &models.NetworkMessage{
+		Type:      constants.MsgSessionToken,
+		Payload:   models.SessionTokenPayload{Token: newToken, RoomID: roomID},
+		Timestamp: time.Now(),
+	})
+
+	// La partie a pu avancer pendant la coupure: renvoyer l'état complet
+	s.sendMessage(client, &models.NetworkMessage{
+		Type: constants.MsgGameState,
+		Payload: models.GameStatePayload{
+			Game:            gameRoom.snapshot(gameRoom.engine.GetGameState()),
+			Resync:          true,
+			AwaitingMove:    gameRoom.engine.Rolled(),
+			Paused:          gameRoom.engine.Paused(),
+			TurnRemainingMs: gameRoom.engine.TurnRemaining().Milliseconds(),
+		},
+		Timestamp: time.Now(),
+	})
+
+	log.Printf("%s resumed session in room %s", client.username, roomID)
+}
+
+// holdSeat détache un joueur déconnecté de sa salle sans libérer sa place.
+// La salle cesse de lui écrire jusqu'à la reprise de sa session.
+func (s *Server) holdSeat(client *Client) {
+	s.mu.RLock()
+	gameRoom := s.rooms[client.roomID]
+	s.mu.RUnlock()
+
+	if gameRoom == nil {
+		return
+	}
+
+	gameRoom.mu.Lock()
+	var left <-chan struct{}
+	if gameRoom.clients[client.userID] == client {
+		left = gameRoom.removeClient(client.userID)
+	} else {
+		// Session déjà reprise: la nouvelle connexion a pris la place
+		left = gameRoom.flush()
+	}
+	gameRoom.mu.Unlock()
+
+	// handleDisconnect ferme ensuite le canal d'envoi du client
+	<-left
+
+	log.Printf("%s disconnected, seat held in room %s", client.username, client.roomID)
+}
+
+// expireSeat libère la place d'un joueur qui n'est pas revenu pendant la
+// fenêtre de reconnexion
+func (s *Server) expireSeat(userID int64, roomID string) {
+	if !s.sessions.Expire(userID, roomID) {
+		return
+	}
+
+	s.mu.RLock()
+	gameRoom := s.rooms[roomID]
+	s.mu.RUnlock()
+
+	if gameRoom == nil {
+		return
+	}
+
+	gameRoom.do(func() {
+		gameRoom.mu.RLock()
+		_, back := gameRoom.clients[userID]
+		gameRoom.mu.RUnlock()
+		if back {
+			return
+		}
+		log.Printf("Player %d did not come back, releasing seat in room %s", userID, roomID)
+		s.releaseSeat(roomID, gameRoom, userID)
+	})
+}
+
+// Len retourne le nombre de jetons en mémoire
+func (st *SessionStore) Len() int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return len(st.sessions)
+}
@@ -0,0 +1,152 @@
+// cmd/server/sessions_test.go
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+)
+
+func TestSessionResumeRotatesToken(t *testing.T) {
+	store := NewSessionStore()
+	token := store.Issue(42, "K7MQ2X")
+
+	// Un joueur encore connecté ne peut pas être repris
+	if _, _, _, err := store.Resume(token); err == nil {
+		t.Fatal("Expected resume to fail while the player is connected")
+	}
+
+	store.Suspend(42, time.Minute)
+	userID, roomID, newToken, err := store.Resume(token)
+	if err != nil {
+		t.Fatalf("Failed to resume: %v", err)
+	}
+	if userID != 42 || roomID != "K7MQ2X" {
+		t.Errorf("Expected seat 42/K7MQ2X, got %d/%s", userID, roomID)
+	}
+	if newToken == token {
+		t.Error("Expected a rotated token")
+	}
+
+	// L'ancien jeton ne doit plus jamais servir
+	store.Suspend(42, time.Minute)
+	if _, _, _, err := store.Resume(token); err == nil {
+		t.Error("Expected the old token to be rejected")
+	}
+}
+
+func TestSessionExpiresAfterWindow(t *testing.T) {
+	store := NewSessionStore()
+	token := store.Issue(7, "ABCDEF")
+
+	store.Suspend(7, -time.Second)
+	if _, _, _, err := store.Resume(token); err == nil {
+		t.Error("Expected an expired token to be rejected")
+	}
+}
+
+func TestHoldSeatKeepsResumedClient(t *testing.T) {
+	s := &Server{rooms: make(map[string]*GameRoom)}
+	gameRoom := &GameRoom{clients: make(map[int64]*Client)}
+	s.rooms["K7MQ2X"] = gameRoom
+
+	dropped := &Client{userID: 42, roomID: "K7MQ2X"}
+	gameRoom.clients[42] = dropped
+	s.holdSeat(dropped)
+	if _, exists := gameRoom.clients[42]; exists {
+		t.Fatal("Expected the dropped connection to stop receiving room messages")
+	}
+
+	// Une ancienne connexion fermée après la reprise ne doit pas détacher la nouvelle
+	resumed := &Client{userID: 42, roomID: "K7MQ2X"}
+	gameRoom.clients[42] = resumed
+	s.holdSeat(dropped)
+	if gameRoom.clients[42] != resumed {
+		t.Error("Expected the resumed client to keep its seat")
+	}
+}
+
+func TestSessionExpireOnlyAfterWindow(t *testing.T) {
+	store := NewSessionStore()
+	token := store.Issue(7, "ABCDEF")
+
+	if store.Expire(7, "ABCDEF") {
+		t.Fatal("Expected a connected player to keep the seat")
+	}
+	store.Suspend(7, time.Minute)
+	if store.Expire(7, "ABCDEF") {
+		t.Fatal("Expected the seat to be held during the window")
+	}
+
+	// Reprise puis nouvelle coupure: l'ancienne échéance ne compte plus
+	store.Resume(token)
+	store.Suspend(7, 0)
+	if store.Expire(7, "OTHER1") {
+		t.Error("Expected a window for another room to be ignored")
+	}
+	if !store.Expire(7, "ABCDEF") {
+		t.Fatal("Expected the window to be over")
+	}
+	if store.Len() != 0 {
+		t.Error("Expected the expired session to be dropped")
+	}
+}
+
+func TestLeaveRoomReleasesSeatAndHost(t *testing.T) {
+	s, host, guest := newLobbyServer()
+	s.sessions = NewSessionStore()
+	s.sessions.Issue(host.userID, "ABC234")
+
+	s.handleLeaveRoom(host, nil)
+	gameRoom := s.rooms["ABC234"]
+	if len(gameRoom.room.Players) != 1 || gameRoom.clients[host.userID] != nil || host.roomID != "" {
+		t.Fatal("Expected the host's seat to be free")
+	}
+	if gameRoom.room.HostID != guest.userID {
+		t.Errorf("Expected the guest to become host, got %d", gameRoom.room.HostID)
+	}
+	if msg := lastMessage(guest); msg == nil || msg.Type != constants.MsgGameState {
+		t.Errorf("Expected the guest to get the updated lobby, got %+v", msg)
+	}
+	if s.sessions.Len() != 0 {
+		t.Error("Expected the leaver's session to be revoked")
+	}
+
+	s.handleLeaveRoom(guest, nil)
+	if _, exists := s.rooms["ABC234"]; exists {
+		t.Error("Expected the empty room to be closed")
+	}
+}
+
+func TestExpiredSeatIsReleased(t *testing.T) {
+	s, host, guest := newLobbyServer()
+	s.sessions = NewSessionStore()
+	gameRoom := s.rooms["ABC234"]
+
+	// Salle en attente: la place se libère à la fin de la fenêtre
+	s.sessions.Issue(guest.userID, "ABC234")
+	s.sessions.Suspend(guest.userID, 0)
+	s.holdSeat(guest)
+	s.expireSeat(guest.userID, "ABC234")
+	if len(gameRoom.room.Players) != 1 {
+		t.Fatal("Expected the waiting room to free the seat")
+	}
+
+	// Partie lancée: l'IA prend la place
+	s, host, _ = newLobbyServer()
+	s.sessions = NewSessionStore()
+	gameRoom = s.rooms["ABC234"]
+	gameRoom.engine.SetTurnTimeout(time.Minute)
+	gameRoom.engine.SetStarter(1)
+	if err := gameRoom.engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	s.sessions.Issue(host.userID, "ABC234")
+	s.sessions.Suspend(host.userID, 0)
+	s.holdSeat(host)
+	s.expireSeat(host.userID, "ABC234")
+	if seat := gameRoom.room.Players[0]; !seat.IsAI || len(gameRoom.room.Players) != 2 {
+		t.Fatal("Expected the AI to take the dropped player's seat")
+	}
+}
@@ -0,0 +1,136 @@
+// cmd/server/spectators.go
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/server/room"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// handleSpectateRoom ajoute un client comme spectateur d'une salle
+func (s *Server) handleSpectateRoom(client *Client, msg *models.NetworkMessage) {
+	var payload models.JoinRoomPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+	if client.roomID != "" {
+		s.sendError(client, constants.ErrAlreadyInRoom, nil)
+		return
+	}
+	roomID := payload.RoomID
+
+	s.mu.RLock()
+	gameRoom, exists := s.rooms[roomID]
+	s.mu.RUnlock()
+
+	if !exists {
+		s.sendError(client, constants.ErrRoomNotFound, nil)
+		return
+	}
+
+	// Regarder une salle privée demande aussi son mot de passe
+	if err := room.CheckPassword(gameRoom.room, payload.Password); err != nil {
+		s.sendFailure(client, err, constants.ErrRoomPasswordWrong)
+		return
+	}
+
+	client.roomID = roomID
+	client.spectating = true
+
+	gameRoom.spectatorMu.Lock()
+	gameRoom.spectators[client.connID] = client
+	startFlush := !gameRoom.spectatorFlushing
+	gameRoom.spectatorFlushing = true
+	gameRoom.spectatorMu.Unlock()
+
+	s.mu.Lock()
+	s.clients[client.userID] = client
+	s.mu.Unlock()
+
+	// Le premier spectateur démarre la boucle de regroupement
+	if startFlush {
+		go s.flushSpectators(gameRoom)
+	}
+
+	s.sendMessage(client, &models.NetworkMessage{
+		Type: constants.MsgGameState,
+		Payload: models.GameStatePayload{
+			Game:            gameRoom.snapshot(gameRoom.engine.GetGameState()),
+			TurnRemainingMs: gameRoom.engine.TurnRemaining().Milliseconds(),
+			Paused:          gameRoom.engine.Paused(),
+		},
+		Timestamp: time.Now(),
+	})
+
+	log.Printf("%s is spectating room %s", client.username, roomID)
+}
+
+// removeSpectator retire un spectateur de sa salle
+func (s *Server) removeSpectator(client *Client) {
+	s.mu.RLock()
+	gameRoom := s.rooms[client.roomID]
+	s.mu.RUnlock()
+
+	if gameRoom == nil {
+		return
+	}
+
+	gameRoom.spectatorMu.Lock()
+	delete(gameRoom.spectators, client.connID)
+	gameRoom.spectatorMu.Unlock()
+}
+
+// queueForSpectators met un événement en attente pour les spectateurs
+func (gr *GameRoom) queueForSpectators(msg *models.NetworkMessage) {
+	gr.spectatorMu.Lock()
+	defer gr.spectatorMu.Unlock()
+
+	if len(gr.spectators) == 0 {
+		return
+	}
+	gr.spectatorQueue = append(gr.spectatorQueue, msg)
+}
+
+// flushSpectators envoie les événements en attente par lots, une fois par fenêtre.
+// La boucle s'arrête quand il n'y a plus de spectateurs. Un spectateur parti
+// pendant l'envoi est sans risque: sendMessage ignore une connexion fermée.
+func (s *Server) flushSpectators(gameRoom *GameRoom) {
+	ticker := time.NewTicker(constants.SpectatorBatchWindow * time.Millisecond)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		gameRoom.spectatorMu.Lock()
+		if len(gameRoom.spectators) == 0 {
+			gameRoom.spectatorQueue = nil
+			gameRoom.spectatorFlushing = false
+			gameRoom.spectatorMu.Unlock()
+			return
+		}
+
+		events := gameRoom.spectatorQueue
+		gameRoom.spectatorQueue = nil
+		spectators := make([]*Client, 0, len(gameRoom.spectators))
+		for _, spectator := range gameRoom.spectators {
+			spectators = append(spectators, spectator)
+		}
+		gameRoom.spectatorMu.Unlock()
+
+		if len(events) == 0 {
+			continue
+		}
+
+		batch := &models.NetworkMessage{
+			Type:      constants.MsgEventBatch,
+			Payload:   models.EventBatchPayload{Events: events},
+			Timestamp: time.Now(),
+		}
+		for _, spectator := range spectators {
+			s.sendMessage(spectator, batch)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// cmd/server/spectators_test.go
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// Un spectateur qui se déconnecte pendant un lot ne doit pas faire tomber le
+// serveur, et deux onglets du même compte regardent chacun la salle.
+func TestSpectatorLeavingDuringBatch(t *testing.T) {
+	gameRoom := newGameRoom(&models.Room{ID: "ABC234", State: constants.StatePlaying})
+	s := &Server{rooms: map[string]*GameRoom{"ABC234": gameRoom}}
+
+	first := &Client{connID: 1, userID: 7, roomID: "ABC234", spectating: true, send: make(chan *models.NetworkMessage, 8)}
+	second := &Client{connID: 2, userID: 7, roomID: "ABC234", spectating: true, send: make(chan *models.NetworkMessage, 8)}
+	gameRoom.spectators[first.connID] = first
+	gameRoom.spectators[second.connID] = second
+	if len(gameRoom.spectators) != 2 {
+		t.Fatalf("second tab replaced the first: %d spectators", len(gameRoom.spectators))
+	}
+
+	// La connexion se ferme avant que la boucle ne la retire de la salle
+	first.close()
+	gameRoom.spectatorFlushing = true
+	go s.flushSpectators(gameRoom)
+	gameRoom.queueForSpectators(&models.NetworkMessage{Type: constants.MsgDiceRolled})
+
+	select {
+	case msg := <-second.send:
+		if msg.Type != constants.MsgEventBatch {
+			t.Fatalf("got %s, want an event batch", msg.Type)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("remaining spectator got no batch")
+	}
+
+	s.removeSpectator(first)
+	s.removeSpectator(second)
+	if first.trySend(&models.NetworkMessage{}) {
+		t.Fatal("send accepted on a closed connection")
+	}
+}
+
+// Un joueur assis ne peut pas regarder une autre salle sans quitter la sienne
+func TestSpectateRefusedWhileInRoom(t *testing.T) {
+	s, host, _ := newLobbyServer()
+
+	s.handleSpectateRoom(host, &models.NetworkMessage{Payload: models.JoinRoomPayload{RoomID: "ABC234"}})
+	if msg := lastMessage(host); msg == nil || msg.Payload.(models.ErrorPayload).Code != constants.ErrAlreadyInRoom {
+		t.Fatalf("spectate reply = %+v, want %s", msg, constants.ErrAlreadyInRoom)
+	}
+	if host.spectating || len(s.rooms["ABC234"].spectators) != 0 {
+		t.Fatal("seated player was added as a spectator")
+	}
+}
@@ -0,0 +1,73 @@
+// cmd/server/spin.go
+package main
+
+import (
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/dice"
+)
+
+// handleStartSpin démarre le sélecteur du dé à viser et publie l'engagement
+func (s *Server) handleStartSpin(client *Client, msg *models.NetworkMessage) {
+	s.mu.RLock()
+	gameRoom := s.rooms[client.roomID]
+	s.mu.RUnlock()
+
+	if gameRoom == nil || !gameRoom.room.SkillDice {
+		return
+	}
+
+	spin, err := gameRoom.engine.StartSpin(client.userID)
+	if err != nil {
+		s.sendFailure(client, err, constants.ErrInvalidMove)
+		return
+	}
+
+	s.broadcastToRoom(client.roomID, &models.NetworkMessage{
+		Type: constants.MsgSpinStarted,
+		Payload: models.SpinStartedPayload{
+			PlayerID:     client.userID,
+			Commitment:   spin.Commitment(),
+			FacePeriodMs: dice.SpinFacePeriod.Milliseconds(),
+		},
+		Timestamp: time.Now(),
+	})
+}
+
+// handleStopSpin arrête le sélecteur puis révèle la graine engagée
+func (s *Server) handleStopSpin(client *Client, msg *models.NetworkMessage) {
+	var payload models.StopSpinPayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+
+	s.mu.RLock()
+	gameRoom := s.rooms[client.roomID]
+	s.mu.RUnlock()
+
+	if gameRoom == nil || !gameRoom.room.SkillDice {
+		return
+	}
+
+	claimed := time.Duration(payload.ElapsedMs) * time.Millisecond
+	spin, elapsed, value, err := gameRoom.engine.StopSpin(client.userID, claimed)
+	if err != nil {
+		s.sendFailure(client, err, constants.ErrInvalidMove)
+		return
+	}
+
+	s.broadcastToRoom(client.roomID, &models.NetworkMessage{
+		Type: constants.MsgSpinResult,
+		Payload: models.SpinResultPayload{
+			PlayerID:  client.userID,
+			Seed:      spin.Seed,
+			ElapsedMs: elapsed.Milliseconds(),
+			Value:     value,
+		},
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,62 @@
+// cmd/server/store.go
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/database"
+)
+
+// Pilotes de stockage acceptés par database.driver
+const (
+	driverMySQL  = "mysql"
+	driverMemory = "memory"
+)
+
+// validateDriver vérifie le pilote de stockage. Le registre d'instances du
+// mode cluster n'existe que dans MySQL.
+func validateDriver(config *Config) error {
+	switch config.Database.Driver {
+	case "", driverMySQL:
+		return nil
+	case driverMemory:
+		if config.Cluster.InstanceID != "" {
+			return fmt.Errorf("cluster mode requires the %q database driver", driverMySQL)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown database driver %q", config.Database.Driver)
+}
+
+// openStore ouvre le stockage choisi par database.driver (MySQL par défaut)
+func openStore(config *Config) (database.Store, error) {
+	if config.Database.Driver == driverMemory {
+		log.Printf("⚠️ Using in-memory storage: accounts and statistics are lost on shutdown")
+		return database.NewMemoryStore(), nil
+	}
+
+	db, err := database.NewDB(
+		config.Database.Host,
+		config.Database.Port,
+		config.Database.Username,
+		config.Database.Password,
+		config.Database.Database,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ Connected to database successfully")
+
+	if replica := config.Database.ReadReplica; replica.Host != "" {
+		if err := db.UseReadReplica(replica.Host, replica.Port, replica.Username,
+			replica.Password, config.Database.Database); err != nil {
+			db.Close()
+			return nil, err
+		}
+		log.Printf("✅ Using read replica %s:%s", replica.Host, replica.Port)
+	}
+
+	return db, nil
+}
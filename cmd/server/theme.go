@@ -0,0 +1,63 @@
+// cmd/server/theme.go
+package main
+
+import (
+	"log"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// handleSetLobbyTheme change le décor et la musique du lobby. Le thème
+// voyage avec la salle: chaque joueur qui la rejoint le reçoit.
+func (s *Server) handleSetLobbyTheme(client *Client, msg *models.NetworkMessage) {
+	var payload models.SetLobbyThemePayload
+	if err := protocol.ExtractPayload(msg.Payload, &payload); err != nil {
+		s.sendError(client, constants.ErrInvalidPayload, nil)
+		return
+	}
+
+	gameRoom := s.lobbyRoom(client, constants.PermChangeRules)
+	if gameRoom == nil {
+		return
+	}
+
+	theme := payload.Theme
+	if theme != nil && *theme == (models.LobbyTheme{}) {
+		theme = nil
+	}
+	if theme != nil && theme.Pack != "" {
+		code, params := s.checkThemePack(theme)
+		if code != "" {
+			s.sendError(client, code, params)
+			return
+		}
+	}
+
+	gameRoom.mu.Lock()
+	gameRoom.room.Theme = theme
+	gameRoom.mu.Unlock()
+
+	log.Printf("%s changed the lobby theme of room %s", client.username, client.roomID)
+	s.broadcastLobby(client.roomID, gameRoom)
+}
+
+// checkThemePack vérifie que le pack du thème est proposé par le serveur de
+// ressources, pour que les joueurs puissent le télécharger, et qu'il
+// contient le morceau choisi
+func (s *Server) checkThemePack(theme *models.LobbyTheme) (string, i18n.Params) {
+	if s.config.Server.AssetsAddr != "" {
+		for _, manifest := range listAssetPacks(s.config.Server.AssetsDir) {
+			if manifest.Name != theme.Pack {
+				continue
+			}
+			if _, ok := manifest.Music[theme.Track]; theme.Track != "" && !ok {
+				return constants.ErrUnknownTrack, i18n.Params{"track": theme.Track}
+			}
+			return "", nil
+		}
+	}
+	return constants.ErrUnknownTheme, i18n.Params{"pack": theme.Pack}
+}
@@ -0,0 +1,253 @@
+// cmd/server/tutorial.go
+package main
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/moves"
+)
+
+// Coach des salles d'apprentissage: une IA facile qui commente la partie
+const (
+	tutorName  = "🎓 Coach"
+	tutorLevel = "easy"
+	// tutorID est négatif pour ne croiser aucun compte
+	tutorID int64 = -1
+)
+
+// tutorHelpWords déclenchent un rappel des règles quand l'élève les écrit
+var tutorHelpWords = []string{"help", "aide", "?"}
+
+// tutor réagit aux actions de l'élève par des conseils dans le chat. Chaque
+// conseil n'est donné qu'une fois, sauf le rappel demandé.
+type tutor struct {
+	mu        sync.Mutex
+	studentID int64
+	said      map[string]bool
+	say       func(code string, params i18n.Params)
+}
+
+func newTutor(studentID int64, say func(code string, params i18n.Params)) *tutor {
+	return &tutor{studentID: studentID, said: make(map[string]bool), say: say}
+}
+
+// once donne un conseil s'il n'a pas déjà été donné. t.mu doit être tenu.
+func (t *tutor) once(code string, params i18n.Params) {
+	if t.said[code] {
+		return
+	}
+	t.said[code] = true
+	t.say(code, params)
+}
+
+// turnChanged accueille l'élève à son premier tour et annonce le premier
+// tour du coach
+func (t *tutor) turnChanged(playerID int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if playerID == t.studentID {
+		t.once(constants.MsgTextTutorialWelcome, nil)
+	} else {
+		t.once(constants.MsgTextTutorialCoachTurn, nil)
+	}
+}
+
+// diceRolled explique le six: il faut en faire un pour sortir, et il
+// donne un nouveau lancer
+func (t *tutor) diceRolled(student *models.Player, playerID int64, value int, extraTurn bool) {
+	if playerID != t.studentID || student == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	six := i18n.Params{"six": constants.RollToStart}
+	inBase := allInBase(student)
+	switch {
+	case inBase && value != constants.RollToStart:
+		t.once(constants.MsgTextTutorialNeedSix, six)
+	case inBase:
+		t.once(constants.MsgTextTutorialSixOut, six)
+	case extraTurn:
+		t.once(constants.MsgTextTutorialExtraRoll, nil)
+	}
+}
+
+// tokenMoved commente les étapes du parcours d'un pion de l'élève
+func (t *tutor) tokenMoved(student *models.Player, playerID int64, from, to int) {
+	if playerID != t.studentID || student == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch {
+	case to == moves.Home:
+		left := len(student.Tokens) - pawnsHome(student)
+		if left > 0 {
+			t.say(constants.MsgTextTutorialPawnHome, i18n.Params{"left": left})
+		}
+	case to >= moves.StretchStart:
+		t.once(constants.MsgTextTutorialHomeLane, nil)
+	case from == moves.Base:
+		t.once(constants.MsgTextTutorialOnTrack, nil)
+	}
+}
+
+// tokenCaptured félicite l'élève, ou lui explique la prise qu'il a subie
+func (t *tutor) tokenCaptured(capturer, victim int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch t.studentID {
+	case capturer:
+		t.once(constants.MsgTextTutorialCapture, nil)
+	case victim:
+		t.once(constants.MsgTextTutorialCaptured, nil)
+	}
+}
+
+// timedOut rappelle le compte à rebours quand l'élève laisse filer son tour
+func (t *tutor) timedOut(playerID int64) {
+	if playerID != t.studentID {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.once(constants.MsgTextTutorialTimedOut, nil)
+}
+
+// gameOver conclut la leçon
+func (t *tutor) gameOver(winner *models.Player) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if winner != nil && winner.ID == t.studentID {
+		t.once(constants.MsgTextTutorialWon, nil)
+	} else if winner != nil {
+		t.once(constants.MsgTextTutorialLost, nil)
+	}
+}
+
+// chat répond aux demandes d'aide de l'élève
+func (t *tutor) chat(userID int64, text string) {
+	if userID != t.studentID {
+		return
+	}
+	text = strings.ToLower(strings.TrimSpace(text))
+	for _, word := range tutorHelpWords {
+		if strings.Contains(text, word) {
+			t.mu.Lock()
+			t.say(constants.MsgTextTutorialHelp, i18n.Params{"six": constants.RollToStart})
+			t.mu.Unlock()
+			return
+		}
+	}
+}
+
+// allInBase indique qu'aucun pion du joueur n'a quitté la base
+func allInBase(player *models.Player) bool {
+	for _, token := range player.Tokens {
+		if token.Position != moves.Base {
+			return false
+		}
+	}
+	return true
+}
+
+// pawnsHome compte les pions du joueur arrivés à la maison
+func pawnsHome(player *models.Player) int {
+	count := 0
+	for _, token := range player.Tokens {
+		if token.IsHome {
+			count++
+		}
+	}
+	return count
+}
+
+// student retourne l'élève d'une salle d'apprentissage, nil sinon
+func (gr *GameRoom) student() *models.Player {
+	if gr.tutor == nil {
+		return nil
+	}
+	for _, player := range gr.room.Players {
+		if player.ID == gr.tutor.studentID {
+			return player
+		}
+	}
+	return nil
+}
+
+// tutorSay envoie un conseil du coach dans le chat de la salle, traduit par
+// le client
+func (s *Server) tutorSay(roomID string) func(code string, params i18n.Params) {
+	return func(code string, params i18n.Params) {
+		s.broadcastToRoom(roomID, &models.NetworkMessage{
+			Type: constants.MsgChatMessage,
+			Payload: models.ChatPayload{
+				UserID:   tutorID,
+				Username: tutorName,
+				Text:     i18n.Render(i18n.Default, code, params),
+				Code:     code,
+				Params:   params,
+				SentAt:   time.Now(),
+			},
+			Timestamp: time.Now(),
+		})
+	}
+}
+
+// handleStartTutorial ouvre une salle d'apprentissage: l'élève contre le
+// coach, par le même chemin réseau qu'une vraie partie
+func (s *Server) handleStartTutorial(client *Client, msg *models.NetworkMessage) {
+	if s.refuseWhileDraining(client) {
+		return
+	}
+	s.leaveMatchmaking(client)
+
+	roomID := s.reserveRoomID()
+	room := &models.Room{
+		ID:         roomID,
+		Name:       "Tutorial",
+		HostID:     client.userID,
+		Players:    make([]*models.Player, 0, 2),
+		MaxPlayers: 2,
+		GameMode:   "online",
+		State:      constants.StateWaiting,
+		CreatedAt:  time.Now(),
+		IsPrivate:  true,
+		GameNumber: 1,
+		Series:     make(map[int64]int),
+		Tutorial:   true,
+	}
+
+	student := models.NewPlayer(client.userID, client.username, constants.FreeSeat(2, nil))
+	student.IsReady = true
+	coach := models.NewAIPlayer(constants.FreeSeat(2, map[constants.PlayerColor]bool{student.Color: true}), tutorLevel)
+	coach.ID = tutorID
+	coach.Username = tutorName
+	room.Players = append(room.Players, student, coach)
+
+	gameRoom := newGameRoom(room)
+	client.roomID = roomID
+	gameRoom.addClient(client)
+	gameRoom.tutor = newTutor(client.userID, s.tutorSay(roomID))
+	gameRoom.engine = s.newEngine(roomID, gameRoom, nil)
+	gameRoom.open(roomID)
+
+	s.mu.Lock()
+	s.rooms[roomID] = gameRoom
+	delete(s.pendingRoomIDs, roomID)
+	s.clients[client.userID] = client
+	s.mu.Unlock()
+
+	s.sendSessionToken(client)
+
+	log.Printf("🎓 Tutorial room %s started for %s", roomID, client.username)
+	s.startGame(roomID, gameRoom)
+}
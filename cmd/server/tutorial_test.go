@@ -0,0 +1,69 @@
+// cmd/server/tutorial_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/hooks"
+)
+
+func TestTutorReactsOnceToEachStep(t *testing.T) {
+	var said []string
+	coach := newTutor(1, func(code string, _ i18n.Params) { said = append(said, code) })
+	student := models.NewPlayer(1, "student", constants.ColorRed)
+
+	coach.turnChanged(1)
+	coach.diceRolled(student, 1, 3, false)
+	coach.diceRolled(student, 1, 2, false)
+	coach.turnChanged(tutorID)
+	coach.turnChanged(1)
+	coach.diceRolled(student, 1, constants.RollToStart, true)
+	student.Tokens[0].Position = constants.StartingPositions[student.Color]
+	coach.tokenMoved(student, 1, -1, student.Tokens[0].Position)
+	coach.diceRolled(student, 1, constants.RollToStart, true)
+	coach.tokenCaptured(tutorID, 1)
+	coach.tokenMoved(student, tutorID, -1, 0)
+	coach.chat(1, "Help please")
+	coach.chat(2, "help")
+
+	want := []string{
+		constants.MsgTextTutorialWelcome,
+		constants.MsgTextTutorialNeedSix,
+		constants.MsgTextTutorialCoachTurn,
+		constants.MsgTextTutorialSixOut,
+		constants.MsgTextTutorialOnTrack,
+		constants.MsgTextTutorialExtraRoll,
+		constants.MsgTextTutorialCaptured,
+		constants.MsgTextTutorialHelp,
+	}
+	if !reflect.DeepEqual(said, want) {
+		t.Errorf("coach said %v, want %v", said, want)
+	}
+}
+
+func TestTutorialChatCarriesCoachCodesOnly(t *testing.T) {
+	s, host, guest := newLobbyServer()
+	s.hooks = hooks.NewRegistry()
+	gameRoom := s.rooms[host.roomID]
+	gameRoom.tutor = newTutor(host.userID, s.tutorSay(host.roomID))
+
+	// Un joueur ne peut pas se faire passer pour le coach
+	s.handleChatMessage(guest, &models.NetworkMessage{Payload: models.ChatPayload{Text: "hi", Code: constants.MsgTextTutorialWon}})
+	if msg := lastMessage(host); msg == nil || msg.Payload.(models.ChatPayload).Code != "" {
+		t.Fatalf("player chat relayed as %+v", msg)
+	}
+
+	s.handleChatMessage(host, &models.NetworkMessage{Payload: models.ChatPayload{Text: "aide ?"}})
+	msg := lastMessage(guest)
+	if msg == nil {
+		t.Fatal("no coach reply")
+	}
+	chat := msg.Payload.(models.ChatPayload)
+	if chat.Code != constants.MsgTextTutorialHelp || chat.UserID != tutorID || chat.Text == "" {
+		t.Errorf("coach reply = %+v", chat)
+	}
+}
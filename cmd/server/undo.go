@@ -0,0 +1,36 @@
+// cmd/server/undo.go
+package main
+
+import (
+	"log"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// handleUndoMove reprend le dernier coup du joueur, si la salle joue avec
+// la règle maison du coup repris et que personne n'a relancé le dé depuis
+func (s *Server) handleUndoMove(client *Client, msg *models.NetworkMessage) {
+	s.mu.RLock()
+	gameRoom := s.rooms[client.roomID]
+	s.mu.RUnlock()
+
+	if gameRoom == nil {
+		s.sendError(client, constants.ErrRoomNotFound, nil)
+		return
+	}
+
+	gameRoom.mu.RLock()
+	ranked := gameRoom.room.Queue == constants.QueueRanked
+	gameRoom.mu.RUnlock()
+	if ranked || client.spectating {
+		s.sendError(client, constants.ErrUndoDisabled, nil)
+		return
+	}
+
+	if err := gameRoom.engine.Undo(client.userID); err != nil {
+		s.sendFailure(client, err, constants.ErrNothingToUndo)
+		return
+	}
+	log.Printf("%s took back a move in room %s", client.username, client.roomID)
+}
@@ -0,0 +1,101 @@
+// cmd/server/version.go
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+// outdatedCloseDelay laisse au client refusé le temps de lire la réponse
+const outdatedCloseDelay = 2 * time.Second
+
+// handleHello répond à la version annoncée par le client
+func (s *Server) handleHello(client *Client, msg *models.NetworkMessage) {
+	var hello models.HelloPayload
+	if err := protocol.ExtractPayload(msg.Payload, &hello); err != nil || hello.Version == "" {
+		hello.Version = "0.0.0"
+	}
+	client.version = truncate(hello.Version, 32)
+
+	welcome := s.welcomeFor(client.version)
+	if welcome.Update != constants.UpdateRequired {
+		welcome.Codec = negotiateCodec(client, hello)
+	}
+	s.sendMessage(client, &models.NetworkMessage{
+		Type:      constants.MsgWelcome,
+		Payload:   welcome,
+		Timestamp: time.Now(),
+	})
+
+	if welcome.Update == constants.UpdateRequired {
+		log.Printf("Rejecting client %s (%s/%s): below minimum version %s",
+			client.version, hello.OS, hello.Arch, welcome.MinVersion)
+		s.rejectOutdated(client)
+	}
+}
+
+// welcomeFor compare une version du client aux versions configurées
+func (s *Server) welcomeFor(version string) models.WelcomePayload {
+	updates := s.getConfig().Updates
+	welcome := models.WelcomePayload{
+		Update:        constants.UpdateNone,
+		MinVersion:    updates.MinVersion,
+		LatestVersion: updates.LatestVersion,
+		DownloadURL:   updates.DownloadURL,
+	}
+
+	// Le client traduit lui-même ces messages à partir des versions
+	switch {
+	case updates.MinVersion != "" && protocol.CompareVersions(version, updates.MinVersion) < 0:
+		welcome.Update = constants.UpdateRequired
+		welcome.Message = i18n.Render(i18n.Default, constants.ErrClientOutdated,
+			i18n.Params{"version": version, "min": updates.MinVersion})
+	case updates.LatestVersion != "" && protocol.CompareVersions(version, updates.LatestVersion) < 0:
+		welcome.Update = constants.UpdateAvailable
+		welcome.Message = i18n.Render(i18n.Default, constants.MsgTextUpdateAvailable,
+			i18n.Params{"latest": updates.LatestVersion, "version": version})
+	}
+	return welcome
+}
+
+// checkClientVersion refuse les messages d'un client trop ancien. Un client
+// qui n'a pas envoyé HELLO est antérieur à la vérification des versions.
+func (s *Server) checkClientVersion(client *Client) bool {
+	if client.outdated {
+		return false
+	}
+	if client.version != "" || s.getConfig().Updates.MinVersion == "" {
+		return true
+	}
+
+	// Ces clients n'affichent que le texte anglais: y joindre le lien
+	welcome := s.welcomeFor("unknown")
+	message := welcome.Message
+	if welcome.DownloadURL != "" {
+		message += " Download: " + welcome.DownloadURL
+	}
+	s.sendMessage(client, &models.NetworkMessage{
+		Type: constants.MsgError,
+		Payload: models.ErrorPayload{
+			Code:    constants.ErrClientOutdated,
+			Params:  i18n.Params{"version": "unknown", "min": welcome.MinVersion},
+			Message: message,
+		},
+		Timestamp: time.Now(),
+	})
+	s.rejectOutdated(client)
+	return false
+}
+
+// rejectOutdated ferme la connexion une fois la réponse envoyée
+func (s *Server) rejectOutdated(client *Client) {
+	client.outdated = true
+	time.AfterFunc(outdatedCloseDelay, func() {
+		client.conn.Close()
+	})
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"net"
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/protocol"
+)
+
+func TestWelcomeForVersion(t *testing.T) {
+	config := &Config{}
+	config.Updates.MinVersion = "1.2.0"
+	config.Updates.LatestVersion = "1.4.0"
+	s := &Server{config: config}
+
+	cases := map[string]string{
+		"1.1.9": constants.UpdateRequired,
+		"1.2.0": constants.UpdateAvailable,
+		"1.4.0": constants.UpdateNone,
+		"2.0.0": constants.UpdateNone,
+	}
+	for version, want := range cases {
+		if got := s.welcomeFor(version).Update; got != want {
+			t.Errorf("welcomeFor(%s) = %s, want %s", version, got, want)
+		}
+	}
+}
+
+func TestHelloNegotiatesBinaryCodec(t *testing.T) {
+	s := &Server{config: &Config{}}
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+	client := &Client{conn: conn, send: make(chan *models.NetworkMessage, 8)}
+
+	s.handleHello(client, &models.NetworkMessage{
+		Type:    constants.MsgHello,
+		Payload: models.HelloPayload{Version: constants.ClientVersion, Codecs: []string{protocol.CodecMsgPack}},
+	})
+	msg := lastMessage(client)
+	if msg == nil || msg.Payload.(models.WelcomePayload).Codec != protocol.CodecMsgPack {
+		t.Fatalf("Expected the welcome to switch to %s, got %+v", protocol.CodecMsgPack, msg)
+	}
+	if negotiatedCodec(msg) != protocol.CodecMsgPack {
+		t.Error("Expected the writer to switch codec after the welcome")
+	}
+
+	// Un client qui ne propose rien reste en JSON
+	s.handleHello(client, &models.NetworkMessage{
+		Type:    constants.MsgHello,
+		Payload: models.HelloPayload{Version: constants.ClientVersion},
+	})
+	if msg := lastMessage(client); negotiatedCodec(msg) != "" {
+		t.Errorf("Expected JSON for a client without codecs, got %q", negotiatedCodec(msg))
+	}
+}
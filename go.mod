@@ -5,6 +5,7 @@ go 1.25.6
 require (
 	fyne.io/fyne/v2 v2.7.2
 	github.com/go-sql-driver/mysql v1.9.3
+	golang.org/x/crypto v0.33.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -0,0 +1,99 @@
+// internal/client/audio/events.go
+package audio
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// Événements de partie qui déclenchent un effet sonore. Par défaut, chaque
+// événement joue le son du même nom.
+const (
+	EventDiceRoll     = "dice_roll"
+	EventTokenMove    = "token_move"
+	EventTokenCapture = "token_capture"
+	EventYourTurn     = "your_turn"
+	EventVictory      = "victory"
+	EventDefeat       = "defeat"
+)
+
+// SoundNone coupe le son d'un événement
+const SoundNone = "none"
+
+// Events liste les événements réglables, dans l'ordre des réglages
+var Events = []string{
+	EventDiceRoll,
+	EventTokenMove,
+	EventTokenCapture,
+	EventYourTurn,
+	EventVictory,
+	EventDefeat,
+}
+
+// SetEventSound choisit le son d'un événement: nom d'un son chargé, chemin
+// d'un fichier audio ou SoundNone. Une chaîne vide rétablit le son par défaut.
+func (m *Manager) SetEventSound(event, sound string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if sound == "" || sound == event {
+		delete(m.events, event)
+		return
+	}
+	m.events[event] = sound
+}
+
+// EventSound retourne le son joué pour un événement
+func (m *Manager) EventSound(event string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.eventSound(event)
+}
+
+func (m *Manager) eventSound(event string) string {
+	if sound, ok := m.events[event]; ok {
+		return sound
+	}
+	return event
+}
+
+// PlayEvent joue le son associé à un événement de partie. Un fichier
+// choisi par le joueur est chargé à sa première lecture.
+func (m *Manager) PlayEvent(event string) error {
+	m.mu.RLock()
+	sound := m.eventSound(event)
+	_, loaded := m.sounds[sound]
+	m.mu.RUnlock()
+
+	if sound == SoundNone {
+		return nil
+	}
+	if !loaded && IsSoundFile(sound) {
+		if err := m.LoadSound(sound, sound); err != nil {
+			return err
+		}
+	}
+	return m.PlaySound(sound)
+}
+
+// SoundNames retourne les noms des sons chargés, triés
+func (m *Manager) SoundNames() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	names := make([]string, 0, len(m.sounds))
+	for name := range m.sounds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsSoundFile indique si le son désigne un fichier plutôt qu'un son chargé
+func IsSoundFile(sound string) bool {
+	switch filepath.Ext(sound) {
+	case ".mp3", ".wav", ".ogg", ".flac":
+		return true
+	}
+	return false
+}
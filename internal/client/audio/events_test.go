@@ -0,0 +1,29 @@
+// internal/client/audio/events_test.go
+package audio
+
+import "testing"
+
+func TestEventSoundMapping(t *testing.T) {
+	m := NewManager()
+	if got := m.EventSound(EventDiceRoll); got != EventDiceRoll {
+		t.Errorf("default sound = %q, want %q", got, EventDiceRoll)
+	}
+
+	m.SetEventSound(EventDiceRoll, SoundNone)
+	if err := m.PlayEvent(EventDiceRoll); err != nil {
+		t.Errorf("silenced event should not fail: %v", err)
+	}
+
+	m.SetEventSound(EventDiceRoll, "/tmp/roll.wav")
+	if err := m.PlayEvent(EventDiceRoll); err != nil {
+		t.Fatalf("custom file should load on first play: %v", err)
+	}
+	if names := m.SoundNames(); len(names) != 1 || names[0] != "/tmp/roll.wav" {
+		t.Errorf("loaded sounds = %v, want the custom file", names)
+	}
+
+	m.SetEventSound(EventDiceRoll, "")
+	if got := m.EventSound(EventDiceRoll); got != EventDiceRoll {
+		t.Errorf("reset sound = %q, want %q", got, EventDiceRoll)
+	}
+}
@@ -4,12 +4,27 @@ package audio
 import (
 	"fmt"
 	"log"
+	"math"
 	"sync"
+	"time"
+)
+
+// Fondu enchaîné entre deux morceaux
+const (
+	DefaultCrossfade = 3 * time.Second
+	fadeSteps        = 30
 )
 
 // Manager gère tous les sons du jeu
 type Manager struct {
 	sounds      map[string]*Sound
+	events      map[string]string // Son choisi par événement, voir events.go
+	playlists   map[string]*Playlist
+	playlist    string             // Playlist en cours, "" pour un morceau seul
+	track       string             // Morceau en cours, "" sans musique
+	levels      map[string]float64 // Volume de chaque morceau audible
+	crossfade   time.Duration
+	fadeStop    chan struct{} // Arrête le fondu en cours
 	musicVolume float64
 	sfxVolume   float64
 	enabled     bool
@@ -27,6 +42,10 @@ type Sound struct {
 func NewManager() *Manager {
 	return &Manager{
 		sounds:      make(map[string]*Sound),
+		events:      make(map[string]string),
+		playlists:   make(map[string]*Playlist),
+		levels:      make(map[string]float64),
+		crossfade:   DefaultCrossfade,
 		musicVolume: 0.7,
 		sfxVolume:   0.8,
 		enabled:     true,
@@ -75,28 +94,192 @@ func (m *Manager) PlaySound(name string) error {
 	return nil
 }
 
-// PlayMusic joue de la musique de fond
+// PlayMusic joue un morceau seul, en fondu enchaîné avec la musique en
+// cours. Il remplace la playlist en cours.
 func (m *Manager) PlayMusic(name string, loop bool) error {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
 	if !m.enabled {
 		return nil
 	}
 
-	_, exists := m.sounds[name]
+	snd, exists := m.sounds[name]
 	if !exists {
 		return fmt.Errorf("music not found: %s", name)
 	}
 
 	log.Printf("🎵 Playing music: %s (loop: %v, volume: %.0f%%)", name, loop, m.musicVolume*100)
+	m.playlist = ""
+	m.crossfadeTo(snd.FilePath)
 	// TODO: Implémenter la lecture de musique en boucle
 
 	return nil
 }
 
+// SetPlaylists remplace les playlists disponibles (voir LoadPlaylists)
+func (m *Manager) SetPlaylists(playlists map[string]*Playlist) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.playlists = playlists
+}
+
+// SetShuffle mélange ou non les playlists, à partir de leur prochain tour
+func (m *Manager) SetShuffle(shuffle bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, playlist := range m.playlists {
+		playlist.Shuffle = shuffle
+	}
+}
+
+// SetCrossfade définit la durée du fondu enchaîné, 0 pour couper net
+func (m *Manager) SetCrossfade(duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if duration < 0 {
+		duration = 0
+	}
+	m.crossfade = duration
+}
+
+// PlayPlaylist passe à une playlist, en fondu depuis la musique en cours.
+// Rien ne change si elle est déjà en cours.
+func (m *Manager) PlayPlaylist(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.enabled || m.playlist == name {
+		return nil
+	}
+
+	playlist := m.playlists[name]
+	if playlist == nil || len(playlist.Tracks) == 0 {
+		return fmt.Errorf("playlist is empty: %s", name)
+	}
+
+	m.playlist = name
+	m.crossfadeTo(playlist.Next())
+	return nil
+}
+
+// NextTrack enchaîne le morceau suivant de la playlist en cours. La
+// lecture l'appelle à la fin de chaque morceau.
+func (m *Manager) NextTrack() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if playlist := m.playlists[m.playlist]; m.enabled && playlist != nil {
+		m.crossfadeTo(playlist.Next())
+	}
+}
+
+// CurrentTrack retourne le morceau en cours et sa playlist
+func (m *Manager) CurrentTrack() (track, playlist string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.track, m.playlist
+}
+
+// TrackVolume retourne le volume d'un morceau (0.0 - 1.0), fondu compris
+func (m *Manager) TrackVolume(track string) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.levels[track] * m.musicVolume
+}
+
+// CrossfadeGains retourne les gains du morceau sortant et du morceau
+// entrant après elapsed sur un fondu de total. Le fondu à puissance
+// constante évite le creux de volume d'un fondu linéaire.
+func CrossfadeGains(elapsed, total time.Duration) (out, in float64) {
+	if total <= 0 || elapsed >= total {
+		return 0, 1
+	}
+	if elapsed <= 0 {
+		return 1, 0
+	}
+	angle := float64(elapsed) / float64(total) * math.Pi / 2
+	return math.Cos(angle), math.Sin(angle)
+}
+
+// crossfadeTo lance le fondu vers track. Le verrou doit être détenu.
+func (m *Manager) crossfadeTo(track string) {
+	m.stopFade()
+	from := m.track
+	m.track = track
+	log.Printf("🎵 Now playing: %s", track)
+
+	// Un fondu interrompu laisse d'autres morceaux audibles: les couper
+	for name := range m.levels {
+		if name != from {
+			delete(m.levels, name)
+		}
+	}
+
+	if m.crossfade <= 0 || from == "" || from == track {
+		m.levels = map[string]float64{track: 1}
+		return
+	}
+
+	// Le morceau sortant part de son volume actuel (fondu interrompu)
+	level, ok := m.levels[from]
+	if !ok {
+		level = 1
+	}
+	m.levels[track] = 0
+	stop := make(chan struct{})
+	m.fadeStop = stop
+	total := m.crossfade
+	go func() {
+		ticker := time.NewTicker(total / fadeSteps)
+		defer ticker.Stop()
+		start := time.Now()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+
+			out, in := CrossfadeGains(time.Since(start), total)
+			m.mu.Lock()
+			if m.fadeStop != stop {
+				m.mu.Unlock()
+				return
+			}
+			m.levels[from], m.levels[track] = out*level, in
+			if in >= 1 {
+				delete(m.levels, from)
+				m.fadeStop = nil
+				m.mu.Unlock()
+				return
+			}
+			m.mu.Unlock()
+			// TODO: Appliquer les volumes à la lecture réelle
+		}
+	}()
+}
+
+// stopFade interrompt le fondu en cours. Le verrou doit être détenu.
+func (m *Manager) stopFade() {
+	if m.fadeStop != nil {
+		close(m.fadeStop)
+		m.fadeStop = nil
+	}
+}
+
 // StopMusic arrête la musique
 func (m *Manager) StopMusic() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stopMusic()
+}
+
+// stopMusic arrête la musique, verrou détenu
+func (m *Manager) stopMusic() {
+	m.stopFade()
+	m.track, m.playlist = "", ""
+	m.levels = make(map[string]float64)
 	log.Println("⏹️ Music stopped")
 	// TODO: Implémenter l'arrêt de la musique
 }
@@ -144,7 +327,7 @@ func (m *Manager) Disable() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.enabled = false
-	m.StopMusic()
+	m.stopMusic()
 	log.Println("🔇 Audio disabled")
 }
 
@@ -0,0 +1,92 @@
+// internal/client/audio/playlist.go
+package audio
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Playlists du jeu, lues dans les sous-dossiers du répertoire de musique
+const (
+	PlaylistMenu = "menu"
+	PlaylistGame = "game"
+)
+
+// Playlist enchaîne des morceaux, dans l'ordre ou mélangés
+type Playlist struct {
+	Name    string
+	Tracks  []string
+	Shuffle bool
+
+	order []int
+	pos   int
+	last  int // Dernier morceau joué, -1 avant le premier
+	rand  *rand.Rand
+}
+
+// NewPlaylist crée une playlist à partir des chemins des morceaux
+func NewPlaylist(name string, tracks []string, shuffle bool) *Playlist {
+	return &Playlist{
+		Name:    name,
+		Tracks:  tracks,
+		Shuffle: shuffle,
+		last:    -1,
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Next retourne le morceau suivant, "" pour une playlist vide. En mode
+// mélangé, chaque morceau passe une fois par tour et un nouveau tour ne
+// recommence pas par le morceau qui vient de finir.
+func (p *Playlist) Next() string {
+	if len(p.Tracks) == 0 {
+		return ""
+	}
+	if p.pos >= len(p.order) || len(p.order) != len(p.Tracks) {
+		p.reorder()
+	}
+	index := p.order[p.pos]
+	p.pos++
+	p.last = index
+	return p.Tracks[index]
+}
+
+// reorder prépare un nouveau tour de la playlist
+func (p *Playlist) reorder() {
+	p.order = make([]int, len(p.Tracks))
+	for i := range p.order {
+		p.order[i] = i
+	}
+	p.pos = 0
+	if !p.Shuffle {
+		return
+	}
+	p.rand.Shuffle(len(p.order), func(i, j int) {
+		p.order[i], p.order[j] = p.order[j], p.order[i]
+	})
+	if len(p.order) > 1 && p.order[0] == p.last {
+		p.order[0], p.order[1] = p.order[1], p.order[0]
+	}
+}
+
+// LoadPlaylists lit les playlists du répertoire de musique: un
+// sous-dossier par playlist (menu, game) contenant des fichiers audio.
+// Un dossier absent donne une playlist vide.
+func LoadPlaylists(dir string, shuffle bool) map[string]*Playlist {
+	playlists := make(map[string]*Playlist)
+	for _, name := range []string{PlaylistMenu, PlaylistGame} {
+		entries, _ := os.ReadDir(filepath.Join(dir, name))
+		var tracks []string
+		for _, entry := range entries {
+			if !entry.IsDir() && IsSoundFile(entry.Name()) {
+				tracks = append(tracks, filepath.Join(dir, name, entry.Name()))
+			}
+		}
+		sort.Strings(tracks)
+		playlists[name] = NewPlaylist(name, tracks, shuffle)
+	}
+	return playlists
+}
@@ -0,0 +1,71 @@
+// internal/client/audio/playlist_test.go
+package audio
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPlaylistInOrder(t *testing.T) {
+	p := NewPlaylist(PlaylistMenu, []string{"a.mp3", "b.mp3"}, false)
+	for i, want := range []string{"a.mp3", "b.mp3", "a.mp3"} {
+		if got := p.Next(); got != want {
+			t.Fatalf("track %d = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestPlaylistShufflePlaysEachTrackOncePerRound(t *testing.T) {
+	tracks := []string{"a.mp3", "b.mp3", "c.mp3", "d.mp3"}
+	p := NewPlaylist(PlaylistGame, tracks, true)
+
+	previous := ""
+	for round := 0; round < 20; round++ {
+		seen := make(map[string]bool)
+		for range tracks {
+			track := p.Next()
+			if seen[track] {
+				t.Fatalf("round %d played %q twice", round, track)
+			}
+			if track == previous {
+				t.Fatalf("round %d repeated %q back to back", round, track)
+			}
+			seen[track] = true
+			previous = track
+		}
+	}
+}
+
+func TestCrossfadeGainsKeepConstantPower(t *testing.T) {
+	total := 2 * time.Second
+	if out, in := CrossfadeGains(0, total); out != 1 || in != 0 {
+		t.Errorf("start gains = %.2f/%.2f, want 1/0", out, in)
+	}
+	if out, in := CrossfadeGains(total, total); out != 0 || in != 1 {
+		t.Errorf("end gains = %.2f/%.2f, want 0/1", out, in)
+	}
+	out, in := CrossfadeGains(total/2, total)
+	if power := out*out + in*in; math.Abs(power-1) > 1e-9 {
+		t.Errorf("power at midpoint = %.3f, want 1", power)
+	}
+}
+
+func TestPlayPlaylistSwitchesTrack(t *testing.T) {
+	m := NewManager()
+	m.SetCrossfade(0)
+	m.SetPlaylists(map[string]*Playlist{
+		PlaylistMenu: NewPlaylist(PlaylistMenu, []string{"menu.mp3"}, false),
+		PlaylistGame: NewPlaylist(PlaylistGame, nil, false),
+	})
+
+	if err := m.PlayPlaylist(PlaylistMenu); err != nil {
+		t.Fatal(err)
+	}
+	if track, playlist := m.CurrentTrack(); track != "menu.mp3" || playlist != PlaylistMenu {
+		t.Errorf("playing %q from %q, want menu.mp3 from the menu playlist", track, playlist)
+	}
+	if err := m.PlayPlaylist(PlaylistGame); err == nil {
+		t.Error("an empty playlist should be reported")
+	}
+}
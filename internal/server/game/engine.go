@@ -2,25 +2,48 @@
 package game
 
 import (
-	"fmt"
-	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
 	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/moves"
 	"github.com/obrien-tchaleu/ludo-king-go/pkg/ai"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/dice"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/rules"
 )
 
 // Engine gère la logique du jeu
 type Engine struct {
-	game      *models.Game
-	ai        map[int64]*ai.AIPlayer // IA par joueur
-	mu        sync.RWMutex
-	rand      *rand.Rand
-	turnTimer *time.Timer
-	callbacks EngineCallbacks
-	rollCount map[int64]int // Compte les lancers par joueur
+	game        *models.Game
+	ai          map[int64]*ai.AIPlayer // IA par joueur
+	mu          sync.RWMutex
+	dice        *dice.Dice // Lancers de la partie, avec graine pour rejouer une partie
+	turnTimer   *time.Timer
+	turnTimeout time.Duration // Durée d'un tour humain
+	deadline    time.Time     // Fin du tour humain en cours, zéro pendant un tour d'IA
+	callbacks   EngineCallbacks
+	stalled     int               // Tours consécutifs sans aucun mouvement possible
+	rolled      bool              // Le joueur courant a lancé le dé et doit jouer
+	spin        *dice.Spin        // Sélecteur en cours (dé à viser)
+	actionStart time.Time         // Début de la réflexion du joueur courant
+	rules       *rules.Script     // Variante choisie par l'hôte, nil = règles standard
+	starter     int               // Index du premier joueur imposé, -1 = tirage au dé
+	ruleSet     RuleSet           // Forme de la partie, choisie à la création de la salle
+	house       models.RuleConfig // Règles maison, valeur zéro = règles classiques
+	prepared    bool              // Pions mis en place, voir Prepare
+	rebuilding  bool              // Partie reconstruite, voir Rebuild: ni minuteur ni IA
+	undo        *undoPoint        // Dernier coup annulable (règle maison), voir Undo
+
+	// Pause demandée par l'hôte, voir Pause
+	paused      bool
+	pausedAt    time.Time
+	pausedLeft  time.Duration // Temps qui restait au joueur humain courant
+	turnPending bool          // Le tour a changé pendant la pause: il commence à la reprise
+	pauses      int           // Numéro de la pause en cours, pour la reprise automatique
+	pauseTimer  *time.Timer
 }
 
 // EngineCallbacks définit les callbacks pour les événements du jeu
@@ -28,14 +51,20 @@ type EngineCallbacks struct {
 	OnDiceRolled    func(playerID int64, value int, extraTurn bool)
 	OnTokenMoved    func(playerID int64, token *models.Token, from, to int)
 	OnTokenCaptured func(capturer, victim int64, token *models.Token, pos int)
+	OnRollOff       func(rounds [][]models.RollOffRoll, starterID int64)
 	OnTurnChanged   func(playerID int64)
-	OnGameOver      func(winner *models.Player, rankings []*models.Player)
+	OnTurnTimer     func(playerID int64, deadline time.Time)
+	OnTurnTimedOut  func(playerID int64)
+	OnGameOver      func(winner *models.Player, rankings []*models.Player, reason string)
+	// OnPaused signale une pause (resumesAt: reprise automatique) ou une reprise
+	OnPaused func(paused bool, resumesAt time.Time)
+	// OnMoveUndone signale un coup repris: le joueur rejoue avec le même dé
+	OnMoveUndone func(playerID int64, dice int)
 }
 
-// NewEngine crée un nouveau moteur de jeu
-func NewEngine(room *models.Room, callbacks EngineCallbacks) *Engine {
+// NewEngine crée un nouveau moteur de jeu, sous les règles maison données
+func NewEngine(room *models.Room, house models.RuleConfig, callbacks EngineCallbacks) *Engine {
 	board := models.NewBoard()
-
 	engine := &Engine{
 		game: &models.Game{
 			Room:        room,
@@ -44,18 +73,31 @@ func NewEngine(room *models.Room, callbacks EngineCallbacks) *Engine {
 			StartTime:   time.Now(),
 			Rankings:    make([]*models.Player, 0),
 		},
-		ai:        make(map[int64]*ai.AIPlayer),
-		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
-		callbacks: callbacks,
-		rollCount: make(map[int64]int),
+		ai:          make(map[int64]*ai.AIPlayer),
+		dice:        dice.New(),
+		turnTimeout: time.Duration(constants.TurnTimeout) * time.Second,
+		callbacks:   callbacks,
+		starter:     -1,
+		house:       house,
+	}
+	if rs, ok := LookupRuleSet(room.RuleSet); ok {
+		engine.ruleSet = rs
+	} else {
+		engine.ruleSet = ruleSets[constants.RuleSetClassic]
 	}
 
-	// Initialiser les IA si nécessaire
+	// Empiler les pions déjà sortis et initialiser les IA si nécessaire
 	for _, player := range room.Players {
+		for _, token := range player.Tokens {
+			if cell := engine.cellAt(player.Color, token.Position); cell != nil {
+				cell.Add(token)
+			}
+		}
 		if player.IsAI {
-			engine.ai[player.ID] = ai.NewAIPlayer(player.AILevel)
+			bot := ai.NewAIPlayer(player.AILevel)
+			bot.House = house
+			engine.ai[player.ID] = bot
 		}
-		engine.rollCount[player.ID] = 0
 	}
 
 	return engine
@@ -67,19 +109,31 @@ func (e *Engine) Start() error {
 	defer e.mu.Unlock()
 
 	if e.game.Room.State != constants.StateWaiting {
-		return fmt.Errorf("game already started")
+		return i18n.NewError(constants.ErrGameStarted, nil)
 	}
 
 	// Vérifier le nombre de joueurs
 	if len(e.game.Room.Players) < constants.MinPlayers {
-		return fmt.Errorf("not enough players")
+		return i18n.NewError(constants.ErrNotEnoughPlayers, i18n.Params{"min": constants.MinPlayers})
 	}
 
-	// Choisir un joueur aléatoire pour commencer
-	e.game.Room.CurrentTurn = e.rand.Intn(len(e.game.Room.Players))
+	e.prepare()
+
+	// Tirage au dé: le plus haut lancer commence, sauf premier joueur imposé
+	if e.starter >= 0 && e.starter < len(e.game.Room.Players) {
+		e.game.Room.CurrentTurn = e.starter
+	} else {
+		rounds, starter := e.rollOff()
+		e.game.Room.CurrentTurn = starter
+		if e.callbacks.OnRollOff != nil {
+			e.callbacks.OnRollOff(rounds, e.game.Room.Players[starter].ID)
+		}
+	}
+	e.game.FirstTurn = e.game.Room.CurrentTurn
 	e.game.Room.State = constants.StatePlaying
 	now := time.Now()
 	e.game.Room.StartedAt = &now
+	e.actionStart = now
 
 	// Notifier le premier joueur
 	currentPlayer := e.game.Room.Players[e.game.Room.CurrentTurn]
@@ -87,60 +141,155 @@ func (e *Engine) Start() error {
 		e.callbacks.OnTurnChanged(currentPlayer.ID)
 	}
 
+	if e.rebuilding {
+		return nil
+	}
+
 	// Si c'est une IA, lancer automatiquement
 	if currentPlayer.IsAI {
+		e.deadline = time.Time{}
 		go e.handleAITurn(currentPlayer)
 	} else {
-		e.startTurnTimer(currentPlayer.ID)
+		e.startTurnTimer(currentPlayer.ID, e.turnTimeout)
 	}
 
 	return nil
 }
 
-// RollDice lance le dé pour un joueur (avec système de dés truqués)
+// rollOff fait lancer le dé à tous les joueurs; les ex aequo au plus haut
+// lancer relancent jusqu'à ce qu'un seul reste. Retourne les manches et
+// l'index du joueur qui commence.
+func (e *Engine) rollOff() ([][]models.RollOffRoll, int) {
+	contenders := make([]int, len(e.game.Room.Players))
+	for i := range contenders {
+		contenders[i] = i
+	}
+
+	var rounds [][]models.RollOffRoll
+	for {
+		round := make([]models.RollOffRoll, 0, len(contenders))
+		best, leaders := 0, []int(nil)
+		for _, index := range contenders {
+			value := e.dice.Roll()
+			round = append(round, models.RollOffRoll{PlayerID: e.game.Room.Players[index].ID, Value: value})
+			switch {
+			case value > best:
+				best, leaders = value, []int{index}
+			case value == best:
+				leaders = append(leaders, index)
+			}
+		}
+		rounds = append(rounds, round)
+
+		if len(leaders) == 1 {
+			return rounds, leaders[0]
+		}
+		contenders = leaders
+	}
+}
+
+// RollDice lance le dé pour un joueur
 func (e *Engine) RollDice(playerID int64) (int, bool, error) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	// Vérifier que c'est le tour du joueur
+	// Vérifier que c'est le tour du joueur et qu'il n'a pas déjà lancé
+	if err := e.checkCanRoll(playerID); err != nil {
+		return 0, false, err
+	}
 	currentPlayer := e.game.Room.Players[e.game.Room.CurrentTurn]
-	if currentPlayer.ID != playerID {
-		return 0, false, fmt.Errorf(constants.ErrNotYourTurn)
+
+	diceValue := e.dice.Roll()
+	return diceValue, e.applyRoll(currentPlayer, diceValue), nil
+}
+
+// StartSpin démarre le sélecteur du dé à viser pour le joueur courant
+func (e *Engine) StartSpin(playerID int64) (*dice.Spin, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.checkCanRoll(playerID); err != nil {
+		return nil, err
 	}
 
-	// Incrémenter le compteur de lancers pour ce joueur
-	e.rollCount[playerID]++
-	rollNumber := e.rollCount[playerID]
+	spin, err := dice.NewSpin(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	e.spin = spin
+	return spin, nil
+}
 
-	var diceValue int
+// StopSpin arrête le sélecteur et joue la face obtenue.
+// Retourne la durée retenue par le serveur et la valeur du dé.
+func (e *Engine) StopSpin(playerID int64, claimed time.Duration) (*dice.Spin, time.Duration, int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 
-	// 🎲 SYSTÈME DE DÉS TRUQUÉS
-	// Premier lancer OU tous les 5 lancers = 6 automatique
-	if rollNumber == 1 || rollNumber%5 == 0 {
-		diceValue = 6
-	} else {
-		// Lancer normal
-		diceValue = e.rand.Intn(constants.DiceMax) + constants.DiceMin
+	if err := e.checkCanRoll(playerID); err != nil {
+		return nil, 0, 0, err
+	}
+	if e.spin == nil {
+		return nil, 0, 0, i18n.NewError(constants.ErrNoSpin, nil)
 	}
 
+	spin := e.spin
+	e.spin = nil
+	elapsed, value := spin.Stop(claimed, time.Now())
+
+	currentPlayer := e.game.Room.Players[e.game.Room.CurrentTurn]
+	e.applyRoll(currentPlayer, value)
+
+	return spin, elapsed, value, nil
+}
+
+// checkCanRoll vérifie que le joueur peut lancer le dé maintenant
+func (e *Engine) checkCanRoll(playerID int64) error {
+	if e.game.Room.State != constants.StatePlaying {
+		return i18n.NewError(constants.ErrGameNotStarted, nil)
+	}
+	currentPlayer := e.game.Room.Players[e.game.Room.CurrentTurn]
+	if currentPlayer.ID != playerID {
+		return i18n.NewError(constants.ErrNotYourTurn, nil)
+	}
+	if e.paused && !currentPlayer.IsAI {
+		return i18n.NewError(constants.ErrGamePaused, nil)
+	}
+	if e.rolled {
+		return i18n.NewError(constants.ErrDiceRolled, nil)
+	}
+	return nil
+}
+
+// applyRoll applique une valeur de dé au joueur courant et retourne
+// s'il rejoue
+func (e *Engine) applyRoll(currentPlayer *models.Player, diceValue int) bool {
+	playerID := currentPlayer.ID
 	e.game.Room.LastDice = diceValue
+	e.rolled = true
+	e.undo = nil
+
+	// Journal des lancers: une action sans pion déplacé, pour que les
+	// joueurs vérifient l'équité du dé après la partie
+	e.game.TurnHistory = append(e.game.TurnHistory, models.TurnAction{
+		PlayerID:  playerID,
+		DiceValue: diceValue,
+		Timestamp: time.Now(),
+	})
 
-	// Vérifier les 6 consécutifs (règle des 3 six)
-	extraTurn := false
-	if diceValue == constants.RollForExtraTurn {
-		currentPlayer.ConsecutiveSix++
-		if currentPlayer.ConsecutiveSix >= constants.MaxConsecutiveSix {
-			// Perdre le tour après 3 six consécutifs
-			currentPlayer.ConsecutiveSix = 0
-			e.nextTurn()
-			if e.callbacks.OnDiceRolled != nil {
-				e.callbacks.OnDiceRolled(playerID, diceValue, false)
-			}
-			return diceValue, false, nil
+	// Vérifier les 6 consécutifs (règle des 3 six). Sans la pénalité, le
+	// troisième six fait rejouer comme les autres.
+	extraTurn, forfeit := moves.Roll(currentPlayer, diceValue)
+	if forfeit && e.house.NoTripleSix {
+		extraTurn, forfeit = true, false
+	}
+	if forfeit {
+		// Perdre le tour après 3 six consécutifs
+		e.nextTurn()
+		if e.callbacks.OnDiceRolled != nil {
+			e.callbacks.OnDiceRolled(playerID, diceValue, false)
 		}
-		extraTurn = true
-	} else {
-		currentPlayer.ConsecutiveSix = 0
+		return false
 	}
 
 	// Vérifier si le joueur peut jouer
@@ -149,6 +298,9 @@ func (e *Engine) RollDice(playerID int64) (int, bool, error) {
 		// Pas de mouvement possible, tour suivant
 		if !extraTurn {
 			e.nextTurn()
+		} else {
+			e.rolled = false
+			e.actionStart = time.Now()
 		}
 	}
 
@@ -156,41 +308,74 @@ func (e *Engine) RollDice(playerID int64) (int, bool, error) {
 		e.callbacks.OnDiceRolled(playerID, diceValue, extraTurn)
 	}
 
-	return diceValue, extraTurn, nil
+	return extraTurn
 }
 
 // MoveToken déplace un token
 func (e *Engine) MoveToken(playerID int64, tokenID int) error {
 	e.mu.Lock()
 	defer e.mu.Unlock()
+	return e.moveToken(playerID, tokenID)
+}
+
+func (e *Engine) moveToken(playerID int64, tokenID int) error {
+	if e.game.Room.State != constants.StatePlaying {
+		return i18n.NewError(constants.ErrGameNotStarted, nil)
+	}
 
 	currentPlayer := e.game.Room.Players[e.game.Room.CurrentTurn]
 	if currentPlayer.ID != playerID {
-		return fmt.Errorf(constants.ErrNotYourTurn)
+		return i18n.NewError(constants.ErrNotYourTurn, nil)
+	}
+	if e.paused && !currentPlayer.IsAI {
+		return i18n.NewError(constants.ErrGamePaused, nil)
+	}
+
+	// Un déplacement anticipé (premove) ne peut précéder le lancer du joueur
+	if !e.rolled {
+		return i18n.NewError(constants.ErrDiceNotRolled, nil)
 	}
 
 	if tokenID < 0 || tokenID >= len(currentPlayer.Tokens) {
-		return fmt.Errorf("invalid token id")
+		return i18n.NewError(constants.ErrInvalidToken, nil)
 	}
 
 	token := currentPlayer.Tokens[tokenID]
 	diceValue := e.game.Room.LastDice
 
 	// Valider le mouvement
-	if !e.canMoveToken(token, diceValue, currentPlayer.Color) {
-		return fmt.Errorf(constants.ErrInvalidMove)
+	if !e.canMoveToken(currentPlayer, token, diceValue) {
+		return e.moveRejection(currentPlayer, token, diceValue)
 	}
 
 	oldPos := token.Position
 	newPos := e.calculateNewPosition(token, diceValue, currentPlayer.Color)
+	undo := e.saveUndoPoint(currentPlayer)
+
+	// Les règles de la variante voient le coup avant qu'il soit joué
+	env := e.moveEnv(currentPlayer, token, diceValue, newPos)
+	standardExtra := diceValue == constants.RollForExtraTurn ||
+		(e.house.CaptureBonus && e.victimAt(newPos, currentPlayer) != nil)
+	extraTurn := e.rules.Bool(rules.RuleExtraTurn, env, standardExtra)
+	currentPlayer.Score += e.rules.Int(rules.RuleScore, env, 0)
+
+	// Passer l'entrée de la maison consomme un tour supplémentaire
+	if token.Laps > 0 && moves.CrossesHomeEntry(currentPlayer.Color, token.Position, diceValue) {
+		token.Laps--
+	}
 
 	// Effectuer le déplacement
 	e.moveTokenToPosition(token, newPos, currentPlayer.Color)
 
 	// Vérifier capture
-	captured := e.checkCapture(newPos, currentPlayer)
+	captured := e.checkCapture(newPos, oldPos, currentPlayer)
+
+	// Enregistrer l'action et le temps de réflexion
+	now := time.Now()
+	elapsed := now.Sub(e.actionStart).Milliseconds()
+	currentPlayer.Decisions++
+	currentPlayer.DecisionMs += elapsed
 
-	// Enregistrer l'action
 	action := models.TurnAction{
 		PlayerID:   playerID,
 		DiceValue:  diceValue,
@@ -198,7 +383,8 @@ func (e *Engine) MoveToken(playerID int64, tokenID int) error {
 		FromPos:    oldPos,
 		ToPos:      newPos,
 		Captured:   captured,
-		Timestamp:  time.Now(),
+		DurationMs: elapsed,
+		Timestamp:  now,
 	}
 	e.game.TurnHistory = append(e.game.TurnHistory, action)
 
@@ -225,198 +411,506 @@ func (e *Engine) MoveToken(playerID int64, tokenID int) error {
 		return nil
 	}
 
-	// Tour suivant si pas de 6
-	if diceValue != constants.RollForExtraTurn {
+	// Tour suivant si pas de 6 (ou selon la variante)
+	if !extraTurn {
 		e.nextTurn()
+	} else {
+		e.rolled = false
+		e.actionStart = now
+	}
+	if e.game.Room.State == constants.StatePlaying {
+		e.undo = undo
 	}
 
 	return nil
 }
 
+// FastestPlayer retourne le joueur humain au temps de réflexion moyen le
+// plus court, ou nil si aucun humain n'a joué
+func FastestPlayer(players []*models.Player) *models.Player {
+	var fastest *models.Player
+	for _, player := range players {
+		if player.IsAI || player.Decisions == 0 {
+			continue
+		}
+		if fastest == nil || player.AvgDecision() < fastest.AvgDecision() {
+			fastest = player
+		}
+	}
+	return fastest
+}
+
 // hasValidMove vérifie si le joueur a un mouvement valide
 func (e *Engine) hasValidMove(player *models.Player, diceValue int) bool {
 	for _, token := range player.Tokens {
-		if e.canMoveToken(token, diceValue, player.Color) {
+		if e.canMoveToken(player, token, diceValue) {
 			return true
 		}
 	}
 	return false
 }
 
-// canMoveToken vérifie si un token peut bouger
-func (e *Engine) canMoveToken(token *models.Token, diceValue int, color constants.PlayerColor) bool {
-	if token.IsHome {
-		return false
+// canMoveToken vérifie si un token peut bouger. Une variante peut assouplir
+// ou durcir la règle de sortie de base et la légalité du coup, mais jamais
+// autoriser un dépassement ou un empilement sur son propre pion.
+func (e *Engine) canMoveToken(player *models.Player, token *models.Token, diceValue int) bool {
+	newPos, standard := moves.LegalWith(e.house, e.game.Room.Players, player, token, diceValue)
+	if !e.rules.Has(rules.RuleCanMove) {
+		return standard
 	}
 
-	if token.Position == -1 && diceValue != constants.RollToStart {
-		return false
+	if !standard {
+		// Seule la sortie de base sans 6 reste à l'appréciation de la variante
+		if token.Position != moves.Base {
+			return false
+		}
+		var free bool
+		if newPos, free = moves.LegalWith(e.house, e.game.Room.Players, player, token, constants.RollToStart); !free {
+			return false
+		}
 	}
+	return e.rules.Bool(rules.RuleCanMove, e.moveEnv(player, token, diceValue, newPos), standard)
+}
 
-	newPos := e.calculateNewPosition(token, diceValue, color)
+// moveRejection précise au joueur pourquoi son coup est refusé. Un coup
+// légal sans la variante n'a pu être refusé que par elle.
+func (e *Engine) moveRejection(player *models.Player, token *models.Token, diceValue int) error {
+	code, params := moves.RejectionWith(e.house, e.game.Room.Players, player, token, diceValue)
+	if code == "" {
+		return i18n.NewError(constants.ErrInvalidMove, nil)
+	}
+	return i18n.NewError(code, params)
+}
 
-	// Vérifier dépassement
-	if newPos > 57 {
-		return false
+// moveEnv décrit un coup pour les règles de la variante
+func (e *Engine) moveEnv(player *models.Player, token *models.Token, diceValue, newPos int) rules.Env {
+	captures, safe := e.victimAt(newPos, player) != nil, true
+	if newPos >= 0 && newPos < moves.StretchStart {
+		safe = e.game.Board.Cells[newPos].IsSafe
 	}
 
-	// Vérifier collision avec son propre token
-	if newPos >= 52 {
-		homeIdx := newPos - 52
-		if e.game.Board.HomeStretches[color][homeIdx].Token != nil {
-			return false
-		}
-	} else {
-		cell := e.game.Board.Cells[newPos]
-		if cell.Token != nil && cell.Token.Color == color {
-			return false
+	inBase := 0
+	for _, t := range player.Tokens {
+		if t.Position == -1 {
+			inBase++
 		}
 	}
 
-	return true
+	return rules.Env{
+		"dice":            rules.IntValue(diceValue),
+		"from":            rules.IntValue(token.Position),
+		"to":              rules.IntValue(newPos),
+		"from_base":       rules.BoolValue(token.Position == -1),
+		"to_home_stretch": rules.BoolValue(newPos >= moves.StretchStart),
+		"reaches_home":    rules.BoolValue(newPos == moves.Home),
+		"captures":        rules.BoolValue(captures),
+		"safe":            rules.BoolValue(safe),
+		"consecutive_six": rules.IntValue(player.ConsecutiveSix),
+		"tokens_home":     rules.IntValue(tokensHome(player)),
+		"tokens_in_base":  rules.IntValue(inBase),
+	}
+}
+
+// SetStarter impose le premier joueur (index dans la salle) au lieu du
+// tirage au dé, par exemple pour alterner dans un match
+func (e *Engine) SetStarter(index int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.starter = index
+}
+
+// SetRules applique une variante à la partie, nil pour les règles standard
+func (e *Engine) SetRules(script *rules.Script) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rules = script
 }
 
-// calculateNewPosition calcule la nouvelle position
+// calculateNewPosition calcule la nouvelle position d'un coup déjà validé.
+// Une sortie de base autorisée par la variante mène au départ quel que soit
+// le dé, et un dépassement permis par les règles maison à la maison.
 func (e *Engine) calculateNewPosition(token *models.Token, diceValue int, color constants.PlayerColor) int {
-	if token.Position == -1 {
+	if token.Position == moves.Base {
 		return constants.StartingPositions[color]
 	}
+	newPos, ok := moves.Target(color, token.Position, diceValue, token.Laps)
+	if !ok {
+		return moves.Home
+	}
+	return newPos
+}
 
-	newPos := token.Position + diceValue
-	homeEntry := constants.HomeStretchStart[color]
+// Prepare met les pions en place selon le jeu de règles et les handicaps.
+// Start s'en charge s'il n'a pas été appelé; l'appeler avant permet
+// d'envoyer le plateau de départ avant le tirage au dé.
+func (e *Engine) Prepare() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.prepare()
+}
 
-	// Vérifier entrée dans la zone maison
-	if token.Position < homeEntry && newPos >= homeEntry {
-		overflow := newPos - homeEntry
-		return 52 + overflow
+func (e *Engine) prepare() {
+	if e.prepared || e.game.Room.State != constants.StateWaiting {
+		return
 	}
-
-	// Boucler sur le plateau
-	if newPos >= 52 && token.Position < 52 {
-		newPos = newPos % 52
+	e.prepared = true
+	for _, player := range e.game.Room.Players {
+		e.ruleSet.setup(e, player)
 	}
+	e.applyHandicaps()
+}
 
-	return newPos
+// applyHandicaps met en place les handicaps de départ choisis dans le lobby
+func (e *Engine) applyHandicaps() {
+	for _, player := range e.game.Room.Players {
+		switch player.Handicap {
+		case constants.HandicapHeadStart:
+			// Le premier pion encore en base: une partie rapide en sort déjà un
+			for _, token := range player.Tokens {
+				if token.Position == -1 {
+					e.moveTokenToPosition(token, constants.StartingPositions[player.Color], player.Color)
+					break
+				}
+			}
+		case constants.HandicapExtraLap:
+			for _, token := range player.Tokens {
+				token.Laps = 1
+			}
+		}
+	}
 }
 
 // moveTokenToPosition déplace effectivement le token
 func (e *Engine) moveTokenToPosition(token *models.Token, newPos int, color constants.PlayerColor) {
-	// Retirer de l'ancienne position
-	if token.Position >= 0 && token.Position < 52 {
-		e.game.Board.Cells[token.Position].Token = nil
-	} else if token.Position >= 52 {
-		homeIdx := token.Position - 52
-		e.game.Board.HomeStretches[color][homeIdx].Token = nil
+	// Retirer de la pile de l'ancienne case, où d'autres pions peuvent rester
+	if cell := e.cellAt(color, token.Position); cell != nil {
+		cell.Remove(token)
 	}
 
 	// Placer à la nouvelle position
 	token.Position = newPos
-	if newPos >= 52 {
-		homeIdx := newPos - 52
-		if homeIdx >= 6 {
-			token.IsHome = true
-		} else {
-			e.game.Board.HomeStretches[color][homeIdx].Token = token
-		}
-	} else {
-		e.game.Board.Cells[newPos].Token = token
-		token.IsSafe = e.game.Board.Cells[newPos].IsSafe
+	switch newPos {
+	case moves.Base:
+		token.IsHome = false
+		token.IsSafe = true
+	case moves.Home:
+		token.IsHome = true
+	}
+	if cell := e.cellAt(color, newPos); cell != nil {
+		cell.Add(token)
+		token.IsSafe = cell.IsSafe
 	}
 }
 
-// checkCapture vérifie et effectue une capture
-func (e *Engine) checkCapture(pos int, capturer *models.Player) *models.Token {
-	if pos < 0 || pos >= 52 {
-		return nil
+// cellAt retourne la case du plateau d'une position d'un pion de la
+// couleur, nil pour la base et la maison
+func (e *Engine) cellAt(color constants.PlayerColor, pos int) *models.Cell {
+	switch {
+	case pos >= 0 && pos < moves.StretchStart:
+		return e.game.Board.Cells[pos]
+	case pos >= moves.StretchStart && pos < moves.Home:
+		return e.game.Board.HomeStretches[color][pos-moves.StretchStart]
 	}
+	return nil
+}
 
-	cell := e.game.Board.Cells[pos]
-	if cell.Token == nil || cell.IsSafe {
+// checkCapture vérifie et effectue une capture du pion arrivé en pos depuis
+// from. Le pion pris rentre en base, ou prend la case de départ du preneur
+// avec la règle maison de l'échange.
+func (e *Engine) checkCapture(pos, from int, capturer *models.Player) *models.Token {
+	victim := e.victimAt(pos, capturer)
+	if victim == nil {
 		return nil
 	}
 
-	victim := cell.Token
-	if victim.Color == capturer.Color {
-		return nil
+	dest := moves.Base
+	if e.house.SwapOnCapture && from != moves.Base {
+		dest = from
 	}
-
-	// Capturer le token
-	victim.Position = -1
-	victim.IsHome = false
-	victim.IsSafe = true
-	cell.Token = nil
-
+	e.moveTokenToPosition(victim, dest, victim.Color)
 	return victim
 }
 
+// victimAt retourne le pion adverse que prendrait un pion du joueur arrivant
+// sur la case, nil s'il n'y en a pas
+func (e *Engine) victimAt(pos int, capturer *models.Player) *models.Token {
+	if moves.IsSafe(pos) {
+		return nil
+	}
+	return e.game.Board.Cells[pos].Opponent(capturer.Color)
+}
+
 // checkWin vérifie si le joueur a gagné
 func (e *Engine) checkWin(player *models.Player) bool {
-	for _, token := range player.Tokens {
-		if !token.IsHome {
+	if !e.ruleSet.won(player) {
+		return false
+	}
+	player.TokensAtHome = tokensHome(player)
+	return true
+}
+
+// isBlocked indique qu'aucun pion du joueur ne peut bouger, quel que soit le dé
+func (e *Engine) isBlocked(player *models.Player) bool {
+	for dice := constants.DiceMin; dice <= constants.DiceMax; dice++ {
+		if e.hasValidMove(player, dice) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkStalemate compte les tours où tous les joueurs sont bloqués et
+// termine la partie quand la situation dure trop longtemps
+func (e *Engine) checkStalemate() bool {
+	for _, player := range e.game.Room.Players {
+		if !e.isBlocked(player) {
+			e.stalled = 0
 			return false
 		}
 	}
-	player.TokensAtHome = constants.TokensPerPlayer
+
+	e.stalled++
+	if e.stalled < constants.StalemateTurns {
+		return false
+	}
+
+	e.endStalemate()
 	return true
 }
 
 // nextTurn passe au tour suivant
 func (e *Engine) nextTurn() {
+	e.rolled = false
+	e.spin = nil
+	e.undo = nil
+
+	if e.checkStalemate() {
+		return
+	}
+
 	e.game.Room.CurrentTurn = (e.game.Room.CurrentTurn + 1) % len(e.game.Room.Players)
 	currentPlayer := e.game.Room.Players[e.game.Room.CurrentTurn]
+	e.actionStart = time.Now()
 
 	if e.callbacks.OnTurnChanged != nil {
 		e.callbacks.OnTurnChanged(currentPlayer.ID)
 	}
 
+	if e.paused {
+		// Le tour commencera à la reprise
+		e.deadline = time.Time{}
+		e.turnPending = true
+		return
+	}
+	if e.rebuilding {
+		return
+	}
+
 	if currentPlayer.IsAI {
+		e.deadline = time.Time{}
 		go e.handleAITurn(currentPlayer)
 	} else {
-		e.startTurnTimer(currentPlayer.ID)
+		e.startTurnTimer(currentPlayer.ID, e.turnTimeout)
 	}
 }
 
-// handleAITurn gère le tour d'une IA
+// handleAITurn gère le tour d'une IA. Un 6 ou une capture la font
+// rejouer: elle relance tant qu'elle garde la main.
 func (e *Engine) handleAITurn(player *models.Player) {
+	// Laisser au joueur précédent le temps de reprendre son coup
+	e.mu.RLock()
 	aiPlayer := e.ai[player.ID]
+	undoable := e.undo != nil
+	e.mu.RUnlock()
+	if undoable {
+		time.Sleep(undoGrace)
+	}
+
+	for e.playAIRoll(player, aiPlayer) {
+	}
+}
 
-	// Lancer le dé
-	diceValue, extraTurn, _ := e.RollDice(player.ID)
+// playAIRoll joue un lancer de l'IA et retourne si elle rejoue
+func (e *Engine) playAIRoll(player *models.Player, aiPlayer *ai.AIPlayer) bool {
+	// Lancer le dé. Un coup repris entre-temps a rendu la main au joueur
+	// précédent: ce tour n'a plus lieu.
+	diceValue, _, err := e.RollDice(player.ID)
+	if err != nil {
+		return false
+	}
 
 	// Sélectionner et déplacer un token
 	time.Sleep(500 * time.Millisecond) // Petit délai
 
 	token := aiPlayer.SelectToken(player, diceValue, e.game.Board)
+
+	// L'IA ne connaît pas les variantes et ne lit les règles maison que sur
+	// le plateau: hors règles standard, elle joue le premier pion autorisé
+	// si son choix est refusé ou si elle n'en voit pas
+	if e.hasRules() {
+		if token != nil && e.MoveToken(player.ID, token.ID) == nil {
+			return e.aiReplays(player)
+		}
+		token = nil
+		if id := e.legalToken(player); id >= 0 {
+			token = player.Tokens[id]
+		}
+	}
+
 	if token != nil {
 		e.MoveToken(player.ID, token.ID)
-	} else if !extraTurn {
+	} else {
+		// Sans coup possible, le lancer a déjà passé la main ou fait
+		// rejouer; sinon l'IA ne voit pas le coup permis et passe
 		e.mu.Lock()
-		e.nextTurn()
+		if e.holdsRoll(player) {
+			e.nextTurn()
+		}
 		e.mu.Unlock()
 	}
+	return e.aiReplays(player)
+}
+
+// holdsRoll indique que le joueur a la main et un dé à jouer. Appelé sous
+// le verrou du moteur.
+func (e *Engine) holdsRoll(player *models.Player) bool {
+	return e.game.Room.State == constants.StatePlaying &&
+		e.game.Room.Players[e.game.Room.CurrentTurn] == player && e.rolled
+}
+
+// aiReplays indique que l'IA garde la main pour un nouveau lancer. Pendant
+// une pause, le lancer attend la reprise.
+func (e *Engine) aiReplays(player *models.Player) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.game.Room.State != constants.StatePlaying || e.rolled ||
+		e.game.Room.Players[e.game.Room.CurrentTurn] != player {
+		return false
+	}
+	if e.paused {
+		e.turnPending = true
+		return false
+	}
+	return !e.rebuilding
 }
 
-// startTurnTimer démarre le timer du tour
-func (e *Engine) startTurnTimer(playerID int64) {
+// hasRules indique si la partie suit une variante ou des règles maison
+func (e *Engine) hasRules() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.rules != nil || !e.house.Classic()
+}
+
+// legalToken retourne le premier pion jouable avec le dé courant, -1 sinon
+func (e *Engine) legalToken(player *models.Player) int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	for i, token := range player.Tokens {
+		if e.canMoveToken(player, token, e.game.Room.LastDice) {
+			return i
+		}
+	}
+	return -1
+}
+
+// startTurnTimer démarre le timer du tour, pour la durée donnée
+func (e *Engine) startTurnTimer(playerID int64, timeout time.Duration) {
 	if e.turnTimer != nil {
 		e.turnTimer.Stop()
 	}
 
-	e.turnTimer = time.AfterFunc(time.Duration(constants.TurnTimeout)*time.Second, func() {
+	e.deadline = time.Now().Add(timeout)
+	if e.callbacks.OnTurnTimer != nil {
+		e.callbacks.OnTurnTimer(playerID, e.deadline)
+	}
+
+	e.turnTimer = time.AfterFunc(timeout, func() {
 		e.mu.Lock()
 		defer e.mu.Unlock()
 
+		// Une pause arrête le timer, mais il a pu se déclencher juste avant
+		if e.game.Room.State != constants.StatePlaying || e.paused {
+			return
+		}
 		currentPlayer := e.game.Room.Players[e.game.Room.CurrentTurn]
 		if currentPlayer.ID == playerID {
-			// Timeout: passer au tour suivant
+			// Timeout: prévenir la table puis passer au tour suivant
+			e.deadline = time.Time{}
+			if e.callbacks.OnTurnTimedOut != nil {
+				e.callbacks.OnTurnTimedOut(playerID)
+			}
 			e.nextTurn()
 		}
 	})
 }
 
+// TurnRemaining retourne le temps restant au joueur courant, zéro pendant
+// un tour d'IA ou hors partie
+func (e *Engine) TurnRemaining() time.Duration {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.deadline.IsZero() || e.game.Room.State != constants.StatePlaying {
+		return 0
+	}
+	if remaining := time.Until(e.deadline); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// Abort termine la partie sans vainqueur (abandon voté par les joueurs)
+func (e *Engine) Abort() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.game.Room.State != constants.StatePlaying {
+		return i18n.NewError(constants.ErrGameNotStarted, nil)
+	}
+
+	if e.turnTimer != nil {
+		e.turnTimer.Stop()
+	}
+
+	e.game.Aborted = true
+	e.game.EndReason = constants.GameOverAborted
+	e.game.Room.State = constants.StateFinished
+
+	if e.callbacks.OnGameOver != nil {
+		e.callbacks.OnGameOver(nil, nil, constants.GameOverAborted)
+	}
+
+	return nil
+}
+
+// Seed retourne la graine des dés de la partie, 0 pour des dés tirés de
+// crypto/rand qu'aucune graine ne peut rejouer
+func (e *Engine) Seed() int64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	seed, _ := e.dice.Seed()
+	return seed
+}
+
+// Reseed passe les dés en mode déterministe (tests, reproduction de bugs)
+func (e *Engine) Reseed(seed int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.dice = dice.NewSeeded(seed)
+	e.game.Seed = seed
+}
+
+// SetTurnTimeout modifie la durée des tours, appliquée dès le prochain tour
+func (e *Engine) SetTurnTimeout(timeout time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.turnTimeout = timeout
+}
+
 // endGame termine la partie
 func (e *Engine) endGame(winner *models.Player) {
 	e.game.Winner = winner
+	e.game.EndReason = constants.GameOverWin
 	e.game.Room.State = constants.StateFinished
 
 	// Calculer les classements
@@ -429,11 +923,71 @@ func (e *Engine) endGame(winner *models.Player) {
 		}
 	}
 
+	// Les variantes à points départagent les autres joueurs au score
+	if e.rules.Has(rules.RuleScore) {
+		others := rankings[1:]
+		sort.SliceStable(others, func(i, j int) bool {
+			return others[i].Score > others[j].Score
+		})
+	}
+
 	e.game.Rankings = rankings
 
 	if e.callbacks.OnGameOver != nil {
-		e.callbacks.OnGameOver(winner, rankings)
+		e.callbacks.OnGameOver(winner, rankings, constants.GameOverWin)
+	}
+}
+
+// endStalemate termine une partie bloquée en classant les joueurs selon
+// leurs pions arrivés. En cas d'égalité en tête, il n'y a pas de vainqueur.
+func (e *Engine) endStalemate() {
+	if e.turnTimer != nil {
+		e.turnTimer.Stop()
+	}
+
+	rankings := rankByTokensHome(e.game.Room.Players)
+
+	var winner *models.Player
+	if len(rankings) == 1 || tokensHome(rankings[0]) > tokensHome(rankings[1]) {
+		winner = rankings[0]
 	}
+
+	e.game.Winner = winner
+	e.game.Rankings = rankings
+	e.game.EndReason = constants.GameOverStalemate
+	e.game.Room.State = constants.StateFinished
+
+	if e.callbacks.OnGameOver != nil {
+		e.callbacks.OnGameOver(winner, rankings, constants.GameOverStalemate)
+	}
+}
+
+// rankByTokensHome classe les joueurs par nombre de pions arrivés
+func rankByTokensHome(players []*models.Player) []*models.Player {
+	rankings := make([]*models.Player, len(players))
+	copy(rankings, players)
+	sort.SliceStable(rankings, func(i, j int) bool {
+		return tokensHome(rankings[i]) > tokensHome(rankings[j])
+	})
+	return rankings
+}
+
+// tokensHome compte les pions arrivés d'un joueur
+func tokensHome(player *models.Player) int {
+	count := 0
+	for _, token := range player.Tokens {
+		if token.IsHome {
+			count++
+		}
+	}
+	return count
+}
+
+// Rolled indique que le joueur courant a lancé le dé et doit encore jouer
+func (e *Engine) Rolled() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.rolled
 }
 
 // GetGameState retourne l'état actuel du jeu
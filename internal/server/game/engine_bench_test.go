@@ -0,0 +1,200 @@
+// internal/server/game/engine_bench_test.go
+package game
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// benchSeed fixe les lancers pour que chaque exécution joue les mêmes parties
+const benchSeed = 42
+
+// maxBenchTurns évite une boucle infinie si une partie ne se termine jamais
+const maxBenchTurns = 100000
+
+// newBenchEngine démarre une partie à quatre joueurs humains avec des dés
+// déterministes. Le timer de tour est assez long pour ne jamais expirer.
+func newBenchEngine(b *testing.B, seed int64) *Engine {
+	b.Helper()
+
+	room := &models.Room{
+		Players: []*models.Player{
+			models.NewPlayer(1, "red", constants.ColorRed),
+			models.NewPlayer(2, "green", constants.ColorGreen),
+			models.NewPlayer(3, "yellow", constants.ColorYellow),
+			models.NewPlayer(4, "blue", constants.ColorBlue),
+		},
+		State: constants.StateWaiting,
+	}
+
+	e := NewEngine(room, models.RuleConfig{}, EngineCallbacks{})
+	e.Reseed(seed)
+	e.SetTurnTimeout(time.Hour)
+	if err := e.Start(); err != nil {
+		b.Fatalf("Start: %v", err)
+	}
+	b.Cleanup(func() { stopTurnTimer(e) })
+	return e
+}
+
+// stopTurnTimer arrête le timer laissé par la dernière partie jouée
+func stopTurnTimer(e *Engine) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.turnTimer != nil {
+		e.turnTimer.Stop()
+	}
+}
+
+// currentTurn retourne le joueur courant et l'état de la partie
+func currentTurn(e *Engine) (*models.Player, constants.GameState, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.game.Room.Players[e.game.Room.CurrentTurn], e.game.Room.State, e.rolled
+}
+
+// playTurn lance le dé pour le joueur courant et joue son premier pion
+// autorisé, comme le ferait un client. Retourne false une fois la partie finie.
+func playTurn(e *Engine) bool {
+	player, state, _ := currentTurn(e)
+	if state != constants.StatePlaying {
+		return false
+	}
+
+	if _, _, err := e.RollDice(player.ID); err != nil {
+		return false
+	}
+
+	// Le joueur doit jouer seulement si le lancer lui laisse un coup
+	if current, _, rolled := currentTurn(e); rolled && current == player {
+		if id := e.legalToken(player); id >= 0 {
+			e.MoveToken(player.ID, id)
+		}
+	}
+	return true
+}
+
+// playGame joue une partie complète et retourne le nombre de tours joués
+func playGame(b *testing.B, e *Engine) int {
+	b.Helper()
+	for turns := 0; turns < maxBenchTurns; turns++ {
+		if !playTurn(e) {
+			return turns
+		}
+	}
+	b.Fatalf("game did not finish after %d turns", maxBenchTurns)
+	return 0
+}
+
+func BenchmarkRollDice(b *testing.B) {
+	e := newBenchEngine(b, benchSeed)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		// Relancer sans jouer: seul le coût du lancer est mesuré
+		e.mu.Lock()
+		player := e.game.Room.Players[e.game.Room.CurrentTurn]
+		e.rolled = false
+		e.mu.Unlock()
+
+		if _, _, err := e.RollDice(player.ID); err != nil {
+			b.Fatalf("RollDice: %v", err)
+		}
+	}
+}
+
+func BenchmarkMoveToken(b *testing.B) {
+	e := newBenchEngine(b, benchSeed)
+
+	// Un pion par joueur, espacés d'un quart de plateau, qui tournent sans
+	// fin: avec des 1 ils ne se rattrapent jamais et n'entrent pas au couloir
+	e.mu.Lock()
+	for _, player := range e.game.Room.Players {
+		token := player.Tokens[0]
+		token.Laps = math.MaxInt32
+		e.moveTokenToPosition(token, constants.StartingPositions[player.Color]+1, player.Color)
+	}
+	e.mu.Unlock()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		e.mu.Lock()
+		player := e.game.Room.Players[e.game.Room.CurrentTurn]
+		e.game.Room.LastDice = 1
+		e.rolled = true
+		e.mu.Unlock()
+
+		if err := e.MoveToken(player.ID, 0); err != nil {
+			b.Fatalf("MoveToken: %v", err)
+		}
+	}
+}
+
+func BenchmarkSimulatedGame(b *testing.B) {
+	b.ReportAllocs()
+	turns := 0
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		e := newBenchEngine(b, benchSeed+int64(i))
+		b.StartTimer()
+
+		turns += playGame(b, e)
+	}
+
+	b.ReportMetric(float64(turns)/float64(b.N), "turns/game")
+}
+
+// BenchmarkGameStateDuringPlay mesure la lecture de l'état (diffusions aux
+// clients) pendant qu'une partie se joue, pour suivre la contention du verrou
+func BenchmarkGameStateDuringPlay(b *testing.B) {
+	e := newBenchEngine(b, benchSeed)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if !playTurn(e) {
+				// Partie finie: en recommencer une sur le même moteur
+				stopTurnTimer(e)
+				e.mu.Lock()
+				for _, player := range e.game.Room.Players {
+					player.ResetForRematch()
+				}
+				e.game.Board = models.NewBoard()
+				e.game.Room.State = constants.StateWaiting
+				e.mu.Unlock()
+				e.Start()
+			}
+		}
+	}()
+
+	// Les allocations de la partie en fond faussent ReportAllocs: seul le
+	// temps par lecture est significatif
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if e.GetGameState() == nil {
+				b.Error("nil game state")
+			}
+		}
+	})
+
+	b.StopTimer()
+	close(done)
+	<-stopped
+}
@@ -0,0 +1,421 @@
+// internal/server/game/engine_test.go
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/moves"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/dice"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/rules"
+)
+
+func newStalemateEngine(homeRed, homeBlue int) (*Engine, *string, **models.Player) {
+	red := models.NewPlayer(1, "red", constants.ColorRed)
+	blue := models.NewPlayer(2, "blue", constants.ColorBlue)
+	for i := 0; i < homeRed; i++ {
+		red.Tokens[i].IsHome = true
+	}
+	for i := 0; i < homeBlue; i++ {
+		blue.Tokens[i].IsHome = true
+	}
+
+	room := &models.Room{Players: []*models.Player{red, blue}, State: constants.StatePlaying}
+
+	var reason string
+	var winner *models.Player
+	e := NewEngine(room, models.RuleConfig{}, EngineCallbacks{
+		OnGameOver: func(w *models.Player, rankings []*models.Player, r string) {
+			winner, reason = w, r
+		},
+	})
+	return e, &reason, &winner
+}
+
+func TestEndStalemateRanksByTokensHome(t *testing.T) {
+	e, reason, winner := newStalemateEngine(1, 3)
+	e.endStalemate()
+
+	if *reason != constants.GameOverStalemate {
+		t.Fatalf("reason = %q, want %q", *reason, constants.GameOverStalemate)
+	}
+	if *winner == nil || (*winner).ID != 2 {
+		t.Fatalf("winner = %v, want player 2", *winner)
+	}
+	if e.game.Rankings[1].ID != 1 {
+		t.Errorf("second place = %d, want 1", e.game.Rankings[1].ID)
+	}
+}
+
+func TestEndStalemateTieIsDraw(t *testing.T) {
+	e, _, winner := newStalemateEngine(2, 2)
+	e.endStalemate()
+
+	if *winner != nil {
+		t.Errorf("winner = %v, want draw", *winner)
+	}
+	if e.game.Room.State != constants.StateFinished {
+		t.Errorf("state = %v, want finished", e.game.Room.State)
+	}
+}
+
+func TestMoveTokenRequiresRoll(t *testing.T) {
+	e, _, _ := newStalemateEngine(0, 0)
+	e.game.Room.LastDice = constants.RollToStart
+	current := e.game.Room.Players[e.game.Room.CurrentTurn]
+
+	// Un coup anticipé envoyé avant le lancer doit être refusé
+	if err := e.MoveToken(current.ID, 0); err == nil {
+		t.Fatal("MoveToken before rolling should fail")
+	}
+}
+
+func TestRollDiceIsLogged(t *testing.T) {
+	e, _, _ := newStalemateEngine(0, 0)
+	e.Reseed(7)
+	defer stopTurnTimer(e)
+	current := e.game.Room.Players[e.game.Room.CurrentTurn]
+
+	value, _, err := e.RollDice(current.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rolls := e.game.Rolls()
+	if len(rolls) != 1 || rolls[0] != value {
+		t.Fatalf("roll log = %v, want [%d]", rolls, value)
+	}
+	if logged := e.game.TurnHistory[0]; logged.PlayerID != current.ID {
+		t.Errorf("roll logged for player %d, want %d", logged.PlayerID, current.ID)
+	}
+}
+
+func TestTurnTimeoutSkipsPlayer(t *testing.T) {
+	red := models.NewPlayer(1, "red", constants.ColorRed)
+	blue := models.NewPlayer(2, "blue", constants.ColorBlue)
+	room := &models.Room{Players: []*models.Player{red, blue}, State: constants.StateWaiting}
+
+	timers := make(chan int64, 4)
+	timedOut := make(chan int64, 1)
+	e := NewEngine(room, models.RuleConfig{}, EngineCallbacks{
+		OnTurnTimer:    func(playerID int64, deadline time.Time) { timers <- playerID },
+		OnTurnTimedOut: func(playerID int64) { timedOut <- playerID },
+	})
+	defer stopTurnTimer(e)
+	e.SetTurnTimeout(20 * time.Millisecond)
+	e.SetStarter(0)
+	if err := e.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	if id := <-timers; id != red.ID {
+		t.Fatalf("first timer for player %d, want %d", id, red.ID)
+	}
+	if e.TurnRemaining() <= 0 {
+		t.Error("TurnRemaining should be positive while the timer runs")
+	}
+
+	select {
+	case id := <-timedOut:
+		if id != red.ID {
+			t.Fatalf("timed out player %d, want %d", id, red.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("turn never timed out")
+	}
+	// Le tour passe au joueur suivant, avec un nouveau compte à rebours
+	if id := <-timers; id != blue.ID {
+		t.Errorf("next timer for player %d, want %d", id, blue.ID)
+	}
+}
+
+func TestFastestPlayerIgnoresAIAndIdlePlayers(t *testing.T) {
+	slow := models.NewPlayer(1, "slow", constants.ColorRed)
+	slow.Decisions, slow.DecisionMs = 4, 20000
+	quick := models.NewPlayer(2, "quick", constants.ColorBlue)
+	quick.Decisions, quick.DecisionMs = 5, 10000
+	bot := models.NewAIPlayer(constants.ColorGreen, "hard")
+	bot.Decisions, bot.DecisionMs = 5, 500
+	idle := models.NewPlayer(3, "idle", constants.ColorYellow)
+
+	fastest := FastestPlayer([]*models.Player{slow, quick, bot, idle})
+	if fastest != quick {
+		t.Fatalf("fastest = %v, want quick", fastest)
+	}
+	if fastest.AvgDecision().Seconds() != 2 {
+		t.Errorf("average decision = %v, want 2s", fastest.AvgDecision())
+	}
+}
+
+func TestVariantRulesReleaseTokenOnOne(t *testing.T) {
+	e, _, _ := newStalemateEngine(0, 0)
+	red := e.game.Room.Players[0]
+	token := red.Tokens[0]
+
+	if e.canMoveToken(red, token, 1) {
+		t.Fatal("standard rules need a 6 to leave the base")
+	}
+
+	script, err := rules.Compile("can_move: standard || (from_base && dice == 1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.SetRules(script)
+
+	if !e.canMoveToken(red, token, 1) {
+		t.Error("the variant should release a token on a 1")
+	}
+	if e.canMoveToken(red, token, 3) {
+		t.Error("the variant should not release a token on a 3")
+	}
+}
+
+func TestRollOffKeepsOnlyTiedLeaders(t *testing.T) {
+	room := &models.Room{Players: []*models.Player{
+		models.NewPlayer(1, "a", constants.ColorRed),
+		models.NewPlayer(2, "b", constants.ColorGreen),
+		models.NewPlayer(3, "c", constants.ColorYellow),
+		models.NewPlayer(4, "d", constants.ColorBlue),
+	}}
+	e := NewEngine(room, models.RuleConfig{}, EngineCallbacks{})
+
+	for seed := int64(1); seed <= 200; seed++ {
+		e.Reseed(seed)
+		rounds, starter := e.rollOff()
+
+		if len(rounds[0]) != len(room.Players) {
+			t.Fatalf("seed %d: first round has %d rolls, want everyone", seed, len(rounds[0]))
+		}
+		for i, round := range rounds {
+			best := 0
+			for _, roll := range round {
+				if roll.Value > best {
+					best = roll.Value
+				}
+			}
+			var leaders []int64
+			for _, roll := range round {
+				if roll.Value == best {
+					leaders = append(leaders, roll.PlayerID)
+				}
+			}
+
+			if i == len(rounds)-1 {
+				if len(leaders) != 1 || leaders[0] != room.Players[starter].ID {
+					t.Fatalf("seed %d: last round leaders %v, starter %d", seed, leaders, room.Players[starter].ID)
+				}
+				continue
+			}
+			if len(leaders) < 2 || len(rounds[i+1]) != len(leaders) {
+				t.Fatalf("seed %d: round %d re-rolls %d players for %d tied leaders", seed, i+1, len(rounds[i+1]), len(leaders))
+			}
+			for j, roll := range rounds[i+1] {
+				if roll.PlayerID != leaders[j] {
+					t.Fatalf("seed %d: player %d re-rolled without a tie", seed, roll.PlayerID)
+				}
+			}
+		}
+	}
+}
+
+func TestHandicapsAppliedAtStart(t *testing.T) {
+	weak := models.NewPlayer(1, "weak", constants.ColorRed)
+	weak.Handicap = constants.HandicapHeadStart
+	strong := models.NewPlayer(2, "strong", constants.ColorYellow)
+	strong.Handicap = constants.HandicapExtraLap
+
+	room := &models.Room{Players: []*models.Player{weak, strong}, State: constants.StateWaiting}
+	e := NewEngine(room, models.RuleConfig{}, EngineCallbacks{})
+	e.SetStarter(0)
+	if err := e.Start(); err != nil {
+		t.Fatal(err)
+	}
+	e.turnTimer.Stop()
+
+	if weak.Tokens[0].Position != constants.StartingPositions[constants.ColorRed] {
+		t.Errorf("head start token at %d, want the start cell", weak.Tokens[0].Position)
+	}
+	if weak.Tokens[1].Position != -1 {
+		t.Error("only one token should get a head start")
+	}
+	for _, token := range strong.Tokens {
+		if token.Laps != 1 {
+			t.Fatalf("extra lap token has %d laps left, want 1", token.Laps)
+		}
+	}
+}
+
+func TestExtraLapSkipsHomeEntryOnce(t *testing.T) {
+	e, _, _ := newStalemateEngine(0, 0)
+	token := &models.Token{Position: 48, Laps: 1}
+	color := constants.ColorRed
+
+	// Entrée de la maison rouge en 50: le pion continue sur le parcours
+	if got := e.calculateNewPosition(token, 4, color); got != 0 {
+		t.Fatalf("with a lap left, 48+4 = %d, want 0 (wrap)", got)
+	}
+	if !moves.CrossesHomeEntry(color, token.Position, 4) {
+		t.Fatal("48+4 should cross the red home entry")
+	}
+
+	token.Laps = 0
+	if got := e.calculateNewPosition(token, 4, color); got != 54 {
+		t.Errorf("without laps, 48+4 = %d, want home stretch 54", got)
+	}
+}
+
+func TestQuickRuleSetFirstPawnHomeWins(t *testing.T) {
+	red := models.NewPlayer(1, "red", constants.ColorRed)
+	blue := models.NewPlayer(2, "blue", constants.ColorBlue)
+	room := &models.Room{Players: []*models.Player{red, blue}, State: constants.StateWaiting, RuleSet: constants.RuleSetQuick}
+
+	var winner *models.Player
+	e := NewEngine(room, models.RuleConfig{}, EngineCallbacks{
+		OnGameOver: func(w *models.Player, rankings []*models.Player, r string) { winner = w },
+	})
+	e.SetStarter(0)
+	if err := e.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer stopTurnTimer(e)
+
+	for _, player := range room.Players {
+		if len(player.Tokens) != 2 {
+			t.Fatalf("%s has %d pawns, want 2", player.Username, len(player.Tokens))
+		}
+		if player.Tokens[0].Position != constants.StartingPositions[player.Color] || player.Tokens[1].Position != moves.Base {
+			t.Fatalf("%s pawns at %d and %d, want one on the start cell", player.Username,
+				player.Tokens[0].Position, player.Tokens[1].Position)
+		}
+	}
+
+	// Un seul pion arrivé suffit
+	red.Tokens[0].Position = moves.Home - 2
+	e.rolled = true
+	e.game.Room.LastDice = 2
+	if err := e.MoveToken(red.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	if winner != red || room.State != constants.StateFinished {
+		t.Fatalf("winner = %v, state %v; want red and finished", winner, room.State)
+	}
+}
+
+// newHouseEngine prépare une partie rouge contre bleu, au tour de rouge
+// qui vient de lancer le dé
+func newHouseEngine(house models.RuleConfig, dice int) (*Engine, *models.Player, *models.Player) {
+	red := models.NewPlayer(1, "red", constants.ColorRed)
+	blue := models.NewPlayer(2, "blue", constants.ColorBlue)
+	room := &models.Room{Players: []*models.Player{red, blue}, State: constants.StatePlaying, LastDice: dice}
+	e := NewEngine(room, house, EngineCallbacks{})
+	e.rolled = true
+	return e, red, blue
+}
+
+func TestCaptureSendsHomeOrSwaps(t *testing.T) {
+	for _, swap := range []bool{false, true} {
+		e, red, blue := newHouseEngine(models.RuleConfig{SwapOnCapture: swap}, 3)
+		e.moveTokenToPosition(red.Tokens[0], 20, red.Color)
+		e.moveTokenToPosition(blue.Tokens[0], 23, blue.Color)
+
+		if err := e.MoveToken(red.ID, 0); err != nil {
+			t.Fatal(err)
+		}
+		stopTurnTimer(e)
+
+		want := moves.Base
+		if swap {
+			want = 20
+		}
+		if got := blue.Tokens[0].Position; got != want {
+			t.Errorf("swap %v: captured pawn at %d, want %d", swap, got, want)
+		}
+		if cell := e.game.Board.Cells[23].Tokens; len(cell) != 1 || cell[0] != red.Tokens[0] {
+			t.Errorf("swap %v: capturer missing from its cell", swap)
+		}
+	}
+}
+
+func TestCaptureBonusGivesExtraRoll(t *testing.T) {
+	e, red, blue := newHouseEngine(models.RuleConfig{CaptureBonus: true}, 3)
+	e.moveTokenToPosition(red.Tokens[0], 20, red.Color)
+	e.moveTokenToPosition(blue.Tokens[0], 23, blue.Color)
+
+	if err := e.MoveToken(red.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	defer stopTurnTimer(e)
+	if e.game.Room.CurrentTurn != 0 {
+		t.Error("a capture should give red another roll")
+	}
+}
+
+func TestThreeSixPenaltyCanBeDisabled(t *testing.T) {
+	for _, off := range []bool{false, true} {
+		e, red, _ := newHouseEngine(models.RuleConfig{NoTripleSix: off}, 6)
+		red.ConsecutiveSix = constants.MaxConsecutiveSix - 1
+
+		extra := e.applyRoll(red, 6)
+		stopTurnTimer(e)
+		if extra != off || (e.game.Room.CurrentTurn == 0) != off {
+			t.Errorf("penalty off %v: extra roll %v, turn %d", off, extra, e.game.Room.CurrentTurn)
+		}
+	}
+}
+
+func TestAIPlaysItsExtraTurn(t *testing.T) {
+	// Graine dont le premier lancer est un 6 et le second non
+	seed := int64(1)
+	for ; ; seed++ {
+		d := dice.NewSeeded(seed)
+		if d.Roll() == 6 && d.Roll() != 6 {
+			break
+		}
+	}
+
+	bot := models.NewAIPlayer(constants.ColorRed, "hard")
+	bot.ID = 1
+	human := models.NewPlayer(2, "human", constants.ColorBlue)
+	room := &models.Room{Players: []*models.Player{bot, human}, State: constants.StateWaiting}
+
+	rolls := make(chan int, 8)
+	turns := make(chan int64, 8)
+	e := NewEngine(room, models.RuleConfig{}, EngineCallbacks{
+		OnDiceRolled: func(playerID int64, value int, extraTurn bool) {
+			if playerID == bot.ID {
+				rolls <- value
+			}
+		},
+		OnTurnChanged: func(playerID int64) { turns <- playerID },
+	})
+	defer stopTurnTimer(e)
+	e.SetTurnTimeout(time.Minute)
+	e.Reseed(seed)
+	e.SetStarter(0)
+	if err := e.Start(); err != nil {
+		t.Fatal(err)
+	}
+	<-turns
+
+	for i, want := range []string{"first roll", "extra turn"} {
+		select {
+		case value := <-rolls:
+			if i == 0 && value != 6 {
+				t.Fatalf("first roll = %d, want 6", value)
+			}
+		case <-time.After(3 * time.Second):
+			t.Fatalf("the AI never played its %s", want)
+		}
+	}
+
+	select {
+	case id := <-turns:
+		if id != human.ID {
+			t.Errorf("turn passed to player %d, want %d", id, human.ID)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("the AI kept the turn after its extra turn")
+	}
+}
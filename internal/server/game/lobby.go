@@ -0,0 +1,93 @@
+// internal/server/game/lobby.go
+package game
+
+import (
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// AssignColor donne une couleur à un joueur avant la partie. Si un autre
+// joueur l'a déjà, les deux échangent leurs couleurs.
+func AssignColor(room *models.Room, playerID int64, color constants.PlayerColor) error {
+	if room.State != constants.StateWaiting {
+		return i18n.NewError(constants.ErrGameStarted, nil)
+	}
+	if !isQuadrant(color) {
+		return i18n.NewError(constants.ErrUnknownColor, nil)
+	}
+
+	var player, holder *models.Player
+	for _, p := range room.Players {
+		if p.ID == playerID {
+			player = p
+		}
+		if p.Color == color {
+			holder = p
+		}
+	}
+	if player == nil {
+		return i18n.NewError(constants.ErrPlayerNotInRoom, nil)
+	}
+
+	if holder != nil && holder != player {
+		setColor(holder, player.Color)
+	}
+	setColor(player, color)
+	return nil
+}
+
+// RemovePlayer retire un joueur de la salle avant la partie
+func RemovePlayer(room *models.Room, playerID int64) (*models.Player, error) {
+	if room.State != constants.StateWaiting {
+		return nil, i18n.NewError(constants.ErrGameStarted, nil)
+	}
+	for i, p := range room.Players {
+		if p.ID == playerID {
+			room.Players = append(room.Players[:i], room.Players[i+1:]...)
+			return p, nil
+		}
+	}
+	return nil, i18n.NewError(constants.ErrPlayerNotInRoom, nil)
+}
+
+// AssignColor change la couleur d'un joueur de la salle en attente
+func (e *Engine) AssignColor(playerID int64, color constants.PlayerColor) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return AssignColor(e.game.Room, playerID, color)
+}
+
+// RemovePlayer retire un joueur de la salle en attente, par exemple exclu
+// par l'hôte
+func (e *Engine) RemovePlayer(playerID int64) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	player, err := RemovePlayer(e.game.Room, playerID)
+	if err != nil {
+		return err
+	}
+	if player.IsAI {
+		delete(e.ai, player.ID)
+	}
+	return nil
+}
+
+// setColor repeint un joueur et ses pions, encore tous en base
+func setColor(player *models.Player, color constants.PlayerColor) {
+	player.Color = color
+	for _, token := range player.Tokens {
+		token.Color = color
+	}
+}
+
+// isQuadrant indique si la couleur correspond à un quadrant du plateau
+func isQuadrant(color constants.PlayerColor) bool {
+	for _, quadrant := range constants.BoardQuadrants {
+		if quadrant == color {
+			return true
+		}
+	}
+	return false
+}
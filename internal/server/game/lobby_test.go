@@ -0,0 +1,75 @@
+// internal/server/game/lobby_test.go
+package game
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func newLobbyRoom() *models.Room {
+	return &models.Room{
+		State: constants.StateWaiting,
+		Players: []*models.Player{
+			models.NewPlayer(1, "host", constants.ColorRed),
+			models.NewPlayer(2, "guest", constants.ColorYellow),
+		},
+	}
+}
+
+func TestAssignColorSwapsWithHolder(t *testing.T) {
+	room := newLobbyRoom()
+	if err := AssignColor(room, 2, constants.ColorRed); err != nil {
+		t.Fatalf("AssignColor: %v", err)
+	}
+
+	host, guest := room.Players[0], room.Players[1]
+	if guest.Color != constants.ColorRed || host.Color != constants.ColorYellow {
+		t.Fatalf("colors = %s/%s, want yellow/red", host.Color, guest.Color)
+	}
+	for _, token := range host.Tokens {
+		if token.Color != constants.ColorYellow {
+			t.Fatal("tokens should follow their player's new color")
+		}
+	}
+}
+
+func TestAssignColorRejections(t *testing.T) {
+	room := newLobbyRoom()
+	if err := AssignColor(room, 2, "purple"); errorCode(err) != constants.ErrUnknownColor {
+		t.Errorf("unknown color: err = %v", err)
+	}
+	if err := AssignColor(room, 9, constants.ColorBlue); errorCode(err) != constants.ErrPlayerNotInRoom {
+		t.Errorf("unknown player: err = %v", err)
+	}
+
+	room.State = constants.StatePlaying
+	if err := AssignColor(room, 2, constants.ColorBlue); errorCode(err) != constants.ErrGameStarted {
+		t.Errorf("started game: err = %v", err)
+	}
+}
+
+func TestRemovePlayerBeforeStart(t *testing.T) {
+	room := newLobbyRoom()
+	e := NewEngine(room, models.RuleConfig{}, EngineCallbacks{})
+
+	if err := e.RemovePlayer(2); err != nil {
+		t.Fatalf("RemovePlayer: %v", err)
+	}
+	if len(room.Players) != 1 || room.Players[0].ID != 1 {
+		t.Fatalf("players = %d, want only the host", len(room.Players))
+	}
+	if err := e.RemovePlayer(2); errorCode(err) != constants.ErrPlayerNotInRoom {
+		t.Errorf("second removal: err = %v", err)
+	}
+}
+
+func errorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	code, _ := i18n.CodeOf(err, "")
+	return code
+}
@@ -0,0 +1,102 @@
+// internal/server/game/match.go
+package game
+
+import "github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+
+// Formats de match proposés à la création d'une salle
+var MatchFormats = []int{3, 5}
+
+// Match enchaîne les parties d'un match au meilleur de N. Chaque partie a son
+// propre moteur; le match compte les victoires et alterne le premier joueur.
+type Match struct {
+	score        models.MatchScore
+	firstStarter int // Index du vainqueur du tirage de la première partie
+}
+
+// NewMatch crée un match au meilleur de bestOf parties (impair)
+func NewMatch(bestOf int) *Match {
+	return &Match{
+		score:        models.MatchScore{BestOf: bestOf, Wins: make(map[int64]int)},
+		firstStarter: -1,
+	}
+}
+
+// ValidBestOf indique si un format de match est proposé
+func ValidBestOf(bestOf int) bool {
+	for _, format := range MatchFormats {
+		if format == bestOf {
+			return true
+		}
+	}
+	return false
+}
+
+// Threshold est le nombre de victoires qui remporte le match
+func (m *Match) Threshold() int {
+	return m.score.BestOf/2 + 1
+}
+
+// Decided indique si le match a un vainqueur
+func (m *Match) Decided() bool {
+	return m.score.WinnerID != 0
+}
+
+// Record compte une partie terminée (winner nil pour un nul ou un abandon) et
+// retourne true si le match est joué. Au-delà de N parties sans personne au
+// seuil, le joueur seul en tête l'emporte; à égalité on continue.
+func (m *Match) Record(winner *models.Player) bool {
+	if m.Decided() {
+		return true
+	}
+	m.score.Played++
+	if winner != nil {
+		m.score.Wins[winner.ID]++
+		if m.score.Wins[winner.ID] >= m.Threshold() {
+			m.score.WinnerID = winner.ID
+			return true
+		}
+	}
+
+	if m.score.Played >= m.score.BestOf {
+		var leader int64
+		best, tied := 0, false
+		for id, wins := range m.score.Wins {
+			switch {
+			case wins > best:
+				leader, best, tied = id, wins, false
+			case wins == best:
+				tied = true
+			}
+		}
+		if best > 0 && !tied {
+			m.score.WinnerID = leader
+		}
+	}
+	return m.Decided()
+}
+
+// SetFirstStarter retient le vainqueur du tirage de la première partie
+func (m *Match) SetFirstStarter(index int) {
+	if m.firstStarter < 0 {
+		m.firstStarter = index
+	}
+}
+
+// NextStarter retourne l'index du premier joueur de la prochaine partie, en
+// tournant à partir du vainqueur du premier tirage; -1 = tirage au dé
+func (m *Match) NextStarter(players int) int {
+	if m.firstStarter < 0 || players == 0 {
+		return -1
+	}
+	return (m.firstStarter + m.score.Played) % players
+}
+
+// Score retourne une copie du score, pour l'envoyer aux joueurs
+func (m *Match) Score() *models.MatchScore {
+	score := m.score
+	score.Wins = make(map[int64]int, len(m.score.Wins))
+	for id, wins := range m.score.Wins {
+		score.Wins[id] = wins
+	}
+	return &score
+}
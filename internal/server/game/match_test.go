@@ -0,0 +1,60 @@
+// internal/server/game/match_test.go
+package game
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestMatchEndsAtThreshold(t *testing.T) {
+	alice := models.NewPlayer(1, "alice", constants.ColorRed)
+	bob := models.NewPlayer(2, "bob", constants.ColorYellow)
+	m := NewMatch(3)
+
+	if m.Record(alice) {
+		t.Fatal("one win out of three should not end the match")
+	}
+	if m.Record(bob) {
+		t.Fatal("1-1 should not end the match")
+	}
+	if !m.Record(alice) || m.Score().WinnerID != alice.ID {
+		t.Fatalf("alice should win 2-1, got %+v", m.Score())
+	}
+}
+
+func TestMatchDrawsExtendUntilALeader(t *testing.T) {
+	alice := models.NewPlayer(1, "alice", constants.ColorRed)
+	bob := models.NewPlayer(2, "bob", constants.ColorYellow)
+	m := NewMatch(3)
+
+	m.Record(alice)
+	m.Record(bob)
+	if m.Record(nil) {
+		t.Fatal("1-1 after three games is still tied")
+	}
+	if m.Record(nil) {
+		t.Fatal("a draw cannot decide a tied match")
+	}
+	if !m.Record(bob) || m.Score().WinnerID != bob.ID {
+		t.Fatalf("bob should take the match, got %+v", m.Score())
+	}
+}
+
+func TestMatchAlternatesStarter(t *testing.T) {
+	m := NewMatch(5)
+	if m.NextStarter(2) != -1 {
+		t.Fatal("the first game should use the roll-off")
+	}
+
+	m.SetFirstStarter(1)
+	m.Record(nil)
+	if got := m.NextStarter(2); got != 0 {
+		t.Errorf("game 2 starter = %d, want 0", got)
+	}
+	m.Record(nil)
+	if got := m.NextStarter(2); got != 1 {
+		t.Errorf("game 3 starter = %d, want 1", got)
+	}
+}
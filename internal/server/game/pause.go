@@ -0,0 +1,106 @@
+// internal/server/game/pause.go
+package game
+
+import (
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+)
+
+// minResumeTurn est le temps laissé au joueur humain courant à la reprise,
+// même si son tour allait expirer au moment de la pause
+const minResumeTurn = 5 * time.Second
+
+// Pause fige la partie: le compte à rebours du tour s'arrête et les joueurs
+// humains ne peuvent plus jouer. Un tour d'IA déjà commencé se termine, le
+// suivant attend la reprise. La partie reprend d'elle-même après limit.
+func (e *Engine) Pause(limit time.Duration) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.game.Room.State != constants.StatePlaying {
+		return i18n.NewError(constants.ErrGameNotStarted, nil)
+	}
+	if e.paused {
+		return i18n.NewError(constants.ErrGamePaused, nil)
+	}
+
+	if e.turnTimer != nil {
+		e.turnTimer.Stop()
+	}
+	now := time.Now()
+	e.paused = true
+	e.pausedAt = now
+	e.pausedLeft = 0
+	if !e.deadline.IsZero() {
+		e.pausedLeft = e.deadline.Sub(now)
+		e.deadline = time.Time{}
+	}
+
+	e.pauses++
+	pause := e.pauses
+	e.pauseTimer = time.AfterFunc(limit, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if e.paused && e.pauses == pause && e.game.Room.State == constants.StatePlaying {
+			e.resume()
+		}
+	})
+
+	if e.callbacks.OnPaused != nil {
+		e.callbacks.OnPaused(true, now.Add(limit))
+	}
+	return nil
+}
+
+// Resume reprend une partie en pause. Le joueur humain courant retrouve le
+// temps qui lui restait.
+func (e *Engine) Resume() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.game.Room.State != constants.StatePlaying {
+		return i18n.NewError(constants.ErrGameNotStarted, nil)
+	}
+	if !e.paused {
+		return i18n.NewError(constants.ErrGameNotPaused, nil)
+	}
+	e.resume()
+	return nil
+}
+
+// resume relance les tours. Appelé sous le verrou du moteur.
+func (e *Engine) resume() {
+	e.paused = false
+	if e.pauseTimer != nil {
+		e.pauseTimer.Stop()
+	}
+	// La pause ne compte pas dans le temps de réflexion
+	e.actionStart = e.actionStart.Add(time.Since(e.pausedAt))
+
+	if e.callbacks.OnPaused != nil {
+		e.callbacks.OnPaused(false, time.Time{})
+	}
+
+	currentPlayer := e.game.Room.Players[e.game.Room.CurrentTurn]
+	pending := e.turnPending
+	e.turnPending = false
+	switch {
+	case currentPlayer.IsAI:
+		if pending {
+			go e.handleAITurn(currentPlayer)
+		}
+	case pending:
+		e.startTurnTimer(currentPlayer.ID, e.turnTimeout)
+	default:
+		e.startTurnTimer(currentPlayer.ID, max(e.pausedLeft, minResumeTurn))
+	}
+}
+
+// Paused indique que la partie est en pause
+func (e *Engine) Paused() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.paused
+}
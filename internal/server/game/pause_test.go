@@ -0,0 +1,95 @@
+// internal/server/game/pause_test.go
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestPauseFreezesTurnUntilResume(t *testing.T) {
+	red := models.NewPlayer(1, "red", constants.ColorRed)
+	blue := models.NewPlayer(2, "blue", constants.ColorBlue)
+	room := &models.Room{Players: []*models.Player{red, blue}, State: constants.StateWaiting}
+
+	timers := make(chan time.Time, 4)
+	timedOut := make(chan int64, 1)
+	paused := make(chan bool, 4)
+	e := NewEngine(room, models.RuleConfig{}, EngineCallbacks{
+		OnTurnTimer:    func(playerID int64, deadline time.Time) { timers <- deadline },
+		OnTurnTimedOut: func(playerID int64) { timedOut <- playerID },
+		OnPaused:       func(p bool, resumesAt time.Time) { paused <- p },
+	})
+	defer stopTurnTimer(e)
+	e.SetTurnTimeout(30 * time.Millisecond)
+	e.SetStarter(0)
+	if err := e.Start(); err != nil {
+		t.Fatal(err)
+	}
+	<-timers
+
+	if err := e.Pause(time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if !<-paused || !e.Paused() || e.TurnRemaining() != 0 {
+		t.Fatal("expected a paused game without a running turn timer")
+	}
+	if _, _, err := e.RollDice(red.ID); errCode(err) != constants.ErrGamePaused {
+		t.Errorf("roll while paused = %v, want %s", err, constants.ErrGamePaused)
+	}
+	select {
+	case <-timedOut:
+		t.Fatal("turn timed out during the pause")
+	case <-time.After(60 * time.Millisecond):
+	}
+
+	if err := e.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	if <-paused {
+		t.Error("expected the resume to be announced")
+	}
+	// Le tour de red reprend avec au moins minResumeTurn
+	if deadline := <-timers; time.Until(deadline) < minResumeTurn-time.Second {
+		t.Errorf("resumed turn ends in %s, want about %s", time.Until(deadline), minResumeTurn)
+	}
+	if err := e.Resume(); errCode(err) != constants.ErrGameNotPaused {
+		t.Errorf("second resume = %v, want %s", err, constants.ErrGameNotPaused)
+	}
+}
+
+func TestPauseResumesAfterLimit(t *testing.T) {
+	red := models.NewPlayer(1, "red", constants.ColorRed)
+	blue := models.NewPlayer(2, "blue", constants.ColorBlue)
+	room := &models.Room{Players: []*models.Player{red, blue}, State: constants.StateWaiting}
+
+	paused := make(chan bool, 4)
+	e := NewEngine(room, models.RuleConfig{}, EngineCallbacks{
+		OnPaused: func(p bool, resumesAt time.Time) { paused <- p },
+	})
+	defer stopTurnTimer(e)
+	e.SetStarter(0)
+	if err := e.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	e.Pause(10 * time.Millisecond)
+	<-paused
+	select {
+	case p := <-paused:
+		if p || e.Paused() {
+			t.Fatal("expected the game to resume by itself")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the pause never ended")
+	}
+}
+
+// errCode retourne le code traduisible d'une erreur du moteur
+func errCode(err error) string {
+	code, _ := i18n.CodeOf(err, "")
+	return code
+}
@@ -0,0 +1,197 @@
+// internal/server/game/rebuild.go
+package game
+
+import (
+	"fmt"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/rules"
+)
+
+// Rebuild reconstruit une partie à partir de sa salle, de la graine de ses
+// dés et de son historique, sans rien d'autre de son état: les joueurs
+// repartent de leur base et chaque action est rejouée par le moteur.
+//
+// La graine refait le tirage du premier joueur; sans graine, le premier à
+// lancer le dé a commencé. Un tour expiré ne laisse pas de trace dans
+// l'historique: le tour passe jusqu'à l'auteur du lancer suivant.
+//
+// Une action refusée par le moteur, ou dont le résultat diffère de
+// l'historique, arrête la reconstruction: l'erreur la désigne et la partie
+// retournée s'arrête juste avant.
+func Rebuild(room *models.Room, seed int64, history []models.TurnAction) (*models.Game, error) {
+	var variant *rules.Script
+	if room.Rules != "" {
+		script, err := rules.Compile(room.Rules)
+		if err != nil {
+			return nil, fmt.Errorf("rules: %w", err)
+		}
+		variant = script
+	}
+
+	start := startingRoom(room)
+	e := NewEngine(start, room.HouseRules, EngineCallbacks{})
+	e.rebuilding = true
+	e.rules = variant
+	switch {
+	case seed != 0:
+		e.Reseed(seed)
+	case len(history) > 0:
+		for i, player := range start.Players {
+			if player.ID == history[0].PlayerID {
+				e.starter = i
+			}
+		}
+	}
+	if err := e.Start(); err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, action := range history {
+		if err := e.replay(action); err != nil {
+			return e.game, fmt.Errorf("action %d: %w", i+1, err)
+		}
+	}
+	return e.game, nil
+}
+
+// Verify reconstruit une partie enregistrée et compare le résultat à son
+// état final: positions des pions, premier joueur et issue de la partie.
+// Une différence trahit un changement des règles depuis la partie, ou un
+// historique corrompu.
+func Verify(stored *models.Game) error {
+	rebuilt, err := Rebuild(stored.Room, stored.Seed, stored.TurnHistory)
+	if err != nil {
+		return err
+	}
+
+	if stored.Seed != 0 && rebuilt.FirstTurn != stored.FirstTurn {
+		return fmt.Errorf("seat %d started, recorded %d", rebuilt.FirstTurn, stored.FirstTurn)
+	}
+	for i, player := range stored.Room.Players {
+		tokens := rebuilt.Room.Players[i].Tokens
+		if len(tokens) != len(player.Tokens) {
+			return fmt.Errorf("%s has %d pawns, recorded %d", player.Color, len(tokens), len(player.Tokens))
+		}
+		for j, token := range player.Tokens {
+			if tokens[j].Position != token.Position || tokens[j].Laps != token.Laps {
+				return fmt.Errorf("%s pawn %d ends on %d, recorded %d", player.Color, j, tokens[j].Position, token.Position)
+			}
+		}
+	}
+
+	// Un abandon n'est pas rejouable: seules les positions comptent
+	if stored.Aborted || stored.Room.State != constants.StateFinished {
+		return nil
+	}
+	if rebuilt.EndReason != stored.EndReason {
+		return fmt.Errorf("game ended with %q, recorded %q", rebuilt.EndReason, stored.EndReason)
+	}
+	if winnerID(rebuilt) != winnerID(stored) {
+		return fmt.Errorf("player %d won, recorded %d", winnerID(rebuilt), winnerID(stored))
+	}
+	return nil
+}
+
+// startingRoom copie la salle d'une partie telle qu'elle était avant le
+// premier lancer
+func startingRoom(room *models.Room) *models.Room {
+	start := *room
+	start.State = constants.StateWaiting
+	start.CurrentTurn, start.LastDice, start.StartedAt = 0, 0, nil
+	start.Players = make([]*models.Player, len(room.Players))
+	for i, player := range room.Players {
+		p := *player
+		p.ResetForRematch()
+		start.Players[i] = &p
+	}
+	return &start
+}
+
+// replay rejoue une action de l'historique. e.mu doit être tenu.
+func (e *Engine) replay(action models.TurnAction) error {
+	if e.game.Room.State != constants.StatePlaying {
+		return fmt.Errorf("game already over")
+	}
+	if action.TokenMoved == nil {
+		return e.replayRoll(action)
+	}
+	return e.replayMove(action)
+}
+
+// replayRoll rejoue un lancer, après avoir passé les tours expirés
+func (e *Engine) replayRoll(action models.TurnAction) error {
+	players := e.game.Room.Players
+	for skipped := 0; e.rolled || players[e.game.Room.CurrentTurn].ID != action.PlayerID; skipped++ {
+		if skipped == len(players) {
+			return fmt.Errorf("player %d cannot roll", action.PlayerID)
+		}
+		e.nextTurn()
+		if e.game.Room.State != constants.StatePlaying {
+			return fmt.Errorf("game already over")
+		}
+	}
+	if action.DiceValue < constants.DiceMin || action.DiceValue > constants.DiceMax {
+		return fmt.Errorf("invalid dice value %d", action.DiceValue)
+	}
+
+	e.applyRoll(players[e.game.Room.CurrentTurn], action.DiceValue)
+	e.game.TurnHistory[len(e.game.TurnHistory)-1].Timestamp = action.Timestamp
+	return nil
+}
+
+// replayMove rejoue un déplacement et vérifie qu'il mène où l'historique le
+// dit, avec la même prise
+func (e *Engine) replayMove(action models.TurnAction) error {
+	tokenID := action.TokenMoved.ID
+	if action.DiceValue != e.game.Room.LastDice {
+		return fmt.Errorf("pawn %d moved with %d, last roll was %d", tokenID, action.DiceValue, e.game.Room.LastDice)
+	}
+	if err := e.moveToken(action.PlayerID, tokenID); err != nil {
+		return fmt.Errorf("player %d cannot move pawn %d: %w", action.PlayerID, tokenID, err)
+	}
+
+	played := &e.game.TurnHistory[len(e.game.TurnHistory)-1]
+	if played.FromPos != action.FromPos || played.ToPos != action.ToPos {
+		return fmt.Errorf("pawn %d moved %d→%d, recorded %d→%d", tokenID, played.FromPos, played.ToPos, action.FromPos, action.ToPos)
+	}
+	if !sameToken(played.Captured, action.Captured) {
+		return fmt.Errorf("pawn %d captured %s, recorded %s", tokenID, tokenName(played.Captured), tokenName(action.Captured))
+	}
+
+	// Le temps de réflexion est celui de la partie, pas de la reconstruction
+	for _, player := range e.game.Room.Players {
+		if player.ID == action.PlayerID {
+			player.DecisionMs += action.DurationMs - played.DurationMs
+		}
+	}
+	played.DurationMs, played.Timestamp = action.DurationMs, action.Timestamp
+	return nil
+}
+
+// sameToken indique que deux pions d'historique désignent le même pion
+func sameToken(a, b *models.Token) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Color == b.Color && a.ID == b.ID
+}
+
+// tokenName présente un pion pris dans un message d'erreur
+func tokenName(token *models.Token) string {
+	if token == nil {
+		return "nothing"
+	}
+	return fmt.Sprintf("%s pawn %d", token.Color, token.ID)
+}
+
+// winnerID retourne l'identifiant du vainqueur, 0 sans vainqueur
+func winnerID(game *models.Game) int64 {
+	if game.Winner == nil {
+		return 0
+	}
+	return game.Winner.ID
+}
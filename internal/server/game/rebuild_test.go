@@ -0,0 +1,85 @@
+// internal/server/game/rebuild_test.go
+package game
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// playedGame joue une partie complète à trois joueurs avec des dés
+// déterministes
+func playedGame(t *testing.T, seed int64) *models.Game {
+	t.Helper()
+	room := &models.Room{
+		Players: []*models.Player{
+			models.NewPlayer(1, "red", constants.ColorRed),
+			models.NewPlayer(2, "green", constants.ColorGreen),
+			models.NewPlayer(3, "blue", constants.ColorBlue),
+		},
+		State: constants.StateWaiting,
+	}
+	e := NewEngine(room, models.RuleConfig{}, EngineCallbacks{})
+	e.Reseed(seed)
+	e.SetTurnTimeout(time.Hour)
+	if err := e.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer stopTurnTimer(e)
+
+	for turns := 0; playTurn(e); turns++ {
+		if turns == maxBenchTurns {
+			t.Fatal("game never ended")
+		}
+	}
+	return e.GetGameState()
+}
+
+func TestRebuiltGameMatchesTheOriginal(t *testing.T) {
+	played := playedGame(t, 11)
+	if played.Winner == nil {
+		t.Fatal("no winner in the played game")
+	}
+
+	// La partie passe par son format de stockage
+	data, err := json.Marshal(played)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored *models.Game
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(stored); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	// Sans graine, le premier lancer désigne le premier joueur
+	unseeded := *stored
+	unseeded.Seed = 0
+	if err := Verify(&unseeded); err != nil {
+		t.Errorf("Verify without seed: %v", err)
+	}
+}
+
+func TestVerifyReportsDivergentMove(t *testing.T) {
+	stored := playedGame(t, 11)
+
+	history := make([]models.TurnAction, len(stored.TurnHistory))
+	copy(history, stored.TurnHistory)
+	for i, action := range history {
+		if action.TokenMoved != nil && action.FromPos >= 0 {
+			history[i].ToPos++
+			break
+		}
+	}
+
+	_, err := Rebuild(stored.Room, stored.Seed, history)
+	if err == nil || !strings.Contains(err.Error(), "recorded") {
+		t.Errorf("Rebuild error = %v, want a divergent move", err)
+	}
+}
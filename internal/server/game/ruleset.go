@@ -0,0 +1,47 @@
+// internal/server/game/ruleset.go
+package game
+
+import (
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// RuleSet décrit la forme d'une partie: nombre de pions, placement de
+// départ et condition de victoire. Les déplacements (internal/shared/moves)
+// et les variantes scriptées (pkg/rules) sont communs à tous les jeux de
+// règles: une nouvelle forme de partie n'est qu'une entrée de ruleSets.
+type RuleSet struct {
+	Name    constants.RuleSet
+	Tokens  int // Pions par joueur
+	OnBoard int // Pions posés sur leur case de départ au début de la partie
+	ToWin   int // Pions à ramener à la maison pour gagner
+}
+
+// ruleSets liste les jeux de règles proposés
+var ruleSets = map[constants.RuleSet]RuleSet{
+	constants.RuleSetClassic: {Name: constants.RuleSetClassic, Tokens: constants.TokensPerPlayer, ToWin: constants.TokensPerPlayer},
+	constants.RuleSetQuick:   {Name: constants.RuleSetQuick, Tokens: 2, OnBoard: 1, ToWin: 1},
+}
+
+// LookupRuleSet retrouve un jeu de règles par son nom
+func LookupRuleSet(name constants.RuleSet) (RuleSet, bool) {
+	rs, ok := ruleSets[name]
+	return rs, ok
+}
+
+// setup prépare les pions d'un joueur au début de la partie
+func (rs RuleSet) setup(e *Engine, player *models.Player) {
+	if len(player.Tokens) > rs.Tokens {
+		player.Tokens = player.Tokens[:rs.Tokens]
+	}
+	for _, token := range player.Tokens[:rs.OnBoard] {
+		if token.Position == -1 {
+			e.moveTokenToPosition(token, constants.StartingPositions[player.Color], player.Color)
+		}
+	}
+}
+
+// won indique que le joueur a ramené assez de pions pour gagner
+func (rs RuleSet) won(player *models.Player) bool {
+	return tokensHome(player) >= rs.ToWin
+}
@@ -0,0 +1,60 @@
+// internal/server/game/takeover.go
+package game
+
+import (
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/ai"
+)
+
+// takeoverLevel est le niveau de l'IA qui reprend la place d'un joueur parti
+const takeoverLevel = "medium"
+
+// TakeOver confie à l'IA la place d'un joueur parti en cours de partie. Si
+// c'est son tour et qu'il n'a pas encore lancé le dé, l'IA joue tout de
+// suite; sinon le minuteur du tour finit de le passer.
+func (e *Engine) TakeOver(playerID int64) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.game.Room.State != constants.StatePlaying {
+		return i18n.NewError(constants.ErrGameNotStarted, nil)
+	}
+	var player *models.Player
+	for _, p := range e.game.Room.Players {
+		if p.ID == playerID {
+			player = p
+		}
+	}
+	if player == nil {
+		return i18n.NewError(constants.ErrPlayerNotInRoom, nil)
+	}
+	if player.IsAI {
+		return nil
+	}
+
+	player.IsAI = true
+	player.AILevel = takeoverLevel
+	bot := ai.NewAIPlayer(takeoverLevel)
+	bot.House = e.house
+	e.ai[player.ID] = bot
+
+	current := e.game.Room.Players[e.game.Room.CurrentTurn]
+	if current != player || e.rolled || e.rebuilding {
+		return nil
+	}
+	if e.turnTimer != nil {
+		e.turnTimer.Stop()
+	}
+	e.deadline = time.Time{}
+	if e.paused {
+		// L'IA jouera à la reprise
+		e.turnPending = true
+		return nil
+	}
+	go e.handleAITurn(player)
+	return nil
+}
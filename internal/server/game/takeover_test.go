@@ -0,0 +1,51 @@
+// internal/server/game/takeover_test.go
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestTakeOverPlaysCurrentTurn(t *testing.T) {
+	red := models.NewPlayer(1, "red", constants.ColorRed)
+	blue := models.NewPlayer(2, "blue", constants.ColorBlue)
+	room := &models.Room{Players: []*models.Player{red, blue}, State: constants.StateWaiting}
+
+	rolls := make(chan int64, 8)
+	e := NewEngine(room, models.RuleConfig{}, EngineCallbacks{
+		OnDiceRolled: func(playerID int64, value int, extraTurn bool) { rolls <- playerID },
+	})
+	defer stopTurnTimer(e)
+	e.SetTurnTimeout(time.Minute)
+
+	if err := e.TakeOver(red.ID); errCode(err) != constants.ErrGameNotStarted {
+		t.Fatalf("takeover before the game = %v, want %s", err, constants.ErrGameNotStarted)
+	}
+
+	e.SetStarter(0)
+	if err := e.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.TakeOver(red.ID); err != nil {
+		t.Fatal(err)
+	}
+	if !red.IsAI || e.TurnRemaining() != 0 {
+		t.Fatal("expected the AI to hold red's seat without a turn timer")
+	}
+
+	select {
+	case id := <-rolls:
+		if id != red.ID {
+			t.Errorf("rolled for player %d, want %d", id, red.ID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the AI never played the dropped player's turn")
+	}
+
+	if err := e.TakeOver(99); errCode(err) != constants.ErrPlayerNotInRoom {
+		t.Errorf("takeover of a stranger = %v, want %s", err, constants.ErrPlayerNotInRoom)
+	}
+}
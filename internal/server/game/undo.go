@@ -0,0 +1,104 @@
+// internal/server/game/undo.go
+package game
+
+import (
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// undoGrace retarde le lancer d'une IA qui suit un coup annulable, pour
+// laisser au joueur le temps de le reprendre
+const undoGrace = 2 * time.Second
+
+// undoPoint est l'état de la partie juste avant le dernier coup d'un
+// joueur humain, gardé jusqu'au lancer suivant
+type undoPoint struct {
+	playerID    int64
+	turn        int // CurrentTurn avant le coup
+	history     int // Longueur de l'historique avant le coup
+	stalled     int
+	score       int
+	decisions   int
+	decisionMs  int64
+	actionStart time.Time
+	tokens      map[*models.Token]models.Token
+}
+
+// saveUndoPoint mémorise l'état avant le coup du joueur courant, si la
+// règle maison le permet. Appelé sous le verrou du moteur.
+func (e *Engine) saveUndoPoint(player *models.Player) *undoPoint {
+	if !e.house.Undo || player.IsAI || e.rebuilding {
+		return nil
+	}
+	point := &undoPoint{
+		playerID:    player.ID,
+		turn:        e.game.Room.CurrentTurn,
+		history:     len(e.game.TurnHistory),
+		stalled:     e.stalled,
+		score:       player.Score,
+		decisions:   player.Decisions,
+		decisionMs:  player.DecisionMs,
+		actionStart: e.actionStart,
+		tokens:      make(map[*models.Token]models.Token),
+	}
+	for _, p := range e.game.Room.Players {
+		for _, token := range p.Tokens {
+			point.tokens[token] = *token
+		}
+	}
+	return point
+}
+
+// Undo reprend le dernier coup du joueur, tant que personne n'a relancé le
+// dé depuis: les pions retrouvent leur case, y compris un pion pris, et le
+// joueur rejoue avec le même dé.
+func (e *Engine) Undo(playerID int64) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.house.Undo {
+		return i18n.NewError(constants.ErrUndoDisabled, nil)
+	}
+	if e.game.Room.State != constants.StatePlaying {
+		return i18n.NewError(constants.ErrGameNotStarted, nil)
+	}
+	if e.paused {
+		return i18n.NewError(constants.ErrGamePaused, nil)
+	}
+	point := e.undo
+	if point == nil || point.playerID != playerID {
+		return i18n.NewError(constants.ErrNothingToUndo, nil)
+	}
+	e.undo = nil
+
+	// Pions remis en place par la pile des cases, comme pendant la partie
+	for _, p := range e.game.Room.Players {
+		for _, token := range p.Tokens {
+			saved := point.tokens[token]
+			if token.Position != saved.Position {
+				e.moveTokenToPosition(token, saved.Position, p.Color)
+			}
+			token.IsHome, token.IsSafe, token.Laps = saved.IsHome, saved.IsSafe, saved.Laps
+		}
+	}
+
+	room := e.game.Room
+	room.CurrentTurn = point.turn
+	e.game.TurnHistory = e.game.TurnHistory[:point.history]
+	e.stalled = point.stalled
+	e.rolled = true
+	e.spin = nil
+	e.actionStart = point.actionStart
+
+	player := room.Players[point.turn]
+	player.Score, player.Decisions, player.DecisionMs = point.score, point.decisions, point.decisionMs
+
+	if e.callbacks.OnMoveUndone != nil {
+		e.callbacks.OnMoveUndone(playerID, room.LastDice)
+	}
+	e.startTurnTimer(playerID, e.turnTimeout)
+	return nil
+}
@@ -0,0 +1,82 @@
+// internal/server/game/undo_test.go
+package game
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestUndoRestoresCaptureUntilNextRoll(t *testing.T) {
+	red := models.NewPlayer(1, "red", constants.ColorRed)
+	blue := models.NewPlayer(2, "blue", constants.ColorBlue)
+	red.Tokens[0].Position = 1
+	blue.Tokens[0].Position = 4
+	room := &models.Room{Players: []*models.Player{red, blue}, State: constants.StateWaiting}
+
+	undone := make(chan int, 1)
+	e := NewEngine(room, models.RuleConfig{Undo: true}, EngineCallbacks{
+		OnMoveUndone: func(playerID int64, dice int) { undone <- dice },
+	})
+	defer stopTurnTimer(e)
+	e.SetStarter(0)
+	if err := e.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	e.mu.Lock()
+	e.applyRoll(red, 3)
+	e.mu.Unlock()
+	history := len(e.game.TurnHistory)
+	if err := e.MoveToken(red.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	if blue.Tokens[0].Position != -1 || room.CurrentTurn != 1 {
+		t.Fatalf("expected a capture and blue's turn, got blue pawn on %d, turn %d", blue.Tokens[0].Position, room.CurrentTurn)
+	}
+
+	if err := e.Undo(blue.ID); errCode(err) != constants.ErrNothingToUndo {
+		t.Errorf("blue undo = %v, want %s", err, constants.ErrNothingToUndo)
+	}
+	if err := e.Undo(red.ID); err != nil {
+		t.Fatal(err)
+	}
+	if dice := <-undone; dice != 3 {
+		t.Errorf("undone with dice %d, want 3", dice)
+	}
+	if red.Tokens[0].Position != 1 || blue.Tokens[0].Position != 4 {
+		t.Errorf("pawns on %d and %d after undo, want 1 and 4", red.Tokens[0].Position, blue.Tokens[0].Position)
+	}
+	if room.CurrentTurn != 0 || !e.Rolled() || len(e.game.TurnHistory) != history {
+		t.Errorf("turn %d, rolled %v, %d actions after undo", room.CurrentTurn, e.Rolled(), len(e.game.TurnHistory))
+	}
+	if e.game.Board.Cells[4].Opponent(constants.ColorRed) != blue.Tokens[0] {
+		t.Error("blue pawn missing from its cell after undo")
+	}
+
+	// Le coup se rejoue, puis le lancer suivant le rend définitif
+	if err := e.MoveToken(red.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := e.RollDice(blue.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Undo(red.ID); errCode(err) != constants.ErrNothingToUndo {
+		t.Errorf("undo after the next roll = %v, want %s", err, constants.ErrNothingToUndo)
+	}
+}
+
+func TestUndoNeedsHouseRule(t *testing.T) {
+	red := models.NewPlayer(1, "red", constants.ColorRed)
+	blue := models.NewPlayer(2, "blue", constants.ColorBlue)
+	room := &models.Room{Players: []*models.Player{red, blue}, State: constants.StateWaiting}
+	e := NewEngine(room, models.RuleConfig{}, EngineCallbacks{})
+	defer stopTurnTimer(e)
+	if err := e.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Undo(red.ID); errCode(err) != constants.ErrUndoDisabled {
+		t.Errorf("undo = %v, want %s", err, constants.ErrUndoDisabled)
+	}
+}
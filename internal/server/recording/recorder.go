@@ -0,0 +1,134 @@
+// internal/server/recording/recorder.go
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// lobbyFile reçoit les messages des connexions qui ne sont dans aucune salle
+const lobbyFile = "lobby"
+
+// Entry est une ligne d'un enregistrement (JSON Lines)
+type Entry struct {
+	AtMs    int64                  `json:"at_ms"` // Depuis le début de l'enregistrement
+	Conn    uint64                 `json:"conn"`
+	Room    string                 `json:"room,omitempty"`
+	Seed    int64                  `json:"seed,omitempty"` // Graine du moteur de la salle
+	Message *models.NetworkMessage `json:"message,omitempty"`
+}
+
+// Recorder écrit les messages entrants de chaque salle dans un fichier
+type Recorder struct {
+	dir   string
+	start time.Time
+	files map[string]*os.File
+	mu    sync.Mutex
+}
+
+// NewRecorder crée un enregistreur dans le répertoire donné
+func NewRecorder(dir string) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Recorder{
+		dir:   dir,
+		start: time.Now(),
+		files: make(map[string]*os.File),
+	}, nil
+}
+
+// Record enregistre un message reçu d'une connexion
+func (r *Recorder) Record(at time.Time, connID uint64, roomID string, msg *models.NetworkMessage) {
+	r.write(roomID, Entry{
+		AtMs:    at.Sub(r.start).Milliseconds(),
+		Conn:    connID,
+		Room:    roomID,
+		Message: msg,
+	})
+}
+
+// RecordSeed enregistre la graine du moteur d'une salle
+func (r *Recorder) RecordSeed(roomID string, seed int64) {
+	r.write(roomID, Entry{
+		AtMs: time.Since(r.start).Milliseconds(),
+		Room: roomID,
+		Seed: seed,
+	})
+}
+
+func (r *Recorder) write(roomID string, entry Entry) {
+	if roomID == "" {
+		roomID = lobbyFile
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	file, ok := r.files[roomID]
+	if !ok {
+		path := filepath.Join(r.dir, roomID+".jsonl")
+		file, err = os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return
+		}
+		r.files[roomID] = file
+	}
+	file.Write(append(data, '\n'))
+}
+
+// Close ferme tous les fichiers d'enregistrement
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var firstErr error
+	for _, file := range r.files {
+		if err := file.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	r.files = make(map[string]*os.File)
+	return firstErr
+}
+
+// ReadFile lit un enregistrement et retourne ses entrées triées par date
+func ReadFile(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := make([]Entry, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].AtMs < entries[j].AtMs
+	})
+	return entries, nil
+}
@@ -0,0 +1,41 @@
+// internal/server/recording/recorder_test.go
+package recording
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestRecordAndReadRoom(t *testing.T) {
+	dir := t.TempDir()
+	r, err := NewRecorder(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	r.RecordSeed("ABC234", 42)
+	r.Record(now.Add(20*time.Millisecond), 2, "ABC234", &models.NetworkMessage{Type: constants.MsgRollDice})
+	r.Record(now.Add(10*time.Millisecond), 1, "ABC234", &models.NetworkMessage{Type: constants.MsgReady})
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ReadFile(filepath.Join(dir, "ABC234.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	if entries[0].Seed != 42 {
+		t.Errorf("first entry seed = %d, want 42", entries[0].Seed)
+	}
+	if entries[1].Message.Type != constants.MsgReady || entries[2].Message.Type != constants.MsgRollDice {
+		t.Errorf("entries not sorted by time: %s, %s", entries[1].Message.Type, entries[2].Message.Type)
+	}
+}
@@ -0,0 +1,100 @@
+// internal/server/room/host.go
+package room
+
+import (
+	"github.com/obrien-tchaleu/ludo-king-go/internal/server/game"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/roles"
+)
+
+// CheckHost vérifie qu'un joueur est l'hôte de la salle
+func CheckHost(room *models.Room, playerID int64) error {
+	if room.HostID != playerID {
+		return i18n.NewError(constants.ErrHostOnly, nil)
+	}
+	return nil
+}
+
+// KickPlayer exclut un joueur de rang inférieur de la salle en attente
+func (r *Room) KickPlayer(actorID, playerID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := roles.Check(r.Model, actorID, constants.PermKick); err != nil {
+		return err
+	}
+	if playerID == actorID {
+		return i18n.NewError(constants.ErrCannotKickSelf, nil)
+	}
+	if !roles.Outranks(r.Model, actorID, playerID) {
+		return i18n.NewError(constants.ErrPermissionDenied, i18n.Params{"permission": string(constants.PermKick)})
+	}
+	if _, err := game.RemovePlayer(r.Model, playerID); err != nil {
+		return err
+	}
+	roles.Forget(r.Model, playerID)
+	delete(r.players, playerID)
+	return nil
+}
+
+// AssignColor change la couleur d'un joueur avant la partie. Le joueur qui
+// avait cette couleur prend l'ancienne.
+func (r *Room) AssignColor(actorID, playerID int64, color constants.PlayerColor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := roles.Check(r.Model, actorID, constants.PermChangeRules); err != nil {
+		return err
+	}
+	return game.AssignColor(r.Model, playerID, color)
+}
+
+// StartBy lance la partie sans attendre que tous les joueurs soient prêts
+func (r *Room) StartBy(actorID int64) error {
+	r.mu.RLock()
+	err := roles.Check(r.Model, actorID, constants.PermStartGame)
+	r.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	return r.Start()
+}
+
+// SetRole nomme un joueur modérateur ou le rend membre
+func (r *Room) SetRole(actorID, playerID int64, role constants.RoomRole) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := roles.Check(r.Model, actorID, constants.PermManageRoles); err != nil {
+		return err
+	}
+	return roles.Assign(r.Model, playerID, role)
+}
+
+// SetPermissions remplace les permissions d'un rôle de la salle
+func (r *Room) SetPermissions(actorID int64, role constants.RoomRole, perms []constants.Permission) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := roles.Check(r.Model, actorID, constants.PermManageRoles); err != nil {
+		return err
+	}
+	return roles.SetPermissions(r.Model, role, perms)
+}
+
+// Mute retire ou rend la parole à un joueur de rang inférieur
+func (r *Room) Mute(actorID, playerID int64, muted bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := roles.Check(r.Model, actorID, constants.PermModerateChat); err != nil {
+		return err
+	}
+	if !roles.Outranks(r.Model, actorID, playerID) {
+		return i18n.NewError(constants.ErrPermissionDenied, i18n.Params{"permission": string(constants.PermModerateChat)})
+	}
+	roles.SetMuted(r.Model, playerID, muted)
+	return nil
+}
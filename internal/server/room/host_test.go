@@ -0,0 +1,60 @@
+// internal/server/room/host_test.go
+package room
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+)
+
+func TestKickPlayerNeedsPermission(t *testing.T) {
+	m := NewManager()
+	created, err := m.CreateRoom("lobby", 1, "host", 4, "online", false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := created.Model.ID
+	if _, err := m.JoinRoom(id, 2, "guest", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := created.KickPlayer(2, 1); errorCode(err) != constants.ErrPermissionDenied {
+		t.Errorf("guest kicking the host = %v, want %s", err, constants.ErrPermissionDenied)
+	}
+	if err := created.KickPlayer(1, 1); errorCode(err) != constants.ErrCannotKickSelf {
+		t.Errorf("host kicking themself = %v, want %s", err, constants.ErrCannotKickSelf)
+	}
+	if err := created.KickPlayer(1, 2); err != nil {
+		t.Fatalf("host kicking the guest: %v", err)
+	}
+	if created.GetPlayerCount() != 1 || len(created.Model.Players) != 1 {
+		t.Error("kicked player is still in the room")
+	}
+}
+
+func TestModeratorKicksMembersOnly(t *testing.T) {
+	m := NewManager()
+	created, err := m.CreateRoom("lobby", 1, "host", 4, "online", false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := created.Model.ID
+	for _, guest := range []int64{2, 3} {
+		if _, err := m.JoinRoom(id, guest, "guest", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := created.SetRole(2, 3, constants.RoleModerator); errorCode(err) != constants.ErrPermissionDenied {
+		t.Errorf("guest naming a moderator = %v, want %s", err, constants.ErrPermissionDenied)
+	}
+	if err := created.SetRole(1, 2, constants.RoleModerator); err != nil {
+		t.Fatalf("host naming a moderator: %v", err)
+	}
+	if err := created.KickPlayer(2, 1); errorCode(err) != constants.ErrPermissionDenied {
+		t.Errorf("moderator kicking the host = %v, want %s", err, constants.ErrPermissionDenied)
+	}
+	if err := created.KickPlayer(2, 3); err != nil {
+		t.Fatalf("moderator kicking a member: %v", err)
+	}
+}
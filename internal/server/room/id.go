@@ -0,0 +1,38 @@
+// internal/server/room/id.go
+package room
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// Alphabet des codes de salle, sans caractères ambigus (0/O, 1/I/L)
+const roomIDAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// RoomIDLength est la longueur des codes de salle
+const RoomIDLength = 6
+
+// GenerateRoomID génère un code de salle court, distinct des salles actives.
+// exists indique si un code est déjà utilisé.
+func GenerateRoomID(exists func(id string) bool) string {
+	for {
+		id := randomCode(RoomIDLength)
+		if exists == nil || !exists(id) {
+			return id
+		}
+	}
+}
+
+// randomCode tire un code aléatoire dans l'alphabet des salles
+func randomCode(length int) string {
+	max := big.NewInt(int64(len(roomIDAlphabet)))
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			panic(err) // crypto/rand ne doit pas échouer
+		}
+		code[i] = roomIDAlphabet[n.Int64()]
+	}
+	return string(code)
+}
@@ -0,0 +1,45 @@
+// internal/server/room/id_test.go
+package room
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateRoomIDAlphabetAndLength(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		id := GenerateRoomID(nil)
+		if len(id) != RoomIDLength {
+			t.Fatalf("%q: got length %d, want %d", id, len(id), RoomIDLength)
+		}
+		for _, r := range id {
+			if !strings.ContainsRune(roomIDAlphabet, r) {
+				t.Fatalf("%q: %q is outside the room code alphabet", id, r)
+			}
+		}
+		// Les caractères ambigus ne doivent jamais apparaître
+		if strings.ContainsAny(id, "0O1IL") {
+			t.Fatalf("%q contains an ambiguous character", id)
+		}
+	}
+}
+
+func TestGenerateRoomIDSkipsUsedCodes(t *testing.T) {
+	used := make(map[string]bool)
+	calls := 0
+	id := GenerateRoomID(func(id string) bool {
+		calls++
+		// Les trois premiers tirages sont déjà pris
+		if calls <= 3 {
+			used[id] = true
+			return true
+		}
+		return used[id]
+	})
+	if calls < 4 {
+		t.Fatalf("expected a retry after used codes, got %d calls", calls)
+	}
+	if used[id] {
+		t.Fatalf("got used code %q", id)
+	}
+}
@@ -0,0 +1,62 @@
+// internal/server/room/list.go
+package room
+
+import (
+	"sort"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// Listed indique si la salle apparaît dans la liste publique: publique,
+// en attente de joueurs et pas encore complète
+func Listed(room *models.Room) bool {
+	return !room.IsPrivate && room.State == constants.StateWaiting && len(room.Players) < room.MaxPlayers
+}
+
+// Summarize résume une salle pour la liste publique
+func Summarize(room *models.Room) models.RoomSummary {
+	summary := models.RoomSummary{
+		ID:         room.ID,
+		Name:       room.Name,
+		Players:    len(room.Players),
+		MaxPlayers: room.MaxPlayers,
+		BestOf:     room.BestOf,
+		Variant:    room.Rules != "",
+		SkillDice:  room.SkillDice,
+		E2EChat:    room.E2EChat,
+		Party:      room.Theme != nil,
+		RuleSet:    room.RuleSet,
+		HouseRules: !room.HouseRules.Classic(),
+		CreatedAt:  room.CreatedAt,
+	}
+	for _, player := range room.Players {
+		if player.ID == room.HostID {
+			summary.HostName = player.Username
+		}
+	}
+	return summary
+}
+
+// List résume les salles listées, dans l'ordre de SortSummaries
+func List(rooms []*models.Room) []models.RoomSummary {
+	summaries := make([]models.RoomSummary, 0, len(rooms))
+	for _, room := range rooms {
+		if Listed(room) {
+			summaries = append(summaries, Summarize(room))
+		}
+	}
+	SortSummaries(summaries)
+	return summaries
+}
+
+// SortSummaries classe les salles les plus remplies d'abord, puis les plus
+// récentes: une table presque complète démarre plus vite
+func SortSummaries(summaries []models.RoomSummary) {
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Players != summaries[j].Players {
+			return summaries[i].Players > summaries[j].Players
+		}
+		return summaries[i].CreatedAt.After(summaries[j].CreatedAt)
+	})
+}
@@ -0,0 +1,49 @@
+// internal/server/room/list_test.go
+package room
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func newListRoom(id string, players, max int, created time.Time) *models.Room {
+	room := &models.Room{ID: id, Name: "room " + id, HostID: 1, MaxPlayers: max, State: constants.StateWaiting, CreatedAt: created}
+	for i := 0; i < players; i++ {
+		room.Players = append(room.Players, models.NewPlayer(int64(i+1), "p"+string(rune('a'+i)), constants.ColorRed))
+	}
+	return room
+}
+
+func TestListShowsOpenPublicRooms(t *testing.T) {
+	now := time.Now()
+	private := newListRoom("PRIV", 1, 4, now)
+	private.IsPrivate = true
+	playing := newListRoom("PLAY", 2, 4, now)
+	playing.State = constants.StatePlaying
+
+	rooms := []*models.Room{
+		newListRoom("OLD", 1, 4, now.Add(-time.Minute)),
+		newListRoom("FULL", 2, 2, now),
+		newListRoom("NEW", 1, 4, now),
+		newListRoom("BUSY", 3, 4, now.Add(-time.Hour)),
+		private,
+		playing,
+	}
+
+	list := List(rooms)
+	want := []string{"BUSY", "NEW", "OLD"}
+	if len(list) != len(want) {
+		t.Fatalf("listed %d rooms, want %d", len(list), len(want))
+	}
+	for i, id := range want {
+		if list[i].ID != id {
+			t.Errorf("room %d = %s, want %s", i, list[i].ID, id)
+		}
+	}
+	if list[0].HostName != "pa" || list[0].Players != 3 || list[0].MaxPlayers != 4 {
+		t.Errorf("summary = %+v, want host pa with 3/4 players", list[0])
+	}
+}
@@ -23,13 +23,25 @@ func NewManager() *Manager {
 	}
 }
 
-// CreateRoom crée une nouvelle salle
-func (m *Manager) CreateRoom(name string, hostID int64, hostName string, maxPlayers int, gameMode string, isPrivate bool) (*Room, error) {
+// CreateRoom crée une nouvelle salle. Un mot de passe rend la salle privée.
+func (m *Manager) CreateRoom(name string, hostID int64, hostName string, maxPlayers int, gameMode string, isPrivate bool, password string) (*Room, error) {
+	var hash string
+	if password != "" {
+		var err error
+		if hash, err = HashPassword(password); err != nil {
+			return nil, err
+		}
+		isPrivate = true
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Générer un ID unique
-	roomID := generateRoomID()
+	// Générer un code unique parmi les salles actives
+	roomID := GenerateRoomID(func(id string) bool {
+		_, exists := m.rooms[id]
+		return exists
+	})
 
 	// Créer la room model
 	roomModel := &models.Room{
@@ -42,6 +54,7 @@ func (m *Manager) CreateRoom(name string, hostID int64, hostName string, maxPlay
 		State:      constants.StateWaiting,
 		CreatedAt:  time.Now(),
 		IsPrivate:  isPrivate,
+		Password:   hash,
 	}
 
 	// Créer le joueur hôte
@@ -84,13 +97,18 @@ func (m *Manager) GetRoom(roomID string) (*Room, error) {
 	return room, nil
 }
 
-// JoinRoom permet à un joueur de rejoindre une salle
-func (m *Manager) JoinRoom(roomID string, playerID int64, username string) (*Room, error) {
+// JoinRoom permet à un joueur de rejoindre une salle, avec son mot de
+// passe si elle en a un
+func (m *Manager) JoinRoom(roomID string, playerID int64, username, password string) (*Room, error) {
 	room, err := m.GetRoom(roomID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := CheckPassword(room.Model, password); err != nil {
+		return nil, err
+	}
+
 	if err := room.AddPlayer(playerID, username); err != nil {
 		return nil, err
 	}
@@ -124,7 +142,7 @@ func (m *Manager) ListRooms() []*models.Room {
 
 	rooms := make([]*models.Room, 0)
 	for _, room := range m.rooms {
-		if !room.Model.IsPrivate && room.Model.State == constants.StateWaiting {
+		if Listed(room.Model) {
 			rooms = append(rooms, room.Model)
 		}
 	}
@@ -150,8 +168,3 @@ func (m *Manager) CleanupEmptyRooms() {
 		}
 	}
 }
-
-// generateRoomID génère un ID unique pour une salle
-func generateRoomID() string {
-	return fmt.Sprintf("ROOM_%d", time.Now().UnixNano())
-}
@@ -0,0 +1,41 @@
+// internal/server/room/password.go
+package room
+
+import (
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// MaxPasswordLength borne les mots de passe de salle: bcrypt ignore les
+// octets suivants
+const MaxPasswordLength = 72
+
+// HashPassword retourne l'empreinte bcrypt à stocker dans Room.Password
+func HashPassword(password string) (string, error) {
+	if len(password) > MaxPasswordLength {
+		return "", i18n.NewError(constants.ErrPasswordTooLong, i18n.Params{"max": MaxPasswordLength})
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword vérifie le mot de passe donné pour entrer dans la salle.
+// Une salle sans mot de passe accepte tout le monde.
+func CheckPassword(room *models.Room, password string) error {
+	if room.Password == "" {
+		return nil
+	}
+	if password == "" {
+		return i18n.NewError(constants.ErrRoomPasswordRequired, nil)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(room.Password), []byte(password)) != nil {
+		return i18n.NewError(constants.ErrRoomPasswordWrong, nil)
+	}
+	return nil
+}
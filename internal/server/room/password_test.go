@@ -0,0 +1,47 @@
+// internal/server/room/password_test.go
+package room
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func errorCode(err error) string {
+	var coded *i18n.Error
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	return ""
+}
+
+func TestJoinPrivateRoomChecksPassword(t *testing.T) {
+	m := NewManager()
+	created, err := m.CreateRoom("secret", 1, "host", 4, "online", false, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created.Model.IsPrivate || created.Model.Password == "hunter2" {
+		t.Fatal("a password should make the room private and be stored hashed")
+	}
+
+	id := created.Model.ID
+	if _, err := m.JoinRoom(id, 2, "guest", ""); errorCode(err) != constants.ErrRoomPasswordRequired {
+		t.Errorf("join without password = %v, want %s", err, constants.ErrRoomPasswordRequired)
+	}
+	if _, err := m.JoinRoom(id, 2, "guest", "wrong"); errorCode(err) != constants.ErrRoomPasswordWrong {
+		t.Errorf("join with wrong password = %v, want %s", err, constants.ErrRoomPasswordWrong)
+	}
+	if _, err := m.JoinRoom(id, 2, "guest", "hunter2"); err != nil {
+		t.Errorf("join with the password: %v", err)
+	}
+}
+
+func TestOpenRoomNeedsNoPassword(t *testing.T) {
+	if err := CheckPassword(&models.Room{}, ""); err != nil {
+		t.Errorf("room without password refused a player: %v", err)
+	}
+}
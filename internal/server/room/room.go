@@ -51,23 +51,12 @@ func (r *Room) AddPlayer(playerID int64, username string) error {
 		return fmt.Errorf("player already in room")
 	}
 
-	// Choisir une couleur disponible
-	colors := []constants.PlayerColor{
-		constants.ColorRed, constants.ColorBlue,
-		constants.ColorGreen, constants.ColorYellow,
-	}
+	// Choisir une couleur disponible (en diagonale à deux joueurs)
 	usedColors := make(map[constants.PlayerColor]bool)
 	for _, p := range r.Model.Players {
 		usedColors[p.Color] = true
 	}
-
-	var playerColor constants.PlayerColor
-	for _, c := range colors {
-		if !usedColors[c] {
-			playerColor = c
-			break
-		}
-	}
+	playerColor := constants.FreeSeat(r.Model.MaxPlayers, usedColors)
 
 	// Créer le joueur
 	player := models.NewPlayer(playerID, username, playerColor)
@@ -198,18 +187,19 @@ func (r *Room) Start() error {
 					PlayerID: playerID,
 				}
 			},
-			OnGameOver: func(winner *models.Player, rankings []*models.Player) {
+			OnGameOver: func(winner *models.Player, rankings []*models.Player, reason string) {
 				r.messages <- &RoomMessage{
 					Type: "game_over",
 					Data: map[string]interface{}{
 						"winner":   winner,
 						"rankings": rankings,
+						"reason":   reason,
 					},
 				}
 			},
 		}
 
-		r.Engine = game.NewEngine(r.Model, callbacks)
+		r.Engine = game.NewEngine(r.Model, r.Model.HouseRules, callbacks)
 	}
 
 	// Démarrer le moteur
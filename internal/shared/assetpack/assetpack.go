@@ -0,0 +1,216 @@
+// internal/shared/assetpack/assetpack.go
+//
+// Un pack de ressources est une archive zip contenant un manifest.json,
+// une image de plateau, des sprites de pions et des sons, qui remplacent
+// ceux du client sans nouvelle version. Un pack peut aussi fournir le décor
+// et les morceaux des salles à thème ("party rooms"). Exemple de manifeste:
+//
+//	{
+//	  "name": "halloween",
+//	  "title": "Halloween",
+//	  "version": 1,
+//	  "season": {"from": "10-20", "to": "11-02"},
+//	  "board": "board.png",
+//	  "tokens": {"red": "tokens/red.png", "blue": "tokens/blue.png"},
+//	  "sounds": {"dice_roll": "sounds/dice.mp3"},
+//	  "lobby": "lobby.png",
+//	  "music": {"spooky": "music/spooky.mp3"}
+//	}
+package assetpack
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/png" // Décodeur des images des packs
+	"io"
+	"path"
+	"regexp"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+)
+
+// Limites appliquées aux archives téléchargées
+const (
+	ManifestFile = "manifest.json"
+	MaxPackSize  = 20 << 20 // Taille maximale d'une archive
+	maxEntrySize = 8 << 20  // Taille maximale d'un fichier décompressé
+)
+
+// validName limite les noms de packs à ce qui peut servir de nom de fichier
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// Season est la période de l'année où le pack s'active, au format "MM-JJ".
+// Une période peut chevaucher le nouvel an ("12-15" à "01-06").
+type Season struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Manifest décrit le contenu d'un pack
+type Manifest struct {
+	Name    string                           `json:"name"`
+	Title   string                           `json:"title"`
+	Version int                              `json:"version"`
+	Season  *Season                          `json:"season,omitempty"` // nil = jamais automatique
+	Board   string                           `json:"board,omitempty"`
+	Tokens  map[constants.PlayerColor]string `json:"tokens,omitempty"`
+	Sounds  map[string]string                `json:"sounds,omitempty"`
+	Lobby   string                           `json:"lobby,omitempty"` // Décor du lobby des salles à thème
+	Music   map[string]string                `json:"music,omitempty"` // Morceaux proposés aux salles à thème
+}
+
+// Pack est un pack chargé en mémoire
+type Pack struct {
+	Manifest Manifest
+	Board    image.Image
+	Tokens   map[constants.PlayerColor]image.Image
+	Sounds   map[string][]byte // Nom du son -> contenu du fichier
+	Lobby    image.Image
+	Music    map[string][]byte // Nom du morceau -> contenu du fichier
+}
+
+// InSeason indique si la date tombe dans la saison du pack
+func (m *Manifest) InSeason(t time.Time) bool {
+	if m.Season == nil {
+		return false
+	}
+	day := t.Format("01-02")
+	if m.Season.From <= m.Season.To {
+		return day >= m.Season.From && day <= m.Season.To
+	}
+	return day >= m.Season.From || day <= m.Season.To
+}
+
+// ValidName indique si name peut nommer un pack ou un morceau
+func ValidName(name string) bool {
+	return validName.MatchString(name)
+}
+
+// Validate vérifie le manifeste
+func (m *Manifest) Validate() error {
+	if !ValidName(m.Name) {
+		return fmt.Errorf("invalid pack name %q", m.Name)
+	}
+	for track := range m.Music {
+		if !ValidName(track) {
+			return fmt.Errorf("invalid track name %q", track)
+		}
+	}
+	if m.Season != nil {
+		for _, day := range []string{m.Season.From, m.Season.To} {
+			if _, err := time.Parse("01-02", day); err != nil {
+				return fmt.Errorf("invalid season day %q", day)
+			}
+		}
+	}
+	return nil
+}
+
+// ReadManifest lit uniquement le manifeste d'une archive
+func ReadManifest(zipPath string) (*Manifest, error) {
+	archive, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+	return readManifest(&archive.Reader)
+}
+
+// Load charge une archive complète
+func Load(zipPath string) (*Pack, error) {
+	archive, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	manifest, err := readManifest(&archive.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	pack := &Pack{
+		Manifest: *manifest,
+		Tokens:   make(map[constants.PlayerColor]image.Image),
+		Sounds:   make(map[string][]byte),
+		Music:    make(map[string][]byte),
+	}
+
+	if manifest.Board != "" {
+		if pack.Board, err = readImage(&archive.Reader, manifest.Board); err != nil {
+			return nil, err
+		}
+	}
+	for color, name := range manifest.Tokens {
+		if pack.Tokens[color], err = readImage(&archive.Reader, name); err != nil {
+			return nil, err
+		}
+	}
+	for sound, name := range manifest.Sounds {
+		if pack.Sounds[sound], err = readEntry(&archive.Reader, name); err != nil {
+			return nil, err
+		}
+	}
+	if manifest.Lobby != "" {
+		if pack.Lobby, err = readImage(&archive.Reader, manifest.Lobby); err != nil {
+			return nil, err
+		}
+	}
+	for track, name := range manifest.Music {
+		if pack.Music[track], err = readEntry(&archive.Reader, name); err != nil {
+			return nil, err
+		}
+	}
+
+	return pack, nil
+}
+
+func readManifest(archive *zip.Reader) (*Manifest, error) {
+	data, err := readEntry(archive, ManifestFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+func readImage(archive *zip.Reader, name string) (image.Image, error) {
+	data, err := readEntry(archive, name)
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return img, nil
+}
+
+// readEntry lit un fichier de l'archive en bornant sa taille décompressée
+func readEntry(archive *zip.Reader, name string) ([]byte, error) {
+	file, err := archive.Open(path.Clean(name))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxEntrySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxEntrySize {
+		return nil, fmt.Errorf("%s: file too large", name)
+	}
+	return data, nil
+}
@@ -0,0 +1,78 @@
+// internal/shared/assetpack/assetpack_test.go
+package assetpack
+
+import (
+	"archive/zip"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+)
+
+func TestInSeasonWrapsAroundNewYear(t *testing.T) {
+	m := Manifest{Season: &Season{From: "12-15", To: "01-06"}}
+
+	cases := map[string]bool{
+		"2026-12-20": true,
+		"2027-01-03": true,
+		"2026-07-01": false,
+		"2026-12-14": false,
+	}
+	for day, want := range cases {
+		date, _ := time.Parse("2006-01-02", day)
+		if got := m.InSeason(date); got != want {
+			t.Errorf("InSeason(%s) = %v, want %v", day, got, want)
+		}
+	}
+}
+
+func TestLoadPack(t *testing.T) {
+	zipPath := filepath.Join(t.TempDir(), "winter.zip")
+	file, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := zip.NewWriter(file)
+
+	w, _ := archive.Create(ManifestFile)
+	w.Write([]byte(`{"name":"winter","title":"Winter","version":1,
+		"season":{"from":"12-01","to":"02-28"},
+		"tokens":{"red":"red.png"},"sounds":{"dice_roll":"dice.mp3"},
+		"lobby":"red.png","music":{"snowfall":"dice.mp3"}}`))
+	w, _ = archive.Create("red.png")
+	png.Encode(w, image.NewNRGBA(image.Rect(0, 0, 4, 4)))
+	w, _ = archive.Create("dice.mp3")
+	w.Write([]byte("sound"))
+	archive.Close()
+	file.Close()
+
+	pack, err := Load(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pack.Manifest.Title != "Winter" || pack.Tokens[constants.ColorRed] == nil {
+		t.Errorf("unexpected pack %+v", pack.Manifest)
+	}
+	if string(pack.Sounds["dice_roll"]) != "sound" {
+		t.Errorf("sound = %q", pack.Sounds["dice_roll"])
+	}
+	if pack.Lobby == nil || string(pack.Music["snowfall"]) != "sound" {
+		t.Errorf("expected the lobby art and the snowfall track, got %v and %q", pack.Lobby, pack.Music["snowfall"])
+	}
+}
+
+func TestValidateRejectsUnsafeNames(t *testing.T) {
+	m := Manifest{Name: "../evil"}
+	if err := m.Validate(); err == nil {
+		t.Fatal("expected an invalid name error")
+	}
+
+	m = Manifest{Name: "party", Music: map[string]string{"../track": "music/track.mp3"}}
+	if err := m.Validate(); err == nil {
+		t.Fatal("expected an invalid track name error")
+	}
+}
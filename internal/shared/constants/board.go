@@ -0,0 +1,43 @@
+// internal/shared/constants/board.go
+package constants
+
+// BoardQuadrants liste les quadrants du plateau dans le sens horaire, en
+// partant du haut à gauche. Chaque quadrant couvre un quart du parcours:
+// les départs, les entrées de la maison et les sièges en découlent.
+var BoardQuadrants = []PlayerColor{ColorRed, ColorGreen, ColorYellow, ColorBlue}
+
+// Positions de départ des couleurs
+var StartingPositions = quadrantPositions(0)
+
+// Chemins vers la maison, deux cases avant le départ
+var HomeStretchStart = quadrantPositions(-2)
+
+func quadrantPositions(offset int) map[PlayerColor]int {
+	positions := make(map[PlayerColor]int, len(BoardQuadrants))
+	for i, color := range BoardQuadrants {
+		positions[color] = (i*TotalCells/len(BoardQuadrants) + offset + TotalCells) % TotalCells
+	}
+	return positions
+}
+
+// SeatColors retourne les couleurs attribuées, dans l'ordre d'arrivée, aux
+// joueurs d'une salle. À deux, les joueurs se font face en diagonale.
+func SeatColors(maxPlayers int) []PlayerColor {
+	if maxPlayers == 2 {
+		return []PlayerColor{BoardQuadrants[0], BoardQuadrants[2]}
+	}
+	return append([]PlayerColor(nil), BoardQuadrants...)
+}
+
+// FreeSeat retourne la prochaine couleur libre d'une salle, ou "" si toutes
+// sont prises. Les quadrants hors des sièges prévus servent de repli.
+func FreeSeat(maxPlayers int, used map[PlayerColor]bool) PlayerColor {
+	for _, seats := range [][]PlayerColor{SeatColors(maxPlayers), BoardQuadrants} {
+		for _, color := range seats {
+			if !used[color] {
+				return color
+			}
+		}
+	}
+	return ""
+}
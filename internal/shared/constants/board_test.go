@@ -0,0 +1,46 @@
+// internal/shared/constants/board_test.go
+package constants
+
+import "testing"
+
+func TestTwoPlayerSeatingIsDiagonal(t *testing.T) {
+	seats := SeatColors(2)
+	if len(seats) != 2 || seats[0] != ColorRed || seats[1] != ColorYellow {
+		t.Fatalf("two-player seats = %v, want [red yellow]", seats)
+	}
+	if gap := StartingPositions[seats[1]] - StartingPositions[seats[0]]; gap != TotalCells/2 {
+		t.Errorf("diagonal seats are %d cells apart, want %d", gap, TotalCells/2)
+	}
+}
+
+func TestQuadrantPositions(t *testing.T) {
+	want := map[PlayerColor][2]int{
+		ColorRed:    {0, 50},
+		ColorGreen:  {13, 11},
+		ColorYellow: {26, 24},
+		ColorBlue:   {39, 37},
+	}
+	for color, positions := range want {
+		if StartingPositions[color] != positions[0] || HomeStretchStart[color] != positions[1] {
+			t.Errorf("%s: start %d, home entry %d; want %v",
+				color, StartingPositions[color], HomeStretchStart[color], positions)
+		}
+	}
+}
+
+func TestFreeSeat(t *testing.T) {
+	used := map[PlayerColor]bool{ColorRed: true}
+	if got := FreeSeat(2, used); got != ColorYellow {
+		t.Errorf("FreeSeat(2) = %s, want yellow", got)
+	}
+	if got := FreeSeat(4, used); got != ColorGreen {
+		t.Errorf("FreeSeat(4) = %s, want green", got)
+	}
+
+	for _, color := range BoardQuadrants {
+		used[color] = true
+	}
+	if got := FreeSeat(4, used); got != "" {
+		t.Errorf("FreeSeat on a full board = %s, want none", got)
+	}
+}
@@ -2,6 +2,9 @@
 package constants
 
 const (
+	// Version du client, jointe aux rapports de plantage
+	ClientVersion = "1.0.0"
+
 	// Configuration réseau
 	DefaultServerPort = "8080"
 	MaxPlayers        = 4
@@ -20,18 +23,143 @@ const (
 	RollToStart       = 6
 	RollForExtraTurn  = 6
 	MaxConsecutiveSix = 3
+	StalemateTurns    = 24 // Tours consécutifs où aucun joueur ne peut bouger
 
 	// Timeouts
 	TurnTimeout      = 30 // secondes
 	RollTimeout      = 10 // secondes
 	ReconnectTimeout = 60 // secondes
+	MatchAcceptTime  = 15 // secondes pour accepter une partie trouvée
+
+	// Durée des tours des parties trouvées par le matchmaking, selon le
+	// rythme des joueurs. Le rythme normal garde la durée configurée.
+	PaceFastTurnTimeout    = 15 // secondes
+	PaceRelaxedTurnTimeout = 60 // secondes
+
+	// Regroupement des événements envoyés aux spectateurs
+	SpectatorBatchWindow = 100 // millisecondes
+
+	// Pages du classement
+	LeaderboardPageSize = 20
+	LeaderboardPageMax  = 100
+
+	// Codes d'erreur. Ce sont aussi les codes des messages traduits par le
+	// client (voir internal/shared/i18n); les paramètres sont indiqués entre
+	// accolades.
+	ErrInvalidMove       = "INVALID_MOVE"
+	ErrNotYourTurn       = "NOT_YOUR_TURN"
+	ErrRoomFull          = "ROOM_FULL" // {max}
+	ErrRoomNotFound      = "ROOM_NOT_FOUND"
+	ErrUnauthorized      = "UNAUTHORIZED"
+	ErrInvalidSession    = "INVALID_SESSION"
+	ErrSessionActive     = "SESSION_ACTIVE"
+	ErrInvalidRules      = "INVALID_RULES"   // {detail}
+	ErrClientOutdated    = "CLIENT_OUTDATED" // {version} {min}
+	ErrAuthFailed        = "AUTH_FAILED"
+	ErrLoginRequired     = "LOGIN_REQUIRED"
+	ErrLoginExpired      = "LOGIN_EXPIRED"
+	ErrBadCredentials    = "BAD_CREDENTIALS"
+	ErrAccountTaken      = "ACCOUNT_TAKEN"
+	ErrInvalidUsername   = "INVALID_USERNAME" // {min} {max}
+	ErrInvalidEmail      = "INVALID_EMAIL"
+	ErrPasswordTooShort  = "PASSWORD_TOO_SHORT" // {min}
+	ErrPasswordTooLong   = "PASSWORD_TOO_LONG"  // {max}
+	ErrInvalidPayload    = "INVALID_PAYLOAD"
+	ErrServerFailure     = "SERVER_FAILURE"
+	ErrEncryptedChatOnly = "ENCRYPTED_CHAT_ONLY"
+	ErrUnknownHandicap   = "UNKNOWN_HANDICAP"
+	ErrHostOnly          = "HOST_ONLY"
+	ErrPlayerNotInRoom   = "PLAYER_NOT_IN_ROOM"
+	ErrGameStarted       = "GAME_STARTED"
+	ErrGameNotStarted    = "GAME_NOT_STARTED"
+	ErrGameNotOver       = "GAME_NOT_OVER"
+	ErrNotEnoughPlayers  = "NOT_ENOUGH_PLAYERS" // {min}
+	ErrSpinnerRoom       = "SPINNER_ROOM"
+	ErrNoSpin            = "NO_SPIN"
+	ErrDiceRolled        = "DICE_ALREADY_ROLLED"
+	ErrDiceNotRolled     = "DICE_NOT_ROLLED"
+	ErrInvalidToken      = "INVALID_TOKEN"
+	ErrAlreadyInRoom     = "ALREADY_IN_ROOM"
+
+	// Salles privées protégées par un mot de passe
+	ErrRoomPasswordRequired = "ROOM_PASSWORD_REQUIRED"
+	ErrRoomPasswordWrong    = "ROOM_PASSWORD_WRONG"
+
+	// Contrôles de l'hôte dans le lobby
+	ErrUnknownColor   = "UNKNOWN_COLOR"
+	ErrCannotKickSelf = "CANNOT_KICK_SELF"
+	ErrKickedFromRoom = "KICKED_FROM_ROOM"
+
+	// Instance en vidage avant une mise à jour
+	ErrServerDraining = "SERVER_DRAINING"
+
+	// Rôles et permissions dans une salle
+	ErrPermissionDenied  = "PERMISSION_DENIED" // {permission}
+	ErrUnknownRole       = "UNKNOWN_ROLE"
+	ErrUnknownPermission = "UNKNOWN_PERMISSION"
+	ErrChatMuted         = "CHAT_MUTED"
+
+	// Enveloppe des messages
+	ErrProtocolVersion = "PROTOCOL_VERSION" // {version} {max}
+
+	// Préférences du joueur
+	ErrUnknownPace = "UNKNOWN_PACE"
+
+	// Files de matchmaking
+	ErrRankedVariant = "RANKED_VARIANT"
+
+	// Thèmes de lobby
+	ErrUnknownTheme = "UNKNOWN_THEME" // {pack}
+	ErrUnknownTrack = "UNKNOWN_TRACK" // {track}
+
+	// Profils
+	ErrUnknownPlayer = "UNKNOWN_PLAYER" // {username}
+
+	// Pause des parties amicales
+	ErrGamePaused    = "GAME_PAUSED"
+	ErrGameNotPaused = "GAME_NOT_PAUSED"
+	ErrRankedPause   = "RANKED_PAUSE"
+
+	// Raisons précises d'un coup refusé (ErrInvalidMove sinon)
+	ErrNeedSix      = "NEED_SIX" // {six}
+	ErrPawnHome     = "PAWN_HOME"
+	ErrPastHome     = "PAST_HOME" // {left}
+	ErrOwnPawnThere = "OWN_PAWN_THERE"
+	ErrBlockade     = "BLOCKADE"
+
+	// Jeux de règles
+	ErrUnknownRuleSet = "UNKNOWN_RULE_SET"
+
+	// Export des données personnelles
+	ErrDataExportPending  = "DATA_EXPORT_PENDING"
+	ErrDataExportCooldown = "DATA_EXPORT_COOLDOWN" // {minutes}
+	ErrDataExportMissing  = "DATA_EXPORT_MISSING"
+	ErrDataExportFailed   = "DATA_EXPORT_FAILED"
 
-	// Codes d'erreur
-	ErrInvalidMove  = "INVALID_MOVE"
-	ErrNotYourTurn  = "NOT_YOUR_TURN"
-	ErrGameFull     = "GAME_FULL"
-	ErrRoomNotFound = "ROOM_NOT_FOUND"
-	ErrUnauthorized = "UNAUTHORIZED"
+	// Coup repris (règle maison)
+	ErrUndoDisabled  = "UNDO_DISABLED"
+	ErrNothingToUndo = "NOTHING_TO_UNDO"
+
+	// Messages traduits qui ne sont pas des erreurs
+	MsgTextUpdateAvailable = "UPDATE_AVAILABLE" // {latest} {version}
+	MsgTextMatchDeclined   = "MATCH_DECLINED"
+	MsgTextMatchExpired    = "MATCH_EXPIRED"
+
+	// Conseils du coach des salles d'apprentissage
+	MsgTextTutorialWelcome   = "TUTORIAL_WELCOME"
+	MsgTextTutorialNeedSix   = "TUTORIAL_NEED_SIX" // {six}
+	MsgTextTutorialSixOut    = "TUTORIAL_SIX_OUT"  // {six}
+	MsgTextTutorialExtraRoll = "TUTORIAL_EXTRA_ROLL"
+	MsgTextTutorialOnTrack   = "TUTORIAL_ON_TRACK"
+	MsgTextTutorialCoachTurn = "TUTORIAL_COACH_TURN"
+	MsgTextTutorialCapture   = "TUTORIAL_CAPTURE"
+	MsgTextTutorialCaptured  = "TUTORIAL_CAPTURED"
+	MsgTextTutorialHomeLane  = "TUTORIAL_HOME_LANE"
+	MsgTextTutorialPawnHome  = "TUTORIAL_PAWN_HOME" // {left}
+	MsgTextTutorialTimedOut  = "TUTORIAL_TIMED_OUT"
+	MsgTextTutorialWon       = "TUTORIAL_WON"
+	MsgTextTutorialLost      = "TUTORIAL_LOST"
+	MsgTextTutorialHelp      = "TUTORIAL_HELP" // {six}
 )
 
 // Couleurs des joueurs
@@ -53,18 +181,165 @@ const (
 	StateFinished GameState = "finished"
 )
 
+// Raisons de fin de partie
+const (
+	GameOverWin       = "win"
+	GameOverAborted   = "aborted"
+	GameOverStalemate = "stalemate"
+)
+
+// Verdict du serveur sur la version du client
+const (
+	UpdateNone      = "none"
+	UpdateAvailable = "available"
+	UpdateRequired  = "required"
+)
+
+// Handicaps de départ, réglés par l'hôte dans le lobby
+const (
+	HandicapNone      = ""
+	HandicapHeadStart = "head_start" // Un pion déjà sur la case de départ
+	HandicapExtraLap  = "extra_lap"  // Chaque pion fait un tour de plus avant la maison
+)
+
+// Rôles dans une salle, du plus élevé au plus bas
+type RoomRole string
+
+const (
+	RoleHost      RoomRole = "host"
+	RoleModerator RoomRole = "moderator"
+	RoleMember    RoomRole = "member"
+	RoleSpectator RoomRole = "spectator"
+)
+
+// Permissions accordées aux rôles d'une salle
+type Permission string
+
+const (
+	PermKick         Permission = "kick"          // Exclure un joueur de rang inférieur
+	PermChangeRules  Permission = "change_rules"  // Couleurs et handicaps avant la partie
+	PermModerateChat Permission = "moderate_chat" // Priver de chat un joueur de rang inférieur
+	PermStartGame    Permission = "start_game"    // Lancer sans attendre que tous soient prêts
+	PermPause        Permission = "pause"         // Pause et reprise d'une partie non classée
+	PermManageRoles  Permission = "manage_roles"  // Rôles et permissions (hôte seulement)
+)
+
+// Rythme de jeu préféré, utilisé par le matchmaking
+type Pace string
+
+const (
+	PaceFast    Pace = "fast"
+	PaceNormal  Pace = "normal"
+	PaceRelaxed Pace = "relaxed"
+)
+
+// Paces liste les rythmes, du plus rapide au plus lent
+var Paces = []Pace{PaceFast, PaceNormal, PaceRelaxed}
+
+// Files de matchmaking. La file classée compte pour le classement, avec
+// des dés équitables; la file amicale accepte les variantes.
+type Queue string
+
+const (
+	QueueRanked Queue = "ranked"
+	QueueCasual Queue = "casual"
+)
+
+// Jeux de règles proposés à la création d'une salle. Ils fixent la forme
+// de la partie (pions, placement de départ, victoire); les déplacements
+// restent les mêmes.
+type RuleSet string
+
+const (
+	RuleSetClassic RuleSet = ""      // 4 pions, tous à ramener
+	RuleSetQuick   RuleSet = "quick" // 2 pions dont un déjà sorti, le premier arrivé gagne
+)
+
+// RankedAFKLimit est le nombre de tours perdus par inactivité à partir
+// duquel une partie classée compte comme une défaite, sans récompense
+const RankedAFKLimit = 2
+
+// Cote des parties classées, à la Glicko. Un joueur est en placement tant
+// qu'il n'a pas joué PlacementGames parties classées: sa cote, encore
+// incertaine, bouge vite.
+const (
+	RatingInitial          = 1500.0
+	RatingInitialDeviation = 350.0
+	PlacementGames         = 10
+)
+
+// ExperiencePerLevel est l'expérience qui fait passer un niveau
+const ExperiencePerLevel = 1000
+
+// Niveaux des annonces du serveur
+const (
+	AnnounceInfo        = "info"
+	AnnounceMaintenance = "maintenance"
+	AnnounceEvent       = "event"
+)
+
 // Types de messages réseau
 type MessageType string
 
 const (
 	// Client -> Serveur
-	MsgJoinRoom    MessageType = "JOIN_ROOM"
-	MsgCreateRoom  MessageType = "CREATE_ROOM"
-	MsgLeaveRoom   MessageType = "LEAVE_ROOM"
-	MsgRollDice    MessageType = "ROLL_DICE"
-	MsgMoveToken   MessageType = "MOVE_TOKEN"
-	MsgChatMessage MessageType = "CHAT_MESSAGE"
-	MsgReady       MessageType = "PLAYER_READY"
+	MsgJoinRoom       MessageType = "JOIN_ROOM"
+	MsgCreateRoom     MessageType = "CREATE_ROOM"
+	MsgLeaveRoom      MessageType = "LEAVE_ROOM"
+	MsgRollDice       MessageType = "ROLL_DICE"
+	MsgMoveToken      MessageType = "MOVE_TOKEN"
+	MsgChatMessage    MessageType = "CHAT_MESSAGE"
+	MsgReady          MessageType = "PLAYER_READY"
+	MsgSpectate       MessageType = "SPECTATE_ROOM"
+	MsgResume         MessageType = "RESUME_SESSION"
+	MsgVoteAbort      MessageType = "VOTE_ABORT"
+	MsgStartSpin      MessageType = "START_SPIN"
+	MsgStopSpin       MessageType = "STOP_SPIN"
+	MsgCrashReport    MessageType = "CRASH_REPORT"
+	MsgSyncState      MessageType = "SYNC_STATE"
+	MsgGetProfile     MessageType = "GET_PROFILE"
+	MsgHello          MessageType = "HELLO" // Premier message: version du client
+	MsgRematch        MessageType = "REMATCH"
+	MsgSetHandicap    MessageType = "SET_HANDICAP" // Hôte: handicap de départ d'un joueur
+	MsgRegister       MessageType = "REGISTER"
+	MsgLogin          MessageType = "LOGIN"
+	MsgGetLeaderboard MessageType = "GET_LEADERBOARD" // Page du classement, avec recherche
+	MsgGetRivalries   MessageType = "GET_RIVALRIES"   // Bilan contre les adversaires du lobby
+	MsgFindMatch      MessageType = "FIND_MATCH"      // Entrer dans la file de matchmaking
+	MsgCancelMatch    MessageType = "CANCEL_MATCH"    // Quitter la file
+	MsgMatchReply     MessageType = "MATCH_REPLY"     // Accepter ou refuser une partie trouvée
+	MsgListRooms      MessageType = "LIST_ROOMS"      // Salles publiques en attente de joueurs
+	MsgSetPace        MessageType = "SET_PACE"        // Rythme de jeu préféré, pour le matchmaking
+
+	// Client -> Serveur, historique des cotes
+	MsgGetRatingHistory MessageType = "GET_RATING_HISTORY" // Évolution de la cote d'un joueur
+
+	// Client -> Serveur, partie d'apprentissage contre le coach du serveur
+	MsgStartTutorial MessageType = "START_TUTORIAL"
+
+	// Client -> Serveur, export des données personnelles du joueur
+	MsgRequestDataExport  MessageType = "REQUEST_DATA_EXPORT"  // Préparer l'export en arrière-plan
+	MsgDownloadDataExport MessageType = "DOWNLOAD_DATA_EXPORT" // Recevoir l'export prêt
+
+	// Client -> Serveur, hôte seulement, avant la partie
+	MsgKickPlayer  MessageType = "KICK_PLAYER"  // Exclure un joueur de la salle
+	MsgAssignColor MessageType = "ASSIGN_COLOR" // Changer la couleur d'un joueur
+	MsgStartGame   MessageType = "START_GAME"   // Lancer sans attendre que tous soient prêts
+
+	// Client -> Serveur, décor et musique du lobby ("party rooms")
+	MsgSetLobbyTheme MessageType = "SET_LOBBY_THEME"
+
+	// Client -> Serveur, hôte seulement, pendant une partie non classée
+	MsgPauseGame  MessageType = "PAUSE_GAME"
+	MsgResumeGame MessageType = "RESUME_GAME"
+
+	// Client -> Serveur, partie avec la règle maison du coup repris
+	MsgUndoMove MessageType = "UNDO_MOVE" // Reprendre son dernier coup avant le lancer suivant
+
+	// Client -> Serveur, selon les permissions du rôle dans la salle
+	MsgSetRole        MessageType = "SET_ROLE"        // Nommer ou retirer un modérateur
+	MsgSetPermissions MessageType = "SET_PERMISSIONS" // Permissions d'un rôle
+	MsgMutePlayer     MessageType = "MUTE_PLAYER"     // Priver un joueur de chat, ou le lui rendre
 
 	// Serveur -> Client
 	// Serveur -> Client
@@ -73,34 +348,50 @@ const (
 	MsgPlayerJoined  MessageType = "PLAYER_JOINED"
 	MsgPlayerLeft    MessageType = "PLAYER_LEFT"
 	MsgGameStart     MessageType = "GAME_START"
+	MsgRollOff       MessageType = "ROLL_OFF"
 	MsgDiceRolled    MessageType = "DICE_ROLLED"
 	MsgTokenMoved    MessageType = "TOKEN_MOVED"
 	MsgTokenCaptured MessageType = "TOKEN_CAPTURED"
 	MsgTurnChanged   MessageType = "TURN_CHANGED"
+	MsgTurnTimer     MessageType = "TURN_TIMER"     // Compte à rebours du tour humain en cours
+	MsgTurnTimedOut  MessageType = "TURN_TIMED_OUT" // Tour sauté faute d'action à temps
 	MsgGameOver      MessageType = "GAME_OVER"
 	MsgError         MessageType = "ERROR"
 	MsgGameState     MessageType = "GAME_STATE"
+	MsgEventBatch    MessageType = "EVENT_BATCH"
+	MsgSessionToken  MessageType = "SESSION_TOKEN"
+	MsgAbortVotes    MessageType = "ABORT_VOTES"
+	MsgSpinStarted   MessageType = "SPIN_STARTED"
+	MsgSpinResult    MessageType = "SPIN_RESULT"
+	MsgProfile       MessageType = "PROFILE"
+	MsgAnnouncement  MessageType = "SERVER_ANNOUNCEMENT"
+	MsgWelcome       MessageType = "WELCOME"
+	MsgAuthenticated MessageType = "AUTHENTICATED" // Compte vérifié et jeton d'authentification
+	MsgLeaderboard   MessageType = "LEADERBOARD"
+	MsgRivalries     MessageType = "RIVALRIES"
+	MsgRoomList      MessageType = "ROOM_LIST"
+	MsgKicked        MessageType = "KICKED"          // Joueur exclu de la salle par l'hôte
+	MsgTransfer      MessageType = "SERVER_TRANSFER" // Salle déplacée vers une autre instance
+	MsgStateDelta    MessageType = "STATE_DELTA"     // Pions déplacés depuis le tour précédent, avec somme de contrôle
+	MsgRatingHistory MessageType = "RATING_HISTORY"
+	MsgGamePaused    MessageType = "GAME_PAUSED"  // L'hôte a mis la partie en pause
+	MsgGameResumed   MessageType = "GAME_RESUMED" // Fin de la pause, suivie du compte à rebours du tour
+
+	// Coup repris: le joueur rejoue avec le même dé, suivi du delta des pions
+	MsgMoveUndone MessageType = "MOVE_UNDONE"
+
+	// Export des données personnelles
+	MsgDataExportReady MessageType = "DATA_EXPORT_READY" // L'export demandé peut être téléchargé
+	MsgDataExport      MessageType = "DATA_EXPORT"
+
+	// Matchmaking
+	MsgMatchFound     MessageType = "MATCH_FOUND"
+	MsgMatchCancelled MessageType = "MATCH_CANCELLED"
 
 	// Bidirectionnel
 	MsgPing MessageType = "PING"
 	MsgPong MessageType = "PONG"
 )
 
-// Positions de départ des couleurs
-var StartingPositions = map[PlayerColor]int{
-	ColorRed:    0,
-	ColorBlue:   13,
-	ColorGreen:  26,
-	ColorYellow: 39,
-}
-
 // Positions des zones sécurisées
 var SafePositions = []int{0, 8, 13, 21, 26, 34, 39, 47}
-
-// Chemins vers la maison
-var HomeStretchStart = map[PlayerColor]int{
-	ColorRed:    50,
-	ColorBlue:   11,
-	ColorGreen:  24,
-	ColorYellow: 37,
-}
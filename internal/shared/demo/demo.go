@@ -0,0 +1,194 @@
+// internal/shared/demo/demo.go
+
+// Package demo joue des parties IA contre IA avec les règles communes de
+// internal/shared/moves, coup par coup et sans minuteur. Le client s'en sert
+// pour le mode démonstration du menu principal; chaque coup vérifie aussi
+// que les règles laissent la partie dans un état cohérent, ce qui en fait
+// un test d'endurance des règles.
+package demo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/moves"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/dice"
+)
+
+// MaxActions borne une partie: au-delà, elle est considérée bloquée
+const MaxActions = 5000
+
+// Game est une partie de démonstration. Son historique suit le format des
+// parties enregistrées, pour être dessiné comme un replay.
+type Game struct {
+	*models.Game
+	dice *dice.Dice
+}
+
+// New prépare une partie entre des IA des couleurs données, avec des dés
+// tirés de la graine
+func New(seed int64, colors ...constants.PlayerColor) *Game {
+	room := &models.Room{Name: "Demo", State: constants.StatePlaying}
+	for i, color := range colors {
+		player := models.NewPlayer(int64(i+1), fmt.Sprintf("%s bot", color), color)
+		player.IsAI = true
+		room.Players = append(room.Players, player)
+	}
+	return &Game{
+		Game: &models.Game{Room: room, Seed: seed, StartTime: time.Now()},
+		dice: dice.NewSeeded(seed),
+	}
+}
+
+// Over indique que la partie a un vainqueur
+func (g *Game) Over() bool {
+	return g.Winner != nil
+}
+
+// Step joue un lancer du joueur courant et, s'il le peut, un coup. Il
+// retourne les actions ajoutées à l'historique, et une erreur si les règles
+// ont laissé la partie dans un état impossible.
+func (g *Game) Step() ([]models.TurnAction, error) {
+	if g.Over() {
+		return nil, nil
+	}
+	if len(g.TurnHistory) >= MaxActions {
+		return nil, fmt.Errorf("game %d still running after %d actions", g.Seed, MaxActions)
+	}
+
+	room := g.Room
+	player := room.Players[room.CurrentTurn]
+	value := g.dice.Roll()
+	room.LastDice = value
+	start := len(g.TurnHistory)
+	g.TurnHistory = append(g.TurnHistory, models.TurnAction{
+		PlayerID:  player.ID,
+		DiceValue: value,
+		Timestamp: time.Now(),
+	})
+
+	extra, forfeit := moves.Roll(player, value)
+	if !forfeit {
+		if token, to := g.choose(player, value); token != nil {
+			g.move(player, token, to, value)
+		}
+	}
+
+	if err := g.check(); err != nil {
+		return g.TurnHistory[start:], fmt.Errorf("game %d, action %d: %w", g.Seed, len(g.TurnHistory), err)
+	}
+	if g.won(player) {
+		g.Winner = player
+		room.State = constants.StateFinished
+	} else if !extra {
+		room.CurrentTurn = (room.CurrentTurn + 1) % len(room.Players)
+	}
+	return g.TurnHistory[start:], nil
+}
+
+// choose choisit le coup de l'IA: une prise d'abord, puis une arrivée, une
+// sortie de base, et sinon le pion le plus avancé. Les égalités sont
+// tranchées au dé pour varier les parties.
+func (g *Game) choose(player *models.Player, value int) (*models.Token, int) {
+	var best *models.Token
+	bestTo, bestScore := 0, -1
+	for _, token := range player.Tokens {
+		to, ok := moves.Legal(player, token, value)
+		if !ok {
+			continue
+		}
+		score := moves.Progress(player.Color, to)
+		switch {
+		case g.victim(player, to) != nil:
+			score += 3000
+		case to == moves.Home:
+			score += 2000
+		case token.Position == moves.Base:
+			score += 1000
+		}
+		if score > bestScore || (score == bestScore && g.dice.Intn(2) == 0) {
+			best, bestTo, bestScore = token, to, score
+		}
+	}
+	return best, bestTo
+}
+
+// move joue le coup et l'ajoute à l'historique, avec la prise éventuelle
+func (g *Game) move(player *models.Player, token *models.Token, to, value int) {
+	from := token.Position
+	token.Position = to
+	token.IsHome = to == moves.Home
+	token.IsSafe = moves.IsSafe(to)
+
+	moved := *token
+	action := models.TurnAction{
+		PlayerID:   player.ID,
+		DiceValue:  value,
+		TokenMoved: &moved,
+		FromPos:    from,
+		ToPos:      to,
+		Timestamp:  time.Now(),
+	}
+	if victim := g.victim(player, to); victim != nil {
+		victim.Position = moves.Base
+		victim.IsSafe = true
+		captured := *victim
+		action.Captured = &captured
+	}
+	g.TurnHistory = append(g.TurnHistory, action)
+}
+
+// victim retourne le pion adverse pris en arrivant sur la case, nil sinon
+func (g *Game) victim(player *models.Player, to int) *models.Token {
+	if moves.IsSafe(to) {
+		return nil
+	}
+	for _, other := range g.Room.Players {
+		if other.Color == player.Color {
+			continue
+		}
+		for _, token := range other.Tokens {
+			if token.Position == to {
+				return token
+			}
+		}
+	}
+	return nil
+}
+
+// won indique que tous les pions du joueur sont arrivés
+func (g *Game) won(player *models.Player) bool {
+	for _, token := range player.Tokens {
+		if !token.IsHome {
+			return false
+		}
+	}
+	return true
+}
+
+// check vérifie l'état de la partie: positions possibles, pions arrivés
+// cohérents et jamais deux pions d'une couleur sur la même case
+func (g *Game) check() error {
+	for _, player := range g.Room.Players {
+		seen := make(map[int]bool)
+		for _, token := range player.Tokens {
+			pos := token.Position
+			if pos < moves.Base || pos > moves.Home {
+				return fmt.Errorf("%s pawn %d on impossible square %d", player.Color, token.ID, pos)
+			}
+			if token.IsHome != (pos == moves.Home) {
+				return fmt.Errorf("%s pawn %d on %d marked home=%v", player.Color, token.ID, pos, token.IsHome)
+			}
+			if pos == moves.Base || pos == moves.Home {
+				continue
+			}
+			if seen[pos] {
+				return fmt.Errorf("two %s pawns on square %d", player.Color, pos)
+			}
+			seen[pos] = true
+		}
+	}
+	return nil
+}
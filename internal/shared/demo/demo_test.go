@@ -0,0 +1,42 @@
+// internal/shared/demo/demo_test.go
+package demo
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+)
+
+// TestDemoGamesFinish joue des parties complètes à deux et quatre joueurs:
+// chacune doit se terminer sans état impossible
+func TestDemoGamesFinish(t *testing.T) {
+	seats := [][]constants.PlayerColor{
+		{constants.ColorRed, constants.ColorYellow},
+		{constants.ColorRed, constants.ColorGreen, constants.ColorYellow, constants.ColorBlue},
+	}
+	for seed := int64(1); seed <= 100; seed++ {
+		game := New(seed, seats[seed%2]...)
+		for !game.Over() {
+			if _, err := game.Step(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if game.Room.State != constants.StateFinished || !game.won(game.Winner) {
+			t.Fatalf("game %d ended without a winner", seed)
+		}
+	}
+}
+
+func TestDemoGameIsReproducible(t *testing.T) {
+	a, b := New(7, constants.ColorRed, constants.ColorBlue), New(7, constants.ColorRed, constants.ColorBlue)
+	for i := 0; i < 200 && !a.Over(); i++ {
+		stepA, errA := a.Step()
+		stepB, errB := b.Step()
+		if errA != nil || errB != nil {
+			t.Fatal(errA, errB)
+		}
+		if len(stepA) != len(stepB) || stepA[0].DiceValue != stepB[0].DiceValue {
+			t.Fatalf("step %d differs between two games with the same seed", i)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// internal/shared/discovery/discovery.go
+package discovery
+
+import (
+	"encoding/json"
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Paramètres de la découverte des serveurs sur le réseau local
+const (
+	Port             = 9999
+	announceInterval = 2 * time.Second
+	maxPacketSize    = 512
+)
+
+var (
+	// groupV4 est le groupe multicast IPv4 (portée organisation)
+	groupV4 = net.IPv4(239, 255, 76, 75)
+	// groupV6 est le groupe multicast IPv6 (portée lien local)
+	groupV6 = net.ParseIP("ff02::4c4b")
+)
+
+// Announcement est le paquet diffusé par un serveur
+type Announcement struct {
+	Name string `json:"name"`
+	Port string `json:"port"`
+}
+
+// Server est un serveur trouvé sur le réseau local
+type Server struct {
+	Name    string
+	Address string // "hôte:port", IPv6 avec zone si lien local
+}
+
+// Announce diffuse la présence du serveur en IPv4 et IPv6 jusqu'à stop
+func Announce(name, port string, stop <-chan struct{}) error {
+	packet, err := json.Marshal(Announcement{Name: name, Port: port})
+	if err != nil {
+		return err
+	}
+
+	conn4, err4 := net.ListenUDP("udp4", nil)
+	conn6, err6 := net.ListenUDP("udp6", nil)
+	if err4 != nil && err6 != nil {
+		return err4
+	}
+	if conn4 != nil {
+		defer conn4.Close()
+	}
+	if conn6 != nil {
+		defer conn6.Close()
+	}
+
+	ticker := time.NewTicker(announceInterval)
+	defer ticker.Stop()
+
+	for {
+		if conn4 != nil {
+			conn4.WriteToUDP(packet, &net.UDPAddr{IP: groupV4, Port: Port})
+		}
+		if conn6 != nil {
+			// Le groupe IPv6 est de portée lien: l'envoyer sur chaque interface
+			for _, iface := range multicastInterfaces() {
+				conn6.WriteToUDP(packet, &net.UDPAddr{IP: groupV6, Port: Port, Zone: iface.Name})
+			}
+		}
+
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Browse écoute les annonces pendant timeout et retourne les serveurs trouvés
+func Browse(timeout time.Duration) ([]Server, error) {
+	conns := make([]*net.UDPConn, 0)
+	var firstErr error
+
+	if conn, err := net.ListenMulticastUDP("udp4", nil, &net.UDPAddr{IP: groupV4, Port: Port}); err == nil {
+		conns = append(conns, conn)
+	} else {
+		firstErr = err
+	}
+	for _, iface := range multicastInterfaces() {
+		iface := iface
+		if conn, err := net.ListenMulticastUDP("udp6", &iface, &net.UDPAddr{IP: groupV6, Port: Port}); err == nil {
+			conns = append(conns, conn)
+		} else if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if len(conns) == 0 {
+		return nil, firstErr
+	}
+
+	found := make(map[string]Server)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	deadline := time.Now().Add(timeout)
+
+	for _, conn := range conns {
+		wg.Add(1)
+		go func(conn *net.UDPConn) {
+			defer wg.Done()
+			defer conn.Close()
+
+			conn.SetReadDeadline(deadline)
+			buf := make([]byte, maxPacketSize)
+			for {
+				n, from, err := conn.ReadFromUDP(buf)
+				if err != nil {
+					return
+				}
+
+				var ann Announcement
+				if err := json.Unmarshal(buf[:n], &ann); err != nil || ann.Port == "" {
+					continue
+				}
+
+				host := from.IP.String()
+				if from.Zone != "" {
+					host += "%" + from.Zone
+				}
+				address := net.JoinHostPort(host, ann.Port)
+
+				mu.Lock()
+				found[address] = Server{Name: ann.Name, Address: address}
+				mu.Unlock()
+			}
+		}(conn)
+	}
+	wg.Wait()
+
+	servers := make([]Server, 0, len(found))
+	for _, server := range found {
+		servers = append(servers, server)
+	}
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Address < servers[j].Address
+	})
+	return servers, nil
+}
+
+// multicastInterfaces retourne les interfaces actives acceptant le multicast
+func multicastInterfaces() []net.Interface {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+
+	result := make([]net.Interface, 0, len(ifaces))
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp != 0 && iface.Flags&net.FlagMulticast != 0 {
+			result = append(result, iface)
+		}
+	}
+	return result
+}
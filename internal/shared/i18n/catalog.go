@@ -0,0 +1,215 @@
+// internal/shared/i18n/catalog.go
+package i18n
+
+import "github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+
+// names est le nom de chaque langue dans cette langue, pour les réglages
+var names = map[string]string{
+	English: "English",
+	French:  "Français",
+}
+
+// catalogs associe à chaque langue le texte de chaque code de message.
+// L'anglais est la référence: tout code envoyé par le serveur doit y figurer.
+var catalogs = map[string]map[string]string{
+	English: {
+		constants.ErrInvalidMove:       "This move is not allowed.",
+		constants.ErrNotYourTurn:       "It is not your turn.",
+		constants.ErrRoomFull:          "This room is full ({max} players maximum).",
+		constants.ErrRoomNotFound:      "Room not found.",
+		constants.ErrUnauthorized:      "You are not allowed to do that.",
+		constants.ErrInvalidSession:    "Your session has expired.",
+		constants.ErrSessionActive:     "This session is still connected.",
+		constants.ErrInvalidRules:      "Invalid rules: {detail}",
+		constants.ErrClientOutdated:    "Your client ({version}) is too old for this server. Please install version {min} or later.",
+		constants.ErrAuthFailed:        "Authentication failed.",
+		constants.ErrLoginRequired:     "Please log in first.",
+		constants.ErrLoginExpired:      "Login expired, please sign in again.",
+		constants.ErrBadCredentials:    "Invalid username or password.",
+		constants.ErrAccountTaken:      "Username or email already taken.",
+		constants.ErrInvalidUsername:   "Usernames have {min} to {max} letters, digits, '-' or '_'.",
+		constants.ErrInvalidEmail:      "Invalid email address.",
+		constants.ErrPasswordTooShort:  "Password must be at least {min} characters.",
+		constants.ErrPasswordTooLong:   "Password must be at most {max} bytes.",
+		constants.ErrInvalidPayload:    "The server could not read the request.",
+		constants.ErrServerFailure:     "Something went wrong on the server, please try again.",
+		constants.ErrEncryptedChatOnly: "This room only accepts encrypted chat.",
+		constants.ErrUnknownHandicap:   "Unknown handicap.",
+		constants.ErrHostOnly:          "Only the host can do that.",
+		constants.ErrPlayerNotInRoom:   "This player is not in the room.",
+		constants.ErrGameStarted:       "The game has already started.",
+		constants.ErrGameNotStarted:    "The game is not in progress.",
+		constants.ErrGameNotOver:       "The current game is not over yet.",
+		constants.ErrNotEnoughPlayers:  "At least {min} players are needed to start.",
+		constants.ErrSpinnerRoom:       "This room uses the dice spinner.",
+		constants.ErrNoSpin:            "No spin in progress.",
+		constants.ErrDiceRolled:        "You have already rolled the dice.",
+		constants.ErrDiceNotRolled:     "Roll the dice first.",
+		constants.ErrInvalidToken:      "This pawn does not exist.",
+		constants.ErrAlreadyInRoom:     "Leave your current room first.",
+
+		constants.ErrRoomPasswordRequired: "This room is private: enter its password.",
+		constants.ErrRoomPasswordWrong:    "Wrong room password.",
+
+		constants.ErrUnknownColor:   "Unknown color.",
+		constants.ErrCannotKickSelf: "You cannot kick yourself: leave the room instead.",
+		constants.ErrKickedFromRoom: "You were removed from this room.",
+
+		constants.ErrServerDraining: "This server is restarting for an update: try again in a moment.",
+
+		constants.ErrPermissionDenied:  "Your role in this room does not allow that ({permission}).",
+		constants.ErrUnknownRole:       "Unknown role.",
+		constants.ErrUnknownPermission: "Unknown permission.",
+		constants.ErrChatMuted:         "A moderator has muted you in this room.",
+
+		constants.ErrProtocolVersion: "This server does not understand protocol version {version} (at most {max}).",
+
+		constants.ErrUnknownPace: "Unknown game pace.",
+
+		constants.ErrRankedVariant: "Ranked games use the standard rules and fair dice. Choose Casual for variants.",
+
+		constants.ErrUnknownTheme: "This server does not offer the theme {pack}.",
+		constants.ErrUnknownTrack: "The theme has no track named {track}.",
+
+		constants.ErrUnknownPlayer: "No player is called {username}.",
+
+		constants.ErrGamePaused:    "The game is paused.",
+		constants.ErrGameNotPaused: "The game is not paused.",
+		constants.ErrRankedPause:   "Ranked games cannot be paused.",
+
+		constants.ErrNeedSix:      "A pawn needs a {six} to leave the base.",
+		constants.ErrPawnHome:     "This pawn is already home.",
+		constants.ErrPastHome:     "This pawn needs exactly {left} to reach home.",
+		constants.ErrOwnPawnThere: "One of your pawns already stands on that square.",
+		constants.ErrBlockade:     "An opponent's blockade stands in the way.",
+
+		constants.ErrUnknownRuleSet: "Unknown game type.",
+
+		constants.ErrDataExportPending:  "Your data export is already being prepared.",
+		constants.ErrDataExportCooldown: "You can request a new data export in {minutes} min.",
+		constants.ErrDataExportMissing:  "No data export is ready. Request a new one from your profile.",
+		constants.ErrDataExportFailed:   "Your data export could not be prepared. Please try again later.",
+
+		constants.ErrUndoDisabled:  "Taking back moves is not allowed in this game.",
+		constants.ErrNothingToUndo: "There is no move to take back: the next roll has already been made.",
+
+		constants.MsgTextUpdateAvailable: "Version {latest} is available (you have {version}).",
+		constants.MsgTextMatchDeclined:   "A player declined the match.",
+		constants.MsgTextMatchExpired:    "Not everyone accepted the match in time.",
+
+		constants.MsgTextTutorialWelcome:   "Welcome! I'm your coach for this game. When it's your turn, roll with the 🎲 button. Type \"help\" in the chat whenever you need a reminder.",
+		constants.MsgTextTutorialNeedSix:   "Your pawns start in the base: you need a {six} to bring one out. Keep rolling on your turns!",
+		constants.MsgTextTutorialSixOut:    "A {six}! Click a pawn in your base, then click it again to bring it onto the board.",
+		constants.MsgTextTutorialExtraRoll: "Rolling a six earns you another roll. Three in a row and you lose the turn, though!",
+		constants.MsgTextTutorialOnTrack:   "Your pawn is on its way. Squares with a ★ are safe: nobody can capture you there.",
+		constants.MsgTextTutorialCoachTurn: "My turn now. Watch how my pawns move around the board.",
+		constants.MsgTextTutorialCapture:   "Nice capture! My pawn goes back to its base and has to roll a six again.",
+		constants.MsgTextTutorialCaptured:  "Ouch, I landed on your pawn and sent it back to base. Stay on ★ squares when I'm close behind.",
+		constants.MsgTextTutorialHomeLane:  "You're in your home lane: only your own pawns can go there, so it's perfectly safe.",
+		constants.MsgTextTutorialPawnHome:  "A pawn made it home! {left} more to go.",
+		constants.MsgTextTutorialTimedOut:  "Your turn ran out of time and was skipped. Keep an eye on the countdown next to the dice.",
+		constants.MsgTextTutorialWon:       "You won! You know everything you need to play online now.",
+		constants.MsgTextTutorialLost:      "I won this time. Try again, or jump into a real game!",
+		constants.MsgTextTutorialHelp:      "Roll with 🎲, then click a pawn outlined in green twice to move it. A {six} brings a pawn out, ★ squares are safe, and the first to bring every pawn home wins.",
+	},
+	French: {
+		constants.ErrInvalidMove:       "Ce coup n'est pas autorisé.",
+		constants.ErrNotYourTurn:       "Ce n'est pas votre tour.",
+		constants.ErrRoomFull:          "Cette salle est pleine ({max} joueurs maximum).",
+		constants.ErrRoomNotFound:      "Salle introuvable.",
+		constants.ErrUnauthorized:      "Vous n'avez pas le droit de faire cela.",
+		constants.ErrInvalidSession:    "Votre session a expiré.",
+		constants.ErrSessionActive:     "Cette session est toujours connectée.",
+		constants.ErrInvalidRules:      "Règles invalides : {detail}",
+		constants.ErrClientOutdated:    "Votre client ({version}) est trop ancien pour ce serveur. Installez la version {min} ou plus récente.",
+		constants.ErrAuthFailed:        "Échec de l'authentification.",
+		constants.ErrLoginRequired:     "Veuillez d'abord vous connecter.",
+		constants.ErrLoginExpired:      "Connexion expirée, veuillez vous reconnecter.",
+		constants.ErrBadCredentials:    "Pseudo ou mot de passe incorrect.",
+		constants.ErrAccountTaken:      "Pseudo ou e-mail déjà utilisé.",
+		constants.ErrInvalidUsername:   "Un pseudo compte de {min} à {max} lettres, chiffres, « - » ou « _ ».",
+		constants.ErrInvalidEmail:      "Adresse e-mail invalide.",
+		constants.ErrPasswordTooShort:  "Le mot de passe doit contenir au moins {min} caractères.",
+		constants.ErrPasswordTooLong:   "Le mot de passe doit faire au plus {max} octets.",
+		constants.ErrInvalidPayload:    "Le serveur n'a pas pu lire la requête.",
+		constants.ErrServerFailure:     "Une erreur est survenue sur le serveur, veuillez réessayer.",
+		constants.ErrEncryptedChatOnly: "Cette salle n'accepte que le chat chiffré.",
+		constants.ErrUnknownHandicap:   "Handicap inconnu.",
+		constants.ErrHostOnly:          "Seul l'hôte peut faire cela.",
+		constants.ErrPlayerNotInRoom:   "Ce joueur n'est pas dans la salle.",
+		constants.ErrGameStarted:       "La partie a déjà commencé.",
+		constants.ErrGameNotStarted:    "La partie n'est pas en cours.",
+		constants.ErrGameNotOver:       "La partie en cours n'est pas terminée.",
+		constants.ErrNotEnoughPlayers:  "Il faut au moins {min} joueurs pour commencer.",
+		constants.ErrSpinnerRoom:       "Cette salle utilise le sélecteur de dé.",
+		constants.ErrNoSpin:            "Aucun lancer en cours.",
+		constants.ErrDiceRolled:        "Vous avez déjà lancé le dé.",
+		constants.ErrDiceNotRolled:     "Lancez d'abord le dé.",
+		constants.ErrInvalidToken:      "Ce pion n'existe pas.",
+		constants.ErrAlreadyInRoom:     "Quittez d'abord votre salle actuelle.",
+
+		constants.ErrRoomPasswordRequired: "Cette salle est privée: saisissez son mot de passe.",
+		constants.ErrRoomPasswordWrong:    "Mot de passe de la salle incorrect.",
+
+		constants.ErrUnknownColor:   "Couleur inconnue.",
+		constants.ErrCannotKickSelf: "Vous ne pouvez pas vous exclure: quittez la salle.",
+		constants.ErrKickedFromRoom: "Vous avez été exclu de cette salle.",
+
+		constants.ErrServerDraining: "Ce serveur redémarre pour une mise à jour: réessayez dans un instant.",
+
+		constants.ErrPermissionDenied:  "Votre rôle dans cette salle ne le permet pas ({permission}).",
+		constants.ErrUnknownRole:       "Rôle inconnu.",
+		constants.ErrUnknownPermission: "Permission inconnue.",
+		constants.ErrChatMuted:         "Un modérateur vous a retiré la parole dans cette salle.",
+
+		constants.ErrProtocolVersion: "Ce serveur ne comprend pas la version {version} du protocole (au plus {max}).",
+
+		constants.ErrUnknownPace: "Rythme de jeu inconnu.",
+
+		constants.ErrRankedVariant: "Les parties classées se jouent avec les règles standard et des dés équitables. Choisissez Casual pour les variantes.",
+
+		constants.ErrUnknownTheme: "Ce serveur ne propose pas le thème {pack}.",
+		constants.ErrUnknownTrack: "Le thème n'a pas de morceau nommé {track}.",
+
+		constants.ErrUnknownPlayer: "Aucun joueur ne s'appelle {username}.",
+
+		constants.ErrGamePaused:    "La partie est en pause.",
+		constants.ErrGameNotPaused: "La partie n'est pas en pause.",
+		constants.ErrRankedPause:   "Une partie classée ne peut pas être mise en pause.",
+
+		constants.ErrNeedSix:      "Il faut un {six} pour sortir un pion de la base.",
+		constants.ErrPawnHome:     "Ce pion est déjà arrivé.",
+		constants.ErrPastHome:     "Ce pion doit faire exactement {left} pour arriver.",
+		constants.ErrOwnPawnThere: "Un de vos pions occupe déjà cette case.",
+		constants.ErrBlockade:     "Un barrage adverse barre le passage.",
+
+		constants.ErrUnknownRuleSet: "Type de partie inconnu.",
+
+		constants.ErrDataExportPending:  "L'export de vos données est déjà en préparation.",
+		constants.ErrDataExportCooldown: "Vous pourrez demander un nouvel export dans {minutes} min.",
+		constants.ErrDataExportMissing:  "Aucun export n'est prêt. Demandez-en un nouveau depuis votre profil.",
+		constants.ErrDataExportFailed:   "L'export de vos données n'a pas pu être préparé. Réessayez plus tard.",
+
+		constants.ErrUndoDisabled:  "Cette partie ne permet pas de reprendre un coup.",
+		constants.ErrNothingToUndo: "Aucun coup à reprendre: le dé a déjà été relancé.",
+
+		constants.MsgTextUpdateAvailable: "La version {latest} est disponible (vous avez la {version}).",
+		constants.MsgTextMatchDeclined:   "Un joueur a refusé la partie.",
+		constants.MsgTextMatchExpired:    "Tous les joueurs n'ont pas accepté la partie à temps.",
+
+		constants.MsgTextTutorialWelcome:   "Bienvenue ! Je suis votre coach pour cette partie. À votre tour, lancez le dé avec le bouton 🎲. Tapez « aide » dans le chat pour un rappel à tout moment.",
+		constants.MsgTextTutorialNeedSix:   "Vos pions partent de la base : il faut un {six} pour en sortir un. Continuez à lancer à chaque tour !",
+		constants.MsgTextTutorialSixOut:    "Un {six} ! Cliquez sur un pion de votre base, puis encore une fois pour le poser sur le plateau.",
+		constants.MsgTextTutorialExtraRoll: "Un six vous donne un nouveau lancer. Mais trois de suite, et vous perdez le tour !",
+		constants.MsgTextTutorialOnTrack:   "Votre pion est en route. Les cases marquées ★ sont sûres : personne ne peut vous y prendre.",
+		constants.MsgTextTutorialCoachTurn: "À moi de jouer. Regardez comment mes pions avancent.",
+		constants.MsgTextTutorialCapture:   "Belle prise ! Mon pion retourne à sa base et devra refaire un six.",
+		constants.MsgTextTutorialCaptured:  "Aïe, je suis tombé sur votre pion et il est rentré à la base. Restez sur les cases ★ quand je suis juste derrière.",
+		constants.MsgTextTutorialHomeLane:  "Vous êtes dans votre couloir : seuls vos pions y entrent, vous y êtes à l'abri.",
+		constants.MsgTextTutorialPawnHome:  "Un pion est arrivé ! Plus que {left}.",
+		constants.MsgTextTutorialTimedOut:  "Votre tour a expiré et a été passé. Surveillez le compte à rebours à côté du dé.",
+		constants.MsgTextTutorialWon:       "Vous avez gagné ! Vous savez tout pour jouer en ligne.",
+		constants.MsgTextTutorialLost:      "J'ai gagné cette fois. Réessayez, ou lancez-vous dans une vraie partie !",
+		constants.MsgTextTutorialHelp:      "Lancez avec 🎲, puis cliquez deux fois sur un pion entouré de vert pour le déplacer. Un {six} fait sortir un pion, les cases ★ sont sûres, et le premier à ramener tous ses pions gagne.",
+	},
+}
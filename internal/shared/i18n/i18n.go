@@ -0,0 +1,98 @@
+// internal/shared/i18n/i18n.go
+
+// Package i18n traduit les messages du serveur. Le serveur n'envoie qu'un
+// code stable et ses paramètres (ROOM_FULL {max: 4}); chaque client rend le
+// texte dans la langue de son joueur, le protocole reste neutre.
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Langues connues
+const (
+	English = "en"
+	French  = "fr"
+
+	// Default sert de repli quand une langue ou une traduction manque
+	Default = English
+)
+
+// Params sont les valeurs insérées dans un message ({max}, {version}...)
+type Params map[string]any
+
+// Error est une erreur traduisible: un code de message et ses paramètres.
+// Error() rend le texte dans la langue par défaut, pour les journaux.
+type Error struct {
+	Code   string
+	Params Params
+}
+
+// NewError crée une erreur traduisible
+func NewError(code string, params Params) *Error {
+	return &Error{Code: code, Params: params}
+}
+
+func (e *Error) Error() string {
+	return Render(Default, e.Code, e.Params)
+}
+
+// CodeOf retourne le code et les paramètres d'une erreur traduisible. Une
+// autre erreur prend le code fallback, son texte passant en paramètre detail.
+func CodeOf(err error, fallback string) (string, Params) {
+	var coded *Error
+	if errors.As(err, &coded) {
+		return coded.Code, coded.Params
+	}
+	return fallback, Params{"detail": err.Error()}
+}
+
+// Render rend un message dans la langue demandée, à défaut en anglais, à
+// défaut le code lui-même
+func Render(lang, code string, params Params) string {
+	text, ok := catalogs[Match(lang)][code]
+	if !ok {
+		if text, ok = catalogs[Default][code]; !ok {
+			return code
+		}
+	}
+
+	for name, value := range params {
+		text = strings.ReplaceAll(text, "{"+name+"}", fmt.Sprint(value))
+	}
+	return text
+}
+
+// Match retourne la langue connue la plus proche d'une locale ("fr-CA",
+// "fr_FR.UTF-8"), ou la langue par défaut
+func Match(locale string) string {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "-_."); i >= 0 {
+		lang = lang[:i]
+	}
+	if _, ok := catalogs[lang]; ok {
+		return lang
+	}
+	return Default
+}
+
+// Languages liste les langues disponibles
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Name retourne le nom d'une langue dans cette langue
+func Name(lang string) string {
+	if name, ok := names[lang]; ok {
+		return name
+	}
+	return lang
+}
@@ -0,0 +1,79 @@
+// internal/shared/i18n/i18n_test.go
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+)
+
+func TestRenderParams(t *testing.T) {
+	got := Render(French, constants.ErrRoomFull, Params{"max": 4})
+	if want := "Cette salle est pleine (4 joueurs maximum)."; got != want {
+		t.Errorf("Render = %q, want %q", got, want)
+	}
+}
+
+func TestRenderParamsFromJSON(t *testing.T) {
+	// Le client reçoit les nombres du JSON en float64
+	var params Params
+	if err := json.Unmarshal([]byte(`{"max":4}`), &params); err != nil {
+		t.Fatal(err)
+	}
+	got := Render(English, constants.ErrRoomFull, params)
+	if want := "This room is full (4 players maximum)."; got != want {
+		t.Errorf("Render = %q, want %q", got, want)
+	}
+}
+
+func TestRenderFallback(t *testing.T) {
+	if got, want := Render("de", constants.ErrNotYourTurn, nil), catalogs[English][constants.ErrNotYourTurn]; got != want {
+		t.Errorf("unknown language: Render = %q, want %q", got, want)
+	}
+	if got := Render(French, "SOMETHING_NEW", nil); got != "SOMETHING_NEW" {
+		t.Errorf("unknown code: Render = %q, want the code", got)
+	}
+}
+
+func TestCatalogsCoverEnglish(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for code := range catalogs[English] {
+			if _, ok := catalog[code]; !ok {
+				t.Errorf("%s: missing translation for %s", lang, code)
+			}
+		}
+		if _, ok := names[lang]; !ok {
+			t.Errorf("%s: missing language name", lang)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := map[string]string{
+		"fr":          French,
+		"fr-CA":       French,
+		"fr_FR.UTF-8": French,
+		"EN-us":       English,
+		"de-DE":       Default,
+		"":            Default,
+	}
+	for locale, want := range tests {
+		if got := Match(locale); got != want {
+			t.Errorf("Match(%q) = %q, want %q", locale, got, want)
+		}
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	wrapped := errors.Join(errors.New("context"), NewError(constants.ErrRoomFull, Params{"max": 2}))
+	if code, params := CodeOf(wrapped, constants.ErrInvalidMove); code != constants.ErrRoomFull || params["max"] != 2 {
+		t.Errorf("CodeOf = %s %v, want ROOM_FULL {max: 2}", code, params)
+	}
+
+	code, params := CodeOf(errors.New("boom"), constants.ErrInvalidMove)
+	if code != constants.ErrInvalidMove || params["detail"] != "boom" {
+		t.Errorf("CodeOf = %s %v, want the fallback code with the error text", code, params)
+	}
+}
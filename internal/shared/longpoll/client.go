@@ -0,0 +1,194 @@
+// internal/shared/longpoll/client.go
+package longpoll
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// conn est la connexion d'un client acheminée par requêtes HTTP
+type conn struct {
+	noDeadlines
+	base   string // URL de la session
+	client *http.Client
+	events *io.PipeReader // Événements lus par le client
+	feed   *io.PipeWriter
+	sendMu sync.Mutex
+	sent   uint64 // Numéro de la dernière commande envoyée
+
+	ctx       context.Context // Annulé à la fermeture
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+}
+
+// Dial ouvre une session sur le serveur HTTP baseURL ("http://hôte:8082",
+// "https://jeu.example.org"). client peut porter un proxy ou des certificats;
+// nil utilise un client adapté à l'attente des événements.
+func Dial(baseURL string, client *http.Client) (net.Conn, error) {
+	if client == nil {
+		client = &http.Client{Timeout: PollWait + 10*time.Second}
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	resp, err := client.Post(baseURL+"/poll", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("longpoll: server answered %s", resp.Status)
+	}
+	var open OpenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&open); err != nil || open.Session == "" {
+		return nil, fmt.Errorf("longpoll: invalid session: %v", err)
+	}
+
+	events, feed := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &conn{
+		base:   baseURL + "/poll/" + open.Session,
+		client: client,
+		events: events,
+		feed:   feed,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	go c.poll()
+	return c, nil
+}
+
+// poll relève les événements jusqu'à la fermeture de la session. Une
+// requête perdue est reprise avec le même curseur.
+func (c *conn) poll() {
+	var after uint64
+	failures := 0
+	for {
+		resp, err := c.fetch(after)
+		if c.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			failures++
+			if failures > maxRetries {
+				c.feed.CloseWithError(err)
+				return
+			}
+			time.Sleep(retryDelay)
+			continue
+		}
+		failures = 0
+
+		for _, message := range resp.Messages {
+			if _, err := c.feed.Write(append(message, '\n')); err != nil {
+				return
+			}
+		}
+		after = resp.Next
+	}
+}
+
+// fetch attend les événements qui suivent after
+func (c *conn) fetch(after uint64) (*EventsResponse, error) {
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, fmt.Sprintf("%s?after=%d", c.base, after), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusGone:
+		// Session fermée par le serveur: fin de la connexion, sans reprise
+		c.feed.Close()
+		c.cancel()
+		return nil, io.EOF
+	default:
+		return nil, fmt.Errorf("longpoll: server answered %s", resp.Status)
+	}
+
+	var events EventsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		return nil, err
+	}
+	return &events, nil
+}
+
+// Read lit les messages reçus du serveur
+func (c *conn) Read(p []byte) (int, error) {
+	return c.events.Read(p)
+}
+
+// Write envoie une commande. Elle est renvoyée avec le même numéro si la
+// réponse se perd: le serveur ne la traite qu'une fois.
+func (c *conn) Write(p []byte) (int, error) {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	c.sent++
+	url := fmt.Sprintf("%s?seq=%d", c.base, c.sent)
+	var err error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if c.ctx.Err() != nil {
+			return 0, net.ErrClosed
+		}
+		if attempt > 0 {
+			time.Sleep(retryDelay)
+		}
+
+		var req *http.Request
+		req, err = http.NewRequestWithContext(c.ctx, http.MethodPost, url, bytes.NewReader(p))
+		if err != nil {
+			return 0, err
+		}
+		var resp *http.Response
+		resp, err = c.client.Do(req)
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode == http.StatusNoContent:
+			return len(p), nil
+		case resp.StatusCode >= 500:
+			err = fmt.Errorf("longpoll: server answered %s", resp.Status)
+		default:
+			return 0, fmt.Errorf("longpoll: command refused: %s", resp.Status)
+		}
+	}
+	return 0, err
+}
+
+// Close ferme la session des deux côtés
+func (c *conn) Close() error {
+	c.closeOnce.Do(func() {
+		c.cancel()
+		c.feed.Close()
+
+		// Prévenir le serveur, sans attendre l'expiration de la session
+		req, err := http.NewRequest(http.MethodDelete, c.base, nil)
+		if err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), retryDelay)
+			defer cancel()
+			if resp, err := c.client.Do(req.WithContext(ctx)); err == nil {
+				resp.Body.Close()
+			}
+		}
+	})
+	return nil
+}
+
+func (c *conn) LocalAddr() net.Addr  { return addr("longpoll") }
+func (c *conn) RemoteAddr() net.Addr { return addr(c.base) }
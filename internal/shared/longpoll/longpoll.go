@@ -0,0 +1,65 @@
+// internal/shared/longpoll/longpoll.go
+//
+// Le transport par interrogation HTTP remplace la connexion TCP sur les
+// réseaux qui bloquent les ports inhabituels. Les messages du protocole
+// restent les mêmes (une ligne JSON par message): seul leur acheminement
+// change.
+//
+//	POST   /poll                 ouvre une session, répond {"session": id}
+//	POST   /poll/{id}?seq=N      envoie la commande numéro N (lignes JSON)
+//	GET    /poll/{id}?after=N    attend les événements qui suivent le numéro N
+//	DELETE /poll/{id}            ferme la session
+//
+// Commandes et événements sont numérotés à partir de 1. Une requête perdue
+// peut être reprise telle quelle: le serveur ignore une commande déjà reçue
+// et ne libère un événement qu'une fois acquitté par le curseur after.
+package longpoll
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Paramètres du transport
+const (
+	DefaultPort = "8082"
+
+	// PollWait est l'attente maximale d'une requête GET sans événement
+	PollWait = 25 * time.Second
+	// SessionTimeout ferme une session restée sans requête
+	SessionTimeout = 60 * time.Second
+
+	maxPending     = 1024    // Événements non acquittés avant de fermer la session
+	maxCommandSize = 1 << 20 // Taille maximale d'une requête de commande
+	maxRetries     = 5       // Essais d'une requête avant d'abandonner la session
+	retryDelay     = time.Second
+)
+
+// OpenResponse répond à l'ouverture d'une session
+type OpenResponse struct {
+	Session string `json:"session"`
+}
+
+// EventsResponse porte les événements qui suivent le curseur demandé.
+// Next est le numéro du dernier événement transmis, à renvoyer comme after.
+type EventsResponse struct {
+	Next     uint64            `json:"next"`
+	Messages []json.RawMessage `json:"messages"`
+}
+
+// Network est le réseau des adresses d'une session: les messages y passent
+// en JSON, un par événement
+const Network = "http"
+
+// addr est l'adresse d'une extrémité d'une session HTTP
+type addr string
+
+func (a addr) Network() string { return Network }
+func (a addr) String() string  { return string(a) }
+
+// noDeadlines complète net.Conn: les délais sont gérés par les requêtes HTTP
+type noDeadlines struct{}
+
+func (noDeadlines) SetDeadline(time.Time) error      { return nil }
+func (noDeadlines) SetReadDeadline(time.Time) error  { return nil }
+func (noDeadlines) SetWriteDeadline(time.Time) error { return nil }
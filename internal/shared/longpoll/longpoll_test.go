@@ -0,0 +1,130 @@
+// internal/shared/longpoll/longpoll_test.go
+package longpoll
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// echoServer répond à chaque ligne reçue par "echo <ligne>"
+func echoServer(t *testing.T) (*Handler, *httptest.Server) {
+	handler := NewHandler(func(conn net.Conn) {
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			fmt.Fprintf(conn, "{\"echo\":%q}\n", scanner.Text())
+		}
+	})
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return handler, server
+}
+
+func TestDialExchangesLines(t *testing.T) {
+	_, server := echoServer(t)
+
+	conn, err := Dial(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	for _, text := range []string{"hello", "world"} {
+		if _, err := fmt.Fprintf(conn, "%s\n", text); err != nil {
+			t.Fatal(err)
+		}
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf("{\"echo\":%q}\n", text); line != want {
+			t.Errorf("got %q, want %q", line, want)
+		}
+	}
+}
+
+func TestEventsReplayUntilAcknowledged(t *testing.T) {
+	handler, server := echoServer(t)
+
+	resp, err := http.Post(server.URL+"/poll", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var open OpenResponse
+	json.NewDecoder(resp.Body).Decode(&open)
+	resp.Body.Close()
+
+	id := open.Session
+	handler.mu.Lock()
+	s := handler.sessions[id]
+	handler.mu.Unlock()
+	if s == nil {
+		t.Fatalf("session %q not registered", id)
+	}
+
+	post := func(seq int) int {
+		resp, err := http.Post(fmt.Sprintf("%s/poll/%s?seq=%d", server.URL, id, seq), "", strings.NewReader("ping\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if code := post(1); code != http.StatusNoContent {
+		t.Fatalf("first command: %d", code)
+	}
+	// Une commande renvoyée après une réponse perdue n'est traitée qu'une fois
+	if code := post(1); code != http.StatusNoContent {
+		t.Fatalf("repeated command: %d", code)
+	}
+	if code := post(3); code != http.StatusConflict {
+		t.Errorf("skipped command: %d, want %d", code, http.StatusConflict)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	first, _ := s.wait(req, 0)
+	again, _ := s.wait(req, 0)
+	if len(first.Messages) != 1 || len(again.Messages) != 1 || first.Next != 1 {
+		t.Fatalf("events = %+v then %+v, want the same single event", first, again)
+	}
+
+	// Une fois acquitté, l'événement n'est plus renvoyé
+	ctx, cancel := contextWithTimeout(req, 50*time.Millisecond)
+	defer cancel()
+	if acked, _ := s.wait(ctx, first.Next); len(acked.Messages) != 0 {
+		t.Errorf("acknowledged event sent again: %+v", acked)
+	}
+}
+
+func TestClosedSessionIsForgotten(t *testing.T) {
+	handler, server := echoServer(t)
+
+	conn, err := Dial(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for handler.Sessions() > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := handler.Sessions(); n != 0 {
+		t.Errorf("%d sessions left after close", n)
+	}
+}
+
+// contextWithTimeout borne l'attente d'une requête de test
+func contextWithTimeout(req *http.Request, d time.Duration) (*http.Request, func()) {
+	ctx, cancel := context.WithTimeout(req.Context(), d)
+	return req.WithContext(ctx), cancel
+}
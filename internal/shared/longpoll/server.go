@@ -0,0 +1,320 @@
+// internal/shared/longpoll/server.go
+package longpoll
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Handler sert les sessions HTTP. Chaque session est remise à accept comme
+// une connexion ordinaire, traitée par le serveur comme une connexion TCP.
+type Handler struct {
+	accept   func(net.Conn)
+	mux      *http.ServeMux
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+// NewHandler crée le point d'entrée HTTP du transport
+func NewHandler(accept func(net.Conn)) *Handler {
+	h := &Handler{
+		accept:   accept,
+		mux:      http.NewServeMux(),
+		sessions: make(map[string]*session),
+	}
+	h.mux.HandleFunc("POST /poll", h.open)
+	h.mux.HandleFunc("POST /poll/{id}", h.command)
+	h.mux.HandleFunc("GET /poll/{id}", h.events)
+	h.mux.HandleFunc("DELETE /poll/{id}", h.close)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// Sessions retourne le nombre de sessions ouvertes
+func (h *Handler) Sessions() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.sessions)
+}
+
+// open crée une session et la remet au serveur
+func (h *Handler) open(w http.ResponseWriter, r *http.Request) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		http.Error(w, "session unavailable", http.StatusInternalServerError)
+		return
+	}
+	s := newSession(hex.EncodeToString(id), r.RemoteAddr, h.forget)
+
+	h.mu.Lock()
+	h.sessions[s.id] = s
+	h.mu.Unlock()
+
+	go h.accept(s)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(OpenResponse{Session: s.id})
+}
+
+// command transmet une commande au serveur, une seule fois par numéro
+func (h *Handler) command(w http.ResponseWriter, r *http.Request) {
+	s := h.lookup(r)
+	seq, err := strconv.ParseUint(r.URL.Query().Get("seq"), 10, 64)
+	switch {
+	case s == nil:
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	case err != nil || seq == 0:
+		http.Error(w, "invalid seq", http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCommandSize+1))
+	if err != nil {
+		return
+	}
+	if len(body) > maxCommandSize {
+		http.Error(w, "command too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	switch err := s.deliver(seq, body); {
+	case errors.Is(err, errOutOfOrder):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case err != nil:
+		http.Error(w, "session closed", http.StatusGone)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// events acquitte les événements jusqu'au curseur et attend les suivants
+func (h *Handler) events(w http.ResponseWriter, r *http.Request) {
+	s := h.lookup(r)
+	if s == nil {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+	after, err := strconv.ParseUint(r.URL.Query().Get("after"), 10, 64)
+	if err != nil && r.URL.Query().Get("after") != "" {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	resp, ok := s.wait(r, after)
+	if !ok {
+		http.Error(w, "session closed", http.StatusGone)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// close ferme une session à la demande du client
+func (h *Handler) close(w http.ResponseWriter, r *http.Request) {
+	if s := h.lookup(r); s != nil {
+		s.Close()
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lookup retrouve la session d'une requête et repousse son expiration
+func (h *Handler) lookup(r *http.Request) *session {
+	h.mu.Lock()
+	s := h.sessions[r.PathValue("id")]
+	h.mu.Unlock()
+	if s != nil {
+		s.touch()
+	}
+	return s
+}
+
+// forget retire une session fermée
+func (h *Handler) forget(id string) {
+	h.mu.Lock()
+	delete(h.sessions, id)
+	h.mu.Unlock()
+}
+
+// errOutOfOrder signale une commande arrivée avant la précédente
+var errOutOfOrder = errors.New("command out of order")
+
+// session est une connexion du serveur acheminée par requêtes HTTP
+type session struct {
+	noDeadlines
+	id      string
+	remote  addr
+	inbound *io.PipeReader // Commandes lues par le serveur
+	feed    *io.PipeWriter
+	sendMu  sync.Mutex // Sérialise les commandes vers le serveur
+	idle    *time.Timer
+
+	mu       sync.Mutex
+	events   []json.RawMessage // Événements non acquittés, à partir du numéro first
+	first    uint64
+	partial  []byte        // Début d'une ligne pas encore terminée
+	received uint64        // Numéro de la dernière commande transmise
+	wake     chan struct{} // Fermé à l'arrivée d'événements ou à la fermeture
+	closed   bool
+
+	closeOnce sync.Once
+	onClose   func(id string)
+}
+
+func newSession(id, remote string, onClose func(string)) *session {
+	inbound, feed := io.Pipe()
+	s := &session{
+		id:      id,
+		remote:  addr(remote),
+		inbound: inbound,
+		feed:    feed,
+		first:   1,
+		wake:    make(chan struct{}),
+		onClose: onClose,
+	}
+	s.idle = time.AfterFunc(SessionTimeout, func() {
+		log.Printf("HTTP session %s expired", s.id)
+		s.Close()
+	})
+	return s
+}
+
+// touch repousse l'expiration de la session
+func (s *session) touch() {
+	s.idle.Reset(SessionTimeout)
+}
+
+// deliver transmet une commande au serveur. Une commande déjà reçue est
+// ignorée: le client la renvoie quand la réponse s'est perdue.
+func (s *session) deliver(seq uint64, body []byte) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	s.mu.Lock()
+	received, closed := s.received, s.closed
+	s.mu.Unlock()
+	switch {
+	case closed:
+		return net.ErrClosed
+	case seq <= received:
+		return nil
+	case seq != received+1:
+		return errOutOfOrder
+	}
+
+	if _, err := s.feed.Write(body); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.received = seq
+	s.mu.Unlock()
+	return nil
+}
+
+// wait retire les événements acquittés puis retourne les suivants, en
+// attendant au plus PollWait qu'il en arrive. ok vaut false une fois la
+// session fermée et tous ses événements remis.
+func (s *session) wait(r *http.Request, after uint64) (EventsResponse, bool) {
+	timeout := time.NewTimer(PollWait)
+	defer timeout.Stop()
+
+	for {
+		s.mu.Lock()
+		if after >= s.first {
+			acked := min(after-s.first+1, uint64(len(s.events)))
+			s.events = s.events[acked:]
+			s.first += acked
+		}
+		if len(s.events) > 0 || s.closed {
+			resp := EventsResponse{
+				Next:     s.first + uint64(len(s.events)) - 1,
+				Messages: s.events,
+			}
+			closed := s.closed
+			s.mu.Unlock()
+			return resp, len(resp.Messages) > 0 || !closed
+		}
+		wake := s.wake
+		s.mu.Unlock()
+
+		select {
+		case <-wake:
+		case <-timeout.C:
+			return EventsResponse{Next: after, Messages: []json.RawMessage{}}, true
+		case <-r.Context().Done():
+			return EventsResponse{Next: after, Messages: []json.RawMessage{}}, true
+		}
+	}
+}
+
+// Read lit les commandes envoyées par le client
+func (s *session) Read(p []byte) (int, error) {
+	return s.inbound.Read(p)
+}
+
+// Write découpe les messages du serveur en événements, un par ligne
+func (s *session) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return 0, net.ErrClosed
+	}
+
+	s.partial = append(s.partial, p...)
+	for {
+		i := bytes.IndexByte(s.partial, '\n')
+		if i < 0 {
+			break
+		}
+		event := make(json.RawMessage, i)
+		copy(event, s.partial[:i])
+		s.partial = s.partial[i+1:]
+		if len(bytes.TrimSpace(event)) > 0 {
+			s.events = append(s.events, event)
+		}
+	}
+	overflow := len(s.events) > maxPending
+	close(s.wake)
+	s.wake = make(chan struct{})
+	s.mu.Unlock()
+
+	if overflow {
+		// Le client ne relève plus ses événements
+		s.Close()
+		return 0, errors.New("client is not polling")
+	}
+	return len(p), nil
+}
+
+// Close ferme la session; le serveur voit la fin de la connexion
+func (s *session) Close() error {
+	s.closeOnce.Do(func() {
+		s.idle.Stop()
+		s.mu.Lock()
+		s.closed = true
+		close(s.wake)
+		s.mu.Unlock()
+
+		s.feed.Close()
+		s.inbound.Close()
+		s.onClose(s.id)
+	})
+	return nil
+}
+
+func (s *session) LocalAddr() net.Addr  { return addr("longpoll") }
+func (s *session) RemoteAddr() net.Addr { return s.remote }
@@ -0,0 +1,81 @@
+// internal/shared/models/delta.go
+package models
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+)
+
+// Checksum résume le tour courant et l'état des pions de la salle. Le
+// serveur et les clients le calculent de la même façon: deux valeurs
+// différentes signalent un client désynchronisé.
+func (r *Room) Checksum() uint32 {
+	h := crc32.NewIEEE()
+	var buf [8]byte
+	write := func(v int64) {
+		binary.BigEndian.PutUint64(buf[:], uint64(v))
+		h.Write(buf[:])
+	}
+	flag := func(b bool) int64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	write(int64(r.CurrentTurn))
+	for _, player := range r.Players {
+		write(player.ID)
+		for _, token := range player.Tokens {
+			write(int64(token.ID))
+			write(int64(token.Position))
+			write(flag(token.IsHome)<<1 | flag(token.IsSafe))
+			write(int64(token.Laps))
+		}
+	}
+	return h.Sum32()
+}
+
+// TokenState retourne l'état d'un pion tel qu'il circule dans les deltas
+func (t *Token) TokenState(playerID int64) TokenDelta {
+	return TokenDelta{
+		PlayerID: playerID,
+		TokenID:  t.ID,
+		Position: t.Position,
+		IsHome:   t.IsHome,
+		IsSafe:   t.IsSafe,
+		Laps:     t.Laps,
+	}
+}
+
+// ApplyDelta reporte un delta sur la salle. Retourne false si le delta
+// désigne un pion inconnu: l'état local doit alors être redemandé.
+func (r *Room) ApplyDelta(delta *StateDeltaPayload) bool {
+	r.CurrentTurn = delta.CurrentTurn
+	for _, change := range delta.Tokens {
+		token := r.token(change.PlayerID, change.TokenID)
+		if token == nil {
+			return false
+		}
+		token.Position = change.Position
+		token.IsHome = change.IsHome
+		token.IsSafe = change.IsSafe
+		token.Laps = change.Laps
+	}
+	return true
+}
+
+// token retourne le pion d'un joueur, nil s'il n'existe pas
+func (r *Room) token(playerID int64, tokenID int) *Token {
+	for _, player := range r.Players {
+		if player.ID != playerID {
+			continue
+		}
+		for _, token := range player.Tokens {
+			if token.ID == tokenID {
+				return token
+			}
+		}
+	}
+	return nil
+}
@@ -9,16 +9,34 @@ import (
 
 // User représente un utilisateur
 type User struct {
-	ID           int64     `json:"id"`
-	Username     string    `json:"username"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"-"`
-	AvatarURL    string    `json:"avatar_url"`
-	Level        int       `json:"level"`
-	Experience   int       `json:"experience"`
-	Coins        int       `json:"coins"`
-	CreatedAt    time.Time `json:"created_at"`
-	LastLogin    time.Time `json:"last_login"`
+	ID           int64      `json:"id"`
+	Username     string     `json:"username"`
+	Email        string     `json:"email"`
+	PasswordHash string     `json:"-"`
+	AvatarURL    string     `json:"avatar_url"`
+	Level        int        `json:"level"`
+	Experience   int        `json:"experience"`
+	Coins        int        `json:"coins"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastLogin    *time.Time `json:"last_login,omitempty"` // nil tant que l'utilisateur ne s'est pas connecté
+	// Pace est le rythme de jeu déclaré, vide s'il est déduit des parties jouées
+	Pace constants.Pace `json:"pace,omitempty"`
+}
+
+// LevelProgress retourne l'expérience acquise dans le niveau en cours,
+// entre 0 et 1
+func (u *User) LevelProgress() float64 {
+	return float64(u.Experience%constants.ExperiencePerLevel) / constants.ExperiencePerLevel
+}
+
+// Public retourne le compte tel que les autres joueurs le voient, sans
+// adresse e-mail ni préférences
+func (u *User) Public() *User {
+	public := *u
+	public.Email = ""
+	public.PasswordHash = ""
+	public.Pace = ""
+	return &public
 }
 
 // PlayerStats représente les statistiques d'un joueur
@@ -27,6 +45,7 @@ type PlayerStats struct {
 	TotalGames     int     `json:"total_games"`
 	GamesWon       int     `json:"games_won"`
 	GamesLost      int     `json:"games_lost"`
+	GamesAborted   int     `json:"games_aborted"`
 	TokensCaptured int     `json:"tokens_captured"`
 	TokensLost     int     `json:"tokens_lost"`
 	SixesRolled    int     `json:"sixes_rolled"`
@@ -34,6 +53,47 @@ type PlayerStats struct {
 	WinRate        float64 `json:"win_rate"`
 	HighestStreak  int     `json:"highest_streak"`
 	CurrentStreak  int     `json:"current_streak"`
+	Decisions      int     `json:"decisions"`
+	DecisionMs     int64   `json:"decision_ms"`
+
+	// Cote des parties classées et son incertitude (écart-type)
+	Rating          float64 `json:"rating"`
+	RatingDeviation float64 `json:"rating_deviation"`
+	RankedGames     int     `json:"ranked_games"`
+
+	Rivals []Rivalry `json:"rivals,omitempty"` // Adversaires les plus affrontés
+}
+
+// Provisional indique que le joueur n'a pas fini ses parties de placement
+func (s *PlayerStats) Provisional() bool {
+	return s.RankedGames < constants.PlacementGames
+}
+
+// Rivalry résume les parties d'un joueur contre un adversaire donné
+type Rivalry struct {
+	OpponentID   int64     `json:"opponent_id"`
+	OpponentName string    `json:"opponent_name"`
+	Wins         int       `json:"wins"`
+	Losses       int       `json:"losses"`
+	Games        int       `json:"games"` // Parties jouées ensemble, égalités comprises
+	LastPlayed   time.Time `json:"last_played"`
+}
+
+// AvgDecision retourne le temps de réflexion moyen du joueur sur ses parties
+func (s *PlayerStats) AvgDecision() time.Duration {
+	if s.Decisions == 0 {
+		return 0
+	}
+	return time.Duration(s.DecisionMs/int64(s.Decisions)) * time.Millisecond
+}
+
+// LuckIndex compare la fréquence des 6 du joueur à celle d'un dé équitable:
+// 100 = chance moyenne, 120 = 20 % de 6 en plus. 0 tant qu'aucun dé n'a été lancé.
+func (s *PlayerStats) LuckIndex() float64 {
+	if s.TotalDiceRolls == 0 {
+		return 0
+	}
+	return float64(s.SixesRolled) * 6 * 100 / float64(s.TotalDiceRolls)
 }
 
 // Token représente un pion sur le plateau
@@ -43,6 +103,7 @@ type Token struct {
 	Position int                   `json:"position"` // -1 = base, 0-51 = plateau, 52-57 = maison
 	IsHome   bool                  `json:"is_home"`
 	IsSafe   bool                  `json:"is_safe"`
+	Laps     int                   `json:"laps,omitempty"` // Tours supplémentaires restants (handicap)
 }
 
 // Player représente un joueur dans une partie
@@ -57,6 +118,11 @@ type Player struct {
 	IsReady        bool                  `json:"is_ready"`
 	IsConnected    bool                  `json:"is_connected"`
 	ConsecutiveSix int                   `json:"consecutive_six"`
+	Decisions      int                   `json:"decisions"`       // Coups joués dans la partie
+	DecisionMs     int64                 `json:"decision_ms"`     // Temps de réflexion cumulé
+	Score          int                   `json:"score,omitempty"` // Points des variantes à score
+	Handicap       string                `json:"handicap,omitempty"`
+	Avatar         string                `json:"avatar,omitempty"` // Avatar des IA du catalogue
 }
 
 // Room représente une salle de jeu
@@ -74,6 +140,81 @@ type Room struct {
 	StartedAt   *time.Time          `json:"started_at,omitempty"`
 	IsPrivate   bool                `json:"is_private"`
 	Password    string              `json:"-"`
+	SkillDice   bool                `json:"skill_dice"`         // Dé à viser (parties amicales)
+	E2EChat     bool                `json:"encrypted_chat"`     // Chat chiffré de bout en bout
+	Rules       string              `json:"rules,omitempty"`    // Script de variante, vide = règles standard
+	RuleSet     constants.RuleSet   `json:"rule_set,omitempty"` // Forme de la partie, vide = partie classique
+	GameNumber  int                 `json:"game_number"`        // Numéro de la partie dans la salle, revanches comprises
+	Series      map[int64]int       `json:"series,omitempty"`   // Victoires par joueur sur la série
+	BestOf      int                 `json:"best_of,omitempty"`  // Format du match, 0 = partie simple
+	Pace        constants.Pace      `json:"pace,omitempty"`     // Rythme choisi par le matchmaking, vide = durée configurée
+	Queue       constants.Queue     `json:"queue,omitempty"`    // File du matchmaking, vide pour une salle créée par un joueur
+	Match       *MatchScore         `json:"match,omitempty"`    // Score du match en cours
+
+	// Rôles et permissions réglés par l'hôte (voir internal/shared/roles)
+	Roles       map[int64]constants.RoomRole                  `json:"roles,omitempty"`       // Modérateurs nommés
+	Permissions map[constants.RoomRole][]constants.Permission `json:"permissions,omitempty"` // Remplace les permissions par défaut d'un rôle
+	Muted       map[int64]bool                                `json:"muted,omitempty"`       // Joueurs privés de chat
+
+	Theme *LobbyTheme `json:"theme,omitempty"` // Ambiance du lobby choisie par l'hôte, nil = lobby standard
+
+	HouseRules RuleConfig `json:"house_rules"` // Règles maison choisies à la création
+
+	Tutorial bool `json:"tutorial,omitempty"` // Salle d'apprentissage contre le coach du serveur
+}
+
+// RuleConfig regroupe les règles maison d'une salle. La valeur zéro
+// correspond aux règles classiques.
+type RuleConfig struct {
+	SwapOnCapture bool `json:"swap_on_capture,omitempty"` // Le pion pris prend la case de départ du preneur au lieu de rentrer en base
+	Blockades     bool `json:"blockades,omitempty"`       // Deux pions d'une couleur forment un barrage que les adversaires ne passent pas
+	FreeFinish    bool `json:"free_finish,omitempty"`     // Un dé trop fort fait quand même arriver le pion
+	CaptureBonus  bool `json:"capture_bonus,omitempty"`   // Une capture fait rejouer
+	NoTripleSix   bool `json:"no_triple_six,omitempty"`   // Trois six de suite ne font plus perdre le tour
+	Undo          bool `json:"undo,omitempty"`            // Un coup peut être repris tant que le dé n'a pas été relancé
+}
+
+// Classic indique que la configuration suit les règles classiques
+func (rc RuleConfig) Classic() bool {
+	return rc == RuleConfig{}
+}
+
+// LobbyTheme est l'ambiance d'une salle à thème: tous les joueurs du lobby
+// voient le même décor et entendent le même morceau
+type LobbyTheme struct {
+	Background string `json:"background,omitempty"` // Couleur de fond "#rrggbb"
+	Pack       string `json:"pack,omitempty"`       // Pack de ressources du serveur: décor et morceaux
+	Track      string `json:"track,omitempty"`      // Morceau du pack joué dans le lobby
+}
+
+// RoomSummary décrit une salle publique dans la liste des salles
+type RoomSummary struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	HostName   string            `json:"host_name"`
+	Players    int               `json:"players"`
+	MaxPlayers int               `json:"max_players"`
+	BestOf     int               `json:"best_of,omitempty"`
+	Variant    bool              `json:"variant,omitempty"`        // Règles personnalisées
+	SkillDice  bool              `json:"skill_dice,omitempty"`     // Dé à viser
+	E2EChat    bool              `json:"encrypted_chat,omitempty"` // Mot de passe de chat requis
+	Party      bool              `json:"party,omitempty"`          // Lobby à thème
+	RuleSet    constants.RuleSet `json:"rule_set,omitempty"`       // Jeu de règles, vide = classique
+	HouseRules bool              `json:"house_rules,omitempty"`    // Règles maison
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// RoomListPayload est la liste des salles publiques en attente de joueurs
+type RoomListPayload struct {
+	Rooms []RoomSummary `json:"rooms"`
+}
+
+// MatchScore est le score d'un match au meilleur de N parties
+type MatchScore struct {
+	BestOf   int           `json:"best_of"`
+	Played   int           `json:"played"`
+	Wins     map[int64]int `json:"wins"`
+	WinnerID int64         `json:"winner_id,omitempty"` // 0 tant que le match continue
 }
 
 // Game représente l'état complet d'une partie
@@ -82,8 +223,12 @@ type Game struct {
 	Board       *Board       `json:"board"`
 	TurnHistory []TurnAction `json:"turn_history"`
 	StartTime   time.Time    `json:"start_time"`
+	FirstTurn   int          `json:"first_turn"` // Siège du joueur qui a commencé
 	Winner      *Player      `json:"winner,omitempty"`
 	Rankings    []*Player    `json:"rankings"`
+	Aborted     bool         `json:"aborted"`
+	EndReason   string       `json:"end_reason,omitempty"`
+	Seed        int64        `json:"seed,omitempty"` // Graine des dés, 0 = dés tirés de crypto/rand
 }
 
 // Board représente le plateau de jeu
@@ -92,14 +237,62 @@ type Board struct {
 	HomeStretches map[constants.PlayerColor][6]*Cell `json:"home_stretches"`
 }
 
-// Cell représente une case du plateau
+// Cell représente une case du plateau. Plusieurs pions peuvent s'y empiler:
+// sur une case sûre, ou à deux d'une même couleur pour former un barrage.
 type Cell struct {
-	Position int    `json:"position"`
-	IsSafe   bool   `json:"is_safe"`
-	Token    *Token `json:"token,omitempty"`
+	Position int      `json:"position"`
+	IsSafe   bool     `json:"is_safe"`
+	Tokens   []*Token `json:"tokens,omitempty"`
+}
+
+// Add empile un pion sur la case
+func (c *Cell) Add(token *Token) {
+	for _, t := range c.Tokens {
+		if t == token {
+			return
+		}
+	}
+	c.Tokens = append(c.Tokens, token)
+}
+
+// Remove retire un pion de la pile de la case
+func (c *Cell) Remove(token *Token) {
+	for i, t := range c.Tokens {
+		if t == token {
+			c.Tokens = append(c.Tokens[:i], c.Tokens[i+1:]...)
+			return
+		}
+	}
+}
+
+// Opponent retourne le premier pion de la case qui n'est pas de la couleur
+// donnée, nil s'il n'y en a pas
+func (c *Cell) Opponent(color constants.PlayerColor) *Token {
+	for _, t := range c.Tokens {
+		if t.Color != color {
+			return t
+		}
+	}
+	return nil
 }
 
-// TurnAction représente une action de tour
+// Blockade indique que la case porte un barrage: au moins deux pions, tous
+// de la même couleur, retournée
+func (c *Cell) Blockade() (constants.PlayerColor, bool) {
+	if len(c.Tokens) < 2 {
+		return "", false
+	}
+	owner := c.Tokens[0].Color
+	for _, t := range c.Tokens[1:] {
+		if t.Color != owner {
+			return "", false
+		}
+	}
+	return owner, true
+}
+
+// TurnAction représente une action de tour: un lancer de dé (TokenMoved
+// nil), puis le déplacement joué avec ce lancer
 type TurnAction struct {
 	PlayerID   int64     `json:"player_id"`
 	DiceValue  int       `json:"dice_value"`
@@ -107,11 +300,15 @@ type TurnAction struct {
 	FromPos    int       `json:"from_pos"`
 	ToPos      int       `json:"to_pos"`
 	Captured   *Token    `json:"captured,omitempty"`
+	DurationMs int64     `json:"duration_ms"` // Temps de réflexion avant le coup
 	Timestamp  time.Time `json:"timestamp"`
 }
 
-// NetworkMessage représente un message réseau
+// NetworkMessage représente un message réseau. Version est la version du
+// protocole de l'émetteur, absente chez les clients antérieurs au
+// versionnage (voir protocol.Version).
 type NetworkMessage struct {
+	Version   int                   `json:"version,omitempty"`
 	Type      constants.MessageType `json:"type"`
 	Payload   interface{}           `json:"payload"`
 	Timestamp time.Time             `json:"timestamp"`
@@ -128,13 +325,50 @@ type JoinRoomPayload struct {
 }
 
 type CreateRoomPayload struct {
-	Name       string `json:"name"`
-	MaxPlayers int    `json:"max_players"`
-	GameMode   string `json:"game_mode"`
-	IsPrivate  bool   `json:"is_private"`
-	Password   string `json:"password,omitempty"`
-	UserID     int64  `json:"user_id"`
-	Username   string `json:"username"`
+	Name          string            `json:"name"`
+	MaxPlayers    int               `json:"max_players"`
+	GameMode      string            `json:"game_mode"`
+	IsPrivate     bool              `json:"is_private"`
+	Password      string            `json:"password,omitempty"`
+	UserID        int64             `json:"user_id"`
+	Username      string            `json:"username"`
+	BestOf        int               `json:"best_of,omitempty"`
+	SkillDice     bool              `json:"skill_dice,omitempty"`
+	EncryptedChat bool              `json:"encrypted_chat,omitempty"`
+	Rules         string            `json:"rules,omitempty"` // Source de la variante de règles
+	RuleSet       constants.RuleSet `json:"rule_set,omitempty"`
+	HouseRules    RuleConfig        `json:"house_rules"`
+}
+
+// RoomCreatedPayload confirme la création de la salle à son hôte
+type RoomCreatedPayload struct {
+	RoomID        string `json:"room_id"`
+	Room          *Room  `json:"room"`
+	PublicAddress string `json:"public_address,omitempty"` // Adresse joignable depuis Internet
+}
+
+// PlayerJoinedPayload annonce un nouveau joueur dans la salle
+type PlayerJoinedPayload struct {
+	Player *Player `json:"player"`
+}
+
+// TurnChangedPayload annonce le joueur dont c'est le tour
+type TurnChangedPayload struct {
+	PlayerID int64 `json:"player_id"`
+}
+
+// ProfileRequestPayload demande le profil d'un joueur, par identifiant ou
+// par nom
+type ProfileRequestPayload struct {
+	UserID   int64  `json:"user_id,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// ProfilePayload est le profil d'un joueur: son compte, nil s'il n'a pas pu
+// être lu, et ses statistiques
+type ProfilePayload struct {
+	User  *User        `json:"user,omitempty"`
+	Stats *PlayerStats `json:"stats"`
 }
 
 type RollDicePayload struct {
@@ -148,13 +382,232 @@ type MoveTokenPayload struct {
 	TokenID  int    `json:"token_id"`
 }
 
+// SetHandicapPayload règle le handicap de départ d'un joueur (hôte seulement)
+type SetHandicapPayload struct {
+	PlayerID int64  `json:"player_id"`
+	Handicap string `json:"handicap"`
+}
+
+// KickPlayerPayload exclut un joueur de la salle (hôte seulement)
+type KickPlayerPayload struct {
+	PlayerID int64 `json:"player_id"`
+}
+
+// AssignColorPayload change la couleur d'un joueur (hôte seulement)
+type AssignColorPayload struct {
+	PlayerID int64                 `json:"player_id"`
+	Color    constants.PlayerColor `json:"color"`
+}
+
+// GamePausedPayload annonce la pause d'une partie par l'hôte
+type GamePausedPayload struct {
+	ResumesAt time.Time `json:"resumes_at"` // Reprise automatique si l'hôte ne reprend pas avant
+}
+
+// MoveUndonePayload annonce un coup repris: le joueur rejoue avec le même dé
+type MoveUndonePayload struct {
+	PlayerID  int64 `json:"player_id"`
+	DiceValue int   `json:"dice_value"`
+}
+
+// SetLobbyThemePayload change l'ambiance du lobby, nil la retire
+type SetLobbyThemePayload struct {
+	Theme *LobbyTheme `json:"theme,omitempty"`
+}
+
+// RatingHistoryRequestPayload demande l'évolution de la cote d'un joueur
+type RatingHistoryRequestPayload struct {
+	UserID int64 `json:"user_id"`
+}
+
+// RatingPoint est la cote d'un joueur après une partie classée ou une
+// érosion de fin de saison
+type RatingPoint struct {
+	Rating    float64   `json:"rating"`
+	Deviation float64   `json:"deviation"`
+	Reason    string    `json:"reason"`           // "game" ou "decay"
+	Season    int       `json:"season,omitempty"` // Saison close, pour une érosion
+	At        time.Time `json:"at"`
+}
+
+// RatingHistoryPayload donne les dernières cotes d'un joueur, des plus
+// anciennes aux plus récentes
+type RatingHistoryPayload struct {
+	UserID int64         `json:"user_id"`
+	Points []RatingPoint `json:"points"`
+}
+
+// RivalriesRequestPayload demande le bilan du joueur contre des adversaires
+type RivalriesRequestPayload struct {
+	OpponentIDs []int64 `json:"opponent_ids"`
+}
+
+// RivalriesPayload donne le bilan du joueur contre les adversaires déjà
+// affrontés; les autres sont absents
+type RivalriesPayload struct {
+	Records []Rivalry `json:"records"`
+}
+
+// SetRolePayload change le rôle d'un joueur (hôte seulement)
+type SetRolePayload struct {
+	PlayerID int64              `json:"player_id"`
+	Role     constants.RoomRole `json:"role"`
+}
+
+// SetPermissionsPayload remplace les permissions d'un rôle (hôte seulement)
+type SetPermissionsPayload struct {
+	Role        constants.RoomRole     `json:"role"`
+	Permissions []constants.Permission `json:"permissions"`
+}
+
+// MutePlayerPayload retire ou rend la parole à un joueur dans le chat
+type MutePlayerPayload struct {
+	PlayerID int64 `json:"player_id"`
+	Muted    bool  `json:"muted"`
+}
+
+// KickedPayload prévient un joueur exclu de la salle
+type KickedPayload struct {
+	RoomID string `json:"room_id"`
+	Reason string `json:"reason"` // Code du message à traduire
+}
+
+// RegisterPayload crée un compte
+type RegisterPayload struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Device   string `json:"device,omitempty"` // Identifiant de l'installation du client
+}
+
+// LoginPayload identifie le joueur par mot de passe, par le jeton
+// d'authentification reçu lors d'une connexion précédente, ou par le ticket
+// remis lors d'un transfert vers une autre instance
+type LoginPayload struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
+	Transfer string `json:"transfer,omitempty"`
+	// Device identifie l'installation du client. Le matchmaking ne place
+	// pas à la même table deux comptes du même appareil.
+	Device string `json:"device,omitempty"`
+}
+
+// TransferPayload envoie le joueur d'une salle en attente vers l'instance
+// qui la reprend, pendant le vidage du serveur
+type TransferPayload struct {
+	Address string `json:"address"`
+	RoomID  string `json:"room_id"`
+	Ticket  string `json:"ticket"` // Ticket de connexion à usage unique
+}
+
+// AuthenticatedPayload confirme l'identité du joueur pour la connexion
+type AuthenticatedPayload struct {
+	User  *User  `json:"user"`
+	Token string `json:"token"` // Réutilisable pour se reconnecter sans mot de passe
+}
+
+// ErrorPayload porte un code de message stable et ses paramètres, que le
+// client traduit (voir internal/shared/i18n). Message est le texte anglais,
+// pour les clients antérieurs à la traduction.
 type ErrorPayload struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code    string         `json:"code"`
+	Params  map[string]any `json:"params,omitempty"`
+	Message string         `json:"message"`
 }
 
 type GameStatePayload struct {
-	Game *Game `json:"game"`
+	Game         *Game `json:"game"`
+	Resync       bool  `json:"resync,omitempty"`        // État complet renvoyé après une reconnexion
+	AwaitingMove bool  `json:"awaiting_move,omitempty"` // Le joueur courant a lancé et doit jouer
+	Paused       bool  `json:"paused,omitempty"`        // L'hôte a mis la partie en pause
+	// Temps restant au joueur courant, pour reprendre le compte à rebours
+	TurnRemainingMs int64 `json:"turn_remaining_ms,omitempty"`
+}
+
+// TokenDelta est l'état d'un pion qui a changé depuis le dernier delta
+type TokenDelta struct {
+	PlayerID int64 `json:"player_id"`
+	TokenID  int   `json:"token_id"`
+	Position int   `json:"position"`
+	IsHome   bool  `json:"is_home,omitempty"`
+	IsSafe   bool  `json:"is_safe,omitempty"`
+	Laps     int   `json:"laps,omitempty"`
+}
+
+// StateDeltaPayload accompagne chaque changement de tour: seuls les pions
+// déplacés depuis le delta précédent sont envoyés. Checksum est celui de
+// la salle une fois le delta appliqué (voir Room.Checksum); un client dont
+// l'état diffère redemande l'état complet.
+type StateDeltaPayload struct {
+	CurrentTurn int          `json:"current_turn"`
+	Tokens      []TokenDelta `json:"tokens,omitempty"`
+	Checksum    uint32       `json:"checksum"`
+}
+
+// TurnTimerPayload annonce le temps laissé au joueur courant. Le client
+// décompte à partir de RemainingMs: Deadline suppose des horloges alignées.
+type TurnTimerPayload struct {
+	PlayerID    int64     `json:"player_id"`
+	Deadline    time.Time `json:"deadline"`
+	RemainingMs int64     `json:"remaining_ms"`
+}
+
+// TurnTimedOutPayload signale le tour sauté d'un joueur
+type TurnTimedOutPayload struct {
+	PlayerID int64 `json:"player_id"`
+}
+
+// MatchPlayer présente un adversaire proposé par le matchmaking
+type MatchPlayer struct {
+	ID       int64   `json:"id"`
+	Username string  `json:"username"`
+	WinRate  float64 `json:"win_rate"` // Pourcentage, 50 sans partie jouée
+	Rating   int     `json:"rating,omitempty"`
+	// Provisional signale un joueur en parties de placement
+	Provisional bool `json:"provisional,omitempty"`
+}
+
+// MatchFoundPayload propose une table à chaque joueur, à accepter avant
+// AcceptSeconds
+type MatchFoundPayload struct {
+	MatchID       string        `json:"match_id"`
+	Players       []MatchPlayer `json:"players"`
+	AcceptSeconds int           `json:"accept_seconds"`
+	// Pace est le rythme de la table, qui fixe la durée des tours
+	Pace        constants.Pace `json:"pace,omitempty"`
+	TurnSeconds int            `json:"turn_seconds,omitempty"`
+	// Queue est la file de la table: classée ou amicale
+	Queue     constants.Queue `json:"queue,omitempty"`
+	SkillDice bool            `json:"skill_dice,omitempty"`
+}
+
+// MatchReplyPayload accepte ou refuse la table proposée
+type MatchReplyPayload struct {
+	MatchID string `json:"match_id"`
+	Accept  bool   `json:"accept"`
+}
+
+// FindMatchPayload choisit la file de matchmaking. Un client antérieur aux
+// files n'envoie rien et entre dans la file classée.
+type FindMatchPayload struct {
+	Queue     constants.Queue `json:"queue,omitempty"`
+	SkillDice bool            `json:"skill_dice,omitempty"` // Dé à viser, file amicale seulement
+}
+
+// SetPacePayload déclare le rythme de jeu préféré. Un rythme vide revient
+// au rythme déduit des parties jouées.
+type SetPacePayload struct {
+	Pace constants.Pace `json:"pace"`
+}
+
+// MatchCancelledPayload annule une table proposée. Reason est un code de
+// message traduit par le client; Requeued indique que le joueur reste
+// dans la file.
+type MatchCancelledPayload struct {
+	MatchID  string `json:"match_id"`
+	Reason   string `json:"reason"`
+	Requeued bool   `json:"requeued"`
 }
 
 type DiceRolledPayload struct {
@@ -163,6 +616,19 @@ type DiceRolledPayload struct {
 	ExtraTurn bool  `json:"extra_turn"`
 }
 
+// RollOffRoll est le lancer d'un joueur pendant le tirage du premier joueur
+type RollOffRoll struct {
+	PlayerID int64 `json:"player_id"`
+	Value    int   `json:"value"`
+}
+
+// RollOffPayload détaille le tirage: chaque manche ne garde que les ex aequo
+// au plus haut lancer, jusqu'à ce qu'il n'en reste qu'un
+type RollOffPayload struct {
+	Rounds    [][]RollOffRoll `json:"rounds"`
+	StarterID int64           `json:"starter_id"`
+}
+
 type TokenMovedPayload struct {
 	PlayerID   int64 `json:"player_id"`
 	TokenID    int   `json:"token_id"`
@@ -182,6 +648,147 @@ type GameOverPayload struct {
 	Winner   *Player   `json:"winner"`
 	Rankings []*Player `json:"rankings"`
 	Duration int       `json:"duration_seconds"`
+	Reason   string    `json:"reason"`
+	Fastest  *Player   `json:"fastest,omitempty"` // Joueur humain le plus rapide à jouer
+	// Série de parties dans la salle, après celle-ci
+	GameNumber int           `json:"game_number"`
+	Series     map[int64]int `json:"series,omitempty"`
+	Match      *MatchScore   `json:"match,omitempty"`
+}
+
+// SpinStartedPayload annonce un sélecteur de dé et l'engagement du serveur
+type SpinStartedPayload struct {
+	PlayerID     int64  `json:"player_id"`
+	Commitment   string `json:"commitment"`
+	FacePeriodMs int64  `json:"face_period_ms"`
+}
+
+// StopSpinPayload transmet le moment où le joueur a arrêté le sélecteur
+type StopSpinPayload struct {
+	ElapsedMs int64 `json:"elapsed_ms"`
+}
+
+// SpinResultPayload révèle la graine pour que les clients vérifient le lancer
+type SpinResultPayload struct {
+	PlayerID  int64  `json:"player_id"`
+	Seed      string `json:"seed"`
+	ElapsedMs int64  `json:"elapsed_ms"`
+	Value     int    `json:"value"`
+}
+
+// CrashReportPayload est un rapport de plantage envoyé avec l'accord du joueur.
+// Il ne contient aucun identifiant de compte.
+type CrashReportPayload struct {
+	Version   string    `json:"version"`
+	OS        string    `json:"os"`
+	Arch      string    `json:"arch"`
+	Panic     string    `json:"panic"`
+	Stack     string    `json:"stack"`
+	Logs      []string  `json:"logs"`
+	CrashedAt time.Time `json:"crashed_at"`
+}
+
+// ChatPayload est un message de chat. Dans les salles à chat chiffré, seul
+// Ciphertext est rempli: le serveur relaie sans pouvoir lire. Les messages
+// du serveur peuvent porter un code que le client traduit (Text en repli).
+type ChatPayload struct {
+	UserID     int64          `json:"user_id"`
+	Username   string         `json:"username"`
+	Text       string         `json:"text,omitempty"`
+	Ciphertext string         `json:"ciphertext,omitempty"`
+	Code       string         `json:"code,omitempty"`
+	Params     map[string]any `json:"params,omitempty"`
+	SentAt     time.Time      `json:"sent_at"`
+}
+
+// AbortVotesPayload indique l'avancement d'un vote d'abandon
+type AbortVotesPayload struct {
+	Votes  []int64 `json:"votes"`
+	Needed int     `json:"needed"`
+}
+
+// LeaderboardRequestPayload demande une page du classement. Search filtre
+// les pseudos commençant par le texte saisi.
+type LeaderboardRequestPayload struct {
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+	Search string `json:"search,omitempty"`
+}
+
+// LeaderboardEntry est une ligne du classement. Le rang reste celui du
+// classement général, même dans une recherche.
+type LeaderboardEntry struct {
+	Rank       int     `json:"rank"`
+	UserID     int64   `json:"user_id"`
+	Username   string  `json:"username"`
+	AvatarURL  string  `json:"avatar_url,omitempty"`
+	Level      int     `json:"level"`
+	TotalGames int     `json:"total_games"`
+	GamesWon   int     `json:"games_won"`
+	WinRate    float64 `json:"win_rate"`
+	Rating     float64 `json:"rating"` // Cote des parties classées
+}
+
+// LeaderboardPayload est une page du classement
+type LeaderboardPayload struct {
+	Entries []LeaderboardEntry `json:"entries"`
+	Offset  int                `json:"offset"`
+	Search  string             `json:"search,omitempty"`
+	HasMore bool               `json:"has_more"` // D'autres pages suivent
+}
+
+// SessionTokenPayload transmet le jeton de reconnexion courant
+type SessionTokenPayload struct {
+	Token  string `json:"token"`
+	RoomID string `json:"room_id"`
+}
+
+// HelloPayload est le premier message du client. Codecs liste, par
+// préférence, les formats que le client sait lire en plus de JSON.
+type HelloPayload struct {
+	Version string   `json:"version"`
+	OS      string   `json:"os"`
+	Arch    string   `json:"arch"`
+	Codecs  []string `json:"codecs,omitempty"`
+}
+
+// WelcomePayload répond au HELLO avec les versions du client attendues
+type WelcomePayload struct {
+	Update        string `json:"update"` // none, available, required
+	MinVersion    string `json:"min_version,omitempty"`
+	LatestVersion string `json:"latest_version,omitempty"`
+	DownloadURL   string `json:"download_url,omitempty"`
+	Message       string `json:"message,omitempty"` // Texte anglais, pour les clients antérieurs à la traduction
+	// Codec des messages du serveur qui suivent celui-ci, JSON si vide
+	Codec string `json:"codec,omitempty"`
+}
+
+// AnnouncementPayload est une annonce du serveur à tous les clients,
+// affichée en bannière. Un texte vide retire la bannière en cours.
+type AnnouncementPayload struct {
+	ID        int64      `json:"id"`
+	Level     string     `json:"level"` // info, maintenance, event
+	Text      string     `json:"text"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// DataExportReadyPayload annonce que l'export des données du joueur peut
+// être téléchargé jusqu'à ExpiresAt
+type DataExportReadyPayload struct {
+	Size      int       `json:"size"` // Taille de l'archive en octets
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// DataExportPayload contient l'export des données du joueur: une archive
+// JSON (database.Archive) à enregistrer telle quelle
+type DataExportPayload struct {
+	FileName string `json:"file_name"`
+	Data     string `json:"data"`
+}
+
+// EventBatchPayload regroupe les événements envoyés aux spectateurs
+type EventBatchPayload struct {
+	Events []*NetworkMessage `json:"events"`
 }
 
 // NewPlayer crée un nouveau joueur
@@ -219,6 +826,37 @@ func NewAIPlayer(color constants.PlayerColor, level string) *Player {
 	return player
 }
 
+// ResetForRematch remet le joueur au départ pour une nouvelle partie dans la
+// même salle, en gardant son siège
+func (p *Player) ResetForRematch() {
+	p.Tokens = NewPlayer(p.ID, p.Username, p.Color).Tokens
+	p.TokensAtHome = 0
+	p.IsReady = p.IsAI
+	p.ConsecutiveSix = 0
+	p.Decisions = 0
+	p.DecisionMs = 0
+	p.Score = 0
+}
+
+// AvgDecision retourne le temps de réflexion moyen du joueur dans la partie
+func (p *Player) AvgDecision() time.Duration {
+	if p.Decisions == 0 {
+		return 0
+	}
+	return time.Duration(p.DecisionMs/int64(p.Decisions)) * time.Millisecond
+}
+
+// Rolls retourne les lancers de dé de la partie, dans l'ordre
+func (g *Game) Rolls() []int {
+	var rolls []int
+	for _, action := range g.TurnHistory {
+		if action.TokenMoved == nil {
+			rolls = append(rolls, action.DiceValue)
+		}
+	}
+	return rolls
+}
+
 // NewBoard crée un nouveau plateau
 func NewBoard() *Board {
 	cells := [52]*Cell{}
@@ -226,7 +864,6 @@ func NewBoard() *Board {
 		cells[i] = &Cell{
 			Position: i,
 			IsSafe:   contains(constants.SafePositions, i),
-			Token:    nil,
 		}
 	}
 
@@ -237,7 +874,6 @@ func NewBoard() *Board {
 			stretch[i] = &Cell{
 				Position: 52 + i,
 				IsSafe:   true,
-				Token:    nil,
 			}
 		}
 		homeStretches[color] = stretch
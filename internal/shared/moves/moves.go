@@ -0,0 +1,216 @@
+// internal/shared/moves/moves.go
+
+// Package moves regroupe les règles de déplacement communes au moteur du
+// serveur, au mode hors ligne du client et aux IA: sortie de base, entrée
+// dans le couloir, arrivée à la maison, cases sûres, captures et six.
+package moves
+
+import (
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// Positions particulières d'un pion
+const (
+	Base         = -1                        // Pion en base
+	StretchStart = constants.TotalCells      // Première case du couloir
+	StretchLen   = 5                         // Cases du couloir avant la maison
+	Home         = StretchStart + StretchLen // Pion arrivé
+)
+
+// Target calcule la case d'arrivée d'un pion de la couleur donnée. ok vaut
+// false si le coup est impossible: sortie de base sans 6, pion déjà arrivé
+// ou dépassement de la maison. Tant qu'il reste des tours à faire (laps),
+// le pion passe devant l'entrée du couloir sans y entrer.
+func Target(color constants.PlayerColor, from, dice, laps int) (int, bool) {
+	switch {
+	case from == Base:
+		if dice != constants.RollToStart {
+			return 0, false
+		}
+		return constants.StartingPositions[color], true
+	case from >= Home:
+		return 0, false
+	case from >= StretchStart:
+		to := from + dice
+		return to, to <= Home
+	}
+
+	to := from + dice
+	if CrossesHomeEntry(color, from, dice) && laps == 0 {
+		to = StretchStart + to - constants.HomeStretchStart[color]
+		return to, to <= Home
+	}
+	return to % constants.TotalCells, true
+}
+
+// CrossesHomeEntry indique si le coup atteint ou dépasse l'entrée du couloir
+func CrossesHomeEntry(color constants.PlayerColor, from, dice int) bool {
+	homeEntry := constants.HomeStretchStart[color]
+	return from >= 0 && from < homeEntry && from+dice >= homeEntry
+}
+
+// Legal calcule la case d'arrivée d'un pion du joueur et vérifie qu'elle
+// n'est pas déjà occupée par un autre de ses pions (sauf la maison), selon
+// les règles classiques
+func Legal(player *models.Player, token *models.Token, dice int) (int, bool) {
+	return LegalWith(models.RuleConfig{}, nil, player, token, dice)
+}
+
+// LegalWith est Legal sous les règles maison de la salle. players, les
+// joueurs de la partie, sert à repérer les barrages adverses.
+func LegalWith(house models.RuleConfig, players []*models.Player, player *models.Player, token *models.Token, dice int) (int, bool) {
+	code, to := check(house, players, player, token, dice)
+	return to, code == ""
+}
+
+// Rejection explique pourquoi Legal refuse le coup: le code d'erreur
+// (constants.ErrNeedSix...) et ses paramètres. Le code est vide si le coup
+// est légal.
+func Rejection(player *models.Player, token *models.Token, dice int) (string, map[string]any) {
+	return RejectionWith(models.RuleConfig{}, nil, player, token, dice)
+}
+
+// RejectionWith est Rejection sous les règles maison de la salle
+func RejectionWith(house models.RuleConfig, players []*models.Player, player *models.Player, token *models.Token, dice int) (string, map[string]any) {
+	code, _ := check(house, players, player, token, dice)
+	switch code {
+	case constants.ErrNeedSix:
+		return code, map[string]any{"six": constants.RollToStart}
+	case constants.ErrPastHome:
+		left := Progress(player.Color, Home) - Progress(player.Color, token.Position)
+		return code, map[string]any{"left": left}
+	}
+	return code, nil
+}
+
+// check juge un coup: la case d'arrivée, ou le code qui explique le refus
+func check(house models.RuleConfig, players []*models.Player, player *models.Player, token *models.Token, dice int) (string, int) {
+	switch {
+	case token.IsHome || token.Position >= Home:
+		return constants.ErrPawnHome, 0
+	case token.Position == Base && dice != constants.RollToStart:
+		return constants.ErrNeedSix, 0
+	}
+
+	to, ok := Target(player.Color, token.Position, dice, token.Laps)
+	if !ok {
+		// Seul un dépassement de la maison reste: permis sans compte exact
+		if !house.FreeFinish {
+			return constants.ErrPastHome, 0
+		}
+		to = Home
+	}
+
+	if to != Home && !canShare(house, player, token, to) {
+		return constants.ErrOwnPawnThere, 0
+	}
+	if house.Blockades && blocked(players, player, token, dice, to) {
+		return constants.ErrBlockade, 0
+	}
+	return "", to
+}
+
+// BlockadeSize est le nombre de pions d'une couleur qui forment un barrage
+const BlockadeSize = 2
+
+// canShare indique si le pion peut rejoindre ses pions déjà sur la case.
+// Avec les barrages, deux pions peuvent partager une case du parcours.
+func canShare(house models.RuleConfig, player *models.Player, token *models.Token, to int) bool {
+	own := 0
+	for _, other := range player.Tokens {
+		if other != token && other.Position == to {
+			own++
+		}
+	}
+	if own == 0 {
+		return true
+	}
+	return house.Blockades && to < StretchStart && own < BlockadeSize
+}
+
+// blocked indique qu'un barrage adverse se trouve sur le chemin du pion,
+// case d'arrivée comprise
+func blocked(players []*models.Player, player *models.Player, token *models.Token, dice, to int) bool {
+	if token.Position == Base {
+		return Blockade(players, player.Color, to)
+	}
+	for step := 1; step <= dice; step++ {
+		pos, ok := Target(player.Color, token.Position, step, token.Laps)
+		if !ok || pos >= StretchStart {
+			// Le couloir et la maison n'appartiennent qu'au joueur
+			return false
+		}
+		if Blockade(players, player.Color, pos) {
+			return true
+		}
+	}
+	return false
+}
+
+// Blockade indique qu'un adversaire de la couleur donnée tient un barrage
+// sur la case du parcours
+func Blockade(players []*models.Player, color constants.PlayerColor, position int) bool {
+	if position < 0 || position >= StretchStart {
+		return false
+	}
+	for _, other := range players {
+		if other.Color == color {
+			continue
+		}
+		count := 0
+		for _, t := range other.Tokens {
+			if t.Position == position {
+				count++
+			}
+		}
+		if count >= BlockadeSize {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSafe indique si un pion ne peut pas être capturé à cette position.
+// La base, le couloir et la maison sont toujours sûrs.
+func IsSafe(position int) bool {
+	if position < 0 || position >= constants.TotalCells {
+		return true
+	}
+	for _, safe := range constants.SafePositions {
+		if position == safe {
+			return true
+		}
+	}
+	return false
+}
+
+// Progress retourne le nombre de cases parcourues depuis le départ
+func Progress(color constants.PlayerColor, position int) int {
+	switch {
+	case position == Base:
+		return 0
+	case position >= StretchStart:
+		// Couloir: au-delà de la dernière case avant l'entrée
+		entry := (constants.HomeStretchStart[color] - constants.StartingPositions[color] + constants.TotalCells) % constants.TotalCells
+		return entry + position - StretchStart
+	}
+	return (position - constants.StartingPositions[color] + constants.TotalCells) % constants.TotalCells
+}
+
+// Roll applique un lancer au compteur de six du joueur. extra indique un
+// nouveau lancer après celui-ci; forfeit le troisième six consécutif, qui
+// fait perdre le tour sans jouer.
+func Roll(player *models.Player, dice int) (extra, forfeit bool) {
+	if dice != constants.RollForExtraTurn {
+		player.ConsecutiveSix = 0
+		return false, false
+	}
+
+	player.ConsecutiveSix++
+	if player.ConsecutiveSix >= constants.MaxConsecutiveSix {
+		player.ConsecutiveSix = 0
+		return false, true
+	}
+	return true, false
+}
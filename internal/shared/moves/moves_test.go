@@ -0,0 +1,215 @@
+// internal/shared/moves/moves_test.go
+package moves
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestTarget(t *testing.T) {
+	entry := constants.HomeStretchStart[constants.ColorGreen]
+	tests := []struct {
+		name      string
+		from      int
+		dice      int
+		laps      int
+		wantPos   int
+		wantLegal bool
+	}{
+		{"leave base with a six", Base, 6, 0, constants.StartingPositions[constants.ColorGreen], true},
+		{"stay in base without a six", Base, 5, 0, 0, false},
+		{"plain move", 20, 4, 0, 24, true},
+		{"wrap around the board", 50, 4, 0, 2, true},
+		{"land on the stretch entry", entry - 3, 3, 0, StretchStart, true},
+		{"walk into the stretch", entry - 1, 4, 0, StretchStart + 3, true},
+		{"reach home", StretchStart + 2, 3, 0, Home, true},
+		{"overshoot home", StretchStart + 2, 4, 0, 0, false},
+		{"extra lap passes the entry", entry - 1, 4, 1, entry + 3, true},
+		{"already home", Home, 1, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Target(constants.ColorGreen, tt.from, tt.dice, tt.laps)
+			if ok != tt.wantLegal || (ok && got != tt.wantPos) {
+				t.Errorf("Target(%d, %d, laps %d) = %d, %v; want %d, %v",
+					tt.from, tt.dice, tt.laps, got, ok, tt.wantPos, tt.wantLegal)
+			}
+		})
+	}
+}
+
+func TestLegalForbidsStackingOwnPawns(t *testing.T) {
+	player := &models.Player{Color: constants.ColorRed, Tokens: []*models.Token{
+		{ID: 0, Position: 10},
+		{ID: 1, Position: 14},
+		{ID: 2, Position: Home - 2},
+		{ID: 3, Position: Home, IsHome: true},
+	}}
+
+	if _, ok := Legal(player, player.Tokens[0], 4); ok {
+		t.Error("moving onto an own pawn should be illegal")
+	}
+	if to, ok := Legal(player, player.Tokens[0], 3); !ok || to != 13 {
+		t.Errorf("Legal = %d, %v; want 13, true", to, ok)
+	}
+	if _, ok := Legal(player, player.Tokens[2], 2); !ok {
+		t.Error("several pawns may share home")
+	}
+	if _, ok := Legal(player, player.Tokens[3], 1); ok {
+		t.Error("a pawn at home cannot move")
+	}
+}
+
+func TestRollThreeSixes(t *testing.T) {
+	player := &models.Player{}
+
+	for i := 1; i < constants.MaxConsecutiveSix; i++ {
+		if extra, forfeit := Roll(player, 6); !extra || forfeit {
+			t.Fatalf("six #%d: extra=%v forfeit=%v, want a reroll", i, extra, forfeit)
+		}
+	}
+	if extra, forfeit := Roll(player, 6); extra || !forfeit {
+		t.Errorf("third six: extra=%v forfeit=%v, want the turn lost", extra, forfeit)
+	}
+	if player.ConsecutiveSix != 0 {
+		t.Errorf("ConsecutiveSix = %d after a forfeit, want 0", player.ConsecutiveSix)
+	}
+
+	Roll(player, 6)
+	if extra, _ := Roll(player, 3); extra || player.ConsecutiveSix != 0 {
+		t.Error("any other value should reset the count")
+	}
+}
+
+func TestIsSafe(t *testing.T) {
+	for _, position := range constants.SafePositions {
+		if !IsSafe(position) {
+			t.Errorf("%d should be safe", position)
+		}
+	}
+	if IsSafe(constants.SafePositions[0] + 1) {
+		t.Error("a plain cell should not be safe")
+	}
+	if !IsSafe(Base) || !IsSafe(StretchStart) || !IsSafe(Home) {
+		t.Error("base, stretch and home should be safe")
+	}
+}
+
+func TestFindThreatsExposedTokens(t *testing.T) {
+	me := models.NewPlayer(1, "me", constants.ColorRed)
+	opponent := models.NewPlayer(2, "opponent", constants.ColorYellow)
+	me.Tokens[0].Position = 5  // À 3 cases du pion adverse
+	me.Tokens[1].Position = 8  // Case sûre
+	me.Tokens[2].Position = 30 // Hors de portée
+	opponent.Tokens[0].Position = 2
+
+	threats := FindThreats([]*models.Player{me, opponent}, me)
+	if len(threats.Exposed) != 1 || threats.Exposed[0] != me.Tokens[0] {
+		t.Errorf("Exposed = %+v, want only the token on 5", threats.Exposed)
+	}
+	if len(threats.Finishers) != 0 {
+		t.Errorf("Finishers = %+v, want none", threats.Finishers)
+	}
+}
+
+func TestFindThreatsFinishers(t *testing.T) {
+	me := models.NewPlayer(1, "me", constants.ColorRed)
+	near := models.NewPlayer(2, "near", constants.ColorGreen)
+	far := models.NewPlayer(3, "far", constants.ColorYellow)
+	for _, player := range []*models.Player{near, far} {
+		for _, token := range player.Tokens[1:] {
+			token.Position = Home
+			token.IsHome = true
+		}
+	}
+	near.Tokens[0].Position = StretchStart + 2
+	far.Tokens[0].Position = Base // Sortir demande un 6, puis tout le parcours
+
+	threats := FindThreats([]*models.Player{me, near, far}, me)
+	if len(threats.Finishers) != 1 || threats.Finishers[0] != near.Tokens[0] {
+		t.Errorf("Finishers = %+v, want only the last token of near", threats.Finishers)
+	}
+}
+
+func TestRejection(t *testing.T) {
+	player := &models.Player{Color: constants.ColorRed, Tokens: []*models.Token{
+		{ID: 0, Position: Base},
+		{ID: 1, Position: 14},
+		{ID: 2, Position: Home - 2},
+		{ID: 3, Position: Home, IsHome: true},
+	}}
+	tests := []struct {
+		name  string
+		token int
+		dice  int
+		code  string
+		left  any
+	}{
+		{"legal move", 1, 3, "", nil},
+		{"base without a six", 0, 4, constants.ErrNeedSix, nil},
+		{"already home", 3, 1, constants.ErrPawnHome, nil},
+		{"overshoot home", 2, 3, constants.ErrPastHome, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, params := Rejection(player, player.Tokens[tt.token], tt.dice)
+			if code != tt.code || (tt.left != nil && params["left"] != tt.left) {
+				t.Errorf("Rejection = %q %v; want %q left %v", code, params, tt.code, tt.left)
+			}
+		})
+	}
+
+	player.Tokens[0].Position = 17
+	if code, _ := Rejection(player, player.Tokens[1], 3); code != constants.ErrOwnPawnThere {
+		t.Errorf("Rejection = %q; want %q", code, constants.ErrOwnPawnThere)
+	}
+}
+
+func TestLegalWithHouseRules(t *testing.T) {
+	red := &models.Player{Color: constants.ColorRed, Tokens: []*models.Token{
+		{ID: 0, Position: 10},
+		{ID: 1, Position: 14},
+		{ID: 2, Position: Home - 2},
+		{ID: 3, Position: Base},
+	}}
+	blue := &models.Player{Color: constants.ColorBlue, Tokens: []*models.Token{
+		{ID: 0, Position: 12},
+		{ID: 1, Position: 12},
+		{ID: 2, Position: Base},
+		{ID: 3, Position: Base},
+	}}
+	players := []*models.Player{red, blue}
+
+	free := models.RuleConfig{FreeFinish: true}
+	if to, ok := LegalWith(free, players, red, red.Tokens[2], 5); !ok || to != Home {
+		t.Errorf("free finish: LegalWith = %d, %v; want home", to, ok)
+	}
+
+	walls := models.RuleConfig{Blockades: true}
+	if code, _ := RejectionWith(walls, players, red, red.Tokens[0], 3); code != constants.ErrBlockade {
+		t.Errorf("passing a blockade: code %q, want %q", code, constants.ErrBlockade)
+	}
+	if _, ok := LegalWith(walls, players, red, red.Tokens[0], 1); !ok {
+		t.Error("stopping before a blockade should be legal")
+	}
+	if _, ok := LegalWith(models.RuleConfig{}, players, red, red.Tokens[0], 3); !ok {
+		t.Error("classic rules ignore blockades")
+	}
+
+	// Deux pions rouges peuvent former un barrage, pas trois
+	blue.Tokens[0].Position, blue.Tokens[1].Position = Base, Base
+	if _, ok := LegalWith(walls, players, red, red.Tokens[0], 4); !ok {
+		t.Error("blockades let two own pawns share a square")
+	}
+	if _, ok := Legal(red, red.Tokens[0], 4); ok {
+		t.Error("classic rules forbid sharing a square")
+	}
+	red.Tokens[3].Position = 14
+	if code, _ := RejectionWith(walls, players, red, red.Tokens[0], 4); code != constants.ErrOwnPawnThere {
+		t.Errorf("third pawn on a blockade: code %q, want %q", code, constants.ErrOwnPawnThere)
+	}
+}
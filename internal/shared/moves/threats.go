@@ -0,0 +1,79 @@
+// internal/shared/moves/threats.go
+package moves
+
+import (
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// Threats regroupe les dangers du prochain tour adverse pour un joueur
+type Threats struct {
+	Exposed   []*models.Token // Pions du joueur qu'un adversaire peut prendre d'un seul dé
+	Finishers []*models.Token // Derniers pions d'adversaires qui peuvent gagner d'un seul dé
+}
+
+// FindThreats calcule les dangers pour me d'après l'état de la partie. Un
+// pion est exposé s'il est hors case sûre et qu'un pion adverse peut y
+// arriver avec une valeur de dé; un adversaire est à un coup de la victoire
+// s'il ne lui reste qu'un pion, capable d'atteindre la maison d'un dé.
+func FindThreats(players []*models.Player, me *models.Player) Threats {
+	var threats Threats
+	for _, opponent := range players {
+		if opponent == me || opponent.Color == me.Color {
+			continue
+		}
+		if token := finisher(opponent); token != nil {
+			threats.Finishers = append(threats.Finishers, token)
+		}
+	}
+
+	for _, token := range me.Tokens {
+		if token.IsHome || IsSafe(token.Position) {
+			continue
+		}
+		for _, opponent := range players {
+			if opponent.Color != me.Color && canReach(opponent, token.Position) {
+				threats.Exposed = append(threats.Exposed, token)
+				break
+			}
+		}
+	}
+	return threats
+}
+
+// canReach indique si un pion du joueur peut arriver sur la case d'un seul dé
+func canReach(player *models.Player, position int) bool {
+	for _, token := range player.Tokens {
+		for dice := constants.DiceMin; dice <= constants.DiceMax; dice++ {
+			if to, ok := Legal(player, token, dice); ok && to == position {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// finisher retourne le dernier pion du joueur s'il peut rentrer d'un dé et
+// faire gagner le joueur, nil sinon
+func finisher(player *models.Player) *models.Token {
+	var last *models.Token
+	for _, token := range player.Tokens {
+		if token.IsHome {
+			continue
+		}
+		if last != nil {
+			return nil
+		}
+		last = token
+	}
+	if last == nil {
+		return nil
+	}
+
+	for dice := constants.DiceMin; dice <= constants.DiceMax; dice++ {
+		if to, ok := Legal(player, last, dice); ok && to == Home {
+			return last
+		}
+	}
+	return nil
+}
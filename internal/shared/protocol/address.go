@@ -0,0 +1,43 @@
+// internal/shared/protocol/address.go
+package protocol
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// NormalizeAddress convertit une saisie utilisateur en adresse "hôte:port".
+// Formes acceptées: "hôte", "hôte:port", "[ipv6]", "[ipv6]:port" et une IPv6
+// nue ("::1", "fe80::1%eth0"); avec un port, l'IPv6 doit être entre crochets.
+func NormalizeAddress(input, defaultPort string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("empty server address")
+	}
+
+	host, port, err := net.SplitHostPort(input)
+	if err != nil {
+		switch {
+		case strings.HasPrefix(input, "[") && strings.HasSuffix(input, "]"):
+			host = input[1 : len(input)-1]
+		case strings.Count(input, ":") >= 2:
+			host = input // IPv6 nue, sans port
+		case strings.Contains(input, ":"):
+			return "", fmt.Errorf("invalid server address %q: %w", input, err)
+		default:
+			host = input
+		}
+		port = defaultPort
+	}
+
+	if host == "" {
+		return "", fmt.Errorf("invalid server address %q: missing host", input)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return "", fmt.Errorf("invalid server port %q", port)
+	}
+
+	return net.JoinHostPort(host, port), nil
+}
@@ -0,0 +1,33 @@
+// internal/shared/protocol/address_test.go
+package protocol
+
+import "testing"
+
+func TestNormalizeAddress(t *testing.T) {
+	cases := map[string]string{
+		"localhost":          "localhost:8080",
+		"example.com:9000":   "example.com:9000",
+		"192.168.1.10":       "192.168.1.10:8080",
+		"::1":                "[::1]:8080",
+		"[::1]":              "[::1]:8080",
+		"[2001:db8::1]:9000": "[2001:db8::1]:9000",
+		"fe80::1%eth0":       "[fe80::1%eth0]:8080",
+		" [::1]:8080 ":       "[::1]:8080",
+	}
+	for input, want := range cases {
+		got, err := NormalizeAddress(input, "8080")
+		if err != nil {
+			t.Errorf("NormalizeAddress(%q) error: %v", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("NormalizeAddress(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	for _, input := range []string{"", "host:port", "[::1]:0", ":8080"} {
+		if _, err := NormalizeAddress(input, "8080"); err == nil {
+			t.Errorf("NormalizeAddress(%q) should fail", input)
+		}
+	}
+}
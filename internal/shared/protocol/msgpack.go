@@ -0,0 +1,916 @@
+// internal/shared/protocol/msgpack.go
+package protocol
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// Le codec MessagePack (https://msgpack.org) suit les balises json des
+// modèles: mêmes noms de champs, mêmes omitempty. Les structures deviennent
+// des maps, les dates l'extension timestamp (-1). Les messages se suivent
+// sans séparateur: chaque valeur porte sa propre longueur.
+
+// maxMsgPackLength borne la taille annoncée d'une chaîne, d'un tableau ou
+// d'une map, pour qu'un message corrompu ne réserve pas toute la mémoire
+const maxMsgPackLength = 1 << 24
+
+// extTimestamp est le type d'extension réservé aux dates
+const extTimestamp = -1
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// msgpackSerializer lit et écrit des messages MessagePack
+type msgpackSerializer struct {
+	reader *bufio.Reader
+	writer io.Writer
+	buf    []byte // Message en cours d'encodage, écrit d'un seul bloc
+}
+
+func newMsgPackSerializer(reader io.Reader, writer io.Writer) *msgpackSerializer {
+	s := &msgpackSerializer{writer: writer}
+	if reader != nil {
+		s.reader = bufio.NewReader(reader)
+	}
+	return s
+}
+
+// Encode écrit un message MessagePack
+func (s *msgpackSerializer) Encode(msg *models.NetworkMessage) error {
+	s.buf = s.buf[:0]
+	if err := s.encode(reflect.ValueOf(msg)); err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	if _, err := s.writer.Write(s.buf); err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	return nil
+}
+
+// Decode lit un message MessagePack. Le payload d'un type enregistré (voir
+// Register) est décodé directement dans sa valeur typée.
+func (s *msgpackSerializer) Decode(msg *models.NetworkMessage) error {
+	*msg = models.NetworkMessage{}
+	if err := s.decodeMessage(msg); err != nil {
+		return fmt.Errorf("failed to decode message: %w", err)
+	}
+	return nil
+}
+
+// buffered retourne les octets déjà lus mais pas encore décodés
+func (s *msgpackSerializer) buffered() io.Reader {
+	return s.reader
+}
+
+// decodeMessage lit l'enveloppe champ par champ: le type précède le payload
+// et désigne la valeur dans laquelle le décoder
+func (s *msgpackSerializer) decodeMessage(msg *models.NetworkMessage) error {
+	code, err := s.reader.ReadByte()
+	if err != nil {
+		return err
+	}
+	n, err := s.mapLength(code)
+	if err != nil {
+		return err
+	}
+
+	fields := cachedFields(reflect.TypeOf(*msg))
+	target := reflect.ValueOf(msg).Elem()
+	for i := 0; i < n; i++ {
+		key, err := s.readString()
+		if err != nil {
+			return err
+		}
+		if key == "payload" {
+			if err := s.decodePayload(msg); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.decodeField(target, fields, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodePayload décode le payload dans la valeur enregistrée pour le type
+// du message, sinon en valeur générique
+func (s *msgpackSerializer) decodePayload(msg *models.NetworkMessage) error {
+	entry, ok := decoders[msg.Type]
+	if !ok {
+		var payload interface{}
+		if err := s.decode(reflect.ValueOf(&payload).Elem()); err != nil {
+			return err
+		}
+		msg.Payload = payload
+		return nil
+	}
+
+	code, err := s.reader.ReadByte()
+	if err != nil {
+		return err
+	}
+	if code == 0xc0 {
+		return nil
+	}
+	payload := entry.new()
+	if err := s.decodeWith(code, reflect.ValueOf(payload).Elem()); err != nil {
+		return err
+	}
+	msg.Payload = payload
+	return nil
+}
+
+// --- Encodage ---
+
+func (s *msgpackSerializer) encode(v reflect.Value) error {
+	if !v.IsValid() {
+		s.buf = append(s.buf, 0xc0)
+		return nil
+	}
+	if v.Type() == timeType {
+		s.encodeTime(v.Interface().(time.Time))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			s.buf = append(s.buf, 0xc0)
+			return nil
+		}
+		return s.encode(v.Elem())
+	case reflect.Bool:
+		if v.Bool() {
+			s.buf = append(s.buf, 0xc3)
+		} else {
+			s.buf = append(s.buf, 0xc2)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s.encodeInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s.encodeUint(v.Uint())
+	case reflect.Float32:
+		s.buf = append(s.buf, 0xca)
+		s.buf = binary.BigEndian.AppendUint32(s.buf, math.Float32bits(float32(v.Float())))
+	case reflect.Float64:
+		s.buf = append(s.buf, 0xcb)
+		s.buf = binary.BigEndian.AppendUint64(s.buf, math.Float64bits(v.Float()))
+	case reflect.String:
+		s.encodeString(v.String())
+	case reflect.Slice:
+		if v.IsNil() {
+			s.buf = append(s.buf, 0xc0)
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			s.encodeBytes(v.Bytes())
+			return nil
+		}
+		return s.encodeArray(v)
+	case reflect.Array:
+		return s.encodeArray(v)
+	case reflect.Map:
+		if v.IsNil() {
+			s.buf = append(s.buf, 0xc0)
+			return nil
+		}
+		return s.encodeMap(v)
+	case reflect.Struct:
+		return s.encodeStruct(v)
+	default:
+		return fmt.Errorf("msgpack: unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func (s *msgpackSerializer) encodeInt(n int64) {
+	switch {
+	case n >= 0:
+		s.encodeUint(uint64(n))
+	case n >= -32:
+		s.buf = append(s.buf, byte(n))
+	case n >= math.MinInt8:
+		s.buf = append(s.buf, 0xd0, byte(n))
+	case n >= math.MinInt16:
+		s.buf = binary.BigEndian.AppendUint16(append(s.buf, 0xd1), uint16(n))
+	case n >= math.MinInt32:
+		s.buf = binary.BigEndian.AppendUint32(append(s.buf, 0xd2), uint32(n))
+	default:
+		s.buf = binary.BigEndian.AppendUint64(append(s.buf, 0xd3), uint64(n))
+	}
+}
+
+func (s *msgpackSerializer) encodeUint(n uint64) {
+	switch {
+	case n <= 0x7f:
+		s.buf = append(s.buf, byte(n))
+	case n <= math.MaxUint8:
+		s.buf = append(s.buf, 0xcc, byte(n))
+	case n <= math.MaxUint16:
+		s.buf = binary.BigEndian.AppendUint16(append(s.buf, 0xcd), uint16(n))
+	case n <= math.MaxUint32:
+		s.buf = binary.BigEndian.AppendUint32(append(s.buf, 0xce), uint32(n))
+	default:
+		s.buf = binary.BigEndian.AppendUint64(append(s.buf, 0xcf), n)
+	}
+}
+
+func (s *msgpackSerializer) encodeString(str string) {
+	n := len(str)
+	switch {
+	case n < 32:
+		s.buf = append(s.buf, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		s.buf = append(s.buf, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		s.buf = binary.BigEndian.AppendUint16(append(s.buf, 0xda), uint16(n))
+	default:
+		s.buf = binary.BigEndian.AppendUint32(append(s.buf, 0xdb), uint32(n))
+	}
+	s.buf = append(s.buf, str...)
+}
+
+func (s *msgpackSerializer) encodeBytes(b []byte) {
+	n := len(b)
+	switch {
+	case n <= math.MaxUint8:
+		s.buf = append(s.buf, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		s.buf = binary.BigEndian.AppendUint16(append(s.buf, 0xc5), uint16(n))
+	default:
+		s.buf = binary.BigEndian.AppendUint32(append(s.buf, 0xc6), uint32(n))
+	}
+	s.buf = append(s.buf, b...)
+}
+
+// encodeTime écrit une date au format timestamp 96 bits
+func (s *msgpackSerializer) encodeTime(t time.Time) {
+	s.buf = append(s.buf, 0xc7, 12, 0xff) // 0xff: extTimestamp
+	s.buf = binary.BigEndian.AppendUint32(s.buf, uint32(t.Nanosecond()))
+	s.buf = binary.BigEndian.AppendUint64(s.buf, uint64(t.Unix()))
+}
+
+func (s *msgpackSerializer) encodeLength(n int, fix, code16, code32 byte, fixMax int) {
+	switch {
+	case n <= fixMax:
+		s.buf = append(s.buf, fix|byte(n))
+	case n <= math.MaxUint16:
+		s.buf = binary.BigEndian.AppendUint16(append(s.buf, code16), uint16(n))
+	default:
+		s.buf = binary.BigEndian.AppendUint32(append(s.buf, code32), uint32(n))
+	}
+}
+
+func (s *msgpackSerializer) encodeArray(v reflect.Value) error {
+	s.encodeLength(v.Len(), 0x90, 0xdc, 0xdd, 15)
+	for i := 0; i < v.Len(); i++ {
+		if err := s.encode(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeMap écrit une map dont les clés sont des chaînes ou des entiers
+func (s *msgpackSerializer) encodeMap(v reflect.Value) error {
+	s.encodeLength(v.Len(), 0x80, 0xde, 0xdf, 15)
+	iter := v.MapRange()
+	for iter.Next() {
+		key := iter.Key()
+		switch key.Kind() {
+		case reflect.String:
+			s.encodeString(key.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			s.encodeInt(key.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			s.encodeUint(key.Uint())
+		default:
+			return fmt.Errorf("msgpack: unsupported map key %s", key.Type())
+		}
+		if err := s.encode(iter.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *msgpackSerializer) encodeStruct(v reflect.Value) error {
+	fields := cachedFields(v.Type())
+	present := 0
+	for _, f := range fields.list {
+		if !f.omitEmpty || !isEmptyValue(v.Field(f.index)) {
+			present++
+		}
+	}
+
+	s.encodeLength(present, 0x80, 0xde, 0xdf, 15)
+	for _, f := range fields.list {
+		field := v.Field(f.index)
+		if f.omitEmpty && isEmptyValue(field) {
+			continue
+		}
+		s.encodeString(f.name)
+		if err := s.encode(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isEmptyValue reprend la règle omitempty de encoding/json
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// --- Champs des structures ---
+
+type structField struct {
+	index     int
+	name      string
+	omitEmpty bool
+}
+
+type structFields struct {
+	list   []structField
+	byName map[string]int // Position dans list
+}
+
+var fieldCache sync.Map // reflect.Type -> *structFields
+
+// cachedFields retourne les champs exportés d'une structure sous leur nom
+// json. Les modèles n'imbriquent pas de structures anonymes.
+func cachedFields(t reflect.Type) *structFields {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.(*structFields)
+	}
+
+	fields := &structFields{byName: make(map[string]int)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		fields.byName[name] = len(fields.list)
+		fields.list = append(fields.list, structField{
+			index:     i,
+			name:      name,
+			omitEmpty: strings.Contains(","+options+",", ",omitempty,"),
+		})
+	}
+
+	cached, _ := fieldCache.LoadOrStore(t, fields)
+	return cached.(*structFields)
+}
+
+// lookup retrouve un champ par son nom, sans tenir compte de la casse à
+// défaut du nom exact, comme encoding/json
+func (f *structFields) lookup(name string) (structField, bool) {
+	if i, ok := f.byName[name]; ok {
+		return f.list[i], true
+	}
+	for _, field := range f.list {
+		if strings.EqualFold(field.name, name) {
+			return field, true
+		}
+	}
+	return structField{}, false
+}
+
+// --- Décodage ---
+
+func (s *msgpackSerializer) decode(v reflect.Value) error {
+	code, err := s.reader.ReadByte()
+	if err != nil {
+		return err
+	}
+	return s.decodeWith(code, v)
+}
+
+// decodeWith décode dans v la valeur dont l'octet de tête est code
+func (s *msgpackSerializer) decodeWith(code byte, v reflect.Value) error {
+	if code == 0xc0 {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch {
+	case v.Type() == timeType:
+		t, err := s.readTime(code)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case v.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return s.decodeWith(code, v.Elem())
+	case v.Kind() == reflect.Interface:
+		if v.NumMethod() != 0 {
+			return fmt.Errorf("msgpack: cannot decode into %s", v.Type())
+		}
+		value, err := s.readAny(code)
+		if err != nil {
+			return err
+		}
+		if value == nil {
+			v.Set(reflect.Zero(v.Type()))
+		} else {
+			v.Set(reflect.ValueOf(value))
+		}
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		switch code {
+		case 0xc2:
+			v.SetBool(false)
+		case 0xc3:
+			v.SetBool(true)
+		default:
+			return mismatch(code, v)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := s.readInt(code)
+		if err != nil {
+			return err
+		}
+		if v.OverflowInt(n) {
+			return fmt.Errorf("msgpack: %d overflows %s", n, v.Type())
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := s.readInt(code)
+		if err != nil {
+			return err
+		}
+		if n < 0 || v.OverflowUint(uint64(n)) {
+			return fmt.Errorf("msgpack: %d overflows %s", n, v.Type())
+		}
+		v.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		f, err := s.readFloat(code)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.String:
+		str, err := s.readStringWith(code)
+		if err != nil {
+			return err
+		}
+		v.SetString(str)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 && isBin(code) {
+			b, err := s.readBin(code)
+			if err != nil {
+				return err
+			}
+			v.SetBytes(b)
+			return nil
+		}
+		n, err := s.arrayLength(code)
+		if err != nil {
+			return err
+		}
+		slice := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			if err := s.decode(slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.Array:
+		n, err := s.arrayLength(code)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			if i >= v.Len() {
+				if err := s.skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := s.decode(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		return s.decodeMap(code, v)
+	case reflect.Struct:
+		n, err := s.mapLength(code)
+		if err != nil {
+			return err
+		}
+		fields := cachedFields(v.Type())
+		for i := 0; i < n; i++ {
+			key, err := s.readString()
+			if err != nil {
+				return err
+			}
+			if err := s.decodeField(v, fields, key); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("msgpack: unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// decodeField décode la valeur suivante dans le champ nommé, ou l'ignore
+// si la structure n'a pas ce champ
+func (s *msgpackSerializer) decodeField(v reflect.Value, fields *structFields, key string) error {
+	field, ok := fields.lookup(key)
+	if !ok {
+		return s.skip()
+	}
+	return s.decode(v.Field(field.index))
+}
+
+func (s *msgpackSerializer) decodeMap(code byte, v reflect.Value) error {
+	n, err := s.mapLength(code)
+	if err != nil {
+		return err
+	}
+	if v.IsNil() {
+		v.Set(reflect.MakeMapWithSize(v.Type(), n))
+	}
+
+	keyType, elemType := v.Type().Key(), v.Type().Elem()
+	for i := 0; i < n; i++ {
+		key := reflect.New(keyType).Elem()
+		code, err := s.reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		// Clé entière écrite en texte, comme en JSON
+		if isString(code) && keyType.Kind() != reflect.String {
+			text, err := s.readStringWith(code)
+			if err != nil {
+				return err
+			}
+			if err := setIntKey(key, text); err != nil {
+				return err
+			}
+		} else if err := s.decodeWith(code, key); err != nil {
+			return err
+		}
+
+		elem := reflect.New(elemType).Elem()
+		if err := s.decode(elem); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+	}
+	return nil
+}
+
+func setIntKey(key reflect.Value, text string) error {
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil || key.OverflowInt(n) {
+			return fmt.Errorf("msgpack: invalid map key %q for %s", text, key.Type())
+		}
+		key.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, 64)
+		if err != nil || key.OverflowUint(n) {
+			return fmt.Errorf("msgpack: invalid map key %q for %s", text, key.Type())
+		}
+		key.SetUint(n)
+	default:
+		return fmt.Errorf("msgpack: unsupported map key %s", key.Type())
+	}
+	return nil
+}
+
+// readAny décode une valeur générique, compatible avec encoding/json: les
+// maps ont des clés texte, pour que ExtractPayload puisse les relire
+func (s *msgpackSerializer) readAny(code byte) (interface{}, error) {
+	switch {
+	case code == 0xc0:
+		return nil, nil
+	case code == 0xc2, code == 0xc3:
+		return code == 0xc3, nil
+	case isInt(code):
+		return s.readInt(code)
+	case code == 0xca, code == 0xcb:
+		return s.readFloat(code)
+	case isString(code):
+		return s.readStringWith(code)
+	case isBin(code):
+		return s.readBin(code)
+	case isArray(code):
+		n, err := s.arrayLength(code)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]interface{}, n)
+		for i := range values {
+			next, err := s.reader.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			if values[i], err = s.readAny(next); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	case isMap(code):
+		n, err := s.mapLength(code)
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[string]interface{}, n)
+		for i := 0; i < n; i++ {
+			next, err := s.reader.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			key, err := s.readAny(next)
+			if err != nil {
+				return nil, err
+			}
+			next, err = s.reader.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			if values[fmt.Sprint(key)], err = s.readAny(next); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	case isExt(code):
+		return s.readTime(code)
+	}
+	return nil, fmt.Errorf("msgpack: invalid code 0x%02x", code)
+}
+
+// skip lit et ignore la valeur suivante
+func (s *msgpackSerializer) skip() error {
+	code, err := s.reader.ReadByte()
+	if err != nil {
+		return err
+	}
+	_, err = s.readAny(code)
+	return err
+}
+
+func (s *msgpackSerializer) readInt(code byte) (int64, error) {
+	switch {
+	case code <= 0x7f:
+		return int64(code), nil
+	case code >= 0xe0:
+		return int64(int8(code)), nil
+	}
+
+	switch code {
+	case 0xcc, 0xd0:
+		b, err := s.reader.ReadByte()
+		if code == 0xd0 {
+			return int64(int8(b)), err
+		}
+		return int64(b), err
+	case 0xcd, 0xd1:
+		n, err := s.readUint(2)
+		if code == 0xd1 {
+			return int64(int16(n)), err
+		}
+		return int64(n), err
+	case 0xce, 0xd2:
+		n, err := s.readUint(4)
+		if code == 0xd2 {
+			return int64(int32(n)), err
+		}
+		return int64(n), err
+	case 0xcf, 0xd3:
+		n, err := s.readUint(8)
+		if code == 0xcf && n > math.MaxInt64 {
+			return 0, fmt.Errorf("msgpack: %d overflows int64", n)
+		}
+		return int64(n), err
+	}
+	return 0, fmt.Errorf("msgpack: expected an integer, got 0x%02x", code)
+}
+
+func (s *msgpackSerializer) readFloat(code byte) (float64, error) {
+	switch code {
+	case 0xca:
+		n, err := s.readUint(4)
+		return float64(math.Float32frombits(uint32(n))), err
+	case 0xcb:
+		n, err := s.readUint(8)
+		return math.Float64frombits(n), err
+	}
+	if isInt(code) {
+		n, err := s.readInt(code)
+		return float64(n), err
+	}
+	return 0, fmt.Errorf("msgpack: expected a number, got 0x%02x", code)
+}
+
+func (s *msgpackSerializer) readString() (string, error) {
+	code, err := s.reader.ReadByte()
+	if err != nil {
+		return "", err
+	}
+	return s.readStringWith(code)
+}
+
+func (s *msgpackSerializer) readStringWith(code byte) (string, error) {
+	var n uint64
+	var err error
+	switch {
+	case code >= 0xa0 && code <= 0xbf:
+		n = uint64(code & 0x1f)
+	case code == 0xd9:
+		n, err = s.readUint(1)
+	case code == 0xda:
+		n, err = s.readUint(2)
+	case code == 0xdb:
+		n, err = s.readUint(4)
+	default:
+		return "", fmt.Errorf("msgpack: expected a string, got 0x%02x", code)
+	}
+	if err != nil {
+		return "", err
+	}
+	b, err := s.readN(n)
+	return string(b), err
+}
+
+func (s *msgpackSerializer) readBin(code byte) ([]byte, error) {
+	size := 1 << (code - 0xc4) // bin 8, 16 ou 32
+	n, err := s.readUint(size)
+	if err != nil {
+		return nil, err
+	}
+	return s.readN(n)
+}
+
+// readTime lit une date dans l'un des trois formats timestamp
+func (s *msgpackSerializer) readTime(code byte) (time.Time, error) {
+	var size uint64
+	var err error
+	switch code {
+	case 0xd6:
+		size = 4
+	case 0xd7:
+		size = 8
+	case 0xc7:
+		size, err = s.readUint(1)
+	default:
+		return time.Time{}, fmt.Errorf("msgpack: expected a timestamp, got 0x%02x", code)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	ext, err := s.reader.ReadByte()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if int8(ext) != extTimestamp {
+		return time.Time{}, fmt.Errorf("msgpack: unsupported extension %d", int8(ext))
+	}
+
+	data, err := s.readN(size)
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch size {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(data)), 0), nil
+	case 8:
+		n := binary.BigEndian.Uint64(data)
+		return time.Unix(int64(n&0x3ffffffff), int64(n>>34)), nil
+	case 12:
+		nsec := binary.BigEndian.Uint32(data)
+		return time.Unix(int64(binary.BigEndian.Uint64(data[4:])), int64(nsec)), nil
+	}
+	return time.Time{}, fmt.Errorf("msgpack: invalid timestamp length %d", size)
+}
+
+func (s *msgpackSerializer) arrayLength(code byte) (int, error) {
+	switch {
+	case code >= 0x90 && code <= 0x9f:
+		return int(code & 0x0f), nil
+	case code == 0xdc:
+		return s.readLength(2)
+	case code == 0xdd:
+		return s.readLength(4)
+	}
+	return 0, fmt.Errorf("msgpack: expected an array, got 0x%02x", code)
+}
+
+func (s *msgpackSerializer) mapLength(code byte) (int, error) {
+	switch {
+	case code >= 0x80 && code <= 0x8f:
+		return int(code & 0x0f), nil
+	case code == 0xde:
+		return s.readLength(2)
+	case code == 0xdf:
+		return s.readLength(4)
+	}
+	return 0, fmt.Errorf("msgpack: expected a map, got 0x%02x", code)
+}
+
+func (s *msgpackSerializer) readLength(size int) (int, error) {
+	n, err := s.readUint(size)
+	if err != nil {
+		return 0, err
+	}
+	if n > maxMsgPackLength {
+		return 0, fmt.Errorf("msgpack: length %d too large", n)
+	}
+	return int(n), nil
+}
+
+func (s *msgpackSerializer) readUint(size int) (uint64, error) {
+	b, err := s.readN(uint64(size))
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	return n, nil
+}
+
+func (s *msgpackSerializer) readN(n uint64) ([]byte, error) {
+	if n > maxMsgPackLength {
+		return nil, fmt.Errorf("msgpack: length %d too large", n)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(s.reader, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func mismatch(code byte, v reflect.Value) error {
+	return fmt.Errorf("msgpack: cannot decode 0x%02x into %s", code, v.Type())
+}
+
+func isInt(code byte) bool {
+	return code <= 0x7f || code >= 0xe0 || (code >= 0xcc && code <= 0xd3)
+}
+
+func isString(code byte) bool {
+	return (code >= 0xa0 && code <= 0xbf) || (code >= 0xd9 && code <= 0xdb)
+}
+
+func isBin(code byte) bool {
+	return code >= 0xc4 && code <= 0xc6
+}
+
+func isArray(code byte) bool {
+	return (code >= 0x90 && code <= 0x9f) || code == 0xdc || code == 0xdd
+}
+
+func isMap(code byte) bool {
+	return (code >= 0x80 && code <= 0x8f) || code == 0xde || code == 0xdf
+}
+
+func isExt(code byte) bool {
+	return code == 0xd6 || code == 0xd7 || code == 0xc7
+}
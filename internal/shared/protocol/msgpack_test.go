@@ -0,0 +1,144 @@
+// internal/shared/protocol/msgpack_test.go
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func sampleGame() *models.Game {
+	started := time.Unix(1760000000, 123456789)
+	red := models.NewPlayer(7, "red", constants.ColorRed)
+	bot := models.NewAIPlayer(constants.ColorYellow, "hard")
+	red.Tokens[0].Position = 12
+	return &models.Game{
+		Room: &models.Room{
+			ID:         "ABC234",
+			Name:       "Sunday game",
+			HostID:     7,
+			Players:    []*models.Player{red, bot},
+			MaxPlayers: 4,
+			State:      constants.StatePlaying,
+			CreatedAt:  started.Add(-time.Minute),
+			StartedAt:  &started,
+			Series:     map[int64]int{7: 2, -1: 1},
+			Roles:      map[int64]constants.RoomRole{7: constants.RoleHost},
+		},
+		Board: models.NewBoard(),
+		TurnHistory: []models.TurnAction{
+			{PlayerID: 7, DiceValue: 6, FromPos: -1, ToPos: 0, DurationMs: 1500, Timestamp: started},
+		},
+		StartTime: started,
+	}
+}
+
+// sameJSON compare deux valeurs par leur encodage JSON
+func sameJSON(t *testing.T, got, want interface{}) {
+	t.Helper()
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if !bytes.Equal(gotJSON, wantJSON) {
+		t.Errorf("Round trip mismatch:\n got %s\nwant %s", gotJSON, wantJSON)
+	}
+}
+
+func TestMsgPackRoundTripsGameState(t *testing.T) {
+	payload := models.GameStatePayload{Game: sampleGame(), AwaitingMove: true, TurnRemainingMs: 4200}
+	msg := &models.NetworkMessage{
+		Version:   Version,
+		Type:      constants.MsgGameState,
+		Payload:   payload,
+		Timestamp: time.Unix(1760000100, 0),
+	}
+
+	var wire bytes.Buffer
+	if err := newMsgPackSerializer(nil, &wire).Encode(msg); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	jsonSize, _ := json.Marshal(msg)
+	if wire.Len() >= len(jsonSize) {
+		t.Errorf("MessagePack is %d bytes, JSON %d: expected smaller", wire.Len(), len(jsonSize))
+	}
+
+	var got models.NetworkMessage
+	if err := newMsgPackSerializer(&wire, nil).Decode(&got); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	decoded, ok := got.Payload.(*models.GameStatePayload)
+	if !ok {
+		t.Fatalf("Expected a typed payload, got %T", got.Payload)
+	}
+	if got.Version != Version || got.Type != msg.Type || !got.Timestamp.Equal(msg.Timestamp) {
+		t.Errorf("Envelope mismatch: %+v", got)
+	}
+	sameJSON(t, decoded, payload)
+}
+
+func TestMsgPackNestedEventsDecodeLikeJSON(t *testing.T) {
+	batch := models.EventBatchPayload{Events: []*models.NetworkMessage{{
+		Type:    constants.MsgGameOver,
+		Payload: models.GameOverPayload{Reason: constants.GameOverWin, Series: map[int64]int{7: 3}},
+	}}}
+
+	var wire bytes.Buffer
+	if err := newMsgPackSerializer(nil, &wire).Encode(&models.NetworkMessage{Type: constants.MsgEventBatch, Payload: batch}); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	var got models.NetworkMessage
+	if err := newMsgPackSerializer(&wire, nil).Decode(&got); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	// Les événements regroupés sont génériques, puis typés comme en JSON
+	event := got.Payload.(*models.EventBatchPayload).Events[0]
+	if err := DecodePayload(event); err != nil {
+		t.Fatalf("DecodePayload: %v", err)
+	}
+	over, ok := event.Payload.(*models.GameOverPayload)
+	if !ok || over.Reason != constants.GameOverWin || over.Series[7] != 3 {
+		t.Errorf("Unexpected nested payload %#v", event.Payload)
+	}
+}
+
+func TestSwitchDecoderKeepsReadAheadBytes(t *testing.T) {
+	var wire bytes.Buffer
+	welcome := &models.NetworkMessage{Type: constants.MsgWelcome, Payload: models.WelcomePayload{Codec: CodecMsgPack}}
+	if err := NewSerializer(nil, &wire).Encode(welcome); err != nil {
+		t.Fatal(err)
+	}
+	binary := newMsgPackSerializer(nil, &wire)
+	for _, msgType := range []constants.MessageType{constants.MsgTurnChanged, constants.MsgPong} {
+		if err := binary.Encode(&models.NetworkMessage{Type: msgType}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	in := NewSerializer(&wire, nil)
+	var msg models.NetworkMessage
+	if err := in.Decode(&msg); err != nil || msg.Type != constants.MsgWelcome {
+		t.Fatalf("Expected the JSON welcome, got %s, %v", msg.Type, err)
+	}
+	in, err := SwitchDecoder(in, CodecMsgPack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []constants.MessageType{constants.MsgTurnChanged, constants.MsgPong} {
+		if err := in.Decode(&msg); err != nil || msg.Type != want {
+			t.Errorf("Expected %s, got %s, %v", want, msg.Type, err)
+		}
+	}
+}
+
+func TestNegotiateCodec(t *testing.T) {
+	if got := NegotiateCodec(nil); got != "" {
+		t.Errorf("No offer: got %q, want JSON", got)
+	}
+	if got := NegotiateCodec([]string{"protobuf", CodecMsgPack}); got != CodecMsgPack {
+		t.Errorf("Got %q, want %q", got, CodecMsgPack)
+	}
+}
@@ -0,0 +1,269 @@
+// internal/shared/protocol/payloads.go
+package protocol
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/assetpack"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// Version est la version courante du protocole. Elle change quand un
+// payload existant change de forme; un nouveau type de message n'en a pas
+// besoin.
+const Version = 1
+
+// payloadDecoder décrit le payload typé d'un type de message
+type payloadDecoder struct {
+	// new crée une valeur vide, pour les codecs qui décodent directement
+	new func() interface{}
+	// decode convertit un payload générique en sa valeur typée et la valide
+	decode func(raw interface{}) (interface{}, error)
+}
+
+// decoders associe à chaque type de message le décodeur de son payload.
+// Les types absents n'ont pas de payload, ou un payload libre.
+var decoders = make(map[constants.MessageType]payloadDecoder)
+
+// Register déclare le payload d'un type de message et, si validate n'est
+// pas nil, sa validation. À appeler à l'initialisation uniquement.
+func Register[T any](msgType constants.MessageType, validate func(*T) error) {
+	decode := func(raw interface{}) (interface{}, error) {
+		payload, ok := raw.(*T)
+		if !ok {
+			payload = new(T)
+			if err := ExtractPayload(raw, payload); err != nil {
+				return nil, err
+			}
+		}
+		if validate != nil {
+			if err := validate(payload); err != nil {
+				return nil, err
+			}
+		}
+		return payload, nil
+	}
+	decoders[msgType] = payloadDecoder{
+		new:    func() interface{} { return new(T) },
+		decode: decode,
+	}
+}
+
+// CheckVersion refuse un message d'une version du protocole plus récente
+// que celle-ci. Un message sans version vient d'un client antérieur au
+// versionnage et reste accepté.
+func CheckVersion(msg *models.NetworkMessage) error {
+	if msg.Version > Version {
+		return fmt.Errorf("unsupported protocol version %d (at most %d)", msg.Version, Version)
+	}
+	return nil
+}
+
+// DecodePayload remplace le payload générique d'un message reçu par un
+// pointeur vers sa valeur typée, après l'avoir validée. Les gestionnaires
+// la relisent ensuite avec ExtractPayload, sans nouveau décodage.
+func DecodePayload(msg *models.NetworkMessage) error {
+	entry, ok := decoders[msg.Type]
+	if !ok {
+		return nil
+	}
+	payload, err := entry.decode(msg.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid %s payload: %w", msg.Type, err)
+	}
+	msg.Payload = payload
+	return nil
+}
+
+// Stamp retourne le message marqué de la version du protocole. Le message
+// d'origine, parfois partagé entre plusieurs destinataires, n'est pas modifié.
+func Stamp(msg *models.NetworkMessage) *models.NetworkMessage {
+	if msg.Version == Version {
+		return msg
+	}
+	stamped := *msg
+	stamped.Version = Version
+	return &stamped
+}
+
+func init() {
+	// Messages du client
+	Register[models.HelloPayload](constants.MsgHello, nil)
+	Register(constants.MsgRegister, func(p *models.RegisterPayload) error {
+		return ValidateDevice(p.Device)
+	})
+	Register(constants.MsgLogin, func(p *models.LoginPayload) error {
+		return ValidateDevice(p.Device)
+	})
+	Register(constants.MsgCreateRoom, validateCreateRoom)
+	Register(constants.MsgJoinRoom, validateRoomRequest)
+	Register(constants.MsgSpectate, validateRoomRequest)
+	Register(constants.MsgMoveToken, func(p *models.MoveTokenPayload) error {
+		if p.TokenID < 0 || p.TokenID >= constants.TokensPerPlayer {
+			return fmt.Errorf("token %d does not exist", p.TokenID)
+		}
+		return nil
+	})
+	Register(constants.MsgSetHandicap, func(p *models.SetHandicapPayload) error {
+		return requirePlayer(p.PlayerID)
+	})
+	Register(constants.MsgKickPlayer, func(p *models.KickPlayerPayload) error {
+		return requirePlayer(p.PlayerID)
+	})
+	Register(constants.MsgAssignColor, func(p *models.AssignColorPayload) error {
+		return requirePlayer(p.PlayerID)
+	})
+	Register(constants.MsgSetRole, func(p *models.SetRolePayload) error {
+		return requirePlayer(p.PlayerID)
+	})
+	Register[models.SetPermissionsPayload](constants.MsgSetPermissions, nil)
+	Register(constants.MsgSetLobbyTheme, func(p *models.SetLobbyThemePayload) error {
+		return validateLobbyTheme(p.Theme)
+	})
+	Register(constants.MsgMutePlayer, func(p *models.MutePlayerPayload) error {
+		return requirePlayer(p.PlayerID)
+	})
+	Register(constants.MsgResume, func(p *models.SessionTokenPayload) error {
+		if p.Token == "" {
+			return fmt.Errorf("session token is empty")
+		}
+		return nil
+	})
+	Register(constants.MsgStopSpin, func(p *models.StopSpinPayload) error {
+		if p.ElapsedMs < 0 {
+			return fmt.Errorf("elapsed time is negative")
+		}
+		return nil
+	})
+	Register[models.CrashReportPayload](constants.MsgCrashReport, nil)
+	Register(constants.MsgGetProfile, func(p *models.ProfileRequestPayload) error {
+		if strings.TrimSpace(p.Username) != "" {
+			return nil
+		}
+		return requirePlayer(p.UserID)
+	})
+	Register[models.RivalriesRequestPayload](constants.MsgGetRivalries, nil)
+	Register(constants.MsgGetLeaderboard, func(p *models.LeaderboardRequestPayload) error {
+		if p.Offset < 0 || p.Limit < 0 {
+			return fmt.Errorf("negative page bounds")
+		}
+		return nil
+	})
+	Register(constants.MsgMatchReply, func(p *models.MatchReplyPayload) error {
+		if p.MatchID == "" {
+			return fmt.Errorf("match id is empty")
+		}
+		return nil
+	})
+	Register[models.SetPacePayload](constants.MsgSetPace, nil)
+	Register(constants.MsgGetRatingHistory, func(p *models.RatingHistoryRequestPayload) error {
+		return requirePlayer(p.UserID)
+	})
+	Register(constants.MsgFindMatch, func(p *models.FindMatchPayload) error {
+		switch p.Queue {
+		case "", constants.QueueRanked, constants.QueueCasual:
+			return nil
+		}
+		return fmt.Errorf("unknown queue %q", p.Queue)
+	})
+
+	// Messages dans les deux sens
+	Register[models.ChatPayload](constants.MsgChatMessage, nil)
+
+	// Messages du serveur
+	Register(constants.MsgRoomCreated, func(p *models.RoomCreatedPayload) error {
+		if p.RoomID == "" {
+			return fmt.Errorf("room id is empty")
+		}
+		return nil
+	})
+	Register[models.WelcomePayload](constants.MsgWelcome, nil)
+	Register[models.AuthenticatedPayload](constants.MsgAuthenticated, nil)
+	Register[models.AnnouncementPayload](constants.MsgAnnouncement, nil)
+	Register(constants.MsgPlayerJoined, func(p *models.PlayerJoinedPayload) error {
+		if p.Player == nil {
+			return fmt.Errorf("player is missing")
+		}
+		return nil
+	})
+	Register[models.GameStatePayload](constants.MsgGameStart, nil)
+	Register[models.GameStatePayload](constants.MsgGameState, nil)
+	Register[models.RollOffPayload](constants.MsgRollOff, nil)
+	Register[models.DiceRolledPayload](constants.MsgDiceRolled, nil)
+	Register[models.TokenMovedPayload](constants.MsgTokenMoved, nil)
+	Register[models.TokenCapturedPayload](constants.MsgTokenCaptured, nil)
+	Register[models.TurnChangedPayload](constants.MsgTurnChanged, nil)
+	Register[models.TurnTimerPayload](constants.MsgTurnTimer, nil)
+	Register[models.TurnTimedOutPayload](constants.MsgTurnTimedOut, nil)
+	Register[models.GameOverPayload](constants.MsgGameOver, nil)
+	Register[models.ErrorPayload](constants.MsgError, nil)
+	Register[models.EventBatchPayload](constants.MsgEventBatch, nil)
+	Register[models.SessionTokenPayload](constants.MsgSessionToken, nil)
+	Register[models.AbortVotesPayload](constants.MsgAbortVotes, nil)
+	Register[models.SpinStartedPayload](constants.MsgSpinStarted, nil)
+	Register[models.SpinResultPayload](constants.MsgSpinResult, nil)
+	Register[models.PlayerStats](constants.MsgProfile, nil)
+	Register[models.LeaderboardPayload](constants.MsgLeaderboard, nil)
+	Register[models.RivalriesPayload](constants.MsgRivalries, nil)
+	Register[models.RoomListPayload](constants.MsgRoomList, nil)
+	Register[models.KickedPayload](constants.MsgKicked, nil)
+	Register[models.TransferPayload](constants.MsgTransfer, nil)
+	Register[models.StateDeltaPayload](constants.MsgStateDelta, nil)
+	Register[models.RatingHistoryPayload](constants.MsgRatingHistory, nil)
+	Register[models.MatchFoundPayload](constants.MsgMatchFound, nil)
+	Register[models.MatchCancelledPayload](constants.MsgMatchCancelled, nil)
+	Register[models.DataExportReadyPayload](constants.MsgDataExportReady, nil)
+	Register[models.DataExportPayload](constants.MsgDataExport, nil)
+}
+
+// validateCreateRoom vérifie les réglages d'une nouvelle salle
+func validateCreateRoom(p *models.CreateRoomPayload) error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("room name cannot be empty")
+	}
+	if p.MaxPlayers < constants.MinPlayers || p.MaxPlayers > constants.MaxPlayers {
+		return fmt.Errorf("max players must be between %d and %d", constants.MinPlayers, constants.MaxPlayers)
+	}
+	return nil
+}
+
+// validateRoomRequest vérifie une demande pour rejoindre ou regarder une salle
+func validateRoomRequest(p *models.JoinRoomPayload) error {
+	if strings.TrimSpace(p.RoomID) == "" {
+		return fmt.Errorf("room ID cannot be empty")
+	}
+	return nil
+}
+
+// backgroundColor est le format des couleurs de fond des lobbys
+var backgroundColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// validateLobbyTheme vérifie la forme d'un thème de lobby. Le serveur
+// vérifie ensuite que le pack et le morceau existent.
+func validateLobbyTheme(theme *models.LobbyTheme) error {
+	if theme == nil {
+		return nil
+	}
+	if theme.Background != "" && !backgroundColor.MatchString(theme.Background) {
+		return fmt.Errorf("invalid background color %q", theme.Background)
+	}
+	if theme.Pack != "" && !assetpack.ValidName(theme.Pack) {
+		return fmt.Errorf("invalid theme pack %q", theme.Pack)
+	}
+	if theme.Track != "" && (theme.Pack == "" || !assetpack.ValidName(theme.Track)) {
+		return fmt.Errorf("invalid track %q", theme.Track)
+	}
+	return nil
+}
+
+// requirePlayer vérifie qu'un message désigne un joueur
+func requirePlayer(id int64) error {
+	if id == 0 {
+		return fmt.Errorf("player id is missing")
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// internal/shared/protocol/payloads_test.go
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// received simule un message lu sur le réseau: payload générique
+func received(t *testing.T, msgType constants.MessageType, payload interface{}) *models.NetworkMessage {
+	t.Helper()
+	data, err := json.Marshal(&models.NetworkMessage{Type: msgType, Payload: payload})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := DecodeMessage(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func TestDecodePayloadTypesRegisteredMessages(t *testing.T) {
+	msg := received(t, constants.MsgJoinRoom, map[string]interface{}{"room_id": "ABC234", "password": "secret"})
+	if err := DecodePayload(msg); err != nil {
+		t.Fatalf("DecodePayload: %v", err)
+	}
+	payload, ok := msg.Payload.(*models.JoinRoomPayload)
+	if !ok || payload.RoomID != "ABC234" || payload.Password != "secret" {
+		t.Fatalf("Expected a typed join payload, got %#v", msg.Payload)
+	}
+
+	// Les gestionnaires relisent la valeur sans la décoder de nouveau
+	var join models.JoinRoomPayload
+	if err := ExtractPayload(msg.Payload, &join); err != nil || join != *payload {
+		t.Errorf("ExtractPayload = %+v, %v; want %+v", join, err, *payload)
+	}
+}
+
+func TestDecodePayloadRejectsMalformedInput(t *testing.T) {
+	cases := []struct {
+		name    string
+		msgType constants.MessageType
+		payload interface{}
+	}{
+		{"wrong field type", constants.MsgCreateRoom, map[string]interface{}{"name": "Room", "max_players": "four"}},
+		{"too many players", constants.MsgCreateRoom, map[string]interface{}{"name": "Room", "max_players": 6}},
+		{"missing room", constants.MsgJoinRoom, map[string]interface{}{"password": "secret"}},
+		{"unknown token", constants.MsgMoveToken, map[string]interface{}{"token_id": constants.TokensPerPlayer}},
+		{"no player", constants.MsgKickPlayer, nil},
+		{"not an object", constants.MsgChatMessage, "hello"},
+		{"odd device id", constants.MsgLogin, map[string]interface{}{"token": "t", "device": "a b/c"}},
+		{"bad background", constants.MsgSetLobbyTheme, map[string]interface{}{"theme": map[string]string{"background": "red"}}},
+		{"track without pack", constants.MsgSetLobbyTheme, map[string]interface{}{"theme": map[string]string{"track": "disco"}}},
+	}
+	for _, c := range cases {
+		if err := DecodePayload(received(t, c.msgType, c.payload)); err == nil {
+			t.Errorf("%s: expected an error", c.name)
+		}
+	}
+}
+
+func TestDecodePayloadLeavesUnregisteredMessages(t *testing.T) {
+	msg := received(t, constants.MsgRollDice, nil)
+	if err := DecodePayload(msg); err != nil || msg.Payload != nil {
+		t.Errorf("Expected the message untouched, got %#v, %v", msg.Payload, err)
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	for _, version := range []int{0, Version} {
+		if err := CheckVersion(&models.NetworkMessage{Version: version}); err != nil {
+			t.Errorf("Version %d refused: %v", version, err)
+		}
+	}
+	if err := CheckVersion(&models.NetworkMessage{Version: Version + 1}); err == nil {
+		t.Error("Expected a newer protocol version to be refused")
+	}
+}
+
+func TestStampLeavesSharedMessageUntouched(t *testing.T) {
+	msg := &models.NetworkMessage{Type: constants.MsgTurnChanged}
+	if stamped := Stamp(msg); stamped.Version != Version || msg.Version != 0 {
+		t.Errorf("Stamp: got version %d, original changed to %d", stamped.Version, msg.Version)
+	}
+}
@@ -2,6 +2,7 @@
 package protocol
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,22 +10,74 @@ import (
 	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
 )
 
-// Serializer gère la sérialisation des messages
-type Serializer struct {
+// Codecs des messages. JSON reste le format par défaut: un autre codec
+// n'est utilisé que s'il a été négocié à la connexion (voir NegotiateCodec).
+const (
+	CodecJSON    = "json"
+	CodecMsgPack = "msgpack"
+)
+
+// Serializer lit et écrit les messages d'une connexion dans un codec donné
+type Serializer interface {
+	Encode(msg *models.NetworkMessage) error
+	Decode(msg *models.NetworkMessage) error
+}
+
+// jsonSerializer écrit un message JSON par ligne
+type jsonSerializer struct {
+	reader  io.Reader
 	encoder *json.Encoder
 	decoder *json.Decoder
 }
 
-// NewSerializer crée un nouveau sérialiseur
-func NewSerializer(reader io.Reader, writer io.Writer) *Serializer {
-	return &Serializer{
-		encoder: json.NewEncoder(writer),
-		decoder: json.NewDecoder(reader),
+// NewSerializer crée un sérialiseur JSON. reader ou writer peut être nil
+// pour un sérialiseur qui ne fait que lire ou écrire.
+func NewSerializer(reader io.Reader, writer io.Writer) Serializer {
+	s := &jsonSerializer{reader: reader}
+	if reader != nil {
+		s.decoder = json.NewDecoder(reader)
+	}
+	if writer != nil {
+		s.encoder = json.NewEncoder(writer)
+	}
+	return s
+}
+
+// NewCodecSerializer crée un sérialiseur pour le codec nommé
+func NewCodecSerializer(codec string, reader io.Reader, writer io.Writer) (Serializer, error) {
+	switch codec {
+	case "", CodecJSON:
+		return NewSerializer(reader, writer), nil
+	case CodecMsgPack:
+		return newMsgPackSerializer(reader, writer), nil
+	}
+	return nil, fmt.Errorf("unknown codec %q", codec)
+}
+
+// SwitchDecoder retourne un sérialiseur du codec donné qui reprend la
+// lecture là où current s'est arrêté, sans perdre ce qu'il a lu d'avance
+func SwitchDecoder(current Serializer, codec string) (Serializer, error) {
+	source, ok := current.(interface{ buffered() io.Reader })
+	if !ok {
+		return nil, fmt.Errorf("cannot switch from %T", current)
 	}
+	return NewCodecSerializer(codec, source.buffered(), nil)
+}
+
+// NegotiateCodec choisit, parmi les codecs proposés par le client dans
+// l'ordre de ses préférences, le premier que ce serveur connaît. Retourne
+// "" pour rester en JSON.
+func NegotiateCodec(offered []string) string {
+	for _, codec := range offered {
+		if codec == CodecMsgPack {
+			return codec
+		}
+	}
+	return ""
 }
 
 // Encode encode un message en JSON
-func (s *Serializer) Encode(msg *models.NetworkMessage) error {
+func (s *jsonSerializer) Encode(msg *models.NetworkMessage) error {
 	if err := s.encoder.Encode(msg); err != nil {
 		return fmt.Errorf("failed to encode message: %w", err)
 	}
@@ -32,13 +85,41 @@ func (s *Serializer) Encode(msg *models.NetworkMessage) error {
 }
 
 // Decode décode un message JSON
-func (s *Serializer) Decode(msg *models.NetworkMessage) error {
+func (s *jsonSerializer) Decode(msg *models.NetworkMessage) error {
 	if err := s.decoder.Decode(msg); err != nil {
 		return fmt.Errorf("failed to decode message: %w", err)
 	}
 	return nil
 }
 
+// buffered retourne la suite du flux, en commençant par les octets déjà
+// lus d'avance par le décodeur, sans le saut de ligne du dernier message
+func (s *jsonSerializer) buffered() io.Reader {
+	return &skipLeadingSpace{reader: io.MultiReader(s.decoder.Buffered(), s.reader)}
+}
+
+// skipLeadingSpace ignore les blancs du début d'un flux, qu'ils soient déjà
+// lus d'avance ou encore à venir
+type skipLeadingSpace struct {
+	reader io.Reader
+	done   bool
+}
+
+func (r *skipLeadingSpace) Read(p []byte) (int, error) {
+	for !r.done {
+		n, err := r.reader.Read(p)
+		rest := bytes.TrimLeft(p[:n], " \t\r\n")
+		if len(rest) > 0 {
+			r.done = true
+			return copy(p, rest), err
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return r.reader.Read(p)
+}
+
 // EncodeMessage encode directement un message
 func EncodeMessage(msg *models.NetworkMessage) ([]byte, error) {
 	data, err := json.Marshal(msg)
@@ -4,6 +4,7 @@ package protocol
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
@@ -43,6 +44,12 @@ func (v *Validator) ValidateMessage(msg *models.NetworkMessage) error {
 
 // ExtractPayload extrait et convertit le payload
 func ExtractPayload(payload interface{}, target interface{}) error {
+	// Payload déjà décodé par DecodePayload: simple copie
+	if src := reflect.ValueOf(payload); src.Kind() == reflect.Pointer && !src.IsNil() && src.Type() == reflect.TypeOf(target) {
+		reflect.ValueOf(target).Elem().Set(src.Elem())
+		return nil
+	}
+
 	// Convertir le payload en JSON
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -141,6 +148,12 @@ func (v *Validator) validateConnect(payload interface{}) error {
 	return nil
 }
 
+// Longueurs autorisées d'un nom d'utilisateur
+const (
+	UsernameMinLength = 3
+	UsernameMaxLength = 20
+)
+
 // ValidateUsername valide un nom d'utilisateur
 func ValidateUsername(username string) error {
 	username = strings.TrimSpace(username)
@@ -149,12 +162,12 @@ func ValidateUsername(username string) error {
 		return fmt.Errorf("username cannot be empty")
 	}
 
-	if len(username) < 3 {
-		return fmt.Errorf("username must be at least 3 characters")
+	if len(username) < UsernameMinLength {
+		return fmt.Errorf("username must be at least %d characters", UsernameMinLength)
 	}
 
-	if len(username) > 20 {
-		return fmt.Errorf("username must be at most 20 characters")
+	if len(username) > UsernameMaxLength {
+		return fmt.Errorf("username must be at most %d characters", UsernameMaxLength)
 	}
 
 	// Vérifier les caractères valides
@@ -175,6 +188,23 @@ func isValidUsernameChar(char rune) bool {
 		char == '_' || char == '-'
 }
 
+// DeviceMaxLength borne l'identifiant d'appareil envoyé à la connexion
+const DeviceMaxLength = 64
+
+// ValidateDevice valide un identifiant d'appareil. Vide, il est accepté:
+// les clients antérieurs n'en envoient pas.
+func ValidateDevice(device string) error {
+	if len(device) > DeviceMaxLength {
+		return fmt.Errorf("device id must be at most %d characters", DeviceMaxLength)
+	}
+	for _, char := range device {
+		if !isValidUsernameChar(char) {
+			return fmt.Errorf("device id contains invalid characters")
+		}
+	}
+	return nil
+}
+
 // ValidateRoomName valide un nom de salle
 func ValidateRoomName(name string) error {
 	name = strings.TrimSpace(name)
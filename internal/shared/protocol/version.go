@@ -0,0 +1,36 @@
+// internal/shared/protocol/version.go
+package protocol
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CompareVersions compare deux versions "majeur.mineur.correctif" et retourne
+// -1, 0 ou 1. Un préfixe "v" et un suffixe de préversion ("-beta") sont
+// ignorés; une composante absente ou illisible vaut 0.
+func CompareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := range pa {
+		switch {
+		case pa[i] < pb[i]:
+			return -1
+		case pa[i] > pb[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(v string) [3]int {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+
+	var parts [3]int
+	for i, field := range strings.SplitN(v, ".", 3) {
+		parts[i], _ = strconv.Atoi(field)
+	}
+	return parts
+}
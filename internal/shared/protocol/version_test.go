@@ -0,0 +1,24 @@
+// internal/shared/protocol/version_test.go
+package protocol
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0", "1.0.1", -1},
+		{"1.10.0", "1.9.3", 1},
+		{"v2.0", "2.0.0", 0},
+		{"1.2.0-beta", "1.2.0", 0},
+		{"0.9", "1.0.0", -1},
+		{"", "0.0.1", -1},
+	}
+	for _, c := range cases {
+		if got := CompareVersions(c.a, c.b); got != c.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,352 @@
+// internal/shared/relay/relay.go
+//
+// Le relais permet de jouer sur un serveur qui ne peut pas recevoir de
+// connexions entrantes (NAT strict, pas de redirection de port). Le serveur
+// s'enregistre sous un nom par une connexion de contrôle sortante; chaque
+// joueur qui demande ce nom provoque l'ouverture, par le serveur, d'une
+// nouvelle connexion sortante que le relais raccorde à celle du joueur.
+//
+// Chaque connexion commence par une ligne de poignée de main:
+//
+//	HOST <nom> <secret>   connexion de contrôle du serveur
+//	JOIN <nom>            connexion d'un joueur
+//	ACCEPT <jeton>        connexion ouverte par le serveur pour un joueur
+//
+// Le relais répond "OK" ou "ERR <raison>"; ensuite le trafic passe tel quel.
+// Seuls les serveurs qui connaissent le secret du relais peuvent s'y
+// enregistrer. Chaque joueur en attente reçoit un jeton aléatoire de 128
+// bits, annoncé au seul serveur par "CONNECT <jeton>" sur sa connexion de
+// contrôle: personne d'autre ne peut répondre à sa place.
+package relay
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Paramètres du relais
+const (
+	DefaultPort      = "8090"
+	handshakeTimeout = 10 * time.Second
+	pendingTimeout   = 10 * time.Second
+	keepAlive        = 30 * time.Second
+	maxNameLength    = 64
+	maxLineLength    = 256 // Nom et secret compris
+	tokenBytes       = 16  // Jetons des joueurs en attente: 128 bits
+)
+
+// Relay raccorde les joueurs aux serveurs enregistrés
+type Relay struct {
+	secret  string // Exigé des serveurs qui s'enregistrent
+	hosts   map[string]*host
+	pending map[string]chan net.Conn // Joueurs en attente, par jeton
+	mu      sync.Mutex
+}
+
+type host struct {
+	conn net.Conn
+	mu   sync.Mutex // Sérialise les écritures sur la connexion de contrôle
+}
+
+// NewRelay crée un relais vide. Les serveurs doivent présenter secret pour
+// s'y enregistrer.
+func NewRelay(secret string) *Relay {
+	return &Relay{
+		secret:  secret,
+		hosts:   make(map[string]*host),
+		pending: make(map[string]chan net.Conn),
+	}
+}
+
+// Serve accepte les connexions jusqu'à la fermeture du listener
+func (r *Relay) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go r.handle(conn)
+	}
+}
+
+// Hosts retourne le nombre de serveurs enregistrés
+func (r *Relay) Hosts() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.hosts)
+}
+
+func (r *Relay) handle(conn net.Conn) {
+	conn.SetReadDeadline(time.Now().Add(handshakeTimeout))
+	reader := bufio.NewReader(conn)
+	verb, arg, err := readHandshake(reader)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	switch verb {
+	case "HOST":
+		r.serveHost(conn, reader, arg)
+	case "JOIN":
+		r.serveJoin(conn, reader, arg)
+	case "ACCEPT":
+		r.serveAccept(conn, reader, arg)
+	default:
+		fmt.Fprintf(conn, "ERR unknown command\n")
+		conn.Close()
+	}
+}
+
+// serveHost garde la connexion de contrôle d'un serveur jusqu'à sa fermeture
+func (r *Relay) serveHost(conn net.Conn, reader *bufio.Reader, arg string) {
+	name, secret, _ := strings.Cut(arg, " ")
+	if r.secret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(r.secret)) != 1 {
+		log.Printf("📡 Relay: refused host %q from %s: bad secret", name, conn.RemoteAddr())
+		fmt.Fprintf(conn, "ERR bad secret\n")
+		conn.Close()
+		return
+	}
+
+	h := &host{conn: conn}
+
+	r.mu.Lock()
+	if _, taken := r.hosts[name]; taken {
+		r.mu.Unlock()
+		fmt.Fprintf(conn, "ERR name already registered\n")
+		conn.Close()
+		return
+	}
+	r.hosts[name] = h
+	r.mu.Unlock()
+
+	log.Printf("📡 Relay: host %q registered from %s", name, conn.RemoteAddr())
+	fmt.Fprintf(conn, "OK\n")
+
+	// Le serveur n'envoie rien d'autre: la lecture sert à détecter la coupure
+	io.Copy(io.Discard, reader)
+
+	r.mu.Lock()
+	delete(r.hosts, name)
+	r.mu.Unlock()
+	conn.Close()
+	log.Printf("📡 Relay: host %q left", name)
+}
+
+// serveJoin demande au serveur une connexion pour le joueur puis les raccorde
+func (r *Relay) serveJoin(conn net.Conn, reader *bufio.Reader, name string) {
+	token, err := newToken()
+	if err != nil {
+		fmt.Fprintf(conn, "ERR internal error\n")
+		conn.Close()
+		return
+	}
+
+	r.mu.Lock()
+	h := r.hosts[name]
+	ready := make(chan net.Conn, 1)
+	if h != nil {
+		r.pending[token] = ready
+	}
+	r.mu.Unlock()
+
+	if h == nil {
+		fmt.Fprintf(conn, "ERR unknown host\n")
+		conn.Close()
+		return
+	}
+
+	h.mu.Lock()
+	_, err = fmt.Fprintf(h.conn, "CONNECT %s\n", token)
+	h.mu.Unlock()
+
+	var upstream net.Conn
+	if err == nil {
+		select {
+		case upstream = <-ready:
+		case <-time.After(pendingTimeout):
+		}
+	}
+
+	r.mu.Lock()
+	delete(r.pending, token)
+	r.mu.Unlock()
+
+	// Connexion du serveur arrivée juste après l'expiration
+	if upstream == nil {
+		select {
+		case late := <-ready:
+			late.Close()
+		default:
+		}
+	}
+
+	if upstream == nil {
+		fmt.Fprintf(conn, "ERR host did not answer\n")
+		conn.Close()
+		return
+	}
+
+	fmt.Fprintf(conn, "OK\n")
+	splice(conn, reader, upstream)
+}
+
+// serveAccept remet la connexion du serveur au joueur qui l'attend
+func (r *Relay) serveAccept(conn net.Conn, reader *bufio.Reader, token string) {
+	r.mu.Lock()
+	ready := r.pending[token]
+	delete(r.pending, token)
+	r.mu.Unlock()
+
+	if ready == nil {
+		fmt.Fprintf(conn, "ERR unknown connection\n")
+		conn.Close()
+		return
+	}
+
+	fmt.Fprintf(conn, "OK\n")
+	ready <- &bufferedConn{Conn: conn, reader: reader}
+}
+
+// newToken tire le jeton d'un joueur en attente
+func newToken() (string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// splice copie le trafic dans les deux sens jusqu'à la fermeture d'un côté
+func splice(client net.Conn, clientReader *bufio.Reader, upstream net.Conn) {
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(upstream, clientReader)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(client, upstream)
+		done <- struct{}{}
+	}()
+	<-done
+	client.Close()
+	upstream.Close()
+	<-done
+}
+
+// Register enregistre un serveur auprès du relais avec son secret et appelle
+// serve pour chaque joueur raccordé. Bloque jusqu'à la perte de la connexion
+// de contrôle.
+func Register(relayAddr, name, secret string, serve func(net.Conn)) error {
+	if strings.ContainsAny(name, " \n") || strings.ContainsAny(secret, " \n") {
+		return fmt.Errorf("relay name and secret must not contain spaces")
+	}
+	control, reader, err := handshake(directDial, relayAddr, "HOST", name+" "+secret)
+	if err != nil {
+		return err
+	}
+	defer control.Close()
+
+	for {
+		verb, arg, err := readHandshake(reader)
+		if err != nil {
+			return err
+		}
+		if verb != "CONNECT" {
+			continue
+		}
+
+		go func(token string) {
+			conn, reader, err := handshake(directDial, relayAddr, "ACCEPT", token)
+			if err != nil {
+				log.Printf("Relay: failed to accept player: %v", err)
+				return
+			}
+			serve(&bufferedConn{Conn: conn, reader: reader})
+		}(arg)
+	}
+}
+
+// Dial ouvre une connexion vers le serveur enregistré sous name
+func Dial(relayAddr, name string) (net.Conn, error) {
+	return DialVia(directDial, relayAddr, name)
+}
+
+// DialVia est Dial avec une autre façon de joindre le relais (proxy)
+func DialVia(dial func(addr string) (net.Conn, error), relayAddr, name string) (net.Conn, error) {
+	conn, reader, err := handshake(dial, relayAddr, "JOIN", name)
+	if err != nil {
+		return nil, err
+	}
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
+
+// directDial joint le relais sans intermédiaire
+func directDial(addr string) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: handshakeTimeout, KeepAlive: keepAlive}
+	return dialer.Dial("tcp", addr)
+}
+
+// handshake se connecte au relais et attend son accord
+func handshake(dial func(string) (net.Conn, error), relayAddr, verb, arg string) (net.Conn, *bufio.Reader, error) {
+	conn, err := dial(relayAddr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := fmt.Fprintf(conn, "%s %s\n", verb, arg); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	conn.SetReadDeadline(time.Now().Add(handshakeTimeout + pendingTimeout))
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	line = strings.TrimSpace(line)
+	if line != "OK" {
+		conn.Close()
+		return nil, nil, fmt.Errorf("relay refused: %s", strings.TrimPrefix(line, "ERR "))
+	}
+	return conn, reader, nil
+}
+
+// readHandshake lit une ligne "VERBE argument". Le premier mot de
+// l'argument (nom ou jeton) est limité à maxNameLength.
+func readHandshake(reader *bufio.Reader) (string, string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", "", err
+	}
+	verb, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+	first, _, _ := strings.Cut(arg, " ")
+	if arg == "" || len(arg) > maxLineLength || len(first) > maxNameLength {
+		return "", "", fmt.Errorf("invalid handshake")
+	}
+	return verb, arg, nil
+}
+
+// bufferedConn conserve les octets déjà lus par la poignée de main
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
@@ -0,0 +1,149 @@
+// internal/shared/relay/relay_test.go
+package relay
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "s3cret"
+
+// startRelay démarre un relais local et retourne son adresse
+func startRelay(t *testing.T) (*Relay, string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	r := NewRelay(testSecret)
+	go r.Serve(listener)
+	return r, listener.Addr().String()
+}
+
+// waitForHost attend qu'un serveur soit enregistré
+func waitForHost(t *testing.T, r *Relay) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for r.Hosts() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if r.Hosts() == 0 {
+		t.Fatal("host never registered")
+	}
+}
+
+func TestRelayForwardsTraffic(t *testing.T) {
+	r, addr := startRelay(t)
+
+	// Serveur d'écho enregistré derrière le relais
+	go Register(addr, "home", testSecret, func(conn net.Conn) {
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			fmt.Fprintf(conn, "echo %s\n", scanner.Text())
+		}
+	})
+	waitForHost(t, r)
+
+	conn, err := Dial(addr, "home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	fmt.Fprintf(conn, "hello\n")
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil || line != "echo hello\n" {
+		t.Fatalf("got %q, %v", line, err)
+	}
+
+	if _, err := Dial(addr, "unknown"); err == nil {
+		t.Fatal("expected an error for an unknown host")
+	}
+}
+
+func TestRelayRefusesHostWithoutSecret(t *testing.T) {
+	r, addr := startRelay(t)
+
+	for _, secret := range []string{"wrong", "s3cre"} {
+		if err := Register(addr, "home", secret, func(net.Conn) {}); err == nil || !strings.Contains(err.Error(), "bad secret") {
+			t.Fatalf("secret %q: got %v, want a refusal", secret, err)
+		}
+	}
+	if r.Hosts() != 0 {
+		t.Fatal("a host registered without the secret")
+	}
+
+	// Sans secret configuré, le relais n'accepte aucun serveur
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	open := NewRelay("")
+	go open.Serve(listener)
+	if err := Register(listener.Addr().String(), "home", "", func(net.Conn) {}); err == nil {
+		t.Fatal("a relay without a secret accepted a host")
+	}
+}
+
+// Une connexion qui n'a pas reçu le jeton du joueur ne peut pas répondre à
+// la place du serveur
+func TestRelayRefusesGuessedAccept(t *testing.T) {
+	r, addr := startRelay(t)
+
+	// Connexion de contrôle tenue à la main pour lire le jeton annoncé
+	control, reader, err := handshake(directDial, addr, "HOST", "home "+testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer control.Close()
+	waitForHost(t, r)
+
+	joined := make(chan error, 1)
+	go func() {
+		conn, err := Dial(addr, "home")
+		if err == nil {
+			conn.Close()
+		}
+		joined <- err
+	}()
+
+	control.SetReadDeadline(time.Now().Add(2 * time.Second))
+	verb, token, err := readHandshake(reader)
+	if err != nil || verb != "CONNECT" {
+		t.Fatalf("got %q %q, %v", verb, token, err)
+	}
+	if len(token) != 2*tokenBytes {
+		t.Fatalf("token %q is not %d bits", token, 8*tokenBytes)
+	}
+
+	// Les anciens identifiants séquentiels ne sont plus acceptés
+	for _, guess := range []string{"1", "2", strings.Repeat("0", 2*tokenBytes)} {
+		if _, _, err := handshake(directDial, addr, "ACCEPT", guess); err == nil {
+			t.Fatalf("relay accepted guessed token %q", guess)
+		}
+	}
+
+	// Le vrai serveur reste le seul à pouvoir répondre
+	upstream, _, err := handshake(directDial, addr, "ACCEPT", token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer upstream.Close()
+	if err := <-joined; err != nil {
+		t.Fatalf("player join failed: %v", err)
+	}
+
+	// Un jeton ne sert qu'une fois
+	if _, _, err := handshake(directDial, addr, "ACCEPT", token); err == nil {
+		t.Fatal("relay accepted a token twice")
+	}
+}
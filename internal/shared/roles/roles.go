@@ -0,0 +1,200 @@
+// internal/shared/roles/roles.go
+
+// Package roles regroupe le modèle de rôles d'une salle (hôte, modérateur,
+// membre, spectateur) et les permissions qui en découlent. Le serveur s'en
+// sert pour valider chaque action, le client pour n'afficher que les
+// contrôles autorisés.
+package roles
+
+import (
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// All liste les permissions, dans l'ordre d'affichage
+var All = []constants.Permission{
+	constants.PermKick,
+	constants.PermChangeRules,
+	constants.PermModerateChat,
+	constants.PermStartGame,
+	constants.PermPause,
+	constants.PermManageRoles,
+}
+
+// Configurable liste les rôles dont l'hôte peut régler les permissions
+var Configurable = []constants.RoomRole{constants.RoleModerator, constants.RoleMember}
+
+// Defaults donne les permissions d'un rôle tant que l'hôte ne les a pas
+// changées. L'hôte a toujours toutes les permissions, le spectateur aucune.
+var Defaults = map[constants.RoomRole][]constants.Permission{
+	constants.RoleModerator: {constants.PermKick, constants.PermModerateChat, constants.PermStartGame},
+	constants.RoleMember:    {},
+}
+
+// rank classe les rôles: on n'agit que sur un joueur de rang inférieur
+var rank = map[constants.RoomRole]int{
+	constants.RoleHost:      3,
+	constants.RoleModerator: 2,
+	constants.RoleMember:    1,
+	constants.RoleSpectator: 0,
+}
+
+// RoleOf retourne le rôle d'un joueur dans la salle. Un utilisateur qui
+// n'est pas assis à la table est spectateur.
+func RoleOf(room *models.Room, playerID int64) constants.RoomRole {
+	if room.HostID == playerID {
+		return constants.RoleHost
+	}
+	if !seated(room, playerID) {
+		return constants.RoleSpectator
+	}
+	if role, ok := room.Roles[playerID]; ok {
+		return role
+	}
+	return constants.RoleMember
+}
+
+// Permissions retourne les permissions effectives d'un rôle dans la salle
+func Permissions(room *models.Room, role constants.RoomRole) []constants.Permission {
+	switch role {
+	case constants.RoleHost:
+		return All
+	case constants.RoleSpectator:
+		return nil
+	}
+	if perms, ok := room.Permissions[role]; ok {
+		return perms
+	}
+	return Defaults[role]
+}
+
+// Can indique si un joueur a la permission donnée dans la salle
+func Can(room *models.Room, playerID int64, perm constants.Permission) bool {
+	for _, p := range Permissions(room, RoleOf(room, playerID)) {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// Check retourne ErrPermissionDenied si le joueur n'a pas la permission
+func Check(room *models.Room, playerID int64, perm constants.Permission) error {
+	if !Can(room, playerID, perm) {
+		return i18n.NewError(constants.ErrPermissionDenied, i18n.Params{"permission": string(perm)})
+	}
+	return nil
+}
+
+// Outranks indique si l'acteur a un rang strictement supérieur à la cible:
+// un modérateur ne peut ni exclure l'hôte ni un autre modérateur
+func Outranks(room *models.Room, actorID, targetID int64) bool {
+	return rank[RoleOf(room, actorID)] > rank[RoleOf(room, targetID)]
+}
+
+// Assign change le rôle d'un joueur assis. Seuls modérateur et membre
+// s'attribuent: l'hôte ne se transmet pas par ce biais.
+func Assign(room *models.Room, playerID int64, role constants.RoomRole) error {
+	if !configurable(role) {
+		return i18n.NewError(constants.ErrUnknownRole, nil)
+	}
+	if playerID == room.HostID || !seated(room, playerID) {
+		return i18n.NewError(constants.ErrPlayerNotInRoom, nil)
+	}
+
+	assigned := cloneMap(room.Roles)
+	if role == constants.RoleMember {
+		delete(assigned, playerID)
+	} else {
+		assigned[playerID] = role
+	}
+	room.Roles = assigned
+	return nil
+}
+
+// SetPermissions remplace les permissions d'un rôle configurable. La
+// gestion des rôles reste réservée à l'hôte.
+func SetPermissions(room *models.Room, role constants.RoomRole, perms []constants.Permission) error {
+	if !configurable(role) {
+		return i18n.NewError(constants.ErrUnknownRole, nil)
+	}
+
+	granted := make([]constants.Permission, 0, len(perms))
+	seen := make(map[constants.Permission]bool)
+	for _, perm := range perms {
+		if !known(perm) || perm == constants.PermManageRoles {
+			return i18n.NewError(constants.ErrUnknownPermission, nil)
+		}
+		if !seen[perm] {
+			seen[perm] = true
+			granted = append(granted, perm)
+		}
+	}
+
+	permissions := cloneMap(room.Permissions)
+	permissions[role] = granted
+	room.Permissions = permissions
+	return nil
+}
+
+// SetMuted retire ou rend la parole à un joueur dans le chat de la salle
+func SetMuted(room *models.Room, playerID int64, muted bool) {
+	silenced := cloneMap(room.Muted)
+	if muted {
+		silenced[playerID] = true
+	} else {
+		delete(silenced, playerID)
+	}
+	room.Muted = silenced
+}
+
+// Forget efface le rôle et la mise en sourdine d'un joueur qui quitte la
+// salle, pour qu'il ne les retrouve pas en revenant
+func Forget(room *models.Room, playerID int64) {
+	roles := cloneMap(room.Roles)
+	delete(roles, playerID)
+	room.Roles = roles
+
+	silenced := cloneMap(room.Muted)
+	delete(silenced, playerID)
+	room.Muted = silenced
+}
+
+// cloneMap copie une table de la salle avant de la modifier. Les tables
+// déjà publiées ne changent jamais: un état en cours d'envoi peut encore
+// les parcourir.
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	clone := make(map[K]V, len(m)+1)
+	for k, v := range m {
+		clone[k] = v
+	}
+	return clone
+}
+
+func seated(room *models.Room, playerID int64) bool {
+	for _, player := range room.Players {
+		if player.ID == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+func configurable(role constants.RoomRole) bool {
+	for _, r := range Configurable {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func known(perm constants.Permission) bool {
+	for _, p := range All {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
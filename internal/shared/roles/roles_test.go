@@ -0,0 +1,125 @@
+// internal/shared/roles/roles_test.go
+package roles
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/i18n"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func newRoom() *models.Room {
+	return &models.Room{
+		HostID:  1,
+		Players: []*models.Player{{ID: 1}, {ID: 2}, {ID: 3}},
+	}
+}
+
+func errorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	code, _ := i18n.CodeOf(err, "")
+	return code
+}
+
+func TestRoleOf(t *testing.T) {
+	room := newRoom()
+	room.Roles = map[int64]constants.RoomRole{2: constants.RoleModerator}
+
+	tests := []struct {
+		playerID int64
+		want     constants.RoomRole
+	}{
+		{1, constants.RoleHost},
+		{2, constants.RoleModerator},
+		{3, constants.RoleMember},
+		{4, constants.RoleSpectator},
+	}
+	for _, tt := range tests {
+		if got := RoleOf(room, tt.playerID); got != tt.want {
+			t.Errorf("RoleOf(%d) = %s, want %s", tt.playerID, got, tt.want)
+		}
+	}
+}
+
+func TestCanUsesDefaultsThenOverrides(t *testing.T) {
+	room := newRoom()
+	if err := Assign(room, 2, constants.RoleModerator); err != nil {
+		t.Fatalf("Assign: %v", err)
+	}
+
+	if !Can(room, 2, constants.PermKick) {
+		t.Error("moderator should kick by default")
+	}
+	if Can(room, 2, constants.PermChangeRules) {
+		t.Error("moderator should not change rules by default")
+	}
+	if Can(room, 3, constants.PermStartGame) {
+		t.Error("member should not start the game by default")
+	}
+	if !Can(room, 1, constants.PermManageRoles) {
+		t.Error("host should manage roles")
+	}
+
+	if err := SetPermissions(room, constants.RoleMember, []constants.Permission{constants.PermStartGame}); err != nil {
+		t.Fatalf("SetPermissions: %v", err)
+	}
+	if !Can(room, 3, constants.PermStartGame) {
+		t.Error("member should start the game once granted")
+	}
+	if got := errorCode(Check(room, 4, constants.PermStartGame)); got != constants.ErrPermissionDenied {
+		t.Errorf("spectator Check = %q, want %q", got, constants.ErrPermissionDenied)
+	}
+}
+
+func TestAssignAndSetPermissionsValidation(t *testing.T) {
+	room := newRoom()
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"host role", Assign(room, 2, constants.RoleHost), constants.ErrUnknownRole},
+		{"host target", Assign(room, 1, constants.RoleModerator), constants.ErrPlayerNotInRoom},
+		{"spectator target", Assign(room, 4, constants.RoleModerator), constants.ErrPlayerNotInRoom},
+		{"manage roles", SetPermissions(room, constants.RoleModerator, []constants.Permission{constants.PermManageRoles}), constants.ErrUnknownPermission},
+		{"unknown permission", SetPermissions(room, constants.RoleMember, []constants.Permission{"fly"}), constants.ErrUnknownPermission},
+		{"spectator permissions", SetPermissions(room, constants.RoleSpectator, nil), constants.ErrUnknownRole},
+	}
+	for _, tt := range tests {
+		if got := errorCode(tt.err); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOutranks(t *testing.T) {
+	room := newRoom()
+	room.Players = append(room.Players, &models.Player{ID: 5})
+	Assign(room, 2, constants.RoleModerator)
+	Assign(room, 5, constants.RoleModerator)
+
+	if !Outranks(room, 2, 3) {
+		t.Error("moderator should outrank member")
+	}
+	if Outranks(room, 2, 5) || Outranks(room, 2, 1) {
+		t.Error("moderator should not outrank another moderator or the host")
+	}
+	if !Outranks(room, 1, 2) {
+		t.Error("host should outrank moderator")
+	}
+}
+
+func TestForget(t *testing.T) {
+	room := newRoom()
+	Assign(room, 2, constants.RoleModerator)
+	SetMuted(room, 2, true)
+
+	Forget(room, 2)
+	if RoleOf(room, 2) != constants.RoleMember || room.Muted[2] {
+		t.Error("Forget should clear the role and the mute")
+	}
+}
@@ -7,12 +7,15 @@ import (
 
 	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
 	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/moves"
 )
 
 // AIPlayer représente un joueur IA
 type AIPlayer struct {
 	Level      string // easy, medium, hard
 	ThinkDelay time.Duration
+	Profile    *Profile          // Habitudes de l'adversaire, nil si inconnues
+	House      models.RuleConfig // Règles maison de la partie
 	rand       *rand.Rand
 }
 
@@ -72,7 +75,7 @@ func (ai *AIPlayer) selectTokenMedium(player *models.Player, diceValue int, boar
 
 	// 1. Priorité: Token qui peut capturer
 	for _, token := range validTokens {
-		newPos := ai.calculateNewPosition(token, diceValue, player.Color)
+		newPos, _ := ai.legal(player, token, diceValue, board)
 		if ai.canCapture(newPos, player.Color, board) {
 			return token
 		}
@@ -133,44 +136,54 @@ func (ai *AIPlayer) selectTokenHard(player *models.Player, diceValue int, board
 // evaluateMove évalue la qualité d'un déplacement
 func (ai *AIPlayer) evaluateMove(token *models.Token, diceValue int, player *models.Player, board *models.Board) int {
 	score := 0
-	newPos := ai.calculateNewPosition(token, diceValue, player.Color)
+	newPos, _ := ai.legal(player, token, diceValue, board)
+	w := ai.Profile.Weights()
 
-	// 1. Capture d'un adversaire (+1000 points)
+	// 1. Capture d'un adversaire
 	if ai.canCapture(newPos, player.Color, board) {
-		score += 1000
+		score += w.Capture
 	}
 
-	// 2. Sortir de la base (+500 points)
+	// 2. Sortir de la base
 	if token.Position == -1 && diceValue == constants.RollToStart {
-		score += 500
+		score += w.LeaveBase
 	}
 
-	// 3. Entrer dans la zone maison (+800 points)
-	if newPos >= 52 {
-		score += 800
+	// 3. Entrer dans la zone maison
+	if newPos >= moves.StretchStart {
+		score += w.EnterHome
 	}
 
-	// 4. Atteindre une zone sécurisée (+300 points)
-	if ai.isSafePosition(newPos) {
-		score += 300
+	// 4. Atteindre une zone sécurisée
+	if moves.IsSafe(newPos) {
+		score += w.Safe
 	}
 
-	// 5. Avancer le token le plus proche de la victoire (+100 points par case)
-	score += newPos * 10
+	// 5. Avancer le token le plus proche de la victoire (par case)
+	score += moves.Progress(player.Color, newPos) * w.Advance
 
-	// 6. Éviter de laisser un token isolé (-200 points)
+	// 6. Éviter de laisser un token isolé
 	if ai.isTokenIsolated(token, player.Tokens, board) {
-		score -= 200
+		score -= w.Isolated
 	}
 
-	// 7. Danger d'être capturé après le déplacement (-400 points)
-	if ai.isPositionDangerous(newPos, player.Color, board) {
-		score -= 400
+	// 7. Danger d'être capturé après le déplacement, sauf dans un barrage
+	forms := ai.formsBlockade(token, newPos, player.Color, board)
+	if !forms && ai.isPositionDangerous(newPos, player.Color, board) {
+		score -= w.Danger
 	}
 
-	// 8. Bloquer un adversaire proche de la victoire (+600 points)
+	// 8. Former un barrage plutôt que d'en défaire un
+	if forms {
+		score += w.Blockade
+	}
+	if ai.breaksBlockade(token, newPos, player.Color, board) {
+		score -= w.Blockade
+	}
+
+	// 9. Bloquer un adversaire proche de la victoire
 	if ai.blocksOpponent(newPos, board) {
-		score += 600
+		score += w.Block
 	}
 
 	return score
@@ -181,7 +194,7 @@ func (ai *AIPlayer) getValidTokens(player *models.Player, diceValue int, board *
 	valid := make([]*models.Token, 0, constants.TokensPerPlayer)
 
 	for _, token := range player.Tokens {
-		if ai.canMoveToken(token, diceValue, player.Color, board) {
+		if _, ok := ai.legal(player, token, diceValue, board); ok {
 			valid = append(valid, token)
 		}
 	}
@@ -189,101 +202,60 @@ func (ai *AIPlayer) getValidTokens(player *models.Player, diceValue int, board *
 	return valid
 }
 
-// canMoveToken vérifie si un token peut se déplacer
-func (ai *AIPlayer) canMoveToken(token *models.Token, diceValue int, color constants.PlayerColor, board *models.Board) bool {
-	// Token déjà à la maison
-	if token.IsHome {
-		return false
-	}
-
-	// Token en base: doit obtenir un 6
-	if token.Position == -1 {
-		return diceValue == constants.RollToStart
-	}
-
-	// Vérifier que la nouvelle position est valide
-	newPos := ai.calculateNewPosition(token, diceValue, color)
-
-	// Dépassement de la maison
-	if newPos > 57 {
-		return false
-	}
-
-	// Vérifier qu'il n'y a pas déjà un token de la même couleur
-	if newPos >= 52 {
-		// Zone maison
-		homeIndex := newPos - 52
-		if board.HomeStretches[color][homeIndex].Token != nil &&
-			board.HomeStretches[color][homeIndex].Token.Color == color {
-			return false
-		}
-	} else {
-		// Plateau normal
-		if board.Cells[newPos].Token != nil &&
-			board.Cells[newPos].Token.Color == color {
-			return false
+// legal calcule la case d'arrivée d'un pion sous les règles maison. Les
+// barrages adverses sont lus dans les piles de pions du plateau.
+func (ai *AIPlayer) legal(player *models.Player, token *models.Token, diceValue int, board *models.Board) (int, bool) {
+	to, ok := moves.LegalWith(ai.House, nil, player, token, diceValue)
+	if !ok || !ai.House.Blockades {
+		return to, ok
+	}
+
+	steps := []int{to}
+	if token.Position != moves.Base {
+		steps = steps[:0]
+		for step := 1; step <= diceValue; step++ {
+			pos, ok := moves.Target(player.Color, token.Position, step, token.Laps)
+			if !ok || pos >= moves.StretchStart {
+				break
+			}
+			steps = append(steps, pos)
 		}
 	}
-
-	return true
-}
-
-// calculateNewPosition calcule la nouvelle position d'un token
-func (ai *AIPlayer) calculateNewPosition(token *models.Token, diceValue int, color constants.PlayerColor) int {
-	if token.Position == -1 {
-		// Sortie de la base
-		return constants.StartingPositions[color]
-	}
-
-	newPos := token.Position + diceValue
-
-	// Vérifier si on entre dans la zone maison
-	homeEntry := constants.HomeStretchStart[color]
-	if token.Position < homeEntry && newPos >= homeEntry {
-		// Entrer dans la maison
-		overflow := newPos - homeEntry
-		return 52 + overflow
-	}
-
-	// Gérer le tour du plateau
-	if newPos >= 52 && token.Position < 52 {
-		newPos = newPos % 52
+	for _, pos := range steps {
+		if owner, ok := board.Cells[pos].Blockade(); ok && owner != player.Color {
+			return to, false
+		}
 	}
-
-	return newPos
+	return to, true
 }
 
 // canCapture vérifie si on peut capturer à cette position
 func (ai *AIPlayer) canCapture(pos int, color constants.PlayerColor, board *models.Board) bool {
-	if pos < 0 || pos >= 52 {
+	// Pas de capture sur les zones sécurisées, la base ou le couloir
+	if moves.IsSafe(pos) {
 		return false
 	}
 
-	cell := board.Cells[pos]
-	if cell.Token == nil {
-		return false
-	}
+	return board.Cells[pos].Opponent(color) != nil
+}
 
-	// Pas de capture sur les zones sécurisées
-	if cell.IsSafe {
+// formsBlockade indique que le pion rejoint un autre pion de sa couleur sur
+// le parcours, formant un barrage
+func (ai *AIPlayer) formsBlockade(token *models.Token, pos int, color constants.PlayerColor, board *models.Board) bool {
+	if !ai.House.Blockades || pos < 0 || pos >= moves.StretchStart || pos == token.Position {
 		return false
 	}
-
-	return cell.Token.Color != color
+	cell := board.Cells[pos]
+	return len(cell.Tokens) == 1 && cell.Tokens[0].Color == color
 }
 
-// isSafePosition vérifie si la position est sécurisée
-func (ai *AIPlayer) isSafePosition(pos int) bool {
-	if pos < 0 || pos >= 52 {
-		return true // Base et maison sont sécurisées
-	}
-
-	for _, safe := range constants.SafePositions {
-		if pos == safe {
-			return true
-		}
+// breaksBlockade indique que le pion quitte un barrage de sa couleur
+func (ai *AIPlayer) breaksBlockade(token *models.Token, pos int, color constants.PlayerColor, board *models.Board) bool {
+	if !ai.House.Blockades || token.Position < 0 || token.Position >= moves.StretchStart || pos == token.Position {
+		return false
 	}
-	return false
+	owner, ok := board.Cells[token.Position].Blockade()
+	return ok && owner == color
 }
 
 // isTokenIsolated vérifie si le token est isolé
@@ -309,15 +281,14 @@ func (ai *AIPlayer) isTokenIsolated(token *models.Token, allTokens []*models.Tok
 
 // isPositionDangerous vérifie si la position est dangereuse
 func (ai *AIPlayer) isPositionDangerous(pos int, color constants.PlayerColor, board *models.Board) bool {
-	if ai.isSafePosition(pos) {
+	if moves.IsSafe(pos) {
 		return false
 	}
 
 	// Vérifier s'il y a des adversaires dans un rayon de 6 cases derrière
 	for i := 1; i <= 6; i++ {
 		checkPos := (pos - i + 52) % 52
-		cell := board.Cells[checkPos]
-		if cell.Token != nil && cell.Token.Color != color {
+		if board.Cells[checkPos].Opponent(color) != nil {
 			return true
 		}
 	}
@@ -334,9 +305,10 @@ func (ai *AIPlayer) blocksOpponent(pos int, board *models.Board) bool {
 	// Vérifier s'il y a un adversaire proche de la victoire
 	for i := 1; i <= 6; i++ {
 		checkPos := (pos + i) % 52
-		cell := board.Cells[checkPos]
-		if cell.Token != nil && cell.Token.Position > 45 {
-			return true
+		for _, token := range board.Cells[checkPos].Tokens {
+			if token.Position > 45 {
+				return true
+			}
 		}
 	}
 
@@ -0,0 +1,56 @@
+// pkg/ai/ai_test.go
+package ai
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// stackBoard empile les pions des joueurs sur un plateau neuf
+func stackBoard(players ...*models.Player) *models.Board {
+	board := models.NewBoard()
+	for _, player := range players {
+		for _, token := range player.Tokens {
+			if token.Position >= 0 && token.Position < constants.TotalCells {
+				board.Cells[token.Position].Add(token)
+			}
+		}
+	}
+	return board
+}
+
+func TestBlockadesStopAndAttractPawns(t *testing.T) {
+	red := models.NewPlayer(1, "red", constants.ColorRed)
+	blue := models.NewPlayer(2, "blue", constants.ColorBlue)
+	red.Tokens[0].Position = 10
+	red.Tokens[1].Position = 20
+	red.Tokens[2].Position = 24
+	blue.Tokens[0].Position = 14
+	blue.Tokens[1].Position = 14
+	board := stackBoard(red, blue)
+
+	bot := NewAIPlayer("hard")
+	bot.House = models.RuleConfig{Blockades: true}
+
+	valid := bot.getValidTokens(red, 4, board)
+	for _, token := range valid {
+		if token == red.Tokens[0] {
+			t.Error("pawn may not pass the blue blockade")
+		}
+	}
+	if len(valid) != 2 {
+		t.Errorf("%d movable pawns, want 2", len(valid))
+	}
+
+	// Rejoindre le pion en 24 forme un barrage: préféré au pion plus avancé
+	if got := bot.selectTokenHard(red, 4, board); got != red.Tokens[1] {
+		t.Errorf("hard AI moved pawn %d, want the one forming a blockade", got.ID)
+	}
+
+	bot.House = models.RuleConfig{}
+	if _, ok := bot.legal(red, red.Tokens[1], 4, board); ok {
+		t.Error("classic rules should refuse stacking two pawns")
+	}
+}
@@ -0,0 +1,179 @@
+// pkg/ai/profile.go
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Weights définit les pondérations utilisées par evaluateMove
+type Weights struct {
+	Capture   int `json:"capture"`
+	LeaveBase int `json:"leave_base"`
+	EnterHome int `json:"enter_home"`
+	Safe      int `json:"safe"`
+	Advance   int `json:"advance"`
+	Isolated  int `json:"isolated"`
+	Danger    int `json:"danger"`
+	Block     int `json:"block"`
+	Blockade  int `json:"blockade"` // Former un barrage, ou le garder
+}
+
+// DefaultWeights sont les pondérations de l'IA sans historique
+var DefaultWeights = Weights{
+	Capture:   1000,
+	LeaveBase: 500,
+	EnterHome: 800,
+	Safe:      300,
+	Advance:   10,
+	Isolated:  200,
+	Danger:    400,
+	Block:     600,
+	Blockade:  350,
+}
+
+// profileConfidenceMoves est le nombre de coups observés pour une adaptation complète
+const profileConfidenceMoves = 50
+
+// Profile mémorise les habitudes d'un adversaire humain face à une IA nommée
+type Profile struct {
+	BotName       string `json:"bot_name"`
+	Opponent      string `json:"opponent"`
+	GamesPlayed   int    `json:"games_played"`
+	MovesObserved int    `json:"moves_observed"`
+	Captures      int    `json:"captures"`
+	EarlyExits    int    `json:"early_exits"` // sorties de base alors qu'un pion est déjà en jeu
+}
+
+// NewProfile crée un profil vierge
+func NewProfile(botName, opponent string) *Profile {
+	return &Profile{
+		BotName:  botName,
+		Opponent: opponent,
+	}
+}
+
+// RecordMove enregistre un coup joué par l'adversaire humain
+func (p *Profile) RecordMove(captured, leftBase bool, tokensInPlay int) {
+	p.MovesObserved++
+	if captured {
+		p.Captures++
+	}
+	if leftBase && tokensInPlay > 0 {
+		p.EarlyExits++
+	}
+}
+
+// Aggression retourne la proportion de coups ayant capturé un pion
+func (p *Profile) Aggression() float64 {
+	if p.MovesObserved == 0 {
+		return 0
+	}
+	return float64(p.Captures) / float64(p.MovesObserved)
+}
+
+// EarlyExitRate retourne la proportion de coups sortant un pion supplémentaire
+func (p *Profile) EarlyExitRate() float64 {
+	if p.MovesObserved == 0 {
+		return 0
+	}
+	return float64(p.EarlyExits) / float64(p.MovesObserved)
+}
+
+// Weights adapte les pondérations par défaut aux habitudes observées
+func (p *Profile) Weights() Weights {
+	w := DefaultWeights
+	if p == nil || p.MovesObserved == 0 {
+		return w
+	}
+
+	confidence := float64(p.MovesObserved) / profileConfidenceMoves
+	if confidence > 1 {
+		confidence = 1
+	}
+
+	// Face à un joueur agressif: rester à l'abri et éviter les cases exposées
+	aggression := p.Aggression() * confidence
+	w.Safe += int(float64(DefaultWeights.Safe) * aggression * 2)
+	w.Danger += int(float64(DefaultWeights.Danger) * aggression * 2)
+
+	// Face à un joueur qui sort vite ses pions: le gêner et sortir aussi
+	exits := p.EarlyExitRate() * confidence
+	w.Block += int(float64(DefaultWeights.Block) * exits)
+	w.LeaveBase += int(float64(DefaultWeights.LeaveBase) * exits)
+
+	return w
+}
+
+// ProfileStore sauvegarde les profils en JSON dans un répertoire local
+type ProfileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewProfileStore crée un stockage de profils
+func NewProfileStore(dir string) *ProfileStore {
+	return &ProfileStore{dir: dir}
+}
+
+// Load charge un profil, ou en crée un vierge s'il n'existe pas encore
+func (s *ProfileStore) Load(botName, opponent string) (*Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(botName, opponent))
+	if errors.Is(err, os.ErrNotExist) {
+		return NewProfile(botName, opponent), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile: %w", err)
+	}
+
+	profile := &Profile{}
+	if err := json.Unmarshal(data, profile); err != nil {
+		return nil, fmt.Errorf("failed to decode profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// Save écrit un profil sur le disque
+func (s *ProfileStore) Save(profile *Profile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create profile dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode profile: %w", err)
+	}
+
+	if err := os.WriteFile(s.path(profile.BotName, profile.Opponent), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+
+	return nil
+}
+
+// path retourne le fichier associé à un couple IA/adversaire
+func (s *ProfileStore) path(botName, opponent string) string {
+	return filepath.Join(s.dir, sanitizeName(botName)+"__"+sanitizeName(opponent)+".json")
+}
+
+// sanitizeName rend un nom utilisable dans un nom de fichier
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
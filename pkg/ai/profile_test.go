@@ -0,0 +1,129 @@
+// pkg/ai/profile_test.go
+package ai
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfileRecordsTendencies(t *testing.T) {
+	p := NewProfile("Rex", "alice")
+	if p.Aggression() != 0 || p.EarlyExitRate() != 0 {
+		t.Fatal("a fresh profile must have no tendencies")
+	}
+
+	p.RecordMove(true, false, 1)
+	p.RecordMove(false, true, 0) // Premier pion sorti: pas une sortie hâtive
+	p.RecordMove(false, true, 2)
+	p.RecordMove(false, false, 3)
+
+	if p.MovesObserved != 4 || p.Captures != 1 || p.EarlyExits != 1 {
+		t.Fatalf("got %d moves, %d captures, %d early exits", p.MovesObserved, p.Captures, p.EarlyExits)
+	}
+	if p.Aggression() != 0.25 || p.EarlyExitRate() != 0.25 {
+		t.Fatalf("got aggression %v, early exits %v", p.Aggression(), p.EarlyExitRate())
+	}
+}
+
+func TestProfileWeightsAdaptWithConfidence(t *testing.T) {
+	var none *Profile
+	if none.Weights() != DefaultWeights || NewProfile("Rex", "alice").Weights() != DefaultWeights {
+		t.Fatal("a profile without moves must keep the default weights")
+	}
+
+	aggressive := func(moves int) Weights {
+		p := NewProfile("Rex", "alice")
+		for i := 0; i < moves; i++ {
+			p.RecordMove(true, false, 1)
+		}
+		return p.Weights()
+	}
+
+	// Confiance à moitié: la moitié de l'adaptation
+	half := aggressive(profileConfidenceMoves / 2)
+	if half.Safe != 600 || half.Danger != 800 {
+		t.Fatalf("half confidence: got safe %d, danger %d", half.Safe, half.Danger)
+	}
+
+	// Au-delà de profileConfidenceMoves, l'adaptation plafonne
+	full := aggressive(profileConfidenceMoves)
+	if full.Safe != 900 || full.Danger != 1200 {
+		t.Fatalf("full confidence: got safe %d, danger %d", full.Safe, full.Danger)
+	}
+	if aggressive(4*profileConfidenceMoves) != full {
+		t.Fatal("weights kept growing past full confidence")
+	}
+	if full.Block != DefaultWeights.Block || full.LeaveBase != DefaultWeights.LeaveBase {
+		t.Fatal("captures alone must not change blocking weights")
+	}
+
+	rusher := NewProfile("Rex", "bob")
+	for i := 0; i < profileConfidenceMoves; i++ {
+		rusher.RecordMove(false, true, 1)
+	}
+	w := rusher.Weights()
+	if w.Block != 2*DefaultWeights.Block || w.LeaveBase != 2*DefaultWeights.LeaveBase {
+		t.Fatalf("early exits: got block %d, leave base %d", w.Block, w.LeaveBase)
+	}
+	if w.Safe != DefaultWeights.Safe || w.Danger != DefaultWeights.Danger {
+		t.Fatal("early exits alone must not change safety weights")
+	}
+}
+
+func TestProfileStorePersists(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "profiles")
+	store := NewProfileStore(dir)
+
+	fresh, err := store.Load("Rex", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh.BotName != "Rex" || fresh.Opponent != "alice" || fresh.MovesObserved != 0 {
+		t.Fatalf("missing profile: got %+v", fresh)
+	}
+
+	fresh.GamesPlayed = 3
+	fresh.RecordMove(true, true, 1)
+	if err := store.Save(fresh); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := NewProfileStore(dir).Load("Rex", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *loaded != *fresh {
+		t.Fatalf("got %+v, want %+v", loaded, fresh)
+	}
+	if loaded.Weights() != fresh.Weights() {
+		t.Fatal("a reloaded profile must adapt the same way")
+	}
+}
+
+func TestProfileStoreSanitizesNames(t *testing.T) {
+	dir := t.TempDir()
+	store := NewProfileStore(dir)
+
+	if err := store.Save(NewProfile("Rex", "../../evil")); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "Rex________evil.json" {
+		t.Fatalf("got files %v", entries)
+	}
+}
+
+func TestProfileStoreRejectsCorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	store := NewProfileStore(dir)
+	if err := os.WriteFile(store.path("Rex", "alice"), []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load("Rex", "alice"); err == nil {
+		t.Fatal("a corrupt profile must not load")
+	}
+}
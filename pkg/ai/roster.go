@@ -0,0 +1,74 @@
+// pkg/ai/roster.go
+package ai
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+//go:embed roster.json
+var rosterData []byte
+
+// Bot est une IA nommée du catalogue, avec son avatar et son caractère
+type Bot struct {
+	Name        string  `json:"name"`
+	Avatar      string  `json:"avatar"`
+	Personality string  `json:"personality"`
+	Level       string  `json:"level"` // easy, medium, hard
+	Bias        Weights `json:"bias"`  // Ajouté aux pondérations du profil
+}
+
+// roster est le catalogue des IA, chargé une seule fois
+var roster = mustLoadRoster(rosterData)
+
+// mustLoadRoster décode le catalogue embarqué
+func mustLoadRoster(data []byte) []Bot {
+	var bots []Bot
+	if err := json.Unmarshal(data, &bots); err != nil {
+		panic(fmt.Sprintf("invalid bot roster: %v", err))
+	}
+	return bots
+}
+
+// Roster retourne une copie du catalogue des IA
+func Roster() []Bot {
+	return append([]Bot(nil), roster...)
+}
+
+// DrawBots tire n IA distinctes au hasard, en privilégiant celles du niveau
+// demandé et en complétant avec les autres si le catalogue ne suffit pas
+func DrawBots(n int, level string, r *rand.Rand) []Bot {
+	var matching, others []Bot
+	for _, bot := range roster {
+		if strings.EqualFold(bot.Level, level) {
+			matching = append(matching, bot)
+		} else {
+			others = append(others, bot)
+		}
+	}
+	r.Shuffle(len(matching), func(i, j int) { matching[i], matching[j] = matching[j], matching[i] })
+	r.Shuffle(len(others), func(i, j int) { others[i], others[j] = others[j], others[i] })
+
+	bots := append(matching, others...)
+	if n < len(bots) {
+		bots = bots[:n]
+	}
+	return bots
+}
+
+// Adjust ajoute le caractère de l'IA aux pondérations données
+func (b Bot) Adjust(w Weights) Weights {
+	w.Capture += b.Bias.Capture
+	w.LeaveBase += b.Bias.LeaveBase
+	w.EnterHome += b.Bias.EnterHome
+	w.Safe += b.Bias.Safe
+	w.Advance += b.Bias.Advance
+	w.Isolated += b.Bias.Isolated
+	w.Danger += b.Bias.Danger
+	w.Block += b.Bias.Block
+	w.Blockade += b.Bias.Blockade
+	return w
+}
@@ -0,0 +1,40 @@
+// pkg/ai/roster_test.go
+package ai
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDrawBotsPrefersLevelAndStaysDistinct(t *testing.T) {
+	bots := DrawBots(3, "Hard", rand.New(rand.NewSource(1)))
+	if len(bots) != 3 {
+		t.Fatalf("drew %d bots, want 3", len(bots))
+	}
+	seen := make(map[string]bool)
+	for _, bot := range bots {
+		if bot.Level != "hard" {
+			t.Errorf("%s is %s, want a hard bot", bot.Name, bot.Level)
+		}
+		if seen[bot.Name] {
+			t.Errorf("%s drawn twice", bot.Name)
+		}
+		seen[bot.Name] = true
+	}
+
+	// Plus d'IA que le niveau n'en propose: on complète avec les autres
+	if got := len(DrawBots(len(Roster())+2, "easy", rand.New(rand.NewSource(1)))); got != len(Roster()) {
+		t.Errorf("drew %d bots, want the whole roster (%d)", got, len(Roster()))
+	}
+}
+
+func TestBotAdjustAddsBias(t *testing.T) {
+	bot := Bot{Bias: Weights{Capture: 400, Block: -100}}
+	w := bot.Adjust(DefaultWeights)
+	if w.Capture != DefaultWeights.Capture+400 || w.Block != DefaultWeights.Block-100 {
+		t.Errorf("adjusted weights = %+v", w)
+	}
+	if w.Safe != DefaultWeights.Safe {
+		t.Error("unbiased weights should be unchanged")
+	}
+}
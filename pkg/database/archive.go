@@ -0,0 +1,599 @@
+// pkg/database/archive.go
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// ArchiveVersion est la version du format des archives d'export. Une
+// archive d'une autre version est refusée à l'import.
+const ArchiveVersion = 1
+
+// Archive est l'export portable des joueurs d'une instance: comptes,
+// statistiques et historique. Les ids sont ceux de l'instance d'origine;
+// l'import leur en attribue de nouveaux.
+type Archive struct {
+	Version    int               `json:"version"`
+	ExportedAt time.Time         `json:"exported_at"`
+	Users      []ArchivedUser    `json:"users"`
+	Rivalries  []ArchivedRivalry `json:"rivalries,omitempty"`
+	Series     []ArchivedSeries  `json:"series,omitempty"`
+	Games      []ArchivedGame    `json:"games,omitempty"`
+	Seasons    []ArchivedSeason  `json:"seasons,omitempty"`
+}
+
+// ArchivedUser est un compte avec tout ce qui fait sa progression
+type ArchivedUser struct {
+	User          models.User          `json:"user"`
+	PasswordHash  string               `json:"password_hash"`
+	Stats         models.PlayerStats   `json:"stats"`
+	LastRankedAt  *time.Time           `json:"last_ranked_at,omitempty"`
+	RatingHistory []models.RatingPoint `json:"rating_history,omitempty"`
+	SeasonStats   []SeasonStats        `json:"season_stats,omitempty"`
+}
+
+// SeasonStats sont les statistiques d'un joueur classé à la fin d'une saison
+type SeasonStats struct {
+	Season          int     `json:"season"`
+	Rating          float64 `json:"rating"`
+	RatingDeviation float64 `json:"rating_deviation"`
+	RankedGames     int     `json:"ranked_games"`
+	TotalGames      int     `json:"total_games"`
+	GamesWon        int     `json:"games_won"`
+}
+
+// ArchivedRivalry est le bilan d'une paire de joueurs, rangée par ids croissants
+type ArchivedRivalry struct {
+	UserLow    int64     `json:"user_low"`
+	UserHigh   int64     `json:"user_high"`
+	WinsLow    int       `json:"wins_low"`
+	WinsHigh   int       `json:"wins_high"`
+	Games      int       `json:"games"`
+	LastPlayed time.Time `json:"last_played_at"`
+}
+
+// ArchivedSeries est le score d'un joueur dans une série de revanches
+type ArchivedSeries struct {
+	RoomID      string    `json:"room_id"`
+	StartedAt   time.Time `json:"series_started_at"`
+	UserID      int64     `json:"user_id"`
+	GamesPlayed int       `json:"games_played"`
+	Wins        int       `json:"wins"`
+}
+
+// ArchivedGame est une partie terminée et ses participants
+type ArchivedGame struct {
+	RoomID          string                `json:"room_id"`
+	GameMode        string                `json:"game_mode"`
+	WinnerID        *int64                `json:"winner_id,omitempty"`
+	DurationSeconds int                   `json:"duration_seconds"`
+	StartedAt       time.Time             `json:"started_at"`
+	EndedAt         *time.Time            `json:"ended_at,omitempty"`
+	Aborted         bool                  `json:"aborted"`
+	Participants    []ArchivedParticipant `json:"participants"`
+}
+
+// ArchivedParticipant est le siège d'un joueur dans une partie. UserID est
+// nil pour une IA ou un compte qui n'existe plus.
+type ArchivedParticipant struct {
+	UserID         *int64 `json:"user_id,omitempty"`
+	Position       int    `json:"position"`
+	Color          string `json:"color"`
+	FinalRank      *int   `json:"final_rank,omitempty"`
+	TokensAtHome   int    `json:"tokens_at_home"`
+	TokensCaptured int    `json:"tokens_captured"`
+	DiceRolls      int    `json:"dice_rolls"`
+	IsWinner       bool   `json:"is_winner"`
+	TurnOrder      *int   `json:"turn_order,omitempty"`
+	IsAI           bool   `json:"is_ai"`
+}
+
+// ArchivedSeason est une saison close. L'import la marque close aussi, pour
+// que la cote des joueurs ne s'érode pas une seconde fois.
+type ArchivedSeason struct {
+	Season  int       `json:"season"`
+	EndedAt time.Time `json:"ended_at"`
+}
+
+// ImportReport résume un import
+type ImportReport struct {
+	Users int `json:"users"`
+	// Skipped liste les comptes dont le pseudo ou l'e-mail est déjà pris sur
+	// cette instance. Leurs parties restent, sans lien vers eux.
+	Skipped []string        `json:"skipped,omitempty"`
+	Games   int             `json:"games"`
+	IDs     map[int64]int64 `json:"ids"` // Ancien id -> nouvel id
+
+	// SkippedGames compte les parties déjà présentes (même salle, même
+	// début), par exemple quand la même archive est importée deux fois
+	SkippedGames int `json:"skipped_games,omitempty"`
+}
+
+// checkArchive refuse une archive d'un format inconnu
+func checkArchive(archive *Archive) error {
+	if archive == nil || archive.Version != ArchiveVersion {
+		return fmt.Errorf("unsupported archive version (want %d)", ArchiveVersion)
+	}
+	return nil
+}
+
+// remapID traduit un id de l'archive, nil si le compte n'a pas été importé
+func remapID(ids map[int64]int64, old *int64) *int64 {
+	if old == nil {
+		return nil
+	}
+	id, ok := ids[*old]
+	if !ok {
+		return nil
+	}
+	return &id
+}
+
+// remapped traduit les deux joueurs d'un bilan. Les nouveaux ids peuvent
+// inverser l'ordre de la paire: les victoires suivent alors leur joueur.
+// ok est faux si l'un des deux comptes n'a pas été importé.
+func (r ArchivedRivalry) remapped(ids map[int64]int64) (ArchivedRivalry, bool) {
+	low, okLow := ids[r.UserLow]
+	high, okHigh := ids[r.UserHigh]
+	if !okLow || !okHigh {
+		return ArchivedRivalry{}, false
+	}
+	r.UserLow, r.UserHigh = low, high
+	if low > high {
+		r.UserLow, r.UserHigh = high, low
+		r.WinsLow, r.WinsHigh = r.WinsHigh, r.WinsLow
+	}
+	return r, true
+}
+
+// playerArchive réduit une archive aux données d'un joueur, pour qu'il les
+// télécharge: son compte sans le hash du mot de passe, ses bilans, ses séries
+// et ses parties, où les autres participants restent anonymes
+func playerArchive(archive *Archive, userID int64) *Archive {
+	player := &Archive{Version: archive.Version, ExportedAt: archive.ExportedAt}
+	for _, entry := range archive.Users {
+		if entry.User.ID == userID {
+			entry.PasswordHash = ""
+			player.Users = append(player.Users, entry)
+		}
+	}
+	for _, r := range archive.Rivalries {
+		if r.UserLow == userID || r.UserHigh == userID {
+			player.Rivalries = append(player.Rivalries, r)
+		}
+	}
+	for _, s := range archive.Series {
+		if s.UserID == userID {
+			player.Series = append(player.Series, s)
+		}
+	}
+	for _, g := range archive.Games {
+		played := false
+		participants := make([]ArchivedParticipant, len(g.Participants))
+		for i, p := range g.Participants {
+			if p.UserID != nil && *p.UserID == userID {
+				played = true
+			} else {
+				p.UserID = nil
+			}
+			participants[i] = p
+		}
+		if !played {
+			continue
+		}
+		if g.WinnerID != nil && *g.WinnerID != userID {
+			g.WinnerID = nil
+		}
+		g.Participants = participants
+		player.Games = append(player.Games, g)
+	}
+	return player
+}
+
+// ExportArchive exporte tous les joueurs de la base avec leur historique
+func (db *DB) ExportArchive() (*Archive, error) {
+	return db.exportArchive(nil)
+}
+
+// ExportPlayer exporte les données d'un joueur, sans le hash de son mot de passe
+func (db *DB) ExportPlayer(userID int64) (*Archive, error) {
+	archive, err := db.exportArchive(&userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(archive.Users) == 0 {
+		return nil, fmt.Errorf("user not found")
+	}
+	return playerArchive(archive, userID), nil
+}
+
+// exportArchive lit l'archive de toute la base, ou seulement ce qui concerne
+// userID s'il n'est pas nil
+func (db *DB) exportArchive(userID *int64) (*Archive, error) {
+	archive := &Archive{Version: ArchiveVersion, ExportedAt: time.Now()}
+	conn := db.reader()
+
+	users, err := conn.Query(`SELECT u.id, u.username, u.email, u.password_hash, u.avatar_url,
+	          u.level, u.experience, u.coins, u.created_at, u.last_login, u.pace,
+	          s.total_games, s.games_won, s.games_lost, s.games_aborted,
+	          s.tokens_captured, s.tokens_lost, s.sixes_rolled, s.total_dice_rolls, s.win_rate,
+	          s.highest_streak, s.current_streak, s.decisions, s.decision_ms,
+	          s.rating, s.rating_deviation, s.ranked_games, s.last_ranked_at
+	          FROM users u JOIN player_stats s ON s.user_id = u.id
+	          WHERE ? IS NULL OR u.id = ? ORDER BY u.id`, userID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export users: %w", err)
+	}
+	defer users.Close()
+
+	index := make(map[int64]int)
+	for users.Next() {
+		var entry ArchivedUser
+		user, stats := &entry.User, &entry.Stats
+		var avatarURL, pace sql.NullString
+		var lastLogin, lastRanked sql.NullTime
+		err := users.Scan(&user.ID, &user.Username, &user.Email, &entry.PasswordHash, &avatarURL,
+			&user.Level, &user.Experience, &user.Coins, &user.CreatedAt, &lastLogin, &pace,
+			&stats.TotalGames, &stats.GamesWon, &stats.GamesLost, &stats.GamesAborted,
+			&stats.TokensCaptured, &stats.TokensLost, &stats.SixesRolled, &stats.TotalDiceRolls, &stats.WinRate,
+			&stats.HighestStreak, &stats.CurrentStreak, &stats.Decisions, &stats.DecisionMs,
+			&stats.Rating, &stats.RatingDeviation, &stats.RankedGames, &lastRanked)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export users: %w", err)
+		}
+		setNullableUserFields(user, avatarURL, lastLogin, pace)
+		stats.UserID = user.ID
+		if lastRanked.Valid {
+			entry.LastRankedAt = &lastRanked.Time
+		}
+		index[user.ID] = len(archive.Users)
+		archive.Users = append(archive.Users, entry)
+	}
+	if err := users.Err(); err != nil {
+		return nil, fmt.Errorf("failed to export users: %w", err)
+	}
+
+	history, err := conn.Query(`SELECT user_id, rating, rating_deviation, reason, season, recorded_at
+	          FROM rating_history WHERE ? IS NULL OR user_id = ? ORDER BY id`, userID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export rating history: %w", err)
+	}
+	defer history.Close()
+	for history.Next() {
+		var userID int64
+		var point models.RatingPoint
+		var season sql.NullInt64
+		if err := history.Scan(&userID, &point.Rating, &point.Deviation, &point.Reason, &season, &point.At); err != nil {
+			return nil, fmt.Errorf("failed to export rating history: %w", err)
+		}
+		point.Season = int(season.Int64)
+		if i, ok := index[userID]; ok {
+			archive.Users[i].RatingHistory = append(archive.Users[i].RatingHistory, point)
+		}
+	}
+	if err := history.Err(); err != nil {
+		return nil, fmt.Errorf("failed to export rating history: %w", err)
+	}
+
+	seasonStats, err := conn.Query(`SELECT season, user_id, rating, rating_deviation, ranked_games,
+	          total_games, games_won FROM season_stats
+	          WHERE ? IS NULL OR user_id = ? ORDER BY season, user_id`, userID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export season stats: %w", err)
+	}
+	defer seasonStats.Close()
+	for seasonStats.Next() {
+		var userID int64
+		var s SeasonStats
+		if err := seasonStats.Scan(&s.Season, &userID, &s.Rating, &s.RatingDeviation, &s.RankedGames, &s.TotalGames, &s.GamesWon); err != nil {
+			return nil, fmt.Errorf("failed to export season stats: %w", err)
+		}
+		if i, ok := index[userID]; ok {
+			archive.Users[i].SeasonStats = append(archive.Users[i].SeasonStats, s)
+		}
+	}
+	if err := seasonStats.Err(); err != nil {
+		return nil, fmt.Errorf("failed to export season stats: %w", err)
+	}
+
+	if err := db.exportHistory(conn, archive, userID); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// exportHistory ajoute à l'archive les bilans, séries, parties et saisons,
+// de tous les joueurs ou du seul userID
+func (db *DB) exportHistory(conn *sql.DB, archive *Archive, userID *int64) error {
+	rivalries, err := conn.Query(`SELECT user_low, user_high, wins_low, wins_high, games, last_played_at
+	          FROM rivalries WHERE ? IS NULL OR ? IN (user_low, user_high)
+	          ORDER BY user_low, user_high`, userID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to export rivalries: %w", err)
+	}
+	defer rivalries.Close()
+	for rivalries.Next() {
+		var r ArchivedRivalry
+		if err := rivalries.Scan(&r.UserLow, &r.UserHigh, &r.WinsLow, &r.WinsHigh, &r.Games, &r.LastPlayed); err != nil {
+			return fmt.Errorf("failed to export rivalries: %w", err)
+		}
+		archive.Rivalries = append(archive.Rivalries, r)
+	}
+	if err := rivalries.Err(); err != nil {
+		return fmt.Errorf("failed to export rivalries: %w", err)
+	}
+
+	series, err := conn.Query(`SELECT room_id, series_started_at, user_id, games_played, wins
+	          FROM room_series WHERE ? IS NULL OR user_id = ?
+	          ORDER BY series_started_at, room_id, user_id`, userID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to export series: %w", err)
+	}
+	defer series.Close()
+	for series.Next() {
+		var s ArchivedSeries
+		if err := series.Scan(&s.RoomID, &s.StartedAt, &s.UserID, &s.GamesPlayed, &s.Wins); err != nil {
+			return fmt.Errorf("failed to export series: %w", err)
+		}
+		archive.Series = append(archive.Series, s)
+	}
+	if err := series.Err(); err != nil {
+		return fmt.Errorf("failed to export series: %w", err)
+	}
+
+	games, err := conn.Query(`SELECT id, room_id, game_mode, winner_id, duration_seconds,
+	          started_at, ended_at, aborted FROM game_history
+	          WHERE ? IS NULL OR id IN (SELECT game_id FROM game_participants WHERE user_id = ?)
+	          ORDER BY id`, userID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to export games: %w", err)
+	}
+	defer games.Close()
+	gameIndex := make(map[int64]int)
+	for games.Next() {
+		var id int64
+		var g ArchivedGame
+		var winnerID sql.NullInt64
+		var duration sql.NullInt64
+		var endedAt sql.NullTime
+		if err := games.Scan(&id, &g.RoomID, &g.GameMode, &winnerID, &duration, &g.StartedAt, &endedAt, &g.Aborted); err != nil {
+			return fmt.Errorf("failed to export games: %w", err)
+		}
+		if winnerID.Valid {
+			g.WinnerID = &winnerID.Int64
+		}
+		if endedAt.Valid {
+			g.EndedAt = &endedAt.Time
+		}
+		g.DurationSeconds = int(duration.Int64)
+		gameIndex[id] = len(archive.Games)
+		archive.Games = append(archive.Games, g)
+	}
+	if err := games.Err(); err != nil {
+		return fmt.Errorf("failed to export games: %w", err)
+	}
+
+	participants, err := conn.Query(`SELECT game_id, user_id, player_position, color, final_rank,
+	          tokens_at_home, tokens_captured, dice_rolls, is_winner, turn_order, is_ai
+	          FROM game_participants
+	          WHERE ? IS NULL OR game_id IN (SELECT game_id FROM game_participants WHERE user_id = ?)
+	          ORDER BY game_id, player_position`, userID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to export participants: %w", err)
+	}
+	defer participants.Close()
+	for participants.Next() {
+		var gameID int64
+		var p ArchivedParticipant
+		var userID, finalRank, turnOrder sql.NullInt64
+		err := participants.Scan(&gameID, &userID, &p.Position, &p.Color, &finalRank,
+			&p.TokensAtHome, &p.TokensCaptured, &p.DiceRolls, &p.IsWinner, &turnOrder, &p.IsAI)
+		if err != nil {
+			return fmt.Errorf("failed to export participants: %w", err)
+		}
+		if userID.Valid {
+			p.UserID = &userID.Int64
+		}
+		if finalRank.Valid {
+			rank := int(finalRank.Int64)
+			p.FinalRank = &rank
+		}
+		if turnOrder.Valid {
+			order := int(turnOrder.Int64)
+			p.TurnOrder = &order
+		}
+		if i, ok := gameIndex[gameID]; ok {
+			archive.Games[i].Participants = append(archive.Games[i].Participants, p)
+		}
+	}
+	if err := participants.Err(); err != nil {
+		return fmt.Errorf("failed to export participants: %w", err)
+	}
+
+	seasons, err := conn.Query(`SELECT season, ended_at FROM seasons ORDER BY season`)
+	if err != nil {
+		return fmt.Errorf("failed to export seasons: %w", err)
+	}
+	defer seasons.Close()
+	for seasons.Next() {
+		var s ArchivedSeason
+		if err := seasons.Scan(&s.Season, &s.EndedAt); err != nil {
+			return fmt.Errorf("failed to export seasons: %w", err)
+		}
+		archive.Seasons = append(archive.Seasons, s)
+	}
+	return seasons.Err()
+}
+
+// ImportArchive ajoute les joueurs d'une archive sous de nouveaux ids, en
+// une seule transaction. Un compte dont le pseudo ou l'e-mail est déjà pris
+// est ignoré: deux joueurs différents ne sont jamais fusionnés. Une partie
+// déjà présente n'est pas recréée.
+func (db *DB) ImportArchive(archive *Archive) (*ImportReport, error) {
+	if err := checkArchive(archive); err != nil {
+		return nil, err
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	report := &ImportReport{IDs: make(map[int64]int64)}
+	for _, entry := range archive.Users {
+		id, imported, err := importUserTx(tx, entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import %s: %w", entry.User.Username, err)
+		}
+		if !imported {
+			report.Skipped = append(report.Skipped, entry.User.Username)
+			continue
+		}
+		report.IDs[entry.User.ID] = id
+		report.Users++
+	}
+
+	for _, r := range archive.Rivalries {
+		r, ok := r.remapped(report.IDs)
+		if !ok {
+			continue
+		}
+		query := `INSERT INTO rivalries (user_low, user_high, wins_low, wins_high, games, last_played_at)
+		          VALUES (?, ?, ?, ?, ?, ?)`
+		if _, err := tx.Exec(query, r.UserLow, r.UserHigh, r.WinsLow, r.WinsHigh, r.Games, r.LastPlayed); err != nil {
+			return nil, fmt.Errorf("failed to import rivalries: %w", err)
+		}
+	}
+
+	for _, s := range archive.Series {
+		userID, ok := report.IDs[s.UserID]
+		if !ok {
+			continue
+		}
+		query := `INSERT INTO room_series (room_id, series_started_at, user_id, games_played, wins)
+		          VALUES (?, ?, ?, ?, ?)`
+		if _, err := tx.Exec(query, s.RoomID, s.StartedAt, userID, s.GamesPlayed, s.Wins); err != nil {
+			return nil, fmt.Errorf("failed to import series: %w", err)
+		}
+	}
+
+	for _, g := range archive.Games {
+		var existing int
+		query := `SELECT COUNT(*) FROM game_history WHERE room_id = ? AND started_at = ?`
+		if err := tx.QueryRow(query, g.RoomID, g.StartedAt).Scan(&existing); err != nil {
+			return nil, fmt.Errorf("failed to import games: %w", err)
+		}
+		if existing > 0 {
+			report.SkippedGames++
+			continue
+		}
+		if err := importGameTx(tx, g, report.IDs); err != nil {
+			return nil, fmt.Errorf("failed to import games: %w", err)
+		}
+		report.Games++
+	}
+
+	for _, s := range archive.Seasons {
+		if _, err := tx.Exec(`INSERT IGNORE INTO seasons (season, ended_at) VALUES (?, ?)`, s.Season, s.EndedAt); err != nil {
+			return nil, fmt.Errorf("failed to import seasons: %w", err)
+		}
+	}
+
+	return report, tx.Commit()
+}
+
+// importUserTx crée un compte de l'archive, ses statistiques et son
+// historique. imported est faux si le pseudo ou l'e-mail est déjà pris.
+func importUserTx(tx *sql.Tx, entry ArchivedUser) (id int64, imported bool, err error) {
+	user, stats := entry.User, entry.Stats
+
+	var taken int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM users WHERE username = ? OR email = ?`, user.Username, user.Email).Scan(&taken); err != nil {
+		return 0, false, err
+	}
+	if taken > 0 {
+		return 0, false, nil
+	}
+
+	query := `INSERT INTO users (username, email, password_hash, avatar_url, level, experience,
+	          coins, created_at, last_login, pace)
+	          VALUES (?, ?, ?, NULLIF(?, ''), ?, ?, ?, ?, ?, NULLIF(?, ''))`
+	result, err := tx.Exec(query, user.Username, user.Email, entry.PasswordHash, user.AvatarURL,
+		user.Level, user.Experience, user.Coins, user.CreatedAt, user.LastLogin, string(user.Pace))
+	if err != nil {
+		return 0, false, err
+	}
+	id, err = result.LastInsertId()
+	if err != nil {
+		return 0, false, err
+	}
+
+	statsQuery := `INSERT INTO player_stats (user_id, total_games, games_won, games_lost, games_aborted,
+	               tokens_captured, tokens_lost, sixes_rolled, total_dice_rolls, win_rate,
+	               highest_streak, current_streak, decisions, decision_ms,
+	               rating, rating_deviation, ranked_games, last_ranked_at)
+	               VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err = tx.Exec(statsQuery, id, stats.TotalGames, stats.GamesWon, stats.GamesLost, stats.GamesAborted,
+		stats.TokensCaptured, stats.TokensLost, stats.SixesRolled, stats.TotalDiceRolls, stats.WinRate,
+		stats.HighestStreak, stats.CurrentStreak, stats.Decisions, stats.DecisionMs,
+		stats.Rating, stats.RatingDeviation, stats.RankedGames, entry.LastRankedAt)
+	if err != nil {
+		return 0, false, err
+	}
+
+	for _, point := range entry.RatingHistory {
+		var season *int
+		if point.Season != 0 {
+			season = &point.Season
+		}
+		query := `INSERT INTO rating_history (user_id, rating, rating_deviation, reason, season, recorded_at)
+		          VALUES (?, ?, ?, ?, ?, ?)`
+		if _, err := tx.Exec(query, id, point.Rating, point.Deviation, point.Reason, season, point.At); err != nil {
+			return 0, false, err
+		}
+	}
+
+	for _, s := range entry.SeasonStats {
+		query := `INSERT INTO season_stats
+		          (season, user_id, rating, rating_deviation, ranked_games, total_games, games_won)
+		          VALUES (?, ?, ?, ?, ?, ?, ?)`
+		if _, err := tx.Exec(query, s.Season, id, s.Rating, s.RatingDeviation, s.RankedGames, s.TotalGames, s.GamesWon); err != nil {
+			return 0, false, err
+		}
+	}
+	return id, true, nil
+}
+
+// importGameTx recrée une partie et ses participants avec les nouveaux ids
+func importGameTx(tx *sql.Tx, g ArchivedGame, ids map[int64]int64) error {
+	query := `INSERT INTO game_history
+	          (room_id, game_mode, num_players, winner_id, duration_seconds, started_at, ended_at, aborted)
+	          VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := tx.Exec(query, g.RoomID, g.GameMode, len(g.Participants), remapID(ids, g.WinnerID),
+		g.DurationSeconds, g.StartedAt, g.EndedAt, g.Aborted)
+	if err != nil {
+		return err
+	}
+	gameID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for _, p := range g.Participants {
+		query := `INSERT INTO game_participants
+		          (game_id, user_id, player_position, color, final_rank, tokens_at_home,
+		           tokens_captured, dice_rolls, is_winner, turn_order, is_ai)
+		          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		_, err := tx.Exec(query, gameID, remapID(ids, p.UserID), p.Position, p.Color, p.FinalRank,
+			p.TokensAtHome, p.TokensCaptured, p.DiceRolls, p.IsWinner, p.TurnOrder, p.IsAI)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
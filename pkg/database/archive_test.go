@@ -0,0 +1,135 @@
+// pkg/database/archive_test.go
+package database
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestArchiveMovesPlayersToAnotherInstance(t *testing.T) {
+	source := NewMemoryStore()
+	alice, _ := source.CreateUser("alice", "a@example.com", "hash-a")
+	bob, _ := source.CreateUser("bob", "b@example.com", "hash-b")
+	source.CreateUser("carol", "c@example.com", "hash-c")
+
+	source.WriteStatUpdates([]StatUpdate{{UserID: bob.ID, Won: true}, {UserID: alice.ID}})
+	red := models.NewPlayer(alice.ID, "alice", constants.ColorRed)
+	green := models.NewPlayer(bob.ID, "bob", constants.ColorGreen)
+	source.SaveGameHistory(&models.Game{
+		Room:      &models.Room{ID: "K7MQ2X", Players: []*models.Player{red, green}},
+		StartTime: time.Now(),
+		Winner:    green,
+		Rankings:  []*models.Player{green, red},
+	})
+	source.EndSeason(1, SeasonDecay{InactiveSince: time.Now()})
+
+	exported, err := source.ExportArchive()
+	if err != nil {
+		t.Fatalf("ExportArchive failed: %v", err)
+	}
+	data, _ := json.Marshal(exported)
+	var archive Archive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		t.Fatalf("archive does not survive JSON: %v", err)
+	}
+
+	// La cible a déjà des comptes: les ids changent, carol est en conflit
+	target := NewMemoryStore()
+	target.CreateUser("zoe", "z@example.com", "")
+	target.CreateUser("carole", "c@example.com", "")
+
+	report, err := target.ImportArchive(&archive)
+	if err != nil {
+		t.Fatalf("ImportArchive failed: %v", err)
+	}
+	wantIDs := map[int64]int64{alice.ID: 3, bob.ID: 4}
+	if report.Users != 2 || report.Games != 1 || !reflect.DeepEqual(report.Skipped, []string{"carol"}) || !reflect.DeepEqual(report.IDs, wantIDs) {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+
+	found, _ := target.GetUserByUsername("bob")
+	if found.ID != 4 || found.PasswordHash != "hash-b" || found.Experience != 500 {
+		t.Errorf("bob was not carried over: %+v", found)
+	}
+	if stats, _ := target.GetPlayerStats(4); stats.GamesWon != 1 || stats.UserID != 4 {
+		t.Errorf("bob's stats were not carried over: %+v", stats)
+	}
+	rivals, _ := target.GetTopRivals(3, 5)
+	if len(rivals) != 1 || rivals[0].OpponentID != 4 || rivals[0].Losses != 1 {
+		t.Errorf("alice's rivalry was not remapped: %+v", rivals)
+	}
+	if balance, _ := target.GetBalanceReport(nil); balance.Games != 1 {
+		t.Errorf("Expected the imported game in the balance report, got %d", balance.Games)
+	}
+	if _, closed, _ := target.EndSeason(1, SeasonDecay{}); closed {
+		t.Error("Expected the imported season to stay closed")
+	}
+
+	// Un second import ne recrée ni les comptes ni les parties
+	again, err := target.ImportArchive(&archive)
+	if err != nil {
+		t.Fatalf("second ImportArchive failed: %v", err)
+	}
+	if again.Users != 0 || again.Games != 0 || again.SkippedGames != 1 || len(again.Skipped) != 3 {
+		t.Errorf("Unexpected report for a second import: %+v", again)
+	}
+	if balance, _ := target.GetBalanceReport(nil); balance.Games != 1 {
+		t.Errorf("Expected the game once after a second import, got %d", balance.Games)
+	}
+
+	archive.Version++
+	if _, err := target.ImportArchive(&archive); err == nil {
+		t.Error("Expected an archive of another version to be refused")
+	}
+}
+
+// Les nouveaux ids peuvent inverser une paire: les victoires suivent leur joueur
+func TestRemappedRivalryKeepsWinsWithTheirPlayer(t *testing.T) {
+	r := ArchivedRivalry{UserLow: 1, UserHigh: 2, WinsLow: 5, WinsHigh: 1, Games: 6}
+	got, ok := r.remapped(map[int64]int64{1: 9, 2: 4})
+	want := ArchivedRivalry{UserLow: 4, UserHigh: 9, WinsLow: 1, WinsHigh: 5, Games: 6}
+	if !ok || got != want {
+		t.Errorf("remapped = %+v, want %+v", got, want)
+	}
+	if _, ok := r.remapped(map[int64]int64{1: 9}); ok {
+		t.Error("Expected a rivalry with a skipped player to be dropped")
+	}
+}
+
+// L'export d'un joueur ne garde que ses données: ni hash, ni compte ou id des autres
+func TestPlayerArchiveKeepsOnlyTheirData(t *testing.T) {
+	alice, bob := int64(1), int64(2)
+	archive := &Archive{
+		Version: ArchiveVersion,
+		Users: []ArchivedUser{
+			{User: models.User{ID: alice, Username: "alice"}, PasswordHash: "a"},
+			{User: models.User{ID: bob, Username: "bob"}, PasswordHash: "b"},
+		},
+		Rivalries: []ArchivedRivalry{{UserLow: alice, UserHigh: bob, Games: 1}, {UserLow: bob, UserHigh: 3}},
+		Games: []ArchivedGame{
+			{WinnerID: &bob, Participants: []ArchivedParticipant{{UserID: &alice}, {UserID: &bob, IsWinner: true}}},
+			{Participants: []ArchivedParticipant{{UserID: &bob}}},
+		},
+		Seasons: []ArchivedSeason{{Season: 1}},
+	}
+
+	player := playerArchive(archive, alice)
+	if len(player.Users) != 1 || player.Users[0].User.ID != alice || player.Users[0].PasswordHash != "" {
+		t.Errorf("Unexpected users: %+v", player.Users)
+	}
+	if len(player.Rivalries) != 1 || len(player.Seasons) != 0 || len(player.Games) != 1 {
+		t.Fatalf("Unexpected history: %+v", player)
+	}
+	game := player.Games[0]
+	if game.WinnerID != nil || game.Participants[1].UserID != nil || !game.Participants[1].IsWinner || *game.Participants[0].UserID != alice {
+		t.Errorf("Other players were not anonymised: %+v", game)
+	}
+	if archive.Games[0].Participants[1].UserID == nil {
+		t.Error("playerArchive must not modify the full archive")
+	}
+}
@@ -0,0 +1,176 @@
+// pkg/database/balance.go
+package database
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// Seuils du rapport d'équilibre
+const (
+	balanceMinGames  = 100 // Parties nécessaires avant de juger un siège
+	balanceBiasScore = 3.0 // Écart, en écarts-types, qui signale un biais
+)
+
+// SeatTally compte les victoires d'un siège (couleur et ordre de jeu).
+// ExpectedWins et Variance cumulent 1/n et (1/n)(1-1/n) sur les parties à
+// n joueurs: un plateau équilibré donne en moyenne ExpectedWins victoires.
+type SeatTally struct {
+	Color        string
+	TurnOrder    int
+	Games        int
+	Wins         int
+	ExpectedWins float64
+	Variance     float64
+}
+
+// SeatBalance résume les résultats d'un siège dans le rapport d'équilibre
+type SeatBalance struct {
+	Seat         string  `json:"seat"`
+	Games        int     `json:"games"`
+	Wins         int     `json:"wins"`
+	WinRate      float64 `json:"win_rate"`
+	ExpectedRate float64 `json:"expected_rate"`
+	ZScore       float64 `json:"z_score"`
+	Biased       bool    `json:"biased"`
+}
+
+// BalanceReport agrège les victoires par couleur et par ordre de jeu
+type BalanceReport struct {
+	Since       *time.Time    `json:"since,omitempty"`
+	Games       int           `json:"games"`
+	ByColor     []SeatBalance `json:"by_color"`
+	ByTurnOrder []SeatBalance `json:"by_turn_order"`
+	Biased      bool          `json:"biased"` // Au moins un siège s'écarte de l'attendu
+}
+
+// decidedGame restreint une requête aux parties menées à leur terme avec un
+// vainqueur: abandons et nuls ne disent rien de l'avantage d'un siège
+const decidedGame = `gh.aborted = FALSE
+	AND EXISTS (SELECT 1 FROM game_participants w WHERE w.game_id = gh.id AND w.is_winner)
+	AND (? IS NULL OR gh.started_at >= ?)`
+
+// GetBalanceReport calcule l'équilibre des sièges sur les parties gagnées,
+// depuis since si non nil (pour comparer avant et après un changement de règles)
+func (db *DB) GetBalanceReport(since *time.Time) (*BalanceReport, error) {
+	query := `SELECT gp.color, gp.turn_order, COUNT(*), SUM(gp.is_winner),
+	                 SUM(1.0 / gh.num_players),
+	                 SUM((1.0 / gh.num_players) * (1 - 1.0 / gh.num_players))
+	          FROM game_participants gp
+	          JOIN game_history gh ON gh.id = gp.game_id
+	          WHERE gp.turn_order IS NOT NULL AND ` + decidedGame + `
+	          GROUP BY gp.color, gp.turn_order`
+
+	rows, err := db.reader().Query(query, since, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tallies []SeatTally
+	for rows.Next() {
+		var t SeatTally
+		if err := rows.Scan(&t.Color, &t.TurnOrder, &t.Games, &t.Wins, &t.ExpectedWins, &t.Variance); err != nil {
+			return nil, err
+		}
+		tallies = append(tallies, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var games int
+	err = db.reader().QueryRow(`SELECT COUNT(*) FROM game_history gh WHERE `+decidedGame,
+		since, since).Scan(&games)
+	if err != nil {
+		return nil, err
+	}
+
+	report := BuildBalanceReport(tallies)
+	report.Games = games
+	report.Since = since
+	return report, nil
+}
+
+// BuildBalanceReport regroupe les sièges par couleur et par ordre de jeu et
+// signale ceux qui gagnent nettement plus ou moins que l'attendu
+func BuildBalanceReport(tallies []SeatTally) *BalanceReport {
+	byColor := make(map[string]SeatTally)
+	byOrder := make(map[string]SeatTally)
+	for _, t := range tallies {
+		byColor[t.Color] = mergeTally(byColor[t.Color], t)
+		byOrder[turnOrderLabel(t.TurnOrder)] = mergeTally(byOrder[turnOrderLabel(t.TurnOrder)], t)
+	}
+
+	report := &BalanceReport{
+		ByColor:     seatBalances(byColor),
+		ByTurnOrder: seatBalances(byOrder),
+	}
+	for _, seats := range [][]SeatBalance{report.ByColor, report.ByTurnOrder} {
+		for _, seat := range seats {
+			report.Biased = report.Biased || seat.Biased
+		}
+	}
+	return report
+}
+
+// mergeTally additionne deux décomptes
+func mergeTally(a, b SeatTally) SeatTally {
+	a.Games += b.Games
+	a.Wins += b.Wins
+	a.ExpectedWins += b.ExpectedWins
+	a.Variance += b.Variance
+	return a
+}
+
+// seatBalances calcule les taux et l'écart à l'attendu de chaque siège
+func seatBalances(tallies map[string]SeatTally) []SeatBalance {
+	seats := make([]SeatBalance, 0, len(tallies))
+	for seat, t := range tallies {
+		balance := SeatBalance{Seat: seat, Games: t.Games, Wins: t.Wins}
+		if t.Games > 0 {
+			balance.WinRate = 100 * float64(t.Wins) / float64(t.Games)
+			balance.ExpectedRate = 100 * t.ExpectedWins / float64(t.Games)
+		}
+		if t.Variance > 0 {
+			balance.ZScore = (float64(t.Wins) - t.ExpectedWins) / math.Sqrt(t.Variance)
+		}
+		balance.Biased = t.Games >= balanceMinGames && math.Abs(balance.ZScore) >= balanceBiasScore
+		seats = append(seats, balance)
+	}
+	sort.Slice(seats, func(i, j int) bool { return seats[i].Seat < seats[j].Seat })
+	return seats
+}
+
+// turnOrderLabel nomme une place dans l'ordre de jeu ("1st", "2nd"...)
+func turnOrderLabel(order int) string {
+	switch order {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	}
+	return strconv.Itoa(order) + "th"
+}
+
+// turnOrder retourne la place (1 = premier) du siège dans l'ordre de jeu
+func turnOrder(seat, first, players int) int {
+	return (seat-first+players)%players + 1
+}
+
+// finalRank retourne le classement final du joueur, ou sa place à défaut
+// de classement (partie abandonnée)
+func finalRank(game *models.Game, player *models.Player, seat int) int {
+	for i, ranked := range game.Rankings {
+		if ranked != nil && ranked.Color == player.Color {
+			return i + 1
+		}
+	}
+	return seat + 1
+}
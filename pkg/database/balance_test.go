@@ -0,0 +1,64 @@
+// pkg/database/balance_test.go
+package database
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestTurnOrderStartsAtFirstPlayer(t *testing.T) {
+	// Quatre joueurs, le siège 2 a gagné le tirage au dé
+	want := []int{3, 4, 1, 2}
+	for seat, order := range want {
+		if got := turnOrder(seat, 2, 4); got != order {
+			t.Errorf("turnOrder(seat %d) = %d, want %d", seat, got, order)
+		}
+	}
+}
+
+func TestFinalRankUsesRankings(t *testing.T) {
+	red := models.NewPlayer(1, "red", constants.ColorRed)
+	blue := models.NewPlayer(2, "blue", constants.ColorBlue)
+	game := &models.Game{Rankings: []*models.Player{blue, red}}
+
+	if got := finalRank(game, red, 0); got != 2 {
+		t.Errorf("finalRank(red) = %d, want 2", got)
+	}
+	if got := finalRank(&models.Game{}, red, 0); got != 1 {
+		t.Errorf("finalRank without rankings = %d, want the seat", got)
+	}
+}
+
+func TestBuildBalanceReportFlagsBiasedSeat(t *testing.T) {
+	// 400 parties à deux: rouge commence toujours et gagne 260 fois
+	tallies := []SeatTally{
+		{Color: "red", TurnOrder: 1, Games: 400, Wins: 260, ExpectedWins: 200, Variance: 100},
+		{Color: "blue", TurnOrder: 2, Games: 400, Wins: 140, ExpectedWins: 200, Variance: 100},
+	}
+	report := BuildBalanceReport(tallies)
+
+	if !report.Biased {
+		t.Fatal("a 65% win rate over 400 games should be flagged")
+	}
+	if len(report.ByColor) != 2 || report.ByColor[1].Seat != "red" {
+		t.Fatalf("ByColor = %+v", report.ByColor)
+	}
+	red := report.ByColor[1]
+	if red.WinRate != 65 || red.ExpectedRate != 50 || red.ZScore != 6 {
+		t.Errorf("red = %+v, want 65%% vs 50%%, z=6", red)
+	}
+	if report.ByTurnOrder[0].Seat != "1st" || !report.ByTurnOrder[0].Biased {
+		t.Errorf("ByTurnOrder = %+v", report.ByTurnOrder)
+	}
+}
+
+func TestBuildBalanceReportNeedsEnoughGames(t *testing.T) {
+	tallies := []SeatTally{
+		{Color: "green", TurnOrder: 1, Games: 20, Wins: 18, ExpectedWins: 10, Variance: 5},
+	}
+	if BuildBalanceReport(tallies).Biased {
+		t.Error("20 games are too few to call a seat biased")
+	}
+}
@@ -0,0 +1,150 @@
+// pkg/database/batcher.go
+package database
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull est retourné quand le batcher ne peut plus accepter d'écritures
+var ErrQueueFull = errors.New("stats write queue is full")
+
+// BatcherMetrics expose l'état du batcher de statistiques
+type BatcherMetrics struct {
+	QueueDepth    int   `json:"queue_depth"`
+	QueueCapacity int   `json:"queue_capacity"`
+	Batches       int64 `json:"batches"`
+	Updates       int64 `json:"updates"`
+	Failures      int64 `json:"failures"` // Mises à jour abandonnées
+	Rejected      int64 `json:"rejected"`
+}
+
+// StatsBatcher regroupe les mises à jour de statistiques sur une courte fenêtre
+// et les écrit en une seule transaction
+type StatsBatcher struct {
+	db       StatsStore
+	queue    chan StatUpdate
+	window   time.Duration
+	maxBatch int
+	done     chan struct{}
+	stopped  chan struct{}
+
+	mu      sync.Mutex
+	metrics BatcherMetrics
+}
+
+// NewStatsBatcher crée un batcher et démarre sa boucle d'écriture
+func NewStatsBatcher(db StatsStore, window time.Duration, maxBatch, capacity int) *StatsBatcher {
+	b := &StatsBatcher{
+		db:       db,
+		queue:    make(chan StatUpdate, capacity),
+		window:   window,
+		maxBatch: maxBatch,
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	b.metrics.QueueCapacity = capacity
+
+	go b.run()
+
+	return b
+}
+
+// Add met une mise à jour en file sans bloquer.
+// ErrQueueFull signale à l'appelant qu'il doit écrire directement ou réessayer.
+func (b *StatsBatcher) Add(update StatUpdate) error {
+	select {
+	case b.queue <- update:
+		if depth := len(b.queue); depth > cap(b.queue)*3/4 {
+			log.Printf("⚠️ Stats write queue at %d/%d", depth, cap(b.queue))
+		}
+		return nil
+	default:
+		b.mu.Lock()
+		b.metrics.Rejected++
+		b.mu.Unlock()
+		return ErrQueueFull
+	}
+}
+
+// Metrics retourne une copie des métriques courantes
+func (b *StatsBatcher) Metrics() BatcherMetrics {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	metrics := b.metrics
+	metrics.QueueDepth = len(b.queue)
+	return metrics
+}
+
+// Close écrit les mises à jour restantes et arrête le batcher
+func (b *StatsBatcher) Close() {
+	close(b.done)
+	<-b.stopped
+}
+
+// run collecte les mises à jour et les écrit à chaque fenêtre ou lot plein
+func (b *StatsBatcher) run() {
+	defer close(b.stopped)
+
+	ticker := time.NewTicker(b.window)
+	defer ticker.Stop()
+
+	batch := make([]StatUpdate, 0, b.maxBatch)
+	for {
+		select {
+		case update := <-b.queue:
+			batch = append(batch, update)
+			if len(batch) >= b.maxBatch {
+				b.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				b.flush(batch)
+				batch = batch[:0]
+			}
+		case <-b.done:
+			// Vider la file avant de s'arrêter
+			for {
+				select {
+				case update := <-b.queue:
+					batch = append(batch, update)
+				default:
+					if len(batch) > 0 {
+						b.flush(batch)
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush écrit un lot dans une seule transaction. Si le lot est refusé, chaque
+// mise à jour est réécrite seule: une mise à jour fautive (joueur inconnu par
+// exemple) n'emporte pas les statistiques des autres joueurs.
+func (b *StatsBatcher) flush(batch []StatUpdate) {
+	written, failed := len(batch), 0
+	if err := b.db.WriteStatUpdates(batch); err != nil {
+		log.Printf("⚠️ Stats batch of %d updates rejected, writing them one by one: %v", len(batch), err)
+		written = 0
+		for _, update := range batch {
+			if err := b.db.WriteStatUpdate(update); err != nil {
+				failed++
+				log.Printf("Failed to write stats for user %d: %v", update.UserID, err)
+				continue
+			}
+			written++
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.metrics.Batches++
+	b.metrics.Updates += int64(written)
+	b.metrics.Failures += int64(failed)
+}
@@ -0,0 +1,37 @@
+// pkg/database/batcher_test.go
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+// Un joueur inconnu dans le lot ne doit pas faire perdre les statistiques
+// des autres joueurs
+func TestBatcherKeepsGoodUpdatesOfRejectedBatch(t *testing.T) {
+	store := NewMemoryStore()
+	alice, _ := store.CreateUser("alice", "a@example.com", "")
+	bob, _ := store.CreateUser("bob", "b@example.com", "")
+
+	batcher := NewStatsBatcher(store, time.Hour, 10, 10)
+	for _, update := range []StatUpdate{{UserID: alice.ID, Won: true}, {UserID: 999}, {UserID: bob.ID}} {
+		if err := batcher.Add(update); err != nil {
+			t.Fatal(err)
+		}
+	}
+	batcher.Close()
+
+	for _, id := range []int64{alice.ID, bob.ID} {
+		if stats, _ := store.GetPlayerStats(id); stats.TotalGames != 1 {
+			t.Errorf("user %d: expected 1 game, got %d", id, stats.TotalGames)
+		}
+	}
+	if stats, _ := store.GetPlayerStats(alice.ID); stats.GamesWon != 1 {
+		t.Errorf("Expected alice's win to be kept, got %d wins", stats.GamesWon)
+	}
+
+	metrics := batcher.Metrics()
+	if metrics.Updates != 2 || metrics.Failures != 1 {
+		t.Errorf("Expected 2 updates and 1 failure, got %d and %d", metrics.Updates, metrics.Failures)
+	}
+}
@@ -0,0 +1,169 @@
+// pkg/database/cluster.go
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// Instance est un serveur de jeu inscrit dans le registre partagé
+type Instance struct {
+	ID        string    `json:"id"`
+	Address   string    `json:"address"` // Adresse donnée aux joueurs transférés
+	Draining  bool      `json:"draining"`
+	Players   int       `json:"players"`
+	Rooms     int       `json:"rooms"`
+	Heartbeat time.Time `json:"heartbeat"`
+}
+
+// RoomTransfer est une salle en attente confiée à une autre instance
+type RoomTransfer struct {
+	RoomID       string
+	Target       string       // Instance qui recrée la salle
+	Room         *models.Room // Réglages de la salle, sans les joueurs
+	PasswordHash string       // Absent du JSON de la salle
+}
+
+// TransferTicket authentifie une seule fois un joueur transféré
+type TransferTicket struct {
+	Ticket    string
+	UserID    int64
+	Username  string
+	RoomID    string
+	Target    string
+	ExpiresAt time.Time
+}
+
+// HeartbeatInstance inscrit l'instance ou met à jour son état
+func (db *DB) HeartbeatInstance(instance Instance) error {
+	query := `INSERT INTO server_instances (instance_id, address, draining, players, rooms, heartbeat_at)
+	          VALUES (?, ?, ?, ?, ?, ?)
+	          ON DUPLICATE KEY UPDATE address = VALUES(address), draining = VALUES(draining),
+	              players = VALUES(players), rooms = VALUES(rooms), heartbeat_at = VALUES(heartbeat_at)`
+
+	_, err := db.conn.Exec(query, instance.ID, instance.Address, instance.Draining,
+		instance.Players, instance.Rooms, instance.Heartbeat)
+	return err
+}
+
+// ListInstances retourne les instances qui ont donné signe de vie depuis since
+func (db *DB) ListInstances(since time.Time) ([]Instance, error) {
+	query := `SELECT instance_id, address, draining, players, rooms, heartbeat_at
+	          FROM server_instances WHERE heartbeat_at >= ?`
+
+	rows, err := db.conn.Query(query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var instances []Instance
+	for rows.Next() {
+		var instance Instance
+		err := rows.Scan(&instance.ID, &instance.Address, &instance.Draining,
+			&instance.Players, &instance.Rooms, &instance.Heartbeat)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, instance)
+	}
+	return instances, rows.Err()
+}
+
+// RemoveInstance retire une instance arrêtée du registre
+func (db *DB) RemoveInstance(id string) error {
+	_, err := db.conn.Exec(`DELETE FROM server_instances WHERE instance_id = ?`, id)
+	return err
+}
+
+// SaveRoomTransfer confie une salle en attente à l'instance cible
+func (db *DB) SaveRoomTransfer(transfer RoomTransfer) error {
+	room, err := json.Marshal(transfer.Room)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO room_transfers (room_id, target_instance, room, password_hash)
+	          VALUES (?, ?, ?, ?)
+	          ON DUPLICATE KEY UPDATE target_instance = VALUES(target_instance),
+	              room = VALUES(room), password_hash = VALUES(password_hash)`
+
+	_, err = db.conn.Exec(query, transfer.RoomID, transfer.Target, room, transfer.PasswordHash)
+	return err
+}
+
+// ClaimRoomTransfer récupère et supprime une salle confiée à target. Retourne
+// nil sans erreur si aucune salle n'attend.
+func (db *DB) ClaimRoomTransfer(roomID, target string) (*RoomTransfer, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	transfer := &RoomTransfer{RoomID: roomID, Target: target}
+	var room []byte
+	err = tx.QueryRow(`SELECT room, password_hash FROM room_transfers
+	                   WHERE room_id = ? AND target_instance = ? FOR UPDATE`,
+		roomID, target).Scan(&room, &transfer.PasswordHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(room, &transfer.Room); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM room_transfers WHERE room_id = ?`, roomID); err != nil {
+		return nil, err
+	}
+	return transfer, tx.Commit()
+}
+
+// SaveTransferTicket enregistre le ticket d'un joueur transféré
+func (db *DB) SaveTransferTicket(ticket TransferTicket) error {
+	query := `INSERT INTO transfer_tickets (ticket, user_id, username, room_id, target_instance, expires_at)
+	          VALUES (?, ?, ?, ?, ?, ?)`
+
+	_, err := db.conn.Exec(query, ticket.Ticket, ticket.UserID, ticket.Username,
+		ticket.RoomID, ticket.Target, ticket.ExpiresAt)
+	return err
+}
+
+// ClaimTransferTicket consomme un ticket encore valable destiné à target.
+// Retourne nil sans erreur si le ticket est inconnu ou expiré.
+func (db *DB) ClaimTransferTicket(ticket, target string, now time.Time) (*TransferTicket, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	claimed := &TransferTicket{Ticket: ticket, Target: target}
+	err = tx.QueryRow(`SELECT user_id, username, room_id, expires_at FROM transfer_tickets
+	                   WHERE ticket = ? AND target_instance = ? FOR UPDATE`,
+		ticket, target).Scan(&claimed.UserID, &claimed.Username, &claimed.RoomID, &claimed.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM transfer_tickets WHERE ticket = ? OR expires_at < ?`, ticket, now); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	if now.After(claimed.ExpiresAt) {
+		return nil, nil
+	}
+	return claimed, nil
+}
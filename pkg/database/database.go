@@ -4,18 +4,49 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
 	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/rating"
 )
 
 type DB struct {
 	conn *sql.DB
+	read *sql.DB // Réplica en lecture seule, nil si non configuré
 }
 
-// NewDB crée une nouvelle connexion à la base de données
+// NewDB crée une nouvelle connexion à la base de données et met son schéma à jour
 func NewDB(host, port, user, password, dbname string) (*DB, error) {
+	conn, err := openPool(host, port, user, password, dbname)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrate(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return &DB{conn: conn}, nil
+}
+
+// UseReadReplica envoie les requêtes de lecture lourdes (classement, profils)
+// vers un réplica, les écritures restant sur la base principale
+func (db *DB) UseReadReplica(host, port, user, password, dbname string) error {
+	read, err := openPool(host, port, user, password, dbname)
+	if err != nil {
+		return fmt.Errorf("read replica: %w", err)
+	}
+
+	db.read = read
+	return nil
+}
+
+// openPool ouvre et vérifie un pool de connexions MySQL
+func openPool(host, port, user, password, dbname string) (*sql.DB, error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&charset=utf8mb4",
 		user, password, host, port, dbname)
 
@@ -31,14 +62,26 @@ func NewDB(host, port, user, password, dbname string) (*DB, error) {
 
 	// Test de connexion
 	if err := conn.Ping(); err != nil {
+		conn.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &DB{conn: conn}, nil
+	return conn, nil
+}
+
+// reader retourne le pool à utiliser pour les lectures tolérant un léger retard
+func (db *DB) reader() *sql.DB {
+	if db.read != nil {
+		return db.read
+	}
+	return db.conn
 }
 
 // Close ferme la connexion
 func (db *DB) Close() error {
+	if db.read != nil {
+		db.read.Close()
+	}
 	return db.conn.Close()
 }
 
@@ -69,13 +112,16 @@ func (db *DB) CreateUser(username, email, passwordHash string) (*models.User, er
 // GetUserByID récupère un utilisateur par son ID
 func (db *DB) GetUserByID(id int64) (*models.User, error) {
 	query := `SELECT id, username, email, avatar_url, level, experience, coins, 
-	          created_at, last_login FROM users WHERE id = ?`
+	          created_at, last_login, pace FROM users WHERE id = ?`
 
 	user := &models.User{}
+	var avatarURL sql.NullString
+	var lastLogin sql.NullTime
+	var pace sql.NullString
 	err := db.conn.QueryRow(query, id).Scan(
-		&user.ID, &user.Username, &user.Email, &user.AvatarURL,
+		&user.ID, &user.Username, &user.Email, &avatarURL,
 		&user.Level, &user.Experience, &user.Coins,
-		&user.CreatedAt, &user.LastLogin,
+		&user.CreatedAt, &lastLogin, &pace,
 	)
 
 	if err == sql.ErrNoRows {
@@ -85,19 +131,23 @@ func (db *DB) GetUserByID(id int64) (*models.User, error) {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	setNullableUserFields(user, avatarURL, lastLogin, pace)
 	return user, nil
 }
 
 // GetUserByUsername récupère un utilisateur par son username
 func (db *DB) GetUserByUsername(username string) (*models.User, error) {
 	query := `SELECT id, username, email, password_hash, avatar_url, level, 
-	          experience, coins, created_at, last_login FROM users WHERE username = ?`
+	          experience, coins, created_at, last_login, pace FROM users WHERE username = ?`
 
 	user := &models.User{}
+	var avatarURL sql.NullString
+	var lastLogin sql.NullTime
+	var pace sql.NullString
 	err := db.conn.QueryRow(query, username).Scan(
-		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.AvatarURL,
+		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &avatarURL,
 		&user.Level, &user.Experience, &user.Coins,
-		&user.CreatedAt, &user.LastLogin,
+		&user.CreatedAt, &lastLogin, &pace,
 	)
 
 	if err == sql.ErrNoRows {
@@ -107,9 +157,19 @@ func (db *DB) GetUserByUsername(username string) (*models.User, error) {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	setNullableUserFields(user, avatarURL, lastLogin, pace)
 	return user, nil
 }
 
+// setNullableUserFields copie les colonnes NULL-ables (nouveaux comptes) dans l'utilisateur
+func setNullableUserFields(user *models.User, avatarURL sql.NullString, lastLogin sql.NullTime, pace sql.NullString) {
+	user.AvatarURL = avatarURL.String
+	user.Pace = constants.Pace(pace.String)
+	if lastLogin.Valid {
+		user.LastLogin = &lastLogin.Time
+	}
+}
+
 // UpdateLastLogin met à jour la dernière connexion
 func (db *DB) UpdateLastLogin(userID int64) error {
 	query := `UPDATE users SET last_login = NOW() WHERE id = ?`
@@ -117,18 +177,35 @@ func (db *DB) UpdateLastLogin(userID int64) error {
 	return err
 }
 
+// SetPace enregistre le rythme de jeu préféré du joueur, vide pour l'effacer
+func (db *DB) SetPace(userID int64, pace constants.Pace) error {
+	query := `UPDATE users SET pace = NULLIF(?, '') WHERE id = ?`
+	_, err := db.conn.Exec(query, string(pace), userID)
+	return err
+}
+
+// AddCoins crédite (ou débite) des coins à un joueur
+func (db *DB) AddCoins(userID int64, amount int) error {
+	query := `UPDATE users SET coins = GREATEST(coins + ?, 0) WHERE id = ?`
+	_, err := db.conn.Exec(query, amount, userID)
+	return err
+}
+
 // GetPlayerStats récupère les statistiques d'un joueur
 func (db *DB) GetPlayerStats(userID int64) (*models.PlayerStats, error) {
-	query := `SELECT user_id, total_games, games_won, games_lost, tokens_captured,
-	          tokens_lost, sixes_rolled, total_dice_rolls, win_rate, 
-	          highest_streak, current_streak FROM player_stats WHERE user_id = ?`
+	query := `SELECT user_id, total_games, games_won, games_lost, games_aborted,
+	          tokens_captured, tokens_lost, sixes_rolled, total_dice_rolls, win_rate, 
+	          highest_streak, current_streak, decisions, decision_ms,
+	          rating, rating_deviation, ranked_games
+	          FROM player_stats WHERE user_id = ?`
 
 	stats := &models.PlayerStats{}
-	err := db.conn.QueryRow(query, userID).Scan(
-		&stats.UserID, &stats.TotalGames, &stats.GamesWon, &stats.GamesLost,
+	err := db.reader().QueryRow(query, userID).Scan(
+		&stats.UserID, &stats.TotalGames, &stats.GamesWon, &stats.GamesLost, &stats.GamesAborted,
 		&stats.TokensCaptured, &stats.TokensLost, &stats.SixesRolled,
 		&stats.TotalDiceRolls, &stats.WinRate, &stats.HighestStreak,
-		&stats.CurrentStreak,
+		&stats.CurrentStreak, &stats.Decisions, &stats.DecisionMs,
+		&stats.Rating, &stats.RatingDeviation, &stats.RankedGames,
 	)
 
 	if err != nil {
@@ -138,44 +215,117 @@ func (db *DB) GetPlayerStats(userID int64) (*models.PlayerStats, error) {
 	return stats, nil
 }
 
+// StatUpdate décrit la mise à jour des statistiques d'un joueur après une partie
+type StatUpdate struct {
+	UserID         int64
+	Won            bool
+	Aborted        bool // Partie abandonnée: ni victoire ni défaite
+	TokensCaptured int
+	TokensLost     int
+	SixesRolled    int
+	DiceRolls      int
+	Decisions      int   // Coups joués
+	DecisionMs     int64 // Temps de réflexion cumulé sur ces coups
+	Casual         bool  // Partie amicale: le classement (victoires, séries) ne bouge pas
+	AFK            bool  // Partie classée quittée par inactivité: défaite sans récompense
+	// Opponents sont les adversaires humains d'une partie classée, qui font
+	// bouger la cote. Vide: la cote ne change pas.
+	Opponents []rating.Opponent
+}
+
 // UpdatePlayerStats met à jour les statistiques après une partie
 func (db *DB) UpdatePlayerStats(userID int64, won bool, tokensCaptured, tokensLost int) error {
+	return db.WriteStatUpdate(StatUpdate{
+		UserID:         userID,
+		Won:            won,
+		TokensCaptured: tokensCaptured,
+		TokensLost:     tokensLost,
+	})
+}
+
+// WriteStatUpdate applique immédiatement une mise à jour de statistiques
+func (db *DB) WriteStatUpdate(update StatUpdate) error {
+	return db.WriteStatUpdates([]StatUpdate{update})
+}
+
+// WriteStatUpdates applique toutes les mises à jour du lot dans une seule transaction
+func (db *DB) WriteStatUpdates(batch []StatUpdate) error {
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	query := `UPDATE player_stats SET 
-	          total_games = total_games + 1,
-	          games_won = games_won + ?,
-	          games_lost = games_lost + ?,
-	          tokens_captured = tokens_captured + ?,
-	          tokens_lost = tokens_lost + ?,
-	          win_rate = (games_won + ?) * 100.0 / (total_games + 1),
-	          current_streak = CASE WHEN ? = 1 THEN current_streak + 1 ELSE 0 END,
-	          highest_streak = GREATEST(highest_streak, 
-	                          CASE WHEN ? = 1 THEN current_streak + 1 ELSE 0 END)
-	          WHERE user_id = ?`
-
-	wonInt := 0
-	lostInt := 0
-	if won {
-		wonInt = 1
-	} else {
-		lostInt = 1
+	for _, update := range batch {
+		if err := updatePlayerStatsTx(tx, update); err != nil {
+			return err
+		}
 	}
 
-	_, err = tx.Exec(query, wonInt, lostInt, tokensCaptured, tokensLost,
-		wonInt, wonInt, wonInt, userID)
+	return tx.Commit()
+}
+
+// updatePlayerStatsTx applique une mise à jour de statistiques dans une transaction.
+// La ligne est verrouillée (FOR UPDATE) pour que deux parties terminées en même
+// temps ne calculent pas la série de victoires à partir d'une valeur périmée.
+func updatePlayerStatsTx(tx *sql.Tx, update StatUpdate) error {
+	query := `SELECT total_games, games_won, games_lost, games_aborted,
+	          tokens_captured, tokens_lost, sixes_rolled, total_dice_rolls,
+	          highest_streak, current_streak, decisions, decision_ms,
+	          rating, rating_deviation, ranked_games
+	          FROM player_stats WHERE user_id = ? FOR UPDATE`
+
+	stats := &models.PlayerStats{UserID: update.UserID}
+	err := tx.QueryRow(query, update.UserID).Scan(
+		&stats.TotalGames, &stats.GamesWon, &stats.GamesLost, &stats.GamesAborted,
+		&stats.TokensCaptured, &stats.TokensLost, &stats.SixesRolled, &stats.TotalDiceRolls,
+		&stats.HighestStreak, &stats.CurrentStreak, &stats.Decisions, &stats.DecisionMs,
+		&stats.Rating, &stats.RatingDeviation, &stats.RankedGames,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to lock stats: %w", err)
+	}
+
+	applyGameResult(stats, update)
+
+	updateStats := `UPDATE player_stats SET 
+	                total_games = ?, games_won = ?, games_lost = ?, games_aborted = ?,
+	                tokens_captured = ?, tokens_lost = ?, sixes_rolled = ?, total_dice_rolls = ?,
+	                win_rate = ?, current_streak = ?, highest_streak = ?,
+	                decisions = ?, decision_ms = ?,
+	                rating = ?, rating_deviation = ?, ranked_games = ?,
+	                last_ranked_at = IF(?, NOW(), last_ranked_at)
+	                WHERE user_id = ?`
+
+	rated := len(update.Opponents) > 0
+	_, err = tx.Exec(updateStats, stats.TotalGames, stats.GamesWon, stats.GamesLost,
+		stats.GamesAborted, stats.TokensCaptured, stats.TokensLost, stats.SixesRolled,
+		stats.TotalDiceRolls, stats.WinRate, stats.CurrentStreak, stats.HighestStreak,
+		stats.Decisions, stats.DecisionMs,
+		stats.Rating, stats.RatingDeviation, stats.RankedGames, rated, update.UserID)
 	if err != nil {
 		return err
 	}
 
+	// Un point de l'historique des cotes par partie classée
+	if rated {
+		history := `INSERT INTO rating_history (user_id, rating, rating_deviation, reason)
+		            VALUES (?, ?, ?, ?)`
+		if _, err := tx.Exec(history, update.UserID, stats.Rating, stats.RatingDeviation, RatingReasonGame); err != nil {
+			return err
+		}
+	}
+
+	// Une partie abandonnée ou perdue par inactivité ne rapporte ni
+	// expérience ni coins
+	if update.Aborted || update.AFK {
+		return nil
+	}
+
 	// Mettre à jour l'expérience et les coins
 	expGain := 100
 	coinsGain := 50
-	if won {
+	if update.Won {
 		expGain = 500
 		coinsGain = 200
 	}
@@ -183,15 +333,61 @@ func (db *DB) UpdatePlayerStats(userID int64, won bool, tokensCaptured, tokensLo
 	updateUser := `UPDATE users SET 
 	               experience = experience + ?,
 	               coins = coins + ?,
-	               level = 1 + FLOOR((experience + ?) / 1000)
+	               level = 1 + FLOOR((experience + ?) / ?)
 	               WHERE id = ?`
 
-	_, err = tx.Exec(updateUser, expGain, coinsGain, expGain, userID)
-	if err != nil {
-		return err
+	_, err = tx.Exec(updateUser, expGain, coinsGain, expGain, constants.ExperiencePerLevel, update.UserID)
+	return err
+}
+
+// applyGameResult calcule les nouvelles statistiques après une partie
+func applyGameResult(stats *models.PlayerStats, update StatUpdate) {
+	// Les dés lancés et les coups joués comptent même si la partie est abandonnée
+	stats.SixesRolled += update.SixesRolled
+	stats.TotalDiceRolls += update.DiceRolls
+	stats.Decisions += update.Decisions
+	stats.DecisionMs += update.DecisionMs
+
+	// Un abandon ne compte ni comme partie jouée ni ne casse la série,
+	// sauf pour le joueur inactif d'une partie classée
+	if update.Aborted && !update.AFK {
+		stats.GamesAborted++
+		return
+	}
+	if update.Casual {
+		return
+	}
+	if len(update.Opponents) > 0 {
+		rateGame(stats, update.Opponents)
 	}
 
-	return tx.Commit()
+	stats.TotalGames++
+	stats.TokensCaptured += update.TokensCaptured
+	stats.TokensLost += update.TokensLost
+
+	if update.Won {
+		stats.GamesWon++
+		stats.CurrentStreak++
+		if stats.CurrentStreak > stats.HighestStreak {
+			stats.HighestStreak = stats.CurrentStreak
+		}
+	} else {
+		stats.GamesLost++
+		stats.CurrentStreak = 0
+	}
+
+	stats.WinRate = float64(stats.GamesWon) * 100.0 / float64(stats.TotalGames)
+}
+
+// SaveCrashReport enregistre un rapport de plantage client
+func (db *DB) SaveCrashReport(report *models.CrashReportPayload) error {
+	query := `INSERT INTO crash_reports 
+	          (client_version, os, arch, panic_message, stack_trace, log_lines, crashed_at) 
+	          VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := db.conn.Exec(query, report.Version, report.OS, report.Arch, report.Panic,
+		report.Stack, strings.Join(report.Logs, "\n"), report.CrashedAt)
+	return err
 }
 
 // SaveGameHistory enregistre une partie terminée
@@ -204,17 +400,18 @@ func (db *DB) SaveGameHistory(game *models.Game) error {
 
 	duration := int(time.Since(game.StartTime).Seconds())
 	var winnerID *int64
-	if game.Winner != nil {
+	if game.Winner != nil && !game.Winner.IsAI {
+		// Les IA n'ont pas de compte: seule leur place les désigne
 		winnerID = &game.Winner.ID
 	}
 
 	query := `INSERT INTO game_history 
 	          (room_id, game_mode, num_players, winner_id, duration_seconds, 
-	           started_at, ended_at) 
-	          VALUES (?, ?, ?, ?, ?, ?, NOW())`
+	           started_at, ended_at, aborted) 
+	          VALUES (?, ?, ?, ?, ?, ?, NOW(), ?)`
 
 	result, err := tx.Exec(query, game.Room.ID, game.Room.GameMode,
-		len(game.Room.Players), winnerID, duration, game.StartTime)
+		len(game.Room.Players), winnerID, duration, game.StartTime, game.Aborted)
 	if err != nil {
 		return err
 	}
@@ -224,58 +421,107 @@ func (db *DB) SaveGameHistory(game *models.Game) error {
 		return err
 	}
 
-	// Enregistrer les participants
+	// Enregistrer les participants. Les IA n'ont pas de compte mais leur
+	// place compte pour l'équilibre des couleurs et de l'ordre de jeu.
 	for i, player := range game.Room.Players {
-		if player.IsAI {
-			continue // Ne pas enregistrer les joueurs IA
+		var userID *int64
+		if !player.IsAI {
+			userID = &player.ID
 		}
-
-		finalRank := i + 1
-		isWinner := game.Winner != nil && player.ID == game.Winner.ID
+		isWinner := game.Winner != nil && player.Color == game.Winner.Color
 
 		participantQuery := `INSERT INTO game_participants 
 		                     (game_id, user_id, player_position, color, 
-		                      final_rank, tokens_at_home, is_winner) 
-		                     VALUES (?, ?, ?, ?, ?, ?, ?)`
+		                      final_rank, tokens_at_home, is_winner, turn_order, is_ai) 
+		                     VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-		_, err = tx.Exec(participantQuery, gameID, player.ID, i,
-			player.Color, finalRank, player.TokensAtHome, isWinner)
+		_, err = tx.Exec(participantQuery, gameID, userID, i,
+			player.Color, finalRank(game, player, i), player.TokensAtHome, isWinner,
+			turnOrder(i, game.FirstTurn, len(game.Room.Players)), player.IsAI)
 		if err != nil {
 			return err
 		}
 	}
 
+	// Bilans des confrontations entre joueurs humains
+	if err := saveMatchupsTx(tx, Matchups(game), time.Now()); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
-// GetLeaderboard récupère le classement
-func (db *DB) GetLeaderboard(limit int) ([]*models.User, error) {
-	query := `SELECT u.id, u.username, u.avatar_url, u.level, u.experience,
-	          ps.total_games, ps.games_won, ps.win_rate
-	          FROM users u
-	          JOIN player_stats ps ON u.id = ps.user_id
-	          ORDER BY ps.games_won DESC, ps.win_rate DESC
-	          LIMIT ?`
+// SaveSeries enregistre le score d'une série de revanches dans une salle.
+// Une série est identifiée par la salle et sa date de création.
+func (db *DB) SaveSeries(roomID string, startedAt time.Time, gamesPlayed int, wins map[int64]int, players []int64) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO room_series
+	          (room_id, series_started_at, user_id, games_played, wins)
+	          VALUES (?, ?, ?, ?, ?)
+	          ON DUPLICATE KEY UPDATE games_played = VALUES(games_played), wins = VALUES(wins)`
+
+	for _, userID := range players {
+		if _, err := tx.Exec(query, roomID, startedAt, userID, gamesPlayed, wins[userID]); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
 
-	rows, err := db.conn.Query(query, limit)
+// GetLeaderboard récupère une page du classement à partir de offset. search
+// filtre les pseudos qui commencent par ce texte; le rang retourné reste
+// celui du classement général. hasMore indique qu'une page suit.
+func (db *DB) GetLeaderboard(offset, limit int, search string) (entries []models.LeaderboardEntry, hasMore bool, err error) {
+	query := `SELECT rank_pos, id, username, avatar_url, level, total_games, games_won, win_rate, rating
+	          FROM (
+	              SELECT ROW_NUMBER() OVER (ORDER BY ps.games_won DESC, ps.win_rate DESC, u.id) AS rank_pos,
+	                     u.id, u.username, u.avatar_url, u.level,
+	                     ps.total_games, ps.games_won, ps.win_rate, ps.rating
+	              FROM users u
+	              JOIN player_stats ps ON u.id = ps.user_id
+	          ) ranked
+	          WHERE username LIKE ?
+	          ORDER BY rank_pos
+	          LIMIT ? OFFSET ?`
+
+	// Une ligne de plus que demandé pour savoir si une page suit
+	rows, err := db.reader().Query(query, likePrefix(search), limit+1, offset)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	defer rows.Close()
 
-	var users []*models.User
 	for rows.Next() {
-		user := &models.User{}
-		var totalGames, gamesWon int
-		var winRate float64
+		var entry models.LeaderboardEntry
+		var avatarURL sql.NullString
 
-		err := rows.Scan(&user.ID, &user.Username, &user.AvatarURL,
-			&user.Level, &user.Experience, &totalGames, &gamesWon, &winRate)
+		err := rows.Scan(&entry.Rank, &entry.UserID, &entry.Username, &avatarURL,
+			&entry.Level, &entry.TotalGames, &entry.GamesWon, &entry.WinRate, &entry.Rating)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		users = append(users, user)
+		entry.AvatarURL = avatarURL.String
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, err
 	}
 
-	return users, nil
+	if len(entries) > limit {
+		return entries[:limit], true, nil
+	}
+	return entries, false, nil
+}
+
+// likePrefix construit le motif LIKE des pseudos commençant par prefix, en
+// neutralisant les jokers qu'il contient
+func likePrefix(prefix string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return escaper.Replace(prefix) + "%"
 }
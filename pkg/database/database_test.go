@@ -2,7 +2,9 @@
 package database
 
 import (
+	"fmt"
 	"testing"
+	"time"
 )
 
 func TestDatabaseConnection(t *testing.T) {
@@ -40,6 +42,36 @@ func TestCreateUser(t *testing.T) {
 	t.Logf("✅ User created successfully: %s (ID: %d)", user.Username, user.ID)
 }
 
+func TestGetFreshUserWithNullColumns(t *testing.T) {
+	db, err := NewDB("localhost", "3306", "ludo_user", "LudoPass2024!", "ludo_king")
+	if err != nil {
+		t.Skip("Database not available")
+	}
+	defer db.Close()
+
+	// Un nouvel utilisateur n'a ni avatar ni dernière connexion (NULL)
+	username := fmt.Sprintf("fresh_user_%d", time.Now().UnixNano())
+	created, err := db.CreateUser(username, username+"@test.com", "hashed_password")
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	user, err := db.GetUserByID(created.ID)
+	if err != nil {
+		t.Fatalf("Failed to get fresh user by id: %v", err)
+	}
+	if user.AvatarURL != "" {
+		t.Errorf("Expected empty avatar, got %q", user.AvatarURL)
+	}
+	if user.LastLogin != nil {
+		t.Errorf("Expected nil last login, got %v", user.LastLogin)
+	}
+
+	if _, err := db.GetUserByUsername(username); err != nil {
+		t.Fatalf("Failed to get fresh user by username: %v", err)
+	}
+}
+
 func randomString(n int) string {
 	const letters = "abcdefghijklmnopqrstuvwxyz"
 	b := make([]byte, n)
@@ -0,0 +1,784 @@
+// pkg/database/memory.go
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// memoryCrashReports borne le nombre de rapports de plantage gardés en mémoire
+const memoryCrashReports = 100
+
+// MemoryStore garde toutes les données en mémoire. Il permet de lancer le
+// serveur sans MySQL, pour le développement ou une petite installation;
+// tout est perdu à l'arrêt du serveur.
+type MemoryStore struct {
+	mu        sync.Mutex
+	nextID    int64
+	users     map[int64]*models.User
+	usernames map[string]int64 // Pseudo en minuscules -> id, comme la collation MySQL
+	emails    map[string]int64
+	stats     map[int64]*models.PlayerStats
+	rivalries map[[2]int64]*rivalryRecord
+	games     []playedGame
+	series    map[seriesKey]seriesScore
+	crashes   []models.CrashReportPayload
+
+	lastRanked    map[int64]time.Time // Dernière partie classée de chaque joueur
+	seasons       map[int]time.Time   // Saisons closes et leur date de clôture
+	seasonStats   map[int][]models.PlayerStats
+	ratingHistory map[int64][]ratingEntry
+}
+
+// ratingEntry est une entrée de l'historique des cotes d'un joueur
+type ratingEntry struct {
+	rating, deviation float64
+	reason            string
+	season            int
+	at                time.Time
+}
+
+// rivalryRecord est le bilan d'une paire de joueurs, rangée par ids croissants
+type rivalryRecord struct {
+	winsLow, winsHigh int
+	games             int
+	lastPlayed        time.Time
+}
+
+// playedGame garde d'une partie gagnée ce qu'il faut au rapport d'équilibre
+type playedGame struct {
+	roomID    string
+	startedAt time.Time
+	seats     []SeatTally
+}
+
+// seriesKey identifie le score d'un joueur dans une série de revanches
+type seriesKey struct {
+	roomID    string
+	startedAt time.Time
+	userID    int64
+}
+
+// seriesScore est le score d'un joueur dans une série
+type seriesScore struct {
+	gamesPlayed int
+	wins        int
+}
+
+// NewMemoryStore crée un stockage en mémoire vide
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users:     make(map[int64]*models.User),
+		usernames: make(map[string]int64),
+		emails:    make(map[string]int64),
+		stats:     make(map[int64]*models.PlayerStats),
+		rivalries: make(map[[2]int64]*rivalryRecord),
+		series:    make(map[seriesKey]seriesScore),
+
+		lastRanked:    make(map[int64]time.Time),
+		seasons:       make(map[int]time.Time),
+		seasonStats:   make(map[int][]models.PlayerStats),
+		ratingHistory: make(map[int64][]ratingEntry),
+	}
+}
+
+// Close ne fait rien: il n'y a pas de connexion à fermer
+func (m *MemoryStore) Close() error {
+	return nil
+}
+
+// CreateUser crée un nouvel utilisateur et ses statistiques
+func (m *MemoryStore) CreateUser(username, email, passwordHash string) (*models.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, taken := m.usernames[strings.ToLower(username)]; taken {
+		return nil, fmt.Errorf("failed to create user: username %q is taken", username)
+	}
+	if _, taken := m.emails[strings.ToLower(email)]; taken {
+		return nil, fmt.Errorf("failed to create user: email %q is taken", email)
+	}
+
+	m.nextID++
+	user := &models.User{
+		ID:           m.nextID,
+		Username:     username,
+		Email:        email,
+		PasswordHash: passwordHash,
+		Level:        1,
+		Coins:        1000,
+		CreatedAt:    time.Now(),
+	}
+	m.users[user.ID] = user
+	m.usernames[strings.ToLower(username)] = user.ID
+	m.emails[strings.ToLower(email)] = user.ID
+	m.stats[user.ID] = &models.PlayerStats{
+		UserID:          user.ID,
+		Rating:          constants.RatingInitial,
+		RatingDeviation: constants.RatingInitialDeviation,
+	}
+
+	return m.userCopy(user, false), nil
+}
+
+// GetUserByID récupère un utilisateur par son ID
+func (m *MemoryStore) GetUserByID(id int64) (*models.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return nil, fmt.Errorf("user not found")
+	}
+	return m.userCopy(user, false), nil
+}
+
+// GetUserByUsername récupère un utilisateur par son username
+func (m *MemoryStore) GetUserByUsername(username string) (*models.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id, ok := m.usernames[strings.ToLower(username)]
+	if !ok {
+		return nil, fmt.Errorf("user not found")
+	}
+	return m.userCopy(m.users[id], true), nil
+}
+
+// userCopy retourne une copie de l'utilisateur, que l'appelant peut
+// modifier sans toucher au stockage. Comme avec MySQL, seule la recherche
+// par pseudo lit le hash du mot de passe.
+func (m *MemoryStore) userCopy(user *models.User, withPassword bool) *models.User {
+	copied := *user
+	if user.LastLogin != nil {
+		lastLogin := *user.LastLogin
+		copied.LastLogin = &lastLogin
+	}
+	if !withPassword {
+		copied.PasswordHash = ""
+	}
+	return &copied
+}
+
+// UpdateLastLogin met à jour la date de dernière connexion
+func (m *MemoryStore) UpdateLastLogin(userID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if user, ok := m.users[userID]; ok {
+		now := time.Now()
+		user.LastLogin = &now
+	}
+	return nil
+}
+
+// SetPace enregistre le rythme de jeu déclaré par le joueur
+func (m *MemoryStore) SetPace(userID int64, pace constants.Pace) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if user, ok := m.users[userID]; ok {
+		user.Pace = pace
+	}
+	return nil
+}
+
+// AddCoins crédite (ou débite) des coins à un joueur, sans descendre sous zéro
+func (m *MemoryStore) AddCoins(userID int64, amount int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if user, ok := m.users[userID]; ok {
+		user.Coins = max(user.Coins+amount, 0)
+	}
+	return nil
+}
+
+// GetPlayerStats récupère les statistiques d'un joueur
+func (m *MemoryStore) GetPlayerStats(userID int64) (*models.PlayerStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.stats[userID]
+	if !ok {
+		return nil, fmt.Errorf("failed to get stats: no stats for user %d", userID)
+	}
+	copied := *stats
+	return &copied, nil
+}
+
+// WriteStatUpdate applique immédiatement une mise à jour de statistiques
+func (m *MemoryStore) WriteStatUpdate(update StatUpdate) error {
+	return m.WriteStatUpdates([]StatUpdate{update})
+}
+
+// WriteStatUpdates applique toutes les mises à jour du lot. Comme la
+// transaction MySQL, le lot est refusé en entier si un joueur est inconnu.
+func (m *MemoryStore) WriteStatUpdates(batch []StatUpdate) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, update := range batch {
+		if _, ok := m.stats[update.UserID]; !ok {
+			return fmt.Errorf("failed to lock stats: no stats for user %d", update.UserID)
+		}
+	}
+
+	now := time.Now()
+	for _, update := range batch {
+		applyGameResult(m.stats[update.UserID], update)
+		if len(update.Opponents) > 0 {
+			stats := m.stats[update.UserID]
+			m.lastRanked[update.UserID] = now
+			m.ratingHistory[update.UserID] = append(m.ratingHistory[update.UserID], ratingEntry{
+				rating:    stats.Rating,
+				deviation: stats.RatingDeviation,
+				reason:    RatingReasonGame,
+				at:        now,
+			})
+		}
+
+		// Une partie abandonnée ou perdue par inactivité ne rapporte ni
+		// expérience ni coins
+		user, ok := m.users[update.UserID]
+		if !ok || update.Aborted || update.AFK {
+			continue
+		}
+		expGain, coinsGain := 100, 50
+		if update.Won {
+			expGain, coinsGain = 500, 200
+		}
+		user.Experience += expGain
+		user.Coins += coinsGain
+		user.Level = 1 + user.Experience/constants.ExperiencePerLevel
+	}
+	return nil
+}
+
+// GetLeaderboard récupère une page du classement à partir de offset. search
+// filtre les pseudos qui commencent par ce texte; le rang retourné reste
+// celui du classement général. hasMore indique qu'une page suit.
+func (m *MemoryStore) GetLeaderboard(offset, limit int, search string) (entries []models.LeaderboardEntry, hasMore bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ranked := make([]*models.PlayerStats, 0, len(m.stats))
+	for _, stats := range m.stats {
+		ranked = append(ranked, stats)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.GamesWon != b.GamesWon {
+			return a.GamesWon > b.GamesWon
+		}
+		if a.WinRate != b.WinRate {
+			return a.WinRate > b.WinRate
+		}
+		return a.UserID < b.UserID
+	})
+
+	prefix := strings.ToLower(search)
+	skipped := 0
+	for i, stats := range ranked {
+		user := m.users[stats.UserID]
+		if !strings.HasPrefix(strings.ToLower(user.Username), prefix) {
+			continue
+		}
+		if skipped < offset {
+			skipped++
+			continue
+		}
+		if len(entries) == limit {
+			return entries, true, nil
+		}
+		entries = append(entries, models.LeaderboardEntry{
+			Rank:       i + 1,
+			UserID:     user.ID,
+			Username:   user.Username,
+			AvatarURL:  user.AvatarURL,
+			Level:      user.Level,
+			TotalGames: stats.TotalGames,
+			GamesWon:   stats.GamesWon,
+			WinRate:    stats.WinRate,
+			Rating:     stats.Rating,
+		})
+	}
+	return entries, false, nil
+}
+
+// GetRivalries retourne le bilan d'un joueur contre chacun des adversaires
+// donnés qu'il a déjà affrontés
+func (m *MemoryStore) GetRivalries(userID int64, opponents []int64) ([]models.Rivalry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var rivalries []models.Rivalry
+	for _, opponent := range opponents {
+		key := rivalryKey(userID, opponent)
+		if record, ok := m.rivalries[key]; ok {
+			if rivalry, ok := m.rivalry(userID, key, record); ok {
+				rivalries = append(rivalries, rivalry)
+			}
+		}
+	}
+	return rivalries, nil
+}
+
+// GetTopRivals retourne les adversaires les plus affrontés d'un joueur, les
+// plus récents d'abord à nombre de parties égal
+func (m *MemoryStore) GetTopRivals(userID int64, limit int) ([]models.Rivalry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var rivalries []models.Rivalry
+	for key, record := range m.rivalries {
+		if key[0] != userID && key[1] != userID {
+			continue
+		}
+		if rivalry, ok := m.rivalry(userID, key, record); ok {
+			rivalries = append(rivalries, rivalry)
+		}
+	}
+	sort.Slice(rivalries, func(i, j int) bool {
+		if rivalries[i].Games != rivalries[j].Games {
+			return rivalries[i].Games > rivalries[j].Games
+		}
+		return rivalries[i].LastPlayed.After(rivalries[j].LastPlayed)
+	})
+	if len(rivalries) > limit {
+		rivalries = rivalries[:limit]
+	}
+	return rivalries, nil
+}
+
+// rivalryKey range une paire de joueurs par ids croissants
+func rivalryKey(a, b int64) [2]int64 {
+	if a < b {
+		return [2]int64{a, b}
+	}
+	return [2]int64{b, a}
+}
+
+// rivalry présente un bilan du point de vue du joueur. ok est faux si
+// l'adversaire n'a plus de compte.
+func (m *MemoryStore) rivalry(userID int64, key [2]int64, record *rivalryRecord) (models.Rivalry, bool) {
+	rivalry := models.Rivalry{
+		OpponentID: key[0],
+		Wins:       record.winsHigh,
+		Losses:     record.winsLow,
+		Games:      record.games,
+		LastPlayed: record.lastPlayed,
+	}
+	if key[0] == userID {
+		rivalry.OpponentID = key[1]
+		rivalry.Wins, rivalry.Losses = record.winsLow, record.winsHigh
+	}
+	opponent, ok := m.users[rivalry.OpponentID]
+	if !ok {
+		return models.Rivalry{}, false
+	}
+	rivalry.OpponentName = opponent.Username
+	return rivalry, true
+}
+
+// SaveCrashReport garde un rapport de plantage client, les plus anciens
+// étant oubliés au-delà de memoryCrashReports
+func (m *MemoryStore) SaveCrashReport(report *models.CrashReportPayload) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.crashes = append(m.crashes, *report)
+	if len(m.crashes) > memoryCrashReports {
+		m.crashes = m.crashes[len(m.crashes)-memoryCrashReports:]
+	}
+	return nil
+}
+
+// SaveGameHistory enregistre les rivalités d'une partie terminée et, si
+// elle a un vainqueur, ses sièges pour le rapport d'équilibre
+func (m *MemoryStore) SaveGameHistory(game *models.Game) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for _, matchup := range Matchups(game) {
+		key := [2]int64{matchup.Low, matchup.High}
+		record, ok := m.rivalries[key]
+		if !ok {
+			record = &rivalryRecord{}
+			m.rivalries[key] = record
+		}
+		record.winsLow += boolInt(matchup.LowWon)
+		record.winsHigh += boolInt(matchup.HighWon)
+		record.games++
+		record.lastPlayed = now
+	}
+
+	// Abandons et nuls ne disent rien de l'avantage d'un siège
+	if game.Aborted || game.Winner == nil {
+		return nil
+	}
+	players := len(game.Room.Players)
+	share := 1.0 / float64(players)
+	played := playedGame{roomID: game.Room.ID, startedAt: game.StartTime}
+	for i, player := range game.Room.Players {
+		played.seats = append(played.seats, SeatTally{
+			Color:        string(player.Color),
+			TurnOrder:    turnOrder(i, game.FirstTurn, players),
+			Games:        1,
+			Wins:         boolInt(player.Color == game.Winner.Color),
+			ExpectedWins: share,
+			Variance:     share * (1 - share),
+		})
+	}
+	m.games = append(m.games, played)
+	return nil
+}
+
+// SaveSeries enregistre le score d'une série de revanches dans une salle
+func (m *MemoryStore) SaveSeries(roomID string, startedAt time.Time, gamesPlayed int, wins map[int64]int, players []int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, userID := range players {
+		m.series[seriesKey{roomID: roomID, startedAt: startedAt, userID: userID}] = seriesScore{gamesPlayed: gamesPlayed, wins: wins[userID]}
+	}
+	return nil
+}
+
+// GetBalanceReport calcule l'équilibre des sièges sur les parties gagnées,
+// depuis since si non nil
+func (m *MemoryStore) GetBalanceReport(since *time.Time) (*BalanceReport, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var tallies []SeatTally
+	games := 0
+	for _, played := range m.games {
+		if since != nil && played.startedAt.Before(*since) {
+			continue
+		}
+		games++
+		tallies = append(tallies, played.seats...)
+	}
+
+	report := BuildBalanceReport(tallies)
+	report.Games = games
+	report.Since = since
+	return report, nil
+}
+
+// EndSeason clôt une saison: archive les statistiques des joueurs classés,
+// puis érode la cote des joueurs inactifs
+func (m *MemoryStore) EndSeason(season int, decay SeasonDecay) (decayed int, closed bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ended := m.seasons[season]; ended {
+		return 0, false, nil
+	}
+	now := time.Now()
+	m.seasons[season] = now
+
+	for userID, stats := range m.stats {
+		if stats.RankedGames == 0 {
+			continue
+		}
+		m.seasonStats[season] = append(m.seasonStats[season], *stats)
+
+		if last, ok := m.lastRanked[userID]; ok && !last.Before(decay.InactiveSince) {
+			continue
+		}
+		stats.Rating, stats.RatingDeviation = decayRating(stats.Rating, stats.RatingDeviation, decay)
+		m.ratingHistory[userID] = append(m.ratingHistory[userID], ratingEntry{
+			rating:    stats.Rating,
+			deviation: stats.RatingDeviation,
+			reason:    RatingReasonDecay,
+			season:    season,
+			at:        now,
+		})
+		decayed++
+	}
+	return decayed, true, nil
+}
+
+// GetRatingHistory retourne les limit dernières cotes d'un joueur, des plus
+// anciennes aux plus récentes
+func (m *MemoryStore) GetRatingHistory(userID int64, limit int) ([]models.RatingPoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := m.ratingHistory[userID]
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	points := make([]models.RatingPoint, len(entries))
+	for i, entry := range entries {
+		points[i] = models.RatingPoint{
+			Rating:    entry.rating,
+			Deviation: entry.deviation,
+			Reason:    entry.reason,
+			Season:    entry.season,
+			At:        entry.at,
+		}
+	}
+	return points, nil
+}
+
+// ExportArchive exporte tous les joueurs avec leur historique
+func (m *MemoryStore) ExportArchive() (*Archive, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	archive := &Archive{Version: ArchiveVersion, ExportedAt: time.Now()}
+
+	ids := make([]int64, 0, len(m.users))
+	for id := range m.users {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	seasonStats := make(map[int64][]SeasonStats)
+	for season, snapshot := range m.seasonStats {
+		for _, stats := range snapshot {
+			seasonStats[stats.UserID] = append(seasonStats[stats.UserID], SeasonStats{
+				Season:          season,
+				Rating:          stats.Rating,
+				RatingDeviation: stats.RatingDeviation,
+				RankedGames:     stats.RankedGames,
+				TotalGames:      stats.TotalGames,
+				GamesWon:        stats.GamesWon,
+			})
+		}
+	}
+
+	for _, id := range ids {
+		user := m.users[id]
+		entry := ArchivedUser{
+			User:         *m.userCopy(user, false),
+			PasswordHash: user.PasswordHash,
+			Stats:        *m.stats[id],
+			SeasonStats:  seasonStats[id],
+		}
+		sort.Slice(entry.SeasonStats, func(i, j int) bool { return entry.SeasonStats[i].Season < entry.SeasonStats[j].Season })
+		if last, ok := m.lastRanked[id]; ok {
+			entry.LastRankedAt = &last
+		}
+		for _, e := range m.ratingHistory[id] {
+			entry.RatingHistory = append(entry.RatingHistory, models.RatingPoint{
+				Rating:    e.rating,
+				Deviation: e.deviation,
+				Reason:    e.reason,
+				Season:    e.season,
+				At:        e.at,
+			})
+		}
+		archive.Users = append(archive.Users, entry)
+	}
+
+	for key, record := range m.rivalries {
+		archive.Rivalries = append(archive.Rivalries, ArchivedRivalry{
+			UserLow:    key[0],
+			UserHigh:   key[1],
+			WinsLow:    record.winsLow,
+			WinsHigh:   record.winsHigh,
+			Games:      record.games,
+			LastPlayed: record.lastPlayed,
+		})
+	}
+	sort.Slice(archive.Rivalries, func(i, j int) bool {
+		a, b := archive.Rivalries[i], archive.Rivalries[j]
+		return a.UserLow < b.UserLow || a.UserLow == b.UserLow && a.UserHigh < b.UserHigh
+	})
+
+	for key, score := range m.series {
+		archive.Series = append(archive.Series, ArchivedSeries{
+			RoomID:      key.roomID,
+			StartedAt:   key.startedAt,
+			UserID:      key.userID,
+			GamesPlayed: score.gamesPlayed,
+			Wins:        score.wins,
+		})
+	}
+	sort.Slice(archive.Series, func(i, j int) bool {
+		a, b := archive.Series[i], archive.Series[j]
+		if !a.StartedAt.Equal(b.StartedAt) {
+			return a.StartedAt.Before(b.StartedAt)
+		}
+		return a.RoomID < b.RoomID || a.RoomID == b.RoomID && a.UserID < b.UserID
+	})
+
+	// Seuls les sièges des parties gagnées sont gardés en mémoire
+	for _, played := range m.games {
+		game := ArchivedGame{RoomID: played.roomID, StartedAt: played.startedAt}
+		for i, seat := range played.seats {
+			order := seat.TurnOrder
+			game.Participants = append(game.Participants, ArchivedParticipant{
+				Position:  i,
+				Color:     seat.Color,
+				IsWinner:  seat.Wins > 0,
+				TurnOrder: &order,
+			})
+		}
+		archive.Games = append(archive.Games, game)
+	}
+
+	for season, endedAt := range m.seasons {
+		archive.Seasons = append(archive.Seasons, ArchivedSeason{Season: season, EndedAt: endedAt})
+	}
+	sort.Slice(archive.Seasons, func(i, j int) bool { return archive.Seasons[i].Season < archive.Seasons[j].Season })
+
+	return archive, nil
+}
+
+// hasGame indique si une partie de la salle commencée à startedAt est déjà
+// enregistrée. L'appelant détient m.mu.
+func (m *MemoryStore) hasGame(roomID string, startedAt time.Time) bool {
+	for _, played := range m.games {
+		if played.roomID == roomID && played.startedAt.Equal(startedAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportPlayer exporte les données d'un joueur, sans le hash de son mot de passe
+func (m *MemoryStore) ExportPlayer(userID int64) (*Archive, error) {
+	archive, err := m.ExportArchive()
+	if err != nil {
+		return nil, err
+	}
+	player := playerArchive(archive, userID)
+	if len(player.Users) == 0 {
+		return nil, fmt.Errorf("user not found")
+	}
+	return player, nil
+}
+
+// ImportArchive ajoute les joueurs d'une archive sous de nouveaux ids. Un
+// compte dont le pseudo ou l'e-mail est déjà pris est ignoré, comme une
+// partie déjà présente.
+func (m *MemoryStore) ImportArchive(archive *Archive) (*ImportReport, error) {
+	if err := checkArchive(archive); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	report := &ImportReport{IDs: make(map[int64]int64)}
+	for _, entry := range archive.Users {
+		_, nameTaken := m.usernames[strings.ToLower(entry.User.Username)]
+		_, emailTaken := m.emails[strings.ToLower(entry.User.Email)]
+		if nameTaken || emailTaken {
+			report.Skipped = append(report.Skipped, entry.User.Username)
+			continue
+		}
+
+		m.nextID++
+		user := entry.User
+		user.ID = m.nextID
+		user.PasswordHash = entry.PasswordHash
+		if user.LastLogin != nil {
+			lastLogin := *user.LastLogin
+			user.LastLogin = &lastLogin
+		}
+		m.users[user.ID] = &user
+		m.usernames[strings.ToLower(user.Username)] = user.ID
+		m.emails[strings.ToLower(user.Email)] = user.ID
+
+		stats := entry.Stats
+		stats.UserID = user.ID
+		stats.Rivals = nil
+		m.stats[user.ID] = &stats
+		if entry.LastRankedAt != nil {
+			m.lastRanked[user.ID] = *entry.LastRankedAt
+		}
+		for _, point := range entry.RatingHistory {
+			m.ratingHistory[user.ID] = append(m.ratingHistory[user.ID], ratingEntry{
+				rating:    point.Rating,
+				deviation: point.Deviation,
+				reason:    point.Reason,
+				season:    point.Season,
+				at:        point.At,
+			})
+		}
+		for _, s := range entry.SeasonStats {
+			m.seasonStats[s.Season] = append(m.seasonStats[s.Season], models.PlayerStats{
+				UserID:          user.ID,
+				Rating:          s.Rating,
+				RatingDeviation: s.RatingDeviation,
+				RankedGames:     s.RankedGames,
+				TotalGames:      s.TotalGames,
+				GamesWon:        s.GamesWon,
+			})
+		}
+
+		report.IDs[entry.User.ID] = user.ID
+		report.Users++
+	}
+
+	for _, r := range archive.Rivalries {
+		if r, ok := r.remapped(report.IDs); ok {
+			m.rivalries[[2]int64{r.UserLow, r.UserHigh}] = &rivalryRecord{
+				winsLow:    r.WinsLow,
+				winsHigh:   r.WinsHigh,
+				games:      r.Games,
+				lastPlayed: r.LastPlayed,
+			}
+		}
+	}
+
+	for _, s := range archive.Series {
+		if userID, ok := report.IDs[s.UserID]; ok {
+			m.series[seriesKey{roomID: s.RoomID, startedAt: s.StartedAt, userID: userID}] = seriesScore{gamesPlayed: s.GamesPlayed, wins: s.Wins}
+		}
+	}
+
+	// Comme SaveGameHistory, seules les parties gagnées comptent pour
+	// l'équilibre des sièges
+	for _, game := range archive.Games {
+		if m.hasGame(game.RoomID, game.StartedAt) {
+			report.SkippedGames++
+			continue
+		}
+		report.Games++
+		if game.Aborted || len(game.Participants) == 0 {
+			continue
+		}
+		share := 1.0 / float64(len(game.Participants))
+		played := playedGame{roomID: game.RoomID, startedAt: game.StartedAt}
+		won := false
+		for _, p := range game.Participants {
+			order := p.Position
+			if p.TurnOrder != nil {
+				order = *p.TurnOrder
+			}
+			won = won || p.IsWinner
+			played.seats = append(played.seats, SeatTally{
+				Color:        p.Color,
+				TurnOrder:    order,
+				Games:        1,
+				Wins:         boolInt(p.IsWinner),
+				ExpectedWins: share,
+				Variance:     share * (1 - share),
+			})
+		}
+		if won {
+			m.games = append(m.games, played)
+		}
+	}
+
+	for _, s := range archive.Seasons {
+		if _, ended := m.seasons[s.Season]; !ended {
+			m.seasons[s.Season] = s.EndedAt
+		}
+	}
+	return report, nil
+}
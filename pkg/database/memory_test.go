@@ -0,0 +1,98 @@
+// pkg/database/memory_test.go
+package database
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestMemoryStoreUsers(t *testing.T) {
+	store := NewMemoryStore()
+
+	user, err := store.CreateUser("Alice", "alice@example.com", "hash")
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	if user.Level != 1 || user.Coins != 1000 || user.PasswordHash != "" {
+		t.Errorf("Unexpected new user: %+v", user)
+	}
+	if _, err := store.CreateUser("alice", "other@example.com", "hash"); err == nil {
+		t.Error("Expected a username differing only by case to be refused")
+	}
+
+	found, err := store.GetUserByUsername("ALICE")
+	if err != nil || found.ID != user.ID || found.PasswordHash != "hash" {
+		t.Errorf("Expected lookup by username to return the hash, got %+v (%v)", found, err)
+	}
+
+	store.AddCoins(user.ID, -5000)
+	if found, _ := store.GetUserByID(user.ID); found.Coins != 0 {
+		t.Errorf("Expected coins to stop at 0, got %d", found.Coins)
+	}
+}
+
+func TestMemoryStoreStatsAndLeaderboard(t *testing.T) {
+	store := NewMemoryStore()
+	alice, _ := store.CreateUser("alice", "a@example.com", "")
+	bob, _ := store.CreateUser("bob", "b@example.com", "")
+	store.CreateUser("carol", "c@example.com", "")
+
+	err := store.WriteStatUpdates([]StatUpdate{
+		{UserID: bob.ID, Won: true},
+		{UserID: alice.ID},
+	})
+	if err != nil {
+		t.Fatalf("WriteStatUpdates failed: %v", err)
+	}
+	if err := store.WriteStatUpdates([]StatUpdate{{UserID: alice.ID}, {UserID: 999}}); err == nil {
+		t.Error("Expected a batch with an unknown player to fail")
+	}
+	if stats, _ := store.GetPlayerStats(alice.ID); stats.TotalGames != 1 {
+		t.Errorf("Expected the failed batch to leave alice untouched, got %d games", stats.TotalGames)
+	}
+	if user, _ := store.GetUserByID(bob.ID); user.Experience != 500 || user.Coins != 1200 {
+		t.Errorf("Expected the winner's rewards, got %d xp and %d coins", user.Experience, user.Coins)
+	}
+
+	entries, hasMore, _ := store.GetLeaderboard(0, 2, "")
+	if len(entries) != 2 || !hasMore || entries[0].Username != "bob" || entries[1].Username != "alice" {
+		t.Errorf("Unexpected first page: %+v (more: %v)", entries, hasMore)
+	}
+
+	entries, hasMore, _ = store.GetLeaderboard(0, 10, "CA")
+	if len(entries) != 1 || hasMore || entries[0].Username != "carol" || entries[0].Rank != 3 {
+		t.Errorf("Expected carol at her overall rank, got %+v", entries)
+	}
+}
+
+func TestMemoryStoreRivalries(t *testing.T) {
+	store := NewMemoryStore()
+	alice, _ := store.CreateUser("alice", "a@example.com", "")
+	bob, _ := store.CreateUser("bob", "b@example.com", "")
+
+	red := models.NewPlayer(alice.ID, "alice", constants.ColorRed)
+	green := models.NewPlayer(bob.ID, "bob", constants.ColorGreen)
+	game := &models.Game{
+		Room:     &models.Room{Players: []*models.Player{red, green}},
+		Winner:   green,
+		Rankings: []*models.Player{green, red},
+	}
+	store.SaveGameHistory(game)
+	store.SaveGameHistory(game)
+
+	rivals, _ := store.GetTopRivals(alice.ID, 5)
+	if len(rivals) != 1 || rivals[0].OpponentName != "bob" || rivals[0].Wins != 0 || rivals[0].Losses != 2 {
+		t.Errorf("Unexpected rivals for alice: %+v", rivals)
+	}
+	rivals, _ = store.GetRivalries(bob.ID, []int64{alice.ID})
+	if len(rivals) != 1 || rivals[0].Wins != 2 || rivals[0].Games != 2 {
+		t.Errorf("Unexpected rivalry for bob: %+v", rivals)
+	}
+
+	report, _ := store.GetBalanceReport(nil)
+	if report.Games != 2 {
+		t.Errorf("Expected 2 decided games in the balance report, got %d", report.Games)
+	}
+}
@@ -0,0 +1,238 @@
+// pkg/database/migrate.go
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// migrationFiles contient les scripts du schéma, numérotés 001_nom.sql,
+// 002_nom.sql... Un script déjà appliqué ne doit plus être modifié: tout
+// changement passe par un nouveau fichier.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLock est le verrou MySQL qui empêche deux instances de migrer en même temps
+const migrationLock = "ludo_king_migrations"
+
+// migrationLockWait est le délai d'attente du verrou, en secondes
+const migrationLockWait = 60
+
+// Erreurs MySQL d'un changement déjà présent dans un schéma créé à la main,
+// et d'une clé primaire déjà prise
+const (
+	errTableExists     = 1050
+	errDuplicateColumn = 1060
+	errDuplicateKey    = 1061
+	errDuplicateEntry  = 1062
+)
+
+// migration est un script du schéma découpé en requêtes
+type migration struct {
+	version    int
+	name       string
+	statements []string
+}
+
+// loadMigrations lit les scripts de fsys, triés par version
+func loadMigrations(fsys fs.FS) ([]migration, error) {
+	files, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []migration
+	seen := make(map[int]string)
+	for _, file := range files {
+		name := strings.TrimSuffix(path.Base(file), ".sql")
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: name must start with a version number", file)
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, name, version)
+		}
+		seen[version] = name
+
+		script, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, migration{
+			version:    version,
+			name:       name,
+			statements: splitStatements(string(script)),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+	return migrations, nil
+}
+
+// splitStatements découpe un script en requêtes, le pilote MySQL n'en
+// exécutant qu'une par appel. Les lignes de commentaire sont ignorées; les
+// scripts ne mettent pas de point-virgule dans leurs chaînes.
+func splitStatements(script string) []string {
+	var lines []string
+	for _, line := range strings.Split(script, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			lines = append(lines, line)
+		}
+	}
+
+	var statements []string
+	for _, statement := range strings.Split(strings.Join(lines, "\n"), ";") {
+		if statement = strings.TrimSpace(statement); statement != "" {
+			statements = append(statements, statement)
+		}
+	}
+	return statements
+}
+
+// migrate applique les scripts qui manquent à la base, dans l'ordre, et
+// note chacun dans schema_version. Une base créée à la main avant ce
+// mécanisme est reprise: le schéma initial est tenu pour appliqué et les
+// changements déjà présents sont passés.
+func migrate(conn *sql.DB) error {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		return err
+	}
+
+	// Le verrou MySQL appartient à une connexion: toutes les requêtes
+	// passent par la même
+	ctx := context.Background()
+	c, err := conn.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	var locked sql.NullInt64
+	if err := c.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", migrationLock, migrationLockWait).Scan(&locked); err != nil {
+		return err
+	}
+	if locked.Int64 != 1 {
+		return fmt.Errorf("another server is still migrating the database")
+	}
+	defer c.ExecContext(ctx, "DO RELEASE_LOCK(?)", migrationLock)
+
+	legacy, err := isLegacySchema(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_version (
+	          version INT PRIMARY KEY,
+	          name VARCHAR(255) NOT NULL,
+	          applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	      ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_version: %w", err)
+	}
+
+	applied, err := appliedVersions(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	for i, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+		if legacy && i == 0 {
+			log.Printf("📦 Existing schema found, recording %s as applied", m.name)
+		} else if err := applyMigration(ctx, c, m, legacy); err != nil {
+			return err
+		} else {
+			log.Printf("📦 Applied migration %s", m.name)
+		}
+
+		if _, err := c.ExecContext(ctx, `INSERT INTO schema_version (version, name) VALUES (?, ?)`,
+			m.version, m.name); err != nil {
+			return fmt.Errorf("failed to record migration %s: %w", m.name, err)
+		}
+	}
+	return nil
+}
+
+// isLegacySchema indique une base créée à la main: des tables de jeu
+// existent mais pas encore schema_version
+func isLegacySchema(ctx context.Context, c *sql.Conn) (bool, error) {
+	query := `SELECT COUNT(*) FROM information_schema.tables
+	          WHERE table_schema = DATABASE() AND table_name = ?`
+
+	var versions, users int
+	if err := c.QueryRowContext(ctx, query, "schema_version").Scan(&versions); err != nil {
+		return false, err
+	}
+	if err := c.QueryRowContext(ctx, query, "users").Scan(&users); err != nil {
+		return false, err
+	}
+	return versions == 0 && users > 0, nil
+}
+
+// appliedVersions retourne les versions notées dans schema_version
+func appliedVersions(ctx context.Context, c *sql.Conn) (map[int]bool, error) {
+	rows, err := c.QueryContext(ctx, `SELECT version FROM schema_version`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// applyMigration exécute les requêtes d'un script. Sur une base reprise,
+// une table, colonne ou index déjà présent n'est pas une erreur.
+func applyMigration(ctx context.Context, c *sql.Conn, m migration, legacy bool) error {
+	for _, statement := range m.statements {
+		if _, err := c.ExecContext(ctx, statement); err != nil {
+			if legacy && alreadyApplied(err) {
+				continue
+			}
+			return fmt.Errorf("migration %s: %w", m.name, err)
+		}
+	}
+	return nil
+}
+
+// alreadyApplied reconnaît l'erreur d'un changement déjà fait à la main
+func alreadyApplied(err error) bool {
+	return isMySQLError(err, errTableExists, errDuplicateColumn, errDuplicateKey)
+}
+
+// isMySQLError indique si err est l'une des erreurs MySQL numbers
+func isMySQLError(err error, numbers ...uint16) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	for _, number := range numbers {
+		if mysqlErr.Number == number {
+			return true
+		}
+	}
+	return false
+}
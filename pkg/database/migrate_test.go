@@ -0,0 +1,54 @@
+// pkg/database/migrate_test.go
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestEmbeddedMigrationsAreOrdered(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		t.Fatalf("loadMigrations failed: %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].name != "001_initial_schema" {
+		t.Fatalf("Expected the initial schema first, got %+v", migrations)
+	}
+	for i, m := range migrations {
+		if m.version != i+1 {
+			t.Errorf("Expected version %d at position %d, got %d (%s)", i+1, i, m.version, m.name)
+		}
+		if len(m.statements) == 0 {
+			t.Errorf("Migration %s has no statement", m.name)
+		}
+	}
+}
+
+func TestLoadMigrationsRejectsBadNames(t *testing.T) {
+	for name, files := range map[string]fstest.MapFS{
+		"no version": {"migrations/schema.sql": {Data: []byte("SELECT 1;")}},
+		"duplicate": {
+			"migrations/002_a.sql": {Data: []byte("SELECT 1;")},
+			"migrations/002_b.sql": {Data: []byte("SELECT 2;")},
+		},
+	} {
+		if _, err := loadMigrations(files); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- header; with a semicolon
+
+CREATE TABLE t (
+    id INT
+);
+-- comment
+INSERT INTO t VALUES (1);
+`
+	got := splitStatements(script)
+	if len(got) != 2 || got[1] != "INSERT INTO t VALUES (1)" {
+		t.Errorf("Unexpected statements: %q", got)
+	}
+}
@@ -1,7 +1,4 @@
--- migrations/001_initial_schema.sql
-CREATE DATABASE IF NOT EXISTS ludo_king CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
-
-USE ludo_king;
+-- pkg/database/migrations/001_initial_schema.sql
 
 -- Table des utilisateurs
 CREATE TABLE users (
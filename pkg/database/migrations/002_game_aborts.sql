@@ -0,0 +1,5 @@
+-- pkg/database/migrations/002_game_aborts.sql
+
+-- Parties abandonnées d'un commun accord (ni victoire ni défaite)
+ALTER TABLE player_stats ADD COLUMN games_aborted INT DEFAULT 0;
+ALTER TABLE game_history ADD COLUMN aborted BOOLEAN DEFAULT FALSE;
@@ -0,0 +1,15 @@
+-- pkg/database/migrations/003_crash_reports.sql
+
+-- Rapports de plantage envoyés volontairement par les joueurs
+CREATE TABLE IF NOT EXISTS crash_reports (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    client_version VARCHAR(32) NOT NULL,
+    os VARCHAR(32) NOT NULL,
+    arch VARCHAR(32) NOT NULL,
+    panic_message TEXT NOT NULL,
+    stack_trace MEDIUMTEXT,
+    log_lines MEDIUMTEXT,
+    crashed_at TIMESTAMP NULL,
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_received (received_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
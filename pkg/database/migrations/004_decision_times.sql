@@ -0,0 +1,5 @@
+-- pkg/database/migrations/004_decision_times.sql
+
+-- Temps de réflexion cumulé, pour le temps moyen par coup du profil
+ALTER TABLE player_stats ADD COLUMN decisions INT DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN decision_ms BIGINT DEFAULT 0;
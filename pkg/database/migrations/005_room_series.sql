@@ -0,0 +1,13 @@
+-- pkg/database/migrations/005_room_series.sql
+
+-- Score des séries de revanches jouées dans une même salle (game.save_series)
+CREATE TABLE IF NOT EXISTS room_series (
+    room_id VARCHAR(50) NOT NULL,
+    series_started_at TIMESTAMP NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    games_played INT NOT NULL DEFAULT 0,
+    wins INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (room_id, series_started_at, user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,4 @@
+-- pkg/database/migrations/006_leaderboard_index.sql
+
+-- Ordre du classement, parcouru page par page
+CREATE INDEX idx_leaderboard ON player_stats (games_won DESC, win_rate DESC, user_id);
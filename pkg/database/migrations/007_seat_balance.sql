@@ -0,0 +1,7 @@
+-- pkg/database/migrations/007_seat_balance.sql
+
+-- Ordre de jeu de chaque siège et sièges des IA, pour le rapport d'équilibre
+-- des couleurs (/balance de l'API d'administration)
+ALTER TABLE game_participants ADD COLUMN turn_order INT NULL;
+ALTER TABLE game_participants ADD COLUMN is_ai BOOLEAN DEFAULT FALSE;
+CREATE INDEX idx_participant_seat ON game_participants (color, turn_order);
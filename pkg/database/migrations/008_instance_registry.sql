@@ -0,0 +1,34 @@
+-- pkg/database/migrations/008_instance_registry.sql
+
+-- Registre partagé des instances du serveur (cluster.instance_id). Une
+-- instance en vidage transfère ses salles en attente vers une autre.
+CREATE TABLE IF NOT EXISTS server_instances (
+    instance_id VARCHAR(64) PRIMARY KEY,
+    address VARCHAR(255) NOT NULL,
+    draining BOOLEAN NOT NULL DEFAULT FALSE,
+    players INT NOT NULL DEFAULT 0,
+    rooms INT NOT NULL DEFAULT 0,
+    heartbeat_at TIMESTAMP NOT NULL,
+    INDEX idx_instance_heartbeat (heartbeat_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Salles en attente confiées à une autre instance, recréées à l'arrivée du
+-- premier joueur transféré
+CREATE TABLE IF NOT EXISTS room_transfers (
+    room_id VARCHAR(50) PRIMARY KEY,
+    target_instance VARCHAR(64) NOT NULL,
+    room JSON NOT NULL,
+    password_hash VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Tickets à usage unique qui authentifient un joueur transféré sur l'instance cible
+CREATE TABLE IF NOT EXISTS transfer_tickets (
+    ticket VARCHAR(64) PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    username VARCHAR(50) NOT NULL,
+    room_id VARCHAR(50) NOT NULL,
+    target_instance VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,16 @@
+-- pkg/database/migrations/009_rivalries.sql
+
+-- Bilan des confrontations entre deux joueurs, toutes parties confondues.
+-- Chaque paire n'a qu'une ligne: user_low est le plus petit des deux ids.
+CREATE TABLE IF NOT EXISTS rivalries (
+    user_low BIGINT UNSIGNED NOT NULL,
+    user_high BIGINT UNSIGNED NOT NULL,
+    wins_low INT NOT NULL DEFAULT 0,
+    wins_high INT NOT NULL DEFAULT 0,
+    games INT NOT NULL DEFAULT 0,
+    last_played_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_low, user_high),
+    INDEX idx_rivalries_high (user_high),
+    FOREIGN KEY (user_low) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_high) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
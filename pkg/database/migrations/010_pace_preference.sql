@@ -0,0 +1,5 @@
+-- pkg/database/migrations/010_pace_preference.sql
+
+-- Rythme de jeu déclaré par le joueur (fast, normal, relaxed).
+-- NULL: le matchmaking le déduit du temps de réflexion moyen.
+ALTER TABLE users ADD COLUMN pace VARCHAR(16) NULL;
@@ -0,0 +1,7 @@
+-- pkg/database/migrations/011_ratings.sql
+
+-- Cote des parties classées (Glicko) et son incertitude, qui diminue au
+-- fil des parties de placement
+ALTER TABLE player_stats ADD COLUMN rating DOUBLE DEFAULT 1500;
+ALTER TABLE player_stats ADD COLUMN rating_deviation DOUBLE DEFAULT 350;
+ALTER TABLE player_stats ADD COLUMN ranked_games INT DEFAULT 0;
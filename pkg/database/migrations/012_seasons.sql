@@ -0,0 +1,36 @@
+-- pkg/database/migrations/012_seasons.sql
+
+-- Dernière partie classée, pour l'érosion de la cote des joueurs inactifs
+ALTER TABLE player_stats ADD COLUMN last_ranked_at TIMESTAMP NULL;
+
+-- Saisons closes: la ligne sert aussi de verrou entre instances
+CREATE TABLE IF NOT EXISTS seasons (
+    season INT PRIMARY KEY,
+    ended_at TIMESTAMP NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Statistiques de chaque joueur classé à la fin d'une saison
+CREATE TABLE IF NOT EXISTS season_stats (
+    season INT NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    rating DOUBLE NOT NULL,
+    rating_deviation DOUBLE NOT NULL,
+    ranked_games INT NOT NULL,
+    total_games INT NOT NULL,
+    games_won INT NOT NULL,
+    PRIMARY KEY (season, user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Évolution de la cote de chaque joueur (érosion de fin de saison)
+CREATE TABLE IF NOT EXISTS rating_history (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    rating DOUBLE NOT NULL,
+    rating_deviation DOUBLE NOT NULL,
+    reason VARCHAR(16) NOT NULL,
+    season INT NULL,
+    recorded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_rating_history_user (user_id, recorded_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,74 @@
+// pkg/database/rating.go
+package database
+
+import (
+	"database/sql"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/rating"
+)
+
+// RatedOpponents retourne, pour chaque joueur humain d'une partie terminée,
+// ses adversaires humains et son score contre chacun, d'après Matchups.
+// ratings donne les statistiques d'avant la partie; un joueur absent a la
+// cote initiale.
+func RatedOpponents(game *models.Game, ratings map[int64]*models.PlayerStats) map[int64][]rating.Opponent {
+	current := func(userID int64) (float64, float64) {
+		if stats, ok := ratings[userID]; ok && stats.RatingDeviation > 0 {
+			return stats.Rating, stats.RatingDeviation
+		}
+		return constants.RatingInitial, constants.RatingInitialDeviation
+	}
+
+	opponents := make(map[int64][]rating.Opponent)
+	for _, m := range Matchups(game) {
+		scoreLow := 0.5
+		if m.LowWon {
+			scoreLow = 1
+		} else if m.HighWon {
+			scoreLow = 0
+		}
+
+		lowRating, lowDeviation := current(m.Low)
+		highRating, highDeviation := current(m.High)
+		opponents[m.Low] = append(opponents[m.Low], rating.Opponent{Rating: highRating, Deviation: highDeviation, Score: scoreLow})
+		opponents[m.High] = append(opponents[m.High], rating.Opponent{Rating: lowRating, Deviation: lowDeviation, Score: 1 - scoreLow})
+	}
+	return opponents
+}
+
+// rateGame met à jour la cote du joueur après une partie classée
+func rateGame(stats *models.PlayerStats, opponents []rating.Opponent) {
+	stats.RankedGames++
+	stats.Rating, stats.RatingDeviation = rating.Update(stats.Rating, stats.RatingDeviation, stats.RankedGames, opponents)
+}
+
+// GetRatingHistory retourne les limit dernières cotes d'un joueur, des plus
+// anciennes aux plus récentes
+func (db *DB) GetRatingHistory(userID int64, limit int) ([]models.RatingPoint, error) {
+	query := `SELECT rating, rating_deviation, reason, season, recorded_at FROM (
+	              SELECT id, rating, rating_deviation, reason, season, recorded_at
+	              FROM rating_history WHERE user_id = ?
+	              ORDER BY id DESC LIMIT ?
+	          ) recent
+	          ORDER BY id`
+
+	rows, err := db.reader().Query(query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []models.RatingPoint
+	for rows.Next() {
+		var point models.RatingPoint
+		var season sql.NullInt64
+		if err := rows.Scan(&point.Rating, &point.Deviation, &point.Reason, &season, &point.At); err != nil {
+			return nil, err
+		}
+		point.Season = int(season.Int64)
+		points = append(points, point)
+	}
+	return points, rows.Err()
+}
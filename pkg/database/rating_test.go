@@ -0,0 +1,73 @@
+// pkg/database/rating_test.go
+package database
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/rating"
+)
+
+func TestPlacementSettlesRating(t *testing.T) {
+	stats := &models.PlayerStats{Rating: constants.RatingInitial, RatingDeviation: constants.RatingInitialDeviation}
+	settled := []rating.Opponent{{Rating: 1500, Deviation: 60, Score: 1}}
+
+	applyGameResult(stats, StatUpdate{Won: true, Opponents: settled})
+	firstGain := stats.Rating - constants.RatingInitial
+	if firstGain < 100 {
+		t.Errorf("First placement win gained %.0f points, want a large jump", firstGain)
+	}
+	if !stats.Provisional() {
+		t.Error("Expected the player to still be in placement")
+	}
+
+	for stats.RankedGames < constants.PlacementGames {
+		applyGameResult(stats, StatUpdate{Won: true, Opponents: settled})
+	}
+	if stats.Provisional() || stats.RatingDeviation > rating.SettledDeviation {
+		t.Errorf("After placement: deviation %.0f, provisional %v", stats.RatingDeviation, stats.Provisional())
+	}
+
+	before := stats.Rating
+	applyGameResult(stats, StatUpdate{Won: true, Opponents: settled})
+	if gain := stats.Rating - before; gain <= 0 || gain >= firstGain/3 {
+		t.Errorf("Settled win gained %.1f points, want far less than %.0f", gain, firstGain)
+	}
+}
+
+func TestRatingIgnoresCasualAndUnratedGames(t *testing.T) {
+	stats := &models.PlayerStats{Rating: 1600, RatingDeviation: 80}
+	opponents := []rating.Opponent{{Rating: 1600, Deviation: 80, Score: 0}}
+
+	applyGameResult(stats, StatUpdate{Casual: true, Opponents: opponents})
+	applyGameResult(stats, StatUpdate{Won: true})
+	if stats.Rating != 1600 || stats.RankedGames != 0 {
+		t.Errorf("Rating moved without a ranked game: %.0f after %d games", stats.Rating, stats.RankedGames)
+	}
+
+	applyGameResult(stats, StatUpdate{Opponents: opponents})
+	if stats.Rating >= 1600 || stats.RankedGames != 1 {
+		t.Errorf("Expected a ranked loss to lower the rating, got %.0f", stats.Rating)
+	}
+}
+
+func TestRatedOpponentsScoreEachPair(t *testing.T) {
+	red := models.NewPlayer(1, "red", constants.ColorRed)
+	green := models.NewPlayer(2, "green", constants.ColorGreen)
+	game := &models.Game{
+		Room:     &models.Room{Players: []*models.Player{red, green}},
+		Winner:   green,
+		Rankings: []*models.Player{green, red},
+	}
+
+	opponents := RatedOpponents(game, map[int64]*models.PlayerStats{
+		2: {Rating: 1700, RatingDeviation: 60},
+	})
+	if got := opponents[1]; len(got) != 1 || got[0].Score != 0 || got[0].Rating != 1700 {
+		t.Errorf("Red's opponents: %+v", got)
+	}
+	if got := opponents[2]; len(got) != 1 || got[0].Score != 1 || got[0].Deviation != constants.RatingInitialDeviation {
+		t.Errorf("Green's opponents: %+v, want red at the initial rating", got)
+	}
+}
@@ -0,0 +1,160 @@
+// pkg/database/rivalries.go
+package database
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+	"github.com/obrien-tchaleu/ludo-king-go/pkg/rating"
+)
+
+// Matchup est le résultat d'une partie entre deux joueurs humains. Low est
+// le plus petit des deux ids, comme dans la table rivalries.
+type Matchup struct {
+	Low, High int64
+	LowWon    bool
+	HighWon   bool
+}
+
+// Matchups retourne le résultat de chaque paire de joueurs humains d'une
+// partie terminée: gagne celui qui finit devant l'autre. Deux joueurs sans
+// classement font match nul. Une partie abandonnée ne compte pas.
+func Matchups(game *models.Game) []Matchup {
+	if game.Aborted {
+		return nil
+	}
+
+	var humans []*models.Player
+	for _, player := range game.Room.Players {
+		if !player.IsAI {
+			humans = append(humans, player)
+		}
+	}
+
+	var matchups []Matchup
+	for i, a := range humans {
+		for _, b := range humans[i+1:] {
+			if a.ID == b.ID {
+				continue
+			}
+			score := rating.Pairwise(placing(game, a), placing(game, b))
+			aWon, bWon := score == 1, score == 0
+			if a.ID < b.ID {
+				matchups = append(matchups, Matchup{Low: a.ID, High: b.ID, LowWon: aWon, HighWon: bWon})
+			} else {
+				matchups = append(matchups, Matchup{Low: b.ID, High: a.ID, LowWon: bWon, HighWon: aWon})
+			}
+		}
+	}
+	return matchups
+}
+
+// placing retourne la place du joueur au classement final, 0 s'il n'est
+// pas classé
+func placing(game *models.Game, player *models.Player) int {
+	for i, ranked := range game.Rankings {
+		if ranked != nil && ranked.Color == player.Color {
+			return i + 1
+		}
+	}
+	if game.Winner != nil && game.Winner.Color == player.Color {
+		return 1
+	}
+	return 0
+}
+
+// saveMatchupsTx ajoute les résultats d'une partie aux bilans des paires
+func saveMatchupsTx(tx *sql.Tx, matchups []Matchup, playedAt time.Time) error {
+	query := `INSERT INTO rivalries
+	          (user_low, user_high, wins_low, wins_high, games, last_played_at)
+	          VALUES (?, ?, ?, ?, 1, ?)
+	          ON DUPLICATE KEY UPDATE wins_low = wins_low + VALUES(wins_low),
+	                                  wins_high = wins_high + VALUES(wins_high),
+	                                  games = games + 1,
+	                                  last_played_at = VALUES(last_played_at)`
+
+	for _, m := range matchups {
+		if _, err := tx.Exec(query, m.Low, m.High, boolInt(m.LowWon), boolInt(m.HighWon), playedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetRivalries retourne le bilan d'un joueur contre chacun des adversaires
+// donnés qu'il a déjà affrontés
+func (db *DB) GetRivalries(userID int64, opponents []int64) ([]models.Rivalry, error) {
+	if len(opponents) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(opponents)), ", ")
+	query := rivalrySelect + `
+	          WHERE (r.user_low = ? AND r.user_high IN (` + placeholders + `))
+	             OR (r.user_high = ? AND r.user_low IN (` + placeholders + `))`
+
+	args := []interface{}{userID, userID, userID}
+	for _, id := range opponents {
+		args = append(args, id)
+	}
+	args = append(args, userID)
+	for _, id := range opponents {
+		args = append(args, id)
+	}
+	return db.queryRivalries(userID, query, args...)
+}
+
+// GetTopRivals retourne les adversaires les plus affrontés d'un joueur, les
+// plus récents d'abord à nombre de parties égal
+func (db *DB) GetTopRivals(userID int64, limit int) ([]models.Rivalry, error) {
+	query := rivalrySelect + `
+	          WHERE r.user_low = ? OR r.user_high = ?
+	          ORDER BY r.games DESC, r.last_played_at DESC
+	          LIMIT ?`
+	return db.queryRivalries(userID, query, userID, userID, userID, userID, limit)
+}
+
+// rivalrySelect lit une ligne de rivalries avec le pseudo de l'adversaire.
+// Ses deux premiers paramètres sont l'id du joueur.
+const rivalrySelect = `SELECT IF(r.user_low = ?, r.user_high, r.user_low), u.username,
+	                 r.wins_low, r.wins_high, r.user_low, r.games, r.last_played_at
+	          FROM rivalries r
+	          JOIN users u ON u.id = IF(r.user_low = ?, r.user_high, r.user_low)`
+
+// queryRivalries exécute une requête bâtie sur rivalrySelect et présente
+// chaque bilan du point de vue du joueur
+func (db *DB) queryRivalries(userID int64, query string, args ...interface{}) ([]models.Rivalry, error) {
+	rows, err := db.reader().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rivalries []models.Rivalry
+	for rows.Next() {
+		var rivalry models.Rivalry
+		var winsLow, winsHigh int
+		var low int64
+
+		err := rows.Scan(&rivalry.OpponentID, &rivalry.OpponentName, &winsLow, &winsHigh,
+			&low, &rivalry.Games, &rivalry.LastPlayed)
+		if err != nil {
+			return nil, err
+		}
+		rivalry.Wins, rivalry.Losses = winsHigh, winsLow
+		if low == userID {
+			rivalry.Wins, rivalry.Losses = winsLow, winsHigh
+		}
+		rivalries = append(rivalries, rivalry)
+	}
+	return rivalries, rows.Err()
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,51 @@
+// pkg/database/rivalries_test.go
+package database
+
+import (
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestMatchupsFollowFinalRanking(t *testing.T) {
+	red := models.NewPlayer(7, "red", constants.ColorRed)
+	green := models.NewPlayer(3, "green", constants.ColorGreen)
+	yellow := models.NewPlayer(5, "yellow", constants.ColorYellow)
+	bot := models.NewPlayer(-1, "bot", constants.ColorBlue)
+	bot.IsAI = true
+
+	game := &models.Game{
+		Room:     &models.Room{Players: []*models.Player{red, green, yellow, bot}},
+		Winner:   red,
+		Rankings: []*models.Player{red, bot},
+	}
+
+	got := Matchups(game)
+	want := []Matchup{
+		{Low: 3, High: 7, HighWon: true},
+		{Low: 5, High: 7, HighWon: true},
+		{Low: 3, High: 5}, // Ni l'un ni l'autre n'est classé
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d matchups, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Matchup %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestMatchupsSkipAbortedGames(t *testing.T) {
+	game := &models.Game{
+		Room: &models.Room{Players: []*models.Player{
+			models.NewPlayer(1, "a", constants.ColorRed),
+			models.NewPlayer(2, "b", constants.ColorGreen),
+		}},
+		Aborted: true,
+	}
+	if got := Matchups(game); len(got) != 0 {
+		t.Errorf("Expected no matchup for an aborted game, got %+v", got)
+	}
+}
@@ -0,0 +1,84 @@
+// pkg/database/season.go
+package database
+
+import (
+	"math"
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+)
+
+// Raisons d'une entrée de l'historique des cotes
+const (
+	RatingReasonGame  = "game"  // Partie classée
+	RatingReasonDecay = "decay" // Érosion de fin de saison
+)
+
+// SeasonDecay décrit l'érosion appliquée en fin de saison aux joueurs
+// classés qui n'ont plus joué de partie classée
+type SeasonDecay struct {
+	InactiveSince time.Time // Sans partie classée depuis cette date, la cote s'érode
+	Points        float64   // Points retirés, sans descendre sous la cote initiale
+	Deviation     float64   // Incertitude ajoutée, sans dépasser l'incertitude initiale
+}
+
+// decayRating applique l'érosion à une cote: un joueur inactif perd des
+// points et son niveau redevient plus incertain
+func decayRating(rating, deviation float64, decay SeasonDecay) (float64, float64) {
+	if rating > constants.RatingInitial {
+		rating = math.Max(constants.RatingInitial, rating-decay.Points)
+	}
+	return rating, math.Min(constants.RatingInitialDeviation, deviation+decay.Deviation)
+}
+
+// EndSeason clôt une saison: les statistiques des joueurs classés sont
+// archivées, puis la cote des joueurs inactifs s'érode et l'érosion entre
+// dans leur historique. closed est faux si la saison était déjà close, par
+// cette instance ou une autre; decayed compte les joueurs érodés.
+func (db *DB) EndSeason(season int, decay SeasonDecay) (decayed int, closed bool, err error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback()
+
+	// La clé primaire de seasons garantit une seule clôture par saison
+	if _, err := tx.Exec(`INSERT INTO seasons (season, ended_at) VALUES (?, NOW())`, season); err != nil {
+		if isMySQLError(err, errDuplicateEntry) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	archive := `INSERT INTO season_stats
+	            (season, user_id, rating, rating_deviation, ranked_games, total_games, games_won)
+	            SELECT ?, user_id, rating, rating_deviation, ranked_games, total_games, games_won
+	            FROM player_stats WHERE ranked_games > 0`
+	if _, err := tx.Exec(archive, season); err != nil {
+		return 0, false, err
+	}
+
+	// Même calcul que decayRating
+	inactive := `ranked_games > 0 AND (last_ranked_at IS NULL OR last_ranked_at < ?)`
+	update := `UPDATE player_stats SET
+	           rating = IF(rating > ?, GREATEST(?, rating - ?), rating),
+	           rating_deviation = LEAST(?, rating_deviation + ?)
+	           WHERE ` + inactive
+	result, err := tx.Exec(update, constants.RatingInitial, constants.RatingInitial, decay.Points,
+		constants.RatingInitialDeviation, decay.Deviation, decay.InactiveSince)
+	if err != nil {
+		return 0, false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, false, err
+	}
+
+	history := `INSERT INTO rating_history (user_id, rating, rating_deviation, reason, season)
+	            SELECT user_id, rating, rating_deviation, ?, ? FROM player_stats WHERE ` + inactive
+	if _, err := tx.Exec(history, RatingReasonDecay, season, decay.InactiveSince); err != nil {
+		return 0, false, err
+	}
+
+	return int(rows), true, tx.Commit()
+}
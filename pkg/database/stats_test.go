@@ -0,0 +1,135 @@
+// pkg/database/stats_test.go
+package database
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+func TestApplyGameResultStreak(t *testing.T) {
+	stats := &models.PlayerStats{}
+
+	results := []bool{true, true, false, true, true, true, false}
+	for _, won := range results {
+		applyGameResult(stats, StatUpdate{Won: won})
+	}
+
+	if stats.TotalGames != 7 || stats.GamesWon != 5 || stats.GamesLost != 2 {
+		t.Errorf("Expected 7/5/2 games, got %d/%d/%d", stats.TotalGames, stats.GamesWon, stats.GamesLost)
+	}
+	if stats.CurrentStreak != 0 {
+		t.Errorf("Expected current streak 0 after a loss, got %d", stats.CurrentStreak)
+	}
+	if stats.HighestStreak != 3 {
+		t.Errorf("Expected highest streak 3, got %d", stats.HighestStreak)
+	}
+}
+
+func TestApplyGameResultWinRate(t *testing.T) {
+	stats := &models.PlayerStats{TotalGames: 3, GamesWon: 1, GamesLost: 2}
+
+	applyGameResult(stats, StatUpdate{Won: true, TokensCaptured: 2, TokensLost: 1})
+
+	if stats.WinRate != 50 {
+		t.Errorf("Expected win rate 50, got %.2f", stats.WinRate)
+	}
+	if stats.TokensCaptured != 2 || stats.TokensLost != 1 {
+		t.Errorf("Expected token counters 2/1, got %d/%d", stats.TokensCaptured, stats.TokensLost)
+	}
+	if stats.CurrentStreak != 1 || stats.HighestStreak != 1 {
+		t.Errorf("Expected streak 1/1, got %d/%d", stats.CurrentStreak, stats.HighestStreak)
+	}
+}
+
+func TestApplyGameResultCountsDiceOnAbort(t *testing.T) {
+	stats := &models.PlayerStats{SixesRolled: 2, TotalDiceRolls: 10}
+
+	applyGameResult(stats, StatUpdate{Aborted: true, SixesRolled: 3, DiceRolls: 8})
+
+	if stats.SixesRolled != 5 || stats.TotalDiceRolls != 18 {
+		t.Errorf("Expected dice counters 5/18, got %d/%d", stats.SixesRolled, stats.TotalDiceRolls)
+	}
+	if stats.TotalGames != 0 {
+		t.Errorf("Expected aborted game not to count, got %d games", stats.TotalGames)
+	}
+}
+
+func TestApplyGameResultQueues(t *testing.T) {
+	stats := &models.PlayerStats{TotalGames: 2, GamesWon: 2, CurrentStreak: 2, WinRate: 100}
+
+	applyGameResult(stats, StatUpdate{Casual: true, DiceRolls: 10})
+	if stats.TotalGames != 2 || stats.WinRate != 100 || stats.TotalDiceRolls != 10 {
+		t.Errorf("Expected a casual game to leave the rating alone, got %d games at %.0f%%", stats.TotalGames, stats.WinRate)
+	}
+
+	// Abandon voté: le joueur inactif d'une partie classée perd quand même
+	applyGameResult(stats, StatUpdate{Aborted: true, AFK: true})
+	if stats.GamesLost != 1 || stats.GamesAborted != 0 || stats.CurrentStreak != 0 {
+		t.Errorf("Expected an AFK loss, got %d lost, %d aborted", stats.GamesLost, stats.GamesAborted)
+	}
+}
+
+func TestWriteStatUpdateConcurrent(t *testing.T) {
+	var store Store = NewMemoryStore()
+	user, err := store.CreateUser("Alice", "alice@example.com", "hash")
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	if err := store.WriteStatUpdate(StatUpdate{UserID: user.ID}); err != nil {
+		t.Fatalf("WriteStatUpdate failed: %v", err)
+	}
+
+	// Les fins de partie d'un même joueur arrivent en parallèle: aucune
+	// victoire ne doit se perdre dans la série
+	const wins = 19
+	var wg sync.WaitGroup
+	for i := 0; i < wins; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := store.WriteStatUpdate(StatUpdate{UserID: user.ID, Won: true}); err != nil {
+				t.Errorf("WriteStatUpdate failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats, err := store.GetPlayerStats(user.ID)
+	if err != nil {
+		t.Fatalf("GetPlayerStats failed: %v", err)
+	}
+	if stats.TotalGames != wins+1 || stats.GamesWon != wins || stats.GamesLost != 1 {
+		t.Errorf("Expected %d/%d/1 games, got %d/%d/%d", wins+1, wins, stats.TotalGames, stats.GamesWon, stats.GamesLost)
+	}
+	if stats.CurrentStreak != wins || stats.HighestStreak != wins {
+		t.Errorf("Expected streak %d/%d, got %d/%d", wins, wins, stats.CurrentStreak, stats.HighestStreak)
+	}
+	if stats.WinRate != 95 {
+		t.Errorf("Expected win rate 95, got %.2f", stats.WinRate)
+	}
+}
+
+func TestLuckIndex(t *testing.T) {
+	if got := (&models.PlayerStats{}).LuckIndex(); got != 0 {
+		t.Errorf("Expected 0 without rolls, got %.1f", got)
+	}
+	if got := (&models.PlayerStats{SixesRolled: 12, TotalDiceRolls: 60}).LuckIndex(); got != 120 {
+		t.Errorf("Expected luck index 120, got %.1f", got)
+	}
+}
+
+func TestLikePrefixEscapesWildcards(t *testing.T) {
+	tests := map[string]string{
+		"":        "%",
+		"ali":     "ali%",
+		"100%_ok": `100\%\_ok%`,
+		`back\`:   `back\\%`,
+	}
+	for prefix, want := range tests {
+		if got := likePrefix(prefix); got != want {
+			t.Errorf("likePrefix(%q) = %q, want %q", prefix, got, want)
+		}
+	}
+}
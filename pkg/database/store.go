@@ -0,0 +1,72 @@
+// pkg/database/store.go
+package database
+
+import (
+	"time"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/models"
+)
+
+// UserStore gère les comptes des joueurs
+type UserStore interface {
+	CreateUser(username, email, passwordHash string) (*models.User, error)
+	GetUserByID(id int64) (*models.User, error)
+	// GetUserByUsername retourne aussi le hash du mot de passe
+	GetUserByUsername(username string) (*models.User, error)
+	UpdateLastLogin(userID int64) error
+	SetPace(userID int64, pace constants.Pace) error
+	AddCoins(userID int64, amount int) error
+}
+
+// StatsStore gère les statistiques, le classement et les rivalités
+type StatsStore interface {
+	GetPlayerStats(userID int64) (*models.PlayerStats, error)
+	WriteStatUpdate(update StatUpdate) error
+	// WriteStatUpdates applique un lot de mises à jour d'un seul tenant
+	WriteStatUpdates(batch []StatUpdate) error
+	GetLeaderboard(offset, limit int, search string) (entries []models.LeaderboardEntry, hasMore bool, err error)
+	GetRivalries(userID int64, opponents []int64) ([]models.Rivalry, error)
+	GetTopRivals(userID int64, limit int) ([]models.Rivalry, error)
+	// GetRatingHistory retourne les limit dernières cotes, des plus anciennes aux plus récentes
+	GetRatingHistory(userID int64, limit int) ([]models.RatingPoint, error)
+}
+
+// GameHistoryStore archive les parties, les séries et les rapports de plantage
+type GameHistoryStore interface {
+	SaveGameHistory(game *models.Game) error
+	SaveSeries(roomID string, startedAt time.Time, gamesPlayed int, wins map[int64]int, players []int64) error
+	GetBalanceReport(since *time.Time) (*BalanceReport, error)
+	SaveCrashReport(report *models.CrashReportPayload) error
+}
+
+// SeasonStore clôt les saisons classées
+type SeasonStore interface {
+	EndSeason(season int, decay SeasonDecay) (decayed int, closed bool, err error)
+}
+
+// ArchiveStore exporte et importe les joueurs, pour migrer une instance
+// vers un autre hébergement
+type ArchiveStore interface {
+	ExportArchive() (*Archive, error)
+	// ExportPlayer exporte les seules données d'un joueur, qu'il peut télécharger
+	ExportPlayer(userID int64) (*Archive, error)
+	// ImportArchive ajoute les joueurs de l'archive sous de nouveaux ids
+	ImportArchive(archive *Archive) (*ImportReport, error)
+}
+
+// Store regroupe tout ce dont le serveur a besoin pour persister ses données.
+// DB l'implémente sur MySQL, MemoryStore en mémoire pour le développement.
+type Store interface {
+	UserStore
+	StatsStore
+	GameHistoryStore
+	SeasonStore
+	ArchiveStore
+	Close() error
+}
+
+var (
+	_ Store = (*DB)(nil)
+	_ Store = (*MemoryStore)(nil)
+)
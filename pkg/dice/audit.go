@@ -0,0 +1,40 @@
+// pkg/dice/audit.go
+package dice
+
+// fairChiSquare est le seuil du χ² à 5 degrés de liberté au risque de 1%:
+// un dé équitable le dépasse une partie sur cent
+const fairChiSquare = 15.09
+
+// Distribution compte les faces obtenues sur une suite de lancers
+type Distribution struct {
+	Counts    [6]int  `json:"counts"` // Lancers par face, de 1 à 6
+	Total     int     `json:"total"`
+	ChiSquare float64 `json:"chi_square"` // Écart à un dé équilibré
+}
+
+// Audit compte les lancers et mesure leur écart à un dé équilibré
+func Audit(rolls []int) Distribution {
+	var d Distribution
+	for _, value := range rolls {
+		if value >= 1 && value <= len(d.Counts) {
+			d.Counts[value-1]++
+			d.Total++
+		}
+	}
+	if d.Total == 0 {
+		return d
+	}
+
+	expected := float64(d.Total) / float64(len(d.Counts))
+	for _, count := range d.Counts {
+		diff := float64(count) - expected
+		d.ChiSquare += diff * diff / expected
+	}
+	return d
+}
+
+// Plausible indique si les lancers sont compatibles avec un dé équilibré.
+// Sous une trentaine de lancers le test ne dit rien: la réponse est oui.
+func (d Distribution) Plausible() bool {
+	return d.Total < 30 || d.ChiSquare < fairChiSquare
+}
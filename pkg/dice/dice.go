@@ -0,0 +1,72 @@
+// pkg/dice/dice.go
+package dice
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// Profile définit le poids de chaque face du dé pour un joueur
+type Profile struct {
+	Name    string     `json:"name"`
+	Weights [6]float64 `json:"weights"` // Poids des faces 1 à 6
+}
+
+// Fair est le dé équilibré utilisé par défaut
+var Fair = Profile{
+	Name:    "fair",
+	Weights: [6]float64{1, 1, 1, 1, 1, 1},
+}
+
+// Handicap crée un profil où le 6 est moins probable (sixWeight < 1)
+func Handicap(name string, sixWeight float64) Profile {
+	p := Fair
+	p.Name = name
+	p.Weights[5] = sixWeight
+	return p
+}
+
+// HandicapForLevel retourne le handicap affiché pour une difficulté d'IA
+func HandicapForLevel(level string) Profile {
+	switch strings.ToLower(level) {
+	case "easy":
+		return Handicap("easy_handicap", 0.6)
+	case "medium":
+		return Handicap("medium_handicap", 0.8)
+	default:
+		return Fair
+	}
+}
+
+// Roll lance le dé selon les poids du profil
+func (p Profile) Roll(r *rand.Rand) int {
+	total := 0.0
+	for _, w := range p.Weights {
+		total += w
+	}
+
+	x := r.Float64() * total
+	for face, w := range p.Weights {
+		if x < w {
+			return face + 1
+		}
+		x -= w
+	}
+	return len(p.Weights)
+}
+
+// Probability retourne la probabilité d'obtenir une face (1 à 6)
+func (p Profile) Probability(face int) float64 {
+	if face < 1 || face > len(p.Weights) {
+		return 0
+	}
+
+	total := 0.0
+	for _, w := range p.Weights {
+		total += w
+	}
+	if total == 0 {
+		return 0
+	}
+	return p.Weights[face-1] / total
+}
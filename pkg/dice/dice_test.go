@@ -0,0 +1,93 @@
+// pkg/dice/dice_test.go
+package dice
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestProfileProbability(t *testing.T) {
+	for face := 1; face <= 6; face++ {
+		if got := Fair.Probability(face); math.Abs(got-1.0/6) > 1e-12 {
+			t.Fatalf("fair face %d: got %v", face, got)
+		}
+	}
+	for _, face := range []int{0, 7, -1} {
+		if Fair.Probability(face) != 0 {
+			t.Fatalf("face %d must have no chance", face)
+		}
+	}
+	if (Profile{}).Probability(3) != 0 {
+		t.Fatal("a profile without weights must have no chance")
+	}
+
+	easy := HandicapForLevel("easy")
+	if got, want := easy.Probability(6), 0.6/5.6; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("easy six: got %v, want %v", got, want)
+	}
+	if got, want := easy.Probability(1), 1/5.6; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("easy one: got %v, want %v", got, want)
+	}
+}
+
+func TestHandicapForLevel(t *testing.T) {
+	easy, medium, hard := HandicapForLevel("Easy"), HandicapForLevel("MEDIUM"), HandicapForLevel("Hard")
+	if easy.Name != "easy_handicap" || medium.Name != "medium_handicap" || hard != Fair {
+		t.Fatalf("got %q, %q, %q", easy.Name, medium.Name, hard.Name)
+	}
+	if HandicapForLevel("unknown") != Fair {
+		t.Fatal("an unknown level must play a fair die")
+	}
+
+	// Plus l'IA est facile, plus son 6 est rare; les autres faces restent égales
+	if !(easy.Probability(6) < medium.Probability(6) && medium.Probability(6) < Fair.Probability(6)) {
+		t.Fatal("six must get rarer as the level gets easier")
+	}
+	for _, p := range []Profile{easy, medium, hard} {
+		sum := 0.0
+		for face := 1; face <= 6; face++ {
+			sum += p.Probability(face)
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Fatalf("%s: probabilities sum to %v", p.Name, sum)
+		}
+		for face := 2; face <= 5; face++ {
+			if p.Probability(face) != p.Probability(1) {
+				t.Fatalf("%s: face %d differs from face 1", p.Name, face)
+			}
+		}
+	}
+}
+
+// Les fréquences observées suivent les probabilités affichées au joueur
+func TestProfileRollDistribution(t *testing.T) {
+	const rolls = 120000
+	for _, p := range []Profile{Fair, HandicapForLevel("easy"), HandicapForLevel("medium")} {
+		r := rand.New(rand.NewSource(42))
+		var counts [7]int
+		for i := 0; i < rolls; i++ {
+			face := p.Roll(r)
+			if face < 1 || face > 6 {
+				t.Fatalf("%s rolled %d", p.Name, face)
+			}
+			counts[face]++
+		}
+		for face := 1; face <= 6; face++ {
+			got := float64(counts[face]) / rolls
+			if want := p.Probability(face); math.Abs(got-want) > 0.01 {
+				t.Fatalf("%s face %d: rolled %.4f of the time, want %.4f", p.Name, face, got, want)
+			}
+		}
+	}
+}
+
+func TestProfileNeverRollsZeroWeightFace(t *testing.T) {
+	noSix := Handicap("no_six", 0)
+	r := rand.New(rand.NewSource(7))
+	for i := 0; i < 10000; i++ {
+		if noSix.Roll(r) == 6 {
+			t.Fatal("rolled a face with no weight")
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// pkg/dice/rng_test.go
+package dice
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSeededDiceRepeat(t *testing.T) {
+	a, b := NewSeeded(42), NewSeeded(42)
+	for i := 0; i < 100; i++ {
+		if x, y := a.Roll(), b.Roll(); x != y {
+			t.Fatalf("roll %d: %d != %d with the same seed", i, x, y)
+		}
+	}
+	if seed, ok := a.Seed(); !ok || seed != 42 {
+		t.Errorf("Seed() = %d, %v; want 42, true", seed, ok)
+	}
+	if _, ok := New().Seed(); ok {
+		t.Error("a crypto/rand die has no seed")
+	}
+}
+
+func TestDiceFair(t *testing.T) {
+	for _, d := range []*Dice{New(), NewSeeded(1)} {
+		rolls := make([]int, 6000)
+		for i := range rolls {
+			rolls[i] = d.Roll()
+			if rolls[i] < 1 || rolls[i] > 6 {
+				t.Fatalf("roll out of range: %d", rolls[i])
+			}
+		}
+
+		// Pas de 6 imposé au premier lancer ni tous les cinq lancers: chaque
+		// face reste à moins de sept écarts-types de 1000
+		for face, count := range Audit(rolls).Counts {
+			if count < 800 || count > 1200 {
+				t.Errorf("face %d came %d times out of 6000", face+1, count)
+			}
+		}
+	}
+}
+
+func TestAudit(t *testing.T) {
+	fair := Audit([]int{1, 2, 3, 4, 5, 6, 1, 2, 3, 4, 5, 6})
+	if fair.Total != 12 || fair.ChiSquare != 0 {
+		t.Errorf("balanced rolls: %+v, want χ² 0", fair)
+	}
+
+	sixes := make([]int, 60)
+	for i := range sixes {
+		sixes[i] = 6
+	}
+	if Audit(sixes).Plausible() {
+		t.Error("sixty sixes should not pass for a fair die")
+	}
+	if !Audit(sixes[:5]).Plausible() {
+		t.Error("too few rolls to judge")
+	}
+}
+
+func TestDiceSource(t *testing.T) {
+	a, b := rand.New(NewSeeded(7).Source()), rand.New(NewSeeded(7).Source())
+	for i := 0; i < 100; i++ {
+		if x, y := Fair.Roll(a), Fair.Roll(b); x != y {
+			t.Fatalf("roll %d: %d != %d with the same seed", i, x, y)
+		}
+	}
+
+	r := rand.New(New().Source())
+	rolls := make([]int, 6000)
+	for i := range rolls {
+		rolls[i] = Fair.Roll(r)
+	}
+	for face, count := range Audit(rolls).Counts {
+		if count < 800 || count > 1200 {
+			t.Errorf("face %d came %d times out of 6000", face+1, count)
+		}
+	}
+}
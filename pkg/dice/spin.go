@@ -0,0 +1,85 @@
+// pkg/dice/spin.go
+package dice
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	// SpinFacePeriod est la durée d'affichage de chaque face du sélecteur
+	SpinFacePeriod = 150 * time.Millisecond
+	// SpinSlipWindow borne le glissement caché appliqué à la face visée
+	SpinSlipWindow = 1
+	// SpinLatencyTolerance est l'avance maximale acceptée sur l'horloge serveur
+	SpinLatencyTolerance = 500 * time.Millisecond
+)
+
+// Spin est un lancer "à viser": un sélecteur fait défiler les faces et le
+// joueur l'arrête. Le serveur s'engage à l'avance (empreinte de la graine)
+// sur un glissement caché, révélé après le lancer pour que le client vérifie.
+type Spin struct {
+	Seed      string // Graine secrète (hex), révélée après l'arrêt
+	StartedAt time.Time
+}
+
+// NewSpin démarre un sélecteur avec une graine aléatoire
+func NewSpin(now time.Time) (*Spin, error) {
+	seed := make([]byte, 16)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+	return &Spin{Seed: hex.EncodeToString(seed), StartedAt: now}, nil
+}
+
+// Commitment retourne l'empreinte de la graine, envoyée avant l'arrêt
+func (s *Spin) Commitment() string {
+	sum := sha256.Sum256([]byte(s.Seed))
+	return hex.EncodeToString(sum[:])
+}
+
+// Slip retourne le glissement caché déterminé par la graine
+func (s *Spin) Slip() int {
+	return SlipFromSeed(s.Seed)
+}
+
+// Stop arrête le sélecteur et retourne la durée retenue et la face obtenue.
+// La durée annoncée par le client n'est acceptée que si elle reste proche
+// de celle mesurée par le serveur; sinon l'horloge serveur fait foi.
+func (s *Spin) Stop(claimed time.Duration, now time.Time) (time.Duration, int) {
+	measured := now.Sub(s.StartedAt)
+	elapsed := claimed
+	if claimed > measured || claimed < measured-SpinLatencyTolerance {
+		elapsed = measured
+	}
+	return elapsed, ApplySlip(FaceAt(elapsed), s.Slip())
+}
+
+// FaceAt retourne la face affichée par le sélecteur après elapsed
+func FaceAt(elapsed time.Duration) int {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return int(elapsed/SpinFacePeriod)%6 + 1
+}
+
+// ApplySlip décale une face en restant dans 1..6
+func ApplySlip(face, slip int) int {
+	return ((face-1+slip)%6+6)%6 + 1
+}
+
+// SlipFromSeed calcule le glissement dans [-SpinSlipWindow, SpinSlipWindow]
+func SlipFromSeed(seed string) int {
+	raw, err := hex.DecodeString(seed)
+	if err != nil || len(raw) == 0 {
+		return 0
+	}
+	return int(raw[0])%(2*SpinSlipWindow+1) - SpinSlipWindow
+}
+
+// VerifySpin vérifie qu'une graine révélée correspond à l'engagement reçu
+func VerifySpin(commitment, seed string) bool {
+	return (&Spin{Seed: seed}).Commitment() == commitment
+}
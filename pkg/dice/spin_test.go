@@ -0,0 +1,47 @@
+// pkg/dice/spin_test.go
+package dice
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpinCommitmentVerifies(t *testing.T) {
+	spin, err := NewSpin(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifySpin(spin.Commitment(), spin.Seed) {
+		t.Error("revealed seed should match its commitment")
+	}
+	if VerifySpin(spin.Commitment(), "00"+spin.Seed[2:]) && spin.Seed[:2] != "00" {
+		t.Error("altered seed should not match the commitment")
+	}
+}
+
+func TestSpinStopRejectsFutureClaims(t *testing.T) {
+	start := time.Now()
+	spin := &Spin{Seed: "01", StartedAt: start}
+	now := start.Add(time.Second)
+
+	// Annoncer un arrêt plus tard que l'horloge serveur n'est pas accepté
+	elapsed, _ := spin.Stop(5*time.Second, now)
+	if elapsed != time.Second {
+		t.Errorf("elapsed = %v, want %v", elapsed, time.Second)
+	}
+
+	// Une latence raisonnable est acceptée
+	claimed := time.Second - 200*time.Millisecond
+	if elapsed, _ := spin.Stop(claimed, now); elapsed != claimed {
+		t.Errorf("elapsed = %v, want %v", elapsed, claimed)
+	}
+}
+
+func TestApplySlipWraps(t *testing.T) {
+	if got := ApplySlip(6, 1); got != 1 {
+		t.Errorf("ApplySlip(6, 1) = %d, want 1", got)
+	}
+	if got := ApplySlip(1, -1); got != 6 {
+		t.Errorf("ApplySlip(1, -1) = %d, want 6", got)
+	}
+}
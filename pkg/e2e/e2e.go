@@ -0,0 +1,72 @@
+// pkg/e2e/e2e.go
+package e2e
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+// Paramètres de dérivation de la clé de salle
+const (
+	keyIterations = 200000
+	keyLength     = 32 // AES-256
+	saltPrefix    = "ludo-king-room:"
+)
+
+// ErrDecrypt indique un message illisible avec cette clé
+var ErrDecrypt = errors.New("e2e: cannot decrypt message")
+
+// DeriveRoomKey dérive la clé de chat d'une salle à partir de son mot de passe.
+// Le code de salle sert de sel: le même mot de passe donne une clé par salle.
+func DeriveRoomKey(password, roomID string) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, password, []byte(saltPrefix+roomID), keyIterations, keyLength)
+}
+
+// Seal chiffre un message (AES-GCM) et l'encode en base64, nonce en tête
+func Seal(key []byte, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open déchiffre un message produit par Seal
+func Open(key []byte, ciphertext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil || len(raw) < gcm.NonceSize() {
+		return "", ErrDecrypt
+	}
+
+	nonce, data := raw[:gcm.NonceSize()], raw[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, data, nil)
+	if err != nil {
+		return "", ErrDecrypt
+	}
+	return string(plaintext), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,38 @@
+// pkg/e2e/e2e_test.go
+package e2e
+
+import "testing"
+
+func TestSealOpenRoundTrip(t *testing.T) {
+	key, err := DeriveRoomKey("secret", "K7MQ2X")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sealed, err := Seal(key, "bien joué!")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Open(key, sealed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "bien joué!" {
+		t.Errorf("Open() = %q, want %q", got, "bien joué!")
+	}
+}
+
+func TestOpenWithWrongKeyFails(t *testing.T) {
+	key, _ := DeriveRoomKey("secret", "K7MQ2X")
+	otherRoom, _ := DeriveRoomKey("secret", "ABC234")
+
+	sealed, err := Seal(key, "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Open(otherRoom, sealed); err != ErrDecrypt {
+		t.Errorf("Open() with another room's key: err = %v, want ErrDecrypt", err)
+	}
+}
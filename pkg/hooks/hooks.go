@@ -0,0 +1,178 @@
+// pkg/hooks/hooks.go
+//
+// Points d'extension du serveur: les opérateurs y branchent leurs propres
+// comportements (annonces, récompenses...) sans modifier le code, soit par
+// un plugin Go (LoadPlugin), soit par un script externe (StartScript).
+package hooks
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Host regroupe les actions offertes aux extensions
+type Host interface {
+	// Announce envoie un message du serveur à une salle, à toutes si roomID est vide
+	Announce(roomID, text string)
+	// GrantCoins crédite des coins à un joueur (montant négatif pour débiter)
+	GrantCoins(userID int64, amount int) error
+}
+
+// Hooks est implémenté par les extensions. Intégrer Base pour n'écrire que
+// les méthodes utiles.
+type Hooks interface {
+	OnGameStart(GameStartEvent)
+	OnGameOver(GameOverEvent)
+	// OnChatMessage est appelé avant la diffusion: false bloque le message.
+	// Il s'exécute sur le chemin du chat et doit rester rapide.
+	OnChatMessage(ChatEvent) bool
+	OnPlayerJoin(PlayerJoinEvent)
+}
+
+// Base implémente Hooks sans rien faire
+type Base struct{}
+
+func (Base) OnGameStart(GameStartEvent)   {}
+func (Base) OnGameOver(GameOverEvent)     {}
+func (Base) OnChatMessage(ChatEvent) bool { return true }
+func (Base) OnPlayerJoin(PlayerJoinEvent) {}
+
+// Player est une copie des informations publiques d'un joueur
+type Player struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Color    string `json:"color"`
+	IsAI     bool   `json:"is_ai"`
+}
+
+// GameStartEvent est émis quand une partie commence
+type GameStartEvent struct {
+	RoomID   string   `json:"room_id"`
+	RoomName string   `json:"room_name"`
+	Players  []Player `json:"players"`
+}
+
+// GameOverEvent est émis en fin de partie
+type GameOverEvent struct {
+	RoomID   string        `json:"room_id"`
+	Winner   *Player       `json:"winner,omitempty"` // nil si la partie est abandonnée
+	Rankings []Player      `json:"rankings"`
+	Reason   string        `json:"reason"`
+	Duration time.Duration `json:"duration"`
+}
+
+// ChatEvent est émis pour chaque message de chat. Le texte des salles
+// chiffrées n'est pas lisible par le serveur.
+type ChatEvent struct {
+	RoomID    string `json:"room_id"`
+	UserID    int64  `json:"user_id"`
+	Username  string `json:"username"`
+	Text      string `json:"text,omitempty"`
+	Encrypted bool   `json:"encrypted"`
+}
+
+// PlayerJoinEvent est émis quand un joueur rejoint une salle
+type PlayerJoinEvent struct {
+	RoomID string `json:"room_id"`
+	Player Player `json:"player"`
+}
+
+// Registry distribue les événements aux extensions enregistrées. Les
+// événements autres que le chat sont livrés dans l'ordre par une goroutine,
+// pour qu'une extension lente ne bloque pas les parties.
+type Registry struct {
+	hooks  []Hooks
+	events chan func(Hooks)
+	once   sync.Once
+	mu     sync.RWMutex
+}
+
+// eventQueueSize borne les événements en attente de livraison
+const eventQueueSize = 256
+
+// NewRegistry crée un registre vide
+func NewRegistry() *Registry {
+	return &Registry{events: make(chan func(Hooks), eventQueueSize)}
+}
+
+// Register ajoute une extension
+func (r *Registry) Register(h Hooks) {
+	r.mu.Lock()
+	r.hooks = append(r.hooks, h)
+	r.mu.Unlock()
+
+	r.once.Do(func() { go r.deliver() })
+}
+
+// Len retourne le nombre d'extensions enregistrées
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.hooks)
+}
+
+// GameStart notifie le début d'une partie
+func (r *Registry) GameStart(ev GameStartEvent) {
+	r.enqueue(func(h Hooks) { h.OnGameStart(ev) })
+}
+
+// GameOver notifie la fin d'une partie
+func (r *Registry) GameOver(ev GameOverEvent) {
+	r.enqueue(func(h Hooks) { h.OnGameOver(ev) })
+}
+
+// PlayerJoin notifie l'arrivée d'un joueur
+func (r *Registry) PlayerJoin(ev PlayerJoinEvent) {
+	r.enqueue(func(h Hooks) { h.OnPlayerJoin(ev) })
+}
+
+// ChatMessage consulte les extensions de façon synchrone: le message n'est
+// diffusé que si aucune ne le bloque
+func (r *Registry) ChatMessage(ev ChatEvent) bool {
+	r.mu.RLock()
+	hooks := r.hooks
+	r.mu.RUnlock()
+
+	for _, h := range hooks {
+		allowed := true
+		safeCall(func() { allowed = h.OnChatMessage(ev) })
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Registry) enqueue(call func(Hooks)) {
+	if r.Len() == 0 {
+		return
+	}
+	select {
+	case r.events <- call:
+	default:
+		log.Printf("⚠️ Hooks: event queue full, dropping event")
+	}
+}
+
+func (r *Registry) deliver() {
+	for call := range r.events {
+		r.mu.RLock()
+		hooks := r.hooks
+		r.mu.RUnlock()
+
+		for _, h := range hooks {
+			safeCall(func() { call(h) })
+		}
+	}
+}
+
+// safeCall isole le serveur des paniques d'une extension
+func safeCall(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("⚠️ Hooks: extension panicked: %v", r)
+		}
+	}()
+	fn()
+}
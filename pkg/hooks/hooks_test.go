@@ -0,0 +1,62 @@
+package hooks
+
+import (
+	"testing"
+	"time"
+)
+
+type recordingHooks struct {
+	Base
+	rooms   chan string
+	blocked string
+}
+
+func (h *recordingHooks) OnGameOver(ev GameOverEvent) { h.rooms <- ev.RoomID }
+
+func (h *recordingHooks) OnChatMessage(ev ChatEvent) bool { return ev.Text != h.blocked }
+
+type panickingHooks struct{ Base }
+
+func (panickingHooks) OnGameOver(GameOverEvent)     { panic("boom") }
+func (panickingHooks) OnChatMessage(ChatEvent) bool { panic("boom") }
+
+func TestRegistryDeliversInOrder(t *testing.T) {
+	r := NewRegistry()
+	h := &recordingHooks{rooms: make(chan string, 3)}
+	r.Register(panickingHooks{})
+	r.Register(h)
+
+	for _, id := range []string{"A", "B", "C"} {
+		r.GameOver(GameOverEvent{RoomID: id})
+	}
+
+	for _, want := range []string{"A", "B", "C"} {
+		select {
+		case got := <-h.rooms:
+			if got != want {
+				t.Fatalf("got room %s, want %s", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event %s not delivered", want)
+		}
+	}
+}
+
+func TestRegistryChatFilter(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&recordingHooks{blocked: "spam"})
+
+	if !r.ChatMessage(ChatEvent{Text: "hello"}) {
+		t.Error("hello should be allowed")
+	}
+	if r.ChatMessage(ChatEvent{Text: "spam"}) {
+		t.Error("spam should be blocked")
+	}
+
+	// Une extension qui panique ne bloque pas le chat
+	r = NewRegistry()
+	r.Register(panickingHooks{})
+	if !r.ChatMessage(ChatEvent{Text: "hello"}) {
+		t.Error("a panicking hook should not block chat")
+	}
+}
@@ -0,0 +1,39 @@
+// pkg/hooks/plugin.go
+package hooks
+
+import (
+	"fmt"
+	"plugin"
+)
+
+// PluginSymbol est la fonction qu'un plugin Go doit exporter:
+//
+//	func New(host hooks.Host) hooks.Hooks
+//
+// Le plugin se compile avec `go build -buildmode=plugin`, avec la même
+// version de Go et des mêmes dépendances que le serveur.
+const PluginSymbol = "New"
+
+// LoadPlugin ouvre un plugin Go et crée son extension
+func LoadPlugin(path string, host Host) (Hooks, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sym, err := p.Lookup(PluginSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	newHooks, ok := sym.(func(Host) Hooks)
+	if !ok {
+		return nil, fmt.Errorf("%s: %s has type %T, want func(hooks.Host) hooks.Hooks", path, PluginSymbol, sym)
+	}
+
+	h := newHooks(host)
+	if h == nil {
+		return nil, fmt.Errorf("%s: %s returned no hooks", path, PluginSymbol)
+	}
+	return h, nil
+}
@@ -0,0 +1,130 @@
+// pkg/hooks/script.go
+package hooks
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os/exec"
+	"sync"
+)
+
+// Un script est un programme externe, écrit dans n'importe quel langage.
+// Il reçoit un événement JSON par ligne sur son entrée standard:
+//
+//	{"event":"game_over","data":{"room_id":"AB12CD","winner":{...},...}}
+//
+// et peut répondre à tout moment par des actions, une par ligne:
+//
+//	{"action":"announce","room_id":"AB12CD","text":"GG!"}
+//	{"action":"grant_coins","user_id":42,"amount":100}
+//
+// Les scripts ne reçoivent pas les messages de chat: leur réponse étant
+// asynchrone, ils ne pourraient pas les filtrer.
+
+// scriptEvent est une ligne envoyée au script
+type scriptEvent struct {
+	Event string      `json:"event"`
+	Data  interface{} `json:"data"`
+}
+
+// scriptAction est une ligne reçue du script
+type scriptAction struct {
+	Action string `json:"action"`
+	RoomID string `json:"room_id"`
+	Text   string `json:"text"`
+	UserID int64  `json:"user_id"`
+	Amount int    `json:"amount"`
+}
+
+// Script relie un programme externe aux points d'extension
+type Script struct {
+	Base
+	path  string
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	host  Host
+	dead  bool
+	mu    sync.Mutex
+}
+
+// StartScript lance le programme et lit ses actions
+func StartScript(path string, host Host) (*Script, error) {
+	cmd := exec.Command(path)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	s := &Script{path: path, cmd: cmd, stdin: stdin, host: host}
+	go s.readActions(stdout)
+	return s, nil
+}
+
+func (s *Script) OnGameStart(ev GameStartEvent)   { s.send("game_start", ev) }
+func (s *Script) OnGameOver(ev GameOverEvent)     { s.send("game_over", ev) }
+func (s *Script) OnPlayerJoin(ev PlayerJoinEvent) { s.send("player_join", ev) }
+
+// Close arrête le script
+func (s *Script) Close() error {
+	s.mu.Lock()
+	s.dead = true
+	s.stdin.Close()
+	s.mu.Unlock()
+	return s.cmd.Wait()
+}
+
+func (s *Script) send(event string, data interface{}) {
+	line, err := json.Marshal(scriptEvent{Event: event, Data: data})
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dead {
+		return
+	}
+	if _, err := s.stdin.Write(append(line, '\n')); err != nil {
+		log.Printf("⚠️ Hook script %s stopped: %v", s.path, err)
+		s.dead = true
+	}
+}
+
+func (s *Script) readActions(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		var action scriptAction
+		if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+			log.Printf("⚠️ Hook script %s: invalid action: %v", s.path, err)
+			continue
+		}
+		if err := s.apply(action); err != nil {
+			log.Printf("⚠️ Hook script %s: %v", s.path, err)
+		}
+	}
+}
+
+func (s *Script) apply(action scriptAction) error {
+	switch action.Action {
+	case "announce":
+		if action.Text == "" {
+			return fmt.Errorf("announce without text")
+		}
+		s.host.Announce(action.RoomID, action.Text)
+		return nil
+	case "grant_coins":
+		return s.host.GrantCoins(action.UserID, action.Amount)
+	default:
+		return fmt.Errorf("unknown action %q", action.Action)
+	}
+}
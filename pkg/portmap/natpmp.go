@@ -0,0 +1,109 @@
+// pkg/portmap/natpmp.go
+package portmap
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Protocole NAT-PMP (RFC 6886)
+const (
+	natpmpPort       = 5351
+	natpmpOpExternal = 0
+	natpmpOpMapTCP   = 2
+	natpmpRetries    = 4
+	natpmpFirstWait  = 250 * time.Millisecond
+)
+
+// natpmpMap demande au routeur de rediriger le port TCP vers cette machine
+func natpmpMap(gateway net.IP, port int, lifetime time.Duration) (*Mapping, error) {
+	external, err := natpmpCall(gateway, []byte{0, natpmpOpExternal}, 12)
+	if err != nil {
+		return nil, err
+	}
+	ip, err := parseNATPMPExternal(external)
+	if err != nil {
+		return nil, err
+	}
+
+	req := make([]byte, 12)
+	req[1] = natpmpOpMapTCP
+	binary.BigEndian.PutUint16(req[4:], uint16(port))
+	binary.BigEndian.PutUint16(req[6:], uint16(port))
+	binary.BigEndian.PutUint32(req[8:], uint32(lifetime/time.Second))
+
+	resp, err := natpmpCall(gateway, req, 16)
+	if err != nil {
+		return nil, err
+	}
+	mappedPort, granted, err := parseNATPMPMapping(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Mapping{
+		Method:       "NAT-PMP",
+		ExternalIP:   ip,
+		ExternalPort: mappedPort,
+		InternalPort: port,
+		Lifetime:     granted,
+	}
+	m.remove = func() error {
+		// Une durée nulle supprime la redirection
+		binary.BigEndian.PutUint16(req[6:], 0)
+		binary.BigEndian.PutUint32(req[8:], 0)
+		_, err := natpmpCall(gateway, req, 16)
+		return err
+	}
+	return m, nil
+}
+
+// natpmpCall envoie une requête avec les réessais prévus par la RFC
+func natpmpCall(gateway net.IP, req []byte, respLen int) ([]byte, error) {
+	conn, err := net.DialUDP("udp4", nil, &net.UDPAddr{IP: gateway, Port: natpmpPort})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	buf := make([]byte, 16)
+	wait := natpmpFirstWait
+	for try := 0; try < natpmpRetries; try++ {
+		if _, err := conn.Write(req); err != nil {
+			return nil, err
+		}
+		conn.SetReadDeadline(time.Now().Add(wait))
+		n, err := conn.Read(buf)
+		if err == nil && n >= respLen && buf[1] == req[1]+128 {
+			return buf[:n], nil
+		}
+		wait *= 2
+	}
+	return nil, fmt.Errorf("nat-pmp: no answer from gateway %s", gateway)
+}
+
+// parseNATPMPExternal lit la réponse à une demande d'adresse publique
+func parseNATPMPExternal(resp []byte) (net.IP, error) {
+	if len(resp) < 12 {
+		return nil, fmt.Errorf("nat-pmp: short response")
+	}
+	if code := binary.BigEndian.Uint16(resp[2:]); code != 0 {
+		return nil, fmt.Errorf("nat-pmp: gateway error %d", code)
+	}
+	return net.IPv4(resp[8], resp[9], resp[10], resp[11]), nil
+}
+
+// parseNATPMPMapping lit la réponse à une demande de redirection
+func parseNATPMPMapping(resp []byte) (int, time.Duration, error) {
+	if len(resp) < 16 {
+		return 0, 0, fmt.Errorf("nat-pmp: short response")
+	}
+	if code := binary.BigEndian.Uint16(resp[2:]); code != 0 {
+		return 0, 0, fmt.Errorf("nat-pmp: gateway error %d", code)
+	}
+	port := int(binary.BigEndian.Uint16(resp[10:]))
+	lifetime := time.Duration(binary.BigEndian.Uint32(resp[12:])) * time.Second
+	return port, lifetime, nil
+}
@@ -0,0 +1,166 @@
+// pkg/portmap/portmap.go
+package portmap
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLifetime est la durée demandée au routeur pour une redirection
+const DefaultLifetime = time.Hour
+
+// Mapping est une redirection de port active sur le routeur
+type Mapping struct {
+	Method       string // "NAT-PMP" ou "UPnP"
+	ExternalIP   net.IP
+	ExternalPort int
+	InternalPort int
+	Lifetime     time.Duration
+
+	remove func() error
+	stop   chan struct{}
+	once   sync.Once
+	mu     sync.Mutex
+}
+
+// PublicAddress retourne l'adresse à communiquer aux joueurs hors du LAN
+func (m *Mapping) PublicAddress() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return net.JoinHostPort(m.ExternalIP.String(), strconv.Itoa(m.ExternalPort))
+}
+
+// Map redirige le port TCP du routeur vers cette machine, en essayant
+// NAT-PMP puis UPnP. La redirection est renouvelée jusqu'à Close.
+func Map(port int) (*Mapping, error) {
+	m, err := mapOnce(port, DefaultLifetime)
+	if err != nil {
+		return nil, err
+	}
+
+	m.stop = make(chan struct{})
+	go m.renew(port)
+	return m, nil
+}
+
+// mapOnce tente chaque méthode et retourne la première qui réussit
+func mapOnce(port int, lifetime time.Duration) (*Mapping, error) {
+	var errs []string
+
+	if gateway, err := defaultGateway(); err == nil {
+		m, err := natpmpMap(gateway, port, lifetime)
+		if err == nil {
+			return m, nil
+		}
+		errs = append(errs, err.Error())
+	} else {
+		errs = append(errs, err.Error())
+	}
+
+	m, err := upnpMap(port, lifetime)
+	if err == nil {
+		return m, nil
+	}
+	errs = append(errs, err.Error())
+
+	return nil, fmt.Errorf("port mapping failed: %s", strings.Join(errs, "; "))
+}
+
+// renew redemande la redirection à mi-durée de vie
+func (m *Mapping) renew(port int) {
+	for {
+		m.mu.Lock()
+		wait := m.Lifetime / 2
+		m.mu.Unlock()
+		if wait < time.Minute {
+			wait = time.Minute
+		}
+
+		select {
+		case <-m.stop:
+			return
+		case <-time.After(wait):
+		}
+
+		fresh, err := mapOnce(port, DefaultLifetime)
+		if err != nil {
+			continue
+		}
+		m.mu.Lock()
+		m.ExternalIP = fresh.ExternalIP
+		m.Lifetime = fresh.Lifetime
+		m.remove = fresh.remove
+		m.mu.Unlock()
+	}
+}
+
+// Close arrête le renouvellement et supprime la redirection
+func (m *Mapping) Close() error {
+	var err error
+	m.once.Do(func() {
+		if m.stop != nil {
+			close(m.stop)
+		}
+		m.mu.Lock()
+		remove := m.remove
+		m.mu.Unlock()
+		if remove != nil {
+			err = remove()
+		}
+	})
+	return err
+}
+
+// defaultGateway lit la passerelle par défaut, ou devine "x.y.z.1"
+// à partir de l'adresse locale quand la table de routage est illisible.
+func defaultGateway() (net.IP, error) {
+	if file, err := os.Open("/proc/net/route"); err == nil {
+		defer file.Close()
+		if gateway := parseRouteTable(bufio.NewScanner(file)); gateway != nil {
+			return gateway, nil
+		}
+	}
+
+	conn, err := net.Dial("udp4", "192.0.2.1:9")
+	if err != nil {
+		return nil, fmt.Errorf("no default gateway: %v", err)
+	}
+	defer conn.Close()
+
+	local := conn.LocalAddr().(*net.UDPAddr).IP.To4()
+	if local == nil {
+		return nil, fmt.Errorf("no IPv4 default gateway")
+	}
+	return net.IPv4(local[0], local[1], local[2], 1), nil
+}
+
+// parseRouteTable extrait la passerelle de la route par défaut (Linux)
+func parseRouteTable(scanner *bufio.Scanner) net.IP {
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+		raw, err := hex.DecodeString(fields[2])
+		if err != nil || len(raw) != 4 {
+			continue
+		}
+		// La table est en ordre d'octets de la machine (petit boutiste)
+		value := binary.LittleEndian.Uint32(raw)
+		if value == 0 {
+			continue
+		}
+		gateway := make(net.IP, 4)
+		binary.BigEndian.PutUint32(gateway, value)
+		return gateway
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// pkg/portmap/portmap_test.go
+package portmap
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRouteTable(t *testing.T) {
+	table := "Iface\tDestination\tGateway \tFlags\n" +
+		"eth0\t0001A8C0\t00000000\t0001\n" +
+		"eth0\t00000000\t0101A8C0\t0003\n"
+
+	gateway := parseRouteTable(bufio.NewScanner(strings.NewReader(table)))
+	if gateway == nil || gateway.String() != "192.168.1.1" {
+		t.Fatalf("gateway = %v, want 192.168.1.1", gateway)
+	}
+}
+
+func TestParseNATPMPResponses(t *testing.T) {
+	external := []byte{0, 128, 0, 0, 0, 0, 0, 1, 203, 0, 113, 7}
+	ip, err := parseNATPMPExternal(external)
+	if err != nil || ip.String() != "203.0.113.7" {
+		t.Fatalf("external = %v, %v", ip, err)
+	}
+
+	mapping := []byte{0, 130, 0, 0, 0, 0, 0, 1, 0x1f, 0x90, 0x1f, 0x91, 0, 0, 0x0e, 0x10}
+	port, lifetime, err := parseNATPMPMapping(mapping)
+	if err != nil || port != 8081 || lifetime != time.Hour {
+		t.Fatalf("mapping = %d %v %v", port, lifetime, err)
+	}
+
+	refused := []byte{0, 130, 0, 2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0}
+	if _, _, err := parseNATPMPMapping(refused); err == nil {
+		t.Fatal("expected an error for a refused mapping")
+	}
+}
+
+func TestFindWANService(t *testing.T) {
+	description := `<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+  <device>
+    <deviceList>
+      <device>
+        <deviceList>
+          <device>
+            <serviceList>
+              <service>
+                <serviceType>urn:schemas-upnp-org:service:WANIPConnection:1</serviceType>
+                <controlURL>/ctl/IPConn</controlURL>
+              </service>
+            </serviceList>
+          </device>
+        </deviceList>
+      </device>
+    </deviceList>
+  </device>
+</root>`
+
+	serviceType, control, err := findWANService(strings.NewReader(description), "http://192.168.1.1:5000/rootDesc.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serviceType != "urn:schemas-upnp-org:service:WANIPConnection:1" {
+		t.Errorf("service type = %s", serviceType)
+	}
+	if control != "http://192.168.1.1:5000/ctl/IPConn" {
+		t.Errorf("control URL = %s", control)
+	}
+}
@@ -0,0 +1,256 @@
+// pkg/portmap/upnp.go
+package portmap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Découverte et commande d'une passerelle UPnP IGD
+const (
+	ssdpAddr        = "239.255.255.250:1900"
+	ssdpWait        = 2 * time.Second
+	upnpHTTPTimeout = 5 * time.Second
+	igdSearchTarget = "urn:schemas-upnp-org:device:InternetGatewayDevice:1"
+)
+
+// Services WAN capables de rediriger des ports
+var wanServices = []string{
+	"urn:schemas-upnp-org:service:WANIPConnection:1",
+	"urn:schemas-upnp-org:service:WANIPConnection:2",
+	"urn:schemas-upnp-org:service:WANPPPConnection:1",
+}
+
+type upnpRoot struct {
+	URLBase string     `xml:"URLBase"`
+	Device  upnpDevice `xml:"device"`
+}
+
+type upnpDevice struct {
+	Devices  []upnpDevice  `xml:"deviceList>device"`
+	Services []upnpService `xml:"serviceList>service"`
+}
+
+type upnpService struct {
+	ServiceType string `xml:"serviceType"`
+	ControlURL  string `xml:"controlURL"`
+}
+
+// upnpMap redirige le port via la première passerelle IGD trouvée
+func upnpMap(port int, lifetime time.Duration) (*Mapping, error) {
+	location, err := ssdpDiscover()
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: upnpHTTPTimeout}
+	resp, err := client.Get(location)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	serviceType, controlURL, err := findWANService(resp.Body, location)
+	if err != nil {
+		return nil, err
+	}
+
+	localIP, err := localAddressFor(controlURL)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{
+		"NewRemoteHost", "",
+		"NewExternalPort", strconv.Itoa(port),
+		"NewProtocol", "TCP",
+		"NewInternalPort", strconv.Itoa(port),
+		"NewInternalClient", localIP.String(),
+		"NewEnabled", "1",
+		"NewPortMappingDescription", "Ludo King server",
+		"NewLeaseDuration", strconv.Itoa(int(lifetime / time.Second)),
+	}
+	if _, err := soapCall(client, controlURL, serviceType, "AddPortMapping", args...); err != nil {
+		return nil, err
+	}
+
+	body, err := soapCall(client, controlURL, serviceType, "GetExternalIPAddress")
+	if err != nil {
+		return nil, err
+	}
+	externalIP := net.ParseIP(xmlValue(body, "NewExternalIPAddress"))
+
+	m := &Mapping{
+		Method:       "UPnP",
+		ExternalIP:   externalIP,
+		ExternalPort: port,
+		InternalPort: port,
+		Lifetime:     lifetime,
+	}
+	m.remove = func() error {
+		_, err := soapCall(client, controlURL, serviceType, "DeletePortMapping",
+			"NewRemoteHost", "", "NewExternalPort", strconv.Itoa(port), "NewProtocol", "TCP")
+		return err
+	}
+	return m, nil
+}
+
+// ssdpDiscover cherche une passerelle et retourne l'URL de sa description
+func ssdpDiscover() (string, error) {
+	conn, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	dest, err := net.ResolveUDPAddr("udp4", ssdpAddr)
+	if err != nil {
+		return "", err
+	}
+
+	search := "M-SEARCH * HTTP/1.1\r\n" +
+		"HOST: " + ssdpAddr + "\r\n" +
+		"MAN: \"ssdp:discover\"\r\n" +
+		"MX: 2\r\n" +
+		"ST: " + igdSearchTarget + "\r\n\r\n"
+	if _, err := conn.WriteToUDP([]byte(search), dest); err != nil {
+		return "", err
+	}
+
+	conn.SetReadDeadline(time.Now().Add(ssdpWait))
+	buf := make([]byte, 2048)
+	for {
+		n, _, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			return "", fmt.Errorf("upnp: no internet gateway found")
+		}
+		resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buf[:n])), nil)
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		if location := resp.Header.Get("Location"); location != "" {
+			return location, nil
+		}
+	}
+}
+
+// findWANService trouve le service WAN et son URL de contrôle absolue
+func findWANService(description io.Reader, location string) (string, string, error) {
+	var root upnpRoot
+	if err := xml.NewDecoder(description).Decode(&root); err != nil {
+		return "", "", err
+	}
+
+	base := location
+	if root.URLBase != "" {
+		base = root.URLBase
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", "", err
+	}
+
+	var walk func(d upnpDevice) (upnpService, bool)
+	walk = func(d upnpDevice) (upnpService, bool) {
+		for _, service := range d.Services {
+			for _, wanted := range wanServices {
+				if service.ServiceType == wanted {
+					return service, true
+				}
+			}
+		}
+		for _, child := range d.Devices {
+			if service, ok := walk(child); ok {
+				return service, true
+			}
+		}
+		return upnpService{}, false
+	}
+
+	service, ok := walk(root.Device)
+	if !ok {
+		return "", "", fmt.Errorf("upnp: gateway has no WAN connection service")
+	}
+	control, err := baseURL.Parse(service.ControlURL)
+	if err != nil {
+		return "", "", err
+	}
+	return service.ServiceType, control.String(), nil
+}
+
+// soapCall invoque une action UPnP; args alterne noms et valeurs
+func soapCall(client *http.Client, controlURL, serviceType, action string, args ...string) ([]byte, error) {
+	var body strings.Builder
+	body.WriteString(`<?xml version="1.0"?>` +
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">` +
+		`<s:Body><u:` + action + ` xmlns:u="` + serviceType + `">`)
+	for i := 0; i+1 < len(args); i += 2 {
+		body.WriteString("<" + args[i] + ">")
+		xml.EscapeText(&body, []byte(args[i+1]))
+		body.WriteString("</" + args[i] + ">")
+	}
+	body.WriteString(`</u:` + action + `></s:Body></s:Envelope>`)
+
+	req, err := http.NewRequest(http.MethodPost, controlURL, strings.NewReader(body.String()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	req.Header.Set("SOAPAction", `"`+serviceType+"#"+action+`"`)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("upnp: %s failed: %s", action, resp.Status)
+	}
+	return data, nil
+}
+
+// xmlValue extrait le texte du premier élément nommé name
+func xmlValue(data []byte, name string) string {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return ""
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == name {
+			var value string
+			decoder.DecodeElement(&value, &start)
+			return strings.TrimSpace(value)
+		}
+	}
+}
+
+// localAddressFor retourne l'adresse locale utilisée pour joindre l'URL
+func localAddressFor(rawURL string) (net.IP, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	host := u.Hostname()
+	conn, err := net.Dial("udp4", net.JoinHostPort(host, "1900"))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
@@ -0,0 +1,176 @@
+// pkg/proxy/proxy.go
+
+// Package proxy ouvre des connexions TCP à travers un proxy SOCKS5 ou HTTP
+// (méthode CONNECT), pour les joueurs derrière un réseau d'entreprise. Le
+// proxy se décrit par une URL: socks5://user:pass@hôte:1080, http://hôte:3128.
+package proxy
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// dialTimeout borne la connexion au proxy et sa poignée de main
+const dialTimeout = 10 * time.Second
+
+// Dialer ouvre des connexions par un proxy
+type Dialer struct {
+	URL    *url.URL
+	bypass []string // Hôtes joints directement (NO_PROXY)
+}
+
+// Parse lit l'URL d'un proxy. Sans schéma, le proxy est supposé HTTP.
+func Parse(raw string) (*Dialer, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy %q: %w", raw, err)
+	}
+	switch u.Scheme {
+	case "socks5", "socks5h", "http":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (socks5 or http)", u.Scheme)
+	}
+	if u.Port() == "" {
+		return nil, fmt.Errorf("proxy %q has no port", u.Host)
+	}
+	return &Dialer{URL: u}, nil
+}
+
+// FromEnvironment retourne le proxy des variables ALL_PROXY, HTTPS_PROXY ou
+// HTTP_PROXY (en majuscules ou minuscules), nil si aucune n'est définie.
+// Les hôtes de NO_PROXY sont joints directement.
+func FromEnvironment() (*Dialer, error) {
+	for _, name := range []string{"ALL_PROXY", "HTTPS_PROXY", "HTTP_PROXY"} {
+		raw := getenv(name)
+		if raw == "" {
+			continue
+		}
+		d, err := Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		for _, host := range strings.Split(getenv("NO_PROXY"), ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				d.bypass = append(d.bypass, host)
+			}
+		}
+		return d, nil
+	}
+	return nil, nil
+}
+
+// getenv lit une variable en majuscules, à défaut en minuscules
+func getenv(name string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return os.Getenv(strings.ToLower(name))
+}
+
+// Dial ouvre une connexion TCP vers addr (hôte:port) à travers le proxy
+func (d *Dialer) Dial(addr string) (net.Conn, error) {
+	if d.bypasses(addr) {
+		return net.DialTimeout("tcp", addr, dialTimeout)
+	}
+	return d.dialProxy(addr)
+}
+
+// dialProxy se connecte au proxy et lui demande un tunnel vers addr
+func (d *Dialer) dialProxy(addr string) (net.Conn, error) {
+	conn, err := net.DialTimeout("tcp", d.URL.Host, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("proxy %s: %w", d.URL.Host, err)
+	}
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	if d.URL.Scheme == "http" {
+		conn, err = connectHTTP(conn, addr, d.URL.User)
+	} else {
+		err = connectSOCKS5(conn, addr, d.URL.User)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("proxy %s: %w", d.URL.Host, err)
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// Transport retourne un transport HTTP qui passe par le proxy
+func (d *Dialer) Transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		if d.bypasses(req.URL.Host) {
+			return nil, nil
+		}
+		return d.URL, nil
+	}
+	return transport
+}
+
+// bypasses indique si addr figure dans NO_PROXY (hôte exact, sous-domaine
+// ou "*"). Les adresses de bouclage ne passent jamais par le proxy.
+func (d *Dialer) bypasses(addr string) bool {
+	host := addr
+	if h, _, err := net.SplitHostPort(addr); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return true
+	}
+	for _, pattern := range d.bypass {
+		pattern = strings.TrimPrefix(pattern, "*")
+		if pattern == "" || host == strings.TrimPrefix(pattern, ".") || strings.HasSuffix(host, "."+strings.TrimPrefix(pattern, ".")) {
+			return true
+		}
+	}
+	return false
+}
+
+// connectHTTP demande au proxy HTTP un tunnel vers addr
+func connectHTTP(conn net.Conn, addr string, user *url.Userinfo) (net.Conn, error) {
+	request := fmt.Sprintf("CONNECT %s HTTP/1.1\r\nHost: %s\r\n", addr, addr)
+	if user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		request += "Proxy-Authorization: Basic " + credentials + "\r\n"
+	}
+	if _, err := conn.Write([]byte(request + "\r\n")); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, &http.Request{Method: http.MethodConnect})
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CONNECT refused: %s", resp.Status)
+	}
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
+
+// bufferedConn rend les octets déjà lus avec la réponse du proxy
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
@@ -0,0 +1,168 @@
+// pkg/proxy/proxy_test.go
+package proxy
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+)
+
+// echoTarget est le serveur joint à travers le proxy
+func echoTarget(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go io.Copy(conn, conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// fakeProxy accepte une connexion, la traite avec serve puis la relie à target
+func fakeProxy(t *testing.T, target string, serve func(conn net.Conn, reader *bufio.Reader) bool) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		if !serve(conn, reader) {
+			return
+		}
+		upstream, err := net.Dial("tcp", target)
+		if err != nil {
+			return
+		}
+		defer upstream.Close()
+		go io.Copy(upstream, reader)
+		io.Copy(conn, upstream)
+	}()
+	return listener.Addr().String()
+}
+
+// roundTrip vérifie que la connexion atteint bien le serveur d'écho
+func roundTrip(t *testing.T, conn net.Conn) {
+	t.Helper()
+	defer conn.Close()
+	if _, err := conn.Write([]byte("ping\n")); err != nil {
+		t.Fatal(err)
+	}
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil || line != "ping\n" {
+		t.Fatalf("echo = %q, %v", line, err)
+	}
+}
+
+func TestDialSOCKS5WithPassword(t *testing.T) {
+	target := echoTarget(t)
+	addr := fakeProxy(t, target, func(conn net.Conn, r *bufio.Reader) bool {
+		greeting := make([]byte, 4) // version, 2 méthodes, sans auth, mot de passe
+		io.ReadFull(r, greeting)
+		conn.Write([]byte{5, socksPassword})
+
+		header := make([]byte, 2)
+		io.ReadFull(r, header)
+		login := make([]byte, int(header[1])+1)
+		io.ReadFull(r, login)
+		password := make([]byte, int(login[len(login)-1]))
+		io.ReadFull(r, password)
+		if string(login[:len(login)-1]) != "alice" || string(password) != "secret" {
+			conn.Write([]byte{1, 1})
+			return false
+		}
+		conn.Write([]byte{1, 0})
+
+		request := make([]byte, 4+net.IPv4len+2)
+		io.ReadFull(r, request)
+		conn.Write([]byte{5, 0, 0, socksIPv4, 0, 0, 0, 0, 0, 0})
+		return true
+	})
+
+	d, err := Parse("socks5://alice:secret@" + addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := d.dialProxy(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip(t, conn)
+}
+
+func TestDialHTTPConnect(t *testing.T) {
+	target := echoTarget(t)
+	addr := fakeProxy(t, target, func(conn net.Conn, r *bufio.Reader) bool {
+		req, err := http.ReadRequest(r)
+		if err != nil || req.Method != http.MethodConnect || req.Host != target {
+			conn.Write([]byte("HTTP/1.1 400 Bad Request\r\n\r\n"))
+			return false
+		}
+		conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		return true
+	})
+
+	d, err := Parse(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := d.dialProxy(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip(t, conn)
+}
+
+func TestParse(t *testing.T) {
+	for _, raw := range []string{"ftp://proxy:21", "socks5://proxy", ""} {
+		if _, err := Parse(raw); err == nil {
+			t.Errorf("Parse(%q) should fail", raw)
+		}
+	}
+	if d, err := Parse("proxy.corp:3128"); err != nil || d.URL.Scheme != "http" {
+		t.Errorf("Parse without scheme = %v, %v; want an HTTP proxy", d, err)
+	}
+}
+
+func TestFromEnvironment(t *testing.T) {
+	for _, name := range []string{"ALL_PROXY", "HTTPS_PROXY", "HTTP_PROXY", "all_proxy", "https_proxy", "http_proxy"} {
+		t.Setenv(name, "")
+	}
+	if d, err := FromEnvironment(); d != nil || err != nil {
+		t.Fatalf("no variable: %v, %v; want nil", d, err)
+	}
+
+	t.Setenv("https_proxy", "socks5://proxy.corp:1080")
+	t.Setenv("NO_PROXY", "intranet.corp, .lan")
+	d, err := FromEnvironment()
+	if err != nil || d == nil || d.URL.Host != "proxy.corp:1080" {
+		t.Fatalf("FromEnvironment = %v, %v", d, err)
+	}
+
+	for addr, want := range map[string]bool{
+		"intranet.corp:8080":   true,
+		"game.intranet.corp:1": true,
+		"box.lan:8080":         true,
+		"127.0.0.1:8080":       true,
+		"ludo.example.org:80":  false,
+	} {
+		if got := d.bypasses(addr); got != want {
+			t.Errorf("bypasses(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
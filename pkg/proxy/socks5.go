@@ -0,0 +1,151 @@
+// pkg/proxy/socks5.go
+package proxy
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+)
+
+// Constantes du protocole SOCKS5 (RFC 1928, RFC 1929)
+const (
+	socksVersion  = 5
+	socksNoAuth   = 0
+	socksPassword = 2
+	socksConnect  = 1
+	socksIPv4     = 1
+	socksDomain   = 3
+	socksIPv6     = 4
+)
+
+// socksErrors décrit les codes d'échec d'une commande SOCKS5
+var socksErrors = map[byte]string{
+	1: "general failure",
+	2: "connection not allowed by ruleset",
+	3: "network unreachable",
+	4: "host unreachable",
+	5: "connection refused",
+	6: "TTL expired",
+	7: "command not supported",
+	8: "address type not supported",
+}
+
+// connectSOCKS5 négocie l'authentification puis demande une connexion à addr.
+// Le nom d'hôte est transmis tel quel: le proxy le résout lui-même.
+func connectSOCKS5(conn net.Conn, addr string, user *url.Userinfo) error {
+	host, portText, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil {
+		return fmt.Errorf("invalid port %q", portText)
+	}
+
+	methods := []byte{socksNoAuth}
+	if user != nil {
+		methods = append(methods, socksPassword)
+	}
+	if _, err := conn.Write(append([]byte{socksVersion, byte(len(methods))}, methods...)); err != nil {
+		return err
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[0] != socksVersion {
+		return errors.New("not a SOCKS5 proxy")
+	}
+
+	switch reply[1] {
+	case socksNoAuth:
+	case socksPassword:
+		if user == nil {
+			return errors.New("SOCKS5 proxy requires a username and password")
+		}
+		if err := socksLogin(conn, user); err != nil {
+			return err
+		}
+	default:
+		return errors.New("SOCKS5 proxy refused all authentication methods")
+	}
+
+	request := []byte{socksVersion, socksConnect, 0}
+	switch ip := net.ParseIP(host); {
+	case ip == nil:
+		if len(host) > 255 {
+			return fmt.Errorf("host name too long: %q", host)
+		}
+		request = append(request, socksDomain, byte(len(host)))
+		request = append(request, host...)
+	case ip.To4() != nil:
+		request = append(request, socksIPv4)
+		request = append(request, ip.To4()...)
+	default:
+		request = append(request, socksIPv6)
+		request = append(request, ip.To16()...)
+	}
+	request = binary.BigEndian.AppendUint16(request, uint16(port))
+	if _, err := conn.Write(request); err != nil {
+		return err
+	}
+
+	// Réponse: version, code, réservé, type d'adresse, adresse liée, port
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return err
+	}
+	if header[1] != 0 {
+		if reason, ok := socksErrors[header[1]]; ok {
+			return fmt.Errorf("SOCKS5 connect failed: %s", reason)
+		}
+		return fmt.Errorf("SOCKS5 connect failed: code %d", header[1])
+	}
+
+	var bound int
+	switch header[3] {
+	case socksIPv4:
+		bound = net.IPv4len
+	case socksIPv6:
+		bound = net.IPv6len
+	case socksDomain:
+		size := make([]byte, 1)
+		if _, err := io.ReadFull(conn, size); err != nil {
+			return err
+		}
+		bound = int(size[0])
+	default:
+		return fmt.Errorf("SOCKS5 reply with unknown address type %d", header[3])
+	}
+	_, err = io.ReadFull(conn, make([]byte, bound+2))
+	return err
+}
+
+// socksLogin s'authentifie par nom et mot de passe (RFC 1929)
+func socksLogin(conn net.Conn, user *url.Userinfo) error {
+	password, _ := user.Password()
+	if len(user.Username()) > 255 || len(password) > 255 {
+		return errors.New("SOCKS5 credentials too long")
+	}
+
+	request := []byte{1, byte(len(user.Username()))}
+	request = append(request, user.Username()...)
+	request = append(request, byte(len(password)))
+	request = append(request, password...)
+	if _, err := conn.Write(request); err != nil {
+		return err
+	}
+
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[1] != 0 {
+		return errors.New("SOCKS5 proxy rejected the credentials")
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// pkg/rating/rating.go
+package rating
+
+import (
+	"math"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+)
+
+// Bornes de l'incertitude de la cote
+const (
+	MinDeviation     = 50.0  // Plancher: la cote suit encore la forme du joueur
+	SettledDeviation = 100.0 // Plafond une fois le placement terminé
+)
+
+// q est la constante de Glicko, ln(10)/400
+var q = math.Ln10 / 400
+
+// Opponent est un adversaire d'une partie, avec sa cote d'avant la partie
+type Opponent struct {
+	Rating    float64
+	Deviation float64
+	Score     float64 // 1 fini devant lui, 0 derrière, 0.5 sans classement entre eux
+}
+
+// Pairwise découpe le classement final d'une partie à plusieurs en duels:
+// le score de a contre b est 1 s'il finit devant, 0 derrière et 0.5 si ni
+// l'un ni l'autre n'est classé. Une place de 0 signifie non classé.
+func Pairwise(placeA, placeB int) float64 {
+	switch {
+	case placeA > 0 && (placeB == 0 || placeA < placeB):
+		return 1
+	case placeB > 0 && (placeA == 0 || placeB < placeA):
+		return 0
+	}
+	return 0.5
+}
+
+// Update retourne la cote et l'incertitude après une partie (Glicko-1, une
+// partie par période). games est le nombre de parties classées jouées,
+// celle-ci comprise: l'incertitude ne dépasse pas PlacementDeviation(games),
+// si bien qu'au bout des parties de placement la cote est posée.
+func Update(rating, deviation float64, games int, opponents []Opponent) (float64, float64) {
+	if deviation <= 0 {
+		rating, deviation = constants.RatingInitial, constants.RatingInitialDeviation
+	}
+
+	var information, gain float64
+	for _, opponent := range opponents {
+		g := weight(opponent.Deviation)
+		expected := Expected(rating, opponent.Rating, opponent.Deviation)
+		information += q * q * g * g * expected * (1 - expected)
+		gain += g * (opponent.Score - expected)
+	}
+
+	variance := 1 / (1/(deviation*deviation) + information)
+	rating += q * variance * gain
+
+	deviation = math.Min(math.Sqrt(variance), PlacementDeviation(games))
+	return rating, math.Max(deviation, MinDeviation)
+}
+
+// Expected est le score attendu contre un adversaire, d'autant plus proche
+// de 0.5 que sa cote est incertaine
+func Expected(rating, opponent, opponentDeviation float64) float64 {
+	return 1 / (1 + math.Pow(10, -weight(opponentDeviation)*(rating-opponent)/400))
+}
+
+// weight réduit le poids d'un adversaire dont la cote est incertaine
+func weight(deviation float64) float64 {
+	return 1 / math.Sqrt(1+3*q*q*deviation*deviation/(math.Pi*math.Pi))
+}
+
+// PlacementDeviation retourne l'incertitude maximale après games parties
+// classées: elle descend en ligne droite de l'incertitude initiale à
+// SettledDeviation sur les parties de placement
+func PlacementDeviation(games int) float64 {
+	if games >= constants.PlacementGames {
+		return SettledDeviation
+	}
+	step := (constants.RatingInitialDeviation - SettledDeviation) / constants.PlacementGames
+	return constants.RatingInitialDeviation - step*float64(games)
+}
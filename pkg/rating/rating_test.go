@@ -0,0 +1,51 @@
+// pkg/rating/rating_test.go
+package rating
+
+import (
+	"math"
+	"testing"
+
+	"github.com/obrien-tchaleu/ludo-king-go/internal/shared/constants"
+)
+
+func TestPairwiseFollowsFinalRanking(t *testing.T) {
+	tests := []struct {
+		a, b int
+		want float64
+	}{
+		{a: 1, b: 3, want: 1},
+		{a: 4, b: 2, want: 0},
+		{a: 2, b: 0, want: 1}, // Un joueur non classé finit derrière
+		{a: 0, b: 0, want: 0.5},
+	}
+	for _, tt := range tests {
+		if got := Pairwise(tt.a, tt.b); got != tt.want {
+			t.Errorf("Pairwise(%d, %d) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestUpdateIsZeroSumBetweenEquals(t *testing.T) {
+	winner, _ := Update(1500, 80, 20, []Opponent{{Rating: 1500, Deviation: 80, Score: 1}})
+	loser, _ := Update(1500, 80, 20, []Opponent{{Rating: 1500, Deviation: 80, Score: 0}})
+	if winner <= 1500 || math.Abs((winner-1500)-(1500-loser)) > 1e-9 {
+		t.Errorf("winner %.2f and loser %.2f should move by the same amount", winner, loser)
+	}
+}
+
+func TestUpdateBeatingStrongerPaysMore(t *testing.T) {
+	upset, _ := Update(1500, 80, 20, []Opponent{{Rating: 1800, Deviation: 80, Score: 1}})
+	expected, _ := Update(1500, 80, 20, []Opponent{{Rating: 1200, Deviation: 80, Score: 1}})
+	if upset-1500 <= expected-1500 {
+		t.Errorf("beating a stronger player gained %.1f, a weaker one %.1f", upset-1500, expected-1500)
+	}
+}
+
+func TestPlacementDeviationReachesSettled(t *testing.T) {
+	if got := PlacementDeviation(0); got != constants.RatingInitialDeviation {
+		t.Errorf("PlacementDeviation(0) = %v", got)
+	}
+	if got := PlacementDeviation(constants.PlacementGames); got != SettledDeviation {
+		t.Errorf("PlacementDeviation(%d) = %v, want %v", constants.PlacementGames, got, SettledDeviation)
+	}
+}
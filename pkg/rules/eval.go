@@ -0,0 +1,172 @@
+// pkg/rules/eval.go
+package rules
+
+import "fmt"
+
+// node est un nœud typé de l'arbre d'une expression
+type node interface {
+	typ() Type
+	eval(env Env) (Value, error)
+}
+
+type literal struct{ v Value }
+
+func (l literal) typ() Type               { return l.v.typ }
+func (l literal) eval(Env) (Value, error) { return l.v, nil }
+
+type variable struct {
+	name string
+	t    Type
+}
+
+func (v variable) typ() Type { return v.t }
+
+// Une variable absente de l'environnement vaut zéro ou faux
+func (v variable) eval(env Env) (Value, error) {
+	if value, ok := env[v.name]; ok && value.typ == v.t {
+		return value, nil
+	}
+	return Value{typ: v.t}, nil
+}
+
+type unary struct {
+	op      string
+	operand node
+}
+
+func newUnary(op string, operand node) (node, error) {
+	want := Int
+	if op == "!" {
+		want = Bool
+	}
+	if operand.typ() != want {
+		return nil, fmt.Errorf("%s needs %s, got %s", op, want, operand.typ())
+	}
+	return unary{op: op, operand: operand}, nil
+}
+
+func (u unary) typ() Type { return u.operand.typ() }
+
+func (u unary) eval(env Env) (Value, error) {
+	v, err := u.operand.eval(env)
+	if err != nil {
+		return v, err
+	}
+	if u.op == "!" {
+		return BoolValue(!v.b), nil
+	}
+	return IntValue(-v.i), nil
+}
+
+type binary struct {
+	op          string
+	left, right node
+	result      Type
+}
+
+func newBinary(op string, left, right node) (node, error) {
+	lt, rt := left.typ(), right.typ()
+	switch op {
+	case "&&", "||":
+		if lt != Bool || rt != Bool {
+			return nil, fmt.Errorf("%s needs bool operands", op)
+		}
+		return binary{op, left, right, Bool}, nil
+	case "==", "!=":
+		if lt != rt {
+			return nil, fmt.Errorf("cannot compare %s with %s", lt, rt)
+		}
+		return binary{op, left, right, Bool}, nil
+	case "<", "<=", ">", ">=":
+		if lt != Int || rt != Int {
+			return nil, fmt.Errorf("%s needs int operands", op)
+		}
+		return binary{op, left, right, Bool}, nil
+	default:
+		if lt != Int || rt != Int {
+			return nil, fmt.Errorf("%s needs int operands", op)
+		}
+		return binary{op, left, right, Int}, nil
+	}
+}
+
+func (b binary) typ() Type { return b.result }
+
+func (b binary) eval(env Env) (Value, error) {
+	l, err := b.left.eval(env)
+	if err != nil {
+		return l, err
+	}
+
+	// Évaluation paresseuse des opérateurs logiques
+	switch {
+	case b.op == "&&" && !l.b:
+		return BoolValue(false), nil
+	case b.op == "||" && l.b:
+		return BoolValue(true), nil
+	}
+
+	r, err := b.right.eval(env)
+	if err != nil {
+		return r, err
+	}
+
+	switch b.op {
+	case "&&", "||":
+		return BoolValue(r.b), nil
+	case "==":
+		return BoolValue(l == r), nil
+	case "!=":
+		return BoolValue(l != r), nil
+	case "<":
+		return BoolValue(l.i < r.i), nil
+	case "<=":
+		return BoolValue(l.i <= r.i), nil
+	case ">":
+		return BoolValue(l.i > r.i), nil
+	case ">=":
+		return BoolValue(l.i >= r.i), nil
+	case "+":
+		return IntValue(l.i + r.i), nil
+	case "-":
+		return IntValue(l.i - r.i), nil
+	case "*":
+		return IntValue(l.i * r.i), nil
+	case "/", "%":
+		if r.i == 0 {
+			return Value{}, fmt.Errorf("division by zero")
+		}
+		if b.op == "/" {
+			return IntValue(l.i / r.i), nil
+		}
+		return IntValue(l.i % r.i), nil
+	}
+	return Value{}, fmt.Errorf("unknown operator %s", b.op)
+}
+
+type cond struct {
+	test, then, otherwise node
+}
+
+func newCond(test, then, otherwise node) (node, error) {
+	if test.typ() != Bool {
+		return nil, fmt.Errorf("condition must be bool, got %s", test.typ())
+	}
+	if then.typ() != otherwise.typ() {
+		return nil, fmt.Errorf("conditional branches differ: %s and %s", then.typ(), otherwise.typ())
+	}
+	return cond{test, then, otherwise}, nil
+}
+
+func (c cond) typ() Type { return c.then.typ() }
+
+func (c cond) eval(env Env) (Value, error) {
+	t, err := c.test.eval(env)
+	if err != nil {
+		return t, err
+	}
+	if t.b {
+		return c.then.eval(env)
+	}
+	return c.otherwise.eval(env)
+}
@@ -0,0 +1,242 @@
+// pkg/rules/parse.go
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+// Grammaire, de la priorité la plus faible à la plus forte:
+//
+//	ternary = or [ "?" ternary ":" ternary ]
+//	or      = and { "||" and }
+//	and     = cmp { "&&" cmp }
+//	cmp     = add [ ("==" | "!=" | "<" | "<=" | ">" | ">=") add ]
+//	add     = mul { ("+" | "-") mul }
+//	mul     = unary { ("*" | "/" | "%") unary }
+//	unary   = ("!" | "-") unary | primary
+//	primary = nombre | "true" | "false" | variable | "(" ternary ")"
+
+type parser struct {
+	tokens []string
+	pos    int
+	depth  int
+}
+
+func parse(expr string) (node, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return root, nil
+}
+
+// lex découpe une expression en jetons
+func lex(expr string) ([]string, error) {
+	var tokens []string
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && unicode.IsDigit(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		case r == '_' || unicode.IsLetter(r):
+			j := i
+			for j < len(runes) && (runes[j] == '_' || unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		default:
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "&&", "||", "==", "!=", "<=", ">=":
+					tokens = append(tokens, two)
+					i += 2
+					continue
+				}
+			}
+			switch r {
+			case '(', ')', '!', '-', '+', '*', '/', '%', '<', '>', '?', ':':
+				tokens = append(tokens, string(r))
+				i++
+			default:
+				return nil, fmt.Errorf("unexpected character %q", r)
+			}
+		}
+	}
+	return tokens, nil
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > maxDepth {
+		return fmt.Errorf("expression nested too deeply")
+	}
+	return nil
+}
+
+func (p *parser) ternary() (node, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+
+	cond, err := p.or()
+	if err != nil || p.peek() != "?" {
+		return cond, err
+	}
+	p.next()
+	then, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if p.next() != ":" {
+		return nil, fmt.Errorf("expected \":\" in conditional")
+	}
+	otherwise, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	return newCond(cond, then, otherwise)
+}
+
+func (p *parser) or() (node, error) {
+	return p.binaryLeft(p.and, "||")
+}
+
+func (p *parser) and() (node, error) {
+	return p.binaryLeft(p.cmp, "&&")
+}
+
+func (p *parser) cmp() (node, error) {
+	left, err := p.add()
+	if err != nil {
+		return nil, err
+	}
+	switch op := p.peek(); op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.next()
+		right, err := p.add()
+		if err != nil {
+			return nil, err
+		}
+		return newBinary(op, left, right)
+	}
+	return left, nil
+}
+
+func (p *parser) add() (node, error) {
+	return p.binaryLeft(p.mul, "+", "-")
+}
+
+func (p *parser) mul() (node, error) {
+	return p.binaryLeft(p.unary, "*", "/", "%")
+}
+
+// binaryLeft analyse une suite d'opérateurs associatifs à gauche
+func (p *parser) binaryLeft(operand func() (node, error), ops ...string) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		matched := false
+		for _, candidate := range ops {
+			if op == candidate {
+				matched = true
+			}
+		}
+		if !matched {
+			return left, nil
+		}
+		p.next()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if left, err = newBinary(op, left, right); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	switch op := p.peek(); op {
+	case "!", "-":
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer func() { p.depth-- }()
+
+		p.next()
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return newUnary(op, operand)
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (node, error) {
+	tok := p.next()
+	switch {
+	case tok == "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case tok == "(":
+		inner, err := p.ternary()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing \")\"")
+		}
+		return inner, nil
+	case tok == "true" || tok == "false":
+		return literal{BoolValue(tok == "true")}, nil
+	case unicode.IsDigit(rune(tok[0])):
+		i, err := strconv.Atoi(tok)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", tok)
+		}
+		return literal{IntValue(i)}, nil
+	case tok[0] == '_' || unicode.IsLetter([]rune(tok)[0]):
+		t, ok := variables[tok]
+		if !ok {
+			return nil, fmt.Errorf("unknown variable %q", tok)
+		}
+		return variable{name: tok, t: t}, nil
+	}
+	return nil, fmt.Errorf("unexpected %q", tok)
+}
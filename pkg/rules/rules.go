@@ -0,0 +1,188 @@
+// pkg/rules/rules.go
+//
+// Petit langage de règles pour les variantes inventées par les joueurs.
+// Un script associe une expression à chaque point d'extension:
+//
+//	# Sortir de la base avec un 1 ou un 6
+//	can_move: standard || (from_base && dice == 1)
+//	# Rejouer après une capture
+//	extra_turn: standard || captures
+//	# 10 points par capture, 25 par pion arrivé
+//	score: (captures ? 10 : 0) + (reaches_home ? 25 : 0)
+//
+// Le langage ne connaît que les entiers et les booléens, sans boucle ni
+// appel: l'évaluation est bornée par la taille du script. Les types sont
+// vérifiés à la compilation.
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Points d'extension
+const (
+	RuleCanMove   = "can_move"   // bool: le pion peut-il jouer ce dé
+	RuleExtraTurn = "extra_turn" // bool: le coup donne-t-il un tour de plus
+	RuleScore     = "score"      // int: points gagnés par le coup
+)
+
+// Limites du bac à sable
+const (
+	MaxScriptLen = 4096
+	maxDepth     = 32
+)
+
+// Type est le type d'une expression
+type Type int
+
+const (
+	Int Type = iota
+	Bool
+)
+
+func (t Type) String() string {
+	if t == Bool {
+		return "bool"
+	}
+	return "int"
+}
+
+// ruleTypes donne le type attendu de chaque point d'extension
+var ruleTypes = map[string]Type{
+	RuleCanMove:   Bool,
+	RuleExtraTurn: Bool,
+	RuleScore:     Int,
+}
+
+// Variables disponibles dans les expressions
+var variables = map[string]Type{
+	"dice":            Int,  // Valeur du dé
+	"from":            Int,  // Position de départ (-1 = base, 52+ = couloir)
+	"to":              Int,  // Position d'arrivée selon le déplacement standard
+	"from_base":       Bool, // Le pion sort de la base
+	"to_home_stretch": Bool, // Le pion arrive dans le couloir final
+	"reaches_home":    Bool, // Le pion termine sa course
+	"captures":        Bool, // Le coup capture un pion adverse
+	"safe":            Bool, // La case d'arrivée est protégée
+	"consecutive_six": Int,  // Six consécutifs du joueur
+	"tokens_home":     Int,  // Pions arrivés du joueur
+	"tokens_in_base":  Int,  // Pions du joueur encore en base
+	"standard":        Bool, // Verdict des règles standard (can_move, extra_turn)
+}
+
+// Env est l'ensemble des valeurs des variables pour un coup
+type Env map[string]Value
+
+// Value est une valeur entière ou booléenne
+type Value struct {
+	typ Type
+	i   int
+	b   bool
+}
+
+// IntValue crée une valeur entière
+func IntValue(i int) Value { return Value{typ: Int, i: i} }
+
+// BoolValue crée une valeur booléenne
+func BoolValue(b bool) Value { return Value{typ: Bool, b: b} }
+
+// Script est un jeu de règles compilé
+type Script struct {
+	source string
+	rules  map[string]node
+}
+
+// Compile analyse et vérifie un script
+func Compile(source string) (*Script, error) {
+	if len(source) > MaxScriptLen {
+		return nil, fmt.Errorf("script is longer than %d bytes", MaxScriptLen)
+	}
+
+	script := &Script{source: source, rules: make(map[string]node)}
+	for n, line := range strings.Split(source, "\n") {
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, expr, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"rule: expression\"", n+1)
+		}
+		name = strings.TrimSpace(name)
+		want, known := ruleTypes[name]
+		if !known {
+			return nil, fmt.Errorf("line %d: unknown rule %q (want %s)", n+1, name, strings.Join(RuleNames(), ", "))
+		}
+		if _, dup := script.rules[name]; dup {
+			return nil, fmt.Errorf("line %d: rule %q defined twice", n+1, name)
+		}
+
+		root, err := parse(expr)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		if got := root.typ(); got != want {
+			return nil, fmt.Errorf("line %d: %s must be %s, got %s", n+1, name, want, got)
+		}
+		script.rules[name] = root
+	}
+
+	if len(script.rules) == 0 {
+		return nil, fmt.Errorf("script defines no rule")
+	}
+	return script, nil
+}
+
+// RuleNames liste les points d'extension
+func RuleNames() []string {
+	names := make([]string, 0, len(ruleTypes))
+	for name := range ruleTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Source retourne le texte du script
+func (s *Script) Source() string { return s.source }
+
+// Has indique si le script redéfinit un point d'extension
+func (s *Script) Has(rule string) bool {
+	if s == nil {
+		return false
+	}
+	_, ok := s.rules[rule]
+	return ok
+}
+
+// Bool évalue une règle booléenne. Sans script, sans cette règle ou en cas
+// d'erreur d'exécution (division par zéro), la valeur standard s'applique.
+func (s *Script) Bool(rule string, env Env, standard bool) bool {
+	if !s.Has(rule) {
+		return standard
+	}
+	env["standard"] = BoolValue(standard)
+	v, err := s.rules[rule].eval(env)
+	if err != nil {
+		return standard
+	}
+	return v.b
+}
+
+// Int évalue une règle entière, avec la même règle de repli que Bool
+func (s *Script) Int(rule string, env Env, standard int) int {
+	if !s.Has(rule) {
+		return standard
+	}
+	v, err := s.rules[rule].eval(env)
+	if err != nil {
+		return standard
+	}
+	return v.i
+}
@@ -0,0 +1,99 @@
+package rules
+
+import "testing"
+
+const variant = `
+# Sortir de la base avec un 1 ou un 6
+can_move: standard || (from_base && dice == 1)
+extra_turn: standard || captures   # rejouer après une capture
+score: (captures ? 10 : 0) + (reaches_home ? 25 : 0)
+`
+
+func TestVariantRules(t *testing.T) {
+	script, err := Compile(variant)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fromBase := Env{"dice": IntValue(1), "from_base": BoolValue(true)}
+	if !script.Bool(RuleCanMove, fromBase, false) {
+		t.Error("a 1 should release a token in this variant")
+	}
+	fromBase = Env{"dice": IntValue(3), "from_base": BoolValue(true)}
+	if script.Bool(RuleCanMove, fromBase, false) {
+		t.Error("a 3 should not release a token")
+	}
+
+	capture := Env{"captures": BoolValue(true), "reaches_home": BoolValue(false)}
+	if !script.Bool(RuleExtraTurn, capture, false) {
+		t.Error("a capture should give an extra turn")
+	}
+	if got := script.Int(RuleScore, capture, 0); got != 10 {
+		t.Errorf("score = %d, want 10", got)
+	}
+}
+
+func TestStandardWithoutRule(t *testing.T) {
+	script, err := Compile("score: 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if script.Bool(RuleCanMove, Env{}, true) != true {
+		t.Error("undefined rules must keep the standard verdict")
+	}
+
+	var none *Script
+	if none.Bool(RuleExtraTurn, Env{}, false) != false {
+		t.Error("a nil script must keep the standard verdict")
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	script, err := Compile("score: 2 + 3 * 4 - -1 + (dice > 3 ? 100 : 0)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := script.Int(RuleScore, Env{"dice": IntValue(2)}, 0); got != 15 {
+		t.Errorf("score = %d, want 15", got)
+	}
+}
+
+func TestDivisionByZeroFallsBack(t *testing.T) {
+	script, err := Compile("score: 10 / tokens_home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := script.Int(RuleScore, Env{}, 7); got != 7 {
+		t.Errorf("score = %d, want fallback 7", got)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	bad := []string{
+		"",
+		"can_move: dice",          // int au lieu de bool
+		"score: dice && captures", // && sur un entier
+		"teleport: true",          // point d'extension inconnu
+		"can_move: os_exit",       // variable inconnue
+		"can_move: true\ncan_move: false",
+		"score: (1 + 2",
+		"score: captures ? 1 : false",
+		"score: 1 $ 2",
+	}
+	for _, src := range bad {
+		if _, err := Compile(src); err == nil {
+			t.Errorf("Compile(%q) should fail", src)
+		}
+	}
+}
+
+func TestNestingLimit(t *testing.T) {
+	deep := "score: "
+	for i := 0; i < maxDepth+1; i++ {
+		deep += "-"
+	}
+	deep += "1"
+	if _, err := Compile(deep); err == nil {
+		t.Error("deeply nested expression should be rejected")
+	}
+}